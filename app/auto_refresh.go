@@ -0,0 +1,219 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/appui"
+	log "github.com/sirupsen/logrus"
+)
+
+//autoRefreshRate is how often the list on screen is checked to see if it
+//is due a refresh, the countdown on its header is updated as often
+const autoRefreshRate = time.Second
+
+//autoRefreshChoices are the intervals the user can choose from, in order
+var autoRefreshChoices = []struct {
+	label    string
+	interval time.Duration
+}{
+	{"Off", 0},
+	{"Every 5 seconds", 5 * time.Second},
+	{"Every 10 seconds", 10 * time.Second},
+	{"Every 30 seconds", 30 * time.Second},
+	{"Every minute", time.Minute},
+	{"Every 5 minutes", 5 * time.Minute},
+}
+
+//autoRefreshViews are the views whose list can refresh on its own, the
+//interval of each list is saved with the name of its list
+var autoRefreshViews = map[viewMode]string{
+	Main:     containersList,
+	Images:   imagesList,
+	Networks: networksList,
+}
+
+//autoRefreshWidget is a widget of a list that refreshes on its own
+type autoRefreshWidget interface {
+	SetAutoRefresh(*appui.AutoRefresh)
+	Refreshing() bool
+	Unmount() error
+}
+
+//autoRefreshWidgetOf returns the widget of the list shown on the given view
+func autoRefreshWidgetOf(view viewMode) autoRefreshWidget {
+	switch view {
+	case Main:
+		return widgets.ContainerList
+	case Images:
+		return widgets.ImageList
+	case Networks:
+		return widgets.Networks
+	}
+	return nil
+}
+
+//autoRefresher refreshes the list on screen periodically. A refresh starts
+//once the previous one has finished, so refreshes that take longer than
+//the interval do not pile up, and waits while a dialog is open.
+type autoRefresher struct {
+	sync.Mutex
+	view      viewMode
+	next      time.Time
+	last      time.Time
+	fetching  bool
+	reloading bool
+	suspended bool
+	//generation tells the refreshes of the list on screen from those of
+	//lists that are no longer shown
+	generation uint64
+	done       chan struct{}
+}
+
+func newAutoRefresher() *autoRefresher {
+	return &autoRefresher{done: make(chan struct{})}
+}
+
+//suspend stops or resumes refreshing, i.e. while a prompt is open
+func (r *autoRefresher) suspend(suspended bool) {
+	r.Lock()
+	defer r.Unlock()
+	r.suspended = suspended
+}
+
+//autoRefresh refreshes the list on screen periodically until dry is closed
+func (d *Dry) autoRefresh() {
+	ticker := time.NewTicker(autoRefreshRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			d.autoRefreshTick(now)
+		case <-d.autoRefresher.done:
+			return
+		}
+	}
+}
+
+//autoRefreshTick refreshes the list on screen if it is due, and shows how
+//long until it is refreshed again
+func (d *Dry) autoRefreshTick(now time.Time) {
+	r := d.autoRefresher
+	view := d.viewMode()
+	list, ok := autoRefreshViews[view]
+	interval := userPreferences.autoRefreshInterval(list)
+	w := autoRefreshWidgetOf(view)
+
+	r.Lock()
+	if view != r.view {
+		if previous := autoRefreshWidgetOf(r.view); previous != nil {
+			previous.SetAutoRefresh(nil)
+		}
+		r.view = view
+		r.next, r.last = time.Time{}, time.Time{}
+		r.fetching, r.reloading = false, false
+		r.generation++
+	}
+	if !ok || w == nil || interval == 0 {
+		r.Unlock()
+		if w != nil {
+			w.SetAutoRefresh(nil)
+		}
+		return
+	}
+	start := false
+	switch {
+	case r.fetching:
+	case r.reloading:
+		if !w.Refreshing() {
+			r.reloading = false
+			r.last = now
+			r.next = now.Add(interval)
+		}
+	case r.next.IsZero():
+		r.next = now.Add(interval)
+	case r.suspended || appui.PagerActive():
+	case !now.Before(r.next):
+		r.fetching = true
+		r.next = time.Time{}
+		start = true
+	}
+	generation := r.generation
+	state := &appui.AutoRefresh{Next: r.next, Last: r.last, Suspended: r.suspended}
+	r.Unlock()
+
+	w.SetAutoRefresh(state)
+	if start {
+		d.refreshList(view, w, func() {
+			r.Lock()
+			defer r.Unlock()
+			if generation == r.generation {
+				r.fetching = false
+				r.reloading = true
+			}
+		})
+	}
+	d.refreshIfVisible()
+}
+
+//refreshList fetches again the data of the list shown on the given view,
+//done is called once the widget of the list has been told to load it
+func (d *Dry) refreshList(view viewMode, w autoRefreshWidget, done func()) {
+	if view != Main {
+		w.Unmount()
+		done()
+		return
+	}
+	//containers are kept by the daemon, they are fetched before the list reads them
+	d.dockerDaemon.Refresh(func(err error) {
+		if err != nil {
+			log.Debugf("Error refreshing the container list: %s", err.Error())
+		}
+		w.Unmount()
+		done()
+	})
+}
+
+//resetAutoRefresh restarts the countdown of the list on screen, i.e. after
+//the user refreshed it
+func (d *Dry) resetAutoRefresh() {
+	r := d.autoRefresher
+	interval := userPreferences.autoRefreshInterval(autoRefreshViews[d.viewMode()])
+	r.Lock()
+	defer r.Unlock()
+	if interval > 0 && !r.fetching && !r.reloading {
+		r.next = time.Now().Add(interval)
+	}
+}
+
+//askAutoRefresh asks how often the list on screen refreshes on its own
+func (d *Dry) askAutoRefresh(h eventHandler, f func(eventHandler)) {
+	list, ok := autoRefreshViews[d.viewMode()]
+	if !ok {
+		d.appmessage("This view does not refresh on its own")
+		return
+	}
+	choices := make([]string, len(autoRefreshChoices))
+	for i, c := range autoRefreshChoices {
+		choices[i] = c.label
+	}
+	askChoice(h, fmt.Sprintf("Refresh the %s list", list), choices, f, func(choice string) {
+		for _, c := range autoRefreshChoices {
+			if c.label != choice {
+				continue
+			}
+			if err := userPreferences.setAutoRefreshInterval(list, c.interval); err != nil {
+				d.apperror("The auto refresh interval could not be saved: " + err.Error())
+			}
+			d.resetAutoRefresh()
+			if c.interval == 0 {
+				d.appmessage(fmt.Sprintf("The %s list no longer refreshes on its own", list))
+			} else {
+				d.appmessage(fmt.Sprintf("The %s list refreshes %s", list, strings.ToLower(c.label)))
+			}
+			return
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+)
+
+//browserCommands are the commands that open a URL with the default browser, per OS,
+//in order of preference
+var browserCommands = map[string][][]string{
+	"darwin":  {{"open"}},
+	"windows": {{"rundll32", "url.dll,FileProtocolHandler"}},
+	"linux": {
+		{"xdg-open"},
+		{"sensible-browser"},
+	},
+}
+
+var errNoBrowser = errors.New("no command to open a browser found (open, xdg-open or sensible-browser)")
+
+//openURL opens the given URL with the default browser of the host dry runs on
+func openURL(url string) error {
+	command, err := browserCommand(runtime.GOOS, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	args := append(append([]string(nil), command[1:]...), url)
+	return exec.Command(command[0], args...).Run()
+}
+
+//browserCommand returns the first command for the given OS to open a browser that is installed
+func browserCommand(goos string, lookPath func(string) (string, error)) ([]string, error) {
+	if command := firstInstalledCommand(browserCommands, goos, lookPath); command != nil {
+		return command, nil
+	}
+	return nil, errNoBrowser
+}
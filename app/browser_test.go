@@ -0,0 +1,44 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	installed := func(commands ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, c := range commands {
+				if c == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	tests := []struct {
+		name      string
+		goos      string
+		installed []string
+		want      []string
+		wantErr   bool
+	}{
+		{"mac", "darwin", []string{"open"}, []string{"open"}, false},
+		{"windows", "windows", []string{"rundll32"}, []string{"rundll32", "url.dll,FileProtocolHandler"}, false},
+		{"linux with xdg-open", "linux", []string{"sensible-browser", "xdg-open"}, []string{"xdg-open"}, false},
+		{"freebsd", "freebsd", []string{"sensible-browser"}, []string{"sensible-browser"}, false},
+		{"no browser", "linux", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := browserCommand(tt.goos, installed(tt.installed...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("browserCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("browserCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
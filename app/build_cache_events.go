@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+const forceBuildCachePrune = "force prune, internal records too"
+
+type buildCacheScreenEventHandler struct {
+	baseEventHandler
+	widget *appui.BuildCacheWidget
+}
+
+func (h *buildCacheScreenEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		if du, err := h.dry.dockerDaemon.DiskUsage(); err == nil {
+			widgets.DiskUsage.PrepareToRender(&du, nil)
+		}
+		f(viewsToHandlers[DiskUsage])
+		h.dry.ViewMode(DiskUsage)
+	case termbox.KeyF1: //sort
+		h.widget.Sort()
+	case termbox.KeyF5: // refresh
+		h.dry.appmessage("Refreshing the build cache")
+		h.widget.Unmount()
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case 'p', 'P':
+			handled = true
+			h.prune(f)
+		case '%':
+			handled = true
+			forwarder := newEventForwarder()
+			f(forwarder)
+			refreshScreen()
+			applyFilter := func(filter string, canceled bool) {
+				if !canceled {
+					h.widget.Filter(filter)
+				}
+				f(h)
+			}
+			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		}
+	}
+	if handled {
+		refreshScreen()
+	} else {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//prune asks how much cache to keep and how old the records to prune must be,
+//then prunes the build cache in the background
+func (h *buildCacheScreenEventHandler) prune(f func(eventHandler)) {
+	askText(h, "Storage to keep (e.g. 5GB, leave empty to keep none)", f, func(keep string) {
+		var opts docker.BuildCachePruneOptions
+		if keep = strings.TrimSpace(keep); keep != "" {
+			size, err := units.RAMInBytes(keep)
+			if err != nil {
+				h.dry.apperror(fmt.Sprintf("Invalid storage to keep %q: %s", keep, err.Error()))
+				return
+			}
+			opts.KeepStorage = size
+		}
+		askText(h, "Prune records unused for (e.g. 24h, leave empty for any age)", f, func(age string) {
+			if age = strings.TrimSpace(age); age != "" {
+				unusedFor, err := time.ParseDuration(age)
+				if err != nil {
+					h.dry.apperror(fmt.Sprintf("Invalid age %q: %s", age, err.Error()))
+					return
+				}
+				opts.UnusedFor = unusedFor
+			}
+			askChoice(h, " Prune the build cache ", []string{"prune", forceBuildCachePrune}, f, func(choice string) {
+				opts.All = choice == forceBuildCachePrune
+				h.dry.confirm(confirmBuildCachePrune, "Do you want to prune the build cache?",
+					h.pruneTargets(opts), h, f, func() {
+						h.dry.runJob("Build cache prune", false,
+							func(ctx context.Context, progress func(float64)) (string, error) {
+								reclaimed, err := h.dry.dockerDaemon.BuildCachePrune(opts)
+								if err != nil {
+									return "", err
+								}
+								h.widget.Unmount()
+								return fmt.Sprintf("Build cache pruned, %s reclaimed", units.HumanSize(float64(reclaimed))), nil
+							})
+					})
+			})
+		})
+	})
+}
+
+//pruneTargets describes what a prune with the given options removes
+func (h *buildCacheScreenEventHandler) pruneTargets(opts docker.BuildCachePruneOptions) []string {
+	target := "dangling build cache records"
+	if opts.All {
+		target = "all build cache records, internal and frontend ones too"
+	}
+	if opts.UnusedFor > 0 {
+		target += fmt.Sprintf(" unused for %s", units.HumanDuration(opts.UnusedFor))
+	}
+	targets := []string{target}
+	if opts.KeepStorage > 0 {
+		targets = append(targets, fmt.Sprintf("keeping %s of cache", units.HumanSize(float64(opts.KeepStorage))))
+	}
+	if count, size := h.widget.InUse(); count > 0 {
+		targets = append(targets, fmt.Sprintf("%d records in use (%s) are kept", count, units.HumanSize(float64(size))))
+	}
+	return targets
+}
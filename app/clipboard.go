@@ -0,0 +1,57 @@
+package app
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+//clipboardCommands are the commands that copy their input to the clipboard, per OS,
+//in order of preference
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	},
+}
+
+var errNoClipboard = errors.New("no clipboard command found (pbcopy, wl-copy, xclip or xsel)")
+
+//copyToClipboard copies the given text to the clipboard of the host dry runs on
+func copyToClipboard(text string) error {
+	command, err := clipboardCommand(runtime.GOOS, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+//clipboardCommand returns the first clipboard command for the given OS that is installed
+func clipboardCommand(goos string, lookPath func(string) (string, error)) ([]string, error) {
+	if command := firstInstalledCommand(clipboardCommands, goos, lookPath); command != nil {
+		return command, nil
+	}
+	return nil, errNoClipboard
+}
+
+//firstInstalledCommand returns the first of the given commands for the given OS
+//that is installed, nil if none is
+func firstInstalledCommand(commands map[string][][]string, goos string, lookPath func(string) (string, error)) []string {
+	candidates, ok := commands[goos]
+	if !ok {
+		//BSDs use the same tools as Linux
+		candidates = commands["linux"]
+	}
+	for _, command := range candidates {
+		if _, err := lookPath(command[0]); err == nil {
+			return command
+		}
+	}
+	return nil
+}
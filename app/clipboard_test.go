@@ -0,0 +1,44 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestClipboardCommand(t *testing.T) {
+	installed := func(commands ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, c := range commands {
+				if c == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+	tests := []struct {
+		name      string
+		goos      string
+		installed []string
+		want      []string
+		wantErr   bool
+	}{
+		{"mac", "darwin", []string{"pbcopy"}, []string{"pbcopy"}, false},
+		{"linux with xclip", "linux", []string{"xsel", "xclip"}, []string{"xclip", "-selection", "clipboard"}, false},
+		{"linux on wayland", "linux", []string{"xclip", "wl-copy"}, []string{"wl-copy"}, false},
+		{"freebsd with xsel", "freebsd", []string{"xsel"}, []string{"xsel", "--clipboard", "--input"}, false},
+		{"no clipboard", "linux", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clipboardCommand(tt.goos, installed(tt.installed...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("clipboardCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clipboardCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
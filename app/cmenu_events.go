@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -41,7 +42,7 @@ func (h *cMenuEventHandler) handle(event termbox.Event, f func(eventHandler)) {
 			return nil
 		})
 		if err != nil {
-			h.dry.appmessage(fmt.Sprintf("Could not run command: %s", err.Error()))
+			h.dry.apperror(fmt.Sprintf("Could not run command: %s", err.Error()))
 		}
 	default:
 		handled = false
@@ -60,37 +61,9 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 	container := dry.dockerDaemon.ContainerByID(id)
 	switch command {
 	case docker.KILL:
-		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to kill container %s? (y/N)", id))
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-
-			dry.actionMessage(id, "Killing")
-			err := dry.dockerDaemon.Kill(id)
-			if err == nil {
-				widgets.ContainerMenu.ForContainer(id)
-			} else {
-				dry.errorMessage(id, "killing", err)
-			}
-			refreshScreen()
-		}()
+		dry.askKill(container, h, f, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		})
 	case docker.RESTART:
 
 		prompt := appui.NewPrompt(
@@ -116,13 +89,14 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 				return
 			}
 
-			if err := dry.dockerDaemon.RestartContainer(id); err == nil {
-				widgets.ContainerMenu.ForContainer(id)
-			} else {
-				dry.appmessage(
-					fmt.Sprintf("Error restarting container %s, err: %s", id, err.Error()))
-			}
-			refreshScreen()
+			dry.runJob(fmt.Sprintf("Restart container %s", id), false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					if err := dry.dockerDaemon.RestartContainer(id); err != nil {
+						return "", err
+					}
+					widgets.ContainerMenu.ForContainer(id)
+					return "", nil
+				})
 		}()
 
 	case docker.STOP:
@@ -150,84 +124,77 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 				return
 			}
 
-			dry.actionMessage(id, "Stopping")
-			err := dry.dockerDaemon.StopContainer(id)
-			if err == nil {
+			dry.stopContainer(id, func() {
 				widgets.ContainerMenu.ForContainer(id)
-			} else {
-				dry.errorMessage(id, "stopping", err)
-			}
-			refreshScreen()
+			})
 		}()
+	case docker.RENAME:
+		renameContainer(dry, h, f, container, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		})
+	case docker.UPDATE:
+		updateContainerLimits(dry, h, f, container, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		})
+	case docker.RESTARTPOLICY:
+		editRestartPolicy(dry, h, f, container, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		})
+	case docker.COMMIT:
+		commitContainer(dry, h, f, container)
+	case docker.COPY:
+		copyFiles(dry, h, f, container)
+	case docker.EXEC:
+		execCommand(dry, h, f, container)
+	case docker.ATTACH:
+		attachToContainer(dry, h, f, container)
+	case docker.BROWSE:
+		openPublishedPort(dry, h, f, container)
+	case docker.PAUSE, docker.UNPAUSE:
+		dry.pauseContainer(id, command == docker.PAUSE, func() {
+			widgets.ContainerMenu.ForContainer(id)
+		})
 	case docker.LOGS:
-
-		prompt := logsPrompt()
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			widgets.remove(prompt)
-			since, canceled := prompt.Text()
-
-			if canceled {
-				f(h)
-				return
-			}
-
-			logs, err := h.dry.dockerDaemon.Logs(id, since, false)
-			if err == nil {
-				appui.Stream(logs, forwarder.events(),
-					func() {
-						h.dry.ViewMode(ContainerMenu)
-						f(h)
-						refreshScreen()
-					})
-			} else {
-				h.dry.appmessage("Error showing container logs: " + err.Error())
-			}
-		}()
-	case docker.RM:
-		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to remove container %s? (y/N)", id))
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-
-				return
-			}
-
-			dry.actionMessage(id, "Removing")
-			err := dry.dockerDaemon.Rm(id)
-			if err == nil {
-				dry.actionMessage(id, "removed")
-				widgets.ContainerMenu.Unmount()
-			} else {
-				dry.errorMessage(id, "removing", err)
-			}
+		chooseLogsSource(dry, h, container, f, func(source logsSource) {
+			prompt := logsPrompt()
+			widgets.add(prompt)
+			forwarder := newEventForwarder()
+			f(forwarder)
 			refreshScreen()
-		}()
+			go func() {
+				events := ui.EventSource{
+					Events: forwarder.events(),
+					EventHandledCallback: func(e termbox.Event) error {
+						return refreshScreen()
+					},
+				}
+				prompt.OnFocus(events)
+				widgets.remove(prompt)
+				since, canceled := prompt.Text()
+
+				if canceled {
+					f(h)
+					return
+				}
+
+				logs, err := openLogs(h.dry, source, id, since, false)
+				if err == nil {
+					streamLogs(source, logs, forwarder.events(),
+						func() {
+							h.dry.ViewMode(ContainerMenu)
+							f(h)
+							refreshScreen()
+						})
+				} else {
+					f(h)
+					h.dry.apperror("Error showing container logs: " + err.Error())
+				}
+			}()
+		})
+	case docker.RM:
+		removeContainer(dry, h, f, container, func() {
+			widgets.ContainerMenu.Unmount()
+		})
 
 	case docker.STATS:
 		forwarder := newEventForwarder()
@@ -247,7 +214,6 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 		refreshScreen()
 
 		err := inspect(
-			h.screen,
 			forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.Inspect(id)
@@ -259,10 +225,12 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 			})(id)
 
 		if err != nil {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Error inspecting container: %s", err.Error()))
 			return
 		}
+	case docker.CHECKPOINTS:
+		h.checkpoints(container, f)
 	case docker.HISTORY:
 		history, err := dry.dockerDaemon.History(container.ImageID)
 
@@ -277,8 +245,25 @@ func (h *cMenuEventHandler) handleCommand(id string, command docker.Command, f f
 				refreshScreen()
 			})
 		} else {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Error showing image history: %s", err.Error()))
 		}
+	case docker.DIFF:
+		changes, err := dry.dockerDaemon.ContainerDiff(id)
+
+		if err == nil {
+			renderer := appui.NewContainerDiffRenderer(dry.containerName(id), changes)
+			forwarder := newEventForwarder()
+			f(forwarder)
+			refreshScreen()
+			go appui.Less(renderer, screen, forwarder.events(), func() {
+				h.dry.ViewMode(ContainerMenu)
+				f(h)
+				refreshScreen()
+			})
+		} else {
+			dry.apperror(
+				fmt.Sprintf("Error showing filesystem changes: %s", err.Error()))
+		}
 	}
 }
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+//columnsWidget is a list whose columns can be hidden
+type columnsWidget interface {
+	Columns() []string
+	HiddenColumns() []string
+	SetHiddenColumns([]string)
+}
+
+//chooseColumn lets the user pick a column of the given list to hide, or to
+//show if it is hidden. The columns hidden are saved as user preferences
+//under the given list name.
+func chooseColumn(dry *Dry, h eventHandler, f func(eventHandler), list string, w columnsWidget) {
+	columns := w.Columns()
+	hidden := make(map[string]bool)
+	for _, title := range w.HiddenColumns() {
+		hidden[title] = true
+	}
+	choices := make([]string, len(columns))
+	for i, title := range columns {
+		state := "shown"
+		if hidden[title] {
+			state = "hidden"
+		}
+		choices[i] = fmt.Sprintf("%s (%s)", title, state)
+	}
+	askChoice(h, " Show or hide a column ", choices, f, func(choice string) {
+		for i, c := range choices {
+			if c != choice {
+				continue
+			}
+			title := columns[i]
+			hidden[title] = !hidden[title]
+			if len(hiddenTitles(columns, hidden)) == len(columns) {
+				dry.apperror("At least one column must be shown")
+				return
+			}
+			titles := hiddenTitles(columns, hidden)
+			w.SetHiddenColumns(titles)
+			if err := userPreferences.setHiddenColumns(list, titles); err != nil {
+				dry.apperror(fmt.Sprintf("Hidden columns could not be saved: %s", err.Error()))
+				return
+			}
+			if len(titles) == 0 {
+				dry.appmessage("Every column is shown")
+				return
+			}
+			dry.appmessage(fmt.Sprintf("Hidden columns: %s", strings.Join(titles, ", ")))
+			return
+		}
+	})
+}
+
+//hiddenTitles returns the given columns that are hidden, in order
+func hiddenTitles(columns []string, hidden map[string]bool) []string {
+	var titles []string
+	for _, title := range columns {
+		if hidden[title] {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+//columnResizer is a list whose columns can be resized
+type columnResizer interface {
+	ToggleColumnResize() bool
+	FocusColumn(delta int)
+	ResizeColumn(delta int) (string, int)
+	ResizingColumn() string
+}
+
+//resizeColumns starts resizing the columns of the given list, the given
+//handler gets the events back once resizing ends
+func resizeColumns(dry *Dry, h eventHandler, f func(eventHandler), w columnResizer) {
+	if !w.ToggleColumnResize() {
+		return
+	}
+	f(&columnResizeEventHandler{dry: dry, list: h, widget: w})
+	dry.appmessage(fmt.Sprintf(
+		"Resizing %s: <b>Left/Right</> choose the column, <b>+/-</> resize it, <b>Esc</> to finish", w.ResizingColumn()))
+	refreshScreen()
+}
+
+//columnResizeEventHandler handles the events of a list while its columns
+//are being resized
+type columnResizeEventHandler struct {
+	dry    *Dry
+	list   eventHandler
+	widget columnResizer
+}
+
+func (h *columnResizeEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	switch {
+	case event.Key == termbox.KeyArrowLeft:
+		h.widget.FocusColumn(-1)
+	case event.Key == termbox.KeyArrowRight:
+		h.widget.FocusColumn(1)
+	case event.Ch == '+' || event.Ch == '>':
+		h.resize(1)
+	case event.Ch == '-' || event.Ch == '<':
+		h.resize(-1)
+	case event.Key == termbox.KeyEsc || event.Key == termbox.KeyEnter:
+		h.widget.ToggleColumnResize()
+		f(h.list)
+		h.dry.appmessage("Column widths are kept until dry exits")
+	default:
+		return
+	}
+	refreshScreen()
+}
+
+//resize resizes the column being resized by the given delta
+func (h *columnResizeEventHandler) resize(delta int) {
+	if title, width := h.widget.ResizeColumn(delta); title != "" {
+		h.dry.appmessage(fmt.Sprintf("%s: %d columns wide", title, width))
+	}
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//actionConditions tell, for the actions that need it, if the action can be
+//run on the current state. Actions not found here can always be run.
+var actionConditions = map[string]func(d *Dry) bool{
+	"dismissNotification": func(d *Dry) bool { return d.notifications.Pending() > 0 },
+
+	"recreateContainer":               containerSelected,
+	"removeContainer":                 orProjectSelected(containerSelected),
+	"inspectContainer":                containerSelected,
+	"showContainerLogs":               containerSelected,
+	"showContainerLogsWithTimestamps": containerSelected,
+	"restartContainer":                orProjectSelected(containerSelected),
+	"renameContainer":                 containerSelected,
+	"updateContainer":                 containerSelected,
+	"commitContainer":                 containerSelected,
+	"copyFiles":                       containerSelected,
+	"execCommand":                     selectedContainerRunning,
+	"showContainerDiff":               containerSelected,
+	"attachToContainer":               selectedContainerRunning,
+	"openPublishedPort":               containerSelected,
+	"copyContainerID":                 containerSelected,
+	"copyContainerName":               containerSelected,
+	"editRestartPolicy":               containerSelected,
+	"showContainerEnv":                containerSelected,
+	"showContainerMounts":             containerSelected,
+	"showContainerProcesses":          containerSelected,
+	"showContainerHealth":             containerSelected,
+	"showContainerLinks":              containerSelected,
+	"expandContainer":                 orProjectSelected(containerSelected),
+	"showContainerMenu":               containerSelected,
+	"runContainerLike":                containerSelected,
+	"checkImageUpdate":                containerSelected,
+	"checkImageUpdates":               containerSelected,
+	"killContainer":                   selectedContainerRunning,
+	"stopContainer":                   orProjectSelected(selectedContainerRunning),
+	"pauseContainer":                  selectedContainerRunning,
+	"showContainerStats":              selectedContainerRunning,
+	"showContainerStatsHistory":       selectedContainerRunning,
+}
+
+//paletteCommands returns the commands of the command palette for the given
+//view: the actions of its keymap scope followed by the global ones. The
+//actions that cannot be run on the current state are disabled.
+func paletteCommands(d *Dry, view viewMode) []appui.PaletteCommand {
+	var commands []appui.PaletteCommand
+	scopes := []string{keymapScope(view)}
+	if scopes[0] != globalScope {
+		scopes = append(scopes, globalScope)
+	}
+	for _, s := range scopes {
+		for _, action := range keyActions {
+			if action.scope != s || action.name == "showCommandPalette" {
+				continue
+			}
+			command := appui.PaletteCommand{
+				Name:        action.name,
+				Keys:        activeKeymap.keys(action.name),
+				Description: action.description,
+			}
+			if applicable, ok := actionConditions[action.name]; ok {
+				command.Disabled = !applicable(d)
+			}
+			if feature, ok := actionFeatures[action.name]; ok && !d.dockerDaemon.Supports(feature) {
+				command.Disabled = true
+				command.Description += " (requires API >= " + docker.MinAPIVersion(feature) + ")"
+			}
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+//showCommandPalette shows the command palette of the current view, the
+//command picked is run by h, the handler of the view, as if its default
+//key was pressed
+func showCommandPalette(d *Dry, h eventHandler, f func(eventHandler)) {
+	palette := appui.NewCommandPalette(paletteCommands(d, d.viewMode()))
+	widgets.add(palette)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		palette.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(palette)
+		command, canceled := palette.Command()
+		f(h)
+		if !canceled {
+			for _, action := range keyActions {
+				if action.name == command.Name {
+					h.handle(mustParseKeyChords(action.keys)[0].event(), f)
+					break
+				}
+			}
+		}
+		refreshScreen()
+	}()
+}
+
+//containerSelected returns true if there is a container on the cursor of the container list
+func containerSelected(d *Dry) bool {
+	return selectedContainer(d) != nil
+}
+
+//selectedContainerRunning returns true if the container on the cursor of the container list is running
+func selectedContainerRunning(d *Dry) bool {
+	c := selectedContainer(d)
+	return c != nil && docker.IsContainerRunning(c)
+}
+
+//orProjectSelected returns the given condition, also met if the header of a
+//compose project is on the cursor of the container list
+func orProjectSelected(condition func(d *Dry) bool) func(d *Dry) bool {
+	return func(d *Dry) bool {
+		if _, ok := widgets.ContainerList.SelectedProject(); ok {
+			return true
+		}
+		return condition(d)
+	}
+}
+
+//selectedContainer returns the container on the cursor of the container list, nil if there is none
+func selectedContainer(d *Dry) *docker.Container {
+	var selected *docker.Container
+	widgets.ContainerList.OnEvent(func(id string) error {
+		selected = d.dockerDaemon.ContainerByID(id)
+		return nil
+	})
+	return selected
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//Destructive actions that require confirmation, used to remember the
+//actions confirmed with "don't ask again"
+const (
+	confirmBuildCachePrune    = "build cache prune"
+	confirmCheckpointRm       = "checkpoint rm"
+	confirmContainerKill      = "container kill"
+	confirmContainerPrune     = "container prune"
+	confirmContainerRm        = "container rm"
+	confirmContainerRecreate  = "container recreate"
+	confirmContainerRmStopped = "container rm stopped"
+	confirmContainerRmVolumes = "container rm with anonymous volumes"
+	confirmContainerRestart   = "container restart marked"
+	confirmContainerStop      = "container stop marked"
+	confirmImageRm            = "image rm"
+	confirmImageRmDangling    = "image rm dangling"
+	confirmImageRmParent      = "image rm with children"
+	confirmImagePrune         = "image prune"
+	confirmNetworkRm          = "network rm"
+	confirmPluginRm           = "plugin rm"
+	confirmProjectRestart     = "compose project restart"
+	confirmProjectRm          = "compose project rm"
+	confirmProjectStop        = "compose project stop"
+	confirmPrune              = "prune"
+	confirmServiceRm          = "service rm"
+	confirmStackRm            = "stack rm"
+)
+
+//skippedConfirmations are the actions that are not confirmed for the rest of the session
+type skippedConfirmations struct {
+	actions map[string]bool
+	sync.Mutex
+}
+
+func (s *skippedConfirmations) add(action string) {
+	s.Lock()
+	defer s.Unlock()
+	if s.actions == nil {
+		s.actions = make(map[string]bool)
+	}
+	s.actions[action] = true
+}
+
+func (s *skippedConfirmations) skipped(action string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.actions[action]
+}
+
+//confirm asks the user to confirm the given action, listing the given targets,
+//onConfirm is run if the user confirms. No confirmation is asked if confirmations
+//are disabled or the user asked not to confirm the action again.
+//The handler of the next events is set to the given handler once the user answers.
+func (d *Dry) confirm(action, question string, targets []string, h eventHandler, f func(eventHandler), onConfirm func()) {
+	if !userPreferences.confirmationsEnabled() {
+		onConfirm()
+		return
+	}
+	d.askConfirmation(action, question, targets, h, f, onConfirm)
+}
+
+//askConfirmation is confirm for warnings the user must not miss, it asks
+//even if confirmations are disabled unless the user asked not to confirm the
+//action again
+func (d *Dry) askConfirmation(action, question string, targets []string, h eventHandler, f func(eventHandler), onConfirm func()) {
+	d.askWith(action, appui.NewConfirmation(question, targets), h, f, onConfirm)
+}
+
+//askWith is askConfirmation with the given confirmation, for confirmations
+//with options
+func (d *Dry) askWith(action string, confirmation *appui.Confirmation, h eventHandler, f func(eventHandler), onConfirm func()) {
+	if d.skippedConfirmations.skipped(action) {
+		onConfirm()
+		return
+	}
+	widgets.add(confirmation)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		events := ui.EventSource{
+			Events: forwarder.events(),
+			EventHandledCallback: func(e termbox.Event) error {
+				return refreshScreen()
+			},
+		}
+		confirmation.OnFocus(events)
+		widgets.remove(confirmation)
+		f(h)
+		switch confirmation.Answer() {
+		case appui.ConfirmedDontAskAgain:
+			d.skippedConfirmations.add(action)
+			onConfirm()
+		case appui.Confirmed:
+			onConfirm()
+		}
+		refreshScreen()
+	}()
+}
+
+//containerTarget describes the given container on a confirmation
+func containerTarget(c *docker.Container) string {
+	if c == nil {
+		return "unknown container"
+	}
+	f := formatter.NewContainerFormatter(c, true)
+	return fmt.Sprintf("%s (%s, image %s)", f.Names(), f.ID(), f.Image())
+}
+
+//imageTarget describes the given image on a confirmation
+func imageTarget(image types.ImageSummary) string {
+	name := "untagged"
+	if len(image.RepoTags) > 0 && !docker.IsDangling(image) {
+		name = image.RepoTags[0]
+	}
+	return fmt.Sprintf("%s (%s, %s)", name, docker.ShortImageID(image.ID), units.HumanSize(float64(image.Size)))
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/docker/pkg/term"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//terminalDevice is the terminal attached containers read from and write to
+const terminalDevice = "/dev/tty"
+
+//attachToContainer attaches the terminal to the main process of the given
+//container, as docker attach does. dry gives the terminal to the container
+//until the detach keys, ctrl-p ctrl-q, are pressed or the container stops,
+//then it is shown again as it was.
+func attachToContainer(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	name := dry.containerName(c.ID)
+	if !docker.IsContainerRunning(c) {
+		dry.apperror(fmt.Sprintf("Container %s is not running", name))
+		return
+	}
+	go func() {
+		attachment, err := dry.dockerDaemon.Attach(c.ID)
+		if err != nil {
+			dry.apperror(fmt.Sprintf("Could not attach to %s: %s", name, err))
+			return
+		}
+		if attachment == nil {
+			return
+		}
+		defer attachment.Close()
+		//the terminal is opened again, instead of using stdin, so the input
+		//can be closed to stop reading from it when the container is detached
+		tty, err := os.OpenFile(terminalDevice, os.O_RDWR, 0)
+		if err != nil {
+			dry.apperror(fmt.Sprintf("Could not attach to %s: %s", name, err))
+			return
+		}
+
+		screen := ui.ActiveScreen
+		screen.Suspend()
+		err = attach(attachment, tty, name)
+		tty.Close()
+		if rerr := screen.Resume(); rerr != nil {
+			dry.apperror("There was an error showing dry again: " + rerr.Error())
+			return
+		}
+		//the terminal may have been resized while attached
+		width, height := screen.Dimensions.Width, screen.Dimensions.Height
+		ui.Resize()
+		if screen.Dimensions.Width != width || screen.Dimensions.Height != height {
+			dry.initWidgets()
+		}
+		if err != nil {
+			dry.apperror(fmt.Sprintf("Detached from %s: %s", name, err))
+		} else {
+			dry.appmessage(fmt.Sprintf("Detached from %s", name))
+		}
+		refreshScreen()
+	}()
+}
+
+//attach streams the given terminal to and from the given attachment until
+//the container is detached
+func attach(attachment *docker.ContainerAttachment, tty *os.File, name string) error {
+	//the raw mode is set on stdin, getting the descriptor of the terminal
+	//opened to read from it would make reads on it not stop on close
+	fd, isTerminal := term.GetFdInfo(os.Stdin)
+	if isTerminal {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.RestoreTerminal(fd, state)
+		if size, err := term.GetWinsize(fd); err == nil {
+			attachment.Resize(uint(size.Width), uint(size.Height))
+		}
+	}
+	var out io.Writer = tty
+	if !attachment.TTY {
+		//without a TTY the container sends bare line feeds and the raw
+		//terminal does not return the carriage on them
+		out = &crlfWriter{w: tty}
+	}
+	fmt.Fprintf(tty, "Attached to %s, press ctrl-p ctrl-q to detach\r\n", name)
+	return attachment.Stream(tty, out, out)
+}
+
+//crlfWriter writes to the underlying writer with line feeds turned into
+//carriage return and line feed pairs
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	if _, err := c.w.Write(bytes.Replace(p, []byte("\n"), []byte("\r\n"), -1)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCRLFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &crlfWriter{w: &buf}
+	n, err := w.Write([]byte("one\ntwo\n"))
+	if err != nil || n != 8 {
+		t.Errorf("Write() = %d, %v, want 8, nil", n, err)
+	}
+	if buf.String() != "one\r\ntwo\r\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
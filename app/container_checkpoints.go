@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//What can be done with the checkpoints of a container, checkpoint names
+//have no spaces so they are never taken for one of these
+const (
+	createCheckpoint        = "Create checkpoint"
+	startFromCheckpoint     = "Start from this checkpoint"
+	deleteCheckpoint        = "Delete"
+	checkpointLeaveRunning  = "Leave it running"
+	checkpointStopContainer = "Stop it"
+)
+
+//checkpoints shows the checkpoints of the given container, to create new
+//ones if it is running or to start it from one if it is not
+func (h *cMenuEventHandler) checkpoints(container *docker.Container, f func(eventHandler)) {
+	dry := h.dry
+	if container == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	if !dry.dockerDaemon.Experimental() {
+		dry.appmessage("Checkpoints need a daemon with experimental features enabled")
+		return
+	}
+	if dry.unsupported(docker.CheckpointsFeature) {
+		return
+	}
+	id := container.ID
+	name := dry.containerName(id)
+	checkpoints, err := dry.dockerDaemon.Checkpoints(id)
+	if err != nil {
+		dry.apperror(dry.pageError(h.screen, fmt.Sprintf("The checkpoints of %s could not be listed", name), err).Error())
+		return
+	}
+	running := docker.IsContainerRunning(container)
+	var choices []string
+	if running {
+		choices = append(choices, createCheckpoint)
+	}
+	for _, checkpoint := range checkpoints {
+		choices = append(choices, checkpoint.Name)
+	}
+	if len(choices) == 0 {
+		dry.appmessage(fmt.Sprintf("%s has no checkpoints", name))
+		return
+	}
+	askChoice(h, fmt.Sprintf("Checkpoints of %s", name), choices, f, func(choice string) {
+		if choice == createCheckpoint {
+			h.createCheckpoint(id, name, f)
+			return
+		}
+		h.checkpoint(id, name, choice, running, f)
+	})
+}
+
+//createCheckpoint asks for the name of a new checkpoint of the running
+//container with the given id, and whether to leave it running, and creates it
+func (h *cMenuEventHandler) createCheckpoint(id, name string, f func(eventHandler)) {
+	dry := h.dry
+	askText(h, "Checkpoint name", f, func(checkpoint string) {
+		checkpoint = strings.TrimSpace(checkpoint)
+		if checkpoint == "" {
+			return
+		}
+		choices := []string{checkpointLeaveRunning, checkpointStopContainer}
+		askChoice(h, "Once checkpointed, the container", choices, f, func(choice string) {
+			leaveRunning := choice == checkpointLeaveRunning
+			dry.runJob(fmt.Sprintf("Checkpoint %s of %s", checkpoint, name), false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					if err := dry.dockerDaemon.CreateCheckpoint(id, checkpoint, leaveRunning); err != nil {
+						return "", dry.pageError(h.screen, fmt.Sprintf("Checkpoint %s of %s could not be created", checkpoint, name), err)
+					}
+					widgets.ContainerMenu.ForContainer(id)
+					return fmt.Sprintf("<white>Checkpoint %s of %s created</>", checkpoint, name), nil
+				})
+		})
+	})
+}
+
+//checkpoint asks what to do with the given checkpoint of the container with
+//the given id, a container can only be started from a checkpoint if it is
+//not running
+func (h *cMenuEventHandler) checkpoint(id, name, checkpoint string, running bool, f func(eventHandler)) {
+	dry := h.dry
+	choices := []string{deleteCheckpoint}
+	if !running {
+		choices = []string{startFromCheckpoint, deleteCheckpoint}
+	}
+	askChoice(h, fmt.Sprintf("Checkpoint %s of %s", checkpoint, name), choices, f, func(choice string) {
+		switch choice {
+		case startFromCheckpoint:
+			dry.runJob(fmt.Sprintf("Start %s from checkpoint %s", name, checkpoint), false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					if err := dry.dockerDaemon.StartFromCheckpoint(id, checkpoint); err != nil {
+						return "", dry.pageError(h.screen, fmt.Sprintf("%s could not be started from checkpoint %s", name, checkpoint), err)
+					}
+					widgets.ContainerMenu.ForContainer(id)
+					return fmt.Sprintf("<white>%s started from checkpoint %s</>", name, checkpoint), nil
+				})
+		case deleteCheckpoint:
+			target := fmt.Sprintf("%s (container %s)", checkpoint, name)
+			dry.confirm(confirmCheckpointRm, "Do you want to delete the following checkpoint?", []string{target}, h, f, func() {
+				dry.runJob(fmt.Sprintf("Delete checkpoint %s of %s", checkpoint, name), false,
+					func(ctx context.Context, progress func(float64)) (string, error) {
+						if err := dry.dockerDaemon.DeleteCheckpoint(id, checkpoint); err != nil {
+							return "", dry.pageError(h.screen, fmt.Sprintf("Checkpoint %s of %s could not be deleted", checkpoint, name), err)
+						}
+						return fmt.Sprintf("<white>Checkpoint %s of %s deleted</>", checkpoint, name), nil
+					})
+			})
+		}
+	})
+}
+
+//pageError shows the given error in full on the pager, once the prompts on
+//screen are answered, and returns its first line. It is for errors too long
+//for the notification area, like those of CRIU.
+func (d *Dry) pageError(screen *ui.Screen, title string, err error) error {
+	message := strings.TrimSpace(err.Error())
+	lines := strings.Split(message, "\n")
+	if len(lines) == 1 && len(message) < screen.Dimensions.Width {
+		return err
+	}
+	d.ask(func(h eventHandler, f func(eventHandler)) {
+		forwarder := newEventForwarder()
+		f(forwarder)
+		go appui.Less(ui.StringRenderer(title+":\n\n"+message+"\n"), screen, forwarder.events(), func() {
+			f(h)
+			refreshScreen()
+		})
+	})
+	return errors.New(strings.TrimSpace(lines[0]) + " (the full error is on the pager)")
+}
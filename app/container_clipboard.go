@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/docker"
+)
+
+//copyContainerID copies the full id of the given container to the clipboard
+func copyContainerID(dry *Dry, c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	copyContainerValue(dry, "id", c.ID)
+}
+
+//copyContainerName copies the name of the given container to the clipboard
+func copyContainerName(dry *Dry, c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	if len(c.Names) == 0 {
+		dry.apperror(fmt.Sprintf("Container %s has no name", docker.TruncateID(c.ID)))
+		return
+	}
+	copyContainerValue(dry, "name", strings.TrimPrefix(c.Names[0], "/"))
+}
+
+func copyContainerValue(dry *Dry, what, value string) {
+	if err := copyToClipboard(value); err != nil {
+		dry.apperror(fmt.Sprintf("Could not copy the container %s: %s", what, err.Error()))
+		return
+	}
+	dry.appsuccess(fmt.Sprintf("Container %s %s copied to the clipboard", what, value))
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//commitContainer shows the form to create an image from the changes of the
+//given container. Once the user is done with it the container is committed,
+//if the daemon refuses to commit it the form is shown again. The new image
+//is shown selected on the image list.
+func commitContainer(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	name := dry.containerName(c.ID)
+	form := appui.NewContainerCommitForm(name)
+	widgets.add(form)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		for {
+			form.OnFocus(newEventSource(forwarder.events()))
+			if form.Canceled() {
+				widgets.remove(form)
+				f(h)
+				refreshScreen()
+				return
+			}
+			options := form.Options()
+			id, err := dry.dockerDaemon.CommitContainer(c.ID, options)
+			if err == nil {
+				widgets.remove(form)
+				widgets.ImageList.Unmount()
+				widgets.ImageList.Select(id)
+				ui.ActiveScreen.Cursor.Reset()
+				f(viewsToHandlers[Images])
+				dry.ViewMode(Images)
+				dry.appsuccess(fmt.Sprintf("Container %s committed to %s", name, options.Reference))
+				refreshScreen()
+				return
+			}
+			form.SetError("", err)
+			refreshScreen()
+		}
+	}()
+}
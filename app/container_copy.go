@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//copyFiles shows the form to copy files between the host and the given
+//container, the copy runs as a background job that reports its progress
+func copyFiles(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	name := dry.containerName(c.ID)
+	form := appui.NewContainerCopyForm(name)
+	widgets.add(form)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		form.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(form)
+		f(h)
+		refreshScreen()
+		if form.Canceled() {
+			return
+		}
+		direction, src, dst := form.Transfer()
+		description := fmt.Sprintf("Copy %s to %s:%s", src, name, dst)
+		if direction == docker.CopyFromContainer {
+			description = fmt.Sprintf("Copy %s:%s to %s", name, src, dst)
+		}
+		dry.runJob(description, true, copyFilesJob(dry, c.ID, direction, src, dst))
+	}()
+}
+
+//copyFilesJob returns the job that copies the given source to the given
+//destination, in the given direction
+func copyFilesJob(dry *Dry, id, direction, src, dst string) jobFunc {
+	return func(ctx context.Context, progress func(float64)) (string, error) {
+		var transferred int64
+		report := func(copied, total int64) {
+			transferred = copied
+			if total > 0 {
+				progress(float64(copied) / float64(total))
+			}
+		}
+		transfer := dry.dockerDaemon.CopyToContainer
+		if direction == docker.CopyFromContainer {
+			transfer = dry.dockerDaemon.CopyFromContainer
+		}
+		if err := transfer(ctx, id, src, dst, report); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("<white>%s copied to %s (%s)</>", src, dst, units.BytesSize(float64(transferred))), nil
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type containerEnvEventHandler struct {
+	baseEventHandler
+	widget *appui.ContainerEnvWidget
+}
+
+func (h *containerEnvEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		widgets.ContainerList.Select(h.widget.ContainerID())
+		h.dry.ViewMode(Main)
+		f(viewsToHandlers[Main])
+		refreshScreen()
+	case termbox.KeyF5:
+		h.widget.Refresh()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case 'c', 'C':
+			handled = true
+			h.copyValue()
+		case 's', 'S':
+			handled = true
+			if h.widget.ToggleMask() {
+				h.dry.appmessage("Secret values are masked")
+			} else {
+				h.dry.appmessage("Secret values are shown")
+			}
+			refreshScreen()
+		case '%':
+			handled = true
+			forwarder := newEventForwarder()
+			f(forwarder)
+			refreshScreen()
+			applyFilter := func(filter string, canceled bool) {
+				if !canceled {
+					h.screen.Cursor.Reset()
+					h.widget.Filter(filter)
+				}
+				f(h)
+				refreshScreen()
+			}
+			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		}
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//copyValue copies the value of the selected entry to the clipboard, masked
+//values are copied too
+func (h *containerEnvEventHandler) copyValue() {
+	entry, ok := h.widget.Selected()
+	if !ok {
+		h.dry.appmessage("Select an environment variable or a label to copy its value")
+		return
+	}
+	if err := copyToClipboard(entry.Value); err != nil {
+		h.dry.apperror(fmt.Sprintf("Could not copy the value of %s: %s", entry.Key, err.Error()))
+		return
+	}
+	h.dry.appsuccess(fmt.Sprintf("The value of %s was copied to the clipboard", entry.Key))
+}
@@ -1,11 +1,13 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
 	"github.com/moncho/dry/ui"
 	termbox "github.com/nsf/termbox-go"
 )
@@ -20,7 +22,10 @@ type containersScreenEventHandler struct {
 }
 
 func (h *containersScreenEventHandler) handle(event termbox.Event, f func(eventHandler)) {
-	handled := h.handleKey(event.Key, f)
+	handled := h.handleProjectKey(event, f)
+	if !handled {
+		handled = h.handleKey(event.Key, f)
+	}
 
 	if !handled {
 		handled = h.handleCharacter(event.Ch, f)
@@ -37,38 +42,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 
 	switch command.command {
 	case docker.KILL:
-		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to kill container %s? (y/N)", id))
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-
-				return
-			}
-
-			dry.actionMessage(id, "Killing")
-			err := dry.dockerDaemon.Kill(id)
-			if err == nil {
-				dry.actionMessage(id, "killed")
-			} else {
-				dry.errorMessage(id, "killing", err)
-			}
-
-		}()
+		dry.askKill(command.container, h, f, nil)
 
 	case docker.RESTART:
 		prompt := appui.NewPrompt(
@@ -94,11 +68,10 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 				return
 			}
 
-			if err := dry.dockerDaemon.RestartContainer(id); err != nil {
-				dry.appmessage(
-					fmt.Sprintf("Error restarting container %s, err: %s", id, err.Error()))
-			}
-
+			dry.runJob(fmt.Sprintf("Restart container %s", id), false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					return "", dry.dockerDaemon.RestartContainer(id)
+				})
 		}()
 
 	case docker.STOP:
@@ -125,52 +98,38 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 				return
 			}
 
-			if err := dry.dockerDaemon.StopContainer(id); err != nil {
-				dry.appmessage(
-					fmt.Sprintf("Error stopping container %s, err: %s", id, err.Error()))
-			}
-
+			dry.stopContainer(id, nil)
 		}()
 
+	case docker.RENAME:
+		renameContainer(dry, h, f, command.container, nil)
+	case docker.UPDATE:
+		updateContainerLimits(dry, h, f, command.container, nil)
+	case docker.RESTARTPOLICY:
+		editRestartPolicy(dry, h, f, command.container, nil)
+	case docker.COMMIT:
+		commitContainer(dry, h, f, command.container)
+	case docker.COPY:
+		copyFiles(dry, h, f, command.container)
+	case docker.EXEC:
+		execCommand(dry, h, f, command.container)
+	case docker.ATTACH:
+		attachToContainer(dry, h, f, command.container)
+	case docker.BROWSE:
+		openPublishedPort(dry, h, f, command.container)
+	case docker.PAUSE:
+		dry.pauseContainer(id, true, nil)
+	case docker.UNPAUSE:
+		dry.pauseContainer(id, false, nil)
 	case docker.LOGS:
 		h.showLogs(id, false, f)
 	case docker.RM:
-		prompt := appui.NewPrompt(
-			fmt.Sprintf("Do you want to remove container %s? (y/N)", id))
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-
-				return
-			}
-
-			err := dry.dockerDaemon.Rm(id)
-			if err == nil {
-				dry.actionMessage(id, "removed")
-			} else {
-				dry.errorMessage(id, "removing", err)
-			}
-			refreshScreen()
-		}()
+		removeContainer(dry, h, f, command.container, nil)
 
 	case docker.STATS:
 		c := dry.dockerDaemon.ContainerByID(id)
 		if c == nil || !docker.IsContainerRunning(c) {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Container with id %s not found or not running", id))
 		} else {
 			statsChan := dry.dockerDaemon.OpenChannel(c)
@@ -188,7 +147,6 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 		forwarder := newEventForwarder()
 		f(forwarder)
 		err := inspect(
-			h.screen,
 			forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.Inspect(id)
@@ -200,7 +158,7 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 			})(id)
 
 		if err != nil {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Error inspecting container: %s", err.Error()))
 			return
 		}
@@ -218,9 +176,25 @@ func (h *containersScreenEventHandler) handleCommand(command commandRunner, f fu
 				f(h)
 			})
 		} else {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Error showing image history: %s", err.Error()))
 		}
+	case docker.DIFF:
+		changes, err := dry.dockerDaemon.ContainerDiff(id)
+
+		if err == nil {
+			forwarder := newEventForwarder()
+			f(forwarder)
+			renderer := appui.NewContainerDiffRenderer(dry.containerName(id), changes)
+
+			go appui.Less(renderer, screen, forwarder.events(), func() {
+				h.dry.ViewMode(Main)
+				f(h)
+			})
+		} else {
+			dry.apperror(
+				fmt.Sprintf("Error showing filesystem changes: %s", err.Error()))
+		}
 	}
 }
 
@@ -241,6 +215,160 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 		showFilterInput(newEventSource(forwarder.events()), applyFilter)
 		refreshScreen()
 
+	case '/': //search containers
+		searchList(h, f, widgets.ContainerList)
+		refreshScreen()
+
+	case 'n': //next search match
+		jumpToSearchMatch(dry, widgets.ContainerList, true, "container")
+		refreshScreen()
+
+	case 'N': //previous search match
+		jumpToSearchMatch(dry, widgets.ContainerList, false, "container")
+		refreshScreen()
+
+	case 'c': //run a new container
+		runContainer(dry, h, f, docker.RunOptions{})
+
+	case 'C': //run a container like the selected one
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				inspected, err := dry.dockerDaemon.Inspect(id)
+				if err != nil {
+					return err
+				}
+				runContainer(dry, h, f, docker.RunOptionsFromContainer(inspected))
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error inspecting the container: " + err.Error())
+		}
+
+	case 'r', 'R': //recreate
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.recreateContainer(container, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error recreating the container: " + err.Error())
+		}
+
+	case 'p', 'P': //restart policy
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.RESTARTPOLICY,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error changing the restart policy: " + err.Error())
+		}
+
+	case 'k', 'K': //healthcheck results
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.screen.Cursor.Reset()
+				widgets.ContainerHealth.ForContainer(id, formatter.NewContainerFormatter(container, true).Names())
+				h.dry.ViewMode(ContainerHealth)
+				f(viewsToHandlers[ContainerHealth])
+				return refreshScreen()
+			}); err != nil {
+			h.dry.apperror("There was an error showing the container health: " + err.Error())
+		}
+
+	case 'j', 'J': //processes
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.screen.Cursor.Reset()
+				widgets.ContainerProcs.ForContainer(id, formatter.NewContainerFormatter(container, true).Names())
+				h.dry.ViewMode(ContainerProcesses)
+				f(viewsToHandlers[ContainerProcesses])
+				return refreshScreen()
+			}); err != nil {
+			h.dry.apperror("There was an error showing the container processes: " + err.Error())
+		}
+
+	case 'u': //check if the image of the selected container is outdated
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				dry.runJob(fmt.Sprintf("Check image update of %s", containerTarget(container)), true,
+					checkImageUpdates(dry.dockerDaemon, []*docker.Container{container}))
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error checking the image: " + err.Error())
+		}
+	case 'U': //check the images of every container on the list
+		containers := widgets.ContainerList.Containers()
+		if len(containers) == 0 {
+			h.dry.appmessage("The container list is empty")
+			break
+		}
+		dry.runJob(fmt.Sprintf("Check image updates of %d containers", len(containers)), true,
+			checkImageUpdates(dry.dockerDaemon, containers))
+	case 'v', 'V': //environment and labels
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.screen.Cursor.Reset()
+				widgets.ContainerEnv.ForContainer(container, formatter.NewContainerFormatter(container, true).Names())
+				h.dry.ViewMode(ContainerEnv)
+				f(viewsToHandlers[ContainerEnv])
+				return refreshScreen()
+			}); err != nil {
+			h.dry.apperror("There was an error showing the container environment: " + err.Error())
+		}
+
+	case 'b', 'B': //volumes and bind mounts
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.screen.Cursor.Reset()
+				widgets.ContainerMounts.ForContainer(container, formatter.NewContainerFormatter(container, true).Names())
+				h.dry.ViewMode(ContainerMounts)
+				f(viewsToHandlers[ContainerMounts])
+				return refreshScreen()
+			}); err != nil {
+			h.dry.apperror("There was an error showing the container mounts: " + err.Error())
+		}
+
+	case 'd', 'D': //links to other containers
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				h.screen.Cursor.Reset()
+				widgets.ContainerGraph.ForContainer(id)
+				h.dry.ViewMode(ContainerGraph)
+				f(viewsToHandlers[ContainerGraph])
+				return refreshScreen()
+			}); err != nil {
+			h.dry.apperror("There was an error showing the container links: " + err.Error())
+		}
+
 	case 'e', 'E': //remove
 		if err := h.widget.OnEvent(
 			func(id string) error {
@@ -254,7 +382,7 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error removing the container: " + err.Error())
+			h.dry.apperror("There was an error removing the container: " + err.Error())
 		}
 
 	case 'i', 'I': //inspect
@@ -270,7 +398,7 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error inspecting the container: " + err.Error())
+			h.dry.apperror("There was an error inspecting the container: " + err.Error())
 		}
 
 	case 'l', 'L': //logs
@@ -286,8 +414,107 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error showing logs: " + err.Error())
+			h.dry.apperror("There was an error showing logs: " + err.Error())
+		}
+	case 'y', 'Y': //docker run command
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				return h.runCommand(id, f)
+			}); err != nil {
+			h.dry.apperror("There was an error building the docker run command: " + err.Error())
+		}
+	case 'a', 'A': //commit to an image
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.COMMIT,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error committing the container: " + err.Error())
+		}
+	case '!': //run a command
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.EXEC,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error running the command: " + err.Error())
+		}
+	case 'f', 'F': //copy files
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.COPY,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error copying files: " + err.Error())
+		}
+	case 'z', 'Z': //pause or unpause
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				if !docker.IsContainerRunning(container) {
+					return fmt.Errorf("Container %s is not running", dry.containerName(id))
+				}
+				command := docker.PAUSE
+				if docker.IsContainerPaused(container) {
+					command = docker.UNPAUSE
+				}
+				h.handleCommand(commandRunner{command, container}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error pausing the container: " + err.Error())
+		}
+	case 'x', 'X': //expand the selected row
+		widgets.ContainerList.ToggleExpanded()
+		refreshScreen()
+	case 'o', 'O': //row rules
+		h.editRowRules(f)
+	case '|': //hide or show columns
+		chooseColumn(h.dry, h, f, containersList, widgets.ContainerList)
+	case '#': //copy the container id
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				copyContainerID(dry, dry.dockerDaemon.ContainerByID(id))
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error copying the container id: " + err.Error())
+		}
+	case '@': //copy the container name
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				copyContainerName(dry, dry.dockerDaemon.ContainerByID(id))
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error copying the container name: " + err.Error())
 		}
+	case 't', 'T': //filter by status
+		h.chooseStatusFilter(f)
+	case 'w', 'W': //split view
+		h.dry.toggleSplitView(h.screen.Dimensions.Width)
+		refreshScreen()
 	case 's', 'S': //stats
 		if err := h.widget.OnEvent(
 			func(id string) error {
@@ -301,7 +528,7 @@ func (h *containersScreenEventHandler) handleCharacter(key rune, f func(eventHan
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error showing stats: " + err.Error())
+			h.dry.apperror("There was an error showing stats: " + err.Error())
 		}
 	default:
 		handled = false
@@ -316,55 +543,108 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 	case termbox.KeyF1: //sort
 		h.widget.Sort()
 		refreshScreen()
+	case termbox.KeyF3: //reverse sort
+		widgets.ContainerList.ReverseSort()
+		refreshScreen()
+	case termbox.KeyCtrlV: //open a published port
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.BROWSE,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error opening the published port: " + err.Error())
+		}
+	case termbox.KeyCtrlW: //resize columns
+		resizeColumns(h.dry, h, f, widgets.ContainerList)
 	case termbox.KeyF2: //show all containers
 		cursor.Reset()
 		widgets.ContainerList.ToggleShowAllContainers()
 		refreshScreen()
 	case termbox.KeyF5: // refresh
 		h.dry.appmessage("Refreshing container list")
+		h.dry.resetAutoRefresh()
 		h.dry.dockerDaemon.Refresh(func(e error) {
 			if e == nil {
 				h.widget.Unmount()
 				refreshScreen()
 			} else {
-				h.dry.appmessage("There was an error refreshing: " + e.Error())
+				h.dry.apperror("There was an error refreshing: " + e.Error())
 			}
 		})
 	case termbox.KeyCtrlE: //remove all stopped
-		prompt := appui.NewPrompt(
-			"All stopped containers will be removed. Do you want to continue? (y/N) ")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
+		stopped := h.dry.dockerDaemon.Containers(
+			[]docker.ContainerFilter{docker.ContainerFilters.NotRunning()}, docker.NoSort)
+		if len(stopped) == 0 {
+			h.dry.appmessage("There are no stopped containers")
+			break
+		}
+		targets := make([]string, len(stopped))
+		for i, c := range stopped {
+			targets[i] = containerTarget(c)
+		}
+		h.dry.confirm(confirmContainerRmStopped, "Do you want to remove the following stopped containers?", targets, h, f, func() {
+			h.dry.runJob("Remove all stopped containers", true,
+				removeStoppedContainers(h.dry.dockerDaemon, stopped))
+		})
 
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-			go func() {
-				h.dry.appmessage("<red>Removing all stopped containers</>")
-				if count, err := h.dry.dockerDaemon.RemoveAllStoppedContainers(); err == nil {
-					h.dry.appmessage(fmt.Sprintf("<red>Removed %d stopped containers</>", count))
-				} else {
-					h.dry.appmessage(
-						fmt.Sprintf(
-							"<red>Error removing all stopped containers: %s</>", err.Error()))
+	case termbox.KeyCtrlY: //prune
+		h.pruneContainers(f)
+	case termbox.KeyCtrlG: //stats history
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil || !docker.IsContainerRunning(container) {
+					return fmt.Errorf("Container with id %s not found or not running", id)
 				}
-				refreshScreen()
-			}()
-		}()
-
+				forwarder := newEventForwarder()
+				f(forwarder)
+				h.dry.ViewMode(NoView)
+				go statsHistoryScreen(h.dry.dockerDaemon, container, h.screen, forwarder.events(), func() {
+					h.dry.ViewMode(Main)
+					f(h)
+					refreshScreen()
+				})
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error showing stats history: " + err.Error())
+		}
+	case termbox.KeyCtrlA: //attach
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.ATTACH,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error attaching to the container: " + err.Error())
+		}
+	case termbox.KeyCtrlD: //filesystem changes
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.DIFF,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error showing the filesystem changes: " + err.Error())
+		}
 	case termbox.KeyCtrlK: //kill
 		if err := h.widget.OnEvent(
 			func(id string) error {
@@ -378,7 +658,7 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error killing container: " + err.Error())
+			h.dry.apperror("There was an error killing container: " + err.Error())
 		}
 	case termbox.KeyCtrlL: //Logs with timestamp
 		if err := h.widget.OnEvent(
@@ -390,9 +670,25 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 				h.showLogs(id, true, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error showing logs: " + err.Error())
+			h.dry.apperror("There was an error showing logs: " + err.Error())
 		}
+	case termbox.KeyCtrlF: //group by compose project
+		h.toggleProjectGrouping()
+	case termbox.KeyCtrlP: //pin
+		if name := widgets.ContainerList.TogglePin(); name != "" {
+			h.dry.appmessage(fmt.Sprintf("Container %s pinned, the cursor stays on it", name))
+		} else {
+			h.dry.appmessage("Container unpinned")
+		}
+		refreshScreen()
+	case termbox.KeySpace: //mark
+		widgets.ContainerList.ToggleMark()
+		refreshScreen()
 	case termbox.KeyCtrlR: //start
+		if len(widgets.ContainerList.Marked()) > 0 {
+			h.runOnMarked(restartMarked, confirmContainerRestart, f)
+			break
+		}
 		if err := h.widget.OnEvent(
 			func(id string) error {
 				container := h.dry.dockerDaemon.ContainerByID(id)
@@ -405,9 +701,43 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error restarting: " + err.Error())
+			h.dry.apperror("There was an error restarting: " + err.Error())
+		}
+	case termbox.KeyCtrlN: //rename
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.RENAME,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error renaming the container: " + err.Error())
+		}
+	case termbox.KeyCtrlU: //update limits
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				container := h.dry.dockerDaemon.ContainerByID(id)
+				if container == nil {
+					return fmt.Errorf("Container with id %s not found", id)
+				}
+				h.handleCommand(commandRunner{
+					docker.UPDATE,
+					container,
+				}, f)
+				return nil
+			}); err != nil {
+			h.dry.apperror("There was an error updating the container: " + err.Error())
 		}
 	case termbox.KeyCtrlT: //stop
+		if len(widgets.ContainerList.Marked()) > 0 {
+			h.runOnMarked(stopMarked, confirmContainerStop, f)
+			break
+		}
 		if err := h.widget.OnEvent(
 			func(id string) error {
 				container := h.dry.dockerDaemon.ContainerByID(id)
@@ -420,7 +750,7 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 				}, f)
 				return nil
 			}); err != nil {
-			h.dry.appmessage("There was an error stopping container: " + err.Error())
+			h.dry.apperror("There was an error stopping container: " + err.Error())
 		}
 	case termbox.KeyEnter: //Container menu
 		showMenu := func(id string) error {
@@ -437,7 +767,7 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 			return refreshScreen()
 		}
 		if err := h.widget.OnEvent(showMenu); err != nil {
-			h.dry.appmessage(err.Error())
+			h.dry.apperror(err.Error())
 		}
 
 	default:
@@ -447,6 +777,77 @@ func (h *containersScreenEventHandler) handleKey(key termbox.Key, f func(eventHa
 	return handled
 }
 
+//removeStoppedContainers returns a job that removes the given stopped containers one
+//by one, so progress is reported and the job can be canceled between removals
+func removeStoppedContainers(daemon docker.ContainerDaemon, containers []*docker.Container) jobFunc {
+	return func(ctx context.Context, progress func(float64)) (string, error) {
+		for i, c := range containers {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			if err := daemon.Rm(c.ID); err != nil {
+				return "", fmt.Errorf("removed %d of %d stopped containers: %s", i, len(containers), err)
+			}
+			progress(float64(i+1) / float64(len(containers)))
+		}
+		return fmt.Sprintf("Removed %d stopped containers", len(containers)), nil
+	}
+}
+
+//checkImageUpdates returns a job that checks if the images of the given
+//containers are behind their registry version, the container list shows
+//each result as it arrives
+func checkImageUpdates(daemon docker.ContainerDaemon, containers []*docker.Container) jobFunc {
+	return func(ctx context.Context, progress func(float64)) (string, error) {
+		var lock sync.Mutex
+		var checked, outdated, rateLimited, notFound, failed int
+		var lastErr error
+		daemon.CheckImageUpdates(ctx, containers, func(u docker.ImageUpdate) {
+			widgets.ContainerList.SetImageUpdate(u)
+			lock.Lock()
+			checked++
+			switch {
+			case u.Status == docker.ImageOutdated:
+				outdated++
+			case u.Err == docker.ErrRegistryRateLimited:
+				rateLimited++
+			case u.Err == docker.ErrImageNotInRegistry:
+				notFound++
+			case u.Err != nil:
+				failed++
+				lastErr = u.Err
+			}
+			done := float64(checked) / float64(len(containers))
+			lock.Unlock()
+			progress(done)
+			refreshScreen()
+		})
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if len(containers) == 1 && checked == 1 && (rateLimited+notFound+failed) > 0 {
+			switch {
+			case rateLimited > 0:
+				return "", docker.ErrRegistryRateLimited
+			case notFound > 0:
+				return "", docker.ErrImageNotInRegistry
+			}
+			return "", lastErr
+		}
+		result := fmt.Sprintf("%d of %d containers have outdated images", outdated, len(containers))
+		if rateLimited > 0 {
+			result += fmt.Sprintf(", <red>%d not checked: registry rate limit reached</>", rateLimited)
+		}
+		if notFound > 0 {
+			result += fmt.Sprintf(", %d images not found on their registry", notFound)
+		}
+		if failed > 0 {
+			result += fmt.Sprintf(", %d could not be checked (%s)", failed, lastErr.Error())
+		}
+		return result, nil
+	}
+}
+
 //statsScreen shows container stats on the screen
 //TODO move to appui
 func statsScreen(container *docker.Container, stats *docker.StatsChannel, screen *ui.Screen, events <-chan termbox.Event, closeCallback func()) {
@@ -515,38 +916,59 @@ loop:
 }
 
 func (h *containersScreenEventHandler) showLogs(id string, withTimestamp bool, f func(eventHandler)) {
-	prompt := logsPrompt()
-	widgets.add(prompt)
-	forwarder := newEventForwarder()
-	f(forwarder)
-	refreshScreen()
-
-	go func() {
-		events := ui.EventSource{
-			Events: forwarder.events(),
-			EventHandledCallback: func(e termbox.Event) error {
-				return refreshScreen()
-			},
-		}
-		prompt.OnFocus(events)
-		widgets.remove(prompt)
-		since, canceled := prompt.Text()
+	chooseLogsSource(h.dry, h, h.dry.dockerDaemon.ContainerByID(id), f, func(source logsSource) {
+		prompt := logsPrompt()
+		widgets.add(prompt)
+		forwarder := newEventForwarder()
+		f(forwarder)
+		refreshScreen()
 
-		if canceled {
-			f(h)
-			return
-		}
+		go func() {
+			events := ui.EventSource{
+				Events: forwarder.events(),
+				EventHandledCallback: func(e termbox.Event) error {
+					return refreshScreen()
+				},
+			}
+			prompt.OnFocus(events)
+			widgets.remove(prompt)
+			since, canceled := prompt.Text()
 
-		logs, err := h.dry.dockerDaemon.Logs(id, since, withTimestamp)
-		if err == nil {
-			appui.Stream(logs, forwarder.events(), func() {
-				h.dry.ViewMode(Main)
+			if canceled {
 				f(h)
-				refreshScreen()
-			})
-		} else {
-			h.dry.appmessage("Error showing container logs: " + err.Error())
+				return
+			}
+
+			logs, err := openLogs(h.dry, source, id, since, withTimestamp)
+			if err == nil {
+				streamLogs(source, logs, forwarder.events(), func() {
+					h.dry.ViewMode(Main)
+					f(h)
+					refreshScreen()
+				})
+			} else {
+				f(h)
+				h.dry.apperror("Error showing container logs: " + err.Error())
 
+			}
+		}()
+	})
+}
+
+//anyStatus is the choice of the status filter that lists containers in any state
+const anyStatus = "any state"
+
+//chooseStatusFilter asks the user for the state of the containers to list,
+//the list is filtered by it
+func (h *containersScreenEventHandler) chooseStatusFilter(f func(eventHandler)) {
+	choices := append([]string{anyStatus}, docker.ContainerStates...)
+	askChoice(h, " Show the containers in state ", choices, f, func(status string) {
+		if status == anyStatus {
+			if _, ok := docker.FilteredStatus(widgets.ContainerList.ActiveFilter()); ok {
+				widgets.ContainerList.Filter("")
+			}
+			return
 		}
-	}()
+		widgets.ContainerList.Filter(docker.StatusFilterPrefix + status)
+	})
 }
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//execHistorySize is how many commands are remembered for each container
+const execHistorySize = 5
+
+//execHistory remembers, for the rest of the session, the last commands run
+//in each container
+type execHistory struct {
+	commands map[string][]string
+	sync.Mutex
+}
+
+//add remembers that the given command was run in the container with the given id
+func (h *execHistory) add(id, command string) {
+	h.Lock()
+	defer h.Unlock()
+	if h.commands == nil {
+		h.commands = make(map[string][]string)
+	}
+	commands := []string{command}
+	for _, c := range h.commands[id] {
+		if c != command && len(commands) < execHistorySize {
+			commands = append(commands, c)
+		}
+	}
+	h.commands[id] = commands
+}
+
+//of returns the last commands run in the container with the given id, newest first
+func (h *execHistory) of(id string) []string {
+	h.Lock()
+	defer h.Unlock()
+	return append([]string(nil), h.commands[id]...)
+}
+
+//execCommand shows the form to run a command in the given container, the
+//output of the command is shown until the user closes it. If the daemon
+//refuses to run the command the form is shown again.
+func execCommand(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	if !docker.IsContainerRunning(c) {
+		dry.apperror(fmt.Sprintf("Container %s is not running", dry.containerName(c.ID)))
+		return
+	}
+	name := dry.containerName(c.ID)
+	form := appui.NewContainerExecForm(name, dry.execHistory.of(c.ID))
+	widgets.add(form)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		for {
+			form.OnFocus(newEventSource(forwarder.events()))
+			if form.Canceled() {
+				break
+			}
+			options := form.Options()
+			output, err := dry.dockerDaemon.Exec(c.ID, options)
+			if err != nil {
+				form.SetError("", err)
+				refreshScreen()
+				continue
+			}
+			dry.execHistory.add(c.ID, options.Command)
+			if output == nil {
+				break
+			}
+			widgets.remove(form)
+			appui.Stream(output, forwarder.events(), func() {
+				f(h)
+				refreshScreen()
+			})
+			return
+		}
+		widgets.remove(form)
+		f(h)
+		refreshScreen()
+	}()
+}
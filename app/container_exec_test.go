@@ -0,0 +1,25 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExecHistory(t *testing.T) {
+	var h execHistory
+	if commands := h.of("web"); len(commands) != 0 {
+		t.Errorf("Unexpected commands of a container without history: %v", commands)
+	}
+	for _, c := range []string{"ls", "ps aux", "env", "ls", "df -h", "top -b -n 1", "uptime"} {
+		h.add("web", c)
+	}
+	h.add("db", "psql")
+
+	want := []string{"uptime", "top -b -n 1", "df -h", "ls", "env"}
+	if got := h.of("web"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Commands of web = %v, want %v", got, want)
+	}
+	if got := h.of("db"); !reflect.DeepEqual(got, []string{"psql"}) {
+		t.Errorf("Commands of db = %v, want [psql]", got)
+	}
+}
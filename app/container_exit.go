@@ -0,0 +1,169 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+//exitWaitTimeout is how long a stopped or killed container is waited for
+//to exit
+const exitWaitTimeout = 10 * time.Second
+
+//question asks the user something, h being the handler of the events at the
+//time and f the func that sets the next one
+type question func(h eventHandler, f func(eventHandler))
+
+//ask asks the given question once the prompts on screen, if any, are answered.
+//It is how operations running in the background ask the user.
+func (d *Dry) ask(q question) {
+	go func() {
+		d.questions <- q
+	}()
+}
+
+//containerName returns the name the user knows the container with the given
+//id by
+func (d *Dry) containerName(id string) string {
+	if c := d.dockerDaemon.ContainerByID(id); c != nil && len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return docker.TruncateID(id)
+}
+
+//stopContainer stops the container with the given id in the background. If
+//waiting for containers to exit is enabled, how the container exited is
+//notified, and the user is asked to kill it if it does not exit in time.
+//done, if not nil, is called once the container is stopped.
+func (d *Dry) stopContainer(id string, done func()) {
+	name := d.containerName(id)
+	d.runJob(fmt.Sprintf("Stop container %s", id), false,
+		func(ctx context.Context, progress func(float64)) (string, error) {
+			if !userPreferences.waitForExit() {
+				if err := d.dockerDaemon.StopContainer(id); err != nil {
+					return "", err
+				}
+				if done != nil {
+					done()
+				}
+				return "", nil
+			}
+			exit, err := d.dockerDaemon.StopAndWait(id, exitWaitTimeout)
+			if timeout, ok := err.(*docker.ExitTimeoutError); ok {
+				d.ask(func(h eventHandler, f func(eventHandler)) {
+					askText(h, fmt.Sprintf("%s did not exit within %s, escalate to SIGKILL? (y/N)", name, timeout.Timeout), f, func(answer string) {
+						if answer == "y" || answer == "Y" {
+							d.killContainer(id, done)
+						}
+					})
+				})
+				return "", err
+			}
+			if err != nil {
+				return "", err
+			}
+			if done != nil {
+				done()
+			}
+			return exitMessage(name, exit), nil
+		})
+}
+
+//killContainer kills the container with the given id in the background. If
+//waiting for containers to exit is enabled, how the container exited is
+//notified. done, if not nil, is called once the container is killed.
+func (d *Dry) killContainer(id string, done func()) {
+	name := d.containerName(id)
+	d.runJob(fmt.Sprintf("Kill container %s", id), false,
+		func(ctx context.Context, progress func(float64)) (string, error) {
+			if !userPreferences.waitForExit() {
+				if err := d.dockerDaemon.Kill(id); err != nil {
+					return "", err
+				}
+				if done != nil {
+					done()
+				}
+				return fmt.Sprintf("<white>Container with id %s killed</>", id), nil
+			}
+			exit, err := d.dockerDaemon.KillAndWait(id, exitWaitTimeout)
+			if err != nil {
+				return "", err
+			}
+			if done != nil {
+				done()
+			}
+			return exitMessage(name, exit), nil
+		})
+}
+
+//otherSignal is the choice of the signal picker that asks for any signal
+const otherSignal = "other..."
+
+//askKill asks the user for the signal to kill the given container with and
+//to confirm it, then sends it. SIGKILL, the signal picked unless another
+//one is, kills the container as killContainer does. done, if not nil, is
+//called once the signal is sent.
+func (d *Dry) askKill(c *docker.Container, h eventHandler, f func(eventHandler), done func()) {
+	if c == nil {
+		d.apperror("Container not found")
+		return
+	}
+	d.pickSignal(h, f, func(signal string) {
+		question := "Do you want to kill the following container?"
+		if !docker.IsKillSignal(signal) {
+			question = fmt.Sprintf("Do you want to send %s to the following container?", signal)
+		}
+		d.confirm(confirmContainerKill, question, []string{containerTarget(c)}, h, f, func() {
+			d.signalContainer(c.ID, signal, done)
+		})
+	})
+}
+
+//pickSignal asks the user to pick a signal, or to type any other, onSignal
+//is only called if a valid signal is given
+func (d *Dry) pickSignal(h eventHandler, f func(eventHandler), onSignal func(string)) {
+	choices := append(append([]string(nil), docker.KillSignals...), otherSignal)
+	askChoice(h, " Signal ", choices, f, func(choice string) {
+		if choice != otherSignal {
+			onSignal(choice)
+			return
+		}
+		askText(h, "Signal to send, i.e. SIGWINCH or 28", f, func(text string) {
+			signal, err := docker.ParseSignal(text)
+			if err != nil {
+				d.apperror(err.Error())
+				return
+			}
+			onSignal(signal)
+		})
+	})
+}
+
+//signalContainer sends the given signal to the container with the given id
+//in the background, SIGKILL kills it as killContainer does. done, if not
+//nil, is called once the signal is sent.
+func (d *Dry) signalContainer(id, signal string, done func()) {
+	if docker.IsKillSignal(signal) {
+		d.killContainer(id, done)
+		return
+	}
+	name := d.containerName(id)
+	d.runJob(fmt.Sprintf("Send %s to container %s", signal, id), false,
+		func(ctx context.Context, progress func(float64)) (string, error) {
+			if err := d.dockerDaemon.SignalContainer(id, signal); err != nil {
+				return "", err
+			}
+			if done != nil {
+				done()
+			}
+			return fmt.Sprintf("<white>%s sent to %s</>", signal, name), nil
+		})
+}
+
+//exitMessage tells how the container with the given name exited
+func exitMessage(name string, exit docker.ContainerExit) string {
+	return fmt.Sprintf("<white>%s exited with code %d after %.1fs</>", name, exit.StatusCode, exit.After.Seconds())
+}
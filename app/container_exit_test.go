@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestExitMessage(t *testing.T) {
+	tests := []struct {
+		exit docker.ContainerExit
+		want string
+	}{
+		{docker.ContainerExit{StatusCode: 0, After: 2300 * time.Millisecond}, "<white>web-1 exited with code 0 after 2.3s</>"},
+		{docker.ContainerExit{StatusCode: 137, After: 40 * time.Millisecond}, "<white>web-1 exited with code 137 after 0.0s</>"},
+		{docker.ContainerExit{StatusCode: 143, After: 10 * time.Second}, "<white>web-1 exited with code 143 after 10.0s</>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := exitMessage("web-1", tt.exit); got != tt.want {
+				t.Errorf("exitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
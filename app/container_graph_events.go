@@ -0,0 +1,49 @@
+package app
+
+import (
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type containerGraphEventHandler struct {
+	baseEventHandler
+	widget *appui.ContainerGraphWidget
+}
+
+func (h *containerGraphEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.backToContainers(h.widget.RootID(), f)
+	case termbox.KeyEnter:
+		if err := h.widget.OnEvent(func(id string) error {
+			h.backToContainers(id, f)
+			return nil
+		}); err != nil {
+			h.dry.appmessage(err.Error())
+		}
+	case termbox.KeySpace, termbox.KeyArrowRight:
+		if h.widget.ToggleExpand() {
+			refreshScreen()
+		}
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//backToContainers goes back to the container list with the cursor on the
+//container with the given id
+func (h *containerGraphEventHandler) backToContainers(id string, f func(eventHandler)) {
+	h.widget.Unmount()
+	h.screen.Cursor.Reset()
+	widgets.ContainerList.Select(id)
+	h.dry.ViewMode(Main)
+	f(viewsToHandlers[Main])
+	refreshScreen()
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type containerHealthEventHandler struct {
+	baseEventHandler
+	widget *appui.ContainerHealthWidget
+}
+
+func (h *containerHealthEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		widgets.ContainerList.Select(h.widget.ContainerID())
+		h.dry.ViewMode(Main)
+		f(viewsToHandlers[Main])
+		refreshScreen()
+	case termbox.KeySpace, termbox.KeyEnter:
+		if h.widget.ToggleExpand() {
+			refreshScreen()
+		}
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case 'l', 'L':
+			handled = true
+			h.showLogs(f)
+		}
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//showLogs streams the logs of the container, the health log is shown again
+//once the logs are closed
+func (h *containerHealthEventHandler) showLogs(f func(eventHandler)) {
+	id := h.widget.ContainerID()
+	chooseLogsSource(h.dry, h, h.dry.dockerDaemon.ContainerByID(id), f, func(source logsSource) {
+		logs, err := openLogs(h.dry, source, id, "", false)
+		if err != nil {
+			h.dry.apperror("Error showing container logs: " + err.Error())
+			return
+		}
+		h.widget.Unmount()
+		forwarder := newEventForwarder()
+		f(forwarder)
+		go streamLogs(source, logs, forwarder.events(), func() {
+			h.dry.ViewMode(ContainerHealth)
+			f(h)
+			refreshScreen()
+		})
+	})
+}
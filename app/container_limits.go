@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//updateContainerLimits shows the form to change the resource limits and the
+//restart policy of the given container. Once the user is done with it the
+//container is updated, if the daemon rejects the limits the form is shown
+//again. onUpdated, if not nil, is called once the container is updated.
+func updateContainerLimits(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container, onUpdated func()) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	name := dry.containerName(c.ID)
+	form := appui.NewContainerUpdateForm(name, docker.Limits(c))
+	widgets.add(form)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		defer func() {
+			widgets.remove(form)
+			f(h)
+			refreshScreen()
+		}()
+		for {
+			form.OnFocus(newEventSource(forwarder.events()))
+			if form.Canceled() {
+				return
+			}
+			updated, err := dry.dockerDaemon.UpdateLimits(c.ID, form.Limits())
+			if err == nil {
+				if updated != nil {
+					widgets.ContainerList.UpdateContainer(updated)
+				}
+				if onUpdated != nil {
+					onUpdated()
+				}
+				dry.appsuccess(fmt.Sprintf("Limits of %s updated", name))
+				return
+			}
+			form.SetError("", err)
+			refreshScreen()
+		}
+	}()
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//logsSource is where the logs of a container are read from
+type logsSource int
+
+const (
+	//dockerAPILogs are read through the Docker API
+	dockerAPILogs logsSource = iota
+	//journalLogs are read from the systemd journal of this host, with journalctl
+	journalLogs
+)
+
+//journalBanner labels the logs that do not come from the Docker API
+const journalBanner = "<yellow>[journalctl] Logs read from the systemd journal of this host, not through the Docker API</>"
+
+//chooseLogsSource finds out, from what is known about the given container and
+//so without asking the daemon, where its logs can be read from and calls
+//onSource with it. If the log driver of the container does not let the
+//Docker API read them, the user is told where they go instead or, for
+//journald on this host, asked whether to read the journal. h handles the
+//next events once the user is done.
+func chooseLogsSource(d *Dry, h eventHandler, c *docker.Container, f func(eventHandler), onSource func(logsSource)) {
+	driver := docker.LogDriver(c)
+	if docker.LogsReadable(driver) {
+		onSource(dockerAPILogs)
+		return
+	}
+	if driver == docker.JournaldLogDriver && d.dockerDaemon.IsLocal() {
+		readJournal := "Read them with journalctl, from the journal of this host"
+		askChoice(h, fmt.Sprintf(" %s logs to journald, not readable through the Docker API ", containerTarget(c)),
+			[]string{readJournal, "Cancel"}, f,
+			func(choice string) {
+				if choice == readJournal {
+					onSource(journalLogs)
+				}
+			})
+		return
+	}
+	showLogDriverPanel(d, h, c, f)
+}
+
+//showLogDriverPanel explains why the logs of the given container cannot be
+//shown and where they go
+func showLogDriverPanel(d *Dry, h eventHandler, c *docker.Container, f func(eventHandler)) {
+	driver := docker.LogDriver(c)
+	text := fmt.Sprintf("<white>%s</> uses the <yellow>%s</> logging driver.\n\n", containerTarget(c), driver)
+	switch {
+	case driver == "none":
+		text += "Logging is disabled for this container, its output is discarded.\n"
+	case driver == docker.JournaldLogDriver:
+		text += "Its logs go to the systemd journal of the Docker host, they can be read there with:\n\n" +
+			fmt.Sprintf("\tjournalctl CONTAINER_ID_FULL=%s\n\n", c.ID) +
+			"dry only reads the journal itself when Docker runs on this host.\n"
+	default:
+		text += "The Docker API cannot read the logs written by this driver, they have to be read\n" +
+			"wherever the driver sends them.\n"
+	}
+	if destination := docker.LogDestination(c); destination != "" {
+		text += fmt.Sprintf("\nDriver options: <white>%s</>\n", destination)
+	}
+	text += "\nPress <white>Esc</> to go back."
+
+	view := d.viewMode()
+	d.ViewMode(InfoMode)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	go appui.Less(ui.StringRenderer(text), ui.ActiveScreen, forwarder.events(), func() {
+		d.ViewMode(view)
+		f(h)
+		refreshScreen()
+	})
+}
+
+//openLogs opens the logs of the container with the given id from the given source
+func openLogs(d *Dry, source logsSource, id, since string, withTimestamps bool) (io.ReadCloser, error) {
+	if source == journalLogs {
+		return d.dockerDaemon.JournalLogs(id, since)
+	}
+	return d.dockerDaemon.Logs(id, since, withTimestamps)
+}
+
+//streamLogs shows the given logs, read from the given source, on screen
+func streamLogs(source logsSource, logs io.ReadCloser, events chan termbox.Event, done func()) {
+	if source == journalLogs {
+		appui.StreamText(logs, journalBanner, events, done)
+		return
+	}
+	appui.Stream(logs, events, done)
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type containerMountsEventHandler struct {
+	baseEventHandler
+	widget *appui.ContainerMountsWidget
+}
+
+func (h *containerMountsEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.backToContainers(f)
+	case termbox.KeyF5:
+		h.widget.Refresh()
+		refreshScreen()
+	case termbox.KeyEnter:
+		h.showVolumeContainers(f)
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case 'c', 'C':
+			handled = true
+			h.copyHostPath()
+		case 'i', 'I':
+			handled = true
+			h.inspectVolume(f)
+		}
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//backToContainers goes back to the container list with the container whose
+//mounts were shown on the cursor
+func (h *containerMountsEventHandler) backToContainers(f func(eventHandler)) {
+	h.widget.Unmount()
+	h.screen.Cursor.Reset()
+	widgets.ContainerList.Select(h.widget.ContainerID())
+	h.dry.ViewMode(Main)
+	f(viewsToHandlers[Main])
+	refreshScreen()
+}
+
+//showVolumeContainers goes back to the container list filtered to show
+//every container, running or not, that uses the selected volume
+func (h *containerMountsEventHandler) showVolumeContainers(f func(eventHandler)) {
+	m, ok := h.widget.Selected()
+	if !ok || m.Type != mount.TypeVolume || m.Name == "" {
+		h.dry.appmessage("Select a named volume to list the containers using it")
+		return
+	}
+	h.widget.Unmount()
+	h.screen.Cursor.Reset()
+	if !widgets.ContainerList.ShowAllContainers() {
+		widgets.ContainerList.ToggleShowAllContainers()
+	}
+	widgets.ContainerList.Filter(docker.VolumeFilterPrefix + m.Name)
+	widgets.ContainerList.Select(h.widget.ContainerID())
+	h.dry.ViewMode(Main)
+	f(viewsToHandlers[Main])
+	refreshScreen()
+}
+
+//inspectVolume shows the selected volume as the daemon inspects it, the
+//mounts are shown again once it is closed
+func (h *containerMountsEventHandler) inspectVolume(f func(eventHandler)) {
+	m, ok := h.widget.Selected()
+	if !ok || m.Type != mount.TypeVolume || m.Name == "" {
+		h.dry.appmessage("Select a named volume to inspect it")
+		return
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	err := inspect(
+		forwarder.events(),
+		func(name string) (interface{}, error) {
+			return h.dry.dockerDaemon.VolumeInspect(name)
+		},
+		func() {
+			f(h)
+			refreshScreen()
+		})(m.Name)
+	if err != nil {
+		f(h)
+		h.dry.apperror(fmt.Sprintf("Error inspecting volume %s: %s", m.Name, err.Error()))
+	}
+}
+
+//copyHostPath copies the path on the host of the selected bind mount to
+//the clipboard
+func (h *containerMountsEventHandler) copyHostPath() {
+	m, ok := h.widget.Selected()
+	if !ok || m.Type != mount.TypeBind {
+		h.dry.appmessage("Select a bind mount to copy its host path")
+		return
+	}
+	if err := copyToClipboard(m.Source); err != nil {
+		h.dry.apperror(fmt.Sprintf("Could not copy the host path of %s: %s", m.Destination, err.Error()))
+		return
+	}
+	h.dry.appsuccess(fmt.Sprintf("The host path of %s was copied to the clipboard", m.Destination))
+}
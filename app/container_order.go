@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+//orderedOperation is an operation run on several containers in the order
+//given by their compose dependencies
+type orderedOperation struct {
+	title string //i.e. "Stop"
+	verb  string //i.e. "stop"
+	done  string //i.e. "stopped"
+	run   func(daemon docker.ContainerDaemon, id string) error
+	order func(g *docker.ContainerGraph, ids []string) docker.OperationOrder
+}
+
+var (
+	stopMarked = orderedOperation{
+		title: "Stop",
+		verb:  "stop",
+		done:  "stopped",
+		run: func(daemon docker.ContainerDaemon, id string) error {
+			return daemon.StopContainer(id)
+		},
+		order: (*docker.ContainerGraph).StopOrder,
+	}
+	restartMarked = orderedOperation{
+		title: "Restart",
+		verb:  "restart",
+		done:  "restarted",
+		run: func(daemon docker.ContainerDaemon, id string) error {
+			return daemon.RestartContainer(id)
+		},
+		order: (*docker.ContainerGraph).StartOrder,
+	}
+	removeOrdered = orderedOperation{
+		title: "Remove",
+		verb:  "remove",
+		done:  "removed",
+		run: func(daemon docker.ContainerDaemon, id string) error {
+			return daemon.Rm(id)
+		},
+		order: (*docker.ContainerGraph).StopOrder,
+	}
+)
+
+//runOnMarked runs the given operation on the marked containers, once the user
+//confirms the order in which they are operated on
+func (h *containersScreenEventHandler) runOnMarked(op orderedOperation, action string, f func(eventHandler)) {
+	h.runOnContainers(op, widgets.ContainerList.Marked(), action, "The marked containers are gone", func() {
+		widgets.ContainerList.ClearMarks()
+	}, f)
+}
+
+//runOnContainers runs the given operation on the containers with the given
+//ids, once the user confirms the order in which they are operated on.
+//onConfirm is called once the user confirms, gone is shown if none of the
+//containers is found.
+func (h *containersScreenEventHandler) runOnContainers(op orderedOperation, ids []string, action, gone string, onConfirm func(), f func(eventHandler)) {
+	dry := h.dry
+	var inspected []types.ContainerJSON
+	for _, id := range ids {
+		//containers removed in the meantime are left out
+		if c, err := dry.dockerDaemon.Inspect(id); err == nil {
+			inspected = append(inspected, c)
+		}
+	}
+	graph := docker.NewContainerGraph(inspected)
+	var found []string
+	for _, c := range inspected {
+		found = append(found, c.ID)
+	}
+	order := op.order(graph, found)
+	if order.Len() == 0 {
+		dry.appmessage(gone)
+		return
+	}
+	question := fmt.Sprintf("Do you want to %s the following containers? Containers with the same number go in parallel", op.verb)
+	dry.confirm(action, question, orderTargets(graph, order), h, f, func() {
+		if len(order.Cycle) > 0 {
+			dry.apperror(fmt.Sprintf("<red>Dependency cycle between</> %s, they go one by one in no particular order",
+				strings.Join(containerNames(graph, order.Cycle), ", ")))
+		}
+		onConfirm()
+		dry.runJob(fmt.Sprintf("%s %d containers", op.title, order.Len()), true,
+			runInOrder(dry, op, graph, order))
+	})
+}
+
+//orderTargets describes the given order on a confirmation, each container is
+//numbered after its stage
+func orderTargets(graph *docker.ContainerGraph, order docker.OperationOrder) []string {
+	var targets []string
+	for i, stage := range order.Stages {
+		for _, id := range stage {
+			targets = append(targets, fmt.Sprintf("%d. %s", i+1, graph.Name(id)))
+		}
+	}
+	for _, id := range order.Cycle {
+		targets = append(targets, fmt.Sprintf("?. %s (dependency cycle, any order)", graph.Name(id)))
+	}
+	return targets
+}
+
+//runInOrder returns a job that runs the given operation on the containers of
+//the given order, a stage after the other and the containers of a stage in
+//parallel. Containers on a dependency cycle go last, one by one. The result of
+//every container is notified.
+func runInOrder(dry *Dry, op orderedOperation, graph *docker.ContainerGraph, order docker.OperationOrder) jobFunc {
+	return func(ctx context.Context, progress func(float64)) (string, error) {
+		stages := order.Stages
+		for _, id := range order.Cycle {
+			stages = append(stages, []string{id})
+		}
+		total := order.Len()
+		var done, failed int32
+		for _, stage := range stages {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			var wg sync.WaitGroup
+			for _, id := range stage {
+				wg.Add(1)
+				go func(id string) {
+					defer wg.Done()
+					name := graph.Name(id)
+					if err := op.run(dry.dockerDaemon, id); err != nil {
+						atomic.AddInt32(&failed, 1)
+						dry.apperror(fmt.Sprintf("<red>Could not %s %s:</> %s", op.verb, name, err.Error()))
+					} else {
+						dry.appsuccess(fmt.Sprintf("<white>Container %s %s</>", name, op.done))
+					}
+					progress(float64(atomic.AddInt32(&done, 1)) / float64(total))
+				}(id)
+			}
+			wg.Wait()
+		}
+		if failed > 0 {
+			return "", fmt.Errorf("%d of %d containers could not be %s", failed, total, op.done)
+		}
+		return fmt.Sprintf("<white>%d containers %s</>", total, op.done), nil
+	}
+}
+
+//containerNames returns the names of the given containers
+func containerNames(graph *docker.ContainerGraph, ids []string) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = graph.Name(id)
+	}
+	return names
+}
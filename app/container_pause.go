@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"fmt"
+)
+
+//pauseContainer pauses the container with the given id in the background,
+//or unpauses it if pause is false. done, if not nil, is called once the
+//container is paused or unpaused.
+func (d *Dry) pauseContainer(id string, pause bool, done func()) {
+	name := d.containerName(id)
+	operation, result := "Pause", "paused"
+	call := d.dockerDaemon.PauseContainer
+	if !pause {
+		operation, result = "Unpause", "unpaused"
+		call = d.dockerDaemon.UnpauseContainer
+	}
+	d.runJob(fmt.Sprintf("%s container %s", operation, id), false,
+		func(ctx context.Context, progress func(float64)) (string, error) {
+			if err := call(id); err != nil {
+				return "", err
+			}
+			if done != nil {
+				done()
+			}
+			return fmt.Sprintf("<white>Container %s %s</>", name, result), nil
+		})
+}
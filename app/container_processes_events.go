@@ -0,0 +1,35 @@
+package app
+
+import (
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type containerProcessesEventHandler struct {
+	baseEventHandler
+	widget *appui.ContainerProcessesWidget
+}
+
+func (h *containerProcessesEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		widgets.ContainerList.Select(h.widget.ContainerID())
+		h.dry.ViewMode(Main)
+		f(viewsToHandlers[Main])
+		refreshScreen()
+	case termbox.KeyF1:
+		h.widget.Sort()
+		refreshScreen()
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
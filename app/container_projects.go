@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+//toggleProjectGrouping groups the container list by compose project, or
+//lists the containers all together again
+func (h *containersScreenEventHandler) toggleProjectGrouping() {
+	h.screen.Cursor.Reset()
+	if widgets.ContainerList.ToggleGrouping() {
+		h.dry.appmessage("Containers grouped by compose project")
+	} else {
+		h.dry.appmessage("Containers no longer grouped")
+	}
+	refreshScreen()
+}
+
+//handleProjectKey handles the keys that act on the compose project whose
+//header is on the cursor, the list being grouped by project. It returns
+//false if the cursor is on a container or the key is not handled.
+func (h *containersScreenEventHandler) handleProjectKey(event termbox.Event, f func(eventHandler)) bool {
+	project, ok := widgets.ContainerList.SelectedProject()
+	if !ok {
+		return false
+	}
+	switch {
+	case event.Key == termbox.KeyEnter:
+		widgets.ContainerList.ToggleExpanded()
+		refreshScreen()
+	case event.Key == termbox.KeyCtrlR:
+		h.runOnProject(restartMarked, project, confirmProjectRestart, f)
+	case event.Key == termbox.KeyCtrlT:
+		h.runOnProject(stopMarked, project, confirmProjectStop, f)
+	case event.Ch == 'e' || event.Ch == 'E':
+		h.runOnProject(removeOrdered, project, confirmProjectRm, f)
+	default:
+		return false
+	}
+	return true
+}
+
+//runOnProject runs the given operation on the listed containers of the given
+//compose project, in the order given by their dependencies
+func (h *containersScreenEventHandler) runOnProject(op orderedOperation, project, action string, f func(eventHandler)) {
+	ids := widgets.ContainerList.ProjectContainers(project)
+	gone := fmt.Sprintf("The containers of %s are gone", projectTitle(project))
+	h.runOnContainers(op, ids, action, gone, func() {}, f)
+}
+
+//projectTitle returns how the given compose project is named on messages
+func projectTitle(project string) string {
+	if project == "" {
+		return "the containers without compose project"
+	}
+	return "compose project " + project
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+//pruneContainers asks the user to prune the stopped containers, as docker
+//container prune does, telling how many they are and how much space is
+//reclaimed. Adding up the sizes of the containers takes a while, so it is
+//asked in the background.
+func (h *containersScreenEventHandler) pruneContainers(f func(eventHandler)) {
+	dry := h.dry
+	stopped := dry.dockerDaemon.Containers(
+		[]docker.ContainerFilter{docker.ContainerFilters.NotRunning()}, docker.NoSort)
+	if len(stopped) == 0 {
+		dry.appmessage("There are no stopped containers")
+		return
+	}
+	dry.appmessage("Adding up the size of the stopped containers")
+	go func() {
+		question := fmt.Sprintf("Do you want to prune %d stopped containers?", len(stopped))
+		if sizes, err := dry.dockerDaemon.ContainerSizes(); err == nil {
+			question = fmt.Sprintf("Do you want to prune %d stopped containers, reclaiming %s?",
+				len(stopped), units.HumanSize(float64(docker.ReclaimableSize(stopped, sizes))))
+		}
+		targets := make([]string, len(stopped))
+		for i, c := range stopped {
+			targets[i] = containerTarget(c)
+		}
+		dry.confirm(confirmContainerPrune, question, targets, h, f, func() {
+			dry.runJob("Prune containers", false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					report, err := dry.dockerDaemon.PruneContainers()
+					if err != nil {
+						return "", err
+					}
+					widgets.ContainerList.Unmount()
+					return fmt.Sprintf("Pruned %d containers, %s reclaimed",
+						len(report.ContainersDeleted), units.HumanSize(float64(report.SpaceReclaimed))), nil
+				})
+		})
+	}()
+}
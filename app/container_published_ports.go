@@ -0,0 +1,62 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//openPublishedPort opens with the browser the URL of a port the given
+//container publishes, the user picks the port if there are many. If no
+//browser can be opened the URL is copied to the clipboard instead.
+func openPublishedPort(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	name := dry.containerName(c.ID)
+	dockerHost := ""
+	if env := dry.dockerDaemon.DockerEnv(); env != nil {
+		dockerHost = env.DockerHost
+	}
+	urls := docker.PublishedURLs(c, dockerHost)
+	switch len(urls) {
+	case 0:
+		dry.appmessage(fmt.Sprintf("Container %s publishes no TCP port", name))
+		return
+	case 1:
+		go openPublishedURL(dry, urls[0])
+		return
+	}
+	chooser := appui.NewChoicePrompt(" Open ", urls, "")
+	widgets.add(chooser)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		chooser.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(chooser)
+		f(h)
+		refreshScreen()
+		if url, canceled := chooser.Choice(); !canceled {
+			openPublishedURL(dry, url)
+		}
+	}()
+}
+
+//openPublishedURL opens the given URL with the browser or, if there is no
+//browser, copies it to the clipboard
+func openPublishedURL(dry *Dry, url string) {
+	err := openURL(url)
+	if err == nil {
+		dry.appsuccess(fmt.Sprintf("Opening %s", url))
+		return
+	}
+	if cerr := copyToClipboard(url); cerr != nil {
+		dry.apperror(fmt.Sprintf("Could not open %s: %s", url, err.Error()))
+		return
+	}
+	dry.appmessage(fmt.Sprintf("Could not open a browser, %s was copied to the clipboard", url))
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+)
+
+//recreateSteps is the most steps recreating a container takes: inspect, pull,
+//stop, remove, create and start
+const recreateSteps = 6
+
+//recreateContainer asks whether the image of the given container must be pulled
+//first and recreates the container in the background
+func (h *containersScreenEventHandler) recreateContainer(c *docker.Container, f func(eventHandler)) {
+	dry := h.dry
+	dry.confirm(confirmContainerRecreate, "Do you want to recreate the following container?", []string{containerTarget(c)}, h, f, func() {
+		prompt := appui.NewPrompt(
+			fmt.Sprintf("Pull the latest version of image %s first? (y/N)", c.Image))
+		widgets.add(prompt)
+		forwarder := newEventForwarder()
+		f(forwarder)
+		refreshScreen()
+
+		go func() {
+			prompt.OnFocus(newEventSource(forwarder.events()))
+			answer, canceled := prompt.Text()
+			f(h)
+			widgets.remove(prompt)
+			if canceled {
+				return
+			}
+			pull := answer == "y" || answer == "Y"
+			name := formatter.NewContainerFormatter(c, true).Names()
+			dry.runJob(fmt.Sprintf("Recreate container %s", name), true,
+				recreateContainer(dry, c.ID, name, pull))
+		}()
+	})
+}
+
+//recreateContainer returns the job that recreates the container with the given
+//id, each step is reported on the notification area
+func recreateContainer(dry *Dry, id, name string, pull bool) jobFunc {
+	return func(ctx context.Context, progress func(float64)) (string, error) {
+		steps := 0
+		newID, err := dry.dockerDaemon.RecreateContainer(ctx, id, pull, func(step string) {
+			dry.appmessage(step)
+			if steps < recreateSteps {
+				steps++
+			}
+			progress(float64(steps) / recreateSteps)
+		})
+		if newID != "" {
+			widgets.ContainerList.Unmount()
+			widgets.ContainerList.Select(newID)
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("<white>Container %s recreated</>", name), nil
+	}
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//removeVolumesKey turns on and off removing the anonymous volumes of a
+//container along with it, as docker rm -v does
+const removeVolumesKey = 'v'
+
+//removeContainer removes the given container once the user confirms it.
+//If the container has anonymous volumes, the user is warned that they are
+//left behind and can choose to remove them too. onRemoved, if given, runs
+//once the container is removed.
+func removeContainer(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container, onRemoved func()) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	id := c.ID
+	question := "Do you want to remove the following container?"
+	volumes := docker.AnonymousVolumes(c, nil)
+	if len(volumes) == 0 {
+		dry.confirm(confirmContainerRm, question, []string{containerTarget(c)}, h, f, func() {
+			dry.runJob(fmt.Sprintf("Remove container %s", id), false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					if err := dry.dockerDaemon.Rm(id); err != nil {
+						return "", err
+					}
+					if onRemoved != nil {
+						onRemoved()
+					}
+					return fmt.Sprintf("<white>Container with id %s removed</>", id), nil
+				})
+		})
+		return
+	}
+	//sizes are shown if the daemon can tell them
+	if du, err := dry.dockerDaemon.DiskUsage(); err == nil {
+		volumes = docker.AnonymousVolumes(c, &du)
+	}
+	targets := []string{containerTarget(c)}
+	var names []string
+	for _, v := range volumes {
+		targets = append(targets, volumeTarget(v))
+		names = append(names, v.Name)
+	}
+	confirmation := appui.NewConfirmation(
+		fmt.Sprintf("%s Its %d anonymous volumes are left behind unless removed too", question, len(volumes)),
+		targets)
+	confirmation.SetToggle(removeVolumesKey, "Remove its anonymous volumes too (docker rm -v), named volumes are kept")
+
+	//the warning is shown even if confirmations are disabled
+	dry.askWith(confirmContainerRmVolumes, confirmation, h, f, func() {
+		withVolumes := confirmation.Toggled()
+		dry.runJob(fmt.Sprintf("Remove container %s", id), false,
+			func(ctx context.Context, progress func(float64)) (string, error) {
+				if !withVolumes {
+					if err := dry.dockerDaemon.Rm(id); err != nil {
+						return "", err
+					}
+					if onRemoved != nil {
+						onRemoved()
+					}
+					return fmt.Sprintf("<white>Container with id %s removed, %d anonymous volumes left behind</>", id, len(volumes)), nil
+				}
+				removed, err := dry.dockerDaemon.RmWithVolumes(id, names)
+				if err != nil {
+					return "", err
+				}
+				if onRemoved != nil {
+					onRemoved()
+				}
+				if removed > 0 && widgets.DiskUsage.Loaded() {
+					if du, err := dry.dockerDaemon.DiskUsage(); err == nil {
+						widgets.DiskUsage.PrepareToRender(&du, nil)
+					}
+				}
+				return fmt.Sprintf("<white>Container with id %s removed along with %d of its %d anonymous volumes</>", id, removed, len(volumes)), nil
+			})
+	})
+}
+
+//volumeTarget describes the given anonymous volume on a confirmation
+func volumeTarget(v docker.AnonymousVolume) string {
+	name := v.Name
+	if len(name) > 12 {
+		name = name[:12]
+	}
+	if v.Size < 0 {
+		return fmt.Sprintf("anonymous volume %s", name)
+	}
+	return fmt.Sprintf("anonymous volume %s (%s)", name, units.HumanSize(float64(v.Size)))
+}
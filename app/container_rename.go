@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//renameContainer asks for a new name for the given container, the prompt
+//starts with its current name. The container list shows the new name right
+//away, onRenamed, if not nil, is called once the container is renamed.
+func renameContainer(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container, onRenamed func()) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	current := dry.containerName(c.ID)
+	prompt := appui.NewPromptWithText("New name of the container", current)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		name, canceled := prompt.Text()
+		f(h)
+		name = strings.TrimSpace(name)
+		if canceled || name == "" || name == current {
+			refreshScreen()
+			return
+		}
+		updated, err := dry.dockerDaemon.RenameContainer(c.ID, name)
+		if err != nil {
+			dry.apperror(fmt.Sprintf("Error renaming %s: %s", current, err.Error()))
+			return
+		}
+		if updated != nil {
+			widgets.ContainerList.UpdateContainer(updated)
+		}
+		if onRenamed != nil {
+			onRenamed()
+		}
+		dry.appsuccess(fmt.Sprintf("Container %s renamed to %s", current, strings.TrimPrefix(name, "/")))
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+)
+
+//editRestartPolicy lets the user pick a new restart policy for the given
+//container, on-failure policies also ask for the maximum retry count.
+//onUpdated, if not nil, is called once the container is updated.
+func editRestartPolicy(dry *Dry, h eventHandler, f func(eventHandler), c *docker.Container, onUpdated func()) {
+	if c == nil {
+		dry.apperror("Container not found")
+		return
+	}
+	current := docker.RestartPolicy(c)
+	chooser := appui.NewChoicePrompt(" Restart policy ", docker.RestartPolicies, current.Name)
+	widgets.add(chooser)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		chooser.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(chooser)
+		policy, canceled := chooser.Choice()
+		if canceled {
+			f(h)
+			refreshScreen()
+			return
+		}
+		if policy == "on-failure" {
+			prompt := appui.NewPromptWithText(
+				"Maximum retry count (0 for unlimited)", strconv.Itoa(current.MaximumRetryCount))
+			widgets.add(prompt)
+			refreshScreen()
+			prompt.OnFocus(newEventSource(forwarder.events()))
+			widgets.remove(prompt)
+			count, canceled := prompt.Text()
+			if canceled {
+				f(h)
+				refreshScreen()
+				return
+			}
+			if n, err := strconv.Atoi(count); count != "" && (err != nil || n < 0) {
+				f(h)
+				dry.apperror(fmt.Sprintf("Invalid maximum retry count: %s", count))
+				return
+			}
+			if count != "" && count != "0" {
+				policy += ":" + count
+			}
+		}
+		f(h)
+		name := formatter.NewContainerFormatter(c, true).Names()
+		updated, err := dry.dockerDaemon.UpdateRestartPolicy(c.ID, policy)
+		if err != nil {
+			dry.apperror(fmt.Sprintf("Error changing the restart policy of %s: %s", name, err.Error()))
+			return
+		}
+		widgets.ContainerList.UpdateContainer(updated)
+		if onUpdated != nil {
+			onUpdated()
+		}
+		dry.appsuccess(fmt.Sprintf("Restart policy of %s set to %s", name,
+			docker.FormatRestartPolicy(docker.RestartPolicy(updated))))
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//runContainer shows the container run wizard pre-filled with the given options.
+//Once the user is done with it the container is created and started, if the
+//daemon rejects the options the wizard is shown again on the failing field.
+//The container list is shown with the new container selected.
+func runContainer(dry *Dry, h eventHandler, f func(eventHandler), options docker.RunOptions) {
+	wizard := appui.NewContainerRunWizard(
+		options, imageNames(dry.dockerDaemon), networkNames(dry.dockerDaemon))
+	widgets.add(wizard)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		for {
+			wizard.OnFocus(newEventSource(forwarder.events()))
+			if wizard.Canceled() {
+				break
+			}
+			options := wizard.Options()
+			id, err := dry.dockerDaemon.RunContainer(options)
+			if err == nil {
+				widgets.remove(wizard)
+				containerRun(dry, f, id, options, forwarder.events())
+				return
+			}
+			if id != "" {
+				//created but not started, running it again would create another one
+				widgets.remove(wizard)
+				showContainer(dry, f, id)
+				dry.apperror(
+					fmt.Sprintf("Container %s was created but could not be started: %s", docker.TruncateID(id), err.Error()))
+				refreshScreen()
+				return
+			}
+			field := ""
+			if runErr, ok := err.(*docker.RunOptionError); ok {
+				field = runErr.Field
+				err = runErr.Err
+			}
+			wizard.SetError(field, err)
+			refreshScreen()
+		}
+		widgets.remove(wizard)
+		f(h)
+		refreshScreen()
+	}()
+}
+
+//containerRun moves the cursor to the row of the container that was just run
+//or, for interactive runs, streams its output
+func containerRun(dry *Dry, f func(eventHandler), id string, options docker.RunOptions, events chan termbox.Event) {
+	if !options.Interactive {
+		showContainer(dry, f, id)
+		dry.appsuccess(fmt.Sprintf("Container %s is running", docker.TruncateID(id)))
+		refreshScreen()
+		return
+	}
+	logs, err := dry.dockerDaemon.Logs(id, "", false)
+	if err != nil {
+		showContainer(dry, f, id)
+		dry.apperror("Error showing container logs: " + err.Error())
+		refreshScreen()
+		return
+	}
+	appui.Stream(logs, events, func() {
+		showContainer(dry, f, id)
+		refreshScreen()
+	})
+}
+
+//showContainer shows the container list, from whatever view, with the
+//container with the given id selected
+func showContainer(dry *Dry, f func(eventHandler), id string) {
+	widgets.ContainerList.Unmount()
+	widgets.ContainerList.Select(id)
+	if dry.viewMode() != Main {
+		ui.ActiveScreen.Cursor.Reset()
+		dry.ViewMode(Main)
+	}
+	f(viewsToHandlers[Main])
+}
+
+//imageNames returns the tags of the local images
+func imageNames(daemon docker.ImageAPI) []string {
+	images, err := daemon.Images()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			if tag != "<none>:<none>" {
+				names = append(names, tag)
+			}
+		}
+	}
+	return names
+}
+
+//imageReference returns how the given image is referred to when run, its
+//first tag or, for untagged images, its id
+func imageReference(image types.ImageSummary) string {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return tag
+		}
+	}
+	return image.ID
+}
+
+//networkNames returns the names of the networks
+func networkNames(daemon docker.NetworkAPI) []string {
+	networks, err := daemon.Networks()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(networks))
+	for i, network := range networks {
+		names[i] = network.Name
+	}
+	return names
+}
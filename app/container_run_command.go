@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//What can be done with the docker run command of a container
+const (
+	showRunCommand = "Show"
+	copyRunCommand = "Copy to clipboard"
+	saveRunCommand = "Save to file"
+)
+
+//runCommand asks what to do with the docker run command that creates a
+//container like the one with the given id, and does it
+func (h *containersScreenEventHandler) runCommand(id string, f func(eventHandler)) error {
+	inspected, err := h.dry.dockerDaemon.Inspect(id)
+	if err != nil {
+		return err
+	}
+	//without the image every value is on the command, not only those that override it
+	var image *types.ImageInspect
+	if i, err := h.dry.dockerDaemon.InspectImage(inspected.Image); err == nil {
+		image = &i
+	}
+	command := docker.RunCommand(inspected, image)
+	name := strings.TrimPrefix(inspected.Name, "/")
+	if name == "" {
+		name = docker.TruncateID(id)
+	}
+
+	choices := []string{showRunCommand, copyRunCommand, saveRunCommand}
+	askChoice(h, fmt.Sprintf("docker run command of %s", name), choices, f, func(choice string) {
+		switch choice {
+		case showRunCommand:
+			h.dry.ViewMode(InfoMode)
+			forwarder := newEventForwarder()
+			f(forwarder)
+			go appui.Less(ui.StringRenderer(command), h.screen, forwarder.events(), func() {
+				h.dry.ViewMode(Main)
+				f(h)
+				refreshScreen()
+			})
+		case copyRunCommand:
+			if err := copyToClipboard(command); err != nil {
+				h.dry.apperror(fmt.Sprintf("Could not copy the docker run command of %s: %s", name, err.Error()))
+				return
+			}
+			h.dry.appsuccess(fmt.Sprintf("The docker run command of %s was copied to the clipboard", name))
+		case saveRunCommand:
+			askText(h, fmt.Sprintf("Save the command to (leave empty for %s)", runCommandFileName(name)), f, func(path string) {
+				if path == "" {
+					path = runCommandFileName(name)
+				}
+				//the command has the environment of the container, secrets included
+				if err := ioutil.WriteFile(path, []byte(command), 0600); err != nil {
+					h.dry.apperror(fmt.Sprintf("There was an error saving the docker run command: %s", err.Error()))
+					return
+				}
+				h.dry.appsuccess(fmt.Sprintf("The docker run command of %s was saved to %s", name, path))
+			})
+		}
+	})
+	return nil
+}
+
+//runCommandFileName is the name of the file the docker run command of the
+//container with the given name is saved to if the user does not give one
+func runCommandFileName(name string) string {
+	return fmt.Sprintf("run-%s.sh", name)
+}
@@ -1,16 +1,19 @@
 package app
 
 import (
-	"fmt"
+	"context"
 
-	"github.com/moncho/dry/appui"
-	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/docker"
 	termbox "github.com/nsf/termbox-go"
 )
 
-const (
-	confirmation = `WARNING! This will remove all unused data. Are you sure you want to continue? [y/N]`
-)
+//pruneTargets are the elements removed by a prune
+var pruneTargets = []string{
+	"all stopped containers",
+	"all dangling images",
+	"all networks not used by at least one container",
+	"all volumes not used by at least one container",
+}
 
 type diskUsageScreenEventHandler struct {
 	baseEventHandler
@@ -25,43 +28,31 @@ func (h *diskUsageScreenEventHandler) handle(event termbox.Event, f func(eventHa
 		handled = true
 	}
 	switch event.Ch {
-	case 'p', 'P':
+	case 'b', 'B':
 		handled = true
-
-		rw := appui.NewPrompt(confirmation)
-		widgets.add(rw)
-		forwarder := newEventForwarder()
-		f(forwarder)
+		if h.dry.unsupported(docker.BuildCacheFeature) {
+			break
+		}
+		h.screen.Cursor.Reset()
+		f(viewsToHandlers[BuildCache])
+		h.dry.ViewMode(BuildCache)
 		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			refreshScreen()
-
-			rw.OnFocus(events)
-			widgets.remove(rw)
-			confirmation, canceled := rw.Text()
-			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
-				return
-			}
+	case 'p', 'P':
+		handled = true
 
-			pr, err := h.dry.dockerDaemon.Prune()
-			if err == nil {
-				if du, err := h.dry.dockerDaemon.DiskUsage(); err == nil {
-					widgets.DiskUsage.PrepareToRender(&du, pr)
-				}
-			} else {
-				h.dry.appmessage(
-					fmt.Sprintf(
-						"<red>Error running prune. %s</>", err))
-			}
-			refreshScreen()
-		}()
+		h.dry.confirm(confirmPrune, "WARNING! This will remove all unused data:", pruneTargets, h, f, func() {
+			h.dry.runJob("Prune", false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					pr, err := h.dry.dockerDaemon.Prune()
+					if err != nil {
+						return "", err
+					}
+					if du, err := h.dry.dockerDaemon.DiskUsage(); err == nil {
+						widgets.DiskUsage.PrepareToRender(&du, pr)
+					}
+					return "Prune finished", nil
+				})
+		})
 	}
 	if !handled {
 		h.baseEventHandler.handle(event, f)
@@ -3,32 +3,66 @@ package app
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types/events"
+	"github.com/moncho/dry/appui"
 	drydocker "github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
+	log "github.com/sirupsen/logrus"
 )
 
+//statusBarRefreshRate is how often the status bar is refreshed
+const statusBarRefreshRate = 10 * time.Second
+
 //Dry represents the application.
 type Dry struct {
 	dockerDaemon     drydocker.ContainerDaemon
 	dockerEvents     <-chan events.Message
 	dockerEventsDone chan<- struct{}
-	output           chan string
+	notifications    *appui.Notifications
+	jobs             *jobQueue
+	statusBar        *appui.StatusBar
+	statusBarDone    chan struct{}
+	autoRefresher    *autoRefresher
+	questions        chan question
+
+	skippedConfirmations skippedConfirmations
+	execHistory          execHistory
+	//startup is the view dry started on, its list is created filtered
+	//and sorted as asked
+	startup startup
 
 	sync.RWMutex
-	view viewMode
+	view      viewMode
+	splitView bool
+	//showCallStats tells if the counters of the daemon calls are shown
+	showCallStats bool
+	//themeBeforeCompatibility is the name of the color theme in use before
+	//the compatibility mode was turned on
+	themeBeforeCompatibility string
+}
+
+//toggleCallStats shows or hides the counters of the list calls made to
+//the Docker daemon
+func (d *Dry) toggleCallStats() {
+	d.Lock()
+	defer d.Unlock()
+	d.showCallStats = !d.showCallStats
+}
+
+//callStatsShown returns true if the counters of the daemon calls are shown
+func (d *Dry) callStatsShown() bool {
+	d.RLock()
+	defer d.RUnlock()
+	return d.showCallStats
 }
 
 //Close closes dry, releasing any resources held by it
 func (d *Dry) Close() {
 	close(d.dockerEventsDone)
-	close(d.output)
-}
-
-//OuputChannel returns the channel where dry messages are written
-func (d *Dry) OuputChannel() <-chan string {
-	return d.output
+	close(d.statusBarDone)
+	close(d.autoRefresher.done)
 }
 
 //Ok returns the state of dry
@@ -47,26 +81,110 @@ func (d *Dry) ViewMode(v viewMode) {
 func (d *Dry) startDry() {
 	de := dockerEventsListener{d}
 	de.init()
+	go d.refreshStatusBar()
+	go d.autoRefresh()
 }
 
+//appmessage shows an informative message
 func (d *Dry) appmessage(message string) {
-	go func() {
+	d.notifications.Notify(appui.InfoNotification, message)
+}
+
+//appsuccess shows a message telling that an operation succeeded
+func (d *Dry) appsuccess(message string) {
+	d.notifications.Notify(appui.SuccessNotification, message)
+}
+
+//apperror shows a message telling that an operation failed
+func (d *Dry) apperror(message string) {
+	d.notifications.Notify(appui.ErrorNotification, message)
+}
+
+//runJob runs the given func in the background, the result is notified
+//when it finishes
+func (d *Dry) runJob(description string, cancelable bool, run jobFunc) {
+	d.jobs.submit(description, cancelable, run)
+}
+
+//onJobDone notifies the result of a finished job
+func (d *Dry) onJobDone(job appui.Job, result string) {
+	switch job.Status {
+	case appui.JobSucceeded:
+		if result != "" {
+			d.appsuccess(result)
+		}
+	case appui.JobFailed:
+		d.apperror(fmt.Sprintf("<red>%s failed:</> %s", job.Description, job.Err.Error()))
+	case appui.JobCanceled:
+		d.appmessage(fmt.Sprintf("%s canceled", job.Description))
+	}
+}
+
+//onJobsChange renders dry when the state of a job changes
+func (d *Dry) onJobsChange() {
+	if d.viewMode() == Monitor {
+		//the monitor renders itself, rendering dry would mount it again
+		return
+	}
+	d.refreshIfVisible()
+}
+
+//refreshStatusBar refreshes the status bar periodically until dry is closed
+func (d *Dry) refreshStatusBar() {
+	ticker := time.NewTicker(statusBarRefreshRate)
+	defer ticker.Stop()
+	for {
 		select {
-		case d.output <- message:
-		default:
+		case <-ticker.C:
+			if err := d.statusBar.Refresh(); err != nil {
+				log.Debugf("Error refreshing the status bar: %s", err.Error())
+			}
+			//the monitor renders itself, rendering dry would mount it again
+			if d.viewMode() != Monitor {
+				d.refreshIfVisible()
+			}
+		case <-d.statusBarDone:
+			return
 		}
-	}()
+	}
 }
 
-func (d *Dry) actionMessage(cid interface{}, action string) {
-	d.appmessage(fmt.Sprintf("<red>%s container with id </><white>%v</>",
-		action, cid))
+//refreshIfVisible renders dry, unless a pager or a view that is not
+//rendered by dry is using the screen.
+func (d *Dry) refreshIfVisible() {
+	if appui.PagerActive() || refreshScreen == nil {
+		return
+	}
+	switch d.viewMode() {
+	case EventsMode, HelpMode, InfoMode, NoView:
+		return
+	}
+	refreshScreen()
 }
 
-func (d *Dry) errorMessage(cid interface{}, action string, err error) {
-	d.appmessage(
-		fmt.Sprintf(
-			"%s", err.Error()))
+//initWidgets creates the widgets used by dry
+func (d *Dry) initWidgets() {
+	widgets = newWidgetRegistry(d.dockerDaemon, d.startup)
+	widgets.Jobs = appui.NewJobsWidget(d.jobs.list, appui.MainScreenHeaderSize)
+	appui.RenderRequest = func() {
+		//the monitor renders itself, rendering dry would mount it again
+		if d.viewMode() != Monitor {
+			d.refreshIfVisible()
+		}
+	}
+	widgets.Monitor.SetThresholds(userPreferences.MonitorThresholds)
+	if hidden, ok := userPreferences.hiddenColumns(containersList); ok {
+		widgets.ContainerList.SetHiddenColumns(hidden)
+	}
+	if hidden, ok := userPreferences.hiddenColumns(imagesList); ok {
+		widgets.ImageList.SetHiddenColumns(hidden)
+	}
+	widgets.Monitor.OnAlert = func(a appui.MonitorAlert) {
+		d.apperror(fmt.Sprintf("<red>Alert: </><white>%s %s</>", a.Container, a.Message))
+		if widgets.Monitor.Thresholds().Bell {
+			ringBell()
+		}
+	}
 }
 
 func (d *Dry) viewMode() viewMode {
@@ -75,17 +193,55 @@ func (d *Dry) viewMode() viewMode {
 	return d.view
 }
 
-func newDry(screen *ui.Screen, d *drydocker.DockerDaemon) (*Dry, error) {
+func newDry(screen *ui.Screen, d drydocker.ContainerDaemon, opts StartupOptions) (*Dry, error) {
 	dockerEvents, dockerEventsDone, err := d.Events()
 	if err != nil {
 		return nil, err
 	}
 
 	app := &Dry{}
-	widgets = newWidgetRegistry(d)
-	viewsToHandlers = initHandlers(app, screen)
+	app.notifications = appui.NewNotifications(appui.DefaultNotificationDuration, app.refreshIfVisible)
+	app.jobs = newJobQueue(defaultJobWorkers, app.onJobsChange, app.onJobDone)
+	prefs, err := loadPreferences(preferencesFile)
+	if err != nil {
+		log.Warnf("Error loading preferences from %s: %s", preferencesFile, err.Error())
+	}
+	userPreferences = prefs
+	keymap, err := newKeymap(prefs.Keymap)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", preferencesFile, err.Error())
+	}
+	activeKeymap = keymap
+	start, err := parseStartupOptions(prefs.startupOptions().override(opts))
+	if err != nil {
+		return nil, err
+	}
+	app.startup = start
+	loadColorThemes(screen, prefs)
+	if ui.CompatibilityMode() {
+		app.useCompatibleTheme(true)
+		applyColorTheme(screen)
+	}
+	appui.SetTruncationMode(prefs.truncationMode())
+	app.splitView = prefs.splitView()
+	appui.SetJSONLogFields(prefs.jsonLogFields())
+	drydocker.SetCallTimeout(prefs.daemonCallTimeout())
+	//invalid rules are reported once, they are left out of rendering
+	for _, err := range appui.SetRowRules(prefs.rowRules()) {
+		log.Warnf("%s: %s", preferencesFile, err.Error())
+		app.apperror("Row rule left out, " + err.Error())
+	}
 	app.dockerDaemon = d
-	app.output = make(chan string)
+	app.statusBar = appui.NewStatusBar(d, 0)
+	app.statusBarDone = make(chan struct{})
+	app.autoRefresher = newAutoRefresher()
+	app.questions = make(chan question)
+	if err := app.statusBar.Refresh(); err != nil {
+		log.Warnf("Error retrieving Docker information for the status bar: %s", err.Error())
+	}
+	app.initWidgets()
+	viewsToHandlers = initHandlers(app, screen)
+	app.ViewMode(app.startupView())
 	app.dockerEvents = dockerEvents
 	app.dockerEventsDone = dockerEventsDone
 	app.startDry()
@@ -93,11 +249,28 @@ func newDry(screen *ui.Screen, d *drydocker.DockerDaemon) (*Dry, error) {
 
 }
 
-//NewDry creates a new dry application
-func NewDry(screen *ui.Screen, env *drydocker.Env) (*Dry, error) {
+//NewDry creates a new dry application that starts on the view the given
+//options tell, or the one on the user preferences if they tell none
+func NewDry(screen *ui.Screen, env *drydocker.Env, opts StartupOptions) (*Dry, error) {
 	d, err := drydocker.ConnectToDaemon(env)
 	if err != nil {
 		return nil, err
 	}
-	return newDry(screen, d)
+	return newDry(screen, d, opts)
+}
+
+//startupView returns the view dry starts on, swarm views are only shown
+//once the host is part of a swarm and plugins if the daemon supports them
+func (d *Dry) startupView() viewMode {
+	switch d.startup.view {
+	case Nodes, Services, Stacks:
+		if !d.dockerDaemon.SwarmMode() {
+			return SwarmStatus
+		}
+	case Plugins:
+		if d.unsupported(drydocker.PluginsFeature) {
+			return Main
+		}
+	}
+	return d.startup.view
 }
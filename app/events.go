@@ -2,8 +2,10 @@ package app
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 	termbox "github.com/nsf/termbox-go"
 )
@@ -33,6 +35,9 @@ func (b *baseEventHandler) handle(event termbox.Event, f func(eventHandler)) {
 	case termbox.KeyArrowDown: // cursor down
 		cursor.ScrollCursorDown()
 	case termbox.KeyF8: // disk usage
+		if dry.unsupported(docker.DiskUsageFeature) {
+			break
+		}
 		f(viewsToHandlers[DiskUsage])
 		dry.ViewMode(DiskUsage)
 		if du, err := b.dry.dockerDaemon.DiskUsage(); err == nil {
@@ -52,6 +57,47 @@ func (b *baseEventHandler) handle(event termbox.Event, f func(eventHandler)) {
 			f(viewsToHandlers[view])
 			refreshScreen()
 		})
+	case termbox.KeyF7: // notifications
+		refresh = false
+		view := dry.viewMode()
+		dry.ViewMode(NoView)
+		eh := newEventForwarder()
+		f(eh)
+
+		renderer := appui.NewNotificationsRenderer(dry.notifications.History())
+
+		go appui.Less(renderer, screen, eh.events(), func() {
+			dry.ViewMode(view)
+			f(viewsToHandlers[view])
+			refreshScreen()
+		})
+	case termbox.KeyF6: // color theme
+		dry.rotateColorTheme()
+	case termbox.KeyF11: // truncation mode
+		dry.toggleTruncationMode()
+	case termbox.KeyF12: // save the scene
+		refresh = false
+		view := dry.viewMode()
+		askText(viewsToHandlers[view], "Save the scene to (leave empty for a file in the current directory)", f, func(path string) {
+			if path == "" {
+				path = sceneFileName(time.Now())
+			}
+			if err := dry.saveScene(path); err != nil {
+				dry.apperror(fmt.Sprintf("There was an error saving the scene: %s", err.Error()))
+				return
+			}
+			dry.appsuccess(fmt.Sprintf("Scene saved to %s, environment values scrubbed", path))
+		})
+	case termbox.KeyF4: // auto refresh
+		refresh = false
+		dry.askAutoRefresh(viewsToHandlers[dry.viewMode()], f)
+	case termbox.KeyCtrlO: // compatibility mode
+		dry.toggleCompatibilityMode(screen)
+	case termbox.KeyCtrlB: // daemon calls overlay
+		dry.toggleCallStats()
+	case termbox.KeyCtrlX: // dismiss notification
+		dry.notifications.Dismiss()
+		refresh = false
 	case termbox.KeyF10: // docker info
 		refresh = false
 
@@ -71,19 +117,23 @@ func (b *baseEventHandler) handle(event termbox.Event, f func(eventHandler)) {
 				refreshScreen()
 			})
 		} else {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf(
 					"There was an error retrieving Docker information: %s", err.Error()))
 		}
 	}
 	switch event.Ch {
-	case '?', 'h', 'H': //help
+	case '?': //keys of the current view
+		refresh = false
+		showViewKeys(dry, viewsToHandlers[dry.viewMode()], f)
+	case 'h', 'H': //help
 		refresh = false
 
 		view := dry.viewMode()
+		dry.ViewMode(HelpMode)
 		eh := newEventForwarder()
 		f(eh)
-		go appui.Less(ui.StringRenderer(help), screen, eh.events(), func() {
+		go appui.Less(ui.StringRenderer(helpText(activeKeymap)), screen, eh.events(), func() {
 			dry.ViewMode(view)
 			f(viewsToHandlers[view])
 			refreshScreen()
@@ -100,22 +150,49 @@ func (b *baseEventHandler) handle(event termbox.Event, f func(eventHandler)) {
 		cursor.Reset()
 		f(viewsToHandlers[Networks])
 		dry.ViewMode(Networks)
-	case '4':
+	case '4', '5', '6':
+		cursor.Reset()
+		view := Nodes
+		switch {
+		case !dry.dockerDaemon.SwarmMode():
+			//swarm views are only useful once the host is part of a swarm
+			view = SwarmStatus
+		case event.Ch == '5':
+			widgets.ServiceList.ForStack("")
+			view = Services
+		case event.Ch == '6':
+			view = Stacks
+		}
+		f(viewsToHandlers[view])
+		dry.ViewMode(view)
+	case '7':
 		cursor.Reset()
-		f(viewsToHandlers[Nodes])
-		dry.ViewMode(Nodes)
-	case '5':
+		f(viewsToHandlers[Jobs])
+		dry.ViewMode(Jobs)
+	case '8':
+		if dry.unsupported(docker.PluginsFeature) {
+			break
+		}
 		cursor.Reset()
-		f(viewsToHandlers[Services])
-		dry.ViewMode(Services)
-	case '6':
+		f(viewsToHandlers[Plugins])
+		dry.ViewMode(Plugins)
+	case '9':
 		cursor.Reset()
-		f(viewsToHandlers[Stacks])
-		dry.ViewMode(Stacks)
+		h := viewsToHandlers[RegistrySearch].(*registrySearchEventHandler)
+		f(h)
+		dry.ViewMode(RegistrySearch)
+		if widgets.RegistrySearch.Term() == "" {
+			//there is nothing to show until something is searched
+			refresh = false
+			h.search(f)
+		}
 	case 'm', 'M': //monitor mode
 		cursor.Reset()
 		f(viewsToHandlers[Monitor])
 		dry.ViewMode(Monitor)
+	case ':': //command palette
+		refresh = false
+		showCommandPalette(dry, viewsToHandlers[dry.viewMode()], f)
 	case 'g': //Cursor to the top
 		cursor.Reset()
 	case 'G': //Cursor to the bottom
@@ -149,6 +226,20 @@ func initHandlers(dry *Dry, screen *ui.Screen) map[viewMode]eventHandler {
 			},
 			widgets.Networks,
 		},
+		Plugins: &pluginsScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.Plugins,
+		},
+		BuildCache: &buildCacheScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.BuildCache,
+		},
 		DiskUsage: &diskUsageScreenEventHandler{
 			baseEventHandler{
 				dry:    dry,
@@ -162,6 +253,90 @@ func initHandlers(dry *Dry, screen *ui.Screen) map[viewMode]eventHandler {
 			},
 			widgets.ContainerList,
 		},
+		ContainerGraph: &containerGraphEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ContainerGraph,
+		},
+		ContainerEnv: &containerEnvEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ContainerEnv,
+		},
+		ContainerMounts: &containerMountsEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ContainerMounts,
+		},
+		ImageLayer: &imageLayerEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ImageLayer,
+		},
+		ImageHistory: &imageHistoryEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ImageHistory,
+		},
+		ImageScan: &imageScanEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ImageScan,
+		},
+		ImageManifest: &imageManifestEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ImageManifest,
+		},
+		ImageSizes: &imageSizesEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ImageSizes,
+		},
+		RegistrySearch: &registrySearchEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.RegistrySearch,
+		},
+		ContainerHealth: &containerHealthEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ContainerHealth,
+		},
+		ContainerProcesses: &containerProcessesEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.ContainerProcs,
+		},
+		Jobs: &jobsScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.Jobs,
+		},
 		Monitor: &monitorScreenEventHandler{
 			baseEventHandler: baseEventHandler{
 				dry:    dry,
@@ -211,6 +386,13 @@ func initHandlers(dry *Dry, screen *ui.Screen) map[viewMode]eventHandler {
 			},
 			widgets.StackTasks,
 		},
+		SwarmStatus: &swarmScreenEventHandler{
+			baseEventHandler{
+				dry:    dry,
+				screen: screen,
+			},
+			widgets.SwarmStatus,
+		},
 	}
 
 }
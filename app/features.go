@@ -0,0 +1,26 @@
+package app
+
+import (
+	"github.com/moncho/dry/docker"
+)
+
+//actionFeatures has the Docker API feature that the actions that need one
+//depend on, these actions are not run if the daemon does not support it
+var actionFeatures = map[string]docker.Feature{
+	"showDiskUsage":                 docker.DiskUsageFeature,
+	"showPlugins":                   docker.PluginsFeature,
+	"showServiceLogs":               docker.ServiceLogsFeature,
+	"showServiceLogsWithTimestamps": docker.ServiceLogsFeature,
+	"showBuildCache":                docker.BuildCacheFeature,
+	"pruneBuildCache":               docker.BuildCacheFeature,
+}
+
+//unsupported returns true, and tells the user why, if the API version
+//used with the Docker daemon does not support the given feature
+func (d *Dry) unsupported(f docker.Feature) bool {
+	if d.dockerDaemon.Supports(f) {
+		return false
+	}
+	d.appmessage(docker.FeatureError{Feature: f, APIVersion: d.dockerDaemon.APIVersion()}.Error())
+	return true
+}
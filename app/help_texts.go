@@ -13,73 +13,22 @@ dry
 A tool to interact with a Docker Daemon from the terminal. 
 `
 
-var help = `
+//helpText returns the text of the help screen, the keybinds are
+//generated from the given keymap
+func helpText(k *keymap) string {
+	return `
 <white>dry ` + fmt.Sprintf("version %s, build %s", version.VERSION, version.GITCOMMIT) + `</>` +
-	`
+		`
 A tool to interact with a Docker Daemon from the terminal. 
 
 Visit <blue>http://moncho.github.io/dry/</> for more information.
 
-<yellow>Global keybinds</>
-	<white>F8</>        Shows Docker disk usage
-	<white>F9</>        Shows the last 10 events reported by Docker
-	<white>F10</>       Inspects Docker
-	<white>1</>         To container list
-	<white>2</>         To image list
-	<white>3</>         To network list
-	<white>4</>         To node list (in Swarm mode)
-	<white>5</>         To service list (in Swarm mode)
-	<white>6</>         To stack list (in Swarm mode)
-	<white>m</>         Show container monitor mode
-	<white>h</>         Shows this help screen
-	<white>Ctrl+c</>    Quits <white>dry</> immediately
-	<white>q</>         Quits <white>dry</>
-	<white>esc</>       Goes back to the main screen
-
-<yellow>Global list keybinds</>	
-	<white>F1</>        Cycles through sort modes
-	<white>F5</>        Refreshes the list
-	<white>%</>         Filter
-
-<yellow>Container list keybinds</>
-	<white>F2</>        Toggles showing all containers (default shows just running)
-	<white>e</>         Removes the selected container
-	<white>Ctrl+e</>    Removes all stopped containers
-	<white>Ctrl+k</>    Kills the selected container
-	<white>l</>         Displays the logs of the selected container
-	<white>Ctrl+r</>    Restarts selected container
-	<white>s</>         Displays a live stream of the selected container resource usage statistics
-	<white>Ctrl+t</>    Stops selected container (noop if it is not running)
-	<white>Enter</>     Returns low-level information of the selected container
-
-<yellow>Image list keybinds</>
-	<white>Ctrl+e</>    Removes the selected image
-	<white>Ctrl+f</>    Forces removal of the selected image
-	<white>i</>         Shows image history
-	<white>Enter</>     Returns low-level information of the selected image
-
-<yellow>Network list keybinds</>
-	<white>Enter</>     Returns low-level information of the selected network
-
-<yellow>Node list keybinds</>
-	<white>Enter</>     Shows the list of tasks running on the selected node
-
-<yellow>Service list keybinds</>
-	<white>Enter</>     Shows the list of tasks that are part of the selected service
-	<white>l</>         Displays the logs of the selected service
-	<white>Ctrl+R</>    Removes the selected service
-	<white>Ctrl+S</>    Scales the selected service
-	<white>Ctrl+U</>    Forces an update of the selected service
-
-<yellow>Stack list keybinds</>
-	<white>Enter</>     Shows the list of services of the selected stack
-	<white>Ctrl+R</>    Removes the selected stack
-	
-<yellow>Move around in lists</>
-	<white>ArrowUp</>   Moves the cursor one line up
-	<white>ArrowDown</> Moves the cursor one line down
-	<white>g</>         Moves the cursor to the beginning of the list
-	<white>G</>         Moves the cursor to the end of the list
+` + k.help() + `<yellow>Confirmations</>
+	<white>y/Enter</>   Confirms the action
+	<white>a</>         Confirms the action and does not ask again during this session
+	<white>n/esc</>     Cancels the action
+	<white>v</>         Removing a container, also removes its anonymous volumes
+	Confirmations can be disabled with "confirmations": false in ~/.dry/preferences.json
 
 <yellow>Move around in logs/inspect buffers</>
 	<white>/</>         Searches for a pattern
@@ -88,43 +37,78 @@ Visit <blue>http://moncho.github.io/dry/</> for more information.
 	<white>G</>         Moves the cursor until the end
 	<white>n</>         After a search, it moves forwards to the next search hit
 	<white>N</>         After a search, it moves backwards to the previous search hit
+	<white>j</>         On logs, shows JSON lines as columns or as they were logged
+	<white>=</>         On logs, only shows JSON lines whose fields match, e.g. level=error
+	<white>t</>         On logs with timestamps, shows them in UTC, in local time or as ages
 	<white>pg up</>     Moves the cursor "screen size" lines up
 	<white>pg down</>   Moves the cursor "screen size" lines down
 
 <r> Press ESC to exit help. </r>
 `
+}
 
+//Footer key mappings, {action} references are replaced by the key bound to the action
 const (
-	commonMappings = "<b>[H]:<darkgrey>Help</> <b>[Q]:<darkgrey>Quit</> <blue>|</> "
+	commonMappings = "<b>[{showHelp}]:<darkgrey>Help</> <b>[{showViewKeys}]:<darkgrey>Keys</> <b>[{showCommandPalette}]:<darkgrey>Palette</> <b>[Q]:<darkgrey>Quit</> <blue>|</> "
 	keyMappings    = commonMappings +
-		"<b>[F1]:<darkgrey>Sort</> <b>[F2]:<darkgrey>Toggle Show Containers</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> " +
-		"<b>[m]:<darkgrey>Monitor mode</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Nodes</> <b>[5]:<darkgrey>Services</> <b>[6]:<darkgrey>Stacks</> <blue>|</> <b>[Enter]:<darkgrey>Commands</></>"
+		"<b>[{sortContainers}]:<darkgrey>Sort</> <b>[{reverseSortContainers}]:<darkgrey>Reverse</> <b>[{toggleShowAll}]:<darkgrey>Toggle Show Containers</> <b>[{refreshContainers}]:<darkgrey>Refresh</> <b>[{filterContainers}]:<darkgrey>Filter</> <b>[{searchContainers}]:<darkgrey>Search</> <b>[{runContainer}]:<darkgrey>Run</> <blue>|</> " +
+		"<b>[{showMonitor}]:<darkgrey>Monitor mode</> <b>[{showImages}]:<darkgrey>Images</> <b>[{showNetworks}]:<darkgrey>Networks</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</> <blue>|</> <b>[{showContainerMenu}]:<darkgrey>Commands</></>"
 
 	monitorMapping = commonMappings +
-		"<b>[m]:<darkgrey>Monitor mode</> <b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Nodes</> <b>[5]:<darkgrey>Services</> <b>[6]:<darkgrey>Stacks</>"
+		"<b>[{sortMonitor}]:<darkgrey>Sort</> <b>[{increaseRefreshRate}/{decreaseRefreshRate}]:<darkgrey>Refresh rate</> <b>[{togglePause}]:<darkgrey>Pause</> <b>[{editThresholds}]:<darkgrey>Thresholds</> <b>[{showAlerts}]:<darkgrey>Alerts</> <b>[{toggleNetworkTraffic}]:<darkgrey>Traffic</> <b>[{toggleStatsRecording}]:<darkgrey>Record</> <b>[{exportStats}]:<darkgrey>Export</> <blue>|</> " +
+		"<b>[{showMonitor}]:<darkgrey>Monitor mode</> <b>[{showContainers}]:<darkgrey>Containers</> <b>[{showImages}]:<darkgrey>Images</> <b>[{showNetworks}]:<darkgrey>Networks</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</>"
 
 	swarmMapping = commonMappings +
-		"<b>[m]:<darkgrey>Monitor mode</> <b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Nodes</> <b>[5]:<darkgrey>Services</> <b>[6]:<darkgrey>Stacks</>"
+		"<b>[{showMonitor}]:<darkgrey>Monitor mode</> <b>[{showContainers}]:<darkgrey>Containers</> <b>[{showImages}]:<darkgrey>Images</> <b>[{showNetworks}]:<darkgrey>Networks</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</>"
 
 	imagesKeyMappings = commonMappings +
-		"<b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> " +
-		"<b>[1]:<darkgrey>Containers</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Nodes</> <b>[5]:<darkgrey>Services</> <b>[6]:<darkgrey>Stacks</> <blue>|</>" +
-		"<b>[Ctrl+D]:<darkgrey>Remove Dangling</> <b>[Ctrl+E]:<darkgrey>Remove</> <b>[Ctrl+F]:<darkgrey>Force Remove</> <b>[I]:<darkgrey>History</>"
+		"<b>[{sortImages}]:<darkgrey>Sort</> <b>[{reverseSortImages}]:<darkgrey>Reverse</> <b>[{toggleDanglingImages}]:<darkgrey>Toggle Dangling</> <b>[{refreshImages}]:<darkgrey>Refresh</> <b>[{searchImages}]:<darkgrey>Search</> <blue>|</> " +
+		"<b>[{showContainers}]:<darkgrey>Containers</> <b>[{showNetworks}]:<darkgrey>Networks</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</> <blue>|</>" +
+		"<b>[{removeDanglingImages}]:<darkgrey>Remove Dangling</> <b>[{pruneImages}]:<darkgrey>Prune</> <b>[{removeImage}]:<darkgrey>Remove</> <b>[{forceRemoveImage}]:<darkgrey>Force Remove</> <b>[{showImageHistory}]:<darkgrey>History</> <b>[{showImageSizes}]:<darkgrey>Sizes</> <b>[{scanImage}]:<darkgrey>Scan</> <b>[{showImagePlatforms}]:<darkgrey>Platforms</> <b>[{buildImage}]:<darkgrey>Build</> <b>[{pullImage}]:<darkgrey>Pull</> <b>[{pushImage}]:<darkgrey>Push</> <b>[{tagImage}]:<darkgrey>Tag</> <b>[{saveImage}]:<darkgrey>Save</> <b>[{loadImage}]:<darkgrey>Load</> <b>[{markImage}]:<darkgrey>Mark</> <b>[{compareImages}]:<darkgrey>Compare</> <b>[{browseImageLayer}]:<darkgrey>Layers</> <b>[{showImageChildren}]:<darkgrey>Children</> <b>[{chooseImageColumns}]:<darkgrey>Columns</> <b>[{resizeImageColumns}]:<darkgrey>Resize</>"
+
+	imageHistoryKeyMappings   = "<b>[{closeImageHistory}]:<darkgrey>Back</> <b>[{sortImageHistory}]:<darkgrey>Sort</> <b>[{refreshImageHistory}]:<darkgrey>Refresh</>"
+	imageSizesKeyMappings     = "<b>[{closeImageSizes}]:<darkgrey>Back</> <b>[{sortImageSizes}]:<darkgrey>Sort</> <b>[{refreshImageSizes}]:<darkgrey>Refresh</>"
+	imageScanKeyMappings      = "<b>[{closeImageScan}]:<darkgrey>Back</> <b>[{toggleFixableVulnerabilities}]:<darkgrey>Fixable Only</> <b>[{setImageScanner}]:<darkgrey>Scanner</> <b>[{rescanImage}]:<darkgrey>Rescan</>"
+	imageManifestKeyMappings  = "<b>[{closeImagePlatforms}]:<darkgrey>Back</> <b>[{inspectImageReference}]:<darkgrey>Inspect</> <b>[{refreshImagePlatforms}]:<darkgrey>Refresh</>"
+	registrySearchKeyMappings = "<b>[{closeRegistrySearch}]:<darkgrey>Images</> <b>[{searchRegistry}]:<darkgrey>Search</> <b>[{pullSearchResult}]:<darkgrey>Pull</> <b>[{setSearchRegistry}]:<darkgrey>Registry</> <b>[{refreshRegistrySearch}]:<darkgrey>Refresh</>"
+	imageLayerKeyMappings     = "<b>[{closeImageLayer}]:<darkgrey>Back</> <b>[{refreshImageLayer}]:<darkgrey>Refresh</> <b>[{toggleLayerDir}]:<darkgrey>Expand/Collapse</> <b>[{collapseLayerDir}]:<darkgrey>Collapse</>"
 
 	networkKeyMappings = commonMappings +
-		"<b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</> " +
-		"<b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</> <b>[4]:<darkgrey>Nodes</> <b>[5]:<darkgrey>Services</> <b>[6]:<darkgrey>Stacks</> <blue>|</>" +
-		"<b>[Ctrl+E]:<darkgrey>Remove</> <b>[Enter]:<darkgrey>Inspect</>"
+		"<b>[{sortNetworks}]:<darkgrey>Sort</> <b>[{reverseSortNetworks}]:<darkgrey>Reverse</> <b>[{refreshNetworks}]:<darkgrey>Refresh</> <b>[{searchNetworks}]:<darkgrey>Search</> <blue>|</> " +
+		"<b>[{showContainers}]:<darkgrey>Containers</> <b>[{showImages}]:<darkgrey>Images</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</> <blue>|</>" +
+		"<b>[{removeNetwork}]:<darkgrey>Remove</> <b>[{inspectNetwork}]:<darkgrey>Inspect</> <b>[{toggleNetworkIPAM}]:<darkgrey>Subnets</> <b>[{showNetworkIPAM}]:<darkgrey>IPAM</>"
+
+	pluginKeyMappings = commonMappings +
+		"<b>[{refreshPlugins}]:<darkgrey>Refresh</> <b>[{filterPlugins}]:<darkgrey>Filter</> <blue>|</> " +
+		"<b>[{showContainers}]:<darkgrey>Containers</> <b>[{showImages}]:<darkgrey>Images</> <b>[{showNetworks}]:<darkgrey>Networks</> <blue>|</>" +
+		"<b>[{togglePlugin}]:<darkgrey>Enable/Disable</> <b>[{removePlugin}]:<darkgrey>Remove</> <b>[{forceRemovePlugin}]:<darkgrey>Force Remove</> <b>[{inspectPlugin}]:<darkgrey>Inspect</>"
 
 	diskUsageKeyMappings = commonMappings +
-		"<b>[1]:<darkgrey>Containers</> <b>[2]:<darkgrey>Images</><blue>|</> <b>[3]:<darkgrey>Networks</> <b>[4]:<darkgrey>Nodes</> <b>[5]:<darkgrey>Services</> <b>[6]:<darkgrey>Stacks</> <blue>|</>" +
-		"<b>[p]:<darkgrey>Prune</>"
+		"<b>[{showContainers}]:<darkgrey>Containers</> <b>[{showImages}]:<darkgrey>Images</><blue>|</> <b>[{showNetworks}]:<darkgrey>Networks</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</> <blue>|</>" +
+		"<b>[{prune}]:<darkgrey>Prune</> <b>[{showBuildCache}]:<darkgrey>Build cache</>"
+
+	buildCacheKeyMappings = commonMappings +
+		"<b>[{closeBuildCache}]:<darkgrey>Back</> <b>[{sortBuildCache}]:<darkgrey>Sort</> <b>[{refreshBuildCache}]:<darkgrey>Refresh</> <b>[{filterBuildCache}]:<darkgrey>Filter</> <blue>|</> <b>[{pruneBuildCache}]:<darkgrey>Prune</>"
+
+	serviceKeyMappings = swarmMapping + "<blue>|</> <b>[{sortServices}]:<darkgrey>Sort</> <b>[{refreshServices}]:<darkgrey>Refresh</> <b>[{filterServices}]:<darkgrey>Filter</> <b>[{searchServices}]:<darkgrey>Search</> <blue>|</> <b>[{showServiceLogs}]:<darkgrey>Service logs</> <b>[{showServiceEndpoint}]:<darkgrey>Endpoint</> <b>[{removeService}]:<darkgrey>Remove Service</> <b>[{scaleService}]:<darkgrey>Scale service</><b>[{updateService}]:<darkgrey>Update service</>"
+
+	stackKeyMappings = swarmMapping + "<blue>|</> <b>[{sortStacks}]:<darkgrey>Sort</> <b>[{refreshStacks}]:<darkgrey>Refresh</> <b>[{filterStacks}]:<darkgrey>Filter</> <b>[{searchStacks}]:<darkgrey>Search</> <blue>|</> <b>[{showStackServices}]:<darkgrey>Services</> <b>[{showStackTasks}]:<darkgrey>Tasks</> <b>[{removeStack}]:<darkgrey>Remove Stack</>"
+
+	nodeKeyMappings = swarmMapping + " <blue>|</> <b>[{sortNodes}]:<darkgrey>Sort</> <b>[{refreshNodes}]:<darkgrey>Refresh</> <b>[{searchNodes}]:<darkgrey>Search</> <blue>|</>  <b>[{showNodeTasks}]:<darkgrey>Show Node Tasks</> <b>[{setNodeAvailability}]:<darkgrey>Set Availability</> <b>[{showSwarm}]:<darkgrey>Swarm</>"
+
+	taskKeyMappings = swarmMapping + "<blue>|</> <b>[{sortTasks}]:<darkgrey>Sort</> <b>[{refreshTasks}]:<darkgrey>Refresh</> <b>[{filterTasks}]:<darkgrey>Filter</> <b>[{filterTasksByDesiredState}]:<darkgrey>Desired state</> <b>[{showFailedTasks}]:<darkgrey>Failed</> <blue>|</> <b>[{showTaskError}]:<darkgrey>Error</>"
 
-	serviceKeyMappings = swarmMapping + "<blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[l]:<darkgrey>Service logs</> <b>[Ctrl+R]:<darkgrey>Remove Service</> <b>[Ctrl+S]:<darkgrey>Scale service</><b>[Ctrl+K]:<darkgrey>Update service</>"
+	swarmStatusKeyMappings = "<b>[{closeSwarm}]:<darkgrey>Back</> <b>[{refreshSwarm}]:<darkgrey>Refresh</> <blue>|</> <b>[{initSwarm}]:<darkgrey>Init</> <b>[{joinSwarm}]:<darkgrey>Join</> <b>[{leaveSwarm}]:<darkgrey>Leave</> <blue>|</> <b>[{copyWorkerJoinCommand}]:<darkgrey>Copy worker join</> <b>[{copyManagerJoinCommand}]:<darkgrey>Copy manager join</> <b>[{rotateJoinTokens}]:<darkgrey>Rotate tokens</>"
 
-	stackKeyMappings = swarmMapping + "<blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <b>[%]:<darkgrey>Filter</> <blue>|</> <b>[Ctrl+R]:<darkgrey>Remove Stack</>"
+	jobsKeyMappings = commonMappings +
+		"<b>[{cancelJob}]:<darkgrey>Cancel job</> <blue>|</> " +
+		"<b>[{showContainers}]:<darkgrey>Containers</> <b>[{showImages}]:<darkgrey>Images</> <b>[{showNetworks}]:<darkgrey>Networks</> <b>[{showNodes}]:<darkgrey>Nodes</> <b>[{showServices}]:<darkgrey>Services</> <b>[{showStacks}]:<darkgrey>Stacks</>"
 
-	nodeKeyMappings = swarmMapping + " <blue>|</> <b>[F1]:<darkgrey>Sort</> <b>[F5]:<darkgrey>Refresh</> <blue>|</>  <b>[Enter]:<darkgrey>Show Node Tasks</> <b>[Ctrl+A]:<darkgrey>Set Availability</>"
+	containerHealthKeyMappings    = "<b>[{closeContainerHealth}]:<darkgrey>Back</> <b>[{refreshContainerHealth}]:<darkgrey>Refresh</> <b>[{toggleHealthProbe}]:<darkgrey>Expand/Collapse</> <b>[{showHealthContainerLogs}]:<darkgrey>Logs</>"
+	containerProcessesKeyMappings = "<b>[{closeContainerProcesses}]:<darkgrey>Back</> <b>[{sortContainerProcesses}]:<darkgrey>Sort</> <b>[{refreshContainerProcesses}]:<darkgrey>Refresh</>"
+	containerEnvKeyMappings       = "<b>[{closeContainerEnv}]:<darkgrey>Back</> <b>[{refreshContainerEnv}]:<darkgrey>Refresh</> <b>[{filterContainerEnv}]:<darkgrey>Filter</> <b>[{copyEnvValue}]:<darkgrey>Copy value</> <b>[{toggleEnvSecrets}]:<darkgrey>Show/Mask secrets</>"
+	containerMountsKeyMappings    = "<b>[{closeContainerMounts}]:<darkgrey>Back</> <b>[{refreshContainerMounts}]:<darkgrey>Refresh</> <b>[{showVolumeContainers}]:<darkgrey>Containers using the volume</> <b>[{copyMountHostPath}]:<darkgrey>Copy host path</> <b>[{inspectMountVolume}]:<darkgrey>Inspect volume</>"
+	containerLinksKeyMappings     = "<b>[{closeContainerLinks}]:<darkgrey>Back</> <b>[{refreshContainerLinks}]:<darkgrey>Refresh</> <b>[{toggleContainerLinks}]:<darkgrey>Expand/Collapse</> <b>[{jumpToContainer}]:<darkgrey>Go to container</>"
 
-	commandsMenuBar = "<b>[Esc]:<darkgrey>Back</> <b>[Up]:<darkgrey>Cursor Up</> <b>[Down]:<darkgrey>Cursor Down</> <b>[Enter]:<darkgrey>Execute Command</>"
+	commandsMenuBar = "<b>[{closeContainerMenu}]:<darkgrey>Back</> <b>[{cursorUp}]:<darkgrey>Cursor Up</> <b>[{cursorDown}]:<darkgrey>Cursor Down</> <b>[{runContainerCommand}]:<darkgrey>Execute Command</>"
 )
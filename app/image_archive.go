@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//saveImage asks for the file to save the image with the given id to, a tar
+//archive on the current directory named after the image by default, and
+//saves the image as a background job
+func (h *imagesScreenEventHandler) saveImage(id string, f func(eventHandler)) error {
+	d := h.dry
+	image, err := d.dockerDaemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	name := imageName(image.ID, image.RepoTags)
+	dir, _ := os.Getwd()
+	prompt := appui.NewPromptWithText(
+		fmt.Sprintf("Save %s to (tar archive)", name),
+		filepath.Join(dir, archiveName(name)))
+	ask(h, prompt, f, func(path string) {
+		path = strings.TrimSpace(path)
+		if err := docker.ValidateArchivePath(path); err != nil {
+			d.apperror(fmt.Sprintf("Error saving the image: %s", err.Error()))
+			return
+		}
+		d.runJob(fmt.Sprintf("Save image %s to %s", name, path), true,
+			func(ctx context.Context, progress func(float64)) (string, error) {
+				size, err := d.dockerDaemon.SaveImage(ctx, id, path, progress)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("<white>Image %s saved to %s (%s)</>", name, path, units.BytesSize(float64(size))), nil
+			})
+	})
+	return nil
+}
+
+//loadImage asks for a tar archive, as docker save writes them, and loads
+//its images as a background job
+func (h *imagesScreenEventHandler) loadImage(f func(eventHandler)) {
+	d := h.dry
+	askText(h, "Tar archive to load images from", f, func(path string) {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			d.apperror("No archive to load was given")
+			return
+		}
+		d.runJob(fmt.Sprintf("Load images from %s", path), true,
+			func(ctx context.Context, progress func(float64)) (string, error) {
+				loaded, err := d.dockerDaemon.LoadImage(ctx, path, progress)
+				if err != nil {
+					return "", err
+				}
+				h.widget.Unmount()
+				if len(loaded) == 0 {
+					return fmt.Sprintf("<white>%s loaded</>", path), nil
+				}
+				return fmt.Sprintf("<white>Loaded %s from %s</>", strings.Join(loaded, ", "), path), nil
+			})
+	})
+}
+
+//archiveName returns the name of the archive an image with the given name
+//is saved to by default, i.e. nginx_alpine.tar for nginx:alpine
+func archiveName(image string) string {
+	return strings.NewReplacer("/", "_", ":", "_").Replace(image) + ".tar"
+}
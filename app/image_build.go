@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//buildImage shows the form to build an image, its context is the current
+//directory unless the user changes it. The build output is shown until the
+//user closes it, which cancels the build if it has not finished. The image
+//built is shown selected on the image list.
+func (h *imagesScreenEventHandler) buildImage(f func(eventHandler)) {
+	d := h.dry
+	dir, _ := os.Getwd()
+	form := appui.NewImageBuildForm(dir)
+	widgets.add(form)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		form.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(form)
+		if form.Canceled() {
+			f(h)
+			refreshScreen()
+			return
+		}
+		options := form.Options()
+		ctx, cancel := context.WithCancel(context.Background())
+		r, w := io.Pipe()
+		go func() {
+			id, err := d.dockerDaemon.BuildImage(ctx, options, w)
+			switch {
+			case err == nil:
+				fmt.Fprintf(w, "\nImage %s built, press Esc to go back\n", docker.ShortImageID(id))
+				widgets.ImageList.Unmount()
+				widgets.ImageList.Select(id)
+				d.appsuccess(fmt.Sprintf("Image %s built", docker.ShortImageID(id)))
+			case ctx.Err() == nil:
+				fmt.Fprintf(w, "\nThe build failed: %s\n", err.Error())
+				d.apperror(fmt.Sprintf("Error building the image: %s", err.Error()))
+			}
+			w.Close()
+		}()
+		banner := fmt.Sprintf("Building %s, Dockerfile %s", options.ContextDir, dockerfileName(options))
+		appui.StreamText(r, banner, forwarder.events(), func() {
+			cancel()
+			f(h)
+			refreshScreen()
+		})
+	}()
+}
+
+//dockerfileName returns the Dockerfile the given options build
+func dockerfileName(options docker.BuildOptions) string {
+	if options.Dockerfile == "" {
+		return docker.DefaultDockerfile
+	}
+	return options.Dockerfile
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	drydocker "github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+var errTwoImagesNeeded = errors.New("Mark two images (Space) to compare them")
+
+//imageComparison compares the two images marked on the image list, the image
+//marked first is taken as the old one
+func (h *imagesScreenEventHandler) imageComparison() (*appui.ImageDiffRenderer, string, error) {
+	marked := h.widget.Marked()
+	if len(marked) != 2 {
+		return nil, "", errTwoImagesNeeded
+	}
+	daemon := h.dry.dockerDaemon
+	var names [2]string
+	for i, id := range marked {
+		image, err := daemon.ImageByID(id)
+		if err != nil {
+			return nil, "", err
+		}
+		names[i] = drydocker.ShortImageID(id)
+		if len(image.RepoTags) > 0 && image.RepoTags[0] != "<none>:<none>" {
+			names[i] = image.RepoTags[0]
+		}
+	}
+	oldHistory, err := daemon.History(marked[0])
+	if err != nil {
+		return nil, "", err
+	}
+	newHistory, err := daemon.History(marked[1])
+	if err != nil {
+		return nil, "", err
+	}
+	oldImage, err := daemon.InspectImage(marked[0])
+	if err != nil {
+		return nil, "", err
+	}
+	newImage, err := daemon.InspectImage(marked[1])
+	if err != nil {
+		return nil, "", err
+	}
+	diff := drydocker.CompareImages(oldHistory, newHistory, oldImage, newImage)
+	renderer := appui.NewImageDiffRenderer(names[0], names[1], diff, ui.ActiveScreen.Dimensions.Width)
+	return renderer, comparisonFileName(names[0], names[1]), nil
+}
+
+//showImageComparison shows the comparison of the marked images
+func (h *imagesScreenEventHandler) showImageComparison(f func(eventHandler)) {
+	renderer, _, err := h.imageComparison()
+	if err != nil {
+		h.dry.apperror(fmt.Sprintf("Error comparing images: %s", err.Error()))
+		return
+	}
+	forwarder := newEventForwarder()
+	f(forwarder)
+	go appui.Less(renderer, h.screen, forwarder.events(), func() {
+		h.dry.ViewMode(Images)
+		f(h)
+		refreshScreen()
+	})
+}
+
+//exportImageComparison asks for a file and writes the comparison of the
+//marked images to it as plain text
+func (h *imagesScreenEventHandler) exportImageComparison(f func(eventHandler)) {
+	renderer, fileName, err := h.imageComparison()
+	if err != nil {
+		h.dry.apperror(fmt.Sprintf("Error comparing images: %s", err.Error()))
+		return
+	}
+	prompt := appui.NewPromptWithText("Export the comparison to file", fileName)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		path, canceled := prompt.Text()
+		f(h)
+		if canceled || strings.TrimSpace(path) == "" {
+			return
+		}
+		path = strings.TrimSpace(path)
+		if err := ioutil.WriteFile(path, []byte(renderer.Text()), 0644); err != nil {
+			h.dry.apperror(fmt.Sprintf("Error exporting the comparison: %s", err.Error()))
+			return
+		}
+		h.dry.appsuccess(fmt.Sprintf("Comparison exported to %s", path))
+	}()
+}
+
+//comparisonFileName returns the default name of the file a comparison of the
+//given images is exported to
+func comparisonFileName(oldName, newName string) string {
+	replacer := strings.NewReplacer("/", "_", ":", "_", "@", "_")
+	return fmt.Sprintf("%s-vs-%s.txt", replacer.Replace(oldName), replacer.Replace(newName))
+}
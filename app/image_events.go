@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/docker/docker/api/types"
@@ -33,123 +35,100 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key termbox.Key, f func(eventH
 	switch key {
 	case termbox.KeyF1: //sort
 		h.widget.Sort()
+	case termbox.KeyF2: //show dangling images only
+		h.screen.Cursor.Reset()
+		h.widget.ToggleDanglingOnly()
+	case termbox.KeyF3: //reverse sort
+		h.widget.ReverseSort()
 	case termbox.KeyF5: // refresh
 		h.widget.Unmount()
+		h.dry.resetAutoRefresh()
+	case termbox.KeyCtrlW: //resize columns
+		resizeColumns(h.dry, h, f, h.widget)
+	case termbox.KeyCtrlN: //next search match
+		jumpToSearchMatch(h.dry, h.widget, true, "image")
+	case termbox.KeyCtrlP: //previous search match
+		jumpToSearchMatch(h.dry, h.widget, false, "image")
 	case termbox.KeyCtrlD: //remove dangling images
-		prompt := appui.NewPrompt("Do you want to remove dangling images? (y/N)")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-
-			h.dry.appmessage("<red>Removing dangling images</>")
-			if count, err := h.dry.dockerDaemon.RemoveDanglingImages(); err == nil {
-				h.dry.appmessage(fmt.Sprintf("<red>Removed %d dangling images</>", count))
-			} else {
-				h.dry.appmessage(
-					fmt.Sprintf(
-						"<red>Error removing dangling images. %s</>", err))
-			}
-			refreshScreen()
-
-		}()
-
-	case termbox.KeyCtrlE: //remove image
-
-		prompt := appui.NewPrompt("Do you want to remove the selected image? (y/N)")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-
-			rmImage := func(id string) error {
-				shortID := drydocker.TruncateID(id)
-				if _, err := h.dry.dockerDaemon.Rmi(id, false); err == nil {
-					h.dry.appmessage(fmt.Sprintf("<red>Removed image:</> <white>%s</>", shortID))
-				} else {
-					h.dry.appmessage(fmt.Sprintf("<red>Error removing image </><white>%s: %s</>", shortID, err.Error()))
-				}
-				return nil
-			}
-			if err := h.widget.OnEvent(rmImage); err != nil {
-				h.dry.appmessage(
-					fmt.Sprintf("Error removing image: %s", err.Error()))
+		images, err := h.dry.dockerDaemon.Images()
+		if err != nil {
+			h.dry.apperror(fmt.Sprintf("Error retrieving dangling images: %s", err.Error()))
+			break
+		}
+		var targets []string
+		for _, image := range images {
+			if drydocker.IsDangling(image) {
+				targets = append(targets, imageTarget(image))
 			}
-			refreshScreen()
-
-		}()
+		}
+		if len(targets) == 0 {
+			h.dry.appmessage("There are no dangling images")
+			break
+		}
+		h.dry.confirm(confirmImageRmDangling, "Do you want to remove the following dangling images?", targets, h, f, func() {
+			h.dry.runJob("Remove dangling images", false,
+				func(ctx context.Context, progress func(float64)) (string, error) {
+					count, err := h.dry.dockerDaemon.RemoveDanglingImages()
+					if err != nil {
+						return "", err
+					}
+					return fmt.Sprintf("Removed %d dangling images", count), nil
+				})
+		})
 
-	case termbox.KeyCtrlF: //force remove image
-		prompt := appui.NewPrompt("Do you want to remove the selected image? (y/N)")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
+	case termbox.KeyCtrlE, termbox.KeyCtrlF: //remove image, force remove image
+		force := key == termbox.KeyCtrlF
+		question := "Do you want to remove the following image?"
+		if force {
+			question = "Do you want to force the removal of the following image?"
+		}
+		rmImage := func(id string) error {
+			image, err := h.dry.dockerDaemon.ImageByID(id)
+			if err != nil {
+				return err
 			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
+			shortID := drydocker.TruncateID(id)
+			remove := func() {
+				h.dry.runJob(fmt.Sprintf("Remove image %s", shortID), false,
+					func(ctx context.Context, progress func(float64)) (string, error) {
+						if _, err := h.dry.dockerDaemon.Rmi(id, force); err != nil {
+							return "", err
+						}
+						return fmt.Sprintf("Removed image: <white>%s</>", shortID), nil
+					})
 			}
-
-			rmImage := func(id string) error {
-				shortID := drydocker.TruncateID(id)
-				if _, err := h.dry.dockerDaemon.Rmi(id, true); err == nil {
-					h.dry.appmessage(fmt.Sprintf("<red>Removed image:</> <white>%s</>", shortID))
-				} else {
-					h.dry.appmessage(fmt.Sprintf("<red>Error removing image </><white>%s: %s</>", shortID, err.Error()))
+			if descendants := descendantImages(h.dry.dockerDaemon, id); len(descendants) > 0 {
+				targets := []string{imageTarget(image)}
+				for _, descendant := range descendants {
+					targets = append(targets, "built on it: "+imageTarget(descendant))
 				}
+				h.dry.askConfirmation(confirmImageRmParent,
+					fmt.Sprintf("%d local images are built on this image and would break, do you want to remove it anyway?", len(descendants)),
+					targets, h, f, remove)
 				return nil
 			}
-			if err := h.widget.OnEvent(rmImage); err != nil {
-				h.dry.appmessage(
-					fmt.Sprintf("Error forcing image removal: %s", err.Error()))
-			}
-			refreshScreen()
-
-		}()
+			h.dry.confirm(confirmImageRm, question, []string{imageTarget(image)}, h, f, remove)
+			return nil
+		}
+		if err := h.widget.OnEvent(rmImage); err != nil {
+			h.dry.apperror(
+				fmt.Sprintf("Error removing image: %s", err.Error()))
+		}
 
+	case termbox.KeyCtrlY: //prune images
+		h.pruneImages(f)
+	case termbox.KeyCtrlT: //untag image
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.untagImage(id, f)
+		}); err != nil {
+			h.dry.apperror(fmt.Sprintf("Error untagging the image: %s", err.Error()))
+		}
+	case termbox.KeySpace: //mark image for comparison
+		h.widget.ToggleMark()
 	case termbox.KeyEnter: //inspect image
 		forwarder := newEventForwarder()
 		f(forwarder)
 		inspectImage := inspect(
-			h.screen,
 			forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.InspectImage(id)
@@ -161,7 +140,7 @@ func (h *imagesScreenEventHandler) handleKeyEvent(key termbox.Key, f func(eventH
 			})
 
 		if err := h.widget.OnEvent(inspectImage); err != nil {
-			h.dry.appmessage(
+			h.dry.apperror(
 				fmt.Sprintf("Error inspecting image: %s", err.Error()))
 		}
 
@@ -177,26 +156,87 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 	switch ch {
 	case '2': //Ignore since dry is already on the images screen
 
+	case '|': //hide or show columns
+		chooseColumn(dry, h, f, imagesList, h.widget)
+	case 'd', 'D': //images built on the selected one
+		if err := h.widget.OnEvent(h.showChildren); err != nil {
+			dry.apperror(fmt.Sprintf("Error showing the images built on the image: %s", err.Error()))
+		}
 	case 'i', 'I': //image history
-
-		showHistory := func(id string) error {
-			history, err := dry.dockerDaemon.History(id)
-
-			if err == nil {
-				forwarder := newEventForwarder()
-				f(forwarder)
-				renderer := appui.NewDockerImageHistoryRenderer(history)
-
-				go appui.Less(renderer, h.screen, forwarder.events(), func() {
-					h.dry.ViewMode(Images)
-					f(h)
-					refreshScreen()
-				})
-			}
-			return err
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				image, err := dry.dockerDaemon.ImageByID(id)
+				if err != nil {
+					return err
+				}
+				h.screen.Cursor.Reset()
+				widgets.ImageHistory.ForImage(id, imageName(image.ID, image.RepoTags))
+				dry.ViewMode(ImageHistory)
+				f(viewsToHandlers[ImageHistory])
+				return refreshScreen()
+			}); err != nil {
+			dry.apperror("There was an error showing the image history: " + err.Error())
+		}
+	case 'z', 'Z': //chart of the layer sizes of the image
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				image, err := dry.dockerDaemon.ImageByID(id)
+				if err != nil {
+					return err
+				}
+				h.screen.Cursor.Reset()
+				widgets.ImageSizes.ForImage(id, imageName(image.ID, image.RepoTags))
+				dry.ViewMode(ImageSizes)
+				f(viewsToHandlers[ImageSizes])
+				return refreshScreen()
+			}); err != nil {
+			dry.apperror("There was an error showing the layer sizes of the image: " + err.Error())
+		}
+	case 'v', 'V': //scan the image for vulnerabilities
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				image, err := dry.dockerDaemon.ImageByID(id)
+				if err != nil {
+					return err
+				}
+				h.screen.Cursor.Reset()
+				widgets.ImageScan.ForImage(id, imageName(image.ID, image.RepoTags), imageScanner())
+				dry.ViewMode(ImageScan)
+				f(viewsToHandlers[ImageScan])
+				return refreshScreen()
+			}); err != nil {
+			dry.apperror("There was an error scanning the image: " + err.Error())
+		}
+	case 'a', 'A': //platforms of the image on its registry
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				image, err := dry.dockerDaemon.ImageByID(id)
+				if err != nil {
+					return err
+				}
+				ref, ok := manifestReference(image)
+				if !ok {
+					return errors.New("the image has no tag or digest to look up on a registry")
+				}
+				h.screen.Cursor.Reset()
+				widgets.ImageManifest.ForReference(id, ref)
+				dry.ViewMode(ImageManifest)
+				f(viewsToHandlers[ImageManifest])
+				return refreshScreen()
+			}); err != nil {
+			dry.apperror("There was an error inspecting the manifest of the image: " + err.Error())
 		}
-		if err := h.widget.OnEvent(showHistory); err != nil {
-			dry.appmessage(err.Error())
+	case 'n', 'N': //run a new container from the image, step by step
+		if err := h.widget.OnEvent(
+			func(id string) error {
+				image, err := dry.dockerDaemon.ImageByID(id)
+				if err != nil {
+					return err
+				}
+				runContainer(dry, h, f, drydocker.RunOptions{Image: imageReference(image)})
+				return nil
+			}); err != nil {
+			dry.apperror("There was an error running the image: " + err.Error())
 		}
 	case 'r', 'R': //Run container
 		runImage := func(id string) error {
@@ -225,13 +265,13 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 					return
 				}
 				if err := dry.dockerDaemon.RunImage(image, runCommand); err != nil {
-					dry.appmessage(err.Error())
+					dry.apperror(err.Error())
 				} else {
 					var repo string
 					if len(image.RepoTags) > 0 {
 						repo = image.RepoTags[0]
 					}
-					dry.appmessage(
+					dry.appsuccess(
 						fmt.Sprintf(
 							"Image %s run successfully", repo))
 				}
@@ -241,9 +281,43 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 			return nil
 		}
 		if err := h.widget.OnEvent(runImage); err != nil {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Error running image: %s", err.Error()))
 		}
+	case 'b', 'B': //build an image
+		h.buildImage(f)
+	case 'c', 'C': //compare marked images
+		h.showImageComparison(f)
+	case 'x', 'X': //export comparison of marked images
+		h.exportImageComparison(f)
+	case 'p', 'P': //pull an image
+		h.pullImage(f)
+	case 't', 'T': //tag the image
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.tagImage(id, f)
+		}); err != nil {
+			dry.apperror(fmt.Sprintf("Error tagging the image: %s", err.Error()))
+		}
+	case 'u', 'U': //push the image to its registry
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.pushImage(id, f)
+		}); err != nil {
+			dry.apperror(fmt.Sprintf("Error pushing the image: %s", err.Error()))
+		}
+	case 's', 'S': //save the image to a tar archive
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.saveImage(id, f)
+		}); err != nil {
+			dry.apperror(fmt.Sprintf("Error saving the image: %s", err.Error()))
+		}
+	case 'o', 'O': //load images from a tar archive
+		h.loadImage(f)
+	case 'l', 'L': //browse the content of a layer
+		if err := h.widget.OnEvent(func(id string) error {
+			return h.browseLayer(id, f)
+		}); err != nil {
+			dry.apperror(fmt.Sprintf("Error reading the layers of the image: %s", err.Error()))
+		}
 	case '%':
 		forwarder := newEventForwarder()
 		f(forwarder)
@@ -254,6 +328,8 @@ func (h *imagesScreenEventHandler) handleChEvent(ch rune, f func(eventHandler))
 			f(h)
 		}
 		showFilterInput(newEventSource(forwarder.events()), applyFilter)
+	case '/': //search images
+		searchList(h, f, h.widget)
 	default:
 		handled = false
 	}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	drydocker "github.com/moncho/dry/docker"
+	log "github.com/sirupsen/logrus"
+)
+
+//descendantImages returns the local images built, directly or not, on the
+//image with the given id. None are returned if the graph of the images
+//cannot be built, removing the image is then left to the daemon to refuse.
+func descendantImages(daemon drydocker.ContainerDaemon, id string) []types.ImageSummary {
+	images, err := daemon.Images()
+	if err != nil {
+		log.Debugf("Error listing images: %s", err.Error())
+		return nil
+	}
+	graph, err := daemon.ImageGraph(images)
+	if err != nil {
+		log.Debugf("Error building the image graph: %s", err.Error())
+		return nil
+	}
+	byID := make(map[string]types.ImageSummary, len(images))
+	for _, image := range images {
+		byID[image.ID] = image
+	}
+	var descendants []types.ImageSummary
+	for _, descendant := range graph.Descendants(id) {
+		descendants = append(descendants, byID[descendant])
+	}
+	return descendants
+}
+
+//showChildren filters the image list to show the images built on the image
+//with the given id
+func (h *imagesScreenEventHandler) showChildren(id string) error {
+	images, err := h.dry.dockerDaemon.Images()
+	if err != nil {
+		return err
+	}
+	graph, err := h.dry.dockerDaemon.ImageGraph(images)
+	if err != nil {
+		return err
+	}
+	shortID := drydocker.ShortImageID(id)
+	if len(graph.Children(id)) == 0 {
+		h.dry.appmessage(fmt.Sprintf("No local image is built on image %s", shortID))
+		return nil
+	}
+	h.widget.Filter(drydocker.ParentFilterPrefix + shortID)
+	return nil
+}
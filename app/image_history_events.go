@@ -0,0 +1,35 @@
+package app
+
+import (
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type imageHistoryEventHandler struct {
+	baseEventHandler
+	widget *appui.ImageHistoryWidget
+}
+
+func (h *imageHistoryEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		widgets.ImageList.Select(h.widget.ImageID())
+		h.dry.ViewMode(Images)
+		f(viewsToHandlers[Images])
+		refreshScreen()
+	case termbox.KeyF1:
+		h.widget.Sort()
+		refreshScreen()
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type imageLayerEventHandler struct {
+	baseEventHandler
+	widget *appui.ImageLayerWidget
+}
+
+func (h *imageLayerEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		//reading the image, if it is still being read, is canceled
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		h.dry.ViewMode(Images)
+		f(viewsToHandlers[Images])
+		refreshScreen()
+	case termbox.KeySpace, termbox.KeyEnter, termbox.KeyArrowRight:
+		if h.widget.ToggleExpand() {
+			refreshScreen()
+		}
+	case termbox.KeyArrowLeft:
+		if h.widget.Collapse() {
+			refreshScreen()
+		}
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//browseLayer asks which layer of the image with the given id to browse and
+//shows its content. Layers are listed newest first, as the image history
+//shows them.
+func (h *imagesScreenEventHandler) browseLayer(id string, f func(eventHandler)) error {
+	d := h.dry
+	image, err := d.dockerDaemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	layers, err := d.dockerDaemon.ImageLayers(id)
+	if err != nil {
+		return err
+	}
+	if len(layers) == 0 {
+		return fmt.Errorf("image %s has no layers", docker.ShortImageID(id))
+	}
+	name := docker.ShortImageID(id)
+	if len(image.RepoTags) > 0 && !docker.IsDangling(image) {
+		name = image.RepoTags[0]
+	}
+	choices := make([]string, 0, len(layers))
+	numbers := make(map[string]int, len(layers))
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		size := "?"
+		if layer.Size > 0 {
+			size = units.HumanSize(float64(layer.Size))
+		}
+		createdBy := layer.CreatedBy
+		if createdBy == "" {
+			createdBy = docker.ShortImageID(layer.DiffID)
+		}
+		if runes := []rune(createdBy); len(runes) > 60 {
+			createdBy = string(runes[:59]) + ui.EllipsisGlyph.String()
+		}
+		choice := fmt.Sprintf("%2d %9s  %s", i+1, size, createdBy)
+		choices = append(choices, choice)
+		numbers[choice] = i + 1
+	}
+	askChoice(h, fmt.Sprintf(" Browse a layer of %s ", name), choices, f,
+		func(choice string) {
+			number := numbers[choice]
+			widgets.ImageLayer.ForLayer(id, name, number, len(layers), layers[number-1])
+			h.screen.Cursor.Reset()
+			d.ViewMode(ImageLayer)
+			f(viewsToHandlers[ImageLayer])
+		})
+	return nil
+}
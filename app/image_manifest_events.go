@@ -0,0 +1,67 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type imageManifestEventHandler struct {
+	baseEventHandler
+	widget *appui.ImageManifestWidget
+}
+
+func (h *imageManifestEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		widgets.ImageList.Select(h.widget.ImageID())
+		h.dry.ViewMode(Images)
+		f(viewsToHandlers[Images])
+		refreshScreen()
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case '/':
+			h.inspectReference(f)
+		default:
+			h.baseEventHandler.handle(event, f)
+		}
+	}
+}
+
+//inspectReference asks for another image reference and shows its platforms
+func (h *imageManifestEventHandler) inspectReference(f func(eventHandler)) {
+	askText(h, "Image reference to inspect", f, func(ref string) {
+		if strings.TrimSpace(ref) == "" {
+			return
+		}
+		h.screen.Cursor.Reset()
+		h.widget.ForReference("", strings.TrimSpace(ref))
+	})
+}
+
+//manifestReference returns the reference the manifest of the given image is
+//fetched with from its registry, a tag or else a digest the image was
+//pulled by, false if it has neither
+func manifestReference(image types.ImageSummary) (string, bool) {
+	if tags := docker.ImageTags(image.RepoTags); len(tags) > 0 {
+		return tags[0], true
+	}
+	for _, digest := range image.RepoDigests {
+		if !strings.HasPrefix(digest, "<none>") {
+			return digest, true
+		}
+	}
+	return "", false
+}
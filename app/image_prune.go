@@ -0,0 +1,101 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//Images an image prune removes
+const (
+	pruneDanglingImages = "dangling images"
+	pruneUnusedImages   = "all unused images"
+)
+
+//pruneImages asks which images to prune, dangling or all unused ones, how
+//old they must be and which labels they must have, then prunes them in the
+//background. The images removed and the space reclaimed are shown once
+//done.
+func (h *imagesScreenEventHandler) pruneImages(f func(eventHandler)) {
+	d := h.dry
+	askChoice(h, " Prune images ", []string{pruneDanglingImages, pruneUnusedImages}, f, func(choice string) {
+		opts := docker.ImagePruneOptions{All: choice == pruneUnusedImages}
+		askText(h, "Prune images created before (e.g. 24h or 2019-01-02, leave empty for any age)", f, func(until string) {
+			until = strings.TrimSpace(until)
+			if err := docker.ValidatePruneUntil(until); err != nil {
+				d.apperror(err.Error())
+				return
+			}
+			opts.Until = until
+			askText(h, "Prune images with labels (e.g. env=dev !keep, leave empty for any)", f, func(text string) {
+				labels, err := docker.ParseImagePruneLabels(text)
+				if err != nil {
+					d.apperror(err.Error())
+					return
+				}
+				opts.Labels = labels
+				d.confirm(confirmImagePrune, "Do you want to prune these images?", opts.Describe(), h, f, func() {
+					d.runJob("Prune images", false,
+						func(ctx context.Context, progress func(float64)) (string, error) {
+							report, err := d.dockerDaemon.PruneImages(opts)
+							if err != nil {
+								return "", err
+							}
+							h.widget.Unmount()
+							d.ask(func(h eventHandler, f func(eventHandler)) {
+								forwarder := newEventForwarder()
+								f(forwarder)
+								go appui.Less(ui.StringRenderer(imagePruneReport(report)), ui.ActiveScreen, forwarder.events(), func() {
+									f(h)
+									refreshScreen()
+								})
+							})
+							return fmt.Sprintf("Pruned %d images, %s reclaimed",
+								deletedImages(report), units.HumanSize(float64(report.SpaceReclaimed))), nil
+						})
+				})
+			})
+		})
+	})
+}
+
+//imagePruneReport returns the text telling what the prune with the given
+//report removed, as docker image prune does
+func imagePruneReport(report types.ImagesPruneReport) string {
+	var buf bytes.Buffer
+	if len(report.ImagesDeleted) == 0 {
+		buf.WriteString("No images were pruned\n\n")
+	} else {
+		buf.WriteString("Deleted Images:\n")
+		for _, item := range report.ImagesDeleted {
+			if item.Untagged != "" {
+				fmt.Fprintf(&buf, "untagged: %s\n", item.Untagged)
+			}
+			if item.Deleted != "" {
+				fmt.Fprintf(&buf, "deleted: %s\n", item.Deleted)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	fmt.Fprintf(&buf, "Total reclaimed space: %s\n", units.HumanSize(float64(report.SpaceReclaimed)))
+	return buf.String()
+}
+
+//deletedImages returns how many images the prune with the given report
+//deleted, untagging an image does not delete it
+func deletedImages(report types.ImagesPruneReport) int {
+	var deleted int
+	for _, item := range report.ImagesDeleted {
+		if item.Deleted != "" {
+			deleted++
+		}
+	}
+	return deleted
+}
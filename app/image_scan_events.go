@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type imageScanEventHandler struct {
+	baseEventHandler
+	widget *appui.ImageScanWidget
+}
+
+func (h *imageScanEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		widgets.ImageList.Select(h.widget.ImageID())
+		h.dry.ViewMode(Images)
+		f(viewsToHandlers[Images])
+		refreshScreen()
+	case termbox.KeyF1:
+		h.screen.Cursor.Reset()
+		h.widget.ToggleFixableOnly()
+		refreshScreen()
+	case termbox.KeyF2:
+		h.setScanner(f)
+	case termbox.KeyF5:
+		h.screen.Cursor.Reset()
+		h.widget.Rescan(imageScanner())
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//setScanner asks for the command images are scanned with, saves it and
+//scans the image again with it
+func (h *imageScanEventHandler) setScanner(f func(eventHandler)) {
+	command := userPreferences.scanner()
+	if command == "" {
+		command = docker.DefaultScannerCommand
+	}
+	prompt := appui.NewPromptWithText("Scanner command ({image} is replaced by the image)", command)
+	ask(h, prompt, f, func(command string) {
+		command = strings.TrimSpace(command)
+		if command == docker.DefaultScannerCommand {
+			command = ""
+		}
+		if err := userPreferences.setScanner(command); err != nil {
+			h.dry.apperror(fmt.Sprintf("Error saving the scanner: %s", err.Error()))
+		}
+		h.screen.Cursor.Reset()
+		h.widget.Rescan(imageScanner())
+	})
+}
+
+//imageScanner returns the scanner images are scanned with, the one on the
+//preferences or trivy
+func imageScanner() docker.ImageScanner {
+	return docker.NewCommandScanner(userPreferences.scanner())
+}
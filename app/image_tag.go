@@ -0,0 +1,64 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/docker"
+)
+
+//tagImage asks for a new repository[:tag] for the image with the given id
+//and tags the image with it
+func (h *imagesScreenEventHandler) tagImage(id string, f func(eventHandler)) error {
+	d := h.dry
+	image, err := d.dockerDaemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	question := fmt.Sprintf("New tag for %s (repository[:tag])", imageName(image.ID, image.RepoTags))
+	askText(h, question, f, func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if err := d.dockerDaemon.TagImage(id, ref); err != nil {
+			d.apperror(fmt.Sprintf("Error tagging the image: %s", err.Error()))
+			return
+		}
+		d.appsuccess(fmt.Sprintf("Image %s tagged as %s", docker.ShortImageID(id), ref))
+		h.widget.Unmount()
+	})
+	return nil
+}
+
+//untagImage removes the tag chosen from the image with the given id, the
+//only tag of an image is not removed
+func (h *imagesScreenEventHandler) untagImage(id string, f func(eventHandler)) error {
+	d := h.dry
+	image, err := d.dockerDaemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	tags := docker.ImageTags(image.RepoTags)
+	switch len(tags) {
+	case 0:
+		return fmt.Errorf("image %s has no tags", docker.ShortImageID(id))
+	case 1:
+		return fmt.Errorf("%s is the only tag of the image, removing it would remove the image", tags[0])
+	}
+	askChoice(h, " Tag to remove ", tags, f, func(tag string) {
+		if err := d.dockerDaemon.UntagImage(id, tag); err != nil {
+			d.apperror(fmt.Sprintf("Error untagging the image: %s", err.Error()))
+			return
+		}
+		d.appsuccess(fmt.Sprintf("Tag %s removed from image %s", tag, docker.ShortImageID(id)))
+		h.widget.Unmount()
+	})
+	return nil
+}
+
+//imageName returns the first tag of an image with the given id and tags,
+//its short id if it has none
+func imageName(id string, repoTags []string) string {
+	if tags := docker.ImageTags(repoTags); len(tags) > 0 {
+		return tags[0]
+	}
+	return docker.ShortImageID(id)
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+)
+
+//imageTransfer pulls or pushes an image, telling progress about every
+//change, and returns the reference transferred
+type imageTransfer func(ctx context.Context, progress func(docker.TransferProgress)) (string, error)
+
+//pullImage asks for the reference of an image and pulls it as a background
+//job, the progress of each layer is shown until the user closes it
+func (h *imagesScreenEventHandler) pullImage(f func(eventHandler)) {
+	d := h.dry
+	askText(h, "Image to pull (e.g. nginx:alpine)", f, func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			d.apperror("No image to pull was given")
+			return
+		}
+		pullImage(d, h, ref, f)
+	})
+}
+
+//pullImage pulls the image with the given reference as a background job,
+//the progress of each layer is shown until the user closes it
+func pullImage(d *Dry, h eventHandler, ref string, f func(eventHandler)) {
+	transferImage(d, h, "Pull", ref, f,
+		func(ctx context.Context, progress func(docker.TransferProgress)) (string, error) {
+			return d.dockerDaemon.PullImage(ctx, ref, progress)
+		})
+}
+
+//pushImage pushes the image with the given id to its registry, by the tag
+//chosen if it has more than one. The credentials of the Docker CLI are used
+//if it has them for the registry, otherwise they are asked for.
+func (h *imagesScreenEventHandler) pushImage(id string, f func(eventHandler)) error {
+	image, err := h.dry.dockerDaemon.ImageByID(id)
+	if err != nil {
+		return err
+	}
+	tags := docker.ImageTags(image.RepoTags)
+	switch len(tags) {
+	case 0:
+		return fmt.Errorf("image %s has no tag to push it by, tag it first", docker.ShortImageID(id))
+	case 1:
+		h.pushTag(tags[0], f)
+	default:
+		askChoice(h, " Tag to push ", tags, f, func(tag string) {
+			h.pushTag(tag, f)
+		})
+	}
+	return nil
+}
+
+//pushTag pushes the image with the given tag, asking for the credentials of
+//its registry if the Docker CLI has none
+func (h *imagesScreenEventHandler) pushTag(tag string, f func(eventHandler)) {
+	d := h.dry
+	push := func(credentials *docker.RegistryCredentials) {
+		transferImage(d, h, "Push", tag, f,
+			func(ctx context.Context, progress func(docker.TransferProgress)) (string, error) {
+				return d.dockerDaemon.PushImage(ctx, tag, credentials, progress)
+			})
+	}
+	if docker.HasRegistryCredentials(tag) {
+		push(nil)
+		return
+	}
+	registry, err := docker.RegistryOf(tag)
+	if err != nil {
+		d.apperror(err.Error())
+		return
+	}
+	askText(h, fmt.Sprintf("User name on %s (leave empty to push without logging in)", registry), f, func(user string) {
+		user = strings.TrimSpace(user)
+		if user == "" {
+			push(nil)
+			return
+		}
+		askSecret(h, fmt.Sprintf("Password of %s on %s", user, registry), f, func(password string) {
+			push(&docker.RegistryCredentials{Username: user, Password: password})
+		})
+	})
+}
+
+//transferImage runs the given transfer of the image with the given reference
+//as a background job, action tells what the transfer is, Pull or Push. The
+//progress of each layer is shown until the user closes it, the job goes on
+//after that.
+func transferImage(d *Dry, h eventHandler, action, ref string, f func(eventHandler), transfer imageTransfer) {
+	w := appui.NewTransferWidget(fmt.Sprintf("%s %s", action, ref))
+	d.runJob(fmt.Sprintf("%s image %s", action, ref), true,
+		func(ctx context.Context, progress func(float64)) (string, error) {
+			transferred, err := transfer(ctx, func(p docker.TransferProgress) {
+				w.Update(p)
+				progress(p.Fraction())
+			})
+			if err != nil {
+				w.Finish("", err)
+				return "", err
+			}
+			result := fmt.Sprintf("%s image %s done", action, transferred)
+			w.Finish(result, nil)
+			widgets.ImageList.Unmount()
+			return fmt.Sprintf("<white>%s</>", result), nil
+		})
+
+	widgets.add(w)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		w.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(w)
+		f(h)
+		refreshScreen()
+	}()
+}
@@ -0,0 +1,203 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/appui"
+)
+
+//defaultJobWorkers is the number of jobs that can run at the same time
+const defaultJobWorkers = 4
+
+//maxFinishedJobs is the max number of finished jobs kept by the queue
+const maxFinishedJobs = 50
+
+//jobFunc is the operation executed by a job. Progress, a value between 0 and 1,
+//can be reported using the given func. On success, the returned string is shown
+//to the user.
+type jobFunc func(ctx context.Context, progress func(float64)) (string, error)
+
+type job struct {
+	appui.Job
+	run    jobFunc
+	cancel context.CancelFunc
+}
+
+//jobQueue runs operations in the background on a pool of workers
+type jobQueue struct {
+	jobs     []*job
+	lastID   int
+	workers  chan struct{}
+	onChange func()
+	onDone   func(job appui.Job, result string)
+	sync.Mutex
+}
+
+//newJobQueue creates a queue that runs up to the given number of jobs at
+//the same time. onChange is called every time the state of a job changes,
+//onDone when a job finishes.
+func newJobQueue(workers int, onChange func(), onDone func(appui.Job, string)) *jobQueue {
+	return &jobQueue{
+		workers:  make(chan struct{}, workers),
+		onChange: onChange,
+		onDone:   onDone,
+	}
+}
+
+//submit queues a new job, cancelable tells if the given func stops
+//when its context is canceled. Returns the job ID.
+func (q *jobQueue) submit(description string, cancelable bool, run jobFunc) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	q.Lock()
+	q.lastID++
+	j := &job{
+		Job: appui.Job{
+			ID:          q.lastID,
+			Description: description,
+			Status:      appui.JobPending,
+			Progress:    -1,
+			Cancelable:  cancelable,
+			Created:     time.Now(),
+		},
+		run:    run,
+		cancel: cancel,
+	}
+	q.jobs = append(q.jobs, j)
+	q.trim()
+	q.Unlock()
+	q.changed()
+
+	go q.execute(ctx, j)
+	return j.ID
+}
+
+//cancel cancels the job with the given ID
+func (q *jobQueue) cancel(id int) error {
+	q.Lock()
+	defer q.Unlock()
+	for _, j := range q.jobs {
+		if j.ID != id {
+			continue
+		}
+		switch {
+		case j.Status.Finished():
+			return fmt.Errorf("Job %d has already finished", id)
+		case j.Status == appui.JobRunning && !j.Cancelable:
+			return fmt.Errorf("Job %d cannot be canceled while running", id)
+		}
+		j.cancel()
+		return nil
+	}
+	return fmt.Errorf("Job %d not found", id)
+}
+
+//list returns the jobs of this queue, from the oldest to the newest
+func (q *jobQueue) list() []appui.Job {
+	q.Lock()
+	defer q.Unlock()
+	jobs := make([]appui.Job, len(q.jobs))
+	for i, j := range q.jobs {
+		jobs[i] = j.Job
+	}
+	return jobs
+}
+
+//running returns the number of jobs pending or running
+func (q *jobQueue) running() int {
+	return appui.RunningJobs(q.list())
+}
+
+func (q *jobQueue) execute(ctx context.Context, j *job) {
+	defer j.cancel()
+	select {
+	case q.workers <- struct{}{}:
+		defer func() { <-q.workers }()
+	case <-ctx.Done():
+		q.finish(j, "", ctx.Err())
+		return
+	}
+	if ctx.Err() != nil {
+		q.finish(j, "", ctx.Err())
+		return
+	}
+
+	q.Lock()
+	j.Status = appui.JobRunning
+	j.Started = time.Now()
+	q.Unlock()
+	q.changed()
+
+	result, err := j.run(ctx, func(progress float64) {
+		q.progress(j, progress)
+	})
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	q.finish(j, result, err)
+}
+
+func (q *jobQueue) finish(j *job, result string, err error) {
+	q.Lock()
+	j.Finished = time.Now()
+	switch {
+	case err == context.Canceled:
+		j.Status = appui.JobCanceled
+	case err != nil:
+		j.Status = appui.JobFailed
+		j.Err = err
+	default:
+		j.Status = appui.JobSucceeded
+		j.Progress = 1
+	}
+	finished := j.Job
+	q.Unlock()
+	q.changed()
+	if q.onDone != nil {
+		q.onDone(finished, result)
+	}
+}
+
+//progress updates the progress of the given job, changes
+//smaller than 1% are not notified
+func (q *jobQueue) progress(j *job, progress float64) {
+	progress = math.Max(0, math.Min(1, progress))
+	q.Lock()
+	notify := j.Progress < 0 || math.Floor(progress*100) != math.Floor(j.Progress*100)
+	j.Progress = progress
+	q.Unlock()
+	if notify {
+		q.changed()
+	}
+}
+
+func (q *jobQueue) changed() {
+	if q.onChange != nil {
+		q.onChange()
+	}
+}
+
+//trim removes the oldest finished jobs if there are too many
+func (q *jobQueue) trim() {
+	finished := 0
+	for _, j := range q.jobs {
+		if j.Status.Finished() {
+			finished++
+		}
+	}
+	if finished <= maxFinishedJobs {
+		return
+	}
+	jobs := q.jobs[:0]
+	for _, j := range q.jobs {
+		if j.Status.Finished() && finished > maxFinishedJobs {
+			finished--
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	q.jobs = jobs
+}
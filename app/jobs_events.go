@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strconv"
+
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type jobsScreenEventHandler struct {
+	baseEventHandler
+	widget *appui.JobsWidget
+}
+
+func (h *jobsScreenEventHandler) handle(event termbox.Event, f func(eh eventHandler)) {
+	handled := true
+	switch event.Ch {
+	case '7': //Ignore since dry is already on the jobs screen
+	case 'c', 'C': //cancel job
+		cancelJob := func(id string) error {
+			jobID, err := strconv.Atoi(id)
+			if err != nil {
+				return err
+			}
+			return h.dry.jobs.cancel(jobID)
+		}
+		if err := h.widget.OnEvent(cancelJob); err != nil {
+			h.dry.apperror(err.Error())
+		}
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		h.baseEventHandler.handle(event, f)
+	}
+}
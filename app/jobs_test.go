@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/appui"
+)
+
+func TestJobQueue(t *testing.T) {
+	done := make(chan appui.Job, 10)
+	q := newJobQueue(1, nil, func(j appui.Job, result string) { done <- j })
+
+	q.submit("succeeds", false, func(ctx context.Context, progress func(float64)) (string, error) {
+		progress(0.5)
+		return "ok", nil
+	})
+	q.submit("fails", false, func(ctx context.Context, progress func(float64)) (string, error) {
+		return "", errors.New("failed")
+	})
+
+	want := map[string]appui.JobStatus{
+		"succeeds": appui.JobSucceeded,
+		"fails":    appui.JobFailed,
+	}
+	for range want {
+		select {
+		case j := <-done:
+			if j.Status != want[j.Description] {
+				t.Errorf("Job %s, unexpected status, got %s, want %s", j.Description, j.Status, want[j.Description])
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Jobs did not finish")
+		}
+	}
+	if q.running() != 0 {
+		t.Errorf("No job should be running, got %d", q.running())
+	}
+	jobs := q.list()
+	if len(jobs) != 2 || jobs[0].Progress != 1 || jobs[1].Err == nil {
+		t.Errorf("Unexpected job list: %v", jobs)
+	}
+}
+
+func TestJobQueueCancel(t *testing.T) {
+	done := make(chan appui.Job, 10)
+	q := newJobQueue(1, nil, func(j appui.Job, result string) { done <- j })
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocking := q.submit("blocking", false, func(ctx context.Context, progress func(float64)) (string, error) {
+		close(started)
+		<-release
+		return "", nil
+	})
+	<-started
+	cancelable := q.submit("cancelable", true, func(ctx context.Context, progress func(float64)) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	if err := q.cancel(blocking); err == nil {
+		t.Error("A running job that is not cancelable was canceled")
+	}
+	if err := q.cancel(cancelable); err != nil {
+		t.Errorf("Pending job could not be canceled: %s", err)
+	}
+	if err := q.cancel(42); err == nil {
+		t.Error("Canceling an unknown job did not fail")
+	}
+
+	select {
+	case j := <-done:
+		if j.ID != cancelable || j.Status != appui.JobCanceled {
+			t.Errorf("Unexpected job finished: %v", j)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Canceled job did not finish")
+	}
+	close(release)
+	select {
+	case j := <-done:
+		if j.ID != blocking || j.Status != appui.JobSucceeded {
+			t.Errorf("Unexpected job finished: %v", j)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Blocking job did not finish")
+	}
+	if err := q.cancel(blocking); err == nil {
+		t.Error("A finished job was canceled")
+	}
+}
+
+func TestJobQueueTrim(t *testing.T) {
+	done := make(chan appui.Job, maxFinishedJobs+10)
+	q := newJobQueue(defaultJobWorkers, nil, func(j appui.Job, result string) { done <- j })
+	for i := 0; i < maxFinishedJobs+10; i++ {
+		q.submit("job", false, func(ctx context.Context, progress func(float64)) (string, error) {
+			return "", nil
+		})
+		<-done
+	}
+	if len(q.list()) > maxFinishedJobs+1 {
+		t.Errorf("Finished jobs are not trimmed, got %d jobs", len(q.list()))
+	}
+}
@@ -0,0 +1,726 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//keyChord is a key, or a character, pressed with an optional modifier
+type keyChord struct {
+	key termbox.Key
+	ch  rune
+	mod termbox.Modifier
+}
+
+//namedKeys are the keys, other than characters and Ctrl+<letter>, that can be bound
+var namedKeys = map[string]termbox.Key{
+	"ArrowUp":    termbox.KeyArrowUp,
+	"ArrowDown":  termbox.KeyArrowDown,
+	"ArrowLeft":  termbox.KeyArrowLeft,
+	"ArrowRight": termbox.KeyArrowRight,
+	"Backspace":  termbox.KeyBackspace2,
+	"Delete":     termbox.KeyDelete,
+	"End":        termbox.KeyEnd,
+	"Enter":      termbox.KeyEnter,
+	"Esc":        termbox.KeyEsc,
+	"Home":       termbox.KeyHome,
+	"Insert":     termbox.KeyInsert,
+	"PgDn":       termbox.KeyPgdn,
+	"PgUp":       termbox.KeyPgup,
+	"Space":      termbox.KeySpace,
+	"Tab":        termbox.KeyTab,
+	"F1":         termbox.KeyF1,
+	"F2":         termbox.KeyF2,
+	"F3":         termbox.KeyF3,
+	"F4":         termbox.KeyF4,
+	"F5":         termbox.KeyF5,
+	"F6":         termbox.KeyF6,
+	"F7":         termbox.KeyF7,
+	"F8":         termbox.KeyF8,
+	"F9":         termbox.KeyF9,
+	"F10":        termbox.KeyF10,
+	"F11":        termbox.KeyF11,
+	"F12":        termbox.KeyF12,
+}
+
+//parseKeyChord parses key chords like "e", "%", "F1", "Enter", "Ctrl+e" or "Alt+d"
+func parseKeyChord(s string) (keyChord, error) {
+	var chord keyChord
+	text := s
+	if strings.HasPrefix(text, "Alt+") && len(text) > len("Alt+") {
+		chord.mod = termbox.ModAlt
+		text = text[len("Alt+"):]
+	}
+	if key, ok := namedKeys[text]; ok {
+		chord.key = key
+		return chord, nil
+	}
+	if strings.HasPrefix(text, "Ctrl+") {
+		letter := strings.ToLower(text[len("Ctrl+"):])
+		if len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+			chord.key = termbox.KeyCtrlA + termbox.Key(letter[0]-'a')
+			return chord, nil
+		}
+		return chord, fmt.Errorf("invalid key %q, Ctrl can only be combined with a letter", s)
+	}
+	if utf8.RuneCountInString(text) == 1 {
+		chord.ch, _ = utf8.DecodeRuneInString(text)
+		if chord.ch == ' ' {
+			chord.key = termbox.KeySpace
+			chord.ch = 0
+		}
+		return chord, nil
+	}
+	return chord, fmt.Errorf("invalid key %q", s)
+}
+
+//chordOf returns the key chord of the given event
+func chordOf(e termbox.Event) keyChord {
+	chord := keyChord{ch: e.Ch, mod: e.Mod & termbox.ModAlt}
+	if e.Ch == 0 {
+		chord.key = e.Key
+	}
+	return chord
+}
+
+//event returns a key event for this chord
+func (k keyChord) event() termbox.Event {
+	return termbox.Event{Type: termbox.EventKey, Key: k.key, Ch: k.ch, Mod: k.mod}
+}
+
+func (k keyChord) String() string {
+	var prefix string
+	if k.mod&termbox.ModAlt != 0 {
+		prefix = "Alt+"
+	}
+	if k.ch != 0 {
+		return prefix + string(k.ch)
+	}
+	if k.key >= termbox.KeyCtrlA && k.key <= termbox.KeyCtrlZ {
+		//Some Ctrl+<letter> keys are also named keys
+		switch k.key {
+		case termbox.KeyEnter, termbox.KeyTab:
+		default:
+			return prefix + "Ctrl+" + string('a'+rune(k.key-termbox.KeyCtrlA))
+		}
+	}
+	for name, key := range namedKeys {
+		if key == k.key {
+			return prefix + name
+		}
+	}
+	return prefix + "Key(" + strconv.Itoa(int(k.key)) + ")"
+}
+
+//Keymap scopes, each view handles the actions of its scope and the global ones
+const (
+	globalScope             = "Global"
+	containersScope         = "Container list"
+	containerMenuScope      = "Container menu"
+	containerLinksScope     = "Container links"
+	containerHealthScope    = "Container health"
+	containerEnvScope       = "Container environment"
+	containerMountsScope    = "Container mounts"
+	containerProcessesScope = "Container processes"
+	monitorScope            = "Monitor mode"
+	jobsScope               = "Job list"
+	imagesScope             = "Image list"
+	imageHistoryScope       = "Image history"
+	imageLayerScope         = "Image layer"
+	imageSizesScope         = "Image layer sizes"
+	imageScanScope          = "Image vulnerabilities"
+	imageManifestScope      = "Image platforms"
+	registrySearchScope     = "Registry search"
+	networksScope           = "Network list"
+	pluginsScope            = "Plugin list"
+	nodesScope              = "Node list"
+	servicesScope           = "Service list"
+	stacksScope             = "Stack list"
+	swarmScope              = "Swarm"
+	tasksScope              = "Task list"
+	diskUsageScope          = "Disk usage"
+	buildCacheScope         = "Build cache"
+)
+
+//keymapScopes is the order in which scopes are shown on the help screen
+var keymapScopes = []string{
+	globalScope, containersScope, containerMenuScope, containerLinksScope, containerHealthScope, containerEnvScope, containerMountsScope, containerProcessesScope, monitorScope, jobsScope,
+	imagesScope, imageHistoryScope, imageLayerScope, imageSizesScope, imageScanScope, imageManifestScope, registrySearchScope, networksScope, pluginsScope, nodesScope, servicesScope, stacksScope, swarmScope, tasksScope, diskUsageScope, buildCacheScope,
+}
+
+//keyAction is an action that can be bound to keys
+type keyAction struct {
+	name        string
+	scope       string
+	keys        []string //default keys, the handlers of each view act on the first one
+	description string
+}
+
+//keyActions are the actions that can be bound to keys, in the order they are
+//shown on the help screen
+var keyActions = []keyAction{
+	{"cursorUp", globalScope, []string{"ArrowUp"}, "Moves the cursor one line up"},
+	{"cursorDown", globalScope, []string{"ArrowDown"}, "Moves the cursor one line down"},
+	{"cursorTop", globalScope, []string{"g"}, "Moves the cursor to the beginning of the list"},
+	{"cursorBottom", globalScope, []string{"G"}, "Moves the cursor to the end of the list"},
+	{"switchTheme", globalScope, []string{"F6"}, "Switches to the next color theme"},
+	{"toggleTruncation", globalScope, []string{"F11"}, "Switches between cutting long values with an ellipsis and hard cutting them"},
+	{"showNotifications", globalScope, []string{"F7"}, "Shows the notifications history"},
+	{"showDiskUsage", globalScope, []string{"F8"}, "Shows Docker disk usage"},
+	{"showEvents", globalScope, []string{"F9"}, "Shows the last 10 events reported by Docker"},
+	{"showDockerInfo", globalScope, []string{"F10"}, "Inspects Docker"},
+	{"showContainers", globalScope, []string{"1"}, "To container list"},
+	{"showImages", globalScope, []string{"2"}, "To image list"},
+	{"showNetworks", globalScope, []string{"3"}, "To network list"},
+	{"showNodes", globalScope, []string{"4"}, "To node list (in Swarm mode)"},
+	{"showServices", globalScope, []string{"5"}, "To service list (in Swarm mode)"},
+	{"showStacks", globalScope, []string{"6"}, "To stack list (in Swarm mode)"},
+	{"showJobs", globalScope, []string{"7"}, "To the list of background jobs"},
+	{"showPlugins", globalScope, []string{"8"}, "To the list of engine plugins"},
+	{"showRegistrySearch", globalScope, []string{"9"}, "To the search of images on Docker Hub, or on the registry chosen"},
+	{"showMonitor", globalScope, []string{"m", "M"}, "Show container monitor mode"},
+	{"showHelp", globalScope, []string{"h", "H"}, "Shows this help screen"},
+	{"showViewKeys", globalScope, []string{"?"}, "Shows the keys of the current view and the global ones over the view, global keys shadowed by the view are greyed out"},
+	{"showCommandPalette", globalScope, []string{":"}, "Shows the command palette, to search the actions of the current view and run them"},
+	{"setAutoRefresh", globalScope, []string{"F4"}, "Sets how often the container, image or network list on screen refreshes on its own"},
+	{"toggleCompatibilityMode", globalScope, []string{"Ctrl+o"}, "Draws only ASCII characters and text attributes, for consoles and screen readers that cannot show anything else, or draws as usual"},
+	{"toggleCallStats", globalScope, []string{"Ctrl+b"}, "Shows or hides how many list calls were made to the Docker daemon, how many were coalesced and their average latency"},
+	{"dismissNotification", globalScope, []string{"Ctrl+x"}, "Dismisses the notification on screen"},
+	{"saveScene", globalScope, []string{"F12"}, "Saves the containers, images and networks, the list settings and the screen size to a file, to reproduce what is on screen with --replay"},
+
+	{"sortContainers", containersScope, []string{"F1"}, "Cycles through sort modes (id, image, status, name, creation time, health, CPU and memory usage, size)"},
+	{"reverseSortContainers", containersScope, []string{"F3"}, "Switches the order of the sort column between ascending and descending, each column keeps its own"},
+	{"toggleShowAll", containersScope, []string{"F2"}, "Toggles showing all containers (default shows just running)"},
+	{"refreshContainers", containersScope, []string{"F5"}, "Refreshes the list"},
+	{"filterContainers", containersScope, []string{"%"}, "Filter"},
+	{"filterContainersByStatus", containersScope, []string{"t", "T"}, "Shows only the containers in the state chosen from a menu, as the status:<state> filter does"},
+	{"searchContainers", containersScope, []string{"/"}, "Searches the list, the cursor jumps to the rows that match as the search is typed"},
+	{"searchNextContainer", containersScope, []string{"n"}, "Jumps to the next row that matches the search"},
+	{"searchPreviousContainer", containersScope, []string{"N"}, "Jumps to the previous row that matches the search"},
+	{"runContainer", containersScope, []string{"c"}, "Creates and runs a new container, asking step by step for its options"},
+	{"runContainerLike", containersScope, []string{"C"}, "Creates and runs a new container with the options of the selected one"},
+	{"recreateContainer", containersScope, []string{"r", "R"}, "Replaces the selected container with a new one with the same configuration, optionally pulling its image first"},
+	{"removeContainer", containersScope, []string{"e", "E"}, "Removes the selected container"},
+	{"removeStoppedContainers", containersScope, []string{"Ctrl+e"}, "Removes all stopped containers"},
+	{"pruneContainers", containersScope, []string{"Ctrl+y"}, "Prunes the stopped containers, as docker container prune does, the confirmation tells how many they are and the space reclaimed"},
+	{"inspectContainer", containersScope, []string{"i", "I"}, "Inspects the selected container"},
+	{"attachToContainer", containersScope, []string{"Ctrl+a"}, "Attaches the terminal to the main process of the selected container, dry is shown again on ctrl-p ctrl-q or when the container stops"},
+	{"openPublishedPort", containersScope, []string{"Ctrl+v"}, "Opens with the browser a port published by the selected container, the port is chosen from a menu if there are many"},
+	{"showContainerDiff", containersScope, []string{"Ctrl+d"}, "Shows the paths added, changed and deleted on the filesystem of the selected container, as docker diff does"},
+	{"killContainer", containersScope, []string{"Ctrl+k"}, "Sends a signal, SIGKILL unless another one is picked, to the selected container"},
+	{"showContainerLogs", containersScope, []string{"l", "L"}, "Displays the logs of the selected container"},
+	{"showContainerLogsWithTimestamps", containersScope, []string{"Ctrl+l"}, "Displays the logs of the selected container with Docker timestamps"},
+	{"pinContainer", containersScope, []string{"Ctrl+p"}, "Pins the selected container, the cursor stays on it while the list is sorted, filtered or refreshed, or unpins it"},
+	{"restartContainer", containersScope, []string{"Ctrl+r"}, "Restarts selected container, or the marked ones with the containers they depend on (compose depends_on) first"},
+	{"showContainerStats", containersScope, []string{"s", "S"}, "Displays a live stream of the selected container resource usage statistics"},
+	{"showContainerStatsHistory", containersScope, []string{"Ctrl+g"}, "Displays graphs of the selected container resource usage over time (+/- change the time window)"},
+	{"stopContainer", containersScope, []string{"Ctrl+t"}, "Stops selected container (noop if it is not running), or the marked ones with the containers that depend on them (compose depends_on) first"},
+	{"renameContainer", containersScope, []string{"Ctrl+n"}, "Renames the selected container, the prompt starts with its current name"},
+	{"updateContainer", containersScope, []string{"Ctrl+u"}, "Changes the CPU shares, the memory limit and the restart policy of the selected container without recreating it"},
+	{"commitContainer", containersScope, []string{"a", "A"}, "Creates an image from the changes of the selected container, asking for its repository:tag, a message and an author, and shows it on the image list"},
+	{"execCommand", containersScope, []string{"!"}, "Runs a command, typed with its user, working directory and environment, in the selected container and shows its output, the last commands run in each container are remembered"},
+	{"copyFiles", containersScope, []string{"f", "F"}, "Copies files or directories from the host to the selected container, or from it to the host, as a background job"},
+	{"pauseContainer", containersScope, []string{"z", "Z"}, "Pauses the selected container, or unpauses it if it is paused"},
+	{"markContainer", containersScope, []string{"Space"}, "Marks the selected container, or unmarks it, so stop and restart act on every marked container. On a compose project, marks its containers"},
+	{"groupByProject", containersScope, []string{"Ctrl+f"}, "Lists the containers under the compose project they belong to, Enter or x on a project hides its containers or lists them again, Ctrl+r, Ctrl+t and e restart, stop and remove them"},
+	{"editRestartPolicy", containersScope, []string{"p", "P"}, "Changes the restart policy of the selected container"},
+	{"showContainerEnv", containersScope, []string{"v", "V"}, "Shows the environment variables and labels of the selected container"},
+	{"showContainerMounts", containersScope, []string{"b", "B"}, "Shows the volumes, bind mounts and tmpfs mounts of the selected container"},
+	{"showContainerHealth", containersScope, []string{"k", "K"}, "Shows the latest healthcheck results of the selected container"},
+	{"showContainerProcesses", containersScope, []string{"j", "J"}, "Shows the processes running in the selected container, as docker top does, listed again every 2 seconds"},
+	{"showContainerLinks", containersScope, []string{"d", "D"}, "Shows the networks, volumes and compose dependencies that link the selected container to others"},
+	{"copyContainerID", containersScope, []string{"#"}, "Copies the full id of the selected container to the clipboard"},
+	{"copyContainerName", containersScope, []string{"@"}, "Copies the name of the selected container to the clipboard"},
+	{"showRunCommand", containersScope, []string{"y", "Y"}, "Shows, copies or saves the docker run command that creates a container like the selected one"},
+	{"expandContainer", containersScope, []string{"x", "X"}, "Expands the selected row to show the full command, every port mapping and every name, collapses it again"},
+	{"toggleSplitView", containersScope, []string{"w", "W"}, "Shows the list next to a preview of the container on the cursor, with its logs and stats, or the list alone"},
+	{"editRowRules", containersScope, []string{"o", "O"}, "Edits the rules that style the rows of the containers matching their conditions"},
+	{"chooseContainerColumns", containersScope, []string{"|"}, "Hides the column chosen from a menu, or shows it again if hidden"},
+	{"resizeContainerColumns", containersScope, []string{"Ctrl+w"}, "Resizes the columns, arrow keys choose the column and +/- resize it until Esc"},
+	{"checkImageUpdate", containersScope, []string{"u"}, "Checks if the registry has a newer version of the image of the selected container"},
+	{"checkImageUpdates", containersScope, []string{"U"}, "Checks if the registry has newer versions of the images of every listed container"},
+	{"showContainerMenu", containersScope, []string{"Enter"}, "Shows the command menu of the selected container"},
+
+	{"closeContainerMenu", containerMenuScope, []string{"Esc"}, "Goes back to the container list"},
+	{"runContainerCommand", containerMenuScope, []string{"Enter"}, "Runs the selected command"},
+
+	{"closeContainerLinks", containerLinksScope, []string{"Esc"}, "Goes back to the container list"},
+	{"refreshContainerLinks", containerLinksScope, []string{"F5"}, "Inspects the containers again"},
+	{"toggleContainerLinks", containerLinksScope, []string{"Space", "ArrowRight"}, "Shows or hides the links of the selected container"},
+	{"jumpToContainer", containerLinksScope, []string{"Enter"}, "Goes back to the container list with the selected container on the cursor"},
+
+	{"closeContainerHealth", containerHealthScope, []string{"Esc"}, "Goes back to the container list"},
+	{"refreshContainerHealth", containerHealthScope, []string{"F5"}, "Inspects the container again"},
+	{"toggleHealthProbe", containerHealthScope, []string{"Space", "Enter"}, "Shows or hides the whole output of the selected probe"},
+	{"showHealthContainerLogs", containerHealthScope, []string{"l", "L"}, "Displays the logs of the container"},
+
+	{"closeContainerEnv", containerEnvScope, []string{"Esc"}, "Goes back to the container list"},
+	{"refreshContainerEnv", containerEnvScope, []string{"F5"}, "Inspects the container again"},
+	{"filterContainerEnv", containerEnvScope, []string{"%"}, "Filters the environment variables and labels by key or value"},
+	{"copyEnvValue", containerEnvScope, []string{"c", "C"}, "Copies the value of the selected entry to the clipboard"},
+	{"toggleEnvSecrets", containerEnvScope, []string{"s", "S"}, "Shows or masks the values of the keys that look secret (PASSWORD, TOKEN, KEY...)"},
+
+	{"closeContainerMounts", containerMountsScope, []string{"Esc"}, "Goes back to the container list"},
+	{"refreshContainerMounts", containerMountsScope, []string{"F5"}, "Inspects the container again"},
+	{"showVolumeContainers", containerMountsScope, []string{"Enter"}, "Lists the containers using the selected volume (volume: filter)"},
+	{"copyMountHostPath", containerMountsScope, []string{"c", "C"}, "Copies the host path of the selected bind mount to the clipboard"},
+	{"inspectMountVolume", containerMountsScope, []string{"i", "I"}, "Inspects the selected volume"},
+
+	{"closeContainerProcesses", containerProcessesScope, []string{"Esc"}, "Goes back to the container list"},
+	{"sortContainerProcesses", containerProcessesScope, []string{"F1"}, "Cycles through sort modes (PID, CPU usage and command)"},
+	{"refreshContainerProcesses", containerProcessesScope, []string{"F5"}, "Lists the processes again, they are listed again every 2 seconds anyway"},
+
+	{"sortMonitor", monitorScope, []string{"F1"}, "Cycles through sort modes (name, CPU, memory, memory %, network and block I/O)"},
+	{"increaseRefreshRate", monitorScope, []string{"+"}, "Increases the refresh rate"},
+	{"decreaseRefreshRate", monitorScope, []string{"-"}, "Decreases the refresh rate"},
+	{"togglePause", monitorScope, []string{"p", "P"}, "Pauses/resumes the monitor, the last values are shown while paused"},
+	{"showMonitorStatsHistory", monitorScope, []string{"Ctrl+g"}, "Displays graphs of the selected container resource usage over time"},
+	{"toggleStatsRecording", monitorScope, []string{"r", "R"}, "Starts/stops recording the stats history of every container"},
+	{"toggleNetworkTraffic", monitorScope, []string{"n", "N"}, "Shows the network traffic rates of every container, with their recent peaks, or its stats. F1 sorts them by received or sent traffic"},
+	{"exportStats", monitorScope, []string{"x", "X"}, "Exports the recorded stats history, or the current stats if not recording, to a CSV file"},
+	{"editThresholds", monitorScope, []string{"t", "T"}, "Edits the alert thresholds (CPU and memory percentage, container exits)"},
+	{"showAlerts", monitorScope, []string{"a", "A"}, "Shows the alerts raised"},
+	{"showMonitorContainerMenu", monitorScope, []string{"Enter"}, "Shows the command menu of the selected container"},
+
+	{"cancelJob", jobsScope, []string{"c", "C"}, "Cancels the selected job, running jobs can only be canceled if the operation supports it"},
+
+	{"sortImages", imagesScope, []string{"F1"}, "Cycles through sort modes"},
+	{"toggleDanglingImages", imagesScope, []string{"F2"}, "Toggles showing only dangling images (default shows all)"},
+	{"reverseSortImages", imagesScope, []string{"F3"}, "Switches the order of the sort column between ascending and descending, each column keeps its own"},
+	{"refreshImages", imagesScope, []string{"F5"}, "Refreshes the list"},
+	{"filterImages", imagesScope, []string{"%"}, "Filter"},
+	{"searchImages", imagesScope, []string{"/"}, "Searches the list, the cursor jumps to the rows that match as the search is typed"},
+	{"searchNextImage", imagesScope, []string{"Ctrl+n"}, "Jumps to the next row that matches the search"},
+	{"searchPreviousImage", imagesScope, []string{"Ctrl+p"}, "Jumps to the previous row that matches the search"},
+	{"removeDanglingImages", imagesScope, []string{"Ctrl+d"}, "Removes dangling images"},
+	{"pruneImages", imagesScope, []string{"Ctrl+y"}, "Prunes the dangling or all unused images, as docker image prune does, optionally only those created before a time or with some labels, and shows what was removed"},
+	{"removeImage", imagesScope, []string{"Ctrl+e"}, "Removes the selected image"},
+	{"forceRemoveImage", imagesScope, []string{"Ctrl+f"}, "Forces removal of the selected image"},
+	{"tagImage", imagesScope, []string{"t", "T"}, "Tags the selected image with the repository[:tag] given"},
+	{"untagImage", imagesScope, []string{"Ctrl+t"}, "Removes the tag chosen from the selected image, unless it is its only one"},
+	{"showImageSizes", imagesScope, []string{"z", "Z"}, "Shows the size of each layer of the selected image as a bar of its share of the image size, largest first"},
+	{"scanImage", imagesScope, []string{"v", "V"}, "Scans the selected image for vulnerabilities with the configured scanner, trivy by default, and shows them grouped by severity"},
+	{"showImagePlatforms", imagesScope, []string{"a", "A"}, "Shows the platforms the registry of the selected image has it for, with the digest and size of the image of each one"},
+	{"showImageHistory", imagesScope, []string{"i", "I"}, "Shows the history of the selected image, with the size of each layer and its share of the image size"},
+	{"runImageWizard", imagesScope, []string{"n", "N"}, "Creates and runs a new container from the selected image, asking step by step for its name, ports, environment, volumes and network"},
+	{"buildImage", imagesScope, []string{"b", "B"}, "Builds an image from the build context and Dockerfile given, showing the build output, the image built is selected on the list"},
+	{"pullImage", imagesScope, []string{"p", "P"}, "Pulls the image with the given reference, showing the progress of each layer, the pull goes on as a background job once closed"},
+	{"pushImage", imagesScope, []string{"u", "U"}, "Pushes the selected image to its registry, with the credentials of the Docker CLI or the ones asked for, showing the progress of each layer"},
+	{"saveImage", imagesScope, []string{"s", "S"}, "Saves the selected image, by its tags, to the tar archive given, as docker save does"},
+	{"loadImage", imagesScope, []string{"o", "O"}, "Loads the images of the tar archive given, as docker load does"},
+	{"runImage", imagesScope, []string{"r", "R"}, "Runs a command in a new container created from the selected image"},
+	{"inspectImage", imagesScope, []string{"Enter"}, "Returns low-level information of the selected image"},
+	{"markImage", imagesScope, []string{"Space"}, "Marks the selected image for comparison, up to two images can be marked"},
+	{"compareImages", imagesScope, []string{"c", "C"}, "Compares the two marked images: layers, size, exposed ports, env and labels"},
+	{"exportImageComparison", imagesScope, []string{"x", "X"}, "Exports the comparison of the two marked images to a text file"},
+	{"browseImageLayer", imagesScope, []string{"l", "L"}, "Browses the files of a layer of the selected image, the image is saved to read it"},
+	{"chooseImageColumns", imagesScope, []string{"|"}, "Hides the column chosen from a menu, or shows it again if hidden"},
+	{"resizeImageColumns", imagesScope, []string{"Ctrl+w"}, "Resizes the columns, arrow keys choose the column and +/- resize it until Esc"},
+	{"showImageChildren", imagesScope, []string{"d", "D"}, "Lists the images built on the selected image"},
+
+	{"closeImageHistory", imageHistoryScope, []string{"Esc"}, "Goes back to the image list"},
+	{"sortImageHistory", imageHistoryScope, []string{"F1"}, "Cycles through sort modes (history order and layer size, largest first)"},
+	{"refreshImageHistory", imageHistoryScope, []string{"F5"}, "Reads the history of the image again"},
+
+	{"sortImageSizes", imageSizesScope, []string{"F1"}, "Cycles through sort modes (layer size, largest first, and history order)"},
+	{"refreshImageSizes", imageSizesScope, []string{"F5"}, "Reads the layers of the image again"},
+	{"closeImageSizes", imageSizesScope, []string{"Esc"}, "Goes back to the image list"},
+
+	{"toggleFixableVulnerabilities", imageScanScope, []string{"F1"}, "Shows only the vulnerabilities with a fixed version, or every vulnerability again"},
+	{"setImageScanner", imageScanScope, []string{"F2"}, "Changes the command images are scanned with, {image} is replaced by the image, and scans the image again with it"},
+	{"rescanImage", imageScanScope, []string{"F5"}, "Scans the image again"},
+	{"closeImageScan", imageScanScope, []string{"Esc"}, "Goes back to the image list, canceling the scan if it has not finished"},
+
+	{"inspectImageReference", imageManifestScope, []string{"/"}, "Shows the platforms of the image reference given, i.e. one that is not pulled"},
+	{"refreshImagePlatforms", imageManifestScope, []string{"F5"}, "Fetches the manifest again"},
+	{"closeImagePlatforms", imageManifestScope, []string{"Esc"}, "Goes back to the image list"},
+
+	{"searchRegistry", registrySearchScope, []string{"/"}, "Searches the registry for the term given, a term that starts with the host of a registry is searched on it"},
+	{"pullSearchResult", registrySearchScope, []string{"Enter"}, "Pulls the selected image, with its latest tag, showing the progress of each layer"},
+	{"setSearchRegistry", registrySearchScope, []string{"F2"}, "Changes the registry searched, Docker Hub by default, and searches the last term on it"},
+	{"refreshRegistrySearch", registrySearchScope, []string{"F5"}, "Searches the last term again"},
+	{"closeRegistrySearch", registrySearchScope, []string{"Esc"}, "Goes to the image list"},
+
+	{"closeImageLayer", imageLayerScope, []string{"Esc"}, "Goes back to the image list, canceling the read of the image if it has not finished"},
+	{"refreshImageLayer", imageLayerScope, []string{"F5"}, "Reads the layer again"},
+	{"toggleLayerDir", imageLayerScope, []string{"Space", "Enter", "ArrowRight"}, "Shows or hides the content of the selected directory"},
+	{"collapseLayerDir", imageLayerScope, []string{"ArrowLeft"}, "Hides the content of the selected directory, or of the directory it is in"},
+
+	{"sortNetworks", networksScope, []string{"F1"}, "Cycles through sort modes"},
+	{"reverseSortNetworks", networksScope, []string{"F3"}, "Switches the order of the sort column between ascending and descending, each column keeps its own"},
+	{"refreshNetworks", networksScope, []string{"F5"}, "Refreshes the list"},
+	{"filterNetworks", networksScope, []string{"%"}, "Filter"},
+	{"searchNetworks", networksScope, []string{"/"}, "Searches the list, the cursor jumps to the rows that match as the search is typed"},
+	{"searchNextNetwork", networksScope, []string{"n"}, "Jumps to the next row that matches the search"},
+	{"searchPreviousNetwork", networksScope, []string{"N"}, "Jumps to the previous row that matches the search"},
+	{"removeNetwork", networksScope, []string{"Ctrl+e"}, "Removes the selected network"},
+	{"inspectNetwork", networksScope, []string{"Enter"}, "Returns low-level information of the selected network"},
+	{"toggleNetworkIPAM", networksScope, []string{"s", "S"}, "Shows or hides the subnet and gateway columns"},
+	{"showNetworkIPAM", networksScope, []string{"d", "D"}, "Shows every IPAM config of the selected network and the networks or host interfaces its subnets overlap with"},
+
+	{"refreshPlugins", pluginsScope, []string{"F5"}, "Refreshes the list"},
+	{"filterPlugins", pluginsScope, []string{"%"}, "Filter"},
+	{"togglePlugin", pluginsScope, []string{"e", "E"}, "Enables the selected plugin if it is disabled, disables it otherwise"},
+	{"removePlugin", pluginsScope, []string{"Ctrl+e"}, "Removes the selected plugin, enabled plugins are not removed"},
+	{"forceRemovePlugin", pluginsScope, []string{"Ctrl+f"}, "Forces removal of the selected plugin, even if it is enabled"},
+	{"inspectPlugin", pluginsScope, []string{"Enter"}, "Returns low-level information of the selected plugin, such as its settings and mounts"},
+
+	{"sortNodes", nodesScope, []string{"F1"}, "Cycles through sort modes"},
+	{"refreshNodes", nodesScope, []string{"F5"}, "Refreshes the list"},
+	{"filterNodes", nodesScope, []string{"%"}, "Filter"},
+	{"searchNodes", nodesScope, []string{"/"}, "Searches the list, the cursor jumps to the rows that match as the search is typed"},
+	{"searchNextNode", nodesScope, []string{"n"}, "Jumps to the next row that matches the search"},
+	{"searchPreviousNode", nodesScope, []string{"N"}, "Jumps to the previous row that matches the search"},
+	{"setNodeAvailability", nodesScope, []string{"Ctrl+a"}, "Changes the availability of the selected node"},
+	{"showNodeTasks", nodesScope, []string{"Enter"}, "Shows the list of tasks running on the selected node"},
+	{"showSwarm", nodesScope, []string{"s", "S"}, "Shows the swarm, its join commands and the actions to manage it"},
+
+	{"sortServices", servicesScope, []string{"F1"}, "Cycles through sort modes"},
+	{"refreshServices", servicesScope, []string{"F5"}, "Refreshes the list"},
+	{"filterServices", servicesScope, []string{"%"}, "Filter"},
+	{"searchServices", servicesScope, []string{"/"}, "Searches the list, the cursor jumps to the rows that match as the search is typed"},
+	{"searchNextService", servicesScope, []string{"n"}, "Jumps to the next row that matches the search"},
+	{"searchPreviousService", servicesScope, []string{"N"}, "Jumps to the previous row that matches the search"},
+	{"inspectService", servicesScope, []string{"i"}, "Inspects the selected service"},
+	{"showServiceLogs", servicesScope, []string{"l"}, "Displays the logs of the selected service"},
+	{"showServiceLogsWithTimestamps", servicesScope, []string{"Ctrl+l"}, "Displays the logs of the selected service with Docker timestamps"},
+	{"removeService", servicesScope, []string{"Ctrl+r"}, "Removes the selected service"},
+	{"scaleService", servicesScope, []string{"Ctrl+s"}, "Scales the selected service"},
+	{"updateService", servicesScope, []string{"Ctrl+u"}, "Forces an update of the selected service"},
+	{"showServiceEndpoint", servicesScope, []string{"e", "E"}, "Shows the published ports of the selected service and its virtual IP on each network"},
+	{"showServiceTasks", servicesScope, []string{"Enter"}, "Shows the list of tasks that are part of the selected service"},
+	{"closeStackServices", servicesScope, []string{"Esc"}, "Goes back to the stack list when showing the services of a stack"},
+
+	{"sortStacks", stacksScope, []string{"F1"}, "Cycles through sort modes"},
+	{"refreshStacks", stacksScope, []string{"F5"}, "Refreshes the list"},
+	{"filterStacks", stacksScope, []string{"%"}, "Filter"},
+	{"searchStacks", stacksScope, []string{"/"}, "Searches the list, the cursor jumps to the rows that match as the search is typed"},
+	{"searchNextStack", stacksScope, []string{"n"}, "Jumps to the next row that matches the search"},
+	{"searchPreviousStack", stacksScope, []string{"N"}, "Jumps to the previous row that matches the search"},
+	{"removeStack", stacksScope, []string{"Ctrl+r"}, "Removes the selected stack, optionally with its secrets and configs"},
+	{"showStackServices", stacksScope, []string{"Enter"}, "Shows the list of services of the selected stack"},
+	{"showStackTasks", stacksScope, []string{"t", "T"}, "Shows the list of tasks of the selected stack"},
+
+	{"closeSwarm", swarmScope, []string{"Esc"}, "Goes back to the node list, or to the container list if the Docker host is not part of a swarm"},
+	{"refreshSwarm", swarmScope, []string{"F5"}, "Refreshes the state of the swarm"},
+	{"initSwarm", swarmScope, []string{"i", "I"}, "Inits a new swarm with the Docker host as its first manager"},
+	{"joinSwarm", swarmScope, []string{"j", "J"}, "Joins the Docker host to a swarm, as a worker or as a manager depending on the token"},
+	{"leaveSwarm", swarmScope, []string{"Ctrl+l"}, "Leaves the swarm, optionally forcing it"},
+	{"copyWorkerJoinCommand", swarmScope, []string{"c"}, "Copies the command to join the swarm as a worker to the clipboard"},
+	{"copyManagerJoinCommand", swarmScope, []string{"C"}, "Copies the command to join the swarm as a manager to the clipboard"},
+	{"rotateJoinTokens", swarmScope, []string{"Ctrl+r"}, "Replaces the worker or the manager join token, or both, by new ones"},
+
+	{"closeTasks", tasksScope, []string{"Esc"}, "Goes back to the previous list"},
+	{"sortTasks", tasksScope, []string{"F1"}, "Cycles through sort modes"},
+	{"refreshTasks", tasksScope, []string{"F5"}, "Refreshes the list"},
+	{"filterTasks", tasksScope, []string{"%"}, "Filter"},
+	{"inspectTask", tasksScope, []string{"Enter"}, "Returns low-level information of the selected task"},
+	{"filterTasksByDesiredState", tasksScope, []string{"d", "D"}, "Cycles through showing all tasks, only those meant to run and only those meant to be shut down"},
+	{"showFailedTasks", tasksScope, []string{"f", "F"}, "Shows only the failed tasks, or all of them again"},
+	{"showTaskError", tasksScope, []string{"e", "E"}, "Shows the full error of the selected task"},
+
+	{"prune", diskUsageScope, []string{"p", "P"}, "Removes all unused data (stopped containers, dangling images, unused networks and volumes)"},
+	{"showBuildCache", diskUsageScope, []string{"b", "B"}, "Shows the records of the build cache"},
+
+	{"closeBuildCache", buildCacheScope, []string{"Esc"}, "Goes back to disk usage"},
+	{"sortBuildCache", buildCacheScope, []string{"F1"}, "Cycles through sort modes"},
+	{"refreshBuildCache", buildCacheScope, []string{"F5"}, "Refreshes the list"},
+	{"filterBuildCache", buildCacheScope, []string{"%"}, "Filter"},
+	{"pruneBuildCache", buildCacheScope, []string{"p", "P"}, "Prunes the build cache, keeping the given storage and the records used recently. Records in use are never pruned"},
+}
+
+//keymap binds actions to key chords
+type keymap struct {
+	//bindings are the keys bound to each action
+	bindings map[string][]keyChord
+	//active translates, per scope, the keys bound to an action to the
+	//default key of the action
+	active map[string]map[keyChord]keyChord
+	//unbound are, per scope, the default keys that are no longer bound
+	unbound map[string]map[keyChord]bool
+}
+
+//quitChords are the keys that quit dry, they cannot be bound to actions
+var quitChords = mustParseKeyChords([]string{"q", "Q", "Ctrl+c"})
+
+//activeKeymap is the keymap in use
+var activeKeymap, _ = newKeymap(nil)
+
+//newKeymap creates a keymap that binds the given keys, by action name, the
+//actions not found are bound to their default keys. An error listing all the
+//problems found, i.e. unknown actions, invalid keys or keys bound more than
+//once in the same scope, is returned if the given keys cannot be used.
+func newKeymap(custom map[string][]string) (*keymap, error) {
+	var problems []string
+	known := make(map[string]bool)
+	for _, action := range keyActions {
+		known[action.name] = true
+	}
+	var names []string
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[name] {
+			problems = append(problems, fmt.Sprintf("unknown action %q", name))
+		}
+	}
+
+	k := &keymap{
+		bindings: make(map[string][]keyChord),
+		active:   make(map[string]map[keyChord]keyChord),
+		unbound:  make(map[string]map[keyChord]bool),
+	}
+	//actions bound to each key, per scope
+	boundTo := make(map[string]map[keyChord][]string)
+	for _, scope := range keymapScopes {
+		k.active[scope] = make(map[keyChord]keyChord)
+		k.unbound[scope] = make(map[keyChord]bool)
+		boundTo[scope] = make(map[keyChord][]string)
+	}
+
+	for _, action := range keyActions {
+		defaults := mustParseKeyChords(action.keys)
+		keys := defaults
+		if customKeys, ok := custom[action.name]; ok {
+			keys = nil
+			for _, s := range customKeys {
+				chord, err := parseKeyChord(s)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s: %s", action.name, err.Error()))
+					continue
+				}
+				if containsChord(quitChords, chord) {
+					problems = append(problems, fmt.Sprintf("%s: %s is reserved to quit dry", action.name, chord))
+					continue
+				}
+				keys = append(keys, chord)
+			}
+		}
+		k.bindings[action.name] = keys
+		for _, chord := range keys {
+			k.active[action.scope][chord] = defaults[0]
+			boundTo[action.scope][chord] = append(boundTo[action.scope][chord], action.name)
+		}
+		for _, chord := range defaults {
+			if !containsChord(keys, chord) {
+				k.unbound[action.scope][chord] = true
+			}
+		}
+	}
+
+	for _, scope := range keymapScopes {
+		for chord, actions := range boundTo[scope] {
+			if scope != globalScope {
+				actions = append(actions, boundTo[globalScope][chord]...)
+			}
+			if len(actions) > 1 {
+				problems = append(problems,
+					fmt.Sprintf("%s is bound to %s (%s)", chord, strings.Join(actions, ", "), scope))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New("invalid key bindings:\n\t" + strings.Join(problems, "\n\t"))
+	}
+	return k, nil
+}
+
+//translate translates the given event, if it is a key bound to an action of the given
+//scope or of the global scope, to the default key of the action. Returns false if the
+//event is a default key no longer bound to its action, so it must be ignored.
+func (k *keymap) translate(scope string, e termbox.Event) (termbox.Event, bool) {
+	if e.Type != termbox.EventKey {
+		return e, true
+	}
+	chord := chordOf(e)
+	for _, s := range []string{scope, globalScope} {
+		if defaultChord, ok := k.active[s][chord]; ok {
+			if defaultChord == chord {
+				return e, true
+			}
+			return defaultChord.event(), true
+		}
+	}
+	for _, s := range []string{scope, globalScope} {
+		if k.unbound[s][chord] {
+			return e, false
+		}
+	}
+	return e, true
+}
+
+//keys returns the keys bound to the given action, separated by "/"
+func (k *keymap) keys(action string) string {
+	var keys []string
+	for _, chord := range k.bindings[action] {
+		keys = append(keys, chord.String())
+	}
+	return strings.Join(keys, "/")
+}
+
+//key returns the first key bound to the given action
+func (k *keymap) key(action string) string {
+	if chords := k.bindings[action]; len(chords) > 0 {
+		return chords[0].String()
+	}
+	return ""
+}
+
+//actionRef matches references to actions on footer texts, i.e. {sortContainers}
+var actionRef = regexp.MustCompile(`\{(\w+)\}`)
+
+//expand replaces the references to actions on the given text by the key bound to the action
+func (k *keymap) expand(text string) string {
+	return actionRef.ReplaceAllStringFunc(text, func(ref string) string {
+		return k.key(ref[1 : len(ref)-1])
+	})
+}
+
+//help returns the description of the keys bound to each action, along with
+//the action name to use on the keymap preference
+func (k *keymap) help() string {
+	var buf bytes.Buffer
+	for _, scope := range keymapScopes {
+		fmt.Fprintf(&buf, "<yellow>%s keybinds</>\n", scope)
+		for _, action := range keyActions {
+			if action.scope != scope {
+				continue
+			}
+			keys := k.keys(action.name)
+			if keys == "" {
+				keys = "-"
+			}
+			fmt.Fprintf(&buf, "\t<white>%-9s</> %s [%s]\n", keys, action.description, action.name)
+		}
+		if scope == globalScope {
+			buf.WriteString("\t<white>Ctrl+c</>    Quits <white>dry</> immediately\n")
+			buf.WriteString("\t<white>q</>         Quits <white>dry</>\n")
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+//viewBindings returns the key bindings of the actions of the given scope and
+//of the global ones. Global bindings are shadowed if every key bound to them
+//is also bound to an action of the scope.
+func (k *keymap) viewBindings(scope string) (view []appui.KeyBinding, global []appui.KeyBinding) {
+	for _, action := range keyActions {
+		if action.scope != scope || scope == globalScope {
+			continue
+		}
+		if keys := k.keys(action.name); keys != "" {
+			view = append(view, appui.KeyBinding{Keys: keys, Description: action.description})
+		}
+	}
+	for _, action := range keyActions {
+		if action.scope != globalScope {
+			continue
+		}
+		chords := k.bindings[action.name]
+		if len(chords) == 0 {
+			continue
+		}
+		shadowed := scope != globalScope
+		for _, chord := range chords {
+			if _, ok := k.active[scope][chord]; !ok {
+				shadowed = false
+			}
+		}
+		global = append(global, appui.KeyBinding{
+			Keys: k.keys(action.name), Description: action.description, Shadowed: shadowed})
+	}
+	global = append(global, appui.KeyBinding{Keys: "q/Ctrl+c", Description: "Quits dry"})
+	return view, global
+}
+
+//keymapScope returns the keymap scope of the given view
+func keymapScope(view viewMode) string {
+	switch view {
+	case Main:
+		return containersScope
+	case ContainerMenu:
+		return containerMenuScope
+	case ContainerGraph:
+		return containerLinksScope
+	case ContainerHealth:
+		return containerHealthScope
+	case ContainerEnv:
+		return containerEnvScope
+	case ContainerMounts:
+		return containerMountsScope
+	case ContainerProcesses:
+		return containerProcessesScope
+	case Monitor:
+		return monitorScope
+	case Jobs:
+		return jobsScope
+	case Images:
+		return imagesScope
+	case ImageHistory:
+		return imageHistoryScope
+	case ImageLayer:
+		return imageLayerScope
+	case ImageSizes:
+		return imageSizesScope
+	case ImageScan:
+		return imageScanScope
+	case ImageManifest:
+		return imageManifestScope
+	case RegistrySearch:
+		return registrySearchScope
+	case Networks:
+		return networksScope
+	case Plugins:
+		return pluginsScope
+	case Nodes:
+		return nodesScope
+	case Services:
+		return servicesScope
+	case Stacks:
+		return stacksScope
+	case SwarmStatus:
+		return swarmScope
+	case Tasks, ServiceTasks, StackTasks:
+		return tasksScope
+	case DiskUsage:
+		return diskUsageScope
+	case BuildCache:
+		return buildCacheScope
+	}
+	return globalScope
+}
+
+func mustParseKeyChords(keys []string) []keyChord {
+	chords := make([]keyChord, len(keys))
+	for i, key := range keys {
+		chord, err := parseKeyChord(key)
+		if err != nil {
+			panic(err)
+		}
+		chords[i] = chord
+	}
+	return chords
+}
+
+func containsChord(chords []keyChord, chord keyChord) bool {
+	for _, c := range chords {
+		if c == chord {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestParseKeyChord(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    keyChord
+		wantErr bool
+	}{
+		{"e", keyChord{ch: 'e'}, false},
+		{"%", keyChord{ch: '%'}, false},
+		{"F1", keyChord{key: termbox.KeyF1}, false},
+		{"Enter", keyChord{key: termbox.KeyEnter}, false},
+		{"Space", keyChord{key: termbox.KeySpace}, false},
+		{"Ctrl+e", keyChord{key: termbox.KeyCtrlE}, false},
+		{"Ctrl+E", keyChord{key: termbox.KeyCtrlE}, false},
+		{"Alt+d", keyChord{ch: 'd', mod: termbox.ModAlt}, false},
+		{"Alt+F2", keyChord{key: termbox.KeyF2, mod: termbox.ModAlt}, false},
+		{"Ctrl+1", keyChord{}, true},
+		{"Shift+e", keyChord{}, true},
+		{"", keyChord{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := parseKeyChord(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseKeyChord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseKeyChord() = %v, want %v", got, tt.want)
+			}
+			again, err := parseKeyChord(got.String())
+			if err != nil || again != got {
+				t.Errorf("%s does not parse back to the same key chord: %v", got.String(), err)
+			}
+		})
+	}
+}
+
+func TestNewKeymapErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		custom map[string][]string
+		want   string
+	}{
+		{"unknown action", map[string][]string{"fly": {"f"}}, `unknown action "fly"`},
+		{"invalid key", map[string][]string{"removeContainer": {"Ctrl+%"}}, `invalid key "Ctrl+%"`},
+		{"duplicated in scope", map[string][]string{"removeContainer": {"i"}}, "i is bound to removeContainer, inspectContainer"},
+		{"duplicated with a global key", map[string][]string{"removeImage": {"m"}}, "m is bound to removeImage, showMonitor"},
+		{"quit key", map[string][]string{"removeContainer": {"q"}}, "q is reserved to quit dry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKeymap(tt.custom)
+			if err == nil {
+				t.Fatal("newKeymap() did not fail")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("newKeymap() error = %q, want it to contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestKeymapTranslate(t *testing.T) {
+	k, err := newKeymap(map[string][]string{
+		"removeContainer": {"Alt+e"},
+		"showImages":      {"Alt+2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := func(s string) termbox.Event {
+		chord, _ := parseKeyChord(s)
+		return chord.event()
+	}
+	tests := []struct {
+		name      string
+		scope     string
+		event     termbox.Event
+		want      termbox.Event
+		wantBound bool
+	}{
+		{"remapped key", containersScope, key("Alt+e"), key("e"), true},
+		{"old default key", containersScope, key("e"), key("e"), false},
+		{"remapped key on another scope", imagesScope, key("Alt+e"), key("Alt+e"), true},
+		{"remapped global key", imagesScope, key("Alt+2"), key("2"), true},
+		{"old default global key", networksScope, key("2"), key("2"), false},
+		{"default key", containersScope, key("Ctrl+e"), key("Ctrl+e"), true},
+		{"unbound key", containersScope, key("z"), key("z"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, bound := k.translate(tt.scope, tt.event)
+			if bound != tt.wantBound {
+				t.Fatalf("translate() bound = %v, want %v", bound, tt.wantBound)
+			}
+			if bound && got != tt.want {
+				t.Errorf("translate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeymapHelp(t *testing.T) {
+	k, err := newKeymap(map[string][]string{"removeContainer": {"Alt+e", "Alt+d"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if help := k.help(); !strings.Contains(help, "<white>Alt+e/Alt+d</> Removes the selected container") {
+		t.Errorf("Help does not show the custom keys: %s", help)
+	}
+	if footer := k.expand("<b>[{removeContainer}]:<darkgrey>Remove</>"); footer != "<b>[Alt+e]:<darkgrey>Remove</>" {
+		t.Errorf("Unexpected footer: %s", footer)
+	}
+}
+
+func TestKeymapViewBindings(t *testing.T) {
+	k, err := newKeymap(map[string][]string{"removeContainer": {"Alt+e", "Alt+d"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, global := k.viewBindings(containersScope)
+	found := false
+	for _, b := range view {
+		if b.Keys == "Alt+e/Alt+d" && b.Description == "Removes the selected container" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("The view bindings do not show the custom keys: %v", view)
+	}
+	for _, b := range global {
+		if b.Shadowed {
+			t.Errorf("Unexpected shadowed global binding: %v", b)
+		}
+	}
+
+	//the container list binds the key of a global action
+	k.active[containersScope][keyChord{ch: 'm'}] = keyChord{ch: 'm'}
+	k.active[containersScope][keyChord{ch: 'M'}] = keyChord{ch: 'M'}
+	_, global = k.viewBindings(containersScope)
+	for _, b := range global {
+		if shadowed := b.Keys == "m/M"; b.Shadowed != shadowed {
+			t.Errorf("Unexpected shadowing of %v", b)
+		}
+	}
+
+	view, global = k.viewBindings(globalScope)
+	if len(view) != 0 || len(global) == 0 {
+		t.Errorf("Unexpected bindings of the global scope: %v, %v", view, global)
+	}
+}
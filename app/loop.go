@@ -2,7 +2,6 @@ package app
 
 import (
 	"sync"
-	"time"
 
 	"github.com/moncho/dry/ui"
 	"github.com/nsf/termbox-go"
@@ -34,8 +33,6 @@ func RenderLoop(dry *Dry, screen *ui.Screen) {
 		return nil
 	}
 
-	dryOutputChan := dry.OuputChannel()
-
 	defer close(done)
 	defer close(eventChan)
 	//make the global refreshScreen a noop before closing
@@ -52,7 +49,8 @@ func RenderLoop(dry *Dry, screen *ui.Screen) {
 	//renders dry on message until renderChan is closed
 	go func() {
 		for range renderChan {
-			if !screen.Closing() {
+			//nothing is rendered while a container has the terminal
+			if !screen.Closing() && !screen.Suspended() {
 				screen.Clear()
 				render(dry, screen)
 			}
@@ -62,28 +60,50 @@ func RenderLoop(dry *Dry, screen *ui.Screen) {
 	refreshScreen()
 
 	go func() {
-		statusBar := widgets.MessageBar
+		//Initial handler
+		handler := viewsToHandlers[dry.viewMode()]
+		//questions from the background wait for the prompts on screen, woken
+		//is signalled when one is answered
+		var questions []question
+		woken := make(chan struct{}, 1)
+		setHandler := func(eh eventHandler) {
+			handler = eh
+			//lists do not refresh on their own while a prompt or a dialog is open
+			_, forwarding := eh.(eventHandlerForwarder)
+			dry.autoRefresher.suspend(forwarding)
+			if !forwarding {
+				select {
+				case woken <- struct{}{}:
+				default:
+				}
+			}
+		}
+
 		for {
 			select {
-			case dryMessage, ok := <-dryOutputChan:
-				if ok {
-					statusBar.Message(dryMessage, 10*time.Second)
-					statusBar.Render()
-				} else {
+			case event, ok := <-eventChan:
+				if !ok {
 					return
 				}
+				//Keys are translated to the default keys of the actions they
+				//are bound to, forwarders get the keys pressed (i.e. on prompts)
+				if _, forwarding := handler.(eventHandlerForwarder); !forwarding {
+					translated, bound := activeKeymap.translate(keymapScope(dry.viewMode()), event)
+					if !bound {
+						continue
+					}
+					event = translated
+				}
+				handler.handle(event, setHandler)
+			case q := <-dry.questions:
+				questions = append(questions, q)
+			case <-woken:
+			}
+			if _, forwarding := handler.(eventHandlerForwarder); !forwarding && len(questions) > 0 {
+				q := questions[0]
+				questions = questions[1:]
+				q(handler, setHandler)
 			}
-		}
-	}()
-
-	go func() {
-		//Initial handler
-		handler := viewsToHandlers[dry.viewMode()]
-
-		for event := range eventChan {
-			handler.handle(event, func(eh eventHandler) {
-				handler = eh
-			})
 		}
 	}()
 
@@ -108,7 +128,7 @@ loop:
 		case termbox.EventResize:
 			ui.Resize()
 			//Reload dry ui elements
-			widgets = newWidgetRegistry(dry.dockerDaemon)
+			dry.initWidgets()
 		}
 	}
 
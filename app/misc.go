@@ -1,6 +1,9 @@
 package app
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/ui"
 	termbox "github.com/nsf/termbox-go"
@@ -10,6 +13,11 @@ func logsPrompt() *appui.Prompt {
 	return appui.NewPrompt("Show logs since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes) or leave empty")
 }
 
+//ringBell rings the terminal bell
+func ringBell() {
+	fmt.Fprint(os.Stdout, "\a")
+}
+
 func newEventSource(events <-chan termbox.Event) ui.EventSource {
 	return ui.EventSource{
 		Events: events,
@@ -19,8 +27,10 @@ func newEventSource(events <-chan termbox.Event) ui.EventSource {
 	}
 }
 
+//inspect returns a function that shows what the given inspect function
+//returns for an id on the inspect widget, the given events are sent to the
+//widget until it is closed, then onClose is called.
 func inspect(
-	screen *ui.Screen,
 	events <-chan termbox.Event,
 	inspect func(id string) (interface{}, error),
 	onClose func()) func(id string) error {
@@ -29,8 +39,68 @@ func inspect(
 		if err != nil {
 			return err
 		}
-		renderer := appui.NewJSONRenderer(inspected)
-		go appui.Less(renderer, screen, events, onClose)
+		w, err := appui.NewInspectWidget(id, inspected)
+		if err != nil {
+			return err
+		}
+		widgets.add(w)
+		refreshScreen()
+		go func() {
+			w.OnFocus(newEventSource(events))
+			widgets.remove(w)
+			onClose()
+		}()
 		return nil
 	}
 }
+
+//askText asks the user for some text, onText is only called if the question
+//is not canceled. Once answered, h handles the next events.
+func askText(h eventHandler, question string, f func(eventHandler), onText func(string)) {
+	ask(h, appui.NewPrompt(question), f, onText)
+}
+
+//askSecret asks the user for some text, as askText does, without showing
+//the text typed
+func askSecret(h eventHandler, question string, f func(eventHandler), onText func(string)) {
+	ask(h, appui.NewSecretPrompt(question), f, onText)
+}
+
+func ask(h eventHandler, prompt *appui.Prompt, f func(eventHandler), onText func(string)) {
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		if !canceled {
+			onText(text)
+		}
+		refreshScreen()
+	}()
+}
+
+//askChoice asks the user to pick one of the given choices, onChoice is only
+//called if a choice is made. Once answered, h handles the next events.
+func askChoice(h eventHandler, title string, choices []string, f func(eventHandler), onChoice func(string)) {
+	chooser := appui.NewChoicePrompt(title, choices, choices[0])
+	widgets.add(chooser)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		chooser.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(chooser)
+		choice, canceled := chooser.Choice()
+		f(h)
+		if !canceled {
+			onChoice(choice)
+		}
+		refreshScreen()
+	}()
+}
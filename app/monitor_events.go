@@ -1,7 +1,14 @@
 package app
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
 	termbox "github.com/nsf/termbox-go"
 )
 
@@ -22,6 +29,32 @@ func (h *monitorScreenEventHandler) handle(event termbox.Event, f func(eventHand
 		handled = true
 		cursor.ScrollCursorDown()
 		h.widget.OnEvent(nil)
+	case termbox.KeyF1: //sort
+		handled = true
+		h.widget.Sort()
+		h.widget.OnEvent(nil)
+		renderStatusBar(h.dry, h.screen)
+	case termbox.KeyCtrlG: //Stats history
+		handled = true
+		showHistory := func(id string) error {
+			container := h.dry.dockerDaemon.ContainerByID(id)
+			if container == nil {
+				return fmt.Errorf("Container with id %s not found", id)
+			}
+			h.widget.Unmount()
+			forwarder := newEventForwarder()
+			f(forwarder)
+			h.dry.ViewMode(NoView)
+			go statsHistoryScreen(h.dry.dockerDaemon, container, h.screen, forwarder.events(), func() {
+				h.dry.ViewMode(Monitor)
+				f(h)
+				refreshScreen()
+			})
+			return nil
+		}
+		if err := h.widget.OnEvent(showHistory); err != nil {
+			h.dry.apperror(err.Error())
+		}
 	case termbox.KeyEnter: //Container menu
 		showMenu := func(id string) error {
 			h.widget.Unmount()
@@ -38,7 +71,7 @@ func (h *monitorScreenEventHandler) handle(event termbox.Event, f func(eventHand
 			return refreshScreen()
 		}
 		if err := h.widget.OnEvent(showMenu); err != nil {
-			h.dry.appmessage(err.Error())
+			h.dry.apperror(err.Error())
 		}
 	}
 	if !handled {
@@ -52,6 +85,43 @@ func (h *monitorScreenEventHandler) handle(event termbox.Event, f func(eventHand
 			handled = true
 			cursor.Bottom()
 			h.widget.OnEvent(nil)
+		case '+': //Faster refresh rate
+			handled = true
+			h.widget.IncreaseRefreshRate()
+		case '-': //Slower refresh rate
+			handled = true
+			h.widget.DecreaseRefreshRate()
+		case 'p', 'P': //Pause or resume
+			handled = true
+			h.widget.TogglePause()
+			h.widget.OnEvent(nil)
+		case 'r', 'R': //Record stats history
+			handled = true
+			h.widget.ToggleHistory()
+			h.widget.OnEvent(nil)
+		case 'n', 'N': //Network traffic
+			handled = true
+			h.widget.ToggleTraffic()
+			h.widget.OnEvent(nil)
+			renderStatusBar(h.dry, h.screen)
+		case 'x', 'X': //Export stats
+			handled = true
+			h.exportStats(f)
+		case 't', 'T': //Alert thresholds
+			handled = true
+			h.editThresholds(f)
+		case 'a', 'A': //Alerts
+			handled = true
+			h.widget.Unmount()
+			forwarder := newEventForwarder()
+			f(forwarder)
+			h.dry.ViewMode(NoView)
+			renderer := appui.NewMonitorAlertsRenderer(h.widget.Alerts())
+			go appui.Less(renderer, h.screen, forwarder.events(), func() {
+				h.dry.ViewMode(Monitor)
+				f(h)
+				refreshScreen()
+			})
 		default:
 			handled = false
 		}
@@ -67,3 +137,86 @@ func (h *monitorScreenEventHandler) handle(event termbox.Event, f func(eventHand
 		h.baseEventHandler.handle(event, nh)
 	}
 }
+
+//editThresholds shows a prompt to edit the alert thresholds, the new
+//thresholds are saved as user preferences.
+func (h *monitorScreenEventHandler) editThresholds(f func(eventHandler)) {
+	prompt := appui.NewPromptWithText(
+		"Alert thresholds: cpu=% mem=% intervals=n exit=on|off bell=on|off",
+		h.widget.Thresholds().String())
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		events := ui.EventSource{
+			Events: forwarder.events(),
+			EventHandledCallback: func(e termbox.Event) error {
+				return refreshScreen()
+			},
+		}
+		prompt.OnFocus(events)
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		if canceled {
+			return
+		}
+		thresholds, err := appui.ParseMonitorThresholds(text, h.widget.Thresholds())
+		if err != nil {
+			h.dry.apperror("Invalid alert thresholds: " + err.Error())
+			return
+		}
+		h.widget.SetThresholds(thresholds)
+		if err := userPreferences.setMonitorThresholds(thresholds); err != nil {
+			h.dry.apperror("Alert thresholds could not be saved: " + err.Error())
+			return
+		}
+		h.dry.appsuccess("Alert thresholds updated")
+	}()
+}
+
+//exportStats asks for a file and writes the stats to it as CSV, the stats
+//history recorded if recording, the last stats received otherwise
+func (h *monitorScreenEventHandler) exportStats(f func(eventHandler)) {
+	series := h.widget.StatsSeries()
+	if len(series) == 0 {
+		h.dry.appmessage("There are no stats to export")
+		return
+	}
+	prompt := appui.NewPromptWithText("Export the stats to file",
+		fmt.Sprintf("dry-stats-%s.csv", time.Now().Format("20060102-150405")))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		path, canceled := prompt.Text()
+		f(h)
+		if canceled || strings.TrimSpace(path) == "" {
+			return
+		}
+		path = strings.TrimSpace(path)
+		rows, err := writeStatsFile(path, series)
+		if err != nil {
+			h.dry.apperror(fmt.Sprintf("Error exporting the stats: %s", err.Error()))
+			return
+		}
+		h.dry.appsuccess(fmt.Sprintf("%d rows written to %s", rows, path))
+	}()
+}
+
+//writeStatsFile writes the given stats series as CSV to the given file
+func writeStatsFile(path string, series []docker.ContainerStatsSeries) (int, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := docker.WriteStatsCSV(file, series)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return rows, err
+}
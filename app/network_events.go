@@ -16,20 +16,23 @@ type networksScreenEventHandler struct {
 
 func (h *networksScreenEventHandler) handle(event termbox.Event, f func(eh eventHandler)) {
 	dry := h.dry
-	screen := h.screen
 	handled := true
 	switch event.Key {
 	case termbox.KeyF1: //sort
 		h.widget.Sort()
 		refreshScreen()
+	case termbox.KeyF3: //reverse sort
+		h.widget.ReverseSort()
+		refreshScreen()
 	case termbox.KeyF5: // refresh
 		h.dry.appmessage("Refreshing network list")
 		h.widget.Unmount()
+		h.dry.resetAutoRefresh()
 		refreshScreen()
 	case termbox.KeyEnter: //inspect
 		forwarder := newEventForwarder()
 		f(forwarder)
-		inspectNetwork := inspect(screen, forwarder.events(),
+		inspectNetwork := inspect(forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.NetworkInspect(id)
 			},
@@ -40,49 +43,32 @@ func (h *networksScreenEventHandler) handle(event termbox.Event, f func(eh event
 			})
 
 		if err := h.widget.OnEvent(inspectNetwork); err != nil {
-			dry.appmessage(
+			dry.apperror(
 				fmt.Sprintf("Error inspecting image: %s", err.Error()))
 		}
 
 	case termbox.KeyCtrlE: //remove network
 
-		prompt := appui.NewPrompt("Do you want to remove the selected network? (y/N)")
-		widgets.add(prompt)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
+		rmNetwork := func(id string) error {
+			network, err := h.dry.dockerDaemon.NetworkInspect(id)
+			if err != nil {
+				return err
 			}
-			prompt.OnFocus(events)
-			conf, cancel := prompt.Text()
-			f(h)
-			widgets.remove(prompt)
-			if cancel || (conf != "y" && conf != "Y") {
-				return
-			}
-
-			rmNetwork := func(id string) error {
-				shortID := drydocker.TruncateID(id)
+			shortID := drydocker.TruncateID(id)
+			target := fmt.Sprintf("%s (%s, driver %s)", network.Name, shortID, network.Driver)
+			dry.confirm(confirmNetworkRm, "Do you want to remove the following network?", []string{target}, h, f, func() {
 				if err := h.dry.dockerDaemon.RemoveNetwork(id); err == nil {
-					h.dry.appmessage(fmt.Sprintf("<red>Removed network:</> <white>%s</>", shortID))
+					h.dry.appsuccess(fmt.Sprintf("Removed network: <white>%s</>", shortID))
 				} else {
-					h.dry.appmessage(fmt.Sprintf("<red>Error network image </><white>%s: %s</>", shortID, err.Error()))
+					h.dry.apperror(fmt.Sprintf("<red>Error network image </><white>%s: %s</>", shortID, err.Error()))
 				}
-
-				return nil
-			}
-			if err := h.widget.OnEvent(rmNetwork); err != nil {
-				dry.appmessage(
-					fmt.Sprintf("Error removing network: %s", err.Error()))
-			}
-			refreshScreen()
-
-		}()
+			})
+			return nil
+		}
+		if err := h.widget.OnEvent(rmNetwork); err != nil {
+			dry.apperror(
+				fmt.Sprintf("Error removing network: %s", err.Error()))
+		}
 
 	default:
 		handled = false
@@ -92,6 +78,21 @@ func (h *networksScreenEventHandler) handle(event termbox.Event, f func(eh event
 		case '3':
 			//already in network screen
 			handled = true
+		case 's', 'S':
+			handled = true
+			if h.widget.ToggleIPAM() {
+				h.dry.appmessage("Showing the subnet and gateway of the networks")
+			} else {
+				h.dry.appmessage("Hiding the subnet and gateway of the networks")
+			}
+			refreshScreen()
+		case 'd', 'D':
+			handled = true
+			if err := h.widget.OnEvent(func(id string) error {
+				return h.showIPAMDetails(id, f)
+			}); err != nil {
+				dry.apperror(fmt.Sprintf("Error inspecting network: %s", err.Error()))
+			}
 		case '%':
 			handled = true
 			forwarder := newEventForwarder()
@@ -104,9 +105,42 @@ func (h *networksScreenEventHandler) handle(event termbox.Event, f func(eh event
 				f(h)
 			}
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		case '/': //search networks
+			handled = true
+			searchList(h, f, h.widget)
+			refreshScreen()
+		case 'n': //next search match
+			handled = true
+			jumpToSearchMatch(h.dry, h.widget, true, "network")
+			refreshScreen()
+		case 'N': //previous search match
+			handled = true
+			jumpToSearchMatch(h.dry, h.widget, false, "network")
+			refreshScreen()
 		}
 	}
 	if !handled {
 		h.baseEventHandler.handle(event, f)
 	}
 }
+
+//showIPAMDetails shows the IPAM configs of the network with the given id
+//and the networks or host interfaces its subnets overlap with
+func (h *networksScreenEventHandler) showIPAMDetails(id string, f func(eh eventHandler)) error {
+	network, err := h.dry.dockerDaemon.NetworkInspect(id)
+	if err != nil {
+		return err
+	}
+	text := appui.NetworkIPAMDetails(network, h.widget.SubnetConflicts(id))
+	text += "\nPress <white>Esc</> to go back."
+
+	h.dry.ViewMode(InfoMode)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	go appui.Less(ui.StringRenderer(text), h.screen, forwarder.events(), func() {
+		h.dry.ViewMode(Networks)
+		f(h)
+		refreshScreen()
+	})
+	return nil
+}
@@ -49,7 +49,7 @@ func (h *nodesScreenEventHandler) handle(event termbox.Event, f func(eventHandle
 				return
 			}
 			if availability != "active" && availability != "pause" && availability != "drain" {
-				dry.appmessage(fmt.Sprintf("Invalid availability: %s", availability))
+				dry.apperror(fmt.Sprintf("Invalid availability: %s", availability))
 				return
 			}
 
@@ -59,9 +59,9 @@ func (h *nodesScreenEventHandler) handle(event termbox.Event, f func(eventHandle
 					docker.NewNodeAvailability(availability))
 
 				if err == nil {
-					dry.appmessage(fmt.Sprintf("Node %s availability is now %s", nodeID, availability))
+					dry.appsuccess(fmt.Sprintf("Node %s availability is now %s", nodeID, availability))
 				} else {
-					dry.appmessage(fmt.Sprintf("Could not change node availability, error %s", err.Error()))
+					dry.apperror(fmt.Sprintf("Could not change node availability, error %s", err.Error()))
 					return err
 				}
 				return refreshScreen()
@@ -93,6 +93,20 @@ func (h *nodesScreenEventHandler) handle(event termbox.Event, f func(eventHandle
 				f(h)
 			}
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		case '/': //search nodes
+			handled = true
+			searchList(h, f, h.widget)
+		case 'n': //next search match
+			handled = true
+			jumpToSearchMatch(h.dry, h.widget, true, "node")
+		case 'N': //previous search match
+			handled = true
+			jumpToSearchMatch(h.dry, h.widget, false, "node")
+		case 's', 'S':
+			handled = true
+			h.screen.Cursor.Reset()
+			f(viewsToHandlers[SwarmStatus])
+			h.dry.ViewMode(SwarmStatus)
 		}
 	}
 	if !handled {
@@ -28,7 +28,6 @@ func (h *taskScreenEventHandler) handle(event termbox.Event, f func(eventHandler
 		f(forwarder)
 		if err := h.widget.OnEvent(
 			inspect(
-				h.screen,
 				forwarder.events(),
 				func(id string) (interface{}, error) {
 					return h.dry.dockerDaemon.Task(id)
@@ -38,7 +37,7 @@ func (h *taskScreenEventHandler) handle(event termbox.Event, f func(eventHandler
 					f(h)
 					refreshScreen()
 				})); err != nil {
-			h.dry.appmessage(
+			h.dry.apperror(
 				fmt.Sprintf("Error inspecting stack: %s", err.Error()))
 		}
 	default:
@@ -60,6 +59,9 @@ func (h *taskScreenEventHandler) handle(event termbox.Event, f func(eventHandler
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
 		}
 	}
+	if !handled {
+		handled = handleTaskKeys(h, h.dry, h.screen, h.widget, Tasks, event, f)
+	}
 	if !handled {
 		h.baseEventHandler.handle(event, f)
 	}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type pluginsScreenEventHandler struct {
+	baseEventHandler
+	widget *appui.DockerPluginsWidget
+}
+
+func (h *pluginsScreenEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyF5: // refresh
+		h.dry.appmessage("Refreshing plugin list")
+		h.widget.Unmount()
+	case termbox.KeyEnter: //inspect
+		forwarder := newEventForwarder()
+		f(forwarder)
+		inspectPlugin := inspect(forwarder.events(),
+			func(name string) (interface{}, error) {
+				return h.dry.dockerDaemon.PluginInspect(name)
+			},
+			func() {
+				h.dry.ViewMode(Plugins)
+				f(h)
+				refreshScreen()
+			})
+		if err := h.widget.OnEvent(inspectPlugin); err != nil {
+			f(h)
+			h.dry.apperror(fmt.Sprintf("Error inspecting plugin: %s", err.Error()))
+		}
+	case termbox.KeyCtrlE: //remove plugin
+		h.removePlugin(false, f)
+	case termbox.KeyCtrlF: //force remove plugin
+		h.removePlugin(true, f)
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case '8':
+			//already in plugin screen
+			handled = true
+		case 'e', 'E':
+			handled = true
+			if err := h.widget.OnEvent(h.togglePlugin); err != nil {
+				h.dry.apperror(err.Error())
+			}
+		case '%':
+			handled = true
+			forwarder := newEventForwarder()
+			f(forwarder)
+			refreshScreen()
+			applyFilter := func(filter string, canceled bool) {
+				if !canceled {
+					h.widget.Filter(filter)
+				}
+				f(h)
+			}
+			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		}
+	}
+	if handled {
+		refreshScreen()
+	} else {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//togglePlugin disables the given plugin if it is enabled, enables it otherwise
+func (h *pluginsScreenEventHandler) togglePlugin(name string) error {
+	plugin, err := h.dry.dockerDaemon.PluginInspect(name)
+	if err != nil {
+		return err
+	}
+	if plugin.Enabled {
+		if err := h.dry.dockerDaemon.PluginDisable(name); err != nil {
+			return fmt.Errorf("Error disabling plugin %s: %s", name, err.Error())
+		}
+		h.dry.appsuccess(fmt.Sprintf("Plugin %s disabled", name))
+	} else {
+		if err := h.dry.dockerDaemon.PluginEnable(name); err != nil {
+			return fmt.Errorf("Error enabling plugin %s: %s", name, err.Error())
+		}
+		h.dry.appsuccess(fmt.Sprintf("Plugin %s enabled", name))
+	}
+	h.widget.Unmount()
+	return nil
+}
+
+//removePlugin removes the selected plugin, Docker refuses to remove enabled
+//plugins unless forced
+func (h *pluginsScreenEventHandler) removePlugin(force bool, f func(eventHandler)) {
+	rmPlugin := func(name string) error {
+		plugin, err := h.dry.dockerDaemon.PluginInspect(name)
+		if err != nil {
+			return err
+		}
+		if plugin.Enabled && !force {
+			return fmt.Errorf("Plugin %s is enabled, disable it first or force its removal", name)
+		}
+		target := name
+		if plugin.Enabled {
+			target = fmt.Sprintf("%s (enabled)", name)
+		}
+		h.dry.confirm(confirmPluginRm, "Do you want to remove the following plugin?", []string{target}, h, f, func() {
+			if err := h.dry.dockerDaemon.PluginRemove(name, force); err != nil {
+				h.dry.apperror(fmt.Sprintf("<red>Error removing plugin </><white>%s: %s</>", name, err.Error()))
+				return
+			}
+			h.dry.appsuccess(fmt.Sprintf("Removed plugin: <white>%s</>", name))
+			h.widget.Unmount()
+		})
+		return nil
+	}
+	if err := h.widget.OnEvent(rmPlugin); err != nil {
+		h.dry.apperror(err.Error())
+	}
+}
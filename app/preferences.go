@@ -0,0 +1,338 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/moncho/dry/appui"
+)
+
+//preferencesFile is the file where user preferences are kept
+var preferencesFile, _ = homedir.Expand("~/.dry/preferences.json")
+
+//userPreferences are the preferences of the current user
+var userPreferences *preferences
+
+//preferences are the user preferences that are kept between dry sessions
+type preferences struct {
+	MonitorThresholds appui.MonitorThresholds `json:"monitor_thresholds"`
+	//Confirmations tells if destructive actions must be confirmed
+	Confirmations bool `json:"confirmations"`
+	//Keymap are the keys bound to each action, by action name, the actions
+	//not found are bound to their default keys
+	Keymap map[string][]string `json:"keymap,omitempty"`
+	//Theme is the name of the color theme in use
+	Theme string `json:"theme,omitempty"`
+	//HardCut tells if the text that does not fit on a column is cut without
+	//an ellipsis
+	HardCut bool `json:"hard_cut,omitempty"`
+	//SplitView tells if the container list is shown next to a preview of
+	//the container on the cursor
+	SplitView bool `json:"split_view,omitempty"`
+	//RowRules style the rows of the containers that match their conditions,
+	//in order
+	RowRules []appui.RowRule `json:"row_rules,omitempty"`
+	//AutoRefresh is how often, in seconds, each list refreshes on its own,
+	//by list name
+	AutoRefresh map[string]int `json:"auto_refresh,omitempty"`
+	//WaitForExit tells if stopped or killed containers are waited for to
+	//exit, to tell how they exited
+	WaitForExit bool `json:"wait_for_exit"`
+	//JSONLogFields are the fields shown as columns of JSON logs
+	JSONLogFields []string `json:"json_log_fields,omitempty"`
+	//Startup is the view dry starts on, and how its list is filtered and
+	//sorted, unless told otherwise on the command line
+	Startup *StartupOptions `json:"startup,omitempty"`
+	//DaemonCallTimeout is how long, in seconds, list calls to the Docker
+	//daemon can take
+	DaemonCallTimeout int `json:"daemon_call_timeout,omitempty"`
+	//HiddenColumns are the titles of the columns hidden on each list, by
+	//list name
+	HiddenColumns map[string][]string `json:"hidden_columns,omitempty"`
+	//SearchRegistry is the registry searched for images, Docker Hub if
+	//empty
+	SearchRegistry string `json:"search_registry,omitempty"`
+	//Scanner is the command images are scanned for vulnerabilities with,
+	//{image} is replaced by the image, trivy is run if empty
+	Scanner string `json:"scanner,omitempty"`
+
+	path string
+	lock sync.Mutex
+}
+
+//loadPreferences loads the preferences found on the given file, default
+//values are used for the preferences not found.
+func loadPreferences(path string) (*preferences, error) {
+	p := &preferences{
+		MonitorThresholds: appui.DefaultMonitorThresholds,
+		Confirmations:     true,
+		WaitForExit:       true,
+		path:              path,
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	} else if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(b, p); err != nil {
+		return p, err
+	}
+	//the file can be edited by hand
+	p.MonitorThresholds = p.MonitorThresholds.Valid()
+	return p, nil
+}
+
+//confirmationsEnabled returns true if destructive actions must be confirmed
+func (p *preferences) confirmationsEnabled() bool {
+	if p == nil {
+		return true
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.Confirmations
+}
+
+//setMonitorThresholds sets and saves the monitor thresholds
+func (p *preferences) setMonitorThresholds(t appui.MonitorThresholds) error {
+	p.lock.Lock()
+	p.MonitorThresholds = t
+	p.lock.Unlock()
+	return p.save()
+}
+
+//theme returns the name of the color theme in use, empty if no theme
+//has been chosen
+func (p *preferences) theme() string {
+	if p == nil {
+		return ""
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.Theme
+}
+
+//setTheme sets and saves the color theme in use
+func (p *preferences) setTheme(name string) error {
+	p.lock.Lock()
+	p.Theme = name
+	p.lock.Unlock()
+	return p.save()
+}
+
+//truncationMode returns the truncation mode chosen by the user
+func (p *preferences) truncationMode() appui.TruncationMode {
+	if p == nil {
+		return appui.EllipsisTruncation
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.HardCut {
+		return appui.HardCutTruncation
+	}
+	return appui.EllipsisTruncation
+}
+
+//setTruncationMode sets and saves the truncation mode
+func (p *preferences) setTruncationMode(mode appui.TruncationMode) error {
+	p.lock.Lock()
+	p.HardCut = mode == appui.HardCutTruncation
+	p.lock.Unlock()
+	return p.save()
+}
+
+//splitView returns true if the user wants the container list split
+func (p *preferences) splitView() bool {
+	if p == nil {
+		return false
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.SplitView
+}
+
+//setSplitView sets and saves whether the container list is split
+func (p *preferences) setSplitView(split bool) error {
+	p.lock.Lock()
+	p.SplitView = split
+	p.lock.Unlock()
+	return p.save()
+}
+
+//rowRules returns the rules that style container rows
+func (p *preferences) rowRules() []appui.RowRule {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]appui.RowRule(nil), p.RowRules...)
+}
+
+//setRowRules sets and saves the rules that style container rows
+func (p *preferences) setRowRules(rules []appui.RowRule) error {
+	p.lock.Lock()
+	p.RowRules = rules
+	p.lock.Unlock()
+	return p.save()
+}
+
+//autoRefreshInterval returns how often the list with the given name
+//refreshes on its own, 0 if it does not
+func (p *preferences) autoRefreshInterval(list string) time.Duration {
+	if p == nil {
+		return 0
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return time.Duration(p.AutoRefresh[list]) * time.Second
+}
+
+//setAutoRefreshInterval sets and saves how often the list with the given
+//name refreshes on its own, 0 stops it from refreshing
+func (p *preferences) setAutoRefreshInterval(list string, interval time.Duration) error {
+	p.lock.Lock()
+	if interval > 0 {
+		if p.AutoRefresh == nil {
+			p.AutoRefresh = make(map[string]int)
+		}
+		p.AutoRefresh[list] = int(interval / time.Second)
+	} else {
+		delete(p.AutoRefresh, list)
+	}
+	p.lock.Unlock()
+	return p.save()
+}
+
+//waitForExit returns true if stopped or killed containers are waited for
+//to exit
+func (p *preferences) waitForExit() bool {
+	if p == nil {
+		return true
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.WaitForExit
+}
+
+//jsonLogFields returns the fields shown as columns of JSON logs, none if
+//the user has not chosen any
+func (p *preferences) jsonLogFields() []string {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]string(nil), p.JSONLogFields...)
+}
+
+//startupOptions returns the view dry starts on, and how its list is
+//filtered and sorted, as the user prefers
+func (p *preferences) startupOptions() StartupOptions {
+	if p == nil {
+		return StartupOptions{}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.Startup == nil {
+		return StartupOptions{}
+	}
+	return *p.Startup
+}
+
+//daemonCallTimeout returns how long list calls to the Docker daemon can
+//take, 0 if the default timeout is used
+func (p *preferences) daemonCallTimeout() time.Duration {
+	if p == nil {
+		return 0
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return time.Duration(p.DaemonCallTimeout) * time.Second
+}
+
+//hiddenColumns returns the titles of the columns hidden on the list with
+//the given name, false if the user has not chosen them so the list hides
+//its default ones
+func (p *preferences) hiddenColumns(list string) ([]string, bool) {
+	if p == nil {
+		return nil, false
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	titles, ok := p.HiddenColumns[list]
+	return append([]string(nil), titles...), ok
+}
+
+//setHiddenColumns sets and saves the titles of the columns hidden on the
+//list with the given name, none shows every column
+func (p *preferences) setHiddenColumns(list string, titles []string) error {
+	p.lock.Lock()
+	if p.HiddenColumns == nil {
+		p.HiddenColumns = make(map[string][]string)
+	}
+	//kept even if empty, not to hide the default hidden columns again
+	p.HiddenColumns[list] = append([]string{}, titles...)
+	p.lock.Unlock()
+	return p.save()
+}
+
+//searchRegistry returns the registry searched for images, empty for
+//Docker Hub
+func (p *preferences) searchRegistry() string {
+	if p == nil {
+		return ""
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.SearchRegistry
+}
+
+//setSearchRegistry sets and saves the registry searched for images
+func (p *preferences) setSearchRegistry(registry string) error {
+	p.lock.Lock()
+	p.SearchRegistry = registry
+	p.lock.Unlock()
+	return p.save()
+}
+
+//scanner returns the command images are scanned for vulnerabilities with,
+//empty for the default one
+func (p *preferences) scanner() string {
+	if p == nil {
+		return ""
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.Scanner
+}
+
+//setScanner sets and saves the command images are scanned with
+func (p *preferences) setScanner(command string) error {
+	p.lock.Lock()
+	p.Scanner = command
+	p.lock.Unlock()
+	return p.save()
+}
+
+//save writes the preferences to disk
+func (p *preferences) save() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.path == "" {
+		return nil
+	}
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.path, b, 0600)
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/appui"
+)
+
+func TestPreferences(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "dry", "preferences.json")
+
+	p, err := loadPreferences(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading non-existing preferences: %s", err)
+	}
+	if p.MonitorThresholds != appui.DefaultMonitorThresholds {
+		t.Errorf("Default monitor thresholds were not used: %v", p.MonitorThresholds)
+	}
+	thresholds := p.MonitorThresholds
+	thresholds.CPU = 50
+	thresholds.Bell = true
+	if err := p.setMonitorThresholds(thresholds); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+
+	p, err = loadPreferences(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading preferences: %s", err)
+	}
+	if p.MonitorThresholds.CPU != 50 || !p.MonitorThresholds.Bell {
+		t.Errorf("Preferences were not persisted: %v", p.MonitorThresholds)
+	}
+}
+
+func TestPreferencesInvalidMonitorThresholds(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "preferences.json")
+
+	content := `{"monitor_thresholds": {"cpu": 50, "intervals": 0, "hysteresis": -5}}`
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := loadPreferences(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading preferences: %s", err)
+	}
+	if p.MonitorThresholds.CPU != 50 || p.MonitorThresholds.Intervals != 1 || p.MonitorThresholds.Hysteresis != 0 {
+		t.Errorf("Invalid monitor thresholds were not clamped: %v", p.MonitorThresholds)
+	}
+}
+
+func TestPreferencesConfirmations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "preferences.json")
+
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"no preference", `{}`, true},
+		{"confirmations enabled", `{"confirmations": true}`, true},
+		{"confirmations disabled", `{"confirmations": false}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			p, err := loadPreferences(path)
+			if err != nil {
+				t.Fatalf("Unexpected error loading preferences: %s", err)
+			}
+			if p.confirmationsEnabled() != tt.expected {
+				t.Errorf("Unexpected confirmations preference, got %v, want %v", p.confirmationsEnabled(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPreferencesAutoRefresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "preferences.json")
+
+	p, _ := loadPreferences(path)
+	if interval := p.autoRefreshInterval(containersList); interval != 0 {
+		t.Errorf("Lists must not refresh on their own by default, got %s", interval)
+	}
+	if err := p.setAutoRefreshInterval(containersList, 10*time.Second); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+	if err := p.setAutoRefreshInterval(imagesList, time.Minute); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+	if err := p.setAutoRefreshInterval(imagesList, 0); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+
+	p, err = loadPreferences(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading preferences: %s", err)
+	}
+	if interval := p.autoRefreshInterval(containersList); interval != 10*time.Second {
+		t.Errorf("Auto refresh interval was not persisted, got %s", interval)
+	}
+	if interval := p.autoRefreshInterval(imagesList); interval != 0 {
+		t.Errorf("Auto refresh was not turned off, got %s", interval)
+	}
+}
+
+func TestPreferencesWaitForExit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "preferences.json")
+
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"no preference", `{}`, true},
+		{"waits", `{"wait_for_exit": true}`, true},
+		{"does not wait", `{"wait_for_exit": false}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			p, err := loadPreferences(path)
+			if err != nil {
+				t.Fatalf("Unexpected error loading preferences: %s", err)
+			}
+			if p.waitForExit() != tt.expected {
+				t.Errorf("Unexpected wait for exit preference, got %v, want %v", p.waitForExit(), tt.expected)
+			}
+		})
+	}
+	var p *preferences
+	if !p.waitForExit() {
+		t.Error("Containers are not waited for to exit without preferences")
+	}
+}
+
+func TestPreferencesHiddenColumns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "preferences.json")
+
+	p, _ := loadPreferences(path)
+	if hidden, ok := p.hiddenColumns(containersList); ok || len(hidden) != 0 {
+		t.Errorf("Lists must hide their default columns unless told otherwise, got %v hidden", hidden)
+	}
+	if err := p.setHiddenColumns(containersList, []string{"COMMAND", "PORTS"}); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+	if err := p.setHiddenColumns(imagesList, []string{"TAG"}); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+	if err := p.setHiddenColumns(imagesList, nil); err != nil {
+		t.Fatalf("Unexpected error saving preferences: %s", err)
+	}
+
+	p, err = loadPreferences(path)
+	if err != nil {
+		t.Fatalf("Unexpected error loading preferences: %s", err)
+	}
+	if hidden, _ := p.hiddenColumns(containersList); len(hidden) != 2 || hidden[0] != "COMMAND" || hidden[1] != "PORTS" {
+		t.Errorf("Hidden columns were not persisted, got %v", hidden)
+	}
+	if hidden, ok := p.hiddenColumns(imagesList); !ok || len(hidden) != 0 {
+		t.Errorf("Image columns were not shown again, got %v hidden", hidden)
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type registrySearchEventHandler struct {
+	baseEventHandler
+	widget *appui.RegistrySearchWidget
+}
+
+func (h *registrySearchEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		h.widget.Unmount()
+		h.screen.Cursor.Reset()
+		h.dry.ViewMode(Images)
+		f(viewsToHandlers[Images])
+		refreshScreen()
+	case termbox.KeyEnter: //pull the selected image
+		h.pullSelected(f)
+	case termbox.KeyF2:
+		h.setRegistry(f)
+	case termbox.KeyF5:
+		h.widget.Unmount()
+		refreshScreen()
+	default:
+		handled = false
+	}
+	if !handled {
+		switch event.Ch {
+		case '9': //Ignore since dry is already on the registry search screen
+		case '/':
+			h.search(f)
+		default:
+			h.baseEventHandler.handle(event, f)
+		}
+	}
+}
+
+//search asks for the term to search on the registry chosen, terms that
+//start with the host of a registry are searched on it
+func (h *registrySearchEventHandler) search(f func(eventHandler)) {
+	registry := userPreferences.searchRegistry()
+	if registry == "" {
+		registry = "Docker Hub"
+	}
+	askText(h, fmt.Sprintf("Search %s for", registry), f, func(text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		h.screen.Cursor.Reset()
+		h.widget.Search(docker.SearchTerm(userPreferences.searchRegistry(), text))
+	})
+}
+
+//setRegistry asks for the registry to search and saves it, the last term
+//is searched again on it
+func (h *registrySearchEventHandler) setRegistry(f func(eventHandler)) {
+	prompt := appui.NewPromptWithText("Registry to search (leave empty for Docker Hub)", userPreferences.searchRegistry())
+	ask(h, prompt, f, func(registry string) {
+		registry = strings.TrimSuffix(strings.TrimSpace(registry), "/")
+		if err := userPreferences.setSearchRegistry(registry); err != nil {
+			h.dry.apperror(fmt.Sprintf("Error saving the registry to search: %s", err.Error()))
+		}
+		if term := h.widget.Term(); term != "" {
+			//the term without the registry it was searched on
+			if searched := docker.SearchedRegistry(term); searched != docker.DockerHub {
+				term = strings.TrimPrefix(term, searched+"/")
+			}
+			h.screen.Cursor.Reset()
+			h.widget.Search(docker.SearchTerm(registry, term))
+		}
+	})
+}
+
+//pullSelected pulls the image on the cursor, with its latest tag
+func (h *registrySearchEventHandler) pullSelected(f func(eventHandler)) {
+	name, ok := h.widget.Selected()
+	if !ok {
+		return
+	}
+	pullImage(h.dry, h, name, f)
+}
@@ -13,14 +13,23 @@ var cancelMonitorWidget context.CancelFunc
 
 //render renders dry on the given screen
 func render(d *Dry, screen *ui.Screen) {
+	//theme changes are applied here, on the render goroutine
+	applyColorTheme(screen)
+
 	var bufferers []gizaktermui.Bufferer
 
 	var count int
 	var keymap string
 	var viewRenderer ui.Renderer
+	var list appui.ListStatus
 	di := widgets.DockerInfo
 	bufferers = append(bufferers, di)
 
+	if d.viewMode() != Main {
+		//the preview streams the stats of the container on the cursor while mounted
+		widgets.ContainerPreview.Unmount()
+	}
+
 	switch d.viewMode() {
 	case ContainerMenu:
 		{
@@ -34,14 +43,145 @@ func render(d *Dry, screen *ui.Screen) {
 			keymap = commandsMenuBar
 
 		}
+	case ContainerGraph:
+		{
+			graph := widgets.ContainerGraph
+			if err := graph.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, graph)
+			count = graph.RowCount()
+			keymap = containerLinksKeyMappings
+		}
+	case SwarmStatus:
+		{
+			status := widgets.SwarmStatus
+			if err := status.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, status)
+			keymap = swarmStatusKeyMappings
+		}
+	case ContainerEnv:
+		{
+			env := widgets.ContainerEnv
+			if err := env.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, env)
+			count = env.RowCount()
+			keymap = containerEnvKeyMappings
+		}
+	case ContainerMounts:
+		{
+			mounts := widgets.ContainerMounts
+			if err := mounts.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, mounts)
+			count = mounts.RowCount()
+			keymap = containerMountsKeyMappings
+		}
+	case ImageLayer:
+		{
+			layer := widgets.ImageLayer
+			if err := layer.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, layer)
+			count = layer.RowCount()
+			keymap = imageLayerKeyMappings
+		}
+	case ImageHistory:
+		{
+			history := widgets.ImageHistory
+			if err := history.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, history)
+			count = history.RowCount()
+			keymap = imageHistoryKeyMappings
+		}
+	case ImageScan:
+		{
+			scan := widgets.ImageScan
+			if err := scan.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, scan)
+			count = scan.RowCount()
+			keymap = imageScanKeyMappings
+		}
+	case ImageManifest:
+		{
+			manifest := widgets.ImageManifest
+			if err := manifest.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, manifest)
+			count = manifest.RowCount()
+			keymap = imageManifestKeyMappings
+		}
+	case ImageSizes:
+		{
+			sizes := widgets.ImageSizes
+			if err := sizes.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, sizes)
+			count = sizes.RowCount()
+			keymap = imageSizesKeyMappings
+		}
+	case RegistrySearch:
+		{
+			search := widgets.RegistrySearch
+			if err := search.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, search)
+			count = search.RowCount()
+			keymap = registrySearchKeyMappings
+		}
+	case ContainerProcesses:
+		{
+			processes := widgets.ContainerProcs
+			if err := processes.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, processes)
+			count = processes.RowCount()
+			keymap = containerProcessesKeyMappings
+		}
+	case ContainerHealth:
+		{
+			health := widgets.ContainerHealth
+			if err := health.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			bufferers = append(bufferers, health)
+			count = health.RowCount()
+			keymap = containerHealthKeyMappings
+		}
 	case Main:
 		{
 			containersWidget := widgets.ContainerList
+			preview := widgets.ContainerPreview
+			listWidth, split := d.splitLayout(screen.Dimensions.Width)
+			containersWidget.SetWidth(listWidth)
 			if err := containersWidget.Mount(); err != nil {
 				screen.Render(1, err.Error())
 			}
 			count = containersWidget.RowCount()
+			list = containersWidget
 			bufferers = append(bufferers, containersWidget)
+			//after the list, rendering the list tells the preview what is on the cursor
+			if split {
+				preview.Layout(listWidth, screen.Dimensions.Width-listWidth)
+				preview.Mount()
+				bufferers = append(bufferers, preview)
+			} else {
+				preview.Unmount()
+			}
 			keymap = keyMappings
 
 		}
@@ -53,6 +193,7 @@ func render(d *Dry, screen *ui.Screen) {
 				screen.Render(1, err.Error())
 			}
 			count = widget.RowCount()
+			list = widget
 			bufferers = append(bufferers, widget)
 
 			keymap = imagesKeyMappings
@@ -65,9 +206,21 @@ func render(d *Dry, screen *ui.Screen) {
 				screen.Render(1, err.Error())
 			}
 			count = widget.RowCount()
+			list = widget
 			bufferers = append(bufferers, widget)
 			keymap = networkKeyMappings
 		}
+	case Plugins:
+		{
+			widget := widgets.Plugins
+			if err := widget.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			count = widget.RowCount()
+			list = widget
+			bufferers = append(bufferers, widget)
+			keymap = pluginKeyMappings
+		}
 	case Nodes:
 		{
 			nodes := widgets.Nodes
@@ -76,6 +229,7 @@ func render(d *Dry, screen *ui.Screen) {
 			}
 			bufferers = append(bufferers, nodes)
 			count = nodes.RowCount()
+			list = nodes
 			keymap = nodeKeyMappings
 		}
 	case Services:
@@ -86,6 +240,7 @@ func render(d *Dry, screen *ui.Screen) {
 			}
 			bufferers = append(bufferers, servicesWidget)
 			count = servicesWidget.RowCount()
+			list = servicesWidget
 			keymap = serviceKeyMappings
 		}
 	case Tasks:
@@ -96,7 +251,8 @@ func render(d *Dry, screen *ui.Screen) {
 			}
 			bufferers = append(bufferers, tasks)
 			count = tasks.RowCount()
-			keymap = swarmMapping
+			list = tasks
+			keymap = taskKeyMappings
 		}
 	case ServiceTasks:
 		{
@@ -106,7 +262,8 @@ func render(d *Dry, screen *ui.Screen) {
 			}
 			bufferers = append(bufferers, tasks)
 			count = tasks.RowCount()
-			keymap = swarmMapping
+			list = tasks
+			keymap = taskKeyMappings
 		}
 	case Stacks:
 		{
@@ -116,6 +273,7 @@ func render(d *Dry, screen *ui.Screen) {
 			}
 			bufferers = append(bufferers, stacks)
 			count = stacks.RowCount()
+			list = stacks
 			keymap = stackKeyMappings
 		}
 	case StackTasks:
@@ -126,13 +284,33 @@ func render(d *Dry, screen *ui.Screen) {
 			}
 			bufferers = append(bufferers, tasks)
 			count = tasks.RowCount()
-			keymap = swarmMapping
+			list = tasks
+			keymap = taskKeyMappings
+		}
+	case BuildCache:
+		{
+			widget := widgets.BuildCache
+			if err := widget.Mount(); err != nil {
+				screen.Render(1, err.Error())
+			}
+			count = widget.RowCount()
+			list = widget
+			bufferers = append(bufferers, widget)
+			keymap = buildCacheKeyMappings
 		}
 	case DiskUsage:
 		{
 			viewRenderer = widgets.DiskUsage
 			keymap = diskUsageKeyMappings
 		}
+	case Jobs:
+		{
+			jobs := widgets.Jobs
+			jobs.Mount()
+			bufferers = append(bufferers, jobs)
+			count = jobs.RowCount()
+			keymap = jobsKeyMappings
+		}
 	case Monitor:
 		{
 			if cancelMonitorWidget != nil {
@@ -145,25 +323,48 @@ func render(d *Dry, screen *ui.Screen) {
 			keymap = monitorMapping
 			cancelMonitorWidget = cancel
 			count = monitor.RowCount()
+			list = monitor
 		}
 	}
 
 	updateCursorPosition(screen.Cursor, count)
-	bufferers = append(bufferers, footer(keymap))
+	d.statusBar.SetList(list)
+	bufferers = append(bufferers, d.statusBar)
+	bufferers = append(bufferers, footer(activeKeymap.expand(keymap)))
+	if indicator := appui.JobsIndicator(d.jobs.list()); indicator != nil {
+		bufferers = append(bufferers, indicator)
+	}
+	bufferers = append(bufferers, d.notifications)
+	if d.callStatsShown() {
+		bufferers = append(bufferers, appui.NewDaemonCallsOverlay(d.dockerDaemon.CallStats()))
+	}
 
-	widgets.MessageBar.Render()
 	screen.RenderBufferer(bufferers...)
 	if viewRenderer != nil {
 		screen.RenderRenderer(appui.MainScreenHeaderSize, viewRenderer)
 	}
 
 	for _, widget := range widgets.activeWidgets {
+		if d, ok := widget.(backgroundDimmer); ok && d.DimsBackground() {
+			screen.Dim()
+		}
 		screen.RenderBufferer(widget)
 	}
 
 	screen.Flush()
 }
 
+//backgroundDimmer is implemented by the widgets drawn over the view that dim it
+type backgroundDimmer interface {
+	DimsBackground() bool
+}
+
+//renderStatusBar renders only the status bar, for views that render themselves
+func renderStatusBar(d *Dry, screen *ui.Screen) {
+	screen.RenderBufferer(d.statusBar)
+	screen.Flush()
+}
+
 func footer(mapping string) *termui.MarkupPar {
 
 	par := termui.NewParFromMarkupText(appui.DryTheme, mapping)
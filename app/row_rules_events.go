@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+)
+
+//addRowRule is the choice to add a rule to the row rules
+const addRowRule = "Add a rule"
+
+//editRowRules lets the user pick a row rule to edit, or to add a new one
+func (h *containersScreenEventHandler) editRowRules(f func(eventHandler)) {
+	rules := userPreferences.rowRules()
+	var choices []string
+	for i, rule := range rules {
+		choices = append(choices, fmt.Sprintf("%d. %s", i+1, rule))
+	}
+	choices = append(choices, addRowRule)
+	askChoice(h, "Row rules, later rules win", choices, f, func(choice string) {
+		for i, c := range choices {
+			if c != choice {
+				continue
+			}
+			if i == len(rules) {
+				h.editRowRule(rules, -1, f)
+			} else {
+				h.editRowRule(rules, i, f)
+			}
+			return
+		}
+	})
+}
+
+//editRowRule shows a prompt to edit the rule on the given position of the
+//given rules, a new rule is added if the position is not on the list. An
+//empty rule removes the one edited. The rules are saved as user preferences.
+func (h *containersScreenEventHandler) editRowRule(rules []appui.RowRule, index int, f func(eventHandler)) {
+	text := ""
+	if index >= 0 {
+		text = rules[index].String()
+	}
+	prompt := appui.NewPromptWithText(
+		"Rule: condition => style, i.e. label.com.docker.compose.project == prod => magenta (empty removes it)", text)
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(prompt)
+		text, canceled := prompt.Text()
+		f(h)
+		defer refreshScreen()
+		if canceled {
+			return
+		}
+		var rule appui.RowRule
+		if text != "" {
+			var err error
+			if rule, err = appui.ParseRowRule(text); err != nil {
+				h.dry.apperror("Invalid row rule: " + err.Error())
+				return
+			}
+		}
+		switch {
+		case index < 0 && text == "":
+			return
+		case index < 0:
+			rules = append(rules, rule)
+		case text == "":
+			rules = append(rules[:index], rules[index+1:]...)
+		default:
+			rules[index] = rule
+		}
+		appui.SetRowRules(rules)
+		if err := userPreferences.setRowRules(rules); err != nil {
+			h.dry.apperror("The row rules could not be saved: " + err.Error())
+		}
+	}()
+}
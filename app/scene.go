@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	drydocker "github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/version"
+	log "github.com/sirupsen/logrus"
+)
+
+//names of the lists saved with a scene
+const (
+	containersList = "containers"
+	imagesList     = "images"
+	networksList   = "networks"
+)
+
+//sceneViews are the views whose list is saved with a scene
+var sceneViews = map[viewMode]string{
+	Main:     containersList,
+	Images:   imagesList,
+	Networks: networksList,
+}
+
+//ReplayScene creates a dry application that shows the scene saved on the
+//given file, the Docker daemon the scene was taken from is not needed
+func ReplayScene(screen *ui.Screen, path string) (*Dry, error) {
+	scene, err := drydocker.ReadScene(path)
+	if err != nil {
+		return nil, err
+	}
+	//the layout of the scene is reproduced if the terminal is large enough
+	dimensions := ui.ActiveScreen.Dimensions
+	if scene.Width > 0 && scene.Width <= dimensions.Width && scene.Height > 0 && scene.Height <= dimensions.Height {
+		dimensions.Width, dimensions.Height = scene.Width, scene.Height
+	} else {
+		log.Warnf("The scene was taken on a %dx%d terminal, it is shown on a %dx%d one",
+			scene.Width, scene.Height, dimensions.Width, dimensions.Height)
+	}
+	d, err := newDry(screen, mocks.NewSceneDaemon(scene, path), StartupOptions{})
+	if err != nil {
+		return nil, err
+	}
+	d.setScene(scene)
+	return d, nil
+}
+
+//takeScene returns what dry is showing, as a scene
+func (d *Dry) takeScene() (drydocker.Scene, error) {
+	scene := drydocker.Scene{
+		Taken:      time.Now(),
+		DryVersion: version.VERSION,
+		Width:      ui.ActiveScreen.Dimensions.Width,
+		Height:     ui.ActiveScreen.Dimensions.Height,
+		View:       sceneViews[d.viewMode()],
+	}
+	for _, c := range d.dockerDaemon.Containers(nil, drydocker.NoSort) {
+		scene.Containers = append(scene.Containers, drydocker.NewSceneContainer(c))
+	}
+	images, err := d.dockerDaemon.Images()
+	if err != nil {
+		return scene, err
+	}
+	scene.Images = images
+	networks, err := d.dockerDaemon.Networks()
+	if err != nil {
+		return scene, err
+	}
+	scene.Networks = networks
+	scene.Lists = map[string]drydocker.SceneList{
+		containersList: {
+			Sort:    widgets.ContainerList.SortMode(),
+			Filter:  widgets.ContainerList.ActiveFilter(),
+			ShowAll: widgets.ContainerList.ShowAllContainers(),
+		},
+		imagesList: {
+			Sort:         widgets.ImageList.SortMode(),
+			Filter:       widgets.ImageList.ActiveFilter(),
+			DanglingOnly: widgets.ImageList.DanglingOnly(),
+		},
+		networksList: {
+			Sort:   widgets.Networks.SortMode(),
+			Filter: widgets.Networks.ActiveFilter(),
+		},
+	}
+	return scene, nil
+}
+
+//saveScene saves what dry is showing, as a scene, to the given file
+func (d *Dry) saveScene(path string) error {
+	scene, err := d.takeScene()
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := drydocker.WriteScene(file, scene); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+//setScene sorts and filters the lists, and shows the view, as they were
+//when the given scene was taken
+func (d *Dry) setScene(scene *drydocker.Scene) {
+	if list, ok := scene.Lists[containersList]; ok {
+		widgets.ContainerList.SetSortMode(list.Sort)
+		widgets.ContainerList.Filter(list.Filter)
+		if list.ShowAll != widgets.ContainerList.ShowAllContainers() {
+			widgets.ContainerList.ToggleShowAllContainers()
+		}
+	}
+	if list, ok := scene.Lists[imagesList]; ok {
+		widgets.ImageList.SetSortMode(list.Sort)
+		widgets.ImageList.Filter(list.Filter)
+		if list.DanglingOnly != widgets.ImageList.DanglingOnly() {
+			widgets.ImageList.ToggleDanglingOnly()
+		}
+	}
+	if list, ok := scene.Lists[networksList]; ok {
+		widgets.Networks.SetSortMode(list.Sort)
+		widgets.Networks.Filter(list.Filter)
+	}
+	for view, name := range sceneViews {
+		if name == scene.View {
+			d.ViewMode(view)
+		}
+	}
+}
+
+//sceneFileName is the name of the file a scene is saved to if the user does not give one
+func sceneFileName(t time.Time) string {
+	return fmt.Sprintf("dry-scene-%s.json", t.Format("20060102-150405"))
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//showSearchInput shows a prompt to search the rows of the given widget, the
+//cursor jumps to the first row that matches the pattern as it is typed. The
+//pattern is kept to jump to other matches unless the search is canceled.
+func showSearchInput(events <-chan termbox.Event, widget appui.SearchableWidget, onDone func()) {
+	rw := appui.NewPrompt("Search? (Esc to stop searching)")
+	widgets.add(rw)
+	go func() {
+		rw.OnFocus(ui.EventSource{
+			Events: events,
+			EventHandledCallback: func(e termbox.Event) error {
+				pattern, _ := rw.Text()
+				widget.Search(pattern)
+				return refreshScreen()
+			},
+		})
+		widgets.remove(rw)
+		if _, canceled := rw.Text(); canceled {
+			widget.Search("")
+		}
+		onDone()
+		refreshScreen()
+	}()
+}
+
+//searchList shows the prompt to search the rows of the given widget, the
+//given handler handles the events again once the search is done
+func searchList(h eventHandler, f func(eventHandler), widget appui.SearchableWidget) {
+	forwarder := newEventForwarder()
+	f(forwarder)
+	showSearchInput(forwarder.events(), widget, func() {
+		f(h)
+	})
+}
+
+//jumpToSearchMatch moves the cursor of the given widget to the next row that
+//matches the last search, or to the previous one if forward is false. The
+//given text names the rows of the widget on the message shown if none does.
+func jumpToSearchMatch(dry *Dry, widget appui.SearchableWidget, forward bool, rows string) {
+	var found bool
+	if forward {
+		found = widget.SearchNext()
+	} else {
+		found = widget.SearchPrevious()
+	}
+	if !found {
+		dry.appmessage(fmt.Sprintf("No %s matches the search", rows))
+	}
+}
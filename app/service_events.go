@@ -1,11 +1,13 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/appui/swarm"
+	drydocker "github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 	termbox "github.com/nsf/termbox-go"
 )
@@ -20,45 +22,49 @@ func (h *servicesScreenEventHandler) handle(event termbox.Event, f func(eventHan
 	dry := h.dry
 
 	switch event.Key {
+	case termbox.KeyEsc:
+		if h.widget.Stack() == "" {
+			handled = false
+			break
+		}
+		//back to the stack the services belong to
+		h.widget.ForStack("")
+		h.screen.Cursor.Reset()
+		f(viewsToHandlers[Stacks])
+		dry.ViewMode(Stacks)
+		refreshScreen()
 	case termbox.KeyF1: // sort
 		widgets.ServiceList.Sort()
 	case termbox.KeyF5: // refresh
 		h.dry.appmessage("Refreshing the service list")
 		if err := h.widget.Unmount(); err != nil {
-			h.dry.appmessage("There was an error refreshing the service list: " + err.Error())
+			h.dry.apperror("There was an error refreshing the service list: " + err.Error())
 		}
 	case termbox.KeyCtrlL:
 		h.showLogs(true, f)
 
 	case termbox.KeyCtrlR:
-		rw := appui.NewPrompt("The selected service will be removed. Do you want to proceed? y/N")
-		widgets.add(rw)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			rw.OnFocus(events)
-			widgets.remove(rw)
-			confirmation, canceled := rw.Text()
-			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
-				return
-			}
-			removeService := func(serviceID string) error {
-				err := dry.dockerDaemon.ServiceRemove(serviceID)
+		removeService := func(serviceID string) error {
+			service, err := dry.dockerDaemon.Service(serviceID)
+			if err != nil {
 				return err
 			}
-			if err := h.widget.OnEvent(removeService); err != nil {
-				h.dry.appmessage("There was an error removing the service: " + err.Error())
-			}
-			refreshScreen()
-		}()
+			target := fmt.Sprintf("%s (%s, image %s)",
+				service.Spec.Name, drydocker.TruncateID(serviceID), service.Spec.TaskTemplate.ContainerSpec.Image)
+			dry.confirm(confirmServiceRm, "Do you want to remove the following service?", []string{target}, h, f, func() {
+				dry.runJob(fmt.Sprintf("Remove service %s", serviceID), false,
+					func(ctx context.Context, progress func(float64)) (string, error) {
+						if err := dry.dockerDaemon.ServiceRemove(serviceID); err != nil {
+							return "", err
+						}
+						return fmt.Sprintf("Service %s removed", serviceID), nil
+					})
+			})
+			return nil
+		}
+		if err := h.widget.OnEvent(removeService); err != nil {
+			h.dry.apperror("There was an error removing the service: " + err.Error())
+		}
 
 	case termbox.KeyCtrlS:
 
@@ -83,7 +89,7 @@ func (h *servicesScreenEventHandler) handle(event termbox.Event, f func(eventHan
 			}
 			scaleTo, err := strconv.Atoi(replicas)
 			if err != nil || scaleTo < 0 {
-				dry.appmessage(
+				dry.apperror(
 					fmt.Sprintf("Cannot scale service, invalid number of replicas: %s", replicas))
 				return
 			}
@@ -92,12 +98,12 @@ func (h *servicesScreenEventHandler) handle(event termbox.Event, f func(eventHan
 				err := dry.dockerDaemon.ServiceScale(serviceID, uint64(scaleTo))
 
 				if err == nil {
-					dry.appmessage(fmt.Sprintf("Service %s scaled to %d replicas", serviceID, scaleTo))
+					dry.appsuccess(fmt.Sprintf("Service %s scaled to %d replicas", serviceID, scaleTo))
 				}
 				return err
 			}
 			if err := h.widget.OnEvent(scaleService); err != nil {
-				h.dry.appmessage("There was an error scaling the service: " + err.Error())
+				h.dry.apperror("There was an error scaling the service: " + err.Error())
 			}
 			refreshScreen()
 		}()
@@ -126,7 +132,7 @@ func (h *servicesScreenEventHandler) handle(event termbox.Event, f func(eventHan
 				return err
 			}
 			if err := h.widget.OnEvent(removeService); err != nil {
-				h.dry.appmessage("There was an error updating the service: " + err.Error())
+				h.dry.apperror("There was an error updating the service: " + err.Error())
 			}
 			refreshScreen()
 		}()
@@ -155,12 +161,23 @@ func (h *servicesScreenEventHandler) handle(event termbox.Event, f func(eventHan
 			f(h)
 		}
 		showFilterInput(newEventSource(forwarder.events()), applyFilter)
+	case '/': //search services
+		handled = true
+		searchList(h, f, h.widget)
+		refreshScreen()
+	case 'n': //next search match
+		handled = true
+		jumpToSearchMatch(h.dry, h.widget, true, "service")
+		refreshScreen()
+	case 'N': //previous search match
+		handled = true
+		jumpToSearchMatch(h.dry, h.widget, false, "service")
+		refreshScreen()
 	case 'i' | 'I':
 		handled = true
 		forwarder := newEventForwarder()
 		f(forwarder)
 		inspectService := inspect(
-			h.screen,
 			forwarder.events(),
 			func(id string) (interface{}, error) {
 				return h.dry.dockerDaemon.Service(id)
@@ -172,19 +189,57 @@ func (h *servicesScreenEventHandler) handle(event termbox.Event, f func(eventHan
 			})
 
 		if err := h.widget.OnEvent(inspectService); err != nil {
-			h.dry.appmessage("There was an error inspecting the service: " + err.Error())
+			h.dry.apperror("There was an error inspecting the service: " + err.Error())
 		}
 
 	case 'l':
 		handled = true
 		h.showLogs(false, f)
+	case 'e', 'E':
+		handled = true
+		showEndpoint := func(serviceID string) error {
+			return h.showEndpoint(serviceID, f)
+		}
+		if err := h.widget.OnEvent(showEndpoint); err != nil {
+			h.dry.apperror("There was an error showing the service endpoint: " + err.Error())
+		}
 	}
 	if !handled {
 		h.baseEventHandler.handle(event, f)
 	}
 }
 
+//showEndpoint shows the endpoint of the service with the given id: its
+//published ports and its virtual IPs on each network
+func (h *servicesScreenEventHandler) showEndpoint(id string, f func(eventHandler)) error {
+	service, err := h.dry.dockerDaemon.Service(id)
+	if err != nil {
+		return err
+	}
+	networkNames := make(map[string]string)
+	for _, vip := range service.Endpoint.VirtualIPs {
+		if network, err := h.dry.dockerDaemon.NetworkInspect(vip.NetworkID); err == nil {
+			networkNames[vip.NetworkID] = network.Name
+		}
+	}
+	text := swarm.ServiceEndpointDetails(*service, networkNames)
+	text += "\nPress <white>Esc</> to go back."
+
+	h.dry.ViewMode(InfoMode)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	go appui.Less(ui.StringRenderer(text), h.screen, forwarder.events(), func() {
+		h.dry.ViewMode(Services)
+		f(h)
+		refreshScreen()
+	})
+	return nil
+}
+
 func (h *servicesScreenEventHandler) showLogs(withTimestamp bool, f func(eventHandler)) {
+	if h.dry.unsupported(drydocker.ServiceLogsFeature) {
+		return
+	}
 	prompt := logsPrompt()
 	widgets.add(prompt)
 	forwarder := newEventForwarder()
@@ -214,7 +269,7 @@ func (h *servicesScreenEventHandler) showLogs(withTimestamp bool, f func(eventHa
 			return err
 		}
 		if err := h.widget.OnEvent(showServiceLogs); err != nil {
-			h.dry.appmessage("There was an error showing service logs: " + err.Error())
+			h.dry.apperror("There was an error showing service logs: " + err.Error())
 			f(h)
 		}
 	}()
@@ -29,7 +29,6 @@ func (h *serviceTasksScreenEventHandler) handle(event termbox.Event, f func(even
 		f(forwarder)
 		if err := h.widget.OnEvent(
 			inspect(
-				h.screen,
 				forwarder.events(),
 				func(id string) (interface{}, error) {
 					return h.dry.dockerDaemon.Task(id)
@@ -39,7 +38,7 @@ func (h *serviceTasksScreenEventHandler) handle(event termbox.Event, f func(even
 					f(h)
 					refreshScreen()
 				})); err != nil {
-			h.dry.appmessage(
+			h.dry.apperror(
 				fmt.Sprintf("Error inspecting stack: %s", err.Error()))
 		}
 
@@ -62,6 +61,9 @@ func (h *serviceTasksScreenEventHandler) handle(event termbox.Event, f func(even
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
 		}
 	}
+	if !handled {
+		handled = handleTaskKeys(h, h.dry, h.screen, h.widget, ServiceTasks, event, f)
+	}
 	if !handled {
 		h.baseEventHandler.handle(event, f)
 	}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+)
+
+//minSplitWidth is the narrowest screen on which the container list can be
+//split, narrower screens show the list alone
+const minSplitWidth = 140
+
+//splitListRatio is the part of the screen width taken by the container list
+//when it is split
+const splitListRatio = 0.6
+
+//toggleSplitView shows the container list next to a preview of the container
+//on the cursor, or the list alone if it is already split. The choice is saved
+//as a user preference.
+func (d *Dry) toggleSplitView(screenWidth int) {
+	d.Lock()
+	split := !d.splitView
+	if split && screenWidth < minSplitWidth {
+		d.Unlock()
+		d.appmessage(fmt.Sprintf("The split view needs a terminal at least %d columns wide, it has %d", minSplitWidth, screenWidth))
+		return
+	}
+	d.splitView = split
+	d.Unlock()
+	if err := userPreferences.setSplitView(split); err != nil {
+		d.apperror(fmt.Sprintf("Split view preference could not be saved: %s", err.Error()))
+	}
+}
+
+//splitLayout returns the width of the container list if it must be shown
+//split on a screen of the given width, false if it must be shown alone
+func (d *Dry) splitLayout(screenWidth int) (int, bool) {
+	d.RLock()
+	defer d.RUnlock()
+	if !d.splitView || screenWidth < minSplitWidth {
+		return 0, false
+	}
+	return int(float64(screenWidth) * splitListRatio), true
+}
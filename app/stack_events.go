@@ -1,11 +1,13 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/appui/swarm"
-	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/docker"
 	termbox "github.com/nsf/termbox-go"
 )
 
@@ -22,46 +24,21 @@ func (h *stacksScreenEventHandler) handle(event termbox.Event, f func(eventHandl
 	case termbox.KeyF5: // refresh
 		h.dry.appmessage("Refreshing stack list")
 		h.widget.Unmount()
-	case termbox.KeyEnter: //inspect
-		showTasks := func(stack string) error {
-			widgets.StackTasks.ForStack(stack)
-			h.dry.ViewMode(StackTasks)
-			f(viewsToHandlers[StackTasks])
+	case termbox.KeyEnter: //show the services of the stack
+		showServices := func(stack string) error {
+			h.screen.Cursor.Reset()
+			widgets.ServiceList.ForStack(stack)
+			h.dry.ViewMode(Services)
+			f(viewsToHandlers[Services])
 			return refreshScreen()
 		}
-		h.widget.OnEvent(showTasks)
+		h.widget.OnEvent(showServices)
 	case termbox.KeyCtrlR: //remove stack
-		rw := appui.NewPrompt("The selected stack will be removed. Do you want to proceed? y/N")
-		widgets.add(rw)
-		forwarder := newEventForwarder()
-		f(forwarder)
-		refreshScreen()
-		go func() {
-			events := ui.EventSource{
-				Events: forwarder.events(),
-				EventHandledCallback: func(e termbox.Event) error {
-					return refreshScreen()
-				},
-			}
-			rw.OnFocus(events)
-			widgets.remove(rw)
-			confirmation, canceled := rw.Text()
-			f(h)
-			if canceled || (confirmation != "y" && confirmation != "Y") {
-				return
-			}
-			removeStack := func(stack string) error {
-				err := h.dry.dockerDaemon.StackRemove(stack)
-				if err == nil {
-					h.dry.appmessage(fmt.Sprintf("Stack %s removed", stack))
-				}
-				return err
-			}
-			if err := h.widget.OnEvent(removeStack); err != nil {
-				h.dry.appmessage("There was an error removing the stack: " + err.Error())
-			}
-			refreshScreen()
-		}()
+		removeStack := func(stack string) error {
+			h.removeStack(stack, f)
+			return nil
+		}
+		h.widget.OnEvent(removeStack)
 	default:
 		handled = false
 	}
@@ -70,6 +47,15 @@ func (h *stacksScreenEventHandler) handle(event termbox.Event, f func(eventHandl
 		case '6':
 			//already in stack screen
 			handled = true
+		case 't', 'T':
+			handled = true
+			showTasks := func(stack string) error {
+				widgets.StackTasks.ForStack(stack)
+				h.dry.ViewMode(StackTasks)
+				f(viewsToHandlers[StackTasks])
+				return refreshScreen()
+			}
+			h.widget.OnEvent(showTasks)
 		case '%':
 			handled = true
 			forwarder := newEventForwarder()
@@ -81,6 +67,15 @@ func (h *stacksScreenEventHandler) handle(event termbox.Event, f func(eventHandl
 				f(h)
 			}
 			showFilterInput(newEventSource(forwarder.events()), applyFilter)
+		case '/': //search stacks
+			handled = true
+			searchList(h, f, h.widget)
+		case 'n': //next search match
+			handled = true
+			jumpToSearchMatch(h.dry, h.widget, true, "stack")
+		case 'N': //previous search match
+			handled = true
+			jumpToSearchMatch(h.dry, h.widget, false, "stack")
 		}
 	}
 	if handled {
@@ -89,3 +84,64 @@ func (h *stacksScreenEventHandler) handle(event termbox.Event, f func(eventHandl
 		h.baseEventHandler.handle(event, f)
 	}
 }
+
+//removeStack asks whether the secrets and configs of the given stack have to be
+//removed too and, once confirmed, removes the stack in the background
+func (h *stacksScreenEventHandler) removeStack(stack string, f func(eventHandler)) {
+	dry := h.dry
+	prompt := appui.NewPrompt(
+		fmt.Sprintf("Remove the secrets and configs of stack %s too? (y/N)", stack))
+	widgets.add(prompt)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		prompt.OnFocus(newEventSource(forwarder.events()))
+		answer, canceled := prompt.Text()
+		f(h)
+		widgets.remove(prompt)
+		if canceled {
+			refreshScreen()
+			return
+		}
+		withSecretsAndConfigs := answer == "y" || answer == "Y"
+		resources, err := dry.dockerDaemon.StackResources(stack, withSecretsAndConfigs)
+		if err != nil {
+			dry.apperror("There was an error removing the stack: " + err.Error())
+			return
+		}
+		dry.confirm(confirmStackRm, fmt.Sprintf("Do you want to remove stack %s?", stack), stackTargets(resources), h, f, func() {
+			dry.runJob(fmt.Sprintf("Remove stack %s", stack), false, removeStack(dry, stack, resources))
+		})
+	}()
+}
+
+//removeStack returns the job that removes the given resources of a stack, the
+//outcome of each removal is reported on the notification area
+func removeStack(dry *Dry, stack string, resources []docker.StackResource) jobFunc {
+	return func(ctx context.Context, progress func(float64)) (string, error) {
+		report := dry.dockerDaemon.StackRemoveResources(stack, resources,
+			func(result docker.StackRemoveResult, done, total int) {
+				if result.Err != nil {
+					dry.apperror(fmt.Sprintf("Stack %s: could not remove %s: %s", stack, result.StackResource, result.Err.Error()))
+				} else {
+					dry.appmessage(fmt.Sprintf("Stack %s: removed %s (%d/%d)", stack, result.StackResource, done, total))
+				}
+				progress(float64(done) / float64(total))
+			})
+		if len(report.Failed()) > 0 {
+			return "", errors.New(report.Summary())
+		}
+		return report.Summary(), nil
+	}
+}
+
+//stackTargets describes the resources of a stack on a confirmation
+func stackTargets(resources []docker.StackResource) []string {
+	targets := make([]string, len(resources))
+	for i, resource := range resources {
+		targets[i] = resource.String()
+	}
+	return targets
+}
@@ -29,7 +29,6 @@ func (h *stackTasksScreenEventHandler) handle(event termbox.Event, f func(eventH
 		f(forwarder)
 		if err := h.widget.OnEvent(
 			inspect(
-				h.screen,
 				forwarder.events(),
 				func(id string) (interface{}, error) {
 					return h.dry.dockerDaemon.Task(id)
@@ -39,7 +38,7 @@ func (h *stackTasksScreenEventHandler) handle(event termbox.Event, f func(eventH
 					f(h)
 					refreshScreen()
 				})); err != nil {
-			h.dry.appmessage(
+			h.dry.apperror(
 				fmt.Sprintf("Error inspecting stack: %s", err.Error()))
 		}
 	default:
@@ -59,6 +58,9 @@ func (h *stackTasksScreenEventHandler) handle(event termbox.Event, f func(eventH
 		showFilterInput(newEventSource(forwarder.events()), applyFilter)
 	}
 
+	if !handled {
+		handled = handleTaskKeys(h, h.dry, h.screen, h.widget, StackTasks, event, f)
+	}
 	if handled {
 		refreshScreen()
 	} else {
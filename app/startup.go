@@ -0,0 +1,117 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/appui/swarm"
+)
+
+//StartupOptions are the view dry starts on, and how its list is filtered
+//and sorted, as given on the command line or on the user preferences
+type StartupOptions struct {
+	View   string `json:"view,omitempty"`
+	Filter string `json:"filter,omitempty"`
+	Sort   string `json:"sort,omitempty"`
+}
+
+//override returns these options overridden by the given ones: a view
+//replaces every option, a filter or a sort mode without view only
+//replaces its counterpart
+func (o StartupOptions) override(other StartupOptions) StartupOptions {
+	if other.View != "" {
+		return other
+	}
+	if other.Filter != "" {
+		o.Filter = other.Filter
+	}
+	if other.Sort != "" {
+		o.Sort = other.Sort
+	}
+	return o
+}
+
+//startupView is a view dry can start on
+type startupView struct {
+	view viewMode
+	//filterable tells if the list of the view can be filtered
+	filterable bool
+	//sortHeaders returns the columns that sort the list, nil if it is not sorted
+	sortHeaders func() []appui.SortableColumnHeader
+}
+
+//startupViews are the views dry can start on, by the name given on the
+//command line
+var startupViews = map[string]startupView{
+	"containers": {Main, true, appui.ContainerSortHeaders},
+	"images":     {Images, true, appui.ImageSortHeaders},
+	"networks":   {Networks, true, appui.NetworkSortHeaders},
+	"monitor":    {Monitor, false, appui.MonitorSortHeaders},
+	"nodes":      {Nodes, true, swarm.NodeSortHeaders},
+	"services":   {Services, true, swarm.ServiceSortHeaders},
+	"stacks":     {Stacks, true, swarm.StackSortHeaders},
+	"plugins":    {Plugins, true, nil},
+	"jobs":       {Jobs, false, nil},
+	"disk-usage": {DiskUsage, false, nil},
+}
+
+//startup is the view dry starts on, and the options of its list
+type startup struct {
+	view viewMode
+	list appui.ListOptions
+}
+
+//listOptions returns the options the list of the given view starts with,
+//only the list of the startup view is not created with the defaults
+func (s startup) listOptions(view viewMode) appui.ListOptions {
+	if view != s.view {
+		return appui.ListOptions{}
+	}
+	return s.list
+}
+
+//parseStartupOptions returns the view dry starts on as the given options
+//tell, an error is returned if the view is unknown or its list cannot
+//be filtered or sorted as asked
+func parseStartupOptions(opts StartupOptions) (startup, error) {
+	name := strings.ToLower(opts.View)
+	if name == "" {
+		name = "containers"
+	}
+	v, ok := startupViews[name]
+	if !ok {
+		return startup{}, fmt.Errorf("unknown view %q, valid views are: %s",
+			opts.View, strings.Join(startupViewNames(), ", "))
+	}
+	start := startup{view: v.view}
+	if opts.Filter != "" {
+		if !v.filterable {
+			return startup{}, fmt.Errorf("the %s view cannot be filtered", name)
+		}
+		start.list.Filter = opts.Filter
+	}
+	if opts.Sort != "" {
+		if v.sortHeaders == nil {
+			return startup{}, fmt.Errorf("the %s view cannot be sorted", name)
+		}
+		mode, ok := appui.SortModeByName(v.sortHeaders(), opts.Sort)
+		if !ok {
+			return startup{}, fmt.Errorf("the %s view cannot be sorted by %q, valid sort modes are: %s",
+				name, opts.Sort, strings.Join(appui.SortNames(v.sortHeaders()), ", "))
+		}
+		start.list.SortMode = mode
+	}
+	return start, nil
+}
+
+//startupViewNames returns the names of the views dry can start on, sorted
+func startupViewNames() []string {
+	names := make([]string, 0, len(startupViews))
+	for name := range startupViews {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
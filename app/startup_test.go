@@ -0,0 +1,82 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestParseStartupOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     StartupOptions
+		view     viewMode
+		filter   string
+		sortMode docker.SortMode
+	}{
+		{"defaults", StartupOptions{}, Main, "", docker.NoSort},
+		{"filtered containers", StartupOptions{View: "containers", Filter: "status:exited"}, Main, "status:exited", docker.NoSort},
+		{"containers filtered without view", StartupOptions{Filter: "web"}, Main, "web", docker.NoSort},
+		{"sorted images", StartupOptions{View: "images", Sort: "size"}, Images, "", docker.SortImagesBySize},
+		{"names ignore case", StartupOptions{View: "Networks", Sort: "NETWORK-ID"}, Networks, "", docker.SortNetworksByID},
+		{"sorted monitor", StartupOptions{View: "monitor", Sort: "cpu"}, Monitor, "", docker.SortStatsByCPU},
+		{"filtered services", StartupOptions{View: "services", Filter: "port:443", Sort: "image"}, Services, "port:443", docker.SortByServiceImage},
+		{"jobs", StartupOptions{View: "jobs"}, Jobs, "", docker.NoSort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStartupOptions(tt.opts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if got.view != tt.view || got.list.Filter != tt.filter || got.list.SortMode != tt.sortMode {
+				t.Errorf("parseStartupOptions() = %+v, want view %d, filter %q and sort mode %d",
+					got, tt.view, tt.filter, tt.sortMode)
+			}
+		})
+	}
+}
+
+func TestParseStartupOptions_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts StartupOptions
+		want string
+	}{
+		{"unknown view", StartupOptions{View: "volumes"}, "valid views are: containers, disk-usage, images, jobs, monitor, networks, nodes, plugins, services, stacks"},
+		{"unknown sort mode", StartupOptions{View: "images", Sort: "tag"}, "valid sort modes are: repository, id, created, size"},
+		{"unknown container sort mode", StartupOptions{Sort: "ports"}, "valid sort modes are: container, image, status, names"},
+		{"view that is not sorted", StartupOptions{View: "plugins", Sort: "name"}, "the plugins view cannot be sorted"},
+		{"view that is not filtered", StartupOptions{View: "monitor", Filter: "web"}, "the monitor view cannot be filtered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseStartupOptions(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseStartupOptions() error = %v, want one with %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartupOptionsOverride(t *testing.T) {
+	preferred := StartupOptions{View: "images", Filter: "alpine", Sort: "size"}
+	tests := []struct {
+		name  string
+		given StartupOptions
+		want  StartupOptions
+	}{
+		{"nothing given", StartupOptions{}, preferred},
+		{"a view replaces everything", StartupOptions{View: "monitor"}, StartupOptions{View: "monitor"}},
+		{"a filter replaces the filter", StartupOptions{Filter: "debian"}, StartupOptions{View: "images", Filter: "debian", Sort: "size"}},
+		{"a sort mode replaces the sort mode", StartupOptions{Sort: "created"}, StartupOptions{View: "images", Filter: "alpine", Sort: "created"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preferred.override(tt.given); got != tt.want {
+				t.Errorf("override() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
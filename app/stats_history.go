@@ -0,0 +1,98 @@
+package app
+
+import (
+	"time"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//statsHistoryWindows are the time windows that can be used on the stats history screen
+var statsHistoryWindows = []time.Duration{
+	time.Minute,
+	docker.DefaultStatsHistoryWindow,
+	15 * time.Minute,
+	30 * time.Minute,
+}
+
+//statsHistoryScreen shows the stats history of the given container as graphs. The history
+//is kept while the screen is shown and dropped once it is closed.
+func statsHistoryScreen(daemon docker.ContainerDaemon, container *docker.Container, screen *ui.Screen, events <-chan termbox.Event, closeCallback func()) {
+	defer closeCallback()
+
+	window := 1
+	history := docker.NewStatsHistory(statsHistoryWindows[window])
+	widget := appui.NewContainerStatsHistory(container, history, 1)
+	render := func() {
+		screen.Clear()
+		screen.RenderBufferer(widget)
+		screen.Flush()
+	}
+
+	var stats *docker.StatsChannel
+	var statsC <-chan *docker.Stats
+	open := func() {
+		if c := daemon.ContainerByID(container.ID); c != nil && docker.IsContainerRunning(c) {
+			//a channel without stats is not kept, so that it is opened again
+			if s := daemon.OpenChannel(c); s != nil && s.Stats != nil {
+				stats, statsC = s, s.Stats
+			}
+		}
+	}
+	//if the container stops, the stats channel is opened again once it is running
+	reopen := time.NewTicker(time.Second)
+	defer reopen.Stop()
+
+	screen.ClearAndFlush()
+	open()
+	render()
+loop:
+	for {
+		select {
+		case event := <-events:
+			if event.Type != termbox.EventKey {
+				continue
+			}
+			if event.Key == termbox.KeyEsc {
+				break loop
+			}
+			switch event.Ch {
+			case '+':
+				if window < len(statsHistoryWindows)-1 {
+					window++
+					history.SetWindow(statsHistoryWindows[window])
+				}
+			case '-':
+				if window > 0 {
+					window--
+					history.SetWindow(statsHistoryWindows[window])
+				}
+			}
+			render()
+		case stat, ok := <-statsC:
+			if !ok {
+				history.AddGap(time.Now())
+				stats, statsC = nil, nil
+			} else {
+				history.Add(time.Now(), stat)
+			}
+			render()
+		case <-reopen.C:
+			if stats == nil {
+				open()
+			}
+		}
+	}
+	if stats != nil && stats.Done != nil {
+		close(stats.Done)
+		//drained until closed so the stats stream never blocks
+		go func(c <-chan *docker.Stats) {
+			for range c {
+			}
+		}(statsC)
+	}
+	screen.Clear()
+	screen.Sync()
+}
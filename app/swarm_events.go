@@ -0,0 +1,162 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui/swarm"
+	termbox "github.com/nsf/termbox-go"
+)
+
+type swarmScreenEventHandler struct {
+	baseEventHandler
+	widget *swarm.SwarmStatusWidget
+}
+
+func (h *swarmScreenEventHandler) handle(event termbox.Event, f func(eventHandler)) {
+	handled := true
+	switch event.Key {
+	case termbox.KeyEsc:
+		view := Main
+		if h.dry.dockerDaemon.SwarmMode() {
+			view = Nodes
+		}
+		h.screen.Cursor.Reset()
+		f(viewsToHandlers[view])
+		h.dry.ViewMode(view)
+	case termbox.KeyF5:
+		h.widget.Unmount()
+	case termbox.KeyCtrlL:
+		h.leaveSwarm(f)
+	case termbox.KeyCtrlR:
+		h.rotateTokens(f)
+	default:
+		handled = false
+	}
+	if !handled {
+		handled = true
+		switch event.Ch {
+		case 'i', 'I':
+			h.initSwarm(f)
+		case 'j', 'J':
+			h.joinSwarm(f)
+		case 'c':
+			tokens := h.widget.Tokens()
+			h.copyJoinCommand("worker", tokens.Worker, tokens.JoinCommand(tokens.Worker))
+		case 'C':
+			tokens := h.widget.Tokens()
+			h.copyJoinCommand("manager", tokens.Manager, tokens.JoinCommand(tokens.Manager))
+		default:
+			handled = false
+		}
+	}
+	if handled {
+		refreshScreen()
+	} else {
+		h.baseEventHandler.handle(event, f)
+	}
+}
+
+//initSwarm creates a new swarm, asking first for the address to advertise
+func (h *swarmScreenEventHandler) initSwarm(f func(eventHandler)) {
+	if h.dry.dockerDaemon.SwarmMode() {
+		h.dry.apperror("This Docker host is already part of a swarm")
+		return
+	}
+	askText(h, "Advertise address (e.g. 192.168.1.10, leave empty to let Docker choose)", f,
+		func(addr string) {
+			h.dry.appmessage("Initializing swarm")
+			if err := h.dry.dockerDaemon.SwarmInit(addr); err != nil {
+				h.dry.apperror(err.Error())
+				return
+			}
+			h.swarmChanged()
+			h.dry.appsuccess("Swarm initialized, this Docker host is now a manager")
+		})
+}
+
+//joinSwarm joins the swarm managed from the address given by the user, as a worker
+//or as a manager depending on the token given
+func (h *swarmScreenEventHandler) joinSwarm(f func(eventHandler)) {
+	if h.dry.dockerDaemon.SwarmMode() {
+		h.dry.apperror("This Docker host is already part of a swarm")
+		return
+	}
+	askText(h, "Manager address (e.g. 192.168.1.10:2377)", f, func(addr string) {
+		if addr == "" {
+			h.dry.apperror("A manager address is needed to join a swarm")
+			return
+		}
+		askText(h, "Join token", f, func(token string) {
+			h.dry.appmessage(fmt.Sprintf("Joining the swarm managed from %s", addr))
+			if err := h.dry.dockerDaemon.SwarmJoin(addr, token); err != nil {
+				h.dry.apperror(err.Error())
+				return
+			}
+			h.swarmChanged()
+			h.dry.appsuccess("This Docker host joined the swarm")
+			h.screen.Cursor.Reset()
+			f(viewsToHandlers[Nodes])
+			h.dry.ViewMode(Nodes)
+		})
+	})
+}
+
+//leaveSwarm leaves the swarm, managers are warned that the swarm can lose its quorum
+func (h *swarmScreenEventHandler) leaveSwarm(f func(eventHandler)) {
+	if !h.dry.dockerDaemon.SwarmMode() {
+		h.dry.apperror("This Docker host is not part of a swarm")
+		return
+	}
+	title := " Leave the swarm "
+	if h.widget.Manager() {
+		title = " This node is a manager, the swarm can lose its quorum if it leaves "
+	}
+	askChoice(h, title, []string{"leave", "force leave"}, f, func(choice string) {
+		if err := h.dry.dockerDaemon.SwarmLeave(choice == "force leave"); err != nil {
+			h.dry.apperror(err.Error())
+			return
+		}
+		h.swarmChanged()
+		h.dry.appsuccess("This Docker host left the swarm")
+	})
+}
+
+//rotateTokens replaces the join tokens chosen by the user by new ones
+func (h *swarmScreenEventHandler) rotateTokens(f func(eventHandler)) {
+	if !h.widget.Manager() {
+		h.dry.apperror("Join tokens can only be rotated on swarm managers")
+		return
+	}
+	askChoice(h, " Rotate join token ", []string{"worker", "manager", "both"}, f, func(choice string) {
+		worker := choice == "worker" || choice == "both"
+		manager := choice == "manager" || choice == "both"
+		if err := h.dry.dockerDaemon.SwarmRotateJoinTokens(worker, manager); err != nil {
+			h.dry.apperror(err.Error())
+			return
+		}
+		h.widget.Unmount()
+		h.dry.appsuccess(fmt.Sprintf("Join token rotated: %s", choice))
+	})
+}
+
+func (h *swarmScreenEventHandler) copyJoinCommand(role, token, command string) {
+	if token == "" {
+		h.dry.apperror("Join tokens are only available on swarm managers")
+		return
+	}
+	if err := copyToClipboard(command); err != nil {
+		h.dry.apperror(fmt.Sprintf("Could not copy the %s join command: %s", role, err.Error()))
+		return
+	}
+	h.dry.appsuccess(fmt.Sprintf("The %s join command was copied to the clipboard", role))
+}
+
+//swarmChanged reloads what is shown about the swarm once the Docker host has
+//joined or left one
+func (h *swarmScreenEventHandler) swarmChanged() {
+	widgets.DockerInfo.Refresh(h.dry.dockerDaemon)
+	h.widget.Unmount()
+	widgets.Nodes.Unmount()
+	widgets.ServiceList.Unmount()
+	widgets.Stacks.Unmount()
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//tasksWidget is what the task views have in common
+type tasksWidget interface {
+	OnEvent(appui.EventCommand) error
+	ToggleDesiredStateFilter()
+	ToggleFailedFilter()
+}
+
+//handleTaskKeys handles the keys that every task view shares, it returns
+//true if the given event was handled. view is where the user comes back to
+//after looking at the error of a task.
+func handleTaskKeys(h eventHandler, dry *Dry, screen *ui.Screen, widget tasksWidget, view viewMode,
+	event termbox.Event, f func(eventHandler)) bool {
+	switch event.Ch {
+	case 'd', 'D':
+		widget.ToggleDesiredStateFilter()
+	case 'f', 'F':
+		widget.ToggleFailedFilter()
+	case 'e', 'E':
+		forwarder := newEventForwarder()
+		f(forwarder)
+		if err := widget.OnEvent(func(id string) error {
+			task, err := dry.dockerDaemon.Task(id)
+			if err != nil {
+				return err
+			}
+			if task.Status.Err == "" {
+				return fmt.Errorf("task %s has no error", id)
+			}
+			go appui.Less(ui.StringRenderer(task.Status.Err), screen, forwarder.events(), func() {
+				dry.ViewMode(view)
+				f(h)
+				refreshScreen()
+			})
+			return nil
+		}); err != nil {
+			f(h)
+			dry.apperror(err.Error())
+		}
+	default:
+		return false
+	}
+	refreshScreen()
+	return true
+}
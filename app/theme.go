@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/ui"
+	log "github.com/sirupsen/logrus"
+)
+
+//themeFile is the file where the user-defined color theme is kept
+var themeFile, _ = homedir.Expand("~/.dry/theme.json")
+
+//loadColorThemes adds the user-defined color theme, if there is one, to the
+//themes available and activates the theme found on the user preferences
+func loadColorThemes(screen *ui.Screen, prefs *preferences) {
+	theme, err := loadUserColorTheme(themeFile)
+	if err != nil {
+		log.Warnf("Error loading color theme from %s: %s", themeFile, err.Error())
+	} else if theme != nil {
+		appui.AddColorTheme(theme)
+	}
+	name := prefs.theme()
+	if name == "" {
+		return
+	}
+	if theme = appui.ColorThemeByName(name); theme == nil {
+		log.Warnf("Color theme %s not found", name)
+		return
+	}
+	appui.SetColorTheme(theme)
+	applyColorTheme(screen)
+}
+
+//applyColorTheme makes the theme set since the last render the active one.
+//The screen is locked while the theme changes so that nothing is rendered
+//with half of it.
+func applyColorTheme(screen *ui.Screen) {
+	screen.Lock()
+	changed := appui.ApplyColorTheme()
+	screen.Unlock()
+	if changed {
+		screen.ColorTheme(appui.DryTheme)
+	}
+}
+
+//loadUserColorTheme loads the color theme from the given file, a nil
+//theme is returned if the file does not exist
+func loadUserColorTheme(path string) (*ui.ColorTheme, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return appui.LoadColorTheme(f)
+}
+
+//rotateColorTheme changes the color theme to the next one on the next
+//render, the theme is saved as a user preference
+func (d *Dry) rotateColorTheme() {
+	theme := appui.RotateColorTheme()
+	if err := userPreferences.setTheme(theme.Name); err != nil {
+		d.apperror(fmt.Sprintf("Color theme could not be saved: %s", err.Error()))
+		return
+	}
+	d.appmessage(fmt.Sprintf("Color theme: %s", theme.Name))
+}
+
+//toggleTruncationMode switches between cutting the text that does not fit on
+//a column with an ellipsis and hard cutting it, the mode is saved as a user
+//preference
+func (d *Dry) toggleTruncationMode() {
+	mode := appui.ToggleTruncationMode()
+	if err := userPreferences.setTruncationMode(mode); err != nil {
+		d.apperror(fmt.Sprintf("Truncation mode could not be saved: %s", err.Error()))
+		return
+	}
+	d.appmessage(fmt.Sprintf("Truncation mode: %s", mode))
+}
+
+//toggleCompatibilityMode switches between drawing only ASCII characters
+//and text attributes, with the monochrome theme, and drawing as usual
+func (d *Dry) toggleCompatibilityMode(screen *ui.Screen) {
+	on := !ui.CompatibilityMode()
+	screen.CompatibilityMode(on)
+	d.useCompatibleTheme(on)
+	screen.Sync()
+	if on {
+		d.appmessage("Compatibility mode: ASCII characters and text attributes only")
+	} else {
+		d.appmessage("Compatibility mode off")
+	}
+}
+
+//useCompatibleTheme switches to the monochrome theme on the next render, the
+//theme in use is restored once the compatibility mode is left
+func (d *Dry) useCompatibleTheme(on bool) {
+	d.Lock()
+	defer d.Unlock()
+	if on {
+		d.themeBeforeCompatibility = appui.ColorThemeName()
+		appui.SetColorTheme(appui.Monochrome)
+	} else if theme := appui.ColorThemeByName(d.themeBeforeCompatibility); theme != nil {
+		appui.SetColorTheme(theme)
+	}
+}
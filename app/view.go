@@ -20,5 +20,20 @@ const (
 	StackTasks
 	Tasks
 	ContainerMenu
+	Jobs
+	ContainerGraph
+	ContainerHealth
+	SwarmStatus
+	Plugins
+	BuildCache
+	ContainerEnv
+	ImageLayer
+	ContainerMounts
+	ContainerProcesses
+	ImageHistory
+	RegistrySearch
+	ImageScan
+	ImageManifest
+	ImageSizes
 	NoView
 )
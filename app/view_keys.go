@@ -0,0 +1,22 @@
+package app
+
+import "github.com/moncho/dry/appui"
+
+//showViewKeys shows, over the current view, the keys bound to its actions and
+//the global ones. Any key closes them and the view handler, h, takes over again.
+func showViewKeys(d *Dry, h eventHandler, f func(eventHandler)) {
+	scope := keymapScope(d.viewMode())
+	view, global := activeKeymap.viewBindings(scope)
+	overlay := appui.NewKeysOverlay(scope, view, global)
+	widgets.add(overlay)
+	forwarder := newEventForwarder()
+	f(forwarder)
+	refreshScreen()
+
+	go func() {
+		overlay.OnFocus(newEventSource(forwarder.events()))
+		widgets.remove(overlay)
+		f(h)
+		refreshScreen()
+	}()
+}
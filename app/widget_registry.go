@@ -16,47 +16,80 @@ import (
 //   this struct.
 // * a list of widgets to be rendered on the next rendering.
 type widgetRegistry struct {
-	ContainerList *appui.ContainersWidget
-	ContainerMenu *appui.ContainerMenuWidget
-	DiskUsage     *appui.DockerDiskUsageRenderer
-	DockerInfo    *appui.DockerInfo
-	ImageList     *appui.DockerImagesWidget
-	Monitor       *appui.Monitor
-	Networks      *appui.DockerNetworksWidget
-	Nodes         *swarm.NodesWidget
-	NodeTasks     *swarm.NodeTasksWidget
-	ServiceTasks  *swarm.ServiceTasksWidget
-	ServiceList   *swarm.ServicesWidget
-	Stacks        *swarm.StacksWidget
-	StackTasks    *swarm.StacksTasksWidget
-	MessageBar    *ui.ExpiringMessageWidget
-	activeWidgets map[string]termui.Widget
+	BuildCache       *appui.BuildCacheWidget
+	ContainerEnv     *appui.ContainerEnvWidget
+	ContainerGraph   *appui.ContainerGraphWidget
+	ContainerHealth  *appui.ContainerHealthWidget
+	ContainerList    *appui.ContainersWidget
+	ContainerMenu    *appui.ContainerMenuWidget
+	ContainerMounts  *appui.ContainerMountsWidget
+	ContainerPreview *appui.ContainerPreviewWidget
+	ContainerProcs   *appui.ContainerProcessesWidget
+	DiskUsage        *appui.DockerDiskUsageRenderer
+	DockerInfo       *appui.DockerInfo
+	ImageHistory     *appui.ImageHistoryWidget
+	ImageLayer       *appui.ImageLayerWidget
+	ImageManifest    *appui.ImageManifestWidget
+	ImageScan        *appui.ImageScanWidget
+	ImageSizes       *appui.ImageSizesWidget
+	ImageList        *appui.DockerImagesWidget
+	Jobs             *appui.JobsWidget
+	Monitor          *appui.Monitor
+	Networks         *appui.DockerNetworksWidget
+	Nodes            *swarm.NodesWidget
+	Plugins          *appui.DockerPluginsWidget
+	RegistrySearch   *appui.RegistrySearchWidget
+	NodeTasks        *swarm.NodeTasksWidget
+	ServiceTasks     *swarm.ServiceTasksWidget
+	ServiceList      *swarm.ServicesWidget
+	Stacks           *swarm.StacksWidget
+	StackTasks       *swarm.StacksTasksWidget
+	SwarmStatus      *swarm.SwarmStatusWidget
+	activeWidgets    map[string]termui.Widget
 	sync.Mutex
 }
 
-//NewWidgetRegistry creates the WidgetCatalog
-func newWidgetRegistry(daemon docker.ContainerDaemon) *widgetRegistry {
+//NewWidgetRegistry creates the WidgetCatalog, the list of the view dry
+//starts on is created filtered and sorted as asked
+func newWidgetRegistry(daemon docker.ContainerDaemon, start startup) *widgetRegistry {
 	di := appui.NewDockerInfo(daemon)
 	di.SetX(0)
 	di.SetY(1)
 	di.SetWidth(ui.ActiveScreen.Dimensions.Width)
 	w := widgetRegistry{
-		DockerInfo:    di,
-		ContainerList: appui.NewContainersWidget(daemon, appui.MainScreenHeaderSize),
-		ContainerMenu: appui.NewContainerMenuWidget(daemon, appui.MainScreenHeaderSize),
-		ImageList:     appui.NewDockerImagesWidget(daemon, appui.MainScreenHeaderSize),
-		DiskUsage:     appui.NewDockerDiskUsageRenderer(ui.ActiveScreen.Dimensions.Height),
-		Monitor:       appui.NewMonitor(daemon, appui.MainScreenHeaderSize),
-		Networks:      appui.NewDockerNetworksWidget(daemon, appui.MainScreenHeaderSize),
-		Nodes:         swarm.NewNodesWidget(daemon, appui.MainScreenHeaderSize),
-		NodeTasks:     swarm.NewNodeTasksWidget(daemon, appui.MainScreenHeaderSize),
-		ServiceTasks:  swarm.NewServiceTasksWidget(daemon, appui.MainScreenHeaderSize),
-		ServiceList:   swarm.NewServicesWidget(daemon, appui.MainScreenHeaderSize),
-		Stacks:        swarm.NewStacksWidget(daemon, appui.MainScreenHeaderSize),
-		StackTasks:    swarm.NewStacksTasksWidget(daemon, appui.MainScreenHeaderSize),
-		activeWidgets: make(map[string]termui.Widget),
-		MessageBar:    ui.NewExpiringMessageWidget(0, ui.ActiveScreen.Dimensions.Width, appui.DryTheme),
+		DockerInfo:       di,
+		BuildCache:       appui.NewBuildCacheWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerEnv:     appui.NewContainerEnvWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerGraph:   appui.NewContainerGraphWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerHealth:  appui.NewContainerHealthWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerList:    appui.NewContainersWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Main)),
+		ContainerMenu:    appui.NewContainerMenuWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerMounts:  appui.NewContainerMountsWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerPreview: appui.NewContainerPreviewWidget(daemon, appui.MainScreenHeaderSize),
+		ContainerProcs:   appui.NewContainerProcessesWidget(daemon, appui.MainScreenHeaderSize),
+		ImageHistory:     appui.NewImageHistoryWidget(daemon, appui.MainScreenHeaderSize),
+		ImageLayer:       appui.NewImageLayerWidget(daemon, appui.MainScreenHeaderSize),
+		ImageManifest:    appui.NewImageManifestWidget(docker.NewRegistryClient(), appui.MainScreenHeaderSize),
+		ImageScan:        appui.NewImageScanWidget(appui.MainScreenHeaderSize),
+		ImageSizes:       appui.NewImageSizesWidget(daemon, appui.MainScreenHeaderSize),
+		ImageList:        appui.NewDockerImagesWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Images)),
+		DiskUsage:        appui.NewDockerDiskUsageRenderer(ui.ActiveScreen.Dimensions.Height),
+		Monitor:          appui.NewMonitor(daemon, appui.MainScreenHeaderSize, start.listOptions(Monitor)),
+		Networks:         appui.NewDockerNetworksWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Networks)),
+		Nodes:            swarm.NewNodesWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Nodes)),
+		Plugins:          appui.NewDockerPluginsWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Plugins)),
+		RegistrySearch:   appui.NewRegistrySearchWidget(daemon, appui.MainScreenHeaderSize),
+		NodeTasks:        swarm.NewNodeTasksWidget(daemon, appui.MainScreenHeaderSize),
+		ServiceTasks:     swarm.NewServiceTasksWidget(daemon, appui.MainScreenHeaderSize),
+		ServiceList:      swarm.NewServicesWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Services)),
+		Stacks:           swarm.NewStacksWidget(daemon, appui.MainScreenHeaderSize, start.listOptions(Stacks)),
+		StackTasks:       swarm.NewStacksTasksWidget(daemon, appui.MainScreenHeaderSize),
+		SwarmStatus:      swarm.NewSwarmStatusWidget(daemon, appui.MainScreenHeaderSize),
+		activeWidgets:    make(map[string]termui.Widget),
 	}
+	w.ContainerList.OnSelectionChange(w.ContainerPreview.Select)
+	//checkpoints are an experimental feature, hidden if the daemon does not enable them
+	w.ContainerMenu.ShowCheckpoints(daemon.Experimental())
 
 	return &w
 }
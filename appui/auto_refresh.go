@@ -0,0 +1,44 @@
+package appui
+
+import (
+	"fmt"
+	"time"
+)
+
+//AutoRefresh is the state of the automatic refresh of a list, shown on the
+//header of its widget
+type AutoRefresh struct {
+	//Next is when the list is refreshed next, zero while it is refreshed
+	Next time.Time
+	//Last is when the list was last refreshed, zero if it was not yet
+	Last time.Time
+	//Suspended tells if refreshing waits for a dialog to be closed
+	Suspended bool
+}
+
+//headerDetails returns the details to show on a widget header about the
+//given auto refresh state, empty if the list does not refresh on its own
+func (r *AutoRefresh) headerDetails(now time.Time) string {
+	if r == nil {
+		return ""
+	}
+	var status string
+	switch {
+	case r.Suspended:
+		status = "paused"
+	case r.Next.IsZero():
+		status = "refreshing"
+	default:
+		//rounded up, so the countdown reaches 0 when the list is refreshed
+		left := r.Next.Sub(now)
+		seconds := int((left + time.Second - 1) / time.Second)
+		if seconds < 0 {
+			seconds = 0
+		}
+		status = fmt.Sprintf("in %ds", seconds)
+	}
+	if !r.Last.IsZero() {
+		status += ", last " + r.Last.Format("15:04:05")
+	}
+	return fmt.Sprintf("<b><blue> | Auto refresh: </><yellow>%s</></> ", status)
+}
@@ -0,0 +1,34 @@
+package appui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoRefreshHeaderDetails(t *testing.T) {
+	now := time.Date(2018, 6, 2, 18, 42, 2, 0, time.UTC)
+	last := now.Add(-8 * time.Second)
+	tests := []struct {
+		name    string
+		refresh *AutoRefresh
+		want    string
+	}{
+		{"no auto refresh", nil, ""},
+		{"first countdown", &AutoRefresh{Next: now.Add(10 * time.Second)}, "in 10s"},
+		{"rounded up", &AutoRefresh{Next: now.Add(1500 * time.Millisecond), Last: last}, "in 2s, last 18:41:54"},
+		{"due", &AutoRefresh{Next: now.Add(-time.Second), Last: last}, "in 0s, last 18:41:54"},
+		{"refreshing", &AutoRefresh{Last: last}, "refreshing, last 18:41:54"},
+		{"suspended", &AutoRefresh{Next: now.Add(time.Second), Suspended: true}, "paused"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want != "" {
+				want = "<b><blue> | Auto refresh: </><yellow>" + want + "</></> "
+			}
+			if got := tt.refresh.headerDetails(now); got != want {
+				t.Errorf("headerDetails() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+var buildCacheTableHeaders = []SortableColumnHeader{
+	{`ID`, docker.NoSortBuildCache},
+	{`TYPE`, docker.NoSortBuildCache},
+	{`SIZE`, docker.SortBuildCacheBySize},
+	{`LAST USED`, docker.SortBuildCacheByLastUsed},
+	{`IN USE`, docker.NoSortBuildCache},
+}
+
+//buildCacheDaemon is what BuildCacheWidget needs from the Docker daemon
+type buildCacheDaemon interface {
+	BuildCache() ([]*types.BuildCache, error)
+}
+
+//BuildCacheWidget shows the records of the build cache, records in use are marked
+type BuildCacheWidget struct {
+	dockerDaemon         buildCacheDaemon
+	header               *termui.TableHeader
+	filteredRows         []*BuildCacheRow
+	totalRows            []*BuildCacheRow
+	filterPattern        string
+	sortMode             docker.SortMode
+	height, width        int
+	selectedIndex        int
+	startIndex, endIndex int
+	x, y                 int
+	mounted              bool
+	loader               *AsyncLoader
+	sync.RWMutex
+}
+
+//NewBuildCacheWidget creates a widget to show the build cache
+func NewBuildCacheWidget(dockerDaemon buildCacheDaemon, y int) *BuildCacheWidget {
+	w := BuildCacheWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		header:       buildCacheTableHeader(),
+		height:       MainScreenAvailableHeight(),
+		sortMode:     docker.SortBuildCacheBySize,
+		width:        ui.ActiveScreen.Dimensions.Width}
+	w.loader = NewAsyncLoader(&w)
+	return &w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *BuildCacheWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	y := s.y
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	var filter string
+	if s.filterPattern != "" {
+		filter = fmt.Sprintf(
+			"<b><blue> | Active filter: </><yellow>%s</></> ", FilterText(s.filterPattern))
+	}
+
+	widgetHeader := WidgetHeader("Build cache records", s.RowCount(), filter+s.sizeDetails()+s.loader.HeaderDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	s.updateHeader()
+	s.header.SetY(y)
+	buf.Merge(s.header.Buffer())
+	y += s.header.GetHeight()
+
+	selected := s.selectedIndex - s.startIndex
+
+	for i, row := range s.visibleRows() {
+		row.SetY(y)
+		y += row.GetHeight()
+		switch {
+		case i == selected:
+			row.Highlighted()
+		case row.record.InUse:
+			row.Marked()
+		default:
+			row.NotHighlighted()
+		}
+		buf.Merge(row.Buffer())
+	}
+	return buf
+}
+
+//Filter filters the build cache records by the given filter
+func (s *BuildCacheWidget) Filter(filter string) {
+	s.Lock()
+	defer s.Unlock()
+	s.filterPattern = filter
+}
+
+//Mount tells this widget to be ready for rendering, the build cache is loaded in the background
+func (s *BuildCacheWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			records, err := s.dockerDaemon.BuildCache()
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]*BuildCacheRow, len(records))
+			for i, record := range records {
+				rows[i] = NewBuildCacheRow(record, s.header)
+			}
+			return func() {
+				s.totalRows = rows
+				s.align()
+			}, nil
+		})
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *BuildCacheWidget) Name() string {
+	return "BuildCacheWidget"
+}
+
+//InUse returns the number of records in use and their size
+func (s *BuildCacheWidget) InUse() (int, int64) {
+	s.RLock()
+	defer s.RUnlock()
+	var count int
+	var size int64
+	for _, row := range s.totalRows {
+		if row.record.InUse {
+			count++
+			size += row.record.Size
+		}
+	}
+	return count, size
+}
+
+//RowCount returns the number of rows of this widget.
+func (s *BuildCacheWidget) RowCount() int {
+	return len(s.filteredRows)
+}
+
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *BuildCacheWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *BuildCacheWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return SortModeTitle(buildCacheTableHeaders, s.sortMode)
+}
+
+//Sort rotates to the next sort mode.
+//SortBuildCacheBySize -> SortBuildCacheByLastUsed -> SortBuildCacheBySize
+func (s *BuildCacheWidget) Sort() {
+	s.Lock()
+	defer s.Unlock()
+	switch s.sortMode {
+	case docker.SortBuildCacheBySize:
+		s.sortMode = docker.SortBuildCacheByLastUsed
+	default:
+		s.sortMode = docker.SortBuildCacheBySize
+	}
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *BuildCacheWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *BuildCacheWidget) align() {
+	x := s.x
+	width := s.width
+
+	s.header.SetWidth(width)
+	s.header.SetX(x)
+
+	for _, row := range s.totalRows {
+		row.SetX(x)
+		row.SetWidth(width)
+	}
+}
+
+func (s *BuildCacheWidget) filterRows() {
+	if s.filterPattern != "" {
+		var rows []*BuildCacheRow
+
+		for _, row := range s.totalRows {
+			if RowFilters.ByPattern(s.filterPattern)(row) {
+				rows = append(rows, row)
+			}
+		}
+		s.filteredRows = rows
+	} else {
+		s.filteredRows = s.totalRows
+	}
+}
+
+func (s *BuildCacheWidget) calculateVisibleRows() {
+	count := s.RowCount()
+	height := s.height - s.header.GetHeight()
+	selected := s.selectedIndex
+
+	switch {
+	case height <= 0 || count == 0:
+		s.startIndex, s.endIndex = 0, 0
+	case count <= height:
+		s.startIndex, s.endIndex = 0, count
+	case selected < s.startIndex:
+		s.startIndex, s.endIndex = selected, selected+height
+	case selected >= s.startIndex+height:
+		s.startIndex, s.endIndex = selected-height+1, selected+1
+	default:
+		s.endIndex = s.startIndex + height
+		if s.endIndex > count {
+			s.startIndex, s.endIndex = count-height, count
+		}
+	}
+}
+
+//prepareForRendering sets the internal state of this widget so it is ready for
+//rendering (i.e. Buffer()).
+func (s *BuildCacheWidget) prepareForRendering() {
+	s.sortRows()
+	s.filterRows()
+	index := ui.ActiveScreen.Cursor.Position()
+	if index < 0 {
+		index = 0
+	} else if index >= s.RowCount() {
+		index = s.RowCount() - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+	s.calculateVisibleRows()
+}
+
+//sizeDetails describes the size of the cache and how much of it is in use
+func (s *BuildCacheWidget) sizeDetails() string {
+	var total, inUse int64
+	for _, row := range s.totalRows {
+		total += row.record.Size
+		if row.record.InUse {
+			inUse += row.record.Size
+		}
+	}
+	return fmt.Sprintf("<b><blue> | Size: </><yellow>%s</><blue> | In use: </><yellow>%s</></> ",
+		units.HumanSize(float64(total)), units.HumanSize(float64(inUse)))
+}
+
+func (s *BuildCacheWidget) updateHeader() {
+	for _, c := range s.header.Columns {
+		colTitle := ColumnTitle(c.Text)
+		c.Text = colTitle
+		for _, h := range buildCacheTableHeaders {
+			if colTitle == h.Title && h.Mode == s.sortMode {
+				c.Text = SortedColumnTitle(colTitle)
+			}
+		}
+	}
+}
+
+//sortRows sorts the records, the biggest and the most recently used first
+func (s *BuildCacheWidget) sortRows() {
+	rows := s.totalRows
+	var sortAlg func(i, j int) bool
+	switch s.sortMode {
+	case docker.SortBuildCacheBySize:
+		sortAlg = func(i, j int) bool {
+			return rows[i].record.Size > rows[j].record.Size
+		}
+	case docker.SortBuildCacheByLastUsed:
+		sortAlg = func(i, j int) bool {
+			return docker.BuildCacheLastUsed(rows[i].record).After(docker.BuildCacheLastUsed(rows[j].record))
+		}
+	default:
+		return
+	}
+	sort.SliceStable(rows, sortAlg)
+}
+
+func (s *BuildCacheWidget) visibleRows() []*BuildCacheRow {
+	return s.filteredRows[s.startIndex:s.endIndex]
+}
+
+func buildCacheTableHeader() *termui.TableHeader {
+	header := termui.NewHeader(DryTheme)
+	header.ColumnSpacing = DefaultColumnSpacing
+	header.AddColumn(buildCacheTableHeaders[0].Title)
+	header.AddFixedWidthColumn(buildCacheTableHeaders[1].Title, 10)
+	header.AddFixedWidthColumn(buildCacheTableHeaders[2].Title, 12)
+	header.AddColumn(buildCacheTableHeaders[3].Title)
+	header.AddFixedWidthColumn(buildCacheTableHeaders[4].Title, 8)
+	return header
+}
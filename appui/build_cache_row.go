@@ -0,0 +1,61 @@
+package appui
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
+	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//BuildCacheRow is a Grid row showing information about a build cache record
+type BuildCacheRow struct {
+	record   *types.BuildCache
+	ID       *drytermui.ParColumn
+	Type     *drytermui.ParColumn
+	Size     *drytermui.ParColumn
+	LastUsed *drytermui.ParColumn
+	InUse    *drytermui.ParColumn
+	Row
+}
+
+//NewBuildCacheRow creates a new BuildCacheRow widget
+func NewBuildCacheRow(record *types.BuildCache, table drytermui.Table) *BuildCacheRow {
+	inUse := ""
+	if record.InUse {
+		inUse = "in use"
+	}
+	row := &BuildCacheRow{
+		record:   record,
+		ID:       drytermui.NewThemedParColumn(DryTheme, docker.TruncateID(record.ID)),
+		Type:     drytermui.NewThemedParColumn(DryTheme, docker.BuildCacheType(record)),
+		Size:     drytermui.NewThemedParColumn(DryTheme, units.HumanSize(float64(record.Size))),
+		LastUsed: drytermui.NewThemedParColumn(DryTheme, units.HumanDuration(time.Since(docker.BuildCacheLastUsed(record)))+" ago"),
+		InUse:    drytermui.NewThemedParColumn(DryTheme, inUse),
+	}
+	row.Height = 1
+	row.Table = table
+	//Columns are rendered following the slice order
+	row.Columns = []termui.GridBufferer{
+		row.ID,
+		row.Type,
+		row.Size,
+		row.LastUsed,
+		row.InUse,
+	}
+	row.ParColumns = []*drytermui.ParColumn{
+		row.ID,
+		row.Type,
+		row.Size,
+		row.LastUsed,
+		row.InUse,
+	}
+	return row
+}
+
+//ColumnsForFilter returns the columns that are used to filter
+func (row *BuildCacheRow) ColumnsForFilter() []*drytermui.ParColumn {
+	return []*drytermui.ParColumn{row.ID, row.Type, row.InUse}
+}
@@ -0,0 +1,76 @@
+package appui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/ui"
+)
+
+type buildCacheDaemonMock []*types.BuildCache
+
+func (d buildCacheDaemonMock) BuildCache() ([]*types.BuildCache, error) {
+	return d, nil
+}
+
+func TestBuildCacheWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Dimensions: &ui.Dimensions{Height: 15, Width: 100},
+		Cursor:     ui.NewCursor()}
+	now := time.Now()
+	lastUsed := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	records := buildCacheDaemonMock{
+		{ID: "small", Size: 10, LastUsedAt: lastUsed(time.Minute)},
+		{ID: "big", Size: 1000, InUse: true, LastUsedAt: lastUsed(time.Hour)},
+		{ID: "never-used", Size: 100, CreatedAt: now.Add(-time.Second), Mutable: true},
+	}
+	w := NewBuildCacheWidget(records, 0)
+	w.Mount()
+	w.loader.Wait()
+
+	order := func() []string {
+		w.prepareForRendering()
+		var ids []string
+		for _, row := range w.visibleRows() {
+			ids = append(ids, row.record.ID)
+		}
+		return ids
+	}
+	tests := []struct {
+		name     string
+		sortedBy string
+		want     []string
+	}{
+		{"by size", "SIZE", []string{"big", "never-used", "small"}},
+		{"by last use", "LAST USED", []string{"never-used", "small", "big"}},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if i > 0 {
+				w.Sort()
+			}
+			if w.SortedBy() != tt.sortedBy {
+				t.Errorf("SortedBy() = %s, want %s", w.SortedBy(), tt.sortedBy)
+			}
+			got := order()
+			for j := range tt.want {
+				if j >= len(got) || got[j] != tt.want[j] {
+					t.Fatalf("records = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if count, size := w.InUse(); count != 1 || size != 1000 {
+		t.Errorf("InUse() = %d, %d, want 1, 1000", count, size)
+	}
+	for _, row := range w.totalRows {
+		if row.record.InUse != (row.InUse.Text == "in use") {
+			t.Errorf("record %s in use is shown as %q", row.record.ID, row.InUse.Text)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package appui
+
+import (
+	"bytes"
+	"sync"
+
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//ChoicePrompt asks the user to pick one of a few choices
+type ChoicePrompt struct {
+	title    string
+	choices  []string
+	selected int
+	canceled bool
+	sync.RWMutex
+}
+
+//NewChoicePrompt creates a ChoicePrompt with the given choices, the given
+//choice is selected initially if it is one of them
+func NewChoicePrompt(title string, choices []string, selected string) *ChoicePrompt {
+	p := &ChoicePrompt{
+		title:   title,
+		choices: choices,
+	}
+	for i, choice := range choices {
+		if choice == selected {
+			p.selected = i
+		}
+	}
+	return p
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (p *ChoicePrompt) Buffer() gtermui.Buffer {
+	p.RLock()
+	defer p.RUnlock()
+	var buf bytes.Buffer
+	width := len(p.title)
+	for i, choice := range p.choices {
+		if i == p.selected {
+			buf.WriteString("<b>" + ui.PointerGlyph.String() + " " + choice + "</>\n")
+		} else {
+			buf.WriteString("  " + choice + "\n")
+		}
+		if len(choice)+2 > width {
+			width = len(choice) + 2
+		}
+	}
+	par := termui.NewParFromMarkupText(DryTheme, buf.String())
+	par.Width = width + 4
+	par.Height = len(p.choices) + 2
+	par.X = (ui.ActiveScreen.Dimensions.Width - par.Width) / 2
+	par.Y = (ui.ActiveScreen.Dimensions.Height - par.Height) / 2
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = p.title
+	par.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
+	return par.Buffer()
+}
+
+//Choice returns the selected choice, the returned bool is true if the user
+//canceled the prompt
+func (p *ChoicePrompt) Choice() (string, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	if p.canceled || len(p.choices) == 0 {
+		return "", true
+	}
+	return p.choices[p.selected], false
+}
+
+//Mount callback
+func (p *ChoicePrompt) Mount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (p *ChoicePrompt) Name() string {
+	return "ChoicePrompt"
+}
+
+//OnFocus starts handling the given events until the user picks a choice,
+//with Enter, or cancels the prompt, with Esc. It is a blocking call.
+func (p *ChoicePrompt) OnFocus(event ui.EventSource) error {
+	if len(p.choices) == 0 {
+		p.canceled = true
+		return nil
+	}
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		done := false
+		p.Lock()
+		switch ev.Key {
+		case termbox.KeyEnter:
+			done = true
+		case termbox.KeyEsc:
+			p.canceled = true
+			done = true
+		case termbox.KeyArrowUp:
+			p.selected = (p.selected - 1 + len(p.choices)) % len(p.choices)
+		case termbox.KeyArrowDown, termbox.KeyTab:
+			p.selected = (p.selected + 1) % len(p.choices)
+		}
+		p.Unlock()
+		if event.EventHandledCallback != nil {
+			if err := event.EventHandledCallback(ev); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+//Unmount callback
+func (p *ChoicePrompt) Unmount() error {
+	return nil
+}
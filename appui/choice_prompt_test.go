@@ -0,0 +1,41 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestChoicePrompt(t *testing.T) {
+	choices := []string{"no", "always", "unless-stopped", "on-failure"}
+	tests := []struct {
+		name         string
+		selected     string
+		keys         []termbox.Key
+		want         string
+		wantCanceled bool
+	}{
+		{"initial choice", "always", []termbox.Key{termbox.KeyEnter}, "always", false},
+		{"unknown initial choice", "nope", []termbox.Key{termbox.KeyEnter}, "no", false},
+		{"down", "always", []termbox.Key{termbox.KeyArrowDown, termbox.KeyEnter}, "unless-stopped", false},
+		{"up wraps around", "no", []termbox.Key{termbox.KeyArrowUp, termbox.KeyEnter}, "on-failure", false},
+		{"tab wraps around", "on-failure", []termbox.Key{termbox.KeyTab, termbox.KeyEnter}, "no", false},
+		{"canceled", "always", []termbox.Key{termbox.KeyArrowDown, termbox.KeyEsc}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewChoicePrompt("Restart policy", choices, tt.selected)
+			events := make(chan termbox.Event, len(tt.keys))
+			for _, k := range tt.keys {
+				events <- keyEvent(k)
+			}
+			close(events)
+			p.OnFocus(ui.EventSource{Events: events})
+			got, canceled := p.Choice()
+			if got != tt.want || canceled != tt.wantCanceled {
+				t.Errorf("ChoicePrompt.Choice() = %s, %v, want %s, %v", got, canceled, tt.want, tt.wantCanceled)
+			}
+		})
+	}
+}
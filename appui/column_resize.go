@@ -0,0 +1,103 @@
+package appui
+
+//minColumnWidth is the narrowest a column can be resized to
+const minColumnWidth = 1
+
+//columnResize keeps the widths the user gave to the columns of a table,
+//for as long as dry runs, and which column is being resized
+type columnResize struct {
+	widths   map[string]int //by column title
+	resizing bool
+	focused  int //position of the column being resized among the visible ones
+}
+
+//apply returns the given widths of the columns with the given headers,
+//0 for the ones that share the width left, with the widths of the
+//resized columns instead
+func (r *columnResize) apply(headers []SortableColumnHeader, widths []int) []int {
+	applied := make([]int, len(widths))
+	for i, width := range widths {
+		if resized, ok := r.widths[headers[i].Title]; ok {
+			width = resized
+		}
+		applied[i] = width
+	}
+	return applied
+}
+
+//toggle starts resizing the first column with a title among the given
+//titles of the visible columns, or stops resizing. Returns true if the
+//columns are being resized.
+func (r *columnResize) toggle(titles []string) bool {
+	r.resizing = !r.resizing
+	r.focused = 0
+	if r.resizing {
+		r.focus(titles, 0)
+	}
+	return r.resizing
+}
+
+//focus moves the column being resized to the next one among the given
+//titles of the visible columns if delta is positive, to the previous one
+//if negative. Columns without title are skipped.
+func (r *columnResize) focus(titles []string, delta int) {
+	if !r.resizing || len(titles) == 0 {
+		return
+	}
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	i := r.focused
+	if i >= len(titles) {
+		i = 0
+	}
+	if delta != 0 {
+		i = (i + step + len(titles)) % len(titles)
+	}
+	for n := 0; n < len(titles) && titles[i] == ""; n++ {
+		i = (i + step + len(titles)) % len(titles)
+	}
+	r.focused = i
+}
+
+//resize changes the width of the column being resized by the given delta,
+//given the titles and widths of the visible columns, up to the given
+//maximum width. Returns the title of the column resized, empty if no
+//column is.
+func (r *columnResize) resize(titles []string, widths []int, delta, max int) string {
+	if !r.resizing || r.focused >= len(titles) || r.focused >= len(widths) {
+		return ""
+	}
+	title := titles[r.focused]
+	width := widths[r.focused] + delta
+	if max > 0 && width > max {
+		width = max
+	}
+	if width < minColumnWidth {
+		width = minColumnWidth
+	}
+	if r.widths == nil {
+		r.widths = make(map[string]int)
+	}
+	r.widths[title] = width
+	return title
+}
+
+//column returns the position of the column being resized among the
+//visible ones, -1 if the columns are not being resized
+func (r *columnResize) column() int {
+	if !r.resizing {
+		return -1
+	}
+	return r.focused
+}
+
+//title returns the title of the column being resized among the given
+//titles of the visible columns, empty if the columns are not being resized
+func (r *columnResize) title(titles []string) string {
+	if i := r.column(); i >= 0 && i < len(titles) {
+		return titles[i]
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package appui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnResize(t *testing.T) {
+	var r columnResize
+	titles := []string{"", "CONTAINER", "IMAGE", "STATUS"}
+	widths := []int{2, 12, 30, 18}
+
+	if r.resize(titles, widths, 1, 100) != "" {
+		t.Error("A column was resized while not resizing")
+	}
+	if !r.toggle(titles) {
+		t.Fatal("Resizing did not start")
+	}
+	//columns without title are skipped
+	if got := r.title(titles); got != "CONTAINER" {
+		t.Errorf("Resizing starts on %q, want CONTAINER", got)
+	}
+	r.focus(titles, -1)
+	if got := r.title(titles); got != "STATUS" {
+		t.Errorf("Resizing moved back to %q, want STATUS", got)
+	}
+	r.focus(titles, 1)
+	if got := r.column(); got != 1 {
+		t.Errorf("Resizing moved forward to column %d, want 1", got)
+	}
+
+	if title := r.resize(titles, widths, 4, 100); title != "CONTAINER" || r.widths[title] != 16 {
+		t.Errorf("Resized %q to %d, want CONTAINER to 16", title, r.widths[title])
+	}
+	r.focus(titles, 1)
+	r.resize(titles, widths, -50, 100)
+	if r.widths["IMAGE"] != minColumnWidth {
+		t.Errorf("IMAGE was shrunk to %d, want %d", r.widths["IMAGE"], minColumnWidth)
+	}
+	r.focus(titles, 1)
+	r.resize(titles, widths, 500, 100)
+	if r.widths["STATUS"] != 100 {
+		t.Errorf("STATUS was grown to %d, want 100", r.widths["STATUS"])
+	}
+
+	headers := []SortableColumnHeader{{Title: ""}, {Title: "CONTAINER"}, {Title: "IMAGE"}, {Title: "COMMAND"}}
+	if got, want := r.apply(headers, []int{2, 12, 0, 0}), []int{2, 16, minColumnWidth, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Resized widths are %v, want %v", got, want)
+	}
+
+	if r.toggle(titles) || r.column() != -1 || r.title(titles) != "" {
+		t.Error("Resizing did not stop")
+	}
+	//widths are kept once resizing stops
+	if r.widths["CONTAINER"] != 16 {
+		t.Errorf("Resized widths were not kept: %v", r.widths)
+	}
+}
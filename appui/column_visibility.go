@@ -0,0 +1,109 @@
+package appui
+
+import (
+	termui "github.com/gizak/termui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//hiddenColumns are the titles of the columns hidden on a table
+type hiddenColumns map[string]bool
+
+//newHiddenColumns returns the given titles as hidden columns of a table with
+//the given headers, the titles that are not columns of it are left out
+func newHiddenColumns(headers []SortableColumnHeader, titles []string) hiddenColumns {
+	hidden := make(hiddenColumns)
+	for _, title := range titles {
+		for _, h := range headers {
+			if h.Title != "" && h.Title == title {
+				hidden[title] = true
+			}
+		}
+	}
+	return hidden
+}
+
+//titles returns the titles of the hidden columns, in the order of the given
+//headers
+func (h hiddenColumns) titles(headers []SortableColumnHeader) []string {
+	var titles []string
+	for _, header := range headers {
+		if h[header.Title] {
+			titles = append(titles, header.Title)
+		}
+	}
+	return titles
+}
+
+//visible returns the given columns, one per header in the same order, but
+//the hidden ones
+func (h hiddenColumns) visible(headers []SortableColumnHeader, columns []termui.GridBufferer) []termui.GridBufferer {
+	var visible []termui.GridBufferer
+	for i, c := range columns {
+		if i < len(headers) && h[headers[i].Title] {
+			continue
+		}
+		visible = append(visible, c)
+	}
+	return visible
+}
+
+//visibleTitles returns the titles of the given headers whose columns are
+//not hidden
+func (h hiddenColumns) visibleTitles(headers []SortableColumnHeader) []string {
+	var titles []string
+	for _, header := range headers {
+		if !h[header.Title] {
+			titles = append(titles, header.Title)
+		}
+	}
+	return titles
+}
+
+//index returns the position among the visible columns of the column with
+//the given title, -1 if it is hidden or not found
+func (h hiddenColumns) index(headers []SortableColumnHeader, title string) int {
+	i := 0
+	for _, header := range headers {
+		if header.Title == title {
+			if h[title] {
+				return -1
+			}
+			return i
+		}
+		if !h[header.Title] {
+			i++
+		}
+	}
+	return -1
+}
+
+//tableHeader creates the header of a table with the given column headers
+//and widths, 0 for the columns that share the width left, but the hidden ones
+func (h hiddenColumns) tableHeader(headers []SortableColumnHeader, widths []int) *drytermui.TableHeader {
+	header := drytermui.NewHeader(DryTheme)
+	header.ColumnSpacing = DefaultColumnSpacing
+	for i, width := range widths {
+		title := headers[i].Title
+		if h[title] {
+			continue
+		}
+		if width > 0 {
+			header.AddFixedWidthColumn(title, width)
+		} else {
+			header.AddColumn(title)
+		}
+	}
+	return header
+}
+
+//columnTitles returns the titles of the given headers that can be hidden,
+//the first count headers are columns
+func columnTitles(headers []SortableColumnHeader, count int) []string {
+	var titles []string
+	for _, h := range headers[:count] {
+		if h.Title != "" {
+			titles = append(titles, h.Title)
+		}
+	}
+	return titles
+}
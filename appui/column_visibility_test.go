@@ -0,0 +1,45 @@
+package appui
+
+import (
+	"reflect"
+	"testing"
+
+	termui "github.com/gizak/termui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+func TestHiddenColumns(t *testing.T) {
+	hidden := newHiddenColumns(imageTableHeaders, []string{"Size", "TAG", "NOPE"})
+
+	if got, want := hidden.titles(imageTableHeaders), []string{"TAG", "Size"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Hidden columns are %v, want %v", got, want)
+	}
+	if got := hidden.index(imageTableHeaders, "ID"); got != 1 {
+		t.Errorf("ID is the visible column %d, want 1", got)
+	}
+	if got := hidden.index(imageTableHeaders, "TAG"); got != -1 {
+		t.Errorf("TAG is the visible column %d, want -1", got)
+	}
+
+	columns := make([]termui.GridBufferer, len(imageTableHeaders))
+	for i, h := range imageTableHeaders {
+		columns[i] = drytermui.NewThemedParColumn(DryTheme, h.Title)
+	}
+	visible := hidden.visible(imageTableHeaders, columns)
+	if len(visible) != 3 || visible[1] != columns[2] {
+		t.Errorf("Visible columns are not the ones not hidden: %v", visible)
+	}
+
+	header := hidden.tableHeader(imageTableHeaders, imageColumnWidths)
+	header.SetWidth(100)
+	var titles []string
+	for _, c := range header.Columns {
+		titles = append(titles, c.Text)
+	}
+	if want := []string{"REPOSITORY", "ID", "Created"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("Header columns are %v, want %v", titles, want)
+	}
+	if widths := header.ColumnWidths(); widths[1] != 12 || widths[0] != 100-12-12-DefaultColumnSpacing {
+		t.Errorf("Unexpected column widths: %v", widths)
+	}
+}
@@ -0,0 +1,256 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//commandPaletteWidth is the width of the palette, if the screen is wide enough
+const commandPaletteWidth = 90
+
+//PaletteCommand is an action that can be run from the command palette
+type PaletteCommand struct {
+	Name        string
+	Keys        string
+	Description string
+	//Disabled commands are shown, greyed out, but cannot be run
+	Disabled bool
+}
+
+//CommandPalette lists the given commands, filtered as the user types, and
+//lets the user pick one of them
+type CommandPalette struct {
+	commands []PaletteCommand
+	matches  []PaletteCommand
+	pattern  string
+	selected int
+	offset   int
+	canceled bool
+	sync.RWMutex
+}
+
+//NewCommandPalette creates a CommandPalette with the given commands
+func NewCommandPalette(commands []PaletteCommand) *CommandPalette {
+	p := &CommandPalette{commands: commands}
+	p.filter()
+	return p
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (p *CommandPalette) Buffer() gtermui.Buffer {
+	p.Lock()
+	defer p.Unlock()
+	width := commandPaletteWidth
+	if screenWidth := ui.ActiveScreen.Dimensions.Width - 2; width > screenWidth {
+		width = screenWidth
+	}
+	//borders and the line with the pattern
+	visible := ui.ActiveScreen.Dimensions.Height/2 - 3
+	if visible < 1 {
+		visible = 1
+	}
+	if p.selected < p.offset {
+		p.offset = p.selected
+	} else if p.selected >= p.offset+visible {
+		p.offset = p.selected - visible + 1
+	}
+
+	keysWidth := 0
+	for _, c := range p.matches {
+		if l := len([]rune(c.Keys)); l > keysWidth {
+			keysWidth = l
+		}
+	}
+	textWidth := width - 2 - 2 - keysWidth - DefaultColumnSpacing
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<b>> </>%s_\n", p.pattern)
+	if len(p.matches) == 0 {
+		buf.WriteString("  <grey>No matching commands</>\n")
+	}
+	for i := p.offset; i < len(p.matches) && i < p.offset+visible; i++ {
+		c := p.matches[i]
+		line := fmt.Sprintf("%-*s%s%s", keysWidth, c.Keys, strings.Repeat(" ", DefaultColumnSpacing),
+			truncateCell(c.Description, textWidth))
+		switch {
+		case c.Disabled:
+			line = "<grey>" + line + "</>"
+		case i != p.selected:
+			line = "<white>" + line + "</>"
+		}
+		if i == p.selected {
+			buf.WriteString("<b>" + ui.PointerGlyph.String() + " " + line + "</>\n")
+		} else {
+			buf.WriteString("  " + line + "\n")
+		}
+	}
+	lines := len(p.matches)
+	if lines > visible {
+		lines = visible
+	}
+	if lines == 0 {
+		lines = 1
+	}
+	par := termui.NewParFromMarkupText(DryTheme, buf.String())
+	par.Width = width
+	par.Height = lines + 3
+	par.X = (ui.ActiveScreen.Dimensions.Width - par.Width) / 2
+	par.Y = (ui.ActiveScreen.Dimensions.Height - par.Height) / 3
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = fmt.Sprintf(" Commands: %d ", len(p.matches))
+	par.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
+	return par.Buffer()
+}
+
+//Command returns the command picked, the returned bool is true if the user
+//canceled the palette
+func (p *CommandPalette) Command() (PaletteCommand, bool) {
+	p.RLock()
+	defer p.RUnlock()
+	if p.canceled || len(p.matches) == 0 || p.matches[p.selected].Disabled {
+		return PaletteCommand{}, true
+	}
+	return p.matches[p.selected], false
+}
+
+//Mount callback
+func (p *CommandPalette) Mount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (p *CommandPalette) Name() string {
+	return "CommandPalette"
+}
+
+//OnFocus starts handling the given events until the user picks a command,
+//with Enter, or cancels the palette, with Esc. Disabled commands cannot be
+//picked. It is a blocking call.
+func (p *CommandPalette) OnFocus(event ui.EventSource) error {
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		done := false
+		p.Lock()
+		switch ev.Key {
+		case termbox.KeyEnter:
+			done = len(p.matches) > 0 && !p.matches[p.selected].Disabled
+		case termbox.KeyEsc:
+			p.canceled = true
+			done = true
+		case termbox.KeyArrowUp:
+			if len(p.matches) > 0 {
+				p.selected = (p.selected - 1 + len(p.matches)) % len(p.matches)
+			}
+		case termbox.KeyArrowDown, termbox.KeyTab:
+			if len(p.matches) > 0 {
+				p.selected = (p.selected + 1) % len(p.matches)
+			}
+		case termbox.KeyBackspace, termbox.KeyBackspace2:
+			if runes := []rune(p.pattern); len(runes) > 0 {
+				p.pattern = string(runes[:len(runes)-1])
+				p.filter()
+			}
+		case termbox.KeySpace:
+			p.pattern += " "
+			p.filter()
+		default:
+			if ev.Ch != 0 {
+				p.pattern += string(ev.Ch)
+				p.filter()
+			}
+		}
+		p.Unlock()
+		if event.EventHandledCallback != nil {
+			if err := event.EventHandledCallback(ev); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+//Unmount callback
+func (p *CommandPalette) Unmount() error {
+	return nil
+}
+
+//filter keeps the commands that match the pattern, best matches first and
+//disabled commands last
+func (p *CommandPalette) filter() {
+	type match struct {
+		command PaletteCommand
+		score   int
+	}
+	var matches []match
+	for _, c := range p.commands {
+		score, ok := fuzzyMatch(p.pattern, c.Description)
+		if nameScore, nameOk := fuzzyMatch(p.pattern, c.Name); nameOk && (!ok || nameScore > score) {
+			score, ok = nameScore, true
+		}
+		if ok {
+			matches = append(matches, match{c, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].command.Disabled != matches[j].command.Disabled {
+			return !matches[i].command.Disabled
+		}
+		return matches[i].score > matches[j].score
+	})
+	p.matches = make([]PaletteCommand, len(matches))
+	for i, m := range matches {
+		p.matches[i] = m.command
+	}
+	p.selected = 0
+	p.offset = 0
+}
+
+//fuzzyMatch returns true if the characters of the given pattern appear, in
+//order, on the given text, case is ignored. The returned score is higher
+//the closer the characters are together and the sooner they appear, and
+//characters starting a word score more.
+func fuzzyMatch(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(text)
+	score := 0
+	last := -1
+	for i := 0; i < len(p); i++ {
+		if p[i] == ' ' {
+			continue
+		}
+		found := -1
+		for j := last + 1; j < len(t); j++ {
+			if unicode.ToLower(t[j]) == p[i] {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			return 0, false
+		}
+		switch {
+		case found == last+1 && last >= 0:
+			score += 3
+		case found == 0 || !unicode.IsLetter(t[found-1]) || unicode.IsUpper(t[found]) && unicode.IsLower(t[found-1]):
+			score += 2
+		}
+		score -= found - last - 1
+		last = found
+	}
+	return score, true
+}
@@ -0,0 +1,74 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestCommandPalette(t *testing.T) {
+	commands := []PaletteCommand{
+		{Name: "toggleShowAll", Keys: "F2", Description: "Toggles showing all containers"},
+		{Name: "removeContainer", Keys: "e/E", Description: "Removes the selected container"},
+		{Name: "stopContainer", Keys: "Ctrl+t", Description: "Stops selected container", Disabled: true},
+		{Name: "showImages", Keys: "2", Description: "To image list"},
+	}
+	tests := []struct {
+		name         string
+		events       []termbox.Event
+		want         string
+		wantCanceled bool
+	}{
+		{"first command", []termbox.Event{keyEvent(termbox.KeyEnter)}, "toggleShowAll", false},
+		{"down", []termbox.Event{keyEvent(termbox.KeyArrowDown), keyEvent(termbox.KeyEnter)}, "removeContainer", false},
+		{"fuzzy filter", append(typedEvents("rmcont"), keyEvent(termbox.KeyEnter)), "removeContainer", false},
+		{"filter on the action name", append(typedEvents("showimages"), keyEvent(termbox.KeyEnter)), "showImages", false},
+		{"backspace", append(typedEvents("imagx"), keyEvent(termbox.KeyBackspace2), keyEvent(termbox.KeyEnter)), "showImages", false},
+		{"disabled commands go last", append(typedEvents("cont"), keyEvent(termbox.KeyArrowUp), keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEsc)), "", true},
+		{"no match", append(typedEvents("zzz"), keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEsc)), "", true},
+		{"canceled", []termbox.Event{keyEvent(termbox.KeyArrowDown), keyEvent(termbox.KeyEsc)}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewCommandPalette(commands)
+			events := make(chan termbox.Event, len(tt.events))
+			for _, e := range tt.events {
+				events <- e
+			}
+			close(events)
+			p.OnFocus(ui.EventSource{Events: events})
+			got, canceled := p.Command()
+			if got.Name != tt.want || canceled != tt.wantCanceled {
+				t.Errorf("CommandPalette.Command() = %s, %v, want %s, %v", got.Name, canceled, tt.want, tt.wantCanceled)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    bool
+	}{
+		{"", "Removes the selected container", true},
+		{"rm", "Removes the selected container", true},
+		{"RSC", "Removes the selected container", true},
+		{"cr", "Removes the selected container", true},
+		{"xyz", "Removes the selected container", false},
+		{"nr", "Removes", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if _, got := fuzzyMatch(tt.pattern, tt.text); got != tt.want {
+				t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.text, got, tt.want)
+			}
+		})
+	}
+	consecutive, _ := fuzzyMatch("rem", "Removes the selected container")
+	scattered, _ := fuzzyMatch("rem", "Restarts the main container")
+	if consecutive <= scattered {
+		t.Errorf("Consecutive matches score %d, scattered ones %d", consecutive, scattered)
+	}
+}
@@ -0,0 +1,203 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//MaxConfirmationTargets is the max number of targets listed on a confirmation
+const MaxConfirmationTargets = 5
+
+const confirmationOptions = "<b>[y/Enter]:<darkgrey>Yes</> <b>[a]:<darkgrey>Yes, don't ask again this session</> <b>[n/Esc]:<darkgrey>No</>"
+
+//ConfirmationAnswer is the answer given to a confirmation
+type ConfirmationAnswer uint8
+
+//Possible answers to a confirmation
+const (
+	Rejected ConfirmationAnswer = iota
+	Confirmed
+	ConfirmedDontAskAgain
+)
+
+//Confirmation is a widget that asks the user to confirm an action, listing
+//the elements affected by it
+type Confirmation struct {
+	question  string
+	targets   []string
+	answer    ConfirmationAnswer
+	toggleKey rune
+	toggle    string
+	toggled   bool
+	sync.RWMutex
+}
+
+//NewConfirmation creates a Confirmation with the given question about the given targets
+func NewConfirmation(question string, targets []string) *Confirmation {
+	return &Confirmation{
+		question: question,
+		targets:  targets,
+	}
+}
+
+//Answer returns the answer given by the user
+func (c *Confirmation) Answer() ConfirmationAnswer {
+	c.RLock()
+	defer c.RUnlock()
+	return c.answer
+}
+
+//SetToggle adds an option to the confirmation, described by the given label,
+//that the user turns on and off with the given key. It is off until then.
+func (c *Confirmation) SetToggle(key rune, label string) {
+	c.Lock()
+	defer c.Unlock()
+	c.toggleKey = key
+	c.toggle = label
+}
+
+//Toggled returns true if the user turned on the option of the confirmation
+func (c *Confirmation) Toggled() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.toggled
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (c *Confirmation) Buffer() gtermui.Buffer {
+	c.RLock()
+	lines := confirmationLines(c.question, c.targets)
+	if c.toggle != "" {
+		lines = append(lines[:len(lines)-1], toggleLine(c.toggleKey, c.toggle, c.toggled), "", confirmationOptions)
+	}
+	c.RUnlock()
+	width := 0
+	for _, line := range lines {
+		if l := len([]rune(ui.SupportedTags.ReplaceAllString(line, ""))); l > width {
+			width = l
+		}
+	}
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	screenWidth := ui.ActiveScreen.Dimensions.Width
+	par := termui.NewParFromMarkupText(DryTheme, buf.String())
+	par.Width = width + 4
+	if par.Width > screenWidth {
+		par.Width = screenWidth
+	}
+	par.Height = len(lines) + 2
+	par.X = (screenWidth - par.Width) / 2
+	par.Y = (ui.ActiveScreen.Dimensions.Height - par.Height) / 2
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = " Confirm "
+	par.BorderFg = NotRunning
+	par.BorderLabelFg = NotRunning
+	return par.Buffer()
+}
+
+//Mount callback
+func (c *Confirmation) Mount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (c *Confirmation) Name() string {
+	return "Confirmation"
+}
+
+//OnFocus starts handling the given events until the user answers, it
+//is a blocking call.
+func (c *Confirmation) OnFocus(event ui.EventSource) error {
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		c.Lock()
+		toggling := c.toggle != "" && ev.Ch == c.toggleKey
+		if toggling {
+			c.toggled = !c.toggled
+		}
+		c.Unlock()
+		if toggling {
+			if event.EventHandledCallback != nil {
+				event.EventHandledCallback(ev)
+			}
+			continue
+		}
+		answer, ok := confirmationAnswer(ev)
+		if !ok {
+			continue
+		}
+		c.Lock()
+		c.answer = answer
+		c.Unlock()
+		if event.EventHandledCallback != nil {
+			return event.EventHandledCallback(ev)
+		}
+		return nil
+	}
+	return nil
+}
+
+//Unmount callback
+func (c *Confirmation) Unmount() error {
+	return nil
+}
+
+//confirmationAnswer returns the answer the given event stands for, false if
+//the event is not an answer
+func confirmationAnswer(ev termbox.Event) (ConfirmationAnswer, bool) {
+	switch ev.Key {
+	case termbox.KeyEnter:
+		return Confirmed, true
+	case termbox.KeyEsc:
+		return Rejected, true
+	}
+	switch ev.Ch {
+	case 'y', 'Y':
+		return Confirmed, true
+	case 'a', 'A':
+		return ConfirmedDontAskAgain, true
+	case 'n', 'N':
+		return Rejected, true
+	}
+	return Rejected, false
+}
+
+//confirmationLines returns the text of a confirmation, targets after
+//MaxConfirmationTargets are summarized
+func confirmationLines(question string, targets []string) []string {
+	lines := []string{"<white>" + question + "</>", ""}
+	for i, target := range targets {
+		if i == MaxConfirmationTargets {
+			lines = append(lines, fmt.Sprintf("  %sand %d more", ui.DotsGlyph, len(targets)-MaxConfirmationTargets))
+			break
+		}
+		lines = append(lines, "  "+target)
+	}
+	if len(targets) > 0 {
+		lines = append(lines, "")
+	}
+	return append(lines, confirmationOptions)
+}
+
+//toggleLine returns the line of a confirmation with its option
+func toggleLine(key rune, label string, on bool) string {
+	check := "[ ]"
+	if on {
+		check = "[x]"
+	}
+	return fmt.Sprintf("<b>[%c]:<darkgrey>%s %s</>", key, check, label)
+}
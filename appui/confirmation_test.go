@@ -0,0 +1,106 @@
+package appui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestConfirmationLines(t *testing.T) {
+	var targets []string
+	for i := 0; i < 17; i++ {
+		targets = append(targets, fmt.Sprintf("container%d", i))
+	}
+	tests := []struct {
+		name    string
+		targets []string
+		want    int
+		last    string
+	}{
+		{"no targets", nil, 3, confirmationOptions},
+		{"one target", targets[:1], 5, "  container0"},
+		{"max targets", targets[:MaxConfirmationTargets], 9, "  container4"},
+		{"too many targets", targets, 10, "  …and 12 more"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := confirmationLines("Remove?", tt.targets)
+			if len(lines) != tt.want {
+				t.Fatalf("Unexpected number of lines, got %d, want %d: %v", len(lines), tt.want, lines)
+			}
+			last := lines[len(lines)-1]
+			if len(tt.targets) > 0 {
+				last = lines[len(lines)-3]
+			}
+			if last != tt.last {
+				t.Errorf("Unexpected line, got %q, want %q", last, tt.last)
+			}
+		})
+	}
+}
+
+func TestConfirmationAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []termbox.Event
+		want   ConfirmationAnswer
+	}{
+		{"y confirms", []termbox.Event{{Type: termbox.EventKey, Ch: 'y'}}, Confirmed},
+		{"Enter confirms", []termbox.Event{{Type: termbox.EventKey, Key: termbox.KeyEnter}}, Confirmed},
+		{"a confirms and does not ask again", []termbox.Event{{Type: termbox.EventKey, Ch: 'a'}}, ConfirmedDontAskAgain},
+		{"Esc rejects", []termbox.Event{{Type: termbox.EventKey, Key: termbox.KeyEsc}}, Rejected},
+		{"other keys are ignored", []termbox.Event{
+			{Type: termbox.EventKey, Ch: 'x'},
+			{Type: termbox.EventKey, Key: termbox.KeySpace},
+			{Type: termbox.EventKey, Ch: 'Y'}}, Confirmed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := make(chan termbox.Event, len(tt.events))
+			for _, e := range tt.events {
+				events <- e
+			}
+			close(events)
+			c := NewConfirmation("Remove?", nil)
+			c.OnFocus(ui.EventSource{Events: events})
+			if c.Answer() != tt.want {
+				t.Errorf("Unexpected answer, got %d, want %d", c.Answer(), tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmationToggle(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []termbox.Event
+		want   bool
+	}{
+		{"off by default", []termbox.Event{{Type: termbox.EventKey, Ch: 'y'}}, false},
+		{"turned on", []termbox.Event{{Type: termbox.EventKey, Ch: 'v'}, {Type: termbox.EventKey, Ch: 'y'}}, true},
+		{"turned on and off", []termbox.Event{
+			{Type: termbox.EventKey, Ch: 'v'},
+			{Type: termbox.EventKey, Ch: 'v'},
+			{Type: termbox.EventKey, Key: termbox.KeyEnter}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := make(chan termbox.Event, len(tt.events))
+			for _, e := range tt.events {
+				events <- e
+			}
+			close(events)
+			c := NewConfirmation("Remove?", nil)
+			c.SetToggle('v', "Remove volumes")
+			c.OnFocus(ui.EventSource{Events: events})
+			if c.Answer() != Confirmed {
+				t.Errorf("Unexpected answer, got %d, want %d", c.Answer(), Confirmed)
+			}
+			if c.Toggled() != tt.want {
+				t.Errorf("Unexpected option, got %v, want %v", c.Toggled(), tt.want)
+			}
+		})
+	}
+}
@@ -24,7 +24,8 @@ func NewContainerInfo(container *docker.Container) (string, int) {
 		{ui.Blue("Container Name:"), ui.Yellow(container.Names[0]), ui.Blue("ID:"), ui.Yellow(docker.TruncateID(container.ID)), ui.Blue("Status:"), status},
 		{ui.Blue("Image:"), ui.Yellow(container.Image), ui.Blue("Created:"), ui.Yellow(docker.DurationForHumans(container.Created) + " ago")},
 		{ui.Blue("Command:"), ui.Yellow(container.Command)},
-		{ui.Blue("Port mapping:"), ui.Yellow(formatter.DisplayablePorts(container.Ports))},
+		{ui.Blue("Port mapping:"), ui.Yellow(formatter.DisplayablePorts(container.Ports)),
+			ui.Blue("Restart policy:"), ui.Yellow(docker.FormatRestartPolicy(docker.RestartPolicy(container)))},
 	}
 	var networkNames []string
 	var networkIps []string
@@ -0,0 +1,59 @@
+package appui
+
+import (
+	"github.com/moncho/dry/docker"
+)
+
+//Fields of the container commit form
+const (
+	commitFieldReference = "reference"
+	commitFieldMessage   = "message"
+	commitFieldAuthor    = "author"
+)
+
+//ContainerCommitForm is a widget that asks for the repository:tag of the
+//image to create from a container and, optionally, a message and an author
+type ContainerCommitForm struct {
+	*wizard
+}
+
+//NewContainerCommitForm creates a ContainerCommitForm for the container with
+//the given name
+func NewContainerCommitForm(name string) *ContainerCommitForm {
+	steps := []*wizardStep{
+		{
+			field:    commitFieldReference,
+			label:    "Repository:tag",
+			help:     "Name of the new image, i.e. myapp:debug, the tag defaults to latest",
+			validate: docker.ValidateImageReference,
+		},
+		{
+			field: commitFieldMessage,
+			label: "Message",
+			help:  "Commit message, optional",
+		},
+		{
+			field: commitFieldAuthor,
+			label: "Author",
+			help:  "Author of the image, i.e. Jane Doe <jane@example.com>, optional",
+		},
+	}
+	return &ContainerCommitForm{newWizard("docker commit "+name, steps, nil)}
+}
+
+//Name returns the widget name
+func (w *ContainerCommitForm) Name() string {
+	return "ContainerCommitForm"
+}
+
+//Options returns the commit options entered on the form
+func (w *ContainerCommitForm) Options() docker.CommitOptions {
+	w.RLock()
+	defer w.RUnlock()
+	values := w.values()
+	return docker.CommitOptions{
+		Reference: values[commitFieldReference],
+		Message:   values[commitFieldMessage],
+		Author:    values[commitFieldAuthor],
+	}
+}
@@ -0,0 +1,39 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestContainerCommitForm(t *testing.T) {
+	w := NewContainerCommitForm("web")
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+	space := []termbox.Event{keyEvent(termbox.KeySpace)}
+
+	focusWizard(w,
+		//a reference is required
+		enter,
+		typedEvents("web:debug"), enter,
+		typedEvents("with"), space, typedEvents("strace"), enter,
+		//no author
+		enter)
+
+	if w.Canceled() {
+		t.Fatal("Form was canceled")
+	}
+	want := docker.CommitOptions{Reference: "web:debug", Message: "with strace"}
+	if got := w.Options(); got != want {
+		t.Errorf("Unexpected options, got %+v, want %+v", got, want)
+	}
+}
+
+func TestContainerCommitForm_Cancel(t *testing.T) {
+	w := NewContainerCommitForm("web")
+	focusWizard(w, typedEvents("web"), []termbox.Event{keyEvent(termbox.KeyEsc)})
+
+	if !w.Canceled() {
+		t.Error("Form was not canceled")
+	}
+}
@@ -0,0 +1,59 @@
+package appui
+
+import (
+	"github.com/moncho/dry/docker"
+)
+
+//Fields of the container copy form
+const (
+	copyFieldDirection   = "direction"
+	copyFieldSource      = "source"
+	copyFieldDestination = "destination"
+)
+
+//ContainerCopyForm is a widget that asks for the files to copy between the
+//host and a container, and where to copy them, as docker cp does
+type ContainerCopyForm struct {
+	*wizard
+}
+
+//NewContainerCopyForm creates a ContainerCopyForm for the container with
+//the given name
+func NewContainerCopyForm(name string) *ContainerCopyForm {
+	steps := []*wizardStep{
+		{
+			field:   copyFieldDirection,
+			label:   "Copy",
+			help:    "Copy files of the host to the container or files of the container to the host",
+			value:   []rune(docker.CopyToContainer),
+			choices: docker.CopyDirections,
+		},
+		{
+			field:    copyFieldSource,
+			label:    "Source path",
+			help:     "File or directory to copy, a host path to copy to the container, a container path to copy from it",
+			validate: docker.ValidateCopyPath,
+		},
+		{
+			field:    copyFieldDestination,
+			label:    "Destination path",
+			help:     "Where to copy, the source is copied into it if it is a directory, otherwise it is copied with this name",
+			validate: docker.ValidateCopyPath,
+		},
+	}
+	return &ContainerCopyForm{newWizard("docker cp "+name, steps, nil)}
+}
+
+//Name returns the widget name
+func (w *ContainerCopyForm) Name() string {
+	return "ContainerCopyForm"
+}
+
+//Transfer returns the direction of the copy, its source and its destination
+//as entered on the form
+func (w *ContainerCopyForm) Transfer() (direction, src, dst string) {
+	w.RLock()
+	defer w.RUnlock()
+	values := w.values()
+	return values[copyFieldDirection], values[copyFieldSource], values[copyFieldDestination]
+}
@@ -0,0 +1,29 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestContainerCopyForm(t *testing.T) {
+	w := NewContainerCopyForm("web")
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+	right := []termbox.Event{keyEvent(termbox.KeyArrowRight)}
+
+	focusWizard(w,
+		right, enter,
+		//a source is required
+		enter,
+		typedEvents("/var/log/nginx"), enter,
+		typedEvents("logs"), enter)
+
+	if w.Canceled() {
+		t.Fatal("Form was canceled")
+	}
+	direction, src, dst := w.Transfer()
+	if direction != docker.CopyFromContainer || src != "/var/log/nginx" || dst != "logs" {
+		t.Errorf("Unexpected transfer: %s %s %s", direction, src, dst)
+	}
+}
@@ -0,0 +1,48 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moncho/dry/docker"
+)
+
+//ContainerDiffRenderer renders the changes of the filesystem of a container,
+//as docker diff does, A for added paths, C for changed and D for deleted
+type ContainerDiffRenderer struct {
+	name    string
+	changes []docker.ContainerChange
+}
+
+//NewContainerDiffRenderer creates a renderer for the given changes of the
+//container with the given name
+func NewContainerDiffRenderer(name string, changes []docker.ContainerChange) *ContainerDiffRenderer {
+	return &ContainerDiffRenderer{name: name, changes: changes}
+}
+
+//Render renders the changes using dry markup
+func (r *ContainerDiffRenderer) Render() string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "<blue>Changes of</> <white>%s</> <blue>to the filesystem of its image</>\n\n", r.name)
+	if len(r.changes) == 0 {
+		buf.WriteString("Nothing changed\n")
+		return buf.String()
+	}
+	counts := make(map[docker.Change]int)
+	for _, c := range r.changes {
+		counts[c.Change]++
+	}
+	fmt.Fprintf(buf, "<green>%d added</>, <yellow>%d changed</>, <red>%d deleted</>\n\n",
+		counts[docker.Added], counts[docker.Changed], counts[docker.Removed])
+	for _, c := range r.changes {
+		switch c.Change {
+		case docker.Added:
+			fmt.Fprintf(buf, "<green>A</> %s\n", c.Path)
+		case docker.Removed:
+			fmt.Fprintf(buf, "<red>D</> %s\n", c.Path)
+		default:
+			fmt.Fprintf(buf, "<yellow>C</> %s\n", c.Path)
+		}
+	}
+	return buf.String()
+}
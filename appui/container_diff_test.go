@@ -0,0 +1,31 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestContainerDiffRenderer(t *testing.T) {
+	r := NewContainerDiffRenderer("web", []docker.ContainerChange{
+		{Change: docker.Changed, Path: "/var/log"},
+		{Change: docker.Added, Path: "/var/log/app.log"},
+		{Change: docker.Removed, Path: "/etc/motd"},
+	})
+	text := r.Render()
+	for _, want := range []string{
+		"<green>1 added</>, <yellow>1 changed</>, <red>1 deleted</>",
+		"<yellow>C</> /var/log\n",
+		"<green>A</> /var/log/app.log\n",
+		"<red>D</> /etc/motd\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Rendered changes do not contain %q:\n%s", want, text)
+		}
+	}
+
+	if text := NewContainerDiffRenderer("web", nil).Render(); !strings.Contains(text, "Nothing changed") {
+		t.Errorf("Unexpected rendering of a container without changes:\n%s", text)
+	}
+}
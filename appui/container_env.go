@@ -0,0 +1,364 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//secretKeyMarkers are the words that make a key look like it holds a secret
+var secretKeyMarkers = []string{"PASSWORD", "PASSWD", "TOKEN", "KEY", "SECRET"}
+
+//maskedValue replaces the values of secret-looking keys while they are masked
+const maskedValue = "********"
+
+//envLine is a line of the environment widget, either the title of a table
+//or (part of) one of its entries
+type envLine struct {
+	text string
+	//entry is the index of the entry the line belongs to, -1 for table titles
+	entry int
+}
+
+//ContainerEnvWidget shows the environment variables and the labels of a
+//container as two key/value tables. Values that do not fit on screen are
+//wrapped and the values of keys that look secret are masked unless told otherwise.
+type ContainerEnvWidget struct {
+	dockerDaemon  docker.ContainerAPI
+	containerID   string
+	name          string
+	env, labels   []docker.EnvVar
+	loaded        bool
+	entries       []docker.EnvVar
+	lines         []envLine
+	filterPattern string
+	unmasked      bool
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewContainerEnvWidget creates a ContainerEnvWidget
+func NewContainerEnvWidget(dockerDaemon docker.ContainerAPI, y int) *ContainerEnvWidget {
+	w := &ContainerEnvWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerEnvWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	widgetHeader := WidgetHeader("Environment of "+s.name, len(s.env)+len(s.labels), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	selectedEntry := s.selectedEntry()
+	for i, line := range s.visibleLines() {
+		par := termui.NewParFromMarkupText(DryTheme, line.text)
+		par.Border = false
+		par.Height = 1
+		par.Width = s.width
+		par.X = s.x
+		par.Y = y
+		par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+		if line.entry >= 0 && line.entry == selectedEntry || line.entry < 0 && i+s.startIndex == s.selectedIndex {
+			par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+		}
+		buf.Merge(par.Buffer())
+		y++
+	}
+	return buf
+}
+
+//ContainerID returns the id of the container whose environment is shown
+func (s *ContainerEnvWidget) ContainerID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.containerID
+}
+
+//ForContainer sets the container whose environment is shown. If the
+//container was already inspected its environment is shown right away,
+//otherwise the container is inspected once the widget is mounted.
+func (s *ContainerEnvWidget) ForContainer(c *docker.Container, name string) {
+	s.Lock()
+	defer s.Unlock()
+	s.containerID = c.ID
+	s.name = name
+	s.filterPattern = ""
+	s.unmasked = false
+	s.loaded = docker.HasConfig(c)
+	if s.loaded {
+		s.env = docker.ContainerEnv(c.ContainerJSON)
+		s.labels = docker.ContainerLabels(c.ContainerJSON)
+	} else {
+		s.env, s.labels = nil, nil
+	}
+	s.buildLines()
+	s.mounted = false
+	s.loader.Reset()
+}
+
+//Filter shows only the entries whose key or value contain the given pattern,
+//the values that are masked are not searched
+func (s *ContainerEnvWidget) Filter(pattern string) {
+	s.Lock()
+	defer s.Unlock()
+	s.filterPattern = pattern
+	s.buildLines()
+}
+
+//ActiveFilter returns the filter applied to the tables, empty if there is none
+func (s *ContainerEnvWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *ContainerEnvWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		if !s.loaded {
+			s.loader.Load(s.fetchEnv)
+		}
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ContainerEnvWidget) Name() string {
+	return "ContainerEnvWidget"
+}
+
+//Refresh inspects the container again the next time the widget is mounted
+func (s *ContainerEnvWidget) Refresh() {
+	s.Lock()
+	defer s.Unlock()
+	s.loaded = false
+	s.mounted = false
+}
+
+//RowCount returns the number of rows of this widget
+func (s *ContainerEnvWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.lines)
+}
+
+//Selected returns the entry on the cursor, false if the cursor is on a table title
+func (s *ContainerEnvWidget) Selected() (docker.EnvVar, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	entry := s.selectedEntry()
+	if entry < 0 {
+		return docker.EnvVar{}, false
+	}
+	return s.entries[entry], true
+}
+
+//ToggleMask shows the values of the keys that look secret, or masks them
+//again. Returns true if the values are masked.
+func (s *ContainerEnvWidget) ToggleMask() bool {
+	s.Lock()
+	defer s.Unlock()
+	s.unmasked = !s.unmasked
+	s.buildLines()
+	return !s.unmasked
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ContainerEnvWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ContainerEnvWidget) fetchEnv(ctx context.Context) (func(), error) {
+	inspected, err := s.dockerDaemon.Inspect(s.containerID)
+	if err != nil {
+		return nil, err
+	}
+	env := docker.ContainerEnv(inspected)
+	labels := docker.ContainerLabels(inspected)
+	return func() {
+		s.env = env
+		s.labels = labels
+		s.loaded = true
+		s.buildLines()
+	}, nil
+}
+
+func (s *ContainerEnvWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	details := "<b><blue> | Secrets: </><yellow>masked</></> "
+	if s.unmasked {
+		details = "<b><blue> | Secrets: </><red>shown</></> "
+	}
+	if s.filterPattern != "" {
+		details += fmt.Sprintf("<b><blue>| Active filter: </><yellow>%s</></> ", FilterText(s.filterPattern))
+	}
+	return details
+}
+
+//buildLines builds the lines of the widget, the values that do not fit on
+//the width of the widget take as many lines as needed
+func (s *ContainerEnvWidget) buildLines() {
+	s.entries = nil
+	s.lines = nil
+	env := s.filter(s.env)
+	labels := s.filter(s.labels)
+	keyWidth := 0
+	for _, entries := range [][]docker.EnvVar{env, labels} {
+		for _, e := range entries {
+			if l := len([]rune(e.Key)); l > keyWidth {
+				keyWidth = l
+			}
+		}
+	}
+	if max := s.width / 3; keyWidth > max {
+		keyWidth = max
+	}
+	valueWidth := s.width - keyWidth - DefaultColumnSpacing
+	indent := strings.Repeat(" ", keyWidth+DefaultColumnSpacing)
+
+	tables := []struct {
+		title   string
+		entries []docker.EnvVar
+	}{
+		{"Environment", env},
+		{"Labels", labels},
+	}
+	for _, table := range tables {
+		s.lines = append(s.lines, envLine{fmt.Sprintf("<b><blue>%s: </><yellow>%d</></>", table.title, len(table.entries)), -1})
+		for _, e := range table.entries {
+			entry := len(s.entries)
+			s.entries = append(s.entries, e)
+			key := truncateCell(e.Key, keyWidth)
+			key += strings.Repeat(" ", keyWidth-len([]rune(key))+DefaultColumnSpacing)
+			for i, line := range wrapCell(s.value(e), valueWidth) {
+				if i == 0 {
+					s.lines = append(s.lines, envLine{"<blue>" + key + "</>" + line, entry})
+				} else {
+					s.lines = append(s.lines, envLine{indent + line, entry})
+				}
+			}
+		}
+	}
+}
+
+//filter returns the given entries that match the active filter
+func (s *ContainerEnvWidget) filter(entries []docker.EnvVar) []docker.EnvVar {
+	if s.filterPattern == "" {
+		return entries
+	}
+	var filtered []docker.EnvVar
+	for _, e := range entries {
+		if MatchesPattern(e.Key, s.filterPattern) ||
+			s.value(e) != maskedValue && MatchesPattern(e.Value, s.filterPattern) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+//value returns the value of the given entry as shown, masked if its key looks secret
+func (s *ContainerEnvWidget) value(e docker.EnvVar) string {
+	if !s.unmasked && isSecretKey(e.Key) && e.Value != "" {
+		return maskedValue
+	}
+	return e.Value
+}
+
+func (s *ContainerEnvWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+		s.buildLines()
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.lines) {
+		index = len(s.lines) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//selectedEntry returns the index of the entry on the cursor, -1 if the
+//cursor is on a table title
+func (s *ContainerEnvWidget) selectedEntry() int {
+	if s.selectedIndex >= len(s.lines) {
+		return -1
+	}
+	return s.lines[s.selectedIndex].entry
+}
+
+func (s *ContainerEnvWidget) visibleLines() []envLine {
+	//the widget header takes a line
+	height := s.height - 1
+	if height <= 0 || len(s.lines) == 0 {
+		return nil
+	}
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(s.lines)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(s.lines) {
+		end = len(s.lines)
+	}
+	return s.lines[s.startIndex:end]
+}
+
+//isSecretKey returns true if the given key looks like it holds a secret
+func isSecretKey(key string) bool {
+	key = strings.ToUpper(key)
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+//envDaemon inspects containers with a few environment variables and labels
+type envDaemon struct {
+	mocks.DockerDaemonMock
+	inspected int
+}
+
+func (d *envDaemon) Inspect(id string) (types.ContainerJSON, error) {
+	d.inspected++
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id},
+		Config: &container.Config{
+			Env: []string{
+				"DB_PASSWORD=s3cr3t",
+				"LOG_LEVEL=debug",
+				"JAVA_OPTS=" + strings.Repeat("-Xmx1g ", 20),
+			},
+			Labels: map[string]string{"maintainer": "ops"},
+		},
+	}, nil
+}
+
+func TestContainerEnvWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 60},
+	}
+	ui.ActiveScreen.Cursor.Max(100)
+	daemon := &envDaemon{}
+	w := NewContainerEnvWidget(daemon, 0)
+	w.ForContainer(&docker.Container{Container: types.Container{ID: "web"}}, "web")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+	w.Buffer()
+
+	if daemon.inspected != 1 {
+		t.Fatalf("Container not inspected, inspected: %d", daemon.inspected)
+	}
+	//two titles, three variables, the long one on several lines, and a label
+	if w.RowCount() <= 6 {
+		t.Errorf("Long values were not wrapped, rows: %d", w.RowCount())
+	}
+	for _, line := range w.lines {
+		if strings.Contains(line.text, "s3cr3t") {
+			t.Errorf("Secret value shown while masked: %s", line.text)
+		}
+	}
+
+	ui.ActiveScreen.Cursor.ScrollTo(1)
+	w.Buffer()
+	if e, ok := w.Selected(); !ok || e.Value != "s3cr3t" {
+		t.Errorf("Unexpected entry selected: %v", e)
+	}
+
+	if w.ToggleMask() {
+		t.Error("Values still masked")
+	}
+	if !strings.Contains(w.lines[1].text, "s3cr3t") {
+		t.Errorf("Secret value not shown once unmasked: %s", w.lines[1].text)
+	}
+
+	w.Filter("maint")
+	if len(w.entries) != 1 || w.entries[0].Key != "maintainer" {
+		t.Errorf("Unexpected entries after filtering: %v", w.entries)
+	}
+}
+
+func TestContainerEnvWidget_InspectedContainer(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	daemon := &envDaemon{}
+	inspected, _ := daemon.Inspect("web")
+	daemon.inspected = 0
+
+	w := NewContainerEnvWidget(daemon, 0)
+	w.ForContainer(&docker.Container{Container: types.Container{ID: "web"}, ContainerJSON: inspected}, "web")
+	if w.RowCount() == 0 {
+		t.Error("The environment of an inspected container must be shown right away")
+	}
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+	if daemon.inspected != 0 {
+		t.Errorf("An inspected container was inspected again %d times", daemon.inspected)
+	}
+}
+
+func TestIsSecretKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"POSTGRES_PASSWORD", true},
+		{"github_token", true},
+		{"AWS_SECRET_ACCESS_KEY", true},
+		{"PATH", false},
+		{"LOG_LEVEL", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := isSecretKey(tt.key); got != tt.want {
+				t.Errorf("isSecretKey(%s) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package appui
+
+import (
+	"github.com/moncho/dry/docker"
+)
+
+//Fields of the container exec form
+const (
+	execFieldCommand    = "command"
+	execFieldUser       = "user"
+	execFieldWorkingDir = "workdir"
+	execFieldEnv        = "env"
+)
+
+//ContainerExecForm is a widget that asks for a command to run in a container
+//and, optionally, the user, the working directory and the environment to
+//run it with, the settings docker exec takes
+type ContainerExecForm struct {
+	*wizard
+}
+
+//NewContainerExecForm creates a ContainerExecForm for the container with the
+//given name, the given commands, newest first, were run before in the
+//container and are offered as completions of the command
+func NewContainerExecForm(name string, history []string) *ContainerExecForm {
+	steps := []*wizardStep{
+		{
+			field: execFieldCommand,
+			label: "Command",
+			help:  "Command to run, quote arguments with spaces, Tab cycles through the last commands run in the container",
+			validate: func(value string) error {
+				_, err := docker.SplitCommand(value)
+				return err
+			},
+			completions: history,
+		},
+		{
+			field: execFieldUser,
+			label: "User",
+			help:  "User to run the command as, name or uid[:gid], leave empty for the container user",
+		},
+		{
+			field: execFieldWorkingDir,
+			label: "Working dir",
+			help:  "Directory to run the command in, leave empty for the container working directory",
+		},
+		{
+			field: execFieldEnv,
+			label: "Environment",
+			help:  "Comma separated environment variables, i.e. KEY=value, DEBUG=1",
+			validate: func(value string) error {
+				return docker.ValidateEnv(splitRunList(value))
+			},
+		},
+	}
+	return &ContainerExecForm{newWizard("docker exec "+name, steps, nil)}
+}
+
+//Name returns the widget name
+func (w *ContainerExecForm) Name() string {
+	return "ContainerExecForm"
+}
+
+//Options returns the options entered on the form
+func (w *ContainerExecForm) Options() docker.ExecOptions {
+	w.RLock()
+	defer w.RUnlock()
+	values := w.values()
+	return docker.ExecOptions{
+		Command:    values[execFieldCommand],
+		User:       values[execFieldUser],
+		WorkingDir: values[execFieldWorkingDir],
+		Env:        splitRunList(values[execFieldEnv]),
+	}
+}
@@ -0,0 +1,31 @@
+package appui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestContainerExecForm(t *testing.T) {
+	w := NewContainerExecForm("web", []string{"ps aux", "cat /etc/hosts"})
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+	tab := []termbox.Event{keyEvent(termbox.KeyTab)}
+
+	focusWizard(w,
+		//the second command run before
+		tab, tab, enter,
+		typedEvents("nobody"), enter,
+		enter,
+		//an invalid variable stops the form until it is fixed
+		typedEvents("DEBUG"), enter, typedEvents("=1"), enter)
+
+	if w.Canceled() {
+		t.Fatal("Form was canceled")
+	}
+	want := docker.ExecOptions{Command: "cat /etc/hosts", User: "nobody", Env: []string{"DEBUG=1"}}
+	if got := w.Options(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected options, got %+v, want %+v", got, want)
+	}
+}
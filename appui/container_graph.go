@@ -0,0 +1,376 @@
+package appui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/docker/docker/api/types"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//graphNode is a row of the container graph tree
+type graphNode struct {
+	//tree drawing that goes before the label
+	prefix string
+	label  string
+	//id of the container of the node, empty for networks, volumes and sections
+	containerID string
+	//path of the node from the root, identifies the node to expand it
+	path string
+	//true if the node is a container whose links can be shown
+	expandable bool
+}
+
+//ContainerGraphWidget shows, as a tree, how a container relates to others: the
+//networks it is connected to and the containers on them, the volumes it shares
+//with other containers and its compose depends_on relationships. Container
+//nodes can be expanded to show their own links.
+type ContainerGraphWidget struct {
+	dockerDaemon  docker.ContainerAPI
+	rootID        string
+	graph         *docker.ContainerGraph
+	expanded      map[string]bool
+	nodes         []graphNode
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	//progress of the inspection of the containers, updated while loading
+	inspected, total int64
+	sync.RWMutex
+}
+
+//NewContainerGraphWidget creates a ContainerGraphWidget
+func NewContainerGraphWidget(dockerDaemon docker.ContainerAPI, y int) *ContainerGraphWidget {
+	w := &ContainerGraphWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+		expanded:     make(map[string]bool),
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerGraphWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	details := s.loader.HeaderDetails()
+	if s.loader.Loading() {
+		details = fmt.Sprintf("<b><blue> | </><yellow>Inspecting containers %d/%d</></> ",
+			atomic.LoadInt64(&s.inspected), atomic.LoadInt64(&s.total))
+	}
+	title := "Links of " + docker.TruncateID(s.rootID)
+	if s.graph != nil {
+		title = "Links of " + s.graph.Name(s.rootID)
+	}
+	widgetHeader := WidgetHeader(title, len(s.nodes), details)
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	for i, node := range s.visibleNodes() {
+		par := termui.NewParFromMarkupText(DryTheme, node.prefix+node.label)
+		par.Border = false
+		par.Height = 1
+		par.Width = s.width
+		par.X = s.x
+		par.Y = y
+		par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+		if i+s.startIndex == s.selectedIndex {
+			par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+		}
+		buf.Merge(par.Buffer())
+		y++
+	}
+	return buf
+}
+
+//ForContainer sets the container whose links are shown
+func (s *ContainerGraphWidget) ForContainer(id string) {
+	s.Lock()
+	defer s.Unlock()
+	if id != s.rootID {
+		s.expanded = make(map[string]bool)
+	}
+	s.rootID = id
+	s.mounted = false
+}
+
+//Mount tells this widget to be ready for rendering. Every container is
+//inspected, in the background, to find out how they relate to each other.
+func (s *ContainerGraphWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.graph = nil
+		s.nodes = nil
+		s.loader.Reset()
+		s.loader.Load(s.fetchGraph)
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ContainerGraphWidget) Name() string {
+	return "ContainerGraphWidget"
+}
+
+//OnEvent runs the given command on the container of the selected node
+func (s *ContainerGraphWidget) OnEvent(event EventCommand) error {
+	s.RLock()
+	if s.selectedIndex >= len(s.nodes) || s.nodes[s.selectedIndex].containerID == "" {
+		s.RUnlock()
+		return errors.New("The selected row is not a container")
+	}
+	id := s.nodes[s.selectedIndex].containerID
+	s.RUnlock()
+	return event(id)
+}
+
+//RootID returns the id of the container whose links are shown
+func (s *ContainerGraphWidget) RootID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.rootID
+}
+
+//RowCount returns the number of rows of this widget
+func (s *ContainerGraphWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.nodes)
+}
+
+//ToggleExpand expands the selected container node, showing its links, or
+//collapses it if it is already expanded. Returns false if the selected node
+//cannot be expanded.
+func (s *ContainerGraphWidget) ToggleExpand() bool {
+	s.Lock()
+	defer s.Unlock()
+	if s.selectedIndex >= len(s.nodes) || !s.nodes[s.selectedIndex].expandable {
+		return false
+	}
+	path := s.nodes[s.selectedIndex].path
+	s.expanded[path] = !s.expanded[path]
+	s.buildNodes()
+	return true
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ContainerGraphWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ContainerGraphWidget) fetchGraph(ctx context.Context) (func(), error) {
+	containers := s.dockerDaemon.Containers(
+		[]docker.ContainerFilter{docker.ContainerFilters.Unfiltered()}, docker.NoSort)
+	atomic.StoreInt64(&s.total, int64(len(containers)))
+	atomic.StoreInt64(&s.inspected, 0)
+	RenderRequest()
+
+	inspected := make([]types.ContainerJSON, 0, len(containers))
+	for _, c := range containers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		//containers removed while inspecting are left out
+		if details, err := s.dockerDaemon.Inspect(c.ID); err == nil {
+			inspected = append(inspected, details)
+		}
+		atomic.AddInt64(&s.inspected, 1)
+		RenderRequest()
+	}
+	graph := docker.NewContainerGraph(inspected)
+	return func() {
+		s.graph = graph
+		s.buildNodes()
+	}, nil
+}
+
+//buildNodes builds the tree of the root container, container nodes are
+//expanded if the user asked to
+func (s *ContainerGraphWidget) buildNodes() {
+	if s.graph == nil {
+		s.nodes = nil
+		return
+	}
+	s.nodes = []graphNode{{
+		label:       containerNodeLabel(s.graph, s.rootID),
+		containerID: s.rootID,
+		path:        s.rootID,
+	}}
+	s.addLinks(s.rootID, s.rootID, "", map[string]bool{s.rootID: true})
+}
+
+//addLinks adds the nodes for the links of the given container. Containers that
+//are already on the path from the root are not expanded again, so cycles in the
+//graph do not result in an endless tree.
+func (s *ContainerGraphWidget) addLinks(id, path, prefix string, ancestors map[string]bool) {
+	links := s.graph.Links(id)
+	type section struct {
+		title string
+		links []docker.ContainerLink
+	}
+	sections := []section{
+		{"Networks", links.Networks},
+		{"Volumes", links.Volumes},
+	}
+	if len(links.DependsOn) > 0 {
+		sections = append(sections, section{"Depends on", []docker.ContainerLink{{Containers: links.DependsOn}}})
+	}
+	if len(links.RequiredBy) > 0 {
+		sections = append(sections, section{"Required by", []docker.ContainerLink{{Containers: links.RequiredBy}}})
+	}
+	if len(links.VolumesFrom) > 0 {
+		sections = append(sections, section{"Volumes from", []docker.ContainerLink{{Containers: links.VolumesFrom}}})
+	}
+	if len(links.VolumesUsedBy) > 0 {
+		sections = append(sections, section{"Volumes used by", []docker.ContainerLink{{Containers: links.VolumesUsedBy}}})
+	}
+	for i, sec := range sections {
+		last := i == len(sections)-1
+		s.nodes = append(s.nodes, graphNode{
+			prefix: prefix + treeBranch(last),
+			label:  "<blue>" + sec.title + "</>",
+		})
+		sectionPrefix := prefix + treeIndent(last)
+		if len(sec.links) == 0 {
+			s.nodes = append(s.nodes, graphNode{
+				prefix: sectionPrefix + treeBranch(true),
+				label:  "<darkgrey>none</>",
+			})
+			continue
+		}
+		for j, link := range sec.links {
+			containersPrefix := sectionPrefix
+			if link.Name != "" {
+				lastLink := j == len(sec.links)-1
+				label := "<yellow>" + link.Name + "</>"
+				if len(link.Containers) == 0 {
+					label += " <darkgrey>(no other containers)</>"
+				}
+				s.nodes = append(s.nodes, graphNode{
+					prefix: sectionPrefix + treeBranch(lastLink),
+					label:  label,
+				})
+				containersPrefix = sectionPrefix + treeIndent(lastLink)
+			}
+			for k, other := range link.Containers {
+				s.addContainer(other, path, containersPrefix, k == len(link.Containers)-1, ancestors)
+			}
+		}
+	}
+}
+
+func (s *ContainerGraphWidget) addContainer(id, parentPath, prefix string, last bool, ancestors map[string]bool) {
+	path := parentPath + "/" + id
+	node := graphNode{
+		prefix:      prefix + treeBranch(last),
+		label:       containerNodeLabel(s.graph, id),
+		containerID: id,
+		path:        path,
+		expandable:  !ancestors[id],
+	}
+	if ancestors[id] {
+		node.label += " <darkgrey>(shown above)</>"
+	} else if s.expanded[path] {
+		node.label = "<b>-</> " + node.label
+	} else {
+		node.label = "<b>+</> " + node.label
+	}
+	s.nodes = append(s.nodes, node)
+	if !node.expandable || !s.expanded[path] {
+		return
+	}
+	ancestors[id] = true
+	s.addLinks(id, path, prefix+treeIndent(last), ancestors)
+	delete(ancestors, id)
+}
+
+func (s *ContainerGraphWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.nodes) {
+		index = len(s.nodes) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+func (s *ContainerGraphWidget) visibleNodes() []graphNode {
+	//the widget header takes a line
+	height := s.height - 1
+	if height <= 0 || len(s.nodes) == 0 {
+		return nil
+	}
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(s.nodes)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(s.nodes) {
+		end = len(s.nodes)
+	}
+	return s.nodes[s.startIndex:end]
+}
+
+func containerNodeLabel(graph *docker.ContainerGraph, id string) string {
+	c, ok := graph.Container(id)
+	if !ok {
+		return "<white>" + docker.TruncateID(id) + "</> <darkgrey>(gone)</>"
+	}
+	state := "<red>stopped</>"
+	if c.State != nil && c.State.Running {
+		state = "<green>running</>"
+	}
+	return fmt.Sprintf("<white>%s</> <darkgrey>%s</> %s", graph.Name(id), docker.TruncateID(id), state)
+}
+
+func treeBranch(last bool) string {
+	if last {
+		return ui.TreeLastBranchGlyph.String()
+	}
+	return ui.TreeBranchGlyph.String()
+}
+
+func treeIndent(last bool) string {
+	if last {
+		return "   "
+	}
+	return ui.TreeTrunkGlyph.String()
+}
@@ -0,0 +1,85 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+//networkedDaemon has two containers connected to the same two networks
+type networkedDaemon struct {
+	mocks.DockerDaemonMock
+}
+
+func (d *networkedDaemon) Containers(filters []docker.ContainerFilter, mode docker.SortMode) []*docker.Container {
+	return []*docker.Container{
+		{Container: types.Container{ID: "web"}},
+		{Container: types.Container{ID: "api"}},
+	}
+}
+
+func (d *networkedDaemon) Inspect(id string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id, Name: "/" + id},
+		Config:            &container.Config{},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"front": {},
+				"back":  {},
+			},
+		},
+	}, nil
+}
+
+func TestContainerGraphWidget_Cycles(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	ui.ActiveScreen.Cursor.Max(100)
+	w := NewContainerGraphWidget(&networkedDaemon{}, 0)
+	w.ForContainer("web")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+
+	//web, Networks, back, api, front, api, Volumes, none
+	if w.RowCount() != 8 {
+		t.Fatalf("Unexpected number of rows: %d", w.RowCount())
+	}
+	//expanding api under the back network, its links lead back to web
+	ui.ActiveScreen.Cursor.ScrollTo(3)
+	w.prepareForRendering()
+	if !w.ToggleExpand() {
+		t.Fatal("Container node could not be expanded")
+	}
+	var shownAbove int
+	for _, node := range w.nodes {
+		if strings.Contains(node.label, "shown above") {
+			shownAbove++
+			if node.expandable {
+				t.Errorf("A container already on the path can be expanded: %s", node.label)
+			}
+		}
+	}
+	//web is on both networks of api
+	if shownAbove != 2 {
+		t.Errorf("Unexpected number of containers shown above: %d", shownAbove)
+	}
+
+	var id string
+	w.OnEvent(func(selected string) error {
+		id = selected
+		return nil
+	})
+	if id != "api" {
+		t.Errorf("Unexpected selected container: %s", id)
+	}
+}
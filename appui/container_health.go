@@ -0,0 +1,349 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//healthRefreshInterval is how often the health log is inspected again while shown
+var healthRefreshInterval = 2 * time.Second
+
+//healthOutputPreview is how many characters of the output of a probe are shown
+//when the probe is not expanded
+const healthOutputPreview = 60
+
+//healthLine is a row of the health log, either the summary of a probe
+//or a line of its output
+type healthLine struct {
+	text string
+	//probe is the index of the probe the line belongs to, -1 for lines not
+	//about a probe
+	probe int
+}
+
+//ContainerHealthWidget shows the latest healthcheck probe results of a
+//container, newest first, and refreshes them while mounted. The output of a
+//probe is shown in full once the probe is expanded.
+type ContainerHealthWidget struct {
+	dockerDaemon  docker.ContainerAPI
+	containerID   string
+	name          string
+	log           docker.HealthLog
+	expanded      map[int64]bool
+	lines         []healthLine
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	stop          chan struct{}
+	sync.RWMutex
+}
+
+//NewContainerHealthWidget creates a ContainerHealthWidget
+func NewContainerHealthWidget(dockerDaemon docker.ContainerAPI, y int) *ContainerHealthWidget {
+	w := &ContainerHealthWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+		expanded:     make(map[int64]bool),
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerHealthWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	title := "Health of " + s.name
+	widgetHeader := WidgetHeader(title, len(s.log.Probes), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	for i, line := range s.visibleLines() {
+		par := termui.NewParFromMarkupText(DryTheme, line.text)
+		par.Border = false
+		par.Height = 1
+		par.Width = s.width
+		par.X = s.x
+		par.Y = y
+		par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+		if i+s.startIndex == s.selectedIndex {
+			par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+		}
+		buf.Merge(par.Buffer())
+		y++
+	}
+	return buf
+}
+
+//ContainerID returns the id of the container whose health is shown
+func (s *ContainerHealthWidget) ContainerID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.containerID
+}
+
+//ForContainer sets the container whose health is shown
+func (s *ContainerHealthWidget) ForContainer(id, name string) {
+	s.Lock()
+	defer s.Unlock()
+	if id != s.containerID {
+		s.expanded = make(map[int64]bool)
+		s.log = docker.HealthLog{}
+		s.lines = nil
+	}
+	s.containerID = id
+	s.name = name
+	s.mounted = false
+	s.stopRefreshing()
+}
+
+//Mount tells this widget to be ready for rendering, the health of the
+//container is inspected periodically until the widget is unmounted
+func (s *ContainerHealthWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Reset()
+		s.loader.Load(s.fetchHealth)
+		s.stopRefreshing()
+		s.stop = make(chan struct{})
+		go s.refresh(s.stop)
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ContainerHealthWidget) Name() string {
+	return "ContainerHealthWidget"
+}
+
+//RowCount returns the number of rows of this widget
+func (s *ContainerHealthWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.lines)
+}
+
+//ToggleExpand shows the whole output of the selected probe, or just a
+//preview of it if it is already expanded. Returns false if no probe is selected.
+func (s *ContainerHealthWidget) ToggleExpand() bool {
+	s.Lock()
+	defer s.Unlock()
+	probe := s.selectedProbe()
+	if probe < 0 {
+		return false
+	}
+	start := s.log.Probes[probe].Start.UnixNano()
+	s.expanded[start] = !s.expanded[start]
+	s.buildLines()
+	return true
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ContainerHealthWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.stopRefreshing()
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ContainerHealthWidget) fetchHealth(ctx context.Context) (func(), error) {
+	inspected, err := s.dockerDaemon.Inspect(s.containerID)
+	if err != nil {
+		return nil, err
+	}
+	log := docker.ContainerHealthLog(inspected)
+	return func() {
+		s.log = log
+		s.buildLines()
+	}, nil
+}
+
+func (s *ContainerHealthWidget) stopRefreshing() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+//refresh inspects the container again, periodically, until told to stop
+func (s *ContainerHealthWidget) refresh(stop <-chan struct{}) {
+	ticker := time.NewTicker(healthRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.Lock()
+			if s.mounted {
+				s.loader.Load(s.fetchHealth)
+			}
+			s.Unlock()
+		}
+	}
+}
+
+func (s *ContainerHealthWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	if !s.log.Defined {
+		return ""
+	}
+	status := s.log.Status
+	if status == "" {
+		status = "starting"
+	}
+	color := "yellow"
+	switch status {
+	case "healthy":
+		color = "green"
+	case "unhealthy":
+		color = "red"
+	}
+	return fmt.Sprintf("<b><blue> | Status: </><%s>%s</><blue> | Failing streak: </><yellow>%d</></> ",
+		color, status, s.log.FailingStreak)
+}
+
+//buildLines builds the rows of the widget from the health log
+func (s *ContainerHealthWidget) buildLines() {
+	s.lines = nil
+	if !s.log.Defined {
+		s.lines = append(s.lines, healthLine{"<yellow>No healthcheck defined for this container</>", -1})
+		return
+	}
+	if s.log.Test != "" {
+		s.lines = append(s.lines, healthLine{"<blue>Test:</> " + s.log.Test, -1})
+	}
+	if len(s.log.Probes) == 0 {
+		s.lines = append(s.lines, healthLine{"<darkgrey>The healthcheck has not run yet</>", -1})
+		return
+	}
+	for i, probe := range s.log.Probes {
+		expanded := s.expanded[probe.Start.UnixNano()]
+		marker := "<b>+</>"
+		if expanded {
+			marker = "<b>-</>"
+		}
+		output := strings.TrimSpace(probe.Output)
+		summary := fmt.Sprintf("%s %s  %s  took %s",
+			marker,
+			probe.Start.Format("2006-01-02 15:04:05"),
+			exitCodeLabel(probe.ExitCode),
+			probeDuration(probe.Start, probe.End))
+		if !expanded && output != "" {
+			preview := strings.Join(strings.Fields(output), " ")
+			if runes := []rune(preview); len(runes) > healthOutputPreview {
+				preview = string(runes[:healthOutputPreview]) + ui.EllipsisGlyph.String()
+			}
+			summary += "  <darkgrey>" + preview + "</>"
+		}
+		s.lines = append(s.lines, healthLine{summary, i})
+		if !expanded {
+			continue
+		}
+		if output == "" {
+			s.lines = append(s.lines, healthLine{"    <darkgrey>no output</>", i})
+			continue
+		}
+		for _, outputLine := range strings.Split(output, "\n") {
+			s.lines = append(s.lines, healthLine{"    " + outputLine, i})
+		}
+	}
+}
+
+func (s *ContainerHealthWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.lines) {
+		index = len(s.lines) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//selectedProbe returns the index of the probe of the selected line, -1 if
+//the line is not about a probe
+func (s *ContainerHealthWidget) selectedProbe() int {
+	if s.selectedIndex >= len(s.lines) {
+		return -1
+	}
+	return s.lines[s.selectedIndex].probe
+}
+
+func (s *ContainerHealthWidget) visibleLines() []healthLine {
+	//the widget header takes a line
+	height := s.height - 1
+	if height <= 0 || len(s.lines) == 0 {
+		return nil
+	}
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(s.lines)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(s.lines) {
+		end = len(s.lines)
+	}
+	return s.lines[s.startIndex:end]
+}
+
+//exitCodeLabel colours the exit code of a probe: 0 means healthy, 1 unhealthy
+//and anything else that the probe could not run properly
+func exitCodeLabel(exitCode int) string {
+	color := "yellow"
+	switch exitCode {
+	case 0:
+		color = "green"
+	case 1:
+		color = "red"
+	}
+	return fmt.Sprintf("<%s>exit %d</>", color, exitCode)
+}
+
+func probeDuration(start, end time.Time) string {
+	if end.Before(start) {
+		return "-"
+	}
+	return end.Sub(start).Round(time.Millisecond).String()
+}
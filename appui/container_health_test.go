@@ -0,0 +1,106 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+//unhealthyDaemon has a container whose last two probes failed
+type unhealthyDaemon struct {
+	mocks.DockerDaemonMock
+}
+
+func (d *unhealthyDaemon) Inspect(id string) (types.ContainerJSON, error) {
+	start := time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC)
+	probe := func(seconds, exitCode int, output string) *types.HealthcheckResult {
+		s := start.Add(time.Duration(seconds) * time.Second)
+		return &types.HealthcheckResult{Start: s, End: s.Add(250 * time.Millisecond), ExitCode: exitCode, Output: output}
+	}
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID: id,
+			State: &types.ContainerState{
+				Health: &types.Health{
+					Status:        "unhealthy",
+					FailingStreak: 2,
+					Log: []*types.HealthcheckResult{
+						probe(0, 0, "ok"),
+						probe(30, 1, "connection refused\nretrying"),
+						probe(60, 1, "connection refused"),
+					},
+				},
+			},
+		},
+		Config: &container.Config{
+			Healthcheck: &container.HealthConfig{Test: []string{"CMD-SHELL", "curl -f localhost"}},
+		},
+	}, nil
+}
+
+func TestContainerHealthWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	ui.ActiveScreen.Cursor.Max(100)
+	w := NewContainerHealthWidget(&unhealthyDaemon{}, 0)
+	w.ForContainer("web", "web")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	//test, and a line per probe
+	if w.RowCount() != 4 {
+		t.Fatalf("Unexpected number of rows: %d", w.RowCount())
+	}
+	if !strings.Contains(w.lines[1].text, "10:01:00") || !strings.Contains(w.lines[1].text, "<red>exit 1</>") {
+		t.Errorf("The newest probe is not shown first: %s", w.lines[1].text)
+	}
+	if !strings.Contains(w.lines[3].text, "<green>exit 0</>") {
+		t.Errorf("Unexpected last probe: %s", w.lines[3].text)
+	}
+
+	//the test line cannot be expanded
+	w.prepareForRendering()
+	if w.ToggleExpand() {
+		t.Error("A line that is not a probe was expanded")
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(2)
+	w.prepareForRendering()
+	if !w.ToggleExpand() {
+		t.Fatal("The probe could not be expanded")
+	}
+	//the output of the probe takes two lines
+	if w.RowCount() != 6 {
+		t.Errorf("Unexpected number of rows once expanded: %d", w.RowCount())
+	}
+	if w.lines[3].text != "    connection refused" || w.lines[4].text != "    retrying" {
+		t.Errorf("Unexpected output lines: %q, %q", w.lines[3].text, w.lines[4].text)
+	}
+}
+
+func TestContainerHealthWidget_NoHealthcheck(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewContainerHealthWidget(&networkedDaemon{}, 0)
+	w.ForContainer("web", "web")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	if w.RowCount() != 1 || !strings.Contains(w.lines[0].text, "No healthcheck defined") {
+		t.Errorf("Unexpected rows for a container with no healthcheck: %+v", w.lines)
+	}
+}
@@ -21,6 +21,7 @@ type ContainerMenuWidget struct {
 	height, width int
 	mounted       bool
 	selectedIndex int
+	checkpoints   bool
 	x, y          int
 	OnUnmount     func() error
 	sync.RWMutex
@@ -80,6 +81,14 @@ func (s *ContainerMenuWidget) ForContainer(cID string) {
 
 }
 
+//ShowCheckpoints shows or hides the checkpoints command
+func (s *ContainerMenuWidget) ShowCheckpoints(show bool) {
+	s.Lock()
+	defer s.Unlock()
+	s.checkpoints = show
+	s.mounted = false
+}
+
 //Mount prepares this widget for rendering
 func (s *ContainerMenuWidget) Mount() error {
 	s.Lock()
@@ -89,15 +98,21 @@ func (s *ContainerMenuWidget) Mount() error {
 		if c != nil {
 			s.cInfo = NewContainerDetailsWidget(c, s.y)
 		}
-		rows := make([]*Row, len(docker.CommandDescriptions))
-		for i, command := range docker.CommandDescriptions {
+		var rows []*Row
+		for _, command := range docker.ContainerCommands {
+			if command.Command == docker.CHECKPOINTS && !s.checkpoints {
+				continue
+			}
+			if !pauseCommandApplies(command.Command, c) {
+				continue
+			}
 			r := &Row{
-				ParColumns: []*drytermui.ParColumn{drytermui.NewThemedParColumn(DryTheme, command)},
+				ParColumns: []*drytermui.ParColumn{drytermui.NewThemedParColumn(DryTheme, command.Description)},
 			}
 			r.AddColumn(r.ParColumns[0])
 			r.Height = 1
 			r.Width = r.ParColumns[0].Width
-			rows[i] = r
+			rows = append(rows, r)
 		}
 		s.rows = rows
 		s.align()
@@ -106,6 +121,18 @@ func (s *ContainerMenuWidget) Mount() error {
 	return nil
 }
 
+//pauseCommandApplies returns false if the given command pauses the given
+//container and it cannot be paused, or unpauses it and it is not paused
+func pauseCommandApplies(command docker.Command, c *docker.Container) bool {
+	switch command {
+	case docker.PAUSE:
+		return docker.IsContainerRunning(c) && !docker.IsContainerPaused(c)
+	case docker.UNPAUSE:
+		return docker.IsContainerPaused(c)
+	}
+	return true
+}
+
 //Name returns this widget name
 func (s *ContainerMenuWidget) Name() string {
 	return "ContainerMenuWidget"
@@ -0,0 +1,35 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+func TestPauseCommandApplies(t *testing.T) {
+	running := &docker.Container{Container: types.Container{State: "running", Status: "Up 2 hours"}}
+	paused := &docker.Container{Container: types.Container{State: "paused", Status: "Up 2 hours (Paused)"}}
+	exited := &docker.Container{Container: types.Container{State: "exited", Status: "Exited (0) 3 days ago"}}
+	tests := []struct {
+		name      string
+		command   docker.Command
+		container *docker.Container
+		want      bool
+	}{
+		{"pause a running container", docker.PAUSE, running, true},
+		{"pause a paused container", docker.PAUSE, paused, false},
+		{"pause an exited container", docker.PAUSE, exited, false},
+		{"unpause a running container", docker.UNPAUSE, running, false},
+		{"unpause a paused container", docker.UNPAUSE, paused, true},
+		{"unpause an unknown container", docker.UNPAUSE, nil, false},
+		{"other commands", docker.LOGS, exited, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pauseCommandApplies(tt.command, tt.container); got != tt.want {
+				t.Errorf("pauseCommandApplies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//mountColumns are the titles of the columns of the mounts widget
+var mountColumns = []string{"TYPE", "SOURCE", "DESTINATION", "MODE", "DRIVER"}
+
+//ContainerMountsWidget shows the volumes, bind mounts and tmpfs mounts of
+//a container, one row per mount, as the container is inspected.
+type ContainerMountsWidget struct {
+	dockerDaemon  docker.ContainerAPI
+	containerID   string
+	name          string
+	mounts        []types.MountPoint
+	loaded        bool
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewContainerMountsWidget creates a ContainerMountsWidget
+func NewContainerMountsWidget(dockerDaemon docker.ContainerAPI, y int) *ContainerMountsWidget {
+	w := &ContainerMountsWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerMountsWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	widgetHeader := WidgetHeader("Mounts of "+s.name, len(s.mounts), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	widths := s.columnWidths()
+	buf.Merge(s.line("<b><blue>"+s.row(mountColumns, widths)+"</></>", y, false).Buffer())
+	y++
+	for i, m := range s.visibleMounts() {
+		selected := i+s.startIndex == s.selectedIndex
+		buf.Merge(s.line(s.row(mountCells(m), widths), y, selected).Buffer())
+		y++
+	}
+	return buf
+}
+
+//ContainerID returns the id of the container whose mounts are shown
+func (s *ContainerMountsWidget) ContainerID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.containerID
+}
+
+//ForContainer sets the container whose mounts are shown. If the container
+//was already inspected its mounts are shown right away, otherwise the
+//container is inspected once the widget is mounted.
+func (s *ContainerMountsWidget) ForContainer(c *docker.Container, name string) {
+	s.Lock()
+	defer s.Unlock()
+	s.containerID = c.ID
+	s.name = name
+	s.loaded = docker.HasConfig(c)
+	if s.loaded {
+		s.mounts = docker.ContainerMounts(c.ContainerJSON)
+	} else {
+		s.mounts = nil
+	}
+	s.mounted = false
+	s.loader.Reset()
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *ContainerMountsWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		if !s.loaded {
+			s.loader.Load(s.fetchMounts)
+		}
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ContainerMountsWidget) Name() string {
+	return "ContainerMountsWidget"
+}
+
+//Refresh inspects the container again the next time the widget is mounted
+func (s *ContainerMountsWidget) Refresh() {
+	s.Lock()
+	defer s.Unlock()
+	s.loaded = false
+	s.mounted = false
+}
+
+//RowCount returns the number of rows of this widget
+func (s *ContainerMountsWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.mounts)
+}
+
+//Selected returns the mount on the cursor, false if there are no mounts
+func (s *ContainerMountsWidget) Selected() (types.MountPoint, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.selectedIndex < 0 || s.selectedIndex >= len(s.mounts) {
+		return types.MountPoint{}, false
+	}
+	return s.mounts[s.selectedIndex], true
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ContainerMountsWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ContainerMountsWidget) fetchMounts(ctx context.Context) (func(), error) {
+	inspected, err := s.dockerDaemon.Inspect(s.containerID)
+	if err != nil {
+		return nil, err
+	}
+	mounts := docker.ContainerMounts(inspected)
+	return func() {
+		s.mounts = mounts
+		s.loaded = true
+	}, nil
+}
+
+func (s *ContainerMountsWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	return ""
+}
+
+//columnWidths returns the width of each column, the source takes what is
+//left once the others fit their content
+func (s *ContainerMountsWidget) columnWidths() []int {
+	widths := make([]int, len(mountColumns))
+	for i, title := range mountColumns {
+		widths[i] = len(title)
+	}
+	for _, m := range s.mounts {
+		for i, cell := range mountCells(m) {
+			if l := len([]rune(cell)); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+	others := 0
+	for i, w := range widths {
+		if i != 1 {
+			others += w + DefaultColumnSpacing
+		}
+	}
+	if available := s.width - others - DefaultColumnSpacing; widths[1] > available {
+		widths[1] = available
+	}
+	return widths
+}
+
+//row returns the given cells padded to the given widths
+func (s *ContainerMountsWidget) row(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		cell = truncateCell(cell, widths[i])
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-len([]rune(cell))+DefaultColumnSpacing))
+		}
+	}
+	return b.String()
+}
+
+func (s *ContainerMountsWidget) line(text string, y int, selected bool) *termui.Par {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *ContainerMountsWidget) prepareForRendering() {
+	s.width = ui.ActiveScreen.Dimensions.Width
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.mounts) {
+		index = len(s.mounts) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+func (s *ContainerMountsWidget) visibleMounts() []types.MountPoint {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.mounts) == 0 {
+		return nil
+	}
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(s.mounts)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(s.mounts) {
+		end = len(s.mounts)
+	}
+	return s.mounts[s.startIndex:end]
+}
+
+//mountCells returns the cells of the row of the given mount, named volumes
+//show their name as source
+func mountCells(m types.MountPoint) []string {
+	source := m.Source
+	if m.Name != "" {
+		source = m.Name
+	}
+	return []string{string(m.Type), source, m.Destination, docker.MountMode(m), m.Driver}
+}
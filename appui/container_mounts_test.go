@@ -0,0 +1,66 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+//mountsDaemon inspects containers with a volume, a bind mount and a tmpfs
+type mountsDaemon struct {
+	mocks.DockerDaemonMock
+	inspected int
+}
+
+func (d *mountsDaemon) Inspect(id string) (types.ContainerJSON, error) {
+	d.inspected++
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", Driver: "local", RW: true},
+			{Type: mount.TypeBind, Source: "/etc/app", Destination: "/etc/app"},
+			{Type: mount.TypeTmpfs, Destination: "/tmp", RW: true},
+		},
+	}, nil
+}
+
+func TestContainerMountsWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 60},
+	}
+	ui.ActiveScreen.Cursor.Max(100)
+	daemon := &mountsDaemon{}
+	w := NewContainerMountsWidget(daemon, 0)
+	w.ForContainer(&docker.Container{Container: types.Container{ID: "web"}}, "web")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+	w.Buffer()
+
+	if daemon.inspected != 1 {
+		t.Fatalf("Container not inspected, inspected: %d", daemon.inspected)
+	}
+	if w.RowCount() != 3 {
+		t.Fatalf("Unexpected number of rows, got %d, want 3", w.RowCount())
+	}
+	m, ok := w.Selected()
+	if !ok || m.Type != mount.TypeBind || m.Source != "/etc/app" {
+		t.Errorf("Unexpected mount on the cursor: %v", m)
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(2)
+	w.Buffer()
+	if m, _ := w.Selected(); m.Name != "data" {
+		t.Errorf("Unexpected mount on the cursor: %v", m)
+	}
+	cells := mountCells(m)
+	if cells[1] != "data" || cells[3] != "RW" || cells[4] != "local" {
+		t.Errorf("Unexpected cells of a volume: %v", cells)
+	}
+}
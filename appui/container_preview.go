@@ -0,0 +1,304 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//previewDebounce is how long the selection has to stay on a container
+//before its logs are fetched, so moving the cursor fast fetches nothing
+var previewDebounce = 250 * time.Millisecond
+
+//previewRefreshInterval is how often the logs of the container are fetched again
+var previewRefreshInterval = 3 * time.Second
+
+//previewLogLines is how many lines of the logs of the container are fetched
+const previewLogLines = 50
+
+//ContainerPreviewWidget shows, next to the container list, the details of
+//the container on the cursor along with the tail of its logs and, if it is
+//running, its resource usage.
+type ContainerPreviewWidget struct {
+	dockerDaemon  docker.ContainerAPI
+	container     *docker.Container
+	logs          []string
+	stats         *docker.Stats
+	statsDone     chan<- struct{}
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	stop          chan struct{}
+	sync.RWMutex
+}
+
+//NewContainerPreviewWidget creates a ContainerPreviewWidget
+func NewContainerPreviewWidget(dockerDaemon docker.ContainerAPI, y int) *ContainerPreviewWidget {
+	w := &ContainerPreviewWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerPreviewWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	//the widget starts below the header of the container list
+	height := s.height - 1
+	if !s.mounted || s.width <= 2 || height <= 2 {
+		return buf
+	}
+	par := termui.NewParFromMarkupText(DryTheme, strings.Join(s.lines(height-2), "\n"))
+	par.X = s.x
+	par.Y = s.y + 1
+	par.Width = s.width
+	par.Height = height
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	par.BorderFg = gizaktermui.Attribute(DryTheme.Footer)
+	par.BorderBg = gizaktermui.Attribute(DryTheme.Bg)
+	par.BorderLabel = " Preview "
+	par.BorderLabelFg = gizaktermui.Attribute(DryTheme.Fg)
+	buf.Merge(par.Buffer())
+	return buf
+}
+
+//Layout places the widget on the given column of the screen with the given width
+func (s *ContainerPreviewWidget) Layout(x, width int) {
+	s.Lock()
+	defer s.Unlock()
+	s.x = x
+	s.width = width
+}
+
+//Mount tells this widget to be ready for rendering, the logs of the
+//container are fetched periodically until the widget is unmounted
+func (s *ContainerPreviewWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.load()
+		s.stopRefreshing()
+		s.stop = make(chan struct{})
+		go s.refresh(s.stop)
+	}
+	return nil
+}
+
+//Name returns this widget name
+func (s *ContainerPreviewWidget) Name() string {
+	return "ContainerPreviewWidget"
+}
+
+//Select sets the container to preview. What is known about the container
+//is shown right away, its logs and stats once the selection settles.
+func (s *ContainerPreviewWidget) Select(c *docker.Container) {
+	s.Lock()
+	defer s.Unlock()
+	same := c != nil && s.container != nil && s.container.ID == c.ID
+	s.container = c
+	if same {
+		if !docker.IsContainerRunning(c) {
+			s.stopStats()
+		}
+		return
+	}
+	s.logs = nil
+	s.stopStats()
+	s.loader.Reset()
+	if s.mounted {
+		s.load()
+	}
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ContainerPreviewWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.stopRefreshing()
+	s.stopStats()
+	s.loader.Cancel()
+	return nil
+}
+
+//load fetches the logs of the selected container, once the selection has
+//been on it for a while, and starts streaming its stats
+func (s *ContainerPreviewWidget) load() {
+	if s.container == nil {
+		return
+	}
+	c := s.container
+	s.loader.Load(func(ctx context.Context) (func(), error) {
+		select {
+		case <-time.After(previewDebounce):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var logs []string
+		if docker.LogsReadable(docker.LogDriver(c)) {
+			var err error
+			if logs, err = s.dockerDaemon.LogTail(ctx, c.ID, previewLogLines); err != nil {
+				return nil, err
+			}
+		}
+		return func() {
+			s.logs = logs
+			if s.statsDone == nil && docker.IsContainerRunning(c) {
+				s.streamStats(c)
+			}
+		}, nil
+	})
+}
+
+//streamStats keeps the latest stats of the given container until the stats
+//are stopped or the container stops
+func (s *ContainerPreviewWidget) streamStats(c *docker.Container) {
+	channel := s.dockerDaemon.OpenChannel(c)
+	if channel == nil || channel.Stats == nil {
+		return
+	}
+	s.statsDone = channel.Done
+	done := channel.Done
+	go func() {
+		//drained until closed so the stats stream never blocks
+		for stats := range channel.Stats {
+			s.Lock()
+			if s.statsDone != done {
+				s.Unlock()
+				continue
+			}
+			s.stats = stats
+			s.Unlock()
+			RenderRequest()
+		}
+	}()
+}
+
+func (s *ContainerPreviewWidget) stopStats() {
+	if s.statsDone != nil {
+		close(s.statsDone)
+		s.statsDone = nil
+	}
+	s.stats = nil
+}
+
+func (s *ContainerPreviewWidget) stopRefreshing() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+//refresh fetches the logs again, periodically, until told to stop
+func (s *ContainerPreviewWidget) refresh(stop <-chan struct{}) {
+	ticker := time.NewTicker(previewRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.Lock()
+			if s.mounted {
+				s.load()
+			}
+			s.Unlock()
+		}
+	}
+}
+
+//lines returns the lines shown on the widget, cut to fit its width and the
+//given height
+func (s *ContainerPreviewWidget) lines(height int) []string {
+	c := s.container
+	if c == nil {
+		return []string{"<grey>No container selected</>"}
+	}
+	//borders
+	width := s.width - 2
+	field := func(name, value string) string {
+		return fmt.Sprintf("<blue>%s:</> %s", name, truncateCell(value, width-len(name)-2))
+	}
+	var name string
+	if len(c.Names) > 0 {
+		name = strings.TrimPrefix(c.Names[0], "/")
+	}
+	status := "<red>" + truncateCell(c.Status, width-len("Status")-2) + "</>"
+	if docker.IsContainerRunning(c) {
+		status = "<green>" + truncateCell(c.Status, width-len("Status")-2) + "</>"
+	}
+	lines := []string{
+		field("Name", name),
+		field("ID", docker.TruncateID(c.ID)),
+		field("Image", c.Image),
+		"<blue>Status:</> " + status,
+		field("Created", docker.DurationForHumans(c.Created)+" ago"),
+		field("Command", c.Command),
+		field("Restart", docker.FormatRestartPolicy(docker.RestartPolicy(c))),
+		field("Ports", formatter.DisplayablePorts(c.Ports)),
+	}
+	if c.Container.NetworkSettings != nil {
+		var networks []string
+		for network, settings := range c.Container.NetworkSettings.Networks {
+			networks = append(networks, network+" "+settings.IPAddress)
+		}
+		sort.Strings(networks)
+		lines = append(lines, field("Networks", strings.Join(networks, ", ")))
+	}
+	lines = append(lines, field("Mounts", fmt.Sprintf("%d", len(c.Container.Mounts))))
+
+	if stats := s.stats; stats != nil {
+		lines = append(lines, "",
+			field("CPU", fmt.Sprintf("%.2f%%", stats.CPUPercentage)),
+			field("Memory", fmt.Sprintf("%s / %s (%.2f%%)",
+				units.BytesSize(stats.Memory), units.BytesSize(stats.MemoryLimit), stats.MemoryPercentage)),
+			field("Net I/O", fmt.Sprintf("%s / %s", units.BytesSize(stats.NetworkRx), units.BytesSize(stats.NetworkTx))),
+			field("PIDs", fmt.Sprintf("%d", stats.PidsCurrent)))
+	}
+
+	lines = append(lines, "")
+	switch driver := docker.LogDriver(c); {
+	case !docker.LogsReadable(driver):
+		lines = append(lines, "<grey>"+truncateCell("Logs sent to "+driver+", not readable through the Docker API", width)+"</>")
+	case s.loader.Loading():
+		lines = append(lines, "<yellow>Loading logs"+ui.DotsGlyph.String()+"</>")
+	case s.loader.Err() != nil:
+		lines = append(lines, "<red>"+truncateCell(s.loader.Err().Error(), width)+"</>")
+	case len(s.logs) == 0:
+		lines = append(lines, "<grey>No logs</>")
+	default:
+		lines = append(lines, "<blue>Logs:</>")
+		//the newest lines that fit
+		logs := s.logs
+		if room := height - len(lines); room < len(logs) {
+			if room < 0 {
+				room = 0
+			}
+			logs = logs[len(logs)-room:]
+		}
+		for _, line := range logs {
+			lines = append(lines, truncateCell(line, width))
+		}
+	}
+	return lines
+}
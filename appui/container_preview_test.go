@@ -0,0 +1,44 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+func TestContainerPreviewWidget(t *testing.T) {
+	previewDebounce = 0
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 30, Width: 160},
+	}
+	w := NewContainerPreviewWidget(&mocks.DockerDaemonMock{}, 0)
+	w.Layout(96, 64)
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	defer w.Unmount()
+	if lines := strings.Join(w.lines(20), "\n"); !strings.Contains(lines, "No container selected") {
+		t.Errorf("Unexpected preview without a container: %s", lines)
+	}
+
+	w.Select(&docker.Container{
+		Container: types.Container{
+			ID:     "c1",
+			Names:  []string{"/web"},
+			Image:  "nginx:alpine",
+			State:  "exited",
+			Status: "Exited (0) 2 hours ago"},
+	})
+	w.loader.Wait()
+	lines := strings.Join(w.lines(20), "\n")
+	for _, want := range []string{"web", "nginx:alpine", "Exited (0) 2 hours ago", "No logs"} {
+		if !strings.Contains(lines, want) {
+			t.Errorf("Preview does not show %q: %s", want, lines)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/docker/docker/api/types/container"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//processesRefreshInterval is how often the process list is retrieved again while shown
+var processesRefreshInterval = 2 * time.Second
+
+//processSort is the column processes are sorted by
+type processSort int
+
+//Known process sort modes
+const (
+	processSortPID processSort = iota
+	processSortCPU
+	processSortCommand
+)
+
+var processSortNames = map[processSort]string{
+	processSortPID:     "PID",
+	processSortCPU:     "CPU",
+	processSortCommand: "COMMAND",
+}
+
+//ContainerProcessesWidget shows the processes running in a container, as
+//docker top does, and lists them again periodically while mounted
+type ContainerProcessesWidget struct {
+	dockerDaemon  docker.ContainerAPI
+	containerID   string
+	name          string
+	top           container.ContainerTopOKBody
+	sortMode      processSort
+	lines         []string
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	stop          chan struct{}
+	sync.RWMutex
+}
+
+//NewContainerProcessesWidget creates a ContainerProcessesWidget
+func NewContainerProcessesWidget(dockerDaemon docker.ContainerAPI, y int) *ContainerProcessesWidget {
+	w := &ContainerProcessesWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ContainerProcessesWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	widgetHeader := WidgetHeader("Processes of "+s.name, len(s.top.Processes), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if len(s.lines) > 0 {
+		buf.Merge(s.line("<blue>"+s.lines[0]+"</>", y, false).Buffer())
+		y++
+	}
+	for i, line := range s.visibleLines() {
+		buf.Merge(s.line(line, y, i+s.startIndex == s.selectedIndex).Buffer())
+		y++
+	}
+	return buf
+}
+
+//ContainerID returns the id of the container whose processes are shown
+func (s *ContainerProcessesWidget) ContainerID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.containerID
+}
+
+//ForContainer sets the container whose processes are shown
+func (s *ContainerProcessesWidget) ForContainer(id, name string) {
+	s.Lock()
+	defer s.Unlock()
+	if id != s.containerID {
+		s.top = container.ContainerTopOKBody{}
+		s.lines = nil
+	}
+	s.containerID = id
+	s.name = name
+	s.mounted = false
+	s.stopRefreshing()
+}
+
+//Mount tells this widget to be ready for rendering, the processes of the
+//container are listed periodically until the widget is unmounted
+func (s *ContainerProcessesWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Reset()
+		s.loader.Load(s.fetchProcesses)
+		s.stopRefreshing()
+		s.stop = make(chan struct{})
+		go s.refresh(s.stop)
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ContainerProcessesWidget) Name() string {
+	return "ContainerProcessesWidget"
+}
+
+//RowCount returns the number of processes shown
+func (s *ContainerProcessesWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.top.Processes)
+}
+
+//Sort sorts the processes by the next column, processes are sorted by PID,
+//CPU usage, busiest first, or command
+func (s *ContainerProcessesWidget) Sort() {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = (s.sortMode + 1) % processSort(len(processSortNames))
+	s.buildLines()
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ContainerProcessesWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.stopRefreshing()
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ContainerProcessesWidget) fetchProcesses(ctx context.Context) (func(), error) {
+	top, err := s.dockerDaemon.Processes(s.containerID)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		s.top = top
+		s.buildLines()
+	}, nil
+}
+
+func (s *ContainerProcessesWidget) stopRefreshing() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+//refresh lists the processes again, periodically, until told to stop
+func (s *ContainerProcessesWidget) refresh(stop <-chan struct{}) {
+	ticker := time.NewTicker(processesRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.Lock()
+			if s.mounted {
+				s.loader.Load(s.fetchProcesses)
+			}
+			s.Unlock()
+		}
+	}
+}
+
+func (s *ContainerProcessesWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	return fmt.Sprintf("<b><blue> | Sorted by: </><yellow>%s</></> ", processSortNames[s.sortMode])
+}
+
+//buildLines sorts the processes and builds the lines of the widget from
+//them, the first line has the column titles
+func (s *ContainerProcessesWidget) buildLines() {
+	s.lines = nil
+	if len(s.top.Titles) == 0 {
+		return
+	}
+	sortProcesses(s.top, s.sortMode)
+	widths := make([]int, len(s.top.Titles))
+	for i, title := range s.top.Titles {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, proc := range s.top.Processes {
+		for i, value := range proc {
+			if n := utf8.RuneCountInString(value); i < len(widths) && n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	line := func(values []string) string {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			//the last column, the command, is not padded
+			if i < len(widths)-1 {
+				value = padRight(value, widths[i])
+			}
+			cells[i] = value
+		}
+		return strings.Join(cells, "  ")
+	}
+	s.lines = append(s.lines, line(s.top.Titles))
+	for _, proc := range s.top.Processes {
+		s.lines = append(s.lines, line(proc))
+	}
+}
+
+func (s *ContainerProcessesWidget) line(text string, y int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *ContainerProcessesWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.top.Processes) {
+		index = len(s.top.Processes) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//visibleLines returns the lines of the processes that fit on the widget
+func (s *ContainerProcessesWidget) visibleLines() []string {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.lines) < 2 {
+		return nil
+	}
+	processes := s.lines[1:]
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(processes)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(processes) {
+		end = len(processes)
+	}
+	return processes[s.startIndex:end]
+}
+
+//sortProcesses sorts the processes of the given list by the given column,
+//lists without the column are sorted by PID
+func sortProcesses(top container.ContainerTopOKBody, mode processSort) {
+	pid := processColumn(top, "PID")
+	var less func(a, b []string) bool
+	switch mode {
+	case processSortCPU:
+		if cpu := processColumn(top, "%CPU", "C"); cpu >= 0 {
+			less = func(a, b []string) bool {
+				return processNumber(a, cpu) > processNumber(b, cpu)
+			}
+		}
+	case processSortCommand:
+		if cmd := processColumn(top, "COMMAND", "CMD"); cmd >= 0 {
+			less = func(a, b []string) bool {
+				return processValue(a, cmd) < processValue(b, cmd)
+			}
+		}
+	}
+	if less == nil {
+		if pid < 0 {
+			return
+		}
+		less = func(a, b []string) bool {
+			return processNumber(a, pid) < processNumber(b, pid)
+		}
+	}
+	sort.SliceStable(top.Processes, func(i, j int) bool {
+		return less(top.Processes[i], top.Processes[j])
+	})
+}
+
+//processColumn returns the index of the first of the given columns the
+//process list has, -1 if it has none
+func processColumn(top container.ContainerTopOKBody, titles ...string) int {
+	for _, title := range titles {
+		for i, t := range top.Titles {
+			if t == title {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func processValue(proc []string, column int) string {
+	if column >= len(proc) {
+		return ""
+	}
+	return proc[column]
+}
+
+func processNumber(proc []string, column int) float64 {
+	n, _ := strconv.ParseFloat(processValue(proc, column), 64)
+	return n
+}
@@ -0,0 +1,83 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+//busyDaemon has a container running three processes
+type busyDaemon struct {
+	mocks.DockerDaemonMock
+}
+
+func (d *busyDaemon) Processes(id string) (container.ContainerTopOKBody, error) {
+	return container.ContainerTopOKBody{
+		Titles: []string{"USER", "PID", "%CPU", "COMMAND"},
+		Processes: [][]string{
+			{"root", "12", "0.5", "nginx: worker process"},
+			{"root", "1", "0.0", "nginx: master process"},
+			{"www", "7", "12.3", "php-fpm: pool www"},
+		},
+	}, nil
+}
+
+func TestContainerProcessesWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewContainerProcessesWidget(&busyDaemon{}, 0)
+	w.ForContainer("web", "web")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	if w.RowCount() != 3 {
+		t.Fatalf("Unexpected number of processes: %d", w.RowCount())
+	}
+	if w.lines[0] != "USER  PID  %CPU  COMMAND" {
+		t.Errorf("Unexpected titles line: %q", w.lines[0])
+	}
+	pids := func() string {
+		var pids []string
+		for _, line := range w.lines[1:] {
+			pids = append(pids, strings.Fields(line)[1])
+		}
+		return strings.Join(pids, ",")
+	}
+	if got := pids(); got != "1,7,12" {
+		t.Errorf("Processes are not sorted by PID: %s", got)
+	}
+	w.Sort()
+	if got := pids(); got != "7,12,1" {
+		t.Errorf("Processes are not sorted by CPU usage: %s", got)
+	}
+	w.Sort()
+	if got := pids(); got != "1,12,7" {
+		t.Errorf("Processes are not sorted by command: %s", got)
+	}
+	w.Sort()
+	if got := pids(); got != "1,7,12" {
+		t.Errorf("Sorting did not go back to PID: %s", got)
+	}
+}
+
+func TestSortProcesses_DefaultColumns(t *testing.T) {
+	top := container.ContainerTopOKBody{
+		Titles: []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+		Processes: [][]string{
+			{"root", "1", "0", "0", "10:00", "?", "00:00:01", "sh"},
+			{"root", "20", "1", "3", "10:00", "?", "00:00:09", "java"},
+		},
+	}
+	sortProcesses(top, processSortCPU)
+	if top.Processes[0][1] != "20" {
+		t.Errorf("Processes are not sorted by the C column: %v", top.Processes)
+	}
+}
@@ -2,7 +2,10 @@ package appui
 
 import (
 	"image"
+	"strconv"
+	"strings"
 
+	"github.com/docker/docker/api/types"
 	termui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/docker/formatter"
@@ -20,39 +23,78 @@ type ContainerRow struct {
 	ID        *drytermui.ParColumn
 	Image     *drytermui.ParColumn
 	Command   *drytermui.ParColumn
+	Created   *drytermui.ParColumn
 	Status    *drytermui.ParColumn
+	Health    *drytermui.ParColumn
+	Restarts  *drytermui.ParColumn
+	Restart   *drytermui.ParColumn
 	Ports     *drytermui.ParColumn
+	Address   *drytermui.ParColumn
 	Names     *drytermui.ParColumn
+	Size      *drytermui.ParColumn
+	Update    *drytermui.ParColumn
 	running   bool
+	outdated  bool
+	expanded  bool
+	//positions of the columns that can be expanded among the visible
+	//columns, -1 if hidden
+	commandColumn, portsColumn, namesColumn int
+	//untruncated values of the columns that can be expanded
+	command, ports, names string
+	allNames, allPorts    []string
 	drytermui.Row
 }
 
-//NewContainerRow creates a new ContainerRow widget
-func NewContainerRow(container *docker.Container, table drytermui.Table) *ContainerRow {
+//NewContainerRow creates a new ContainerRow widget, without the given hidden
+//columns
+func NewContainerRow(container *docker.Container, table drytermui.Table, hidden hiddenColumns) *ContainerRow {
 	cf := formatter.NewContainerFormatter(container, true)
+	full := formatter.NewContainerFormatter(container, false)
 
 	row := &ContainerRow{
 		container: container,
+		command:   full.Command(),
+		ports:     cf.Ports(),
+		names:     cf.Names(),
+		allNames:  strings.Split(full.Names(), ","),
+		allPorts:  formatter.AllPorts(container.Ports),
 		Indicator: drytermui.NewThemedParColumn(DryTheme, statusSymbol),
 		ID:        drytermui.NewThemedParColumn(DryTheme, cf.ID()),
 		Image:     drytermui.NewThemedParColumn(DryTheme, cf.Image()),
-		Command:   drytermui.NewThemedParColumn(DryTheme, cf.Command()),
+		Command:   drytermui.NewThemedParColumn(DryTheme, full.Command()),
+		Created:   drytermui.NewThemedParColumn(DryTheme, createdAgo(cf)),
 		Status:    drytermui.NewThemedParColumn(DryTheme, cf.Status()),
+		Health:    drytermui.NewThemedParColumn(DryTheme, docker.HealthStatus(container)),
+		Restarts:  drytermui.NewThemedParColumn(DryTheme, strconv.Itoa(docker.RestartCount(container))),
+		Restart:   drytermui.NewThemedParColumn(DryTheme, cf.RestartPolicy()),
 		Ports:     drytermui.NewThemedParColumn(DryTheme, cf.Ports()),
+		Address:   drytermui.NewThemedParColumn(DryTheme, cf.Address()),
 		Names:     drytermui.NewThemedParColumn(DryTheme, cf.Names()),
+		Size:      drytermui.NewThemedParColumn(DryTheme, ""),
+		Update:    drytermui.NewThemedParColumn(DryTheme, ""),
 	}
 	row.Height = 1
 	row.Table = table
 	//Columns are rendered following the slice order
-	row.Columns = []termui.GridBufferer{
+	row.Columns = hidden.visible(containerTableHeaders, []termui.GridBufferer{
 		row.Indicator,
 		row.ID,
 		row.Image,
 		row.Command,
+		row.Created,
 		row.Status,
+		row.Health,
+		row.Restarts,
+		row.Restart,
 		row.Ports,
+		row.Address,
 		row.Names,
-	}
+		row.Size,
+		row.Update,
+	})
+	row.commandColumn = hidden.index(containerTableHeaders, "COMMAND")
+	row.portsColumn = hidden.index(containerTableHeaders, "PORTS")
+	row.namesColumn = hidden.index(containerTableHeaders, "NAMES")
 	if !docker.IsContainerRunning(container) {
 		row.markAsNotRunning()
 	} else {
@@ -77,6 +119,75 @@ func (row *ContainerRow) Buffer() termui.Buffer {
 	return buf
 }
 
+//SetWidth sets the width of this row, the command, ports and names that do not
+//fit on their columns are cut unless the row is expanded
+func (row *ContainerRow) SetWidth(width int) {
+	if row.Table != nil {
+		widths := row.Table.ColumnWidths()
+		if len(widths) == len(row.Columns) {
+			row.layoutExpandableColumns(widths)
+		}
+	}
+	row.Row.SetWidth(width)
+}
+
+//Expanded returns true if this row shows the untruncated command, every port
+//mapping and every name of its container
+func (row *ContainerRow) Expanded() bool {
+	return row.expanded
+}
+
+//Expand shows the untruncated command, the port mappings and the names of the
+//container on as many lines as needed
+func (row *ContainerRow) Expand() {
+	row.expanded = true
+	row.SetWidth(row.Width)
+}
+
+//Collapse shows the container on a single line again
+func (row *ContainerRow) Collapse() {
+	row.expanded = false
+	row.SetWidth(row.Width)
+}
+
+//layoutExpandableColumns sets the text and the height of the command, ports
+//and names columns, given the width of each column of the row
+func (row *ContainerRow) layoutExpandableColumns(widths []int) {
+	columns := []struct {
+		par   *drytermui.ParColumn
+		text  string
+		lines []string
+		index int
+	}{
+		{row.Command, row.command, []string{row.command}, row.commandColumn},
+		{row.Ports, row.ports, row.allPorts, row.portsColumn},
+		{row.Names, row.names, row.allNames, row.namesColumn},
+	}
+	row.Height = 1
+	for _, c := range columns {
+		if c.index < 0 {
+			continue
+		}
+		if !row.expanded {
+			c.par.Text = truncateCell(c.text, widths[c.index])
+			c.par.Height = 1
+			continue
+		}
+		var lines []string
+		for _, line := range c.lines {
+			lines = append(lines, wrapCell(line, widths[c.index])...)
+		}
+		c.par.Text = strings.Join(lines, "\n")
+		c.par.Height = len(lines)
+		if c.par.Height == 0 {
+			c.par.Height = 1
+		}
+		if c.par.Height > row.Height {
+			row.Height = c.par.Height
+		}
+	}
+}
+
 //ColumnsForFilter returns the columns that are used to filter
 func (row *ContainerRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.ID, row.Image, row.Names, row.Command}
@@ -85,22 +196,56 @@ func (row *ContainerRow) ColumnsForFilter() []*drytermui.ParColumn {
 //Highlighted marks this rows as being highlighted
 func (row *ContainerRow) Highlighted() {
 	row.changeTextColor(
-		termui.Attribute(DryTheme.Fg),
+		termui.Attribute(DryTheme.CursorLineFg),
 		termui.Attribute(DryTheme.CursorLineBg))
 }
 
+//Marked shows this row as being marked
+func (row *ContainerRow) Marked() {
+	row.changeTextColor(
+		termui.Attribute(DryTheme.Selected),
+		termui.Attribute(DryTheme.Bg))
+}
+
 //NotHighlighted marks this rows as being not highlighted
 func (row *ContainerRow) NotHighlighted() {
 	var fg termui.Attribute
 	if !docker.IsContainerRunning(row.container) {
-		fg = inactiveRowColor
+		fg = inactiveRowColor()
 	} else {
 		fg = termui.Attribute(DryTheme.ListItem)
 	}
+	//rules style the row only when it is not highlighted, so that the
+	//cursor line is always visible
+	fg = styleByRowRules(row.container, fg)
 
 	row.changeTextColor(
 		fg,
 		termui.Attribute(DryTheme.Bg))
+	if row.outdated {
+		row.Update.TextFgColor = termui.ColorYellow
+	}
+	if fg, ok := healthColor(row.container); ok {
+		row.Health.TextFgColor = fg
+	}
+	if docker.IsContainerPaused(row.container) {
+		row.Status.TextFgColor = Paused
+	}
+}
+
+//setImageUpdate shows whether the image of the container is behind its registry version
+func (row *ContainerRow) setImageUpdate(status docker.ImageUpdateStatus) {
+	row.outdated = status == docker.ImageOutdated
+	row.Update.Text = status.String()
+	if row.outdated {
+		row.Update.Text = "\u2191 " + row.Update.Text
+		row.Update.TextFgColor = termui.ColorYellow
+	}
+}
+
+//setSize shows the given size of the container, empty until it is known
+func (row *ContainerRow) setSize(size string) {
+	row.Size.Text = size
 }
 
 func (row *ContainerRow) changeTextColor(fg, bg termui.Attribute) {
@@ -111,29 +256,77 @@ func (row *ContainerRow) changeTextColor(fg, bg termui.Attribute) {
 	row.Image.TextBgColor = bg
 	row.Command.TextFgColor = fg
 	row.Command.TextBgColor = bg
+	row.Created.TextFgColor = fg
+	row.Created.TextBgColor = bg
 	row.Status.TextFgColor = fg
 	row.Status.TextBgColor = bg
+	row.Health.TextFgColor = fg
+	row.Health.TextBgColor = bg
+	row.Restarts.TextFgColor = fg
+	row.Restarts.TextBgColor = bg
+	row.Restart.TextFgColor = fg
+	row.Restart.TextBgColor = bg
 	row.Ports.TextFgColor = fg
 	row.Ports.TextBgColor = bg
+	row.Address.TextFgColor = fg
+	row.Address.TextBgColor = bg
 	row.Names.TextFgColor = fg
 	row.Names.TextBgColor = bg
+	row.Size.TextFgColor = fg
+	row.Size.TextBgColor = bg
+	row.Update.TextFgColor = fg
+	row.Update.TextBgColor = bg
 }
 
 //markAsNotRunning
 func (row *ContainerRow) markAsNotRunning() {
 	row.Indicator.TextFgColor = NotRunning
-	row.ID.TextFgColor = inactiveRowColor
-	row.Image.TextFgColor = inactiveRowColor
-	row.Command.TextFgColor = inactiveRowColor
-	row.Status.TextFgColor = inactiveRowColor
-	row.Ports.TextFgColor = inactiveRowColor
-	row.Names.TextFgColor = inactiveRowColor
+	row.ID.TextFgColor = inactiveRowColor()
+	row.Image.TextFgColor = inactiveRowColor()
+	row.Command.TextFgColor = inactiveRowColor()
+	row.Created.TextFgColor = inactiveRowColor()
+	row.Status.TextFgColor = inactiveRowColor()
+	row.Health.TextFgColor = inactiveRowColor()
+	row.Restarts.TextFgColor = inactiveRowColor()
+	row.Restart.TextFgColor = inactiveRowColor()
+	row.Ports.TextFgColor = inactiveRowColor()
+	row.Address.TextFgColor = inactiveRowColor()
+	row.Names.TextFgColor = inactiveRowColor()
+	row.Size.TextFgColor = inactiveRowColor()
+	row.Update.TextFgColor = inactiveRowColor()
 	row.running = false
 }
 
 //markAsRunning
 func (row *ContainerRow) markAsRunning() {
 	row.Indicator.TextFgColor = Running
+	if docker.IsContainerPaused(row.container) {
+		row.Indicator.TextFgColor = Paused
+		row.Status.TextFgColor = Paused
+	}
+	if fg, ok := healthColor(row.container); ok {
+		row.Health.TextFgColor = fg
+	}
 	row.running = true
 
 }
+
+//createdAgo returns how long ago the container was created, as docker ps
+//shows it
+func createdAgo(cf *formatter.ContainerFormatter) string {
+	return cf.CreatedAt() + " ago"
+}
+
+//healthColor returns the color the health of the given container is shown
+//with, false if the container has no health to show
+func healthColor(c *docker.Container) (termui.Attribute, bool) {
+	switch docker.HealthStatus(c) {
+	case types.Healthy:
+		return termui.ColorGreen, true
+	case types.Unhealthy:
+		return termui.ColorRed, true
+	case types.Starting:
+		return termui.ColorYellow, true
+	}
+	return 0, false
+}
@@ -0,0 +1,144 @@
+package appui
+
+import (
+	"strings"
+
+	"github.com/moncho/dry/docker"
+)
+
+//ContainerRunWizard is a widget that asks, step by step, for the options to
+//create and run a container
+type ContainerRunWizard struct {
+	*wizard
+}
+
+//NewContainerRunWizard creates a ContainerRunWizard pre-filled with the given
+//options, the given image and network names are offered as completions
+func NewContainerRunWizard(options docker.RunOptions, images, networks []string) *ContainerRunWizard {
+	mode := docker.RunDetached
+	if options.Interactive {
+		mode = docker.RunInteractive
+	}
+	steps := []*wizardStep{
+		{
+			field:       docker.RunFieldImage,
+			label:       "Image",
+			help:        "Image to run, Tab completes from the local images",
+			value:       []rune(options.Image),
+			validate:    docker.ValidateImageName,
+			completions: images,
+		},
+		{
+			field:    docker.RunFieldName,
+			label:    "Name",
+			help:     "Container name, leave empty to let Docker pick one",
+			value:    []rune(options.Name),
+			validate: docker.ValidateContainerName,
+		},
+		{
+			field: docker.RunFieldCommand,
+			label: "Command",
+			help:  "Command to run, leave empty to run the image default",
+			value: []rune(options.Command),
+		},
+		{
+			field: docker.RunFieldPorts,
+			label: "Ports",
+			help:  "Comma separated port mappings, i.e. 8080:80, 127.0.0.1:53:53/udp",
+			value: []rune(strings.Join(options.Ports, ", ")),
+			validate: func(value string) error {
+				return docker.ValidatePorts(splitRunList(value))
+			},
+		},
+		{
+			field: docker.RunFieldEnv,
+			label: "Environment",
+			help:  "Comma separated environment variables, i.e. KEY=value, DEBUG=1",
+			value: []rune(strings.Join(options.Env, ", ")),
+			validate: func(value string) error {
+				return docker.ValidateEnv(splitRunList(value))
+			},
+		},
+		{
+			field: docker.RunFieldBinds,
+			label: "Volumes",
+			help:  "Comma separated volume binds, i.e. /host/path:/container/path:ro, volume:/data",
+			value: []rune(strings.Join(options.Binds, ", ")),
+			validate: func(value string) error {
+				return docker.ValidateBinds(splitRunList(value))
+			},
+		},
+		{
+			field:       docker.RunFieldNetwork,
+			label:       "Network",
+			help:        "Network to connect the container to, Tab completes from the existing ones",
+			value:       []rune(options.Network),
+			completions: networks,
+		},
+		{
+			field:       docker.RunFieldRestartPolicy,
+			label:       "Restart policy",
+			help:        "One of " + strings.Join(docker.RestartPolicies, ", ") + ", on-failure accepts a retry count (on-failure:3)",
+			value:       []rune(options.RestartPolicy),
+			validate:    docker.ValidateRestartPolicy,
+			completions: docker.RestartPolicies,
+		},
+		{
+			field:   docker.RunFieldMode,
+			label:   "Mode",
+			help:    "Detached runs in the background, interactive shows the container output",
+			value:   []rune(mode),
+			choices: []string{docker.RunDetached, docker.RunInteractive},
+		},
+	}
+	return &ContainerRunWizard{newWizard("docker run", steps, checkRunOptions)}
+}
+
+//Name returns the widget name
+func (w *ContainerRunWizard) Name() string {
+	return "ContainerRunWizard"
+}
+
+//Options returns the options entered on the wizard
+func (w *ContainerRunWizard) Options() docker.RunOptions {
+	w.RLock()
+	defer w.RUnlock()
+	return runOptions(w.values())
+}
+
+//checkRunOptions validates the options entered on the run wizard, it
+//returns the field whose value is not valid
+func checkRunOptions(values map[string]string) (string, error) {
+	if err := runOptions(values).Validate(); err != nil {
+		if runErr, ok := err.(*docker.RunOptionError); ok {
+			return runErr.Field, runErr.Err
+		}
+	}
+	return "", nil
+}
+
+//runOptions returns the run options with the given values of the run wizard
+func runOptions(values map[string]string) docker.RunOptions {
+	return docker.RunOptions{
+		Image:         values[docker.RunFieldImage],
+		Name:          values[docker.RunFieldName],
+		Command:       values[docker.RunFieldCommand],
+		Ports:         splitRunList(values[docker.RunFieldPorts]),
+		Env:           splitRunList(values[docker.RunFieldEnv]),
+		Binds:         splitRunList(values[docker.RunFieldBinds]),
+		Network:       values[docker.RunFieldNetwork],
+		RestartPolicy: values[docker.RunFieldRestartPolicy],
+		Interactive:   values[docker.RunFieldMode] == docker.RunInteractive,
+	}
+}
+
+//splitRunList splits the given comma separated list, empty elements are dropped
+func splitRunList(list string) []string {
+	var result []string
+	for _, element := range strings.Split(list, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			result = append(result, element)
+		}
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package appui
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func typedEvents(text string) []termbox.Event {
+	var events []termbox.Event
+	for _, r := range text {
+		events = append(events, termbox.Event{Type: termbox.EventKey, Ch: r})
+	}
+	return events
+}
+
+func keyEvent(k termbox.Key) termbox.Event {
+	return termbox.Event{Type: termbox.EventKey, Key: k}
+}
+
+func focusWizard(w interface{ OnFocus(ui.EventSource) error }, events ...[]termbox.Event) {
+	c := make(chan termbox.Event)
+	go func() {
+		defer close(c)
+		for _, e := range events {
+			for _, ev := range e {
+				c <- ev
+			}
+		}
+	}()
+	w.OnFocus(ui.EventSource{Events: c})
+	//drain what was not handled
+	for range c {
+	}
+}
+
+func TestContainerRunWizard_Steps(t *testing.T) {
+	w := NewContainerRunWizard(docker.RunOptions{}, []string{"nginx:latest", "redis:5"}, []string{"bridge", "host"})
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+	tab := []termbox.Event{keyEvent(termbox.KeyTab)}
+
+	focusWizard(w,
+		//image, name and command
+		typedEvents("ngi"), tab, enter,
+		typedEvents("web"), enter,
+		enter,
+		//ports, env and binds
+		typedEvents("8080:80,443"), enter,
+		typedEvents("KEY=value"), enter,
+		typedEvents("/srv:/srv:ro"), enter,
+		//network, restart policy and mode
+		typedEvents("ho"), tab, enter,
+		tab, tab, enter,
+		tab, enter)
+
+	if w.Canceled() {
+		t.Fatal("Wizard was canceled")
+	}
+	want := docker.RunOptions{
+		Image:         "nginx:latest",
+		Name:          "web",
+		Ports:         []string{"8080:80", "443"},
+		Env:           []string{"KEY=value"},
+		Binds:         []string{"/srv:/srv:ro"},
+		Network:       "host",
+		RestartPolicy: "always",
+		Interactive:   true,
+	}
+	if got := w.Options(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected options, got %+v, want %+v", got, want)
+	}
+}
+
+func TestContainerRunWizard_Validation(t *testing.T) {
+	w := NewContainerRunWizard(docker.RunOptions{Image: "nginx"}, nil, nil)
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+	down := []termbox.Event{keyEvent(termbox.KeyArrowDown)}
+
+	focusWizard(w, down, down, down, typedEvents("80:http"), enter, keyEvent(termbox.KeyEsc))
+
+	if !w.Canceled() {
+		t.Error("Wizard was not canceled")
+	}
+	if w.current != 3 || w.errField != docker.RunFieldPorts || w.err == nil {
+		t.Errorf("Invalid ports did not stop the wizard, step: %d, error on: %q", w.current, w.errField)
+	}
+}
+
+func TestContainerRunWizard_SetError(t *testing.T) {
+	w := NewContainerRunWizard(docker.RunOptions{Image: "nginx", Name: "web"}, nil, nil)
+	w.SetError(docker.RunFieldName, errors.New("name in use"))
+
+	if w.current != 1 {
+		t.Errorf("Wizard did not move to the failing field, step: %d", w.current)
+	}
+	lines, _ := w.lines()
+	if want := "<b>»</> <b><red>" + fmt.Sprintf("%-16s", "Name:") + "</></>web"; lines[1] != want {
+		t.Errorf("Failing field is not highlighted, got %q", lines[1])
+	}
+
+	//fixing the value and finishing the wizard
+	focusWizard(w,
+		[]termbox.Event{keyEvent(termbox.KeyBackspace2)},
+		typedEvents("2"),
+		[]termbox.Event{
+			keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEnter),
+			keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEnter), keyEvent(termbox.KeyEnter),
+		})
+	if w.Canceled() || w.err != nil {
+		t.Errorf("Wizard did not finish, canceled: %t, error: %v", w.Canceled(), w.err)
+	}
+	if name := w.Options().Name; name != "we2" {
+		t.Errorf("Unexpected name: %s", name)
+	}
+}
@@ -0,0 +1,77 @@
+package appui
+
+import (
+	"sync"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+)
+
+//containerSizes keeps the sizes of the containers of a list while the list
+//shows or is sorted by them. Sizes are expensive to retrieve, so they are
+//retrieved in the background, one retrieval at a time.
+type containerSizes struct {
+	daemon  docker.ContainerAPI
+	sizes   map[string]docker.ContainerSize
+	loading bool
+	sync.RWMutex
+}
+
+func newContainerSizes(daemon docker.ContainerAPI) *containerSizes {
+	return &containerSizes{daemon: daemon}
+}
+
+//load retrieves the sizes of the containers in the background, the sizes
+//retrieved before are kept until the new ones arrive. Nothing is done if
+//the sizes are already being retrieved.
+func (c *containerSizes) load() {
+	c.Lock()
+	defer c.Unlock()
+	if c.loading {
+		return
+	}
+	c.loading = true
+	go func() {
+		sizes, err := c.daemon.ContainerSizes()
+		c.Lock()
+		c.loading = false
+		if err == nil {
+			c.sizes = sizes
+		}
+		c.Unlock()
+		if err == nil {
+			RenderRequest()
+		}
+	}()
+}
+
+//forget drops the sizes retrieved, they are not shown anymore
+func (c *containerSizes) forget() {
+	c.Lock()
+	defer c.Unlock()
+	c.sizes = nil
+}
+
+//text returns the size of the container with the given id as it is shown,
+//empty if its size is not known
+func (c *containerSizes) text(id string) string {
+	c.RLock()
+	defer c.RUnlock()
+	size, ok := c.sizes[id]
+	if !ok {
+		return ""
+	}
+	return formatter.ContainerSize(size)
+}
+
+//value returns the size of the writable layer of the container with the
+//given id, -1 if its size is not known
+func (c *containerSizes) value(id string) int64 {
+	c.RLock()
+	defer c.RUnlock()
+	size, ok := c.sizes[id]
+	if !ok {
+		return -1
+	}
+	return size.RW
+}
@@ -0,0 +1,81 @@
+package appui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/moncho/dry/docker"
+)
+
+//Fields of the container update form
+const (
+	updateFieldCPUShares     = "cpu-shares"
+	updateFieldMemory        = "memory"
+	updateFieldRestartPolicy = "restart"
+)
+
+//ContainerUpdateForm is a widget that asks for the resource limits and the
+//restart policy of a container, the settings docker update changes
+type ContainerUpdateForm struct {
+	*wizard
+}
+
+//NewContainerUpdateForm creates a ContainerUpdateForm for the container with
+//the given name, pre-filled with the given limits
+func NewContainerUpdateForm(name string, limits docker.ContainerLimits) *ContainerUpdateForm {
+	shares := ""
+	if limits.CPUShares > 0 {
+		shares = strconv.FormatInt(limits.CPUShares, 10)
+	}
+	steps := []*wizardStep{
+		{
+			field: updateFieldCPUShares,
+			label: "CPU shares",
+			help:  "Relative CPU weight, 1024 is the default weight, 0 keeps the current one",
+			value: []rune(shares),
+			validate: func(value string) error {
+				_, err := docker.ParseCPUShares(value)
+				return err
+			},
+		},
+		{
+			field: updateFieldMemory,
+			label: "Memory limit",
+			help:  "Memory limit, i.e. 512m or 1g, Docker cannot remove a limit, leave it empty to keep the current one",
+			value: []rune(docker.FormatMemoryLimit(limits.Memory)),
+			validate: func(value string) error {
+				_, err := docker.ParseMemoryLimit(value)
+				return err
+			},
+		},
+		{
+			field:       updateFieldRestartPolicy,
+			label:       "Restart policy",
+			help:        "One of " + strings.Join(docker.RestartPolicies, ", ") + ", on-failure accepts a retry count (on-failure:3)",
+			value:       []rune(limits.RestartPolicy),
+			validate:    docker.ValidateRestartPolicy,
+			completions: docker.RestartPolicies,
+		},
+	}
+	return &ContainerUpdateForm{newWizard("docker update "+name, steps, nil)}
+}
+
+//Name returns the widget name
+func (w *ContainerUpdateForm) Name() string {
+	return "ContainerUpdateForm"
+}
+
+//Limits returns the limits entered on the form
+func (w *ContainerUpdateForm) Limits() docker.ContainerLimits {
+	w.RLock()
+	defer w.RUnlock()
+	values := w.values()
+	//values were validated on each step
+	shares, _ := docker.ParseCPUShares(values[updateFieldCPUShares])
+	memory, _ := docker.ParseMemoryLimit(values[updateFieldMemory])
+	return docker.ContainerLimits{
+		CPUShares:     shares,
+		Memory:        memory,
+		RestartPolicy: values[updateFieldRestartPolicy],
+	}
+}
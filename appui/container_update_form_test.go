@@ -0,0 +1,29 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestContainerUpdateForm(t *testing.T) {
+	w := NewContainerUpdateForm("web", docker.ContainerLimits{CPUShares: 512, RestartPolicy: "no"})
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+	clear := []termbox.Event{keyEvent(termbox.KeyBackspace2), keyEvent(termbox.KeyBackspace2)}
+
+	focusWizard(w,
+		//CPU shares are kept
+		enter,
+		//an invalid memory limit stops the form until it is fixed
+		typedEvents("lots"), enter, clear, clear, typedEvents("256m"), enter,
+		clear, typedEvents("always"), enter)
+
+	if w.Canceled() {
+		t.Fatal("Form was canceled")
+	}
+	want := docker.ContainerLimits{CPUShares: 512, Memory: 256 * 1024 * 1024, RestartPolicy: "always"}
+	if got := w.Limits(); got != want {
+		t.Errorf("Unexpected limits, got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package appui
+
+import (
+	"sync"
+
+	"github.com/moncho/dry/docker"
+)
+
+//containerUsage keeps the latest stats of the running containers of a list
+//while the list is sorted by resource usage, reading them from the stats
+//stream of each container
+type containerUsage struct {
+	daemon  docker.ContainerAPI
+	streams map[string]chan<- struct{} //done channel of the stream of each container
+	stats   map[string]*docker.Stats
+	sync.RWMutex
+}
+
+func newContainerUsage(daemon docker.ContainerAPI) *containerUsage {
+	return &containerUsage{
+		daemon:  daemon,
+		streams: make(map[string]chan<- struct{}),
+		stats:   make(map[string]*docker.Stats),
+	}
+}
+
+//follow streams the stats of the given containers that are running, the
+//streams of the containers not given are closed
+func (u *containerUsage) follow(containers []*docker.Container) {
+	u.Lock()
+	defer u.Unlock()
+	listed := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		if c == nil || !docker.IsContainerRunning(c) {
+			continue
+		}
+		listed[c.ID] = true
+		if _, ok := u.streams[c.ID]; ok {
+			continue
+		}
+		channel := u.daemon.OpenChannel(c)
+		if channel == nil || channel.Stats == nil {
+			continue
+		}
+		u.streams[c.ID] = channel.Done
+		go u.read(c.ID, channel)
+	}
+	for id := range u.streams {
+		if !listed[id] {
+			u.close(id)
+		}
+	}
+}
+
+//read keeps the stats received on the given channel as the latest of the
+//container with the given id
+func (u *containerUsage) read(id string, channel *docker.StatsChannel) {
+	//drained until closed so the stats stream never blocks
+	for stats := range channel.Stats {
+		u.Lock()
+		if u.streams[id] != channel.Done {
+			u.Unlock()
+			continue
+		}
+		u.stats[id] = stats
+		u.Unlock()
+		RenderRequest()
+	}
+	u.Lock()
+	if u.streams[id] == channel.Done {
+		//the container stopped
+		delete(u.streams, id)
+		delete(u.stats, id)
+	}
+	u.Unlock()
+}
+
+//stop closes every stream
+func (u *containerUsage) stop() {
+	u.Lock()
+	defer u.Unlock()
+	for id := range u.streams {
+		u.close(id)
+	}
+}
+
+//close closes the stream of the container with the given id, it must be
+//called with the lock held
+func (u *containerUsage) close(id string) {
+	close(u.streams[id])
+	delete(u.streams, id)
+	delete(u.stats, id)
+}
+
+//value returns the resource usage that the given mode sorts by of the
+//container with the given id, -1 if there are no stats of it
+func (u *containerUsage) value(id string, mode docker.SortMode) float64 {
+	u.RLock()
+	defer u.RUnlock()
+	return docker.UsageValue(u.stats[id], mode)
+}
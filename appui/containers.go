@@ -1,60 +1,117 @@
 package appui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
 	"github.com/moncho/dry/ui"
 	"github.com/moncho/dry/ui/termui"
 
 	gizaktermui "github.com/gizak/termui"
 )
 
-var defaultContainerTableHeader = containerTableHeader()
+//defaultHiddenContainerColumns are the columns of the container list that
+//are hidden unless the user shows them, sizes are expensive to retrieve
+var defaultHiddenContainerColumns = []string{"IP", "SIZE"}
+
+//containerRowsOverscan is the number of rows, before and after the
+//visible ones, that are built ahead of time so scrolling finds them ready
+const containerRowsOverscan = 5
 
 var containerTableHeaders = []SortableColumnHeader{
 	{``, docker.NoSort},
 	{`CONTAINER`, docker.SortByContainerID},
 	{`IMAGE`, docker.SortByImage},
 	{`COMMAND`, docker.NoSort},
+	{`CREATED`, docker.SortByCreated},
 	{`STATUS`, docker.SortByStatus},
+	{`HEALTH`, docker.SortByHealth},
+	{`RESTARTS`, docker.SortByRestarts},
+	{`RESTART`, docker.NoSort},
 	{`PORTS`, docker.NoSort},
+	{`IP`, docker.NoSort},
 	{`NAMES`, docker.SortByName},
+	{`SIZE`, docker.SortBySize},
+	{`UPDATE`, docker.NoSort},
+	//not columns, the list is sorted by the stats of the containers
+	{`CPU`, docker.SortByCPU},
+	{`MEM`, docker.SortByMemory},
 }
 
+//containerColumnWidths are the widths of the columns of the container table,
+//in the order of containerTableHeaders, 0 for the columns that share the
+//width left
+var containerColumnWidths = []int{2, 12, 0, 0, 18, 18, 10, 9, 14, 0, 0, 0, 22, 10}
+
 //ContainersWidget shows information containers
 type ContainersWidget struct {
 	dockerDaemon         docker.ContainerAPI
-	totalRows            []*ContainerRow
-	filteredRows         []*ContainerRow
+	totalRows            []*containerSummary
+	filteredRows         []*containerSummary
+	rowCache             map[string]*cachedContainerRow
+	rowCacheWidth        int
+	rowCacheTheme        string
+	rowCacheTruncation   TruncationMode
+	expandedID           string
+	imageUpdates         map[string]docker.ImageUpdate
+	selected             *docker.Container
+	onSelect             func(*docker.Container)
 	header               *termui.TableHeader
+	hidden               hiddenColumns
+	resize               columnResize
 	filterPattern        string
+	searchPattern        string
+	pendingSelection     string
 	selectedIndex        int
+	selectedID           string   //the container on the cursor on the last rendering
+	pinnedID             string   //the container the cursor is kept on, whatever happens to the list
+	marked               []string //ids of the marked containers, in the order they were marked
 	x, y                 int
 	height, width        int
+	fixedWidth           int
 	startIndex, endIndex int
 	sortMode             docker.SortMode
+	order                sortOrder
+	usage                *containerUsage
+	sizes                *containerSizes
 	mounted              bool
 	showAllContainers    bool
+	grouped              bool            //containers are listed under the compose project they belong to
+	collapsedProjects    map[string]bool //projects whose containers are not listed
+	loader               *AsyncLoader
+	autoRefresh          *AutoRefresh
 	sync.RWMutex
 }
 
-//NewContainersWidget creates a ContainersWidget
-func NewContainersWidget(dockerDaemon docker.ContainerAPI, y int) *ContainersWidget {
+//NewContainersWidget creates a ContainersWidget that starts filtered and
+//sorted as the given options tell, stopped containers are listed too if it
+//starts filtered by status
+func NewContainersWidget(dockerDaemon docker.ContainerAPI, y int, opts ListOptions) *ContainersWidget {
 	w := ContainersWidget{
 		dockerDaemon:      dockerDaemon,
 		y:                 y,
-		header:            defaultContainerTableHeader,
+		hidden:            newHiddenColumns(containerTableHeaders, defaultHiddenContainerColumns),
 		height:            MainScreenAvailableHeight(),
-		showAllContainers: false,
+		showAllContainers: strings.HasPrefix(opts.Filter, docker.StatusFilterPrefix),
 		sortMode:          docker.SortByContainerID,
 		width:             ui.ActiveScreen.Dimensions.Width}
+	w.header = containerTableHeader(w.hidden, containerColumnWidths)
+	w.filterPattern = opts.Filter
+	if opts.SortMode != docker.NoSort {
+		w.sortMode = opts.SortMode
+	}
+	w.usage = newContainerUsage(dockerDaemon)
+	w.sizes = newContainerSizes(dockerDaemon)
+	w.loader = NewAsyncLoader(&w)
 
-	RegisterWidget(docker.ContainerSource, &w)
+	dockerDaemon.OnContainerEvent(w.onContainerEvent)
 
 	return &w
 
@@ -72,10 +129,17 @@ func (s *ContainersWidget) Buffer() gizaktermui.Buffer {
 		var filter string
 		if s.filterPattern != "" {
 			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+				"<b><blue> | Active filter: </><yellow>%s</></> ", FilterText(s.filterPattern))
 		}
 
-		widgetHeader := WidgetHeader("Containers", s.RowCount(), filter)
+		if pinned := s.pinnedName(); pinned != "" {
+			filter += fmt.Sprintf("<b><blue> | Pinned: </><yellow>%s</></> ", pinned)
+		}
+		if len(s.marked) > 0 {
+			filter += fmt.Sprintf("<b><blue> | Marked: </><yellow>%d</></> ", len(s.marked))
+		}
+		widgetHeader := WidgetHeader("Containers", s.RowCount(),
+			filter+s.autoRefresh.headerDetails(time.Now())+s.loader.HeaderDetails())
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -88,51 +152,353 @@ func (s *ContainersWidget) Buffer() gizaktermui.Buffer {
 
 		selected := s.selectedIndex - s.startIndex
 
-		for i, containerRow := range s.visibleRows() {
+		for i, summary := range s.filteredRows[s.startIndex:s.endIndex] {
+			if summary.group != nil {
+				header := s.groupRow(summary.group, y, i == selected)
+				buf.Merge(header.Buffer())
+				y += header.GetHeight()
+				continue
+			}
+			containerRow := s.row(summary)
 			containerRow.SetY(y)
 			y += containerRow.GetHeight()
-			if i != selected {
-				containerRow.NotHighlighted()
-			} else {
+			if i == selected {
 				containerRow.Highlighted()
+			} else if s.isMarked(containerRow.container.ID) {
+				containerRow.Marked()
+			} else {
+				containerRow.NotHighlighted()
 			}
 			buf.Merge(containerRow.Buffer())
+			highlightMatches(buf, containerRow, s.searchPattern)
 		}
 	}
 	return buf
 }
 
-//Filter applies the given filter to the container list
+//Filter applies the given filter to the container list. Filtering by a
+//state other than running lists stopped containers too, or nothing would
+//match.
 func (s *ContainersWidget) Filter(filter string) {
 	s.Lock()
 	defer s.Unlock()
 	s.filterPattern = filter
+	if status, ok := docker.FilteredStatus(filter); ok && !strings.EqualFold(status, "running") && !s.showAllContainers {
+		s.showAllContainers = true
+		s.mounted = false
+	}
+}
+
+//Search moves the cursor to the first row, starting from the selected one,
+//that matches the given pattern. Matches are highlighted until the pattern
+//is cleared by searching for an empty one. Returns false if no row matches.
+func (s *ContainersWidget) Search(pattern string) bool {
+	s.Lock()
+	defer s.Unlock()
+	s.searchPattern = pattern
+	return s.jumpToMatch(ui.ActiveScreen.Cursor.Position(), true)
+}
+
+//SearchNext moves the cursor to the next row that matches the last pattern searched
+func (s *ContainersWidget) SearchNext() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.jumpToMatch(ui.ActiveScreen.Cursor.Position()+1, true)
+}
+
+//SearchPrevious moves the cursor to the previous row that matches the last pattern searched
+func (s *ContainersWidget) SearchPrevious() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.jumpToMatch(ui.ActiveScreen.Cursor.Position()-1, false)
+}
+
+//Select moves the cursor to the row of the container with the given id. The
+//container might not be on the list yet, the cursor is moved once it shows up
+//or, if it does not, the next time the list is loaded.
+func (s *ContainersWidget) Select(id string) {
+	s.Lock()
+	defer s.Unlock()
+	s.pendingSelection = id
+}
+
+//SetAutoRefresh sets the auto refresh state shown on the header, nil if
+//the list does not refresh on its own
+func (s *ContainersWidget) SetAutoRefresh(r *AutoRefresh) {
+	s.Lock()
+	defer s.Unlock()
+	s.autoRefresh = r
+}
 
+//Refreshing returns true until the list is mounted and loaded
+func (s *ContainersWidget) Refreshing() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return !s.mounted || s.loader.loading
 }
 
-//Mount tells this widget to be ready for rendering
+//TogglePin pins the container on the cursor, so the cursor stays on it
+//while the list changes, or unpins it if it is pinned. Returns the name of
+//the container pinned, empty if the container was unpinned.
+func (s *ContainersWidget) TogglePin() string {
+	s.Lock()
+	defer s.Unlock()
+	if s.pinnedID != "" {
+		s.pinnedID = ""
+		return ""
+	}
+	if s.selectedIndex < 0 || s.selectedIndex >= len(s.filteredRows) ||
+		s.filteredRows[s.selectedIndex].group != nil {
+		return ""
+	}
+	s.pinnedID = s.filteredRows[s.selectedIndex].container.ID
+	return s.pinnedName()
+}
+
+//Marked returns the ids of the marked containers, in the order they were marked
+func (s *ContainersWidget) Marked() []string {
+	s.RLock()
+	defer s.RUnlock()
+	return append([]string(nil), s.marked...)
+}
+
+//ToggleMark marks the selected container, so commands run on every marked
+//container, or unmarks it if it is already marked. On the header of a compose
+//project it marks the containers of the project, or unmarks them if all of
+//them are marked.
+func (s *ContainersWidget) ToggleMark() {
+	s.Lock()
+	defer s.Unlock()
+	if s.selectedIndex < 0 || s.selectedIndex >= len(s.filteredRows) {
+		return
+	}
+	if group := s.filteredRows[s.selectedIndex].group; group != nil {
+		ids := s.projectContainers(group.project)
+		all := true
+		for _, id := range ids {
+			all = all && s.isMarked(id)
+		}
+		for _, id := range ids {
+			if all || !s.isMarked(id) {
+				s.toggleMark(id)
+			}
+		}
+		return
+	}
+	s.toggleMark(s.filteredRows[s.selectedIndex].container.ID)
+}
+
+func (s *ContainersWidget) toggleMark(id string) {
+	for i, marked := range s.marked {
+		if marked == id {
+			s.marked = append(s.marked[:i], s.marked[i+1:]...)
+			return
+		}
+	}
+	s.marked = append(s.marked, id)
+}
+
+//ClearMarks unmarks every container
+func (s *ContainersWidget) ClearMarks() {
+	s.Lock()
+	defer s.Unlock()
+	s.marked = nil
+}
+
+//ToggleExpanded expands the selected row, so it shows the untruncated command,
+//every port mapping and every name of its container, or collapses it if it
+//is already expanded. Expanded rows collapse when the selection moves. On the
+//header of a compose project it hides the containers of the project, or
+//lists them again.
+func (s *ContainersWidget) ToggleExpanded() {
+	s.Lock()
+	defer s.Unlock()
+	if s.selectedIndex < 0 || s.selectedIndex >= len(s.filteredRows) {
+		return
+	}
+	if group := s.filteredRows[s.selectedIndex].group; group != nil {
+		if s.collapsedProjects == nil {
+			s.collapsedProjects = make(map[string]bool)
+		}
+		s.collapsedProjects[group.project] = !s.collapsedProjects[group.project]
+		return
+	}
+	id := s.filteredRows[s.selectedIndex].container.ID
+	expanded := s.expandedID == id
+	s.collapse()
+	if !expanded {
+		s.expandedID = id
+		s.row(s.filteredRows[s.selectedIndex]).Expand()
+	}
+}
+
+//OnSelectionChange sets the func called when the container on the cursor
+//changes, either because the cursor moved or because the container was
+//updated. It is called, with nil if the list is empty, while the widget is
+//being rendered so it must not block nor use the widget.
+func (s *ContainersWidget) OnSelectionChange(f func(*docker.Container)) {
+	s.Lock()
+	defer s.Unlock()
+	s.onSelect = f
+	s.selected = nil
+}
+
+//SetWidth sets the width of the widget, 0 to use the width of the screen
+func (s *ContainersWidget) SetWidth(width int) {
+	s.Lock()
+	defer s.Unlock()
+	s.fixedWidth = width
+}
+
+//Containers returns the containers on the list, filtered as shown
+func (s *ContainersWidget) Containers() []*docker.Container {
+	s.RLock()
+	defer s.RUnlock()
+	containers := make([]*docker.Container, 0, len(s.filteredRows))
+	for _, row := range s.filteredRows {
+		if row.group == nil {
+			containers = append(containers, row.container)
+		}
+	}
+	return containers
+}
+
+//ToggleGrouping lists the containers under the compose project they belong
+//to, the containers that do not belong to a project go last, or lists them
+//all together again. Returns true if the containers are grouped.
+func (s *ContainersWidget) ToggleGrouping() bool {
+	s.Lock()
+	defer s.Unlock()
+	s.grouped = !s.grouped
+	s.collapse()
+	return s.grouped
+}
+
+//SelectedProject returns the compose project whose header is on the cursor,
+//false if the cursor is on a container
+func (s *ContainersWidget) SelectedProject() (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.selectedIndex < 0 || s.selectedIndex >= len(s.filteredRows) {
+		return "", false
+	}
+	if group := s.filteredRows[s.selectedIndex].group; group != nil {
+		return group.project, true
+	}
+	return "", false
+}
+
+//ProjectContainers returns the ids of the listed containers that belong to
+//the given compose project, those of a collapsed project too. The empty
+//project returns the containers that do not belong to a project.
+func (s *ContainersWidget) ProjectContainers(project string) []string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.projectContainers(project)
+}
+
+//SetImageUpdate sets whether the image of a container is behind its registry
+//version, it is shown on the UPDATE column of its row
+func (s *ContainersWidget) SetImageUpdate(update docker.ImageUpdate) {
+	s.Lock()
+	defer s.Unlock()
+	if s.imageUpdates == nil {
+		s.imageUpdates = make(map[string]docker.ImageUpdate)
+	}
+	s.imageUpdates[update.ContainerID] = update
+}
+
+//UpdateContainer replaces the container shown on the list with the given one,
+//its row is rebuilt if what it shows has changed without reloading the list
+func (s *ContainersWidget) UpdateContainer(c *docker.Container) {
+	s.Lock()
+	defer s.Unlock()
+	for i, summary := range s.totalRows {
+		if summary.container.ID == c.ID {
+			s.totalRows[i] = newContainerSummary(c)
+			return
+		}
+	}
+}
+
+//Mount tells this widget to be ready for rendering. The container list is
+//loaded in the background, the list loaded before is shown until it arrives.
 func (s *ContainersWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
+		s.mounted = true
+		s.load()
+	}
+	return s.loader.Err()
+}
 
-		var filters []docker.ContainerFilter
-		if s.showAllContainers {
-			filters = append(filters, docker.ContainerFilters.Unfiltered())
-		} else {
-			filters = append(filters, docker.ContainerFilters.Running())
-		}
-		dockerContainers := s.dockerDaemon.Containers(filters, s.sortMode)
+//load loads the container list in the background
+func (s *ContainersWidget) load() {
+	var filters []docker.ContainerFilter
+	if s.showAllContainers {
+		filters = append(filters, docker.ContainerFilters.Unfiltered())
+	} else {
+		filters = append(filters, docker.ContainerFilters.Running())
+	}
+	sortMode := s.sortMode
+	s.loader.Load(func(ctx context.Context) (func(), error) {
+		dockerContainers := s.dockerDaemon.Containers(filters, sortMode)
 
-		rows := make([]*ContainerRow, len(dockerContainers))
+		summaries := make([]*containerSummary, len(dockerContainers))
 		for i, container := range dockerContainers {
-			rows[i] = NewContainerRow(container, s.header)
+			summaries[i] = newContainerSummary(container)
 		}
-		s.totalRows = rows
-		s.mounted = true
-		s.align()
+		return func() {
+			s.totalRows = summaries
+			s.followUsage()
+			s.followSizes()
+			s.forgetRemovedMarks()
+			s.pruneRowCache()
+			s.align()
+		}, nil
+	})
+}
+
+//onContainerEvent updates the row of the container of the given event, the
+//container is added to the list or removed from it if, after the change, it
+//is no longer shown, without reloading the list. The row keeps the cursor,
+//if it was on it, and its place on the sorted list is found on rendering.
+func (s *ContainersWidget) onContainerEvent(event docker.ContainerEvent) {
+	s.Lock()
+	if s.loader.loading {
+		//the load in flight may have missed the change
+		s.load()
+		s.Unlock()
+		return
 	}
-	return nil
+	listed := event.Container != nil &&
+		(s.showAllContainers || docker.IsContainerRunning(event.Container))
+	rows := make([]*containerSummary, 0, len(s.totalRows)+1)
+	found := false
+	for _, summary := range s.totalRows {
+		if summary.container.ID != event.ID {
+			rows = append(rows, summary)
+			continue
+		}
+		found = true
+		if listed {
+			rows = append(rows, newContainerSummary(event.Container))
+		}
+	}
+	if !found {
+		if !listed {
+			s.Unlock()
+			return
+		}
+		rows = append(rows, newContainerSummary(event.Container))
+	}
+	s.totalRows = rows
+	s.followUsage()
+	s.forgetRemovedMarks()
+	s.pruneRowCache()
+	s.Unlock()
+	RenderRequest()
 }
 
 //Name returns this widget name
@@ -143,6 +509,9 @@ func (s *ContainersWidget) Name() string {
 //OnEvent runs the given command
 func (s *ContainersWidget) OnEvent(event EventCommand) error {
 	if s.RowCount() > 0 {
+		if group := s.filteredRows[s.selectedIndex].group; group != nil {
+			return fmt.Errorf("%s is a compose project, select one of its containers", group.title())
+		}
 		return event(s.filteredRows[s.selectedIndex].container.ID)
 	}
 	return errors.New("The container list is empty")
@@ -153,8 +522,24 @@ func (s *ContainersWidget) RowCount() int {
 	return len(s.filteredRows)
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *ContainersWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *ContainersWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return SortModeTitle(containerTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
-//SortByContainerID -> SortByImage -> SortByStatus -> SortByName -> SortByContainerID
+//SortByContainerID -> SortByImage -> SortByStatus -> SortByName -> SortByCreated ->
+//SortByHealth -> SortByRestarts -> SortByCPU -> SortByMemory -> SortBySize ->
+//SortByContainerID
 func (s *ContainersWidget) Sort() {
 	s.Lock()
 	defer s.Unlock()
@@ -166,9 +551,169 @@ func (s *ContainersWidget) Sort() {
 	case docker.SortByStatus:
 		s.sortMode = docker.SortByName
 	case docker.SortByName:
+		s.sortMode = docker.SortByCreated
+	case docker.SortByCreated:
+		s.sortMode = docker.SortByHealth
+	case docker.SortByHealth:
+		s.sortMode = docker.SortByRestarts
+	case docker.SortByRestarts:
+		s.sortMode = docker.SortByCPU
+	case docker.SortByCPU:
+		s.sortMode = docker.SortByMemory
+	case docker.SortByMemory:
+		s.sortMode = docker.SortBySize
+	case docker.SortBySize:
 		s.sortMode = docker.SortByContainerID
 	default:
 	}
+	s.followUsage()
+	s.followSizes()
+}
+
+//followUsage streams the stats of the running containers of the list if it
+//is sorted by resource usage, and stops streaming them if not
+func (s *ContainersWidget) followUsage() {
+	if s.usage == nil {
+		return
+	}
+	if !docker.IsUsageSort(s.sortMode) {
+		s.usage.stop()
+		return
+	}
+	containers := make([]*docker.Container, len(s.totalRows))
+	for i, summary := range s.totalRows {
+		containers[i] = summary.container
+	}
+	s.usage.follow(containers)
+}
+
+//followSizes retrieves the sizes of the containers if the list shows them
+//or is sorted by them, and forgets them if not
+func (s *ContainersWidget) followSizes() {
+	if s.sizes == nil {
+		return
+	}
+	if s.hidden["SIZE"] && s.sortMode != docker.SortBySize {
+		s.sizes.forget()
+		return
+	}
+	s.sizes.load()
+}
+
+//ReverseSort switches the order of the column the list is sorted by
+//between ascending and descending, each column keeps its own order
+func (s *ContainersWidget) ReverseSort() {
+	s.Lock()
+	defer s.Unlock()
+	s.order.Reverse(s.sortMode)
+}
+
+//SortDescending returns true if the list is sorted in descending order
+func (s *ContainersWidget) SortDescending() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.order.Descending(s.sortMode)
+}
+
+//SortMode returns the sort mode of the list
+func (s *ContainersWidget) SortMode() docker.SortMode {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortMode
+}
+
+//SetSortMode sorts the list with the given sort mode
+func (s *ContainersWidget) SetSortMode(mode docker.SortMode) {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = mode
+	s.followUsage()
+	s.followSizes()
+}
+
+//Columns returns the titles of the columns of the list that can be hidden
+func (s *ContainersWidget) Columns() []string {
+	return columnTitles(containerTableHeaders, len(containerColumnWidths))
+}
+
+//HiddenColumns returns the titles of the hidden columns of the list
+func (s *ContainersWidget) HiddenColumns() []string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.hidden.titles(containerTableHeaders)
+}
+
+//SetHiddenColumns hides the columns of the list with the given titles and
+//shows the rest, the titles that are not columns of the list are ignored
+func (s *ContainersWidget) SetHiddenColumns(titles []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.hidden = newHiddenColumns(containerTableHeaders[:len(containerColumnWidths)], titles)
+	s.resize.focus(s.columnTitles(), 0)
+	s.layoutColumns()
+	s.followSizes()
+}
+
+//ToggleColumnResize starts resizing the columns of the list, the first one
+//first, or stops resizing them. Returns true if they are being resized.
+func (s *ContainersWidget) ToggleColumnResize() bool {
+	s.Lock()
+	defer s.Unlock()
+	resizing := s.resize.toggle(s.columnTitles())
+	s.header.HighlightColumn(s.resize.column())
+	return resizing
+}
+
+//FocusColumn moves the column being resized to the next one if delta is
+//positive, to the previous one if negative
+func (s *ContainersWidget) FocusColumn(delta int) {
+	s.Lock()
+	defer s.Unlock()
+	s.resize.focus(s.columnTitles(), delta)
+	s.header.HighlightColumn(s.resize.column())
+}
+
+//ResizeColumn grows the column being resized by the given delta, shrinks it
+//if negative. Returns the title of the column and its new width, the width
+//is kept for as long as dry runs.
+func (s *ContainersWidget) ResizeColumn(delta int) (string, int) {
+	s.Lock()
+	defer s.Unlock()
+	title := s.resize.resize(s.columnTitles(), s.header.ColumnWidths(), delta, s.width)
+	if title == "" {
+		return "", 0
+	}
+	s.layoutColumns()
+	return title, s.resize.widths[title]
+}
+
+//ResizingColumn returns the title of the column being resized, empty if
+//the columns are not being resized
+func (s *ContainersWidget) ResizingColumn() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.resize.title(s.columnTitles())
+}
+
+//columnTitles returns the titles of the visible columns of the list
+func (s *ContainersWidget) columnTitles() []string {
+	return s.hidden.visibleTitles(containerTableHeaders[:len(containerColumnWidths)])
+}
+
+//layoutColumns builds the header of the list again, with the visible columns
+//and their widths, rows are built again with the new header
+func (s *ContainersWidget) layoutColumns() {
+	s.header = containerTableHeader(s.hidden, s.resize.apply(containerTableHeaders, containerColumnWidths))
+	s.header.HighlightColumn(s.resize.column())
+	s.rowCache = nil
+	s.align()
+}
+
+//ShowAllContainers returns true if stopped containers are listed too
+func (s *ContainersWidget) ShowAllContainers() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.showAllContainers
 }
 
 //ToggleShowAllContainers toggles the show-all-containers state
@@ -185,31 +730,204 @@ func (s *ContainersWidget) Unmount() error {
 	s.Lock()
 	defer s.Unlock()
 	s.mounted = false
+	s.loader.Cancel()
 	return nil
 }
 
-//Align aligns rows
+//Align aligns the table header, rows are aligned when built
 func (s *ContainersWidget) align() {
-	x := s.x
-	width := s.width
+	s.header.SetWidth(s.width)
+	s.header.SetX(s.x)
+}
+
+//row returns the row that shows the container of the given summary. Rows
+//are built on demand and cached until the container changes.
+func (s *ContainersWidget) row(summary *containerSummary) *ContainerRow {
+	if s.rowCache == nil {
+		s.rowCache = make(map[string]*cachedContainerRow)
+	}
+	id := summary.container.ID
+	if cached, ok := s.rowCache[id]; ok && cached.columns == summary.columns {
+		return cached.row
+	}
+	row := NewContainerRow(summary.container, s.header, s.hidden)
+	row.setImageUpdate(summary.columns.update)
+	row.setSize(summary.columns.size)
+	row.SetX(s.x)
+	row.SetWidth(s.width)
+	if id == s.expandedID {
+		row.Expand()
+	}
+	s.rowCache[id] = &cachedContainerRow{summary.columns, row}
+	return row
+}
 
-	s.header.SetWidth(width)
-	s.header.SetX(x)
+//buildRows makes sure that the visible rows, and the ones around them,
+//are built
+func (s *ContainersWidget) buildRows() {
+	start := s.startIndex - containerRowsOverscan
+	if start < 0 {
+		start = 0
+	}
+	end := s.endIndex + containerRowsOverscan
+	if end > len(s.filteredRows) {
+		end = len(s.filteredRows)
+	}
+	for _, summary := range s.filteredRows[start:end] {
+		if summary.group == nil {
+			s.row(summary)
+		}
+	}
+}
 
-	for _, container := range s.totalRows {
-		container.SetX(x)
-		container.SetWidth(width)
+//collapse collapses the expanded row, if any
+func (s *ContainersWidget) collapse() {
+	if s.expandedID == "" {
+		return
+	}
+	if cached, ok := s.rowCache[s.expandedID]; ok {
+		cached.row.Collapse()
 	}
+	s.expandedID = ""
+}
 
+//expandedRowHeight returns the height of the selected row if it is expanded, 1 otherwise
+func (s *ContainersWidget) expandedRowHeight() int {
+	if s.expandedID == "" || s.selectedIndex < 0 || s.selectedIndex >= len(s.filteredRows) {
+		return 1
+	}
+	summary := s.filteredRows[s.selectedIndex]
+	if summary.id() != s.expandedID {
+		return 1
+	}
+	return s.row(summary).GetHeight()
+}
+
+//pruneRowCache removes from the cache the rows of the containers that are
+//gone or have changed
+func (s *ContainersWidget) pruneRowCache() {
+	if len(s.rowCache) == 0 {
+		return
+	}
+	cache := make(map[string]*cachedContainerRow)
+	for _, summary := range s.totalRows {
+		id := summary.container.ID
+		if cached, ok := s.rowCache[id]; ok && cached.columns == summary.columns {
+			cache[id] = cached
+		}
+	}
+	s.rowCache = cache
+}
+
+//jumpToMatch moves the cursor to the row that matches the search pattern,
+//searching from the given index in the given direction
+func (s *ContainersWidget) jumpToMatch(from int, forward bool) bool {
+	if s.searchPattern == "" {
+		return false
+	}
+	index := searchRows(len(s.filteredRows), func(i int) bool {
+		return s.filteredRows[i].matches(s.searchPattern)
+	}, from, forward)
+	if index < 0 {
+		return false
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(index)
+	return true
+}
+
+//selectPending moves the cursor to the row of the container to select, if any
+func (s *ContainersWidget) selectPending() {
+	if s.pendingSelection == "" {
+		return
+	}
+	for i, row := range s.filteredRows {
+		if row.id() == s.pendingSelection {
+			ui.ActiveScreen.Cursor.ScrollTo(i)
+			s.pendingSelection = ""
+			return
+		}
+	}
+	if !s.loader.loading {
+		s.pendingSelection = ""
+	}
+}
+
+//followSelection moves the cursor to the row of the container it was on,
+//or of the pinned container, if sorting, filtering or reloading the list
+//moved the row. Unless a container is pinned, the cursor is left where it
+//is if the user moved it. If the container is gone the cursor stays on
+//the nearest row.
+func (s *ContainersWidget) followSelection() {
+	cursor := ui.ActiveScreen.Cursor
+	id := s.pinnedID
+	if id == "" {
+		if cursor.Position() != s.selectedIndex {
+			return
+		}
+		id = s.selectedID
+	}
+	if id == "" {
+		return
+	}
+	for i, row := range s.filteredRows {
+		if row.id() == id {
+			if i != cursor.Position() {
+				cursor.ScrollTo(i)
+			}
+			return
+		}
+	}
+	if s.pinnedID != "" && !s.loader.loading {
+		s.pinnedID = ""
+	}
+	if count := len(s.filteredRows); count > 0 && cursor.Position() >= count {
+		cursor.ScrollTo(count - 1)
+	}
+}
+
+//pinnedName returns the name of the pinned container, empty if there is none
+func (s *ContainersWidget) pinnedName() string {
+	if s.pinnedID == "" {
+		return ""
+	}
+	for _, row := range s.totalRows {
+		if row.container.ID == s.pinnedID && len(row.container.Names) > 0 {
+			return strings.TrimPrefix(row.container.Names[0], "/")
+		}
+	}
+	return docker.TruncateID(s.pinnedID)
+}
+
+func (s *ContainersWidget) isMarked(id string) bool {
+	for _, marked := range s.marked {
+		if marked == id {
+			return true
+		}
+	}
+	return false
+}
+
+//forgetRemovedMarks unmarks the containers that are no longer listed
+func (s *ContainersWidget) forgetRemovedMarks() {
+	var marked []string
+	for _, id := range s.marked {
+		for _, row := range s.totalRows {
+			if row.container.ID == id {
+				marked = append(marked, id)
+				break
+			}
+		}
+	}
+	s.marked = marked
 }
 
 func (s *ContainersWidget) filterRows() {
 
 	if s.filterPattern != "" {
-		var rows []*ContainerRow
+		var rows []*containerSummary
 
 		for _, row := range s.totalRows {
-			if RowFilters.ByPattern(s.filterPattern)(row) {
+			if row.matches(s.filterPattern) {
 				rows = append(rows, row)
 			}
 		}
@@ -217,13 +935,119 @@ func (s *ContainersWidget) filterRows() {
 	} else {
 		s.filteredRows = s.totalRows
 	}
+	if s.grouped {
+		s.filteredRows = s.groupRows(s.filteredRows)
+	}
+}
+
+//groupRows returns the given rows under the header of the compose project
+//they belong to, projects sorted by name and the rows that do not belong to
+//a project last. The rows of collapsed projects are left out, unless the
+//container to select or the pinned one is among them.
+func (s *ContainersWidget) groupRows(rows []*containerSummary) []*containerSummary {
+	groups := make(map[string]*containerGroup)
+	members := make(map[string][]*containerSummary)
+	var projects []string
+	for _, row := range rows {
+		group, ok := groups[row.project]
+		if !ok {
+			group = &containerGroup{project: row.project}
+			groups[row.project] = group
+			projects = append(projects, row.project)
+		}
+		group.containers++
+		if row.columns.running {
+			group.running++
+		}
+		if id := row.container.ID; id == s.pendingSelection || id == s.pinnedID {
+			delete(s.collapsedProjects, row.project)
+		}
+		members[row.project] = append(members[row.project], row)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i] == "" || projects[j] == "" {
+			return projects[j] == ""
+		}
+		return projects[i] < projects[j]
+	})
+	grouped := make([]*containerSummary, 0, len(rows)+len(projects))
+	for _, project := range projects {
+		group := groups[project]
+		group.collapsed = s.collapsedProjects[project]
+		grouped = append(grouped, &containerSummary{group: group})
+		if !group.collapsed {
+			grouped = append(grouped, members[project]...)
+		}
+	}
+	return grouped
+}
+
+//projectContainers returns the ids of the listed containers that belong to
+//the given compose project
+func (s *ContainersWidget) projectContainers(project string) []string {
+	var ids []string
+	for _, row := range s.totalRows {
+		if row.project == project && (s.filterPattern == "" || row.matches(s.filterPattern)) {
+			ids = append(ids, row.container.ID)
+		}
+	}
+	return ids
+}
+
+//groupRow returns the row that shows the header of the given group at the
+//given position
+func (s *ContainersWidget) groupRow(group *containerGroup, y int, selected bool) *termui.MarkupPar {
+	sign := "-"
+	if group.collapsed {
+		sign = "+"
+	}
+	text := fmt.Sprintf("<b>%s</> <b><yellow>%s</></> <darkgrey>%d containers, %d running</>",
+		sign, group.title(), group.containers, group.running)
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.ListItem)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
 }
 
 //prepareForRendering sets the internal state of this widget so it is ready for
 //rendering(i.e. Buffer()).
 func (s *ContainersWidget) prepareForRendering() {
+	width := ui.ActiveScreen.Dimensions.Width
+	if s.fixedWidth > 0 && s.fixedWidth < width {
+		width = s.fixedWidth
+	}
+	if width != s.width {
+		s.width = width
+		s.align()
+	}
+	if s.rowCacheWidth != s.width || s.rowCacheTheme != DryTheme.Name ||
+		s.rowCacheTruncation != truncationMode {
+		s.rowCache = nil
+		s.rowCacheWidth = s.width
+		s.rowCacheTheme = DryTheme.Name
+		s.rowCacheTruncation = truncationMode
+	}
 	s.sortRows()
+	for _, summary := range s.totalRows {
+		summary.columns.update = s.imageUpdates[summary.container.ID].Status
+		if s.sizes != nil {
+			summary.columns.size = s.sizes.text(summary.container.ID)
+		}
+	}
 	s.filterRows()
+	s.selectPending()
+	s.followSelection()
 	index := ui.ActiveScreen.Cursor.Position()
 	if index < 0 {
 		index = 0
@@ -231,18 +1055,41 @@ func (s *ContainersWidget) prepareForRendering() {
 		index = s.RowCount() - 1
 	}
 	s.selectedIndex = index
+	s.selectedID = ""
+	if index >= 0 && index < len(s.filteredRows) {
+		s.selectedID = s.filteredRows[index].id()
+	}
+	s.notifySelection()
+	if s.expandedID != "" &&
+		(index >= len(s.filteredRows) || s.filteredRows[index].id() != s.expandedID) {
+		s.collapse()
+	}
 	s.calculateVisibleRows()
+	s.buildRows()
+}
+
+//notifySelection tells the selection listener, if any, about the container
+//on the cursor if it is not the one it was told about last time
+func (s *ContainersWidget) notifySelection() {
+	if s.onSelect == nil {
+		return
+	}
+	var selected *docker.Container
+	if s.selectedIndex >= 0 && s.selectedIndex < len(s.filteredRows) {
+		selected = s.filteredRows[s.selectedIndex].container
+	}
+	if selected != s.selected {
+		s.selected = selected
+		s.onSelect(selected)
+	}
 }
 
 func (s *ContainersWidget) updateTableHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := ColumnTitle(c.Text)
 		var header SortableColumnHeader
-		if strings.Contains(colTitle, DownArrow) {
-			colTitle = colTitle[DownArrowLength:]
-		}
 		for _, h := range containerTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -250,7 +1097,7 @@ func (s *ContainersWidget) updateTableHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = DownArrow + colTitle
+			c.Text = SortColumnTitle(colTitle, s.order.Descending(sortMode))
 		} else {
 			c.Text = colTitle
 		}
@@ -270,27 +1117,89 @@ func (s *ContainersWidget) sortRows() {
 	switch mode {
 	case docker.SortByContainerID:
 		sortAlg = func(i, j int) bool {
-			return rows[i].ID.Text < rows[j].ID.Text
+			return rows[i].columns.id < rows[j].columns.id
 		}
 	case docker.SortByImage:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Image.Text < rows[j].Image.Text
+			return rows[i].columns.image < rows[j].columns.image
 		}
 	case docker.SortByStatus:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Status.Text < rows[j].Status.Text
+			return rows[i].columns.status < rows[j].columns.status
 		}
 	case docker.SortByName:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Names.Text < rows[j].Names.Text
+			return rows[i].columns.names < rows[j].columns.names
+		}
+	case docker.SortByCreated:
+		//the newest first
+		sortAlg = func(i, j int) bool {
+			a, b := rows[i].container.Created, rows[j].container.Created
+			if a != b {
+				return a > b
+			}
+			return rows[i].columns.names < rows[j].columns.names
+		}
+	case docker.SortByHealth:
+		//the unhealthy first, containers without healthcheck last
+		sortAlg = func(i, j int) bool {
+			a, b := docker.HealthRank(rows[i].columns.health), docker.HealthRank(rows[j].columns.health)
+			if a != b {
+				return a < b
+			}
+			return rows[i].columns.names < rows[j].columns.names
+		}
+	case docker.SortByRestarts:
+		//the most restarted first
+		sortAlg = func(i, j int) bool {
+			a, b := rows[i].columns.restarts, rows[j].columns.restarts
+			if a != b {
+				return a > b
+			}
+			return rows[i].columns.names < rows[j].columns.names
+		}
+	case docker.SortByCPU, docker.SortByMemory:
+		//the busiest first, containers without stats last
+		usage := make(map[string]float64, len(rows))
+		for _, row := range rows {
+			usage[row.container.ID] = s.usage.value(row.container.ID, mode)
+		}
+		sortAlg = func(i, j int) bool {
+			a, b := usage[rows[i].container.ID], usage[rows[j].container.ID]
+			if a != b {
+				return a > b
+			}
+			return rows[i].columns.names < rows[j].columns.names
+		}
+	case docker.SortBySize:
+		//the largest first, containers whose size is not known last
+		sizes := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			sizes[row.container.ID] = s.sizes.value(row.container.ID)
+		}
+		sortAlg = func(i, j int) bool {
+			a, b := sizes[rows[i].container.ID], sizes[rows[j].container.ID]
+			if a != b {
+				return a > b
+			}
+			return rows[i].columns.names < rows[j].columns.names
 		}
 
 	}
-	sort.SliceStable(rows, sortAlg)
+	sort.SliceStable(rows, s.order.less(mode, sortAlg))
 }
 
+//visibleRows returns the rows of the visible containers, the headers of
+//compose projects are left out
 func (s *ContainersWidget) visibleRows() []*ContainerRow {
-	return s.filteredRows[s.startIndex:s.endIndex]
+	summaries := s.filteredRows[s.startIndex:s.endIndex]
+	rows := make([]*ContainerRow, 0, len(summaries))
+	for _, summary := range summaries {
+		if summary.group == nil {
+			rows = append(rows, s.row(summary))
+		}
+	}
+	return rows
 }
 
 func (s *ContainersWidget) calculateVisibleRows() {
@@ -308,8 +1217,17 @@ func (s *ContainersWidget) calculateVisibleRows() {
 	if count <= s.height {
 		s.startIndex = 0
 		s.endIndex = count
+		s.fitExpandedRow()
 		return
 	}
+	//the window might have been shortened to fit an expanded row
+	if s.endIndex-s.startIndex != s.height {
+		s.endIndex = s.startIndex + s.height
+		if s.endIndex > count {
+			s.endIndex = count
+			s.startIndex = count - s.height
+		}
+	}
 	//at the the start
 	if selected == 0 {
 		s.startIndex = 0
@@ -317,29 +1235,161 @@ func (s *ContainersWidget) calculateVisibleRows() {
 	} else if selected >= count-1 { //at the end
 		s.startIndex = count - s.height
 		s.endIndex = count
+	} else if selected > s.endIndex { //jump down
+		s.startIndex = selected - s.height + 1
+		s.endIndex = selected + 1
 	} else if selected == s.endIndex { //scroll down by one
 		s.startIndex++
 		s.endIndex++
+	} else if selected < s.startIndex-1 { //jump up
+		s.startIndex = selected
+		s.endIndex = selected + s.height
 	} else if selected <= s.startIndex { //scroll up by one
 		s.startIndex--
 		s.endIndex--
-	} else if selected > s.endIndex { // scroll
-		s.startIndex = selected - s.height
-		s.endIndex = selected
 	}
+	s.fitExpandedRow()
 }
 
-func containerTableHeader() *termui.TableHeader {
+//fitExpandedRow shortens the visible rows so the expanded row, always the
+//selected one, and the rows around it fit on the screen
+func (s *ContainersWidget) fitExpandedRow() {
+	extra := s.expandedRowHeight() - 1
+	if extra <= 0 {
+		return
+	}
+	visible := s.height - extra
+	if visible < 1 {
+		visible = 1
+	}
+	if s.endIndex-s.startIndex <= visible {
+		return
+	}
+	if s.selectedIndex < s.startIndex+visible {
+		s.endIndex = s.startIndex + visible
+	} else {
+		s.startIndex = s.selectedIndex - visible + 1
+		s.endIndex = s.selectedIndex + 1
+	}
+}
+
+//containerTableHeader creates the header of the container table with the
+//given column widths, without the given hidden columns
+func containerTableHeader(hidden hiddenColumns, widths []int) *termui.TableHeader {
+	return hidden.tableHeader(containerTableHeaders, widths)
+}
+
+//containerColumns are the values shown on the columns of a container row
+type containerColumns struct {
+	id, image, command, created, status, health, restart, ports, address, names, size string
+	restarts                                                                          int
+	running                                                                           bool
+	update                                                                            docker.ImageUpdateStatus
+}
+
+//containerSummary is a lightweight version of a container row, the container
+//list is sorted and filtered using summaries and rows are only built for the
+//containers on screen. When the list is grouped, the summaries of the headers
+//of compose projects have a group and no container.
+type containerSummary struct {
+	container *docker.Container
+	columns   containerColumns
+	project   string
+	group     *containerGroup
+}
 
-	header := termui.NewHeader(DryTheme)
-	header.ColumnSpacing = DefaultColumnSpacing
-	header.AddFixedWidthColumn(containerTableHeaders[0].Title, 2)
-	header.AddFixedWidthColumn(containerTableHeaders[1].Title, 12)
-	header.AddColumn(containerTableHeaders[2].Title)
-	header.AddColumn(containerTableHeaders[3].Title)
-	header.AddFixedWidthColumn(containerTableHeaders[4].Title, 18)
-	header.AddColumn(containerTableHeaders[5].Title)
-	header.AddColumn(containerTableHeaders[6].Title)
+//id returns the id of the container of the summary or, for the header of a
+//compose project, an id made up from the project
+func (c *containerSummary) id() string {
+	if c.group != nil {
+		return "project:" + c.group.project
+	}
+	return c.container.ID
+}
+
+//containerGroup is the header of the containers of a compose project
+type containerGroup struct {
+	project             string
+	containers, running int
+	collapsed           bool
+}
+
+//title returns the name of the project, the group of the containers that
+//do not belong to a project has none
+func (g *containerGroup) title() string {
+	if g.project == "" {
+		return "(no compose project)"
+	}
+	return g.project
+}
+
+func newContainerSummary(container *docker.Container) *containerSummary {
+	cf := formatter.NewContainerFormatter(container, true)
+	return &containerSummary{
+		container: container,
+		columns: containerColumns{
+			id:       cf.ID(),
+			image:    cf.Image(),
+			command:  cf.Command(),
+			created:  createdAgo(cf),
+			status:   cf.Status(),
+			health:   docker.HealthStatus(container),
+			restarts: docker.RestartCount(container),
+			restart:  cf.RestartPolicy(),
+			ports:    cf.Ports(),
+			address:  cf.Address(),
+			names:    cf.Names(),
+			running:  docker.IsContainerRunning(container),
+		},
+		project: docker.ComposeProject(container),
+	}
+}
+
+//matches returns true if the columns used to filter a container row, its
+//address included, match the given pattern, a regular expression. Patterns
+//like restart:always match the containers with the given restart policy
+//instead, outdated:true those whose image is behind its registry version,
+//port:8080 those with the given port on either the host or the container
+//side, status:exited those in the given state, label:key=value those with
+//the given label and image:nginx those created from the given image.
+func (c *containerSummary) matches(pattern string) bool {
+	if c.group != nil {
+		return MatchesPattern(c.group.title(), pattern)
+	}
+	if status, ok := docker.FilteredStatus(pattern); ok {
+		return docker.HasStatus(c.container, status)
+	}
+	if strings.HasPrefix(pattern, docker.ImageFilterPrefix) {
+		return docker.UsesImage(c.container, strings.TrimPrefix(pattern, docker.ImageFilterPrefix))
+	}
+	if strings.HasPrefix(pattern, docker.LabelFilterPrefix) {
+		return docker.HasLabel(c.container, strings.TrimPrefix(pattern, docker.LabelFilterPrefix))
+	}
+	if strings.HasPrefix(pattern, docker.PortFilterPrefix) {
+		return docker.HasPort(c.container, strings.TrimPrefix(pattern, docker.PortFilterPrefix))
+	}
+	if strings.HasPrefix(pattern, docker.VolumeFilterPrefix) {
+		return docker.UsesVolume(c.container, strings.TrimPrefix(pattern, docker.VolumeFilterPrefix))
+	}
+	if strings.HasPrefix(pattern, docker.OutdatedFilterPrefix) {
+		outdated := strings.TrimPrefix(pattern, docker.OutdatedFilterPrefix) != "false"
+		return (c.columns.update == docker.ImageOutdated) == outdated
+	}
+	if strings.HasPrefix(pattern, docker.RestartPolicyFilterPrefix) {
+		policy := strings.TrimPrefix(pattern, docker.RestartPolicyFilterPrefix)
+		return c.columns.restart == policy ||
+			strings.HasPrefix(c.columns.restart, policy+":")
+	}
+	return MatchesPattern(c.columns.id, pattern) ||
+		MatchesPattern(c.columns.image, pattern) ||
+		MatchesPattern(c.columns.names, pattern) ||
+		MatchesPattern(c.columns.command, pattern) ||
+		MatchesPattern(c.columns.address, pattern)
+}
 
-	return header
+//cachedContainerRow is a row kept on the row cache, along with the
+//columns it was built with
+type cachedContainerRow struct {
+	columns containerColumns
+	row     *ContainerRow
 }
@@ -1,13 +1,18 @@
 package appui
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/mocks"
 	"github.com/moncho/dry/ui"
-	drytermui "github.com/moncho/dry/ui/termui"
 )
 
 func TestContainerListVisibleRows(t *testing.T) {
@@ -21,11 +26,12 @@ func TestContainerListVisibleRows(t *testing.T) {
 	screen.Cursor.Max(10 - 1)
 	ui.ActiveScreen = screen
 
-	w := NewContainersWidget(daemon, 0)
+	w := NewContainersWidget(daemon, 0, ListOptions{})
 
 	if err := w.Mount(); err != nil {
 		t.Errorf("There was an error mounting the widget %v", err)
 	}
+	w.loader.Wait()
 	w.prepareForRendering()
 	rows := w.visibleRows()
 	if len(rows) != w.height {
@@ -135,7 +141,7 @@ func TestContainersWidget_ToggleShowAllContainers(t *testing.T) {
 
 func TestContainersWidget_sortRows(t *testing.T) {
 	type fields struct {
-		totalRows []*ContainerRow
+		totalRows []*containerSummary
 		sortMode  docker.SortMode
 	}
 	tests := []struct {
@@ -145,16 +151,10 @@ func TestContainersWidget_sortRows(t *testing.T) {
 		{
 			"sort by container ID",
 			fields{
-				[]*ContainerRow{
-					{
-						ID: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "2"),
-					},
-					{
-						ID: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "1"),
-					},
-					{
-						ID: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "3"),
-					},
+				[]*containerSummary{
+					{columns: containerColumns{id: "2"}},
+					{columns: containerColumns{id: "1"}},
+					{columns: containerColumns{id: "3"}},
 				},
 				docker.SortByContainerID,
 			},
@@ -170,7 +170,7 @@ func TestContainersWidget_sortRows(t *testing.T) {
 
 			if !sort.SliceIsSorted(s.totalRows,
 				func(i, j int) bool {
-					return s.totalRows[i].ID.Text < s.totalRows[j].ID.Text
+					return s.totalRows[i].columns.id < s.totalRows[j].columns.id
 				}) {
 				t.Error("rows are not sorted")
 			}
@@ -178,10 +178,117 @@ func TestContainersWidget_sortRows(t *testing.T) {
 	}
 }
 
+func TestContainersWidget_sortRowsByUsage(t *testing.T) {
+	summary := func(id, name string) *containerSummary {
+		return &containerSummary{
+			container: &docker.Container{Container: types.Container{ID: id}},
+			columns:   containerColumns{id: id, names: name},
+		}
+	}
+	usage := newContainerUsage(&mocks.DockerDaemonMock{})
+	usage.stats["idle"] = &docker.Stats{CPUPercentage: 0.5, Memory: 300}
+	usage.stats["busy"] = &docker.Stats{CPUPercentage: 90, Memory: 100}
+	usage.stats["busy2"] = &docker.Stats{CPUPercentage: 90, Memory: 200}
+
+	tests := []struct {
+		name       string
+		mode       docker.SortMode
+		descending bool
+		want       []string
+	}{
+		{"by CPU, the busiest first", docker.SortByCPU, false, []string{"busy", "busy2", "idle", "stopped"}},
+		{"by memory, the largest first", docker.SortByMemory, false, []string{"idle", "busy2", "busy", "stopped"}},
+		{"by CPU reversed", docker.SortByCPU, true, []string{"stopped", "idle", "busy2", "busy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ContainersWidget{
+				totalRows: []*containerSummary{
+					summary("stopped", "a"), summary("idle", "b"), summary("busy2", "d"), summary("busy", "c"),
+				},
+				sortMode: tt.mode,
+				usage:    usage,
+			}
+			if tt.descending {
+				s.order.Reverse(tt.mode)
+			}
+			s.sortRows()
+			var got []string
+			for _, row := range s.totalRows {
+				got = append(got, row.container.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Unexpected order, got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainersWidget_sortRowsBySize(t *testing.T) {
+	summary := func(id, name string) *containerSummary {
+		return &containerSummary{
+			container: &docker.Container{Container: types.Container{ID: id}},
+			columns:   containerColumns{id: id, names: name},
+		}
+	}
+	sizes := newContainerSizes(&mocks.DockerDaemonMock{})
+	sizes.sizes = map[string]docker.ContainerSize{
+		"small":  {RW: 2, RootFs: 1000},
+		"large":  {RW: 2048, RootFs: 3000},
+		"large2": {RW: 2048},
+	}
+	s := &ContainersWidget{
+		totalRows: []*containerSummary{
+			summary("unknown", "a"), summary("small", "b"), summary("large2", "d"), summary("large", "c"),
+		},
+		sortMode: docker.SortBySize,
+		sizes:    sizes,
+	}
+	s.sortRows()
+	var got []string
+	for _, row := range s.totalRows {
+		got = append(got, row.container.ID)
+	}
+	want := []string{"large", "large2", "small", "unknown"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected order, got %v, want %v", got, want)
+	}
+	if got := sizes.text("small"); got != "2B (virtual 1kB)" {
+		t.Errorf("Unexpected size of a container, got %s", got)
+	}
+	if got := sizes.text("unknown"); got != "" {
+		t.Errorf("Unexpected size of a container whose size is not known, got %s", got)
+	}
+}
+
+func TestContainersWidget_sortRowsByRestarts(t *testing.T) {
+	summary := func(id, name string, restarts int) *containerSummary {
+		return &containerSummary{
+			container: &docker.Container{Container: types.Container{ID: id}},
+			columns:   containerColumns{id: id, names: name, restarts: restarts},
+		}
+	}
+	s := &ContainersWidget{
+		totalRows: []*containerSummary{
+			summary("stable", "a", 0), summary("flaky", "b", 3), summary("looping2", "d", 40), summary("looping", "c", 40),
+		},
+		sortMode: docker.SortByRestarts,
+	}
+	s.sortRows()
+	var got []string
+	for _, row := range s.totalRows {
+		got = append(got, row.container.ID)
+	}
+	want := []string{"looping", "looping2", "flaky", "stable"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected order, got %v, want %v", got, want)
+	}
+}
+
 func TestContainersWidget_filterRows(t *testing.T) {
 	type fields struct {
-		totalRows     []*ContainerRow
-		filteredRows  []*ContainerRow
+		totalRows     []*containerSummary
+		filteredRows  []*containerSummary
 		filterPattern string
 	}
 	tests := []struct {
@@ -191,43 +298,15 @@ func TestContainersWidget_filterRows(t *testing.T) {
 		{
 			"filter test",
 			fields{
-				[]*ContainerRow{
-					{
-						ID:      drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Image:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Names:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Command: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-					},
-					{
-						ID:      drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Image:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Names:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Command: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-					},
-					{
-						ID:      drytermui.NewThemedParColumn(&ui.ColorTheme{}, "yup"),
-						Image:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Names:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Command: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope")},
-					{
-						ID:      drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Image:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Names:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Command: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "yup"),
-					},
+				[]*containerSummary{
+					{columns: containerColumns{id: "nope", image: "nope", names: "nope", command: "nope"}},
+					{columns: containerColumns{id: "nope", image: "nope", names: "nope", command: "nope"}},
+					{columns: containerColumns{id: "yup", image: "nope", names: "nope", command: "nope"}},
+					{columns: containerColumns{id: "nope", image: "nope", names: "nope", command: "yup"}},
 				},
-				[]*ContainerRow{
-					{
-						ID:      drytermui.NewThemedParColumn(&ui.ColorTheme{}, "yup"),
-						Image:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Names:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Command: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope")},
-					{
-						ID:      drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Image:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Names:   drytermui.NewThemedParColumn(&ui.ColorTheme{}, "nope"),
-						Command: drytermui.NewThemedParColumn(&ui.ColorTheme{}, "yup"),
-					},
+				[]*containerSummary{
+					{columns: containerColumns{id: "yup", image: "nope", names: "nope", command: "nope"}},
+					{columns: containerColumns{id: "nope", image: "nope", names: "nope", command: "yup"}},
 				},
 				"yup",
 			},
@@ -246,3 +325,587 @@ func TestContainersWidget_filterRows(t *testing.T) {
 		})
 	}
 }
+
+func TestContainersWidget_StatusFilter(t *testing.T) {
+	summary := func(id, state string) *containerSummary {
+		return &containerSummary{
+			container: &docker.Container{Container: types.Container{ID: id, State: state}},
+			columns:   containerColumns{id: id},
+		}
+	}
+	s := &ContainersWidget{
+		totalRows: []*containerSummary{summary("1", "running"), summary("2", "exited"), summary("3", "exited"), summary("4", "paused")},
+	}
+	s.Filter("status:exited")
+	if !s.ShowAllContainers() {
+		t.Error("Filtering by a stopped state must list stopped containers too")
+	}
+	s.filterRows()
+	if len(s.filteredRows) != 2 || s.filteredRows[0].container.ID != "2" || s.filteredRows[1].container.ID != "3" {
+		t.Errorf("Unexpected containers with status exited: %v", s.filteredRows)
+	}
+
+	s = &ContainersWidget{totalRows: s.totalRows}
+	s.Filter("status:running")
+	if s.ShowAllContainers() {
+		t.Error("Filtering by the running state must not list stopped containers")
+	}
+	s.filterRows()
+	if len(s.filteredRows) != 1 || s.filteredRows[0].container.ID != "1" {
+		t.Errorf("Unexpected containers with status running: %v", s.filteredRows)
+	}
+}
+
+//manyContainersDaemon is a daemon with the given number of running containers
+type manyContainersDaemon struct {
+	mocks.DockerDaemonMock
+	containers []*docker.Container
+}
+
+func newManyContainersDaemon(count int) *manyContainersDaemon {
+	daemon := &manyContainersDaemon{}
+	for i := 0; i < count; i++ {
+		daemon.containers = append(daemon.containers, &docker.Container{
+			Container: types.Container{
+				ID:      fmt.Sprintf("%064d", i),
+				Names:   []string{fmt.Sprintf("/container-%d", i)},
+				Image:   "nginx:alpine",
+				Command: "nginx -g 'daemon off;'",
+				State:   "running",
+				Status:  "Up 2 hours"},
+		})
+	}
+	return daemon
+}
+
+func (d *manyContainersDaemon) Containers(filters []docker.ContainerFilter, mode docker.SortMode) []*docker.Container {
+	return d.containers
+}
+
+func manyContainersScreen(count int) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 60, Width: 160},
+	}
+	ui.ActiveScreen.Cursor.Max(count - 1)
+}
+
+func TestContainersWidget_RowCache(t *testing.T) {
+	count := 2000
+	manyContainersScreen(count)
+	daemon := newManyContainersDaemon(count)
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.Buffer()
+
+	if max := w.height + containerRowsOverscan*2; len(w.rowCache) > max {
+		t.Errorf("Too many rows were built, got %d, want at most %d", len(w.rowCache), max)
+	}
+	built := make(map[string]*ContainerRow)
+	for id, cached := range w.rowCache {
+		built[id] = cached.row
+	}
+
+	for i := 0; i < w.height+containerRowsOverscan; i++ {
+		ui.ActiveScreen.Cursor.ScrollCursorDown()
+		w.Buffer()
+	}
+	for id, row := range built {
+		if cached, ok := w.rowCache[id]; !ok || cached.row != row {
+			t.Errorf("Row of container %s was built again after scrolling", id)
+		}
+	}
+
+	//a container changes and the list is mounted again
+	changed := daemon.containers[0]
+	daemon.containers[0] = &docker.Container{Container: changed.Container}
+	daemon.containers[0].Status = "Exited (0) 1 second ago"
+	w.Unmount()
+	w.Mount()
+	w.loader.Wait()
+	w.Buffer()
+	if cached, ok := w.rowCache[changed.ID]; ok && cached.row.container == changed {
+		t.Error("Row of a changed container was kept on the cache")
+	}
+	if cached, ok := w.rowCache[daemon.containers[1].ID]; !ok || cached.row != built[daemon.containers[1].ID] {
+		t.Error("Row of an unchanged container was built again after mounting the list")
+	}
+
+	//the screen is resized
+	ui.ActiveScreen.Dimensions.Width = 120
+	w.Buffer()
+	for _, cached := range w.rowCache {
+		if cached.row.Width != 120 {
+			t.Fatalf("Row was not rebuilt after resizing, width %d", cached.row.Width)
+		}
+	}
+}
+
+//BenchmarkContainersWidget_EagerRows measures the cost of rendering the
+//list building a row for every container, as the widget did before rows
+//were built lazily.
+func BenchmarkContainersWidget_EagerRows(b *testing.B) {
+	count := 2000
+	manyContainersScreen(count)
+	daemon := newManyContainersDaemon(count)
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	w.Mount()
+	w.loader.Wait()
+	w.Buffer()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows := make([]*ContainerRow, count)
+		for j, container := range daemon.containers {
+			rows[j] = NewContainerRow(container, w.header, nil)
+			rows[j].SetX(w.x)
+			rows[j].SetWidth(w.width)
+		}
+		buf := gizaktermui.NewBuffer()
+		for _, row := range rows[w.startIndex:w.endIndex] {
+			buf.Merge(row.Buffer())
+		}
+	}
+}
+
+//BenchmarkContainersWidget_Buffer measures the cost of rendering the list
+//while scrolling
+func BenchmarkContainersWidget_Buffer(b *testing.B) {
+	count := 2000
+	manyContainersScreen(count)
+	w := NewContainersWidget(newManyContainersDaemon(count), 0, ListOptions{})
+	w.Mount()
+	w.loader.Wait()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ui.ActiveScreen.Cursor.Position() == count-1 {
+			ui.ActiveScreen.Cursor.Reset()
+		}
+		ui.ActiveScreen.Cursor.ScrollCursorDown()
+		w.Buffer()
+	}
+}
+
+//BenchmarkContainersWidget_Mount measures the cost of rendering the list
+//after it was mounted again, i.e. after a Docker event
+func BenchmarkContainersWidget_Mount(b *testing.B) {
+	defer func(debounce time.Duration) { loadDebounce = debounce }(loadDebounce)
+	loadDebounce = 0
+	count := 2000
+	manyContainersScreen(count)
+	w := NewContainersWidget(newManyContainersDaemon(count), 0, ListOptions{})
+	w.Mount()
+	w.loader.Wait()
+	w.Buffer()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Unmount()
+		w.Mount()
+		w.loader.Wait()
+		w.Buffer()
+	}
+}
+
+func TestContainerSummary_MatchesRestartPolicy(t *testing.T) {
+	withPolicy := func(name string, retries int) *docker.Container {
+		return &docker.Container{
+			Container: types.Container{ID: "1", Names: []string{"/web"}},
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					HostConfig: &container.HostConfig{
+						RestartPolicy: container.RestartPolicy{Name: name, MaximumRetryCount: retries},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		container *docker.Container
+		pattern   string
+		want      bool
+	}{
+		{"always", withPolicy("always", 0), "restart:always", true},
+		{"not always", withPolicy("unless-stopped", 0), "restart:always", false},
+		{"no policy", withPolicy("", 0), "restart:no", true},
+		{"on failure with retries", withPolicy("on-failure", 3), "restart:on-failure", true},
+		{"retry count", withPolicy("on-failure", 3), "restart:on-failure:3", true},
+		{"policy is not matched as text", withPolicy("always", 0), "always", false},
+		{"name", withPolicy("always", 0), "web", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newContainerSummary(tt.container).matches(tt.pattern); got != tt.want {
+				t.Errorf("containerSummary.matches(%s) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainersWidget_ExpandedRow(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 160},
+	}
+	ui.ActiveScreen.Cursor.Max(10 - 1)
+	daemon := newManyContainersDaemon(10)
+	daemon.containers[0].Names = []string{"/web", "/proxy/web", "/worker/web"}
+
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.prepareForRendering()
+
+	w.ToggleExpanded()
+	w.prepareForRendering()
+	rows := w.visibleRows()
+	if !rows[0].Expanded() || rows[0].GetHeight() != 3 {
+		t.Fatalf("Selected row was not expanded, height: %d", rows[0].GetHeight())
+	}
+	if len(rows) != w.height-2 {
+		t.Errorf("The expanded row must push the rows below it, expected %d rows, got %d", w.height-2, len(rows))
+	}
+
+	ui.ActiveScreen.Cursor.ScrollCursorDown()
+	w.prepareForRendering()
+	rows = w.visibleRows()
+	if rows[0].Expanded() || rows[0].GetHeight() != 1 {
+		t.Error("Expanded row did not collapse when the selection moved")
+	}
+	if len(rows) != w.height {
+		t.Errorf("There is room for %d rows but found %d", w.height, len(rows))
+	}
+}
+
+func TestContainersWidget_OutdatedFilter(t *testing.T) {
+	manyContainersScreen(3)
+	daemon := newManyContainersDaemon(3)
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.SetImageUpdate(docker.ImageUpdate{ContainerID: daemon.containers[1].ID, Status: docker.ImageOutdated})
+	w.SetImageUpdate(docker.ImageUpdate{ContainerID: daemon.containers[2].ID, Status: docker.ImageUpToDate})
+
+	w.Filter("outdated:true")
+	w.Buffer()
+	if containers := w.Containers(); len(containers) != 1 || containers[0] != daemon.containers[1] {
+		t.Errorf("Unexpected containers with outdated images: %v", containers)
+	}
+	row := w.row(w.filteredRows[0])
+	if !strings.Contains(row.Update.Text, "outdated") {
+		t.Errorf("Outdated container not marked, update column: %q", row.Update.Text)
+	}
+
+	w.Filter("outdated:false")
+	w.Buffer()
+	if count := w.RowCount(); count != 2 {
+		t.Errorf("Unexpected number of containers with images up to date or not checked, got %d, want 2", count)
+	}
+}
+
+func TestContainersWidget_SplitWidthAndSelection(t *testing.T) {
+	manyContainersScreen(10)
+	daemon := newManyContainersDaemon(10)
+
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	var selections []string
+	w.OnSelectionChange(func(c *docker.Container) {
+		selections = append(selections, c.ID)
+	})
+	w.SetWidth(96)
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.prepareForRendering()
+	if w.width != 96 {
+		t.Errorf("Unexpected widget width, got %d, want 96", w.width)
+	}
+	for _, row := range w.visibleRows() {
+		if row.Width > 96 {
+			t.Fatalf("Row is wider than the widget: %d", row.Width)
+		}
+	}
+
+	w.prepareForRendering()
+	ui.ActiveScreen.Cursor.ScrollCursorDown()
+	w.prepareForRendering()
+	want := []string{daemon.containers[0].ID, daemon.containers[1].ID}
+	if strings.Join(selections, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected selection changes, got %v, want %v", selections, want)
+	}
+
+	w.SetWidth(0)
+	w.prepareForRendering()
+	if w.width != ui.ActiveScreen.Dimensions.Width {
+		t.Errorf("Widget does not use the screen width once its width is reset, got %d", w.width)
+	}
+}
+
+//selectedID returns the id of the container on the cursor
+func selectedID(w *ContainersWidget) string {
+	index := ui.ActiveScreen.Cursor.Position()
+	if index < 0 || index >= len(w.filteredRows) {
+		return ""
+	}
+	return w.filteredRows[index].id()
+}
+
+func TestContainersWidget_FollowSelection(t *testing.T) {
+	manyContainersScreen(3)
+	daemon := &manyContainersDaemon{}
+	for _, c := range []struct{ id, name string }{
+		{"a", "/zeta"}, {"b", "/alpha"}, {"c", "/mu"}} {
+		daemon.containers = append(daemon.containers, &docker.Container{
+			Container: types.Container{
+				ID:     c.id,
+				Names:  []string{c.name},
+				Image:  "nginx:alpine",
+				State:  "running",
+				Status: "Up 2 hours"},
+		})
+	}
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	w.SetSortMode(docker.SortByContainerID)
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	ui.ActiveScreen.Cursor.ScrollTo(1)
+	w.prepareForRendering()
+	if id := selectedID(w); id != "b" {
+		t.Fatalf("Unexpected container on the cursor, got %s, want b", id)
+	}
+
+	//the list is sorted again, container b moves to the top
+	w.SetSortMode(docker.SortByName)
+	w.prepareForRendering()
+	if id := selectedID(w); id != "b" {
+		t.Errorf("The selection did not follow the container after sorting, got %s, want b", id)
+	}
+	if pos := ui.ActiveScreen.Cursor.Position(); pos != 0 {
+		t.Errorf("Unexpected cursor position after sorting, got %d, want 0", pos)
+	}
+
+	//the user moves the cursor, the list keeps it where it is
+	ui.ActiveScreen.Cursor.ScrollTo(2)
+	w.prepareForRendering()
+	if id := selectedID(w); id != "a" {
+		t.Errorf("The cursor did not stay where the user moved it, got %s, want a", id)
+	}
+
+	//container a, on the last row, is gone, the cursor goes to the nearest row
+	daemon.containers = daemon.containers[1:]
+	w.Unmount()
+	w.Mount()
+	w.loader.Wait()
+	w.prepareForRendering()
+	if pos := ui.ActiveScreen.Cursor.Position(); pos != 1 {
+		t.Errorf("Unexpected cursor position after the container was removed, got %d, want 1", pos)
+	}
+
+	//container c is pinned, the cursor goes back to it
+	ui.ActiveScreen.Cursor.ScrollTo(1)
+	w.prepareForRendering()
+	if name := w.TogglePin(); name != "mu" {
+		t.Errorf("Unexpected pinned container, got %q, want mu", name)
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(0)
+	w.SetSortMode(docker.SortByContainerID)
+	w.prepareForRendering()
+	if id := selectedID(w); id != "c" {
+		t.Errorf("The cursor did not stay on the pinned container, got %s, want c", id)
+	}
+	if name := w.TogglePin(); name != "" {
+		t.Errorf("The container was not unpinned, got %q", name)
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(0)
+	w.prepareForRendering()
+	if id := selectedID(w); id != "b" {
+		t.Errorf("The cursor did not move after unpinning, got %s, want b", id)
+	}
+}
+
+func TestContainersWidget_ContainerEvents(t *testing.T) {
+	manyContainersScreen(4)
+	container := func(id, name, state string) *docker.Container {
+		status := "Up 2 hours"
+		if state != "running" {
+			status = "Exited (0) 1 second ago"
+		}
+		return &docker.Container{
+			Container: types.Container{
+				ID:     id,
+				Names:  []string{name},
+				Image:  "nginx:alpine",
+				State:  state,
+				Status: status},
+		}
+	}
+	daemon := &manyContainersDaemon{containers: []*docker.Container{
+		container("a", "/zeta", "running"),
+		container("b", "/alpha", "running"),
+		container("c", "/mu", "running")}}
+	w := NewContainersWidget(daemon, 0, ListOptions{SortMode: docker.SortByName})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	rows := func() string {
+		w.prepareForRendering()
+		var ids []string
+		for _, row := range w.filteredRows {
+			ids = append(ids, row.id())
+		}
+		return strings.Join(ids, ",")
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(1)
+	if got := rows(); got != "b,c,a" || selectedID(w) != "c" {
+		t.Fatalf("Unexpected rows: %s, %s selected", got, selectedID(w))
+	}
+
+	//the daemon list is not retrieved again, changes come from the events
+	daemon.containers = nil
+	w.onContainerEvent(docker.ContainerEvent{ID: "d", Action: "start", Container: container("d", "/beta", "running")})
+	if got := rows(); got != "b,d,c,a" {
+		t.Errorf("Unexpected rows after a container started: %s", got)
+	}
+	if selectedID(w) != "c" {
+		t.Errorf("The cursor did not stay on its container, got %s", selectedID(w))
+	}
+	w.onContainerEvent(docker.ContainerEvent{ID: "d", Action: "rename", Container: container("d", "/omega", "running")})
+	if got := rows(); got != "b,c,d,a" || selectedID(w) != "c" {
+		t.Errorf("Unexpected rows after a container was renamed: %s, %s selected", got, selectedID(w))
+	}
+
+	//stopped containers are not listed unless all containers are shown
+	w.onContainerEvent(docker.ContainerEvent{ID: "a", Action: "die", Container: container("a", "/zeta", "exited")})
+	if got := rows(); got != "b,c,d" {
+		t.Errorf("Unexpected rows after a container died: %s", got)
+	}
+	w.onContainerEvent(docker.ContainerEvent{ID: "c", Action: "destroy"})
+	if got := rows(); got != "b,d" {
+		t.Errorf("Unexpected rows after a container was removed: %s", got)
+	}
+	if selectedID(w) != "d" {
+		t.Errorf("The cursor did not go to the nearest row, got %s", selectedID(w))
+	}
+	w.onContainerEvent(docker.ContainerEvent{ID: "e", Action: "create", Container: container("e", "/new", "created")})
+	if got := rows(); got != "b,d" {
+		t.Errorf("A container that is not running was listed: %s", got)
+	}
+}
+
+func TestContainersWidget_ListOptions(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	w := NewContainersWidget(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	if w.ActiveFilter() != "" || w.SortMode() != docker.SortByContainerID || w.ShowAllContainers() {
+		t.Errorf("Unexpected defaults: filter %q, sort mode %d, show all %t",
+			w.ActiveFilter(), w.SortMode(), w.ShowAllContainers())
+	}
+
+	w = NewContainersWidget(&mocks.DockerDaemonMock{}, 0, ListOptions{Filter: "status:exited", SortMode: docker.SortByName})
+	if w.ActiveFilter() != "status:exited" || w.SortMode() != docker.SortByName {
+		t.Errorf("Unexpected list options: filter %q, sort mode %d", w.ActiveFilter(), w.SortMode())
+	}
+	if !w.ShowAllContainers() {
+		t.Error("Stopped containers must be shown when starting filtered by status")
+	}
+}
+
+func TestContainersWidget_GroupByProject(t *testing.T) {
+	manyContainersScreen(7)
+	daemon := &manyContainersDaemon{}
+	for _, c := range []struct{ id, project, state string }{
+		{"a", "shop", "running"}, {"b", "", "running"}, {"c", "shop", "exited"}, {"d", "blog", "running"}} {
+		labels := map[string]string{}
+		if c.project != "" {
+			labels["com.docker.compose.project"] = c.project
+		}
+		daemon.containers = append(daemon.containers, &docker.Container{
+			Container: types.Container{
+				ID:     c.id,
+				Names:  []string{"/" + c.id},
+				Image:  "nginx:alpine",
+				Labels: labels,
+				State:  c.state},
+		})
+	}
+	w := NewContainersWidget(daemon, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	rows := func() string {
+		w.prepareForRendering()
+		var ids []string
+		for _, row := range w.filteredRows {
+			ids = append(ids, row.id())
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if !w.ToggleGrouping() {
+		t.Fatal("The list is not grouped")
+	}
+	if got := rows(); got != "project:blog,d,project:shop,a,c,project:,b" {
+		t.Fatalf("Unexpected grouped rows: %s", got)
+	}
+	if containers := w.Containers(); len(containers) != 4 {
+		t.Errorf("Project headers must not be listed as containers, got %d", len(containers))
+	}
+
+	ui.ActiveScreen.Cursor.ScrollTo(2)
+	rows()
+	if project, ok := w.SelectedProject(); !ok || project != "shop" {
+		t.Fatalf("Unexpected selected project: %q, %v", project, ok)
+	}
+	if err := w.OnEvent(func(id string) error { return nil }); err == nil {
+		t.Error("Commands on containers must not run on a project header")
+	}
+	if got := strings.Join(w.ProjectContainers("shop"), ","); got != "a,c" {
+		t.Errorf("Unexpected containers of the project: %s", got)
+	}
+
+	w.ToggleMark()
+	if got := strings.Join(w.Marked(), ","); got != "a,c" {
+		t.Errorf("The containers of the project were not marked: %s", got)
+	}
+	w.ToggleMark()
+	if len(w.Marked()) != 0 {
+		t.Errorf("The containers of the project were not unmarked: %v", w.Marked())
+	}
+
+	w.ToggleExpanded()
+	if got := rows(); got != "project:blog,d,project:shop,project:,b" {
+		t.Errorf("Unexpected rows with the project collapsed: %s", got)
+	}
+	if selectedID(w) != "project:shop" {
+		t.Errorf("The cursor did not stay on the header, got %s", selectedID(w))
+	}
+
+	//selecting a container of a collapsed project lists its containers again
+	w.Select("c")
+	if got := rows(); got != "project:blog,d,project:shop,a,c,project:,b" {
+		t.Errorf("Unexpected rows after selecting a hidden container: %s", got)
+	}
+	if selectedID(w) != "c" {
+		t.Errorf("The hidden container was not selected, got %s", selectedID(w))
+	}
+
+	if w.ToggleGrouping() {
+		t.Fatal("The list is still grouped")
+	}
+	if got := rows(); got != "a,b,c,d" {
+		t.Errorf("Unexpected rows once ungrouped: %s", got)
+	}
+}
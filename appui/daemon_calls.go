@@ -0,0 +1,36 @@
+package appui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//NewDaemonCallsOverlay creates a box with the counters of the list calls
+//made to the Docker daemon, shown on the top right corner of the screen
+func NewDaemonCallsOverlay(stats []docker.CallStats) *termui.MarkupPar {
+	lines := []string{fmt.Sprintf("<b><blue>%-10s %6s %9s %9s</></>", "ENDPOINT", "CALLS", "COALESCED", "LATENCY")}
+	for _, s := range stats {
+		latency := fmt.Sprintf("%.1fms", float64(s.Latency)/float64(time.Millisecond))
+		lines = append(lines, fmt.Sprintf("%-10s %6d %9d %9s", s.Endpoint, s.Calls, s.Coalesced, latency))
+	}
+	if len(stats) == 0 {
+		lines = append(lines, "<darkgrey>no calls yet</>")
+	}
+	par := termui.NewParFromMarkupText(DryTheme, strings.Join(lines, "\n"))
+	par.BorderLabel = " Daemon calls "
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	par.BorderLabelFg = gizaktermui.Attribute(DryTheme.Fg)
+	par.Width = 41
+	par.Height = len(lines) + 2
+	par.X = ui.ActiveScreen.Dimensions.Width - par.Width
+	par.Y = MainScreenHeaderSize
+	return par
+}
@@ -58,6 +58,13 @@ func (r *DockerDiskUsageRenderer) PrepareToRender(diskUsage *types.DiskUsage, re
 	r.Unlock()
 }
 
+//Loaded returns true if the renderer has disk usage data to render
+func (r *DockerDiskUsageRenderer) Loaded() bool {
+	r.RLock()
+	defer r.RUnlock()
+	return r.diskUsage != nil
+}
+
 //Render returns the result of docker system df
 func (r *DockerDiskUsageRenderer) Render() string {
 	r.RLock()
@@ -38,6 +38,13 @@ func NewDockerInfo(daemon docker.ContainerDaemon) *DockerInfo {
 	return &DockerInfo{di}
 }
 
+//Refresh updates the information shown, i.e. after the Docker host joins or leaves a swarm
+func (di *DockerInfo) Refresh(daemon docker.ContainerDaemon) {
+	if par, ok := di.SizableBufferer.(*drytermui.MarkupPar); ok {
+		par.Content(dockerInfo(daemon))
+	}
+}
+
 func dockerInfo(daemon docker.ContainerDaemon) string {
 	version, _ := daemon.Version()
 	info, _ := daemon.Info()
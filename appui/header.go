@@ -25,8 +25,8 @@ func WidgetHeader(what string, howMany int, details string) *termui.MarkupPar {
 	par.SetX(0)
 	par.Border = false
 	par.Width = ui.ActiveScreen.Dimensions.Width
-	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
-	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Header)
+	par.Bg = gizaktermui.Attribute(DryTheme.Header)
 
 	return par
 }
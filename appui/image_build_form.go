@@ -0,0 +1,71 @@
+package appui
+
+import (
+	"strings"
+
+	"github.com/moncho/dry/docker"
+)
+
+//Fields of the image build form
+const (
+	buildFieldContext    = "context"
+	buildFieldDockerfile = "dockerfile"
+	buildFieldReference  = "reference"
+)
+
+//ImageBuildForm is a widget that asks for the build context, the Dockerfile
+//and, optionally, the repository:tag of the image to build
+type ImageBuildForm struct {
+	*wizard
+}
+
+//NewImageBuildForm creates an ImageBuildForm whose build context is the
+//given directory until the user changes it
+func NewImageBuildForm(dir string) *ImageBuildForm {
+	steps := []*wizardStep{
+		{
+			field:    buildFieldContext,
+			label:    "Context",
+			help:     "Directory to build the image from, i.e. . or ~/src/myapp",
+			value:    []rune(dir),
+			validate: docker.ValidateBuildContext,
+		},
+		{
+			field: buildFieldDockerfile,
+			label: "Dockerfile",
+			help:  "Path of the Dockerfile in the context, " + docker.DefaultDockerfile + " if empty",
+		},
+		{
+			field: buildFieldReference,
+			label: "Repository:tag",
+			help:  "Name of the image, i.e. myapp:dev, optional",
+			validate: func(ref string) error {
+				if strings.TrimSpace(ref) == "" {
+					return nil
+				}
+				return docker.ValidateImageReference(ref)
+			},
+		},
+	}
+	check := func(values map[string]string) (string, error) {
+		return buildFieldDockerfile, docker.ValidateDockerfile(values[buildFieldContext], values[buildFieldDockerfile])
+	}
+	return &ImageBuildForm{newWizard("docker build", steps, check)}
+}
+
+//Name returns the widget name
+func (w *ImageBuildForm) Name() string {
+	return "ImageBuildForm"
+}
+
+//Options returns the build options entered on the form
+func (w *ImageBuildForm) Options() docker.BuildOptions {
+	w.RLock()
+	defer w.RUnlock()
+	values := w.values()
+	return docker.BuildOptions{
+		ContextDir: values[buildFieldContext],
+		Dockerfile: values[buildFieldDockerfile],
+		Reference:  values[buildFieldReference],
+	}
+}
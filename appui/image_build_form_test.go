@@ -0,0 +1,40 @@
+package appui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestImageBuildForm(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "Dockerfile.dev"), []byte("FROM alpine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := NewImageBuildForm(dir)
+	enter := []termbox.Event{keyEvent(termbox.KeyEnter)}
+
+	focusWizard(w,
+		//the context is given
+		enter,
+		//there is no Dockerfile on the context, the form goes back to it
+		enter, enter,
+		typedEvents("Dockerfile.dev"), enter,
+		typedEvents("myapp:dev"), enter)
+
+	if w.Canceled() {
+		t.Fatal("Form was canceled")
+	}
+	want := docker.BuildOptions{ContextDir: dir, Dockerfile: "Dockerfile.dev", Reference: "myapp:dev"}
+	if got := w.Options(); got != want {
+		t.Errorf("Unexpected options, got %+v, want %+v", got, want)
+	}
+}
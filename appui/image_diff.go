@@ -0,0 +1,219 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/docker/go-units"
+	"github.com/moncho/dry/docker"
+)
+
+const (
+	imageDiffSizeWidth     = 10
+	imageDiffMinLayerWidth = 20
+)
+
+//ImageDiffRenderer renders, side by side, what changed from an image to another
+type ImageDiffRenderer struct {
+	oldName, newName string
+	diff             docker.ImageDiff
+	width            int
+	markup           bool
+}
+
+//NewImageDiffRenderer creates a renderer for the given comparison, layer
+//columns are sized so that the result fits in the given width
+func NewImageDiffRenderer(oldName, newName string, diff docker.ImageDiff, width int) *ImageDiffRenderer {
+	return &ImageDiffRenderer{
+		oldName: oldName,
+		newName: newName,
+		diff:    diff,
+		width:   width,
+		markup:  true,
+	}
+}
+
+//Render renders the comparison using dry markup
+func (r *ImageDiffRenderer) Render() string {
+	return r.render()
+}
+
+//Text renders the comparison as plain text
+func (r *ImageDiffRenderer) Text() string {
+	markup := r.markup
+	r.markup = false
+	defer func() { r.markup = markup }()
+	return r.render()
+}
+
+func (r *ImageDiffRenderer) render() string {
+	buf := new(bytes.Buffer)
+	diff := r.diff
+
+	fmt.Fprintf(buf, "%s %s %s %s\n\n",
+		r.paint("blue", "Comparing"), r.paint("white", r.oldName),
+		r.paint("blue", "with"), r.paint("white", r.newName))
+	fmt.Fprintf(buf, "%s %s -> %s (%s)\n\n",
+		r.paint("blue", "Total size:"),
+		units.HumanSize(float64(diff.OldSize)), units.HumanSize(float64(diff.NewSize)),
+		r.delta(diff.SizeDelta()))
+
+	r.renderLayers(buf)
+	r.renderValues(buf, "EXPOSED PORTS", diff.Ports)
+	r.renderValues(buf, "ENV", diff.Env)
+	r.renderValues(buf, "LABELS", diff.Labels)
+	return buf.String()
+}
+
+func (r *ImageDiffRenderer) renderLayers(buf *bytes.Buffer) {
+	//marker, two sizes, the delta and the separators take the rest of the line
+	layerWidth := (r.width - 2 - 3*imageDiffSizeWidth - 5) / 2
+	if layerWidth < imageDiffMinLayerWidth {
+		layerWidth = imageDiffMinLayerWidth
+	}
+	fmt.Fprintln(buf, r.paint("yellow", "LAYERS"))
+	fmt.Fprintf(buf, "  %s %s | %s %s %s\n",
+		r.paint("blue", padRight(truncate("OLD "+r.oldName, layerWidth), layerWidth)),
+		r.paint("blue", padLeft("SIZE", imageDiffSizeWidth)),
+		r.paint("blue", padRight(truncate("NEW "+r.newName, layerWidth), layerWidth)),
+		r.paint("blue", padLeft("SIZE", imageDiffSizeWidth)),
+		r.paint("blue", padLeft("DELTA", imageDiffSizeWidth)))
+	if len(r.diff.Layers) == 0 {
+		fmt.Fprintln(buf, "  none")
+	}
+	for _, layer := range r.diff.Layers {
+		var oldLayer, oldSize, newLayer, newSize string
+		if layer.Old != nil {
+			oldLayer = instruction(layer.Old.CreatedBy)
+			oldSize = units.HumanSize(float64(layer.Old.Size))
+		}
+		if layer.New != nil {
+			newLayer = instruction(layer.New.CreatedBy)
+			newSize = units.HumanSize(float64(layer.New.Size))
+		}
+		var delta string
+		if layer.Change != docker.Unchanged {
+			delta = r.delta(layer.SizeDelta())
+		}
+		line := fmt.Sprintf("%s %s %s | %s %s %s",
+			changeMarker(layer.Change),
+			padRight(truncate(oldLayer, layerWidth), layerWidth),
+			padLeft(oldSize, imageDiffSizeWidth),
+			padRight(truncate(newLayer, layerWidth), layerWidth),
+			padLeft(newSize, imageDiffSizeWidth),
+			padLeft(delta, imageDiffSizeWidth+r.markupLength(delta)))
+		if layer.Change == docker.Unchanged {
+			line = r.paint("darkgrey", line)
+		}
+		fmt.Fprintln(buf, line)
+	}
+	fmt.Fprintln(buf)
+}
+
+func (r *ImageDiffRenderer) renderValues(buf *bytes.Buffer, title string, values []docker.ValueDiff) {
+	fmt.Fprintln(buf, r.paint("yellow", title))
+	if len(values) == 0 {
+		fmt.Fprintln(buf, "  no differences")
+	}
+	for _, v := range values {
+		var line string
+		switch v.Change {
+		case docker.Added:
+			line = r.paint("green", "+ "+keyValue(v.Key, v.New))
+		case docker.Removed:
+			line = r.paint("red", "- "+keyValue(v.Key, v.Old))
+		default:
+			line = fmt.Sprintf("%s %s -> %s", r.paint("yellow", "~ "+v.Key+":"), v.Old, v.New)
+		}
+		fmt.Fprintln(buf, line)
+	}
+	fmt.Fprintln(buf)
+}
+
+//delta renders a size difference with its sign, green if the size went down
+func (r *ImageDiffRenderer) delta(size int64) string {
+	switch {
+	case size > 0:
+		return r.paint("red", "+"+units.HumanSize(float64(size)))
+	case size < 0:
+		return r.paint("green", "-"+units.HumanSize(float64(-size)))
+	}
+	return "0B"
+}
+
+func (r *ImageDiffRenderer) paint(tag, text string) string {
+	if !r.markup {
+		return text
+	}
+	return "<" + tag + ">" + text + "</>"
+}
+
+//markupLength returns how many characters of the given string are markup tags
+func (r *ImageDiffRenderer) markupLength(s string) int {
+	if !r.markup {
+		return 0
+	}
+	return utf8.RuneCountInString(s) - utf8.RuneCountInString(stripTags(s))
+}
+
+func changeMarker(change docker.Change) string {
+	switch change {
+	case docker.Added:
+		return "+"
+	case docker.Removed:
+		return "-"
+	case docker.Changed:
+		return "~"
+	}
+	return " "
+}
+
+//instruction returns the Dockerfile instruction that created a layer, as
+//the daemon shows it in the history of an image
+func instruction(createdBy string) string {
+	createdBy = strings.Join(strings.Fields(createdBy), " ")
+	if strings.HasPrefix(createdBy, "/bin/sh -c #(nop) ") {
+		return strings.TrimPrefix(createdBy, "/bin/sh -c #(nop) ")
+	}
+	if strings.HasPrefix(createdBy, "/bin/sh -c ") {
+		return "RUN " + strings.TrimPrefix(createdBy, "/bin/sh -c ")
+	}
+	return createdBy
+}
+
+func keyValue(key, value string) string {
+	if value == "" {
+		return key
+	}
+	return key + "=" + value
+}
+
+func stripTags(s string) string {
+	for _, tag := range []string{"</>", "<red>", "<green>"} {
+		s = strings.Replace(s, tag, "", -1)
+	}
+	return s
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width-3]) + "..."
+}
+
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+func padLeft(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return strings.Repeat(" ", width-n) + s
+	}
+	return s
+}
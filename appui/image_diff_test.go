@@ -0,0 +1,48 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/moncho/dry/docker"
+)
+
+func TestImageDiffRenderer_Text(t *testing.T) {
+	diff := docker.ImageDiff{
+		OldSize: 100,
+		NewSize: 120,
+		Layers: []docker.LayerDiff{
+			{
+				Change: docker.Changed,
+				Old:    &image.HistoryResponseItem{CreatedBy: "/bin/sh -c apt-get install -y curl", Size: 80},
+				New:    &image.HistoryResponseItem{CreatedBy: "/bin/sh -c apt-get install -y curl wget", Size: 90},
+			},
+			{
+				Change: docker.Added,
+				New:    &image.HistoryResponseItem{CreatedBy: "/bin/sh -c #(nop)  EXPOSE 8080/tcp", Size: 0},
+			},
+		},
+		Env: []docker.ValueDiff{{Change: docker.Changed, Key: "VERSION", Old: "1.4", New: "1.5"}},
+	}
+	r := NewImageDiffRenderer("myapp:1.4", "myapp:1.5", diff, 120)
+	text := r.Text()
+
+	for _, want := range []string{
+		"Comparing myapp:1.4 with myapp:1.5",
+		"Total size: 100B -> 120B (+20B)",
+		"RUN apt-get install -y curl wget",
+		"EXPOSE 8080/tcp",
+		"~ VERSION: 1.4 -> 1.5",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text does not contain %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "</>") {
+		t.Errorf("Text contains markup:\n%s", text)
+	}
+	if !strings.Contains(r.Render(), "<red>+20B</>") {
+		t.Errorf("Render does not use markup:\n%s", r.Render())
+	}
+}
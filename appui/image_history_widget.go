@@ -0,0 +1,304 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/docker/docker/api/types/image"
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//historySort is the order the history of an image is shown in
+type historySort int
+
+//Known history sort modes
+const (
+	historySortNewest historySort = iota
+	historySortSize
+)
+
+var historySortNames = map[historySort]string{
+	historySortNewest: "HISTORY",
+	historySortSize:   "SIZE",
+}
+
+var historyTitles = []string{"IMAGE", "CREATED", "SIZE", "%", "CREATED BY"}
+
+//ImageHistoryWidget shows the history of an image, as docker history does,
+//with the share of the image size each instruction takes
+type ImageHistoryWidget struct {
+	dockerDaemon  docker.ImageAPI
+	imageID       string
+	name          string
+	history       []image.HistoryResponseItem
+	totalSize     int64
+	sortMode      historySort
+	lines         []string
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewImageHistoryWidget creates an ImageHistoryWidget
+func NewImageHistoryWidget(dockerDaemon docker.ImageAPI, y int) *ImageHistoryWidget {
+	w := &ImageHistoryWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ImageHistoryWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	widgetHeader := WidgetHeader("History of "+s.name, len(s.history), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if len(s.lines) > 0 {
+		buf.Merge(s.line("<blue>"+s.lines[0]+"</>", y, false).Buffer())
+		y++
+	}
+	for i, line := range s.visibleLines() {
+		buf.Merge(s.line(line, y, i+s.startIndex == s.selectedIndex).Buffer())
+		y++
+	}
+	return buf
+}
+
+//ForImage sets the image whose history is shown
+func (s *ImageHistoryWidget) ForImage(id, name string) {
+	s.Lock()
+	defer s.Unlock()
+	if id != s.imageID {
+		s.history = nil
+		s.totalSize = 0
+		s.lines = nil
+	}
+	s.imageID = id
+	s.name = name
+	s.mounted = false
+}
+
+//ImageID returns the id of the image whose history is shown
+func (s *ImageHistoryWidget) ImageID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.imageID
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *ImageHistoryWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Reset()
+		s.loader.Load(s.fetchHistory)
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ImageHistoryWidget) Name() string {
+	return "ImageHistoryWidget"
+}
+
+//RowCount returns the number of entries of the history shown
+func (s *ImageHistoryWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.history)
+}
+
+//Sort sorts the history by the next sort mode, the history is shown newest
+//first, as docker history does, or by size, largest first
+func (s *ImageHistoryWidget) Sort() {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = (s.sortMode + 1) % historySort(len(historySortNames))
+	s.buildLines()
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ImageHistoryWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ImageHistoryWidget) fetchHistory(ctx context.Context) (func(), error) {
+	history, err := s.dockerDaemon.History(s.imageID)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		s.history = history
+		s.totalSize = 0
+		for _, h := range history {
+			s.totalSize += h.Size
+		}
+		s.buildLines()
+	}, nil
+}
+
+func (s *ImageHistoryWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	return fmt.Sprintf("<b><blue> | Size: </><yellow>%s</><blue> | Sorted by: </><yellow>%s</></> ",
+		units.HumanSize(float64(s.totalSize)), historySortNames[s.sortMode])
+}
+
+//buildLines sorts the history and builds the lines of the widget from it,
+//the first line has the column titles
+func (s *ImageHistoryWidget) buildLines() {
+	s.lines = nil
+	if s.history == nil {
+		return
+	}
+	history := make([]image.HistoryResponseItem, len(s.history))
+	copy(history, s.history)
+	if s.sortMode == historySortSize {
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].Size > history[j].Size
+		})
+	}
+	rows := make([][]string, 0, len(history))
+	for _, h := range history {
+		rows = append(rows, s.historyRow(h))
+	}
+	widths := make([]int, len(historyTitles))
+	for i, title := range historyTitles {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if n := utf8.RuneCountInString(value); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	line := func(values []string) string {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			switch i {
+			case len(values) - 1:
+				//the last column, the instruction, is not padded
+			case 2, 3:
+				value = padLeft(value, widths[i])
+			default:
+				value = padRight(value, widths[i])
+			}
+			cells[i] = value
+		}
+		return strings.Join(cells, "  ")
+	}
+	s.lines = append(s.lines, line(historyTitles))
+	for _, row := range rows {
+		s.lines = append(s.lines, line(row))
+	}
+}
+
+//historyRow returns the values of the columns of the given history entry
+func (s *ImageHistoryWidget) historyRow(h image.HistoryResponseItem) []string {
+	id := h.ID
+	if !strings.HasPrefix(id, "<") {
+		id = docker.ShortImageID(id)
+	}
+	share := "-"
+	if s.totalSize > 0 {
+		share = fmt.Sprintf("%.1f", float64(h.Size)*100/float64(s.totalSize))
+	}
+	return []string{
+		id,
+		docker.DurationForHumans(h.Created),
+		units.HumanSize(float64(h.Size)),
+		share,
+		instruction(h.CreatedBy),
+	}
+}
+
+func (s *ImageHistoryWidget) line(text string, y int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *ImageHistoryWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.history) {
+		index = len(s.history) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//visibleLines returns the lines of the history that fit on the widget
+func (s *ImageHistoryWidget) visibleLines() []string {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.lines) < 2 {
+		return nil
+	}
+	entries := s.lines[1:]
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(entries)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[s.startIndex:end]
+}
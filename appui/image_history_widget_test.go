@@ -0,0 +1,66 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/moncho/dry/ui"
+)
+
+//bloatedImageAPI has an image whose biggest layer is not the newest one
+type bloatedImageAPI struct {
+	noopImageAPI
+}
+
+func (i bloatedImageAPI) History(id string) ([]image.HistoryResponseItem, error) {
+	return []image.HistoryResponseItem{
+		{ID: "sha256:3c3b4e1a9b2f0d8e", CreatedBy: `/bin/sh -c #(nop)  CMD ["app"]`, Size: 0},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c apt-get update && apt-get install -y build-essential", Size: 750},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop) ADD file:4b03b5f5 in / ", Size: 250},
+	}, nil
+}
+
+func TestImageHistoryWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewImageHistoryWidget(bloatedImageAPI{}, 0)
+	w.ForImage("3c3b4e1a9b2f", "app:latest")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	if w.RowCount() != 3 {
+		t.Fatalf("Unexpected number of history entries: %d", w.RowCount())
+	}
+	if got := strings.Fields(w.lines[0]); strings.Join(got, " ") != "IMAGE CREATED SIZE % CREATED BY" {
+		t.Errorf("Unexpected titles line: %q", w.lines[0])
+	}
+	//the instruction column starts where its title does
+	column := strings.Index(w.lines[0], "CREATED BY")
+	instructions := func() string {
+		var result []string
+		for _, line := range w.lines[1:] {
+			result = append(result, line[column:column+3])
+		}
+		return strings.Join(result, ",")
+	}
+	if got := instructions(); got != "CMD,RUN,ADD" {
+		t.Errorf("History is not shown newest first: %s", got)
+	}
+	w.Sort()
+	if got := instructions(); got != "RUN,ADD,CMD" {
+		t.Errorf("History is not sorted by size: %s", got)
+	}
+	if !strings.Contains(w.lines[1], "75.0") {
+		t.Errorf("The share of the image size of the biggest layer is not shown: %q", w.lines[1])
+	}
+	w.Sort()
+	if got := instructions(); got != "CMD,RUN,ADD" {
+		t.Errorf("History is not shown newest first again: %s", got)
+	}
+}
@@ -0,0 +1,335 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//layerNode is a row of the tree of a layer
+type layerNode struct {
+	//tree drawing that goes before the label
+	prefix string
+	entry  *docker.LayerEntry
+}
+
+//ImageLayerWidget shows the content of a layer of an image as a tree of
+//directories, each one sized after everything in it and with its entries
+//largest first. Files deleted by the layer are marked as such.
+type ImageLayerWidget struct {
+	dockerDaemon  docker.ImageAPI
+	imageID       string
+	imageName     string
+	layerNumber   int
+	layerCount    int
+	layer         docker.ImageLayer
+	contents      *docker.LayerContents
+	expanded      map[*docker.LayerEntry]bool
+	nodes         []layerNode
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	//how much of the image has been read, in thousandths, updated while loading
+	read int64
+	sync.RWMutex
+}
+
+//NewImageLayerWidget creates an ImageLayerWidget
+func NewImageLayerWidget(dockerDaemon docker.ImageAPI, y int) *ImageLayerWidget {
+	w := &ImageLayerWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+		expanded:     make(map[*docker.LayerEntry]bool),
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ImageLayerWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	details := s.loader.HeaderDetails()
+	if s.loader.Loading() {
+		details = fmt.Sprintf("<b><blue> | </><yellow>Reading the saved image %d%%</></> <darkgrey>(Esc cancels)</>",
+			atomic.LoadInt64(&s.read)/10)
+	} else if s.contents != nil && s.contents.Truncated {
+		details = fmt.Sprintf("<b><blue> | </><yellow>Only the first %d entries are listed, the size of the rest is added to their directory</></>",
+			s.contents.Entries)
+	}
+	title := fmt.Sprintf("Layer %d/%d of %s", s.layerNumber, s.layerCount, s.imageName)
+	if s.layer.CreatedBy != "" {
+		title += " - " + truncateCell(s.layer.CreatedBy, s.width/2)
+	}
+	widgetHeader := WidgetHeader(title, len(s.nodes), details)
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	for i, node := range s.visibleNodes() {
+		par := termui.NewParFromMarkupText(DryTheme, s.row(node))
+		par.Border = false
+		par.Height = 1
+		par.Width = s.width
+		par.X = s.x
+		par.Y = y
+		par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+		if i+s.startIndex == s.selectedIndex {
+			par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+			par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+		}
+		buf.Merge(par.Buffer())
+		y++
+	}
+	return buf
+}
+
+//Collapse collapses the selected directory or, if it is not expanded, the
+//directory it is in. Returns false if there is nothing to collapse, the
+//root of the layer is never collapsed.
+func (s *ImageLayerWidget) Collapse() bool {
+	s.Lock()
+	defer s.Unlock()
+	if s.selectedIndex >= len(s.nodes) {
+		return false
+	}
+	index := s.selectedIndex
+	selected := s.nodes[index].entry
+	if !s.expanded[selected] {
+		if index = s.parentIndex(index); index < 0 {
+			return false
+		}
+		selected = s.nodes[index].entry
+	}
+	if selected == s.contents.Root {
+		return false
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(index)
+	s.selectedIndex = index
+	delete(s.expanded, selected)
+	s.buildNodes()
+	return true
+}
+
+//ForLayer sets the layer whose content is shown, the given number is its
+//position, starting at 1 for the base layer, among the count layers of the image
+func (s *ImageLayerWidget) ForLayer(imageID, imageName string, number, count int, layer docker.ImageLayer) {
+	s.Lock()
+	defer s.Unlock()
+	s.imageID = imageID
+	s.imageName = imageName
+	s.layerNumber = number
+	s.layerCount = count
+	s.layer = layer
+	s.mounted = false
+	s.loader.Cancel()
+}
+
+//ImageID returns the id of the image of the layer shown
+func (s *ImageLayerWidget) ImageID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.imageID
+}
+
+//Mount tells this widget to be ready for rendering. The image is saved, in
+//the background, and read until the layer is found.
+func (s *ImageLayerWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.contents = nil
+		s.nodes = nil
+		s.expanded = make(map[*docker.LayerEntry]bool)
+		s.loader.Reset()
+		id, diffID := s.imageID, s.layer.DiffID
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			return s.fetchLayer(ctx, id, diffID)
+		})
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ImageLayerWidget) Name() string {
+	return "ImageLayerWidget"
+}
+
+//RowCount returns the number of rows of this widget
+func (s *ImageLayerWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.nodes)
+}
+
+//ToggleExpand shows the content of the selected directory, or hides it if
+//it is already shown. Returns false if the selected entry is not a directory.
+func (s *ImageLayerWidget) ToggleExpand() bool {
+	s.Lock()
+	defer s.Unlock()
+	if s.selectedIndex >= len(s.nodes) {
+		return false
+	}
+	entry := s.nodes[s.selectedIndex].entry
+	if !entry.Dir || len(entry.Children) == 0 || entry == s.contents.Root {
+		return false
+	}
+	s.expanded[entry] = !s.expanded[entry]
+	s.buildNodes()
+	return true
+}
+
+//Unmount tells this widget that it will not be rendering anymore, reading
+//the image is canceled if it has not finished
+func (s *ImageLayerWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ImageLayerWidget) fetchLayer(ctx context.Context, id, diffID string) (func(), error) {
+	atomic.StoreInt64(&s.read, 0)
+	RenderRequest()
+	var last int64
+	contents, err := s.dockerDaemon.LayerContents(ctx, id, diffID,
+		func(done float64) {
+			read := int64(done * 1000)
+			atomic.StoreInt64(&s.read, read)
+			//a render per percent read is enough
+			if read/10 != last/10 {
+				RenderRequest()
+			}
+			last = read
+		})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		s.contents = contents
+		//the root of the layer is always expanded
+		s.expanded[contents.Root] = true
+		s.buildNodes()
+	}, nil
+}
+
+//buildNodes builds the rows of the tree, directories are expanded if the
+//user asked to
+func (s *ImageLayerWidget) buildNodes() {
+	s.nodes = nil
+	if s.contents == nil {
+		return
+	}
+	s.nodes = append(s.nodes, layerNode{entry: s.contents.Root})
+	s.addChildren(s.contents.Root, "")
+}
+
+func (s *ImageLayerWidget) addChildren(dir *docker.LayerEntry, prefix string) {
+	for i, child := range dir.Children {
+		last := i == len(dir.Children)-1
+		s.nodes = append(s.nodes, layerNode{prefix: prefix + treeBranch(last), entry: child})
+		if child.Dir && s.expanded[child] {
+			s.addChildren(child, prefix+treeIndent(last))
+		}
+	}
+}
+
+//parentIndex returns the index of the node of the directory the node on
+//the given index is in, -1 for the root
+func (s *ImageLayerWidget) parentIndex(index int) int {
+	depth := len([]rune(s.nodes[index].prefix))
+	for i := index - 1; i >= 0; i-- {
+		if len([]rune(s.nodes[i].prefix)) < depth {
+			return i
+		}
+	}
+	return -1
+}
+
+//row returns the row of the given node, its size goes first so that sizes
+//are aligned
+func (s *ImageLayerWidget) row(node layerNode) string {
+	entry := node.entry
+	size := fmt.Sprintf("<yellow>%10s</>  ", units.HumanSize(float64(entry.Size)))
+	if entry.Deleted {
+		return fmt.Sprintf("%10s  %s<red>%s</> <darkgrey>(deleted)</>", "", node.prefix, entry.Name)
+	}
+	if !entry.Dir {
+		return size + node.prefix + entry.Name
+	}
+	label := "<blue>" + entry.Name + "</>"
+	if entry != s.contents.Root {
+		label = "<blue>" + entry.Name + "/</>"
+		switch {
+		case len(entry.Children) == 0:
+			label = "  " + label
+		case s.expanded[entry]:
+			label = "<b>-</> " + label
+		default:
+			label = "<b>+</> " + label
+		}
+	}
+	if entry.Opaque {
+		label += " <darkgrey>(replaces the directory of the layers below)</>"
+	}
+	return size + node.prefix + label
+}
+
+func (s *ImageLayerWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.nodes) {
+		index = len(s.nodes) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+func (s *ImageLayerWidget) visibleNodes() []layerNode {
+	//the widget header takes a line
+	height := s.height - 1
+	if height <= 0 || len(s.nodes) == 0 {
+		return nil
+	}
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(s.nodes)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(s.nodes) {
+		end = len(s.nodes)
+	}
+	return s.nodes[s.startIndex:end]
+}
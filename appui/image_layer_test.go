@@ -0,0 +1,89 @@
+package appui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//layeredImageAPI returns a layer with a directory of dependencies
+type layeredImageAPI struct {
+	noopImageAPI
+}
+
+func (i layeredImageAPI) LayerContents(ctx context.Context, id, diffID string, progress func(float64)) (*docker.LayerContents, error) {
+	modules := &docker.LayerEntry{Name: "node_modules", Dir: true, Size: 300, Children: []*docker.LayerEntry{
+		{Name: "left-pad", Dir: true, Size: 300, Children: []*docker.LayerEntry{{Name: "index.js", Size: 300}}},
+	}}
+	app := &docker.LayerEntry{Name: "app", Dir: true, Size: 310, Children: []*docker.LayerEntry{
+		modules,
+		{Name: "main.js", Size: 10},
+	}}
+	root := &docker.LayerEntry{Name: "/", Dir: true, Size: 310, Children: []*docker.LayerEntry{
+		app,
+		{Name: "passwd", Deleted: true},
+	}}
+	progress(1)
+	return &docker.LayerContents{Root: root, Entries: 6}, nil
+}
+
+func TestImageLayerWidget_Navigation(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	ui.ActiveScreen.Cursor.Max(100)
+	w := NewImageLayerWidget(layeredImageAPI{}, 0)
+	w.ForLayer("8dfafdbc3a40", "dry/dry:1", 2, 3, docker.ImageLayer{DiffID: "sha256:app", CreatedBy: "COPY . /app"})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+
+	// /, app, passwd
+	if w.RowCount() != 3 {
+		t.Fatalf("Unexpected number of rows: %d", w.RowCount())
+	}
+	if row := w.row(w.nodes[2]); !strings.Contains(row, "passwd") || !strings.Contains(row, "deleted") {
+		t.Errorf("Whiteouts should be shown as deleted, got %s", row)
+	}
+
+	ui.ActiveScreen.Cursor.ScrollTo(1)
+	w.prepareForRendering()
+	if !w.ToggleExpand() {
+		t.Fatal("app could not be expanded")
+	}
+	// /, app, node_modules, main.js, passwd
+	if w.RowCount() != 5 {
+		t.Fatalf("Unexpected number of rows after expanding app: %d", w.RowCount())
+	}
+	for _, dir := range []int{2, 3} {
+		ui.ActiveScreen.Cursor.ScrollTo(dir)
+		w.prepareForRendering()
+		w.ToggleExpand()
+	}
+	// /, app, node_modules, left-pad, index.js, main.js, passwd
+	ui.ActiveScreen.Cursor.ScrollTo(4)
+	w.prepareForRendering()
+	if w.ToggleExpand() {
+		t.Error("A file could be expanded")
+	}
+	if w.RowCount() != 7 {
+		t.Fatalf("Unexpected number of rows after expanding left-pad: %d", w.RowCount())
+	}
+
+	//collapsing index.js collapses the directory it is in
+	if !w.Collapse() {
+		t.Fatal("The directory of index.js could not be collapsed")
+	}
+	if w.RowCount() != 6 || ui.ActiveScreen.Cursor.Position() != 3 {
+		t.Errorf("After collapsing left-pad: %d rows, cursor on %d, want 6 rows, cursor on 3",
+			w.RowCount(), ui.ActiveScreen.Cursor.Position())
+	}
+	if w.ImageID() != "8dfafdbc3a40" {
+		t.Errorf("Unexpected image id: %s", w.ImageID())
+	}
+}
@@ -0,0 +1,274 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+var manifestTitles = []string{"PLATFORM", "DIGEST", "SIZE", "LAYERS"}
+
+//ImageManifestWidget shows the platforms a registry has an image reference
+//for, with the digest and size of the image of each one
+type ImageManifestWidget struct {
+	inspector     docker.ManifestInspector
+	imageID       string
+	ref           string
+	manifest      *docker.ManifestList
+	lines         []string
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewImageManifestWidget creates an ImageManifestWidget that fetches
+//manifests with the given inspector
+func NewImageManifestWidget(inspector docker.ManifestInspector, y int) *ImageManifestWidget {
+	w := &ImageManifestWidget{
+		inspector: inspector,
+		y:         y,
+		height:    MainScreenAvailableHeight(),
+		width:     ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ImageManifestWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	count := 0
+	if s.manifest != nil {
+		count = len(s.manifest.Platforms)
+	}
+	widgetHeader := WidgetHeader("Platforms of "+s.ref, count, s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if len(s.lines) > 0 {
+		buf.Merge(s.line("<blue>"+s.lines[0]+"</>", y, false).Buffer())
+		y++
+	}
+	for i, line := range s.visibleLines() {
+		buf.Merge(s.line(line, y, i+s.startIndex == s.selectedIndex).Buffer())
+		y++
+	}
+	return buf
+}
+
+//ForReference sets the image reference whose manifest is shown, the id is
+//the one of the image on the image list it was chosen from, if any
+func (s *ImageManifestWidget) ForReference(imageID, ref string) {
+	s.Lock()
+	defer s.Unlock()
+	if imageID != "" {
+		s.imageID = imageID
+	}
+	s.ref = ref
+	s.manifest = nil
+	s.lines = nil
+	s.startIndex = 0
+	s.mounted = false
+	s.loader.Cancel()
+}
+
+//ImageID returns the id of the image the reference shown was chosen from
+func (s *ImageManifestWidget) ImageID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.imageID
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *ImageManifestWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Reset()
+		s.loader.Load(s.fetchManifest)
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ImageManifestWidget) Name() string {
+	return "ImageManifestWidget"
+}
+
+//RowCount returns the number of platforms shown
+func (s *ImageManifestWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	if s.manifest == nil {
+		return 0
+	}
+	return len(s.manifest.Platforms)
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ImageManifestWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ImageManifestWidget) fetchManifest(ctx context.Context) (func(), error) {
+	manifest, err := s.inspector.InspectManifest(ctx, s.ref)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		s.manifest = manifest
+		s.buildLines()
+	}, nil
+}
+
+func (s *ImageManifestWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	if s.manifest == nil {
+		return ""
+	}
+	kind := "single image"
+	if s.manifest.List {
+		kind = "manifest list"
+	}
+	return fmt.Sprintf("<b><blue> | Digest: </><yellow>%s</><blue> | Type: </><yellow>%s</></> ",
+		s.manifest.Digest, kind)
+}
+
+//buildLines builds the lines of the widget from the manifest, the first
+//line has the column titles
+func (s *ImageManifestWidget) buildLines() {
+	s.lines = nil
+	if s.manifest == nil {
+		return
+	}
+	rows := make([][]string, 0, len(s.manifest.Platforms))
+	for _, p := range s.manifest.Platforms {
+		rows = append(rows, manifestRow(p))
+	}
+	widths := make([]int, len(manifestTitles))
+	for i, title := range manifestTitles {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if n := utf8.RuneCountInString(value); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	line := func(values []string) string {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			switch i {
+			case 2, 3:
+				value = padLeft(value, widths[i])
+			default:
+				value = padRight(value, widths[i])
+			}
+			cells[i] = value
+		}
+		return strings.Join(cells, "  ")
+	}
+	s.lines = append(s.lines, line(manifestTitles))
+	for _, row := range rows {
+		s.lines = append(s.lines, line(row))
+	}
+}
+
+//manifestRow returns the values of the columns of the image of the given
+//platform
+func manifestRow(p docker.PlatformManifest) []string {
+	size, layers := "-", "-"
+	if p.Size >= 0 {
+		size = units.HumanSize(float64(p.Size))
+		layers = strconv.Itoa(p.Layers)
+	}
+	return []string{p.Platform, p.Digest, size, layers}
+}
+
+func (s *ImageManifestWidget) line(text string, y int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *ImageManifestWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.lines)-1 {
+		index = len(s.lines) - 2
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//visibleLines returns the lines of the platforms that fit on the widget
+func (s *ImageManifestWidget) visibleLines() []string {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.lines) < 2 {
+		return nil
+	}
+	platforms := s.lines[1:]
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(platforms)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(platforms) {
+		end = len(platforms)
+	}
+	return platforms[s.startIndex:end]
+}
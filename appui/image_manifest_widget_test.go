@@ -0,0 +1,62 @@
+package appui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//multiArchInspector has a manifest list for every reference, the manifest
+//of its arm64 image could not be read
+type multiArchInspector struct{}
+
+func (multiArchInspector) InspectManifest(ctx context.Context, ref string) (*docker.ManifestList, error) {
+	return &docker.ManifestList{
+		Reference: ref,
+		Digest:    "sha256:5f7e4a1c",
+		List:      true,
+		Platforms: []docker.PlatformManifest{
+			{Platform: "linux/amd64", Digest: "sha256:1b2d3f4e", Size: 3 * 1000 * 1000, Layers: 4},
+			{Platform: "linux/arm64/v8", Digest: "sha256:9c8b7a6d", Size: -1},
+		},
+	}, nil
+}
+
+func TestImageManifestWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewImageManifestWidget(multiArchInspector{}, 0)
+	w.ForReference("3c3b4e1a9b2f", "nginx:latest")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	if w.RowCount() != 2 {
+		t.Fatalf("Unexpected number of platforms: %d", w.RowCount())
+	}
+	if got := strings.Join(strings.Fields(w.lines[0]), " "); got != "PLATFORM DIGEST SIZE LAYERS" {
+		t.Errorf("Unexpected titles line: %q", w.lines[0])
+	}
+	if got := strings.Join(strings.Fields(w.lines[1]), " "); got != "linux/amd64 sha256:1b2d3f4e 3MB 4" {
+		t.Errorf("Unexpected line of the amd64 image: %q", w.lines[1])
+	}
+	if got := strings.Join(strings.Fields(w.lines[2]), " "); got != "linux/arm64/v8 sha256:9c8b7a6d - -" {
+		t.Errorf("Unexpected line of the arm64 image: %q", w.lines[2])
+	}
+	if !strings.Contains(w.headerDetails(), "manifest list") {
+		t.Errorf("The header does not tell the reference is a manifest list: %s", w.headerDetails())
+	}
+
+	//another reference keeps the image it was chosen from
+	w.ForReference("", "redis:latest")
+	if w.ImageID() != "3c3b4e1a9b2f" || w.RowCount() != 0 {
+		t.Errorf("Unexpected widget state after changing the reference: %s, %d", w.ImageID(), w.RowCount())
+	}
+}
@@ -1,6 +1,8 @@
 package appui
 
 import (
+	"fmt"
+
 	"github.com/docker/docker/api/types"
 	termui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker/formatter"
@@ -10,6 +12,7 @@ import (
 //ImageRow is a Grid row showing information about a Docker image
 type ImageRow struct {
 	image             types.ImageSummary
+	repository        string
 	Repository        *drytermui.ParColumn
 	Tag               *drytermui.ParColumn
 	ID                *drytermui.ParColumn
@@ -21,12 +24,13 @@ type ImageRow struct {
 	Row
 }
 
-//NewImageRow creates a new ImageRow widget
-func NewImageRow(image types.ImageSummary, table drytermui.Table) *ImageRow {
+//NewImageRow creates a new ImageRow widget, without the given hidden columns
+func NewImageRow(image types.ImageSummary, table drytermui.Table, hidden hiddenColumns) *ImageRow {
 	iformatter := formatter.NewImageFormatter(image, true)
 
 	row := &ImageRow{
 		image:             image,
+		repository:        iformatter.Repository(),
 		Repository:        drytermui.NewThemedParColumn(DryTheme, iformatter.Repository()),
 		Tag:               drytermui.NewThemedParColumn(DryTheme, iformatter.Tag()),
 		ID:                drytermui.NewThemedParColumn(DryTheme, iformatter.ID()),
@@ -38,13 +42,13 @@ func NewImageRow(image types.ImageSummary, table drytermui.Table) *ImageRow {
 	row.Height = 1
 	row.Table = table
 	//Columns are rendered following the slice order
-	row.Columns = []termui.GridBufferer{
+	row.Columns = hidden.visible(imageTableHeaders, []termui.GridBufferer{
 		row.Repository,
 		row.Tag,
 		row.ID,
 		row.CreatedSince,
 		row.Size,
-	}
+	})
 	row.ParColumns = []*drytermui.ParColumn{
 		row.Repository,
 		row.Tag,
@@ -61,3 +65,12 @@ func NewImageRow(image types.ImageSummary, table drytermui.Table) *ImageRow {
 func (row *ImageRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.Repository, row.Tag, row.ID}
 }
+
+//SetChildren shows on the row how many local images are built on its image
+func (row *ImageRow) SetChildren(children int) {
+	if children == 0 {
+		row.Repository.Content(row.repository)
+		return
+	}
+	row.Repository.Content(fmt.Sprintf("%s (base of %d)", row.repository, children))
+}
@@ -0,0 +1,331 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+var scanTitles = []string{"VULNERABILITY", "PACKAGE", "INSTALLED", "FIXED", "TITLE"}
+
+//severityColors are the colors each severity is shown with
+var severityColors = map[string]string{
+	docker.SeverityCritical: "<b><red>%s</></>",
+	docker.SeverityHigh:     "<red>%s</>",
+	docker.SeverityMedium:   "<yellow>%s</>",
+	docker.SeverityLow:      "<white>%s</>",
+	docker.SeverityUnknown:  "<darkgrey>%s</>",
+}
+
+//ImageScanWidget shows the vulnerabilities a scanner finds on an image,
+//grouped by severity, most severe first
+type ImageScanWidget struct {
+	scanner       docker.ImageScanner
+	imageID       string
+	name          string
+	report        *docker.ScanReport
+	fixableOnly   bool
+	lines         []string
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewImageScanWidget creates an ImageScanWidget
+func NewImageScanWidget(y int) *ImageScanWidget {
+	w := &ImageScanWidget{
+		y:      y,
+		height: MainScreenAvailableHeight(),
+		width:  ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ImageScanWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	count := 0
+	if s.report != nil {
+		count = len(s.report.Vulnerabilities)
+	}
+	widgetHeader := WidgetHeader("Vulnerabilities of "+s.name, count, s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if len(s.lines) > 0 {
+		buf.Merge(s.line("<blue>"+s.lines[0]+"</>", y, false).Buffer())
+		y++
+	}
+	for i, line := range s.visibleLines() {
+		buf.Merge(s.line(line, y, i+s.startIndex == s.selectedIndex).Buffer())
+		y++
+	}
+	return buf
+}
+
+//ForImage sets the image that is scanned, with the given name, and the
+//scanner it is scanned with
+func (s *ImageScanWidget) ForImage(id, name string, scanner docker.ImageScanner) {
+	s.Lock()
+	defer s.Unlock()
+	s.report = nil
+	s.lines = nil
+	s.startIndex = 0
+	s.imageID = id
+	s.name = name
+	s.scanner = scanner
+	s.mounted = false
+	s.loader.Cancel()
+}
+
+//ImageID returns the id of the image that is scanned
+func (s *ImageScanWidget) ImageID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.imageID
+}
+
+//Mount tells this widget to be ready for rendering, the image is scanned
+//unless it already was
+func (s *ImageScanWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		if s.report == nil && s.scanner != nil {
+			s.loader.Reset()
+			s.loader.Load(s.scan)
+		}
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ImageScanWidget) Name() string {
+	return "ImageScanWidget"
+}
+
+//Rescan scans the image again, with the given scanner
+func (s *ImageScanWidget) Rescan(scanner docker.ImageScanner) {
+	s.Lock()
+	defer s.Unlock()
+	s.report = nil
+	s.lines = nil
+	s.scanner = scanner
+	s.mounted = false
+	s.loader.Cancel()
+}
+
+//RowCount returns the number of lines shown, the severities and their
+//vulnerabilities
+func (s *ImageScanWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	if len(s.lines) == 0 {
+		return 0
+	}
+	return len(s.lines) - 1
+}
+
+//ToggleFixableOnly shows only the vulnerabilities with a fixed version, or
+//every vulnerability again
+func (s *ImageScanWidget) ToggleFixableOnly() {
+	s.Lock()
+	defer s.Unlock()
+	s.fixableOnly = !s.fixableOnly
+	s.startIndex = 0
+	s.buildLines()
+}
+
+//Unmount tells this widget that it will not be rendering anymore, a scan
+//that has not finished is canceled
+func (s *ImageScanWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	if s.report == nil {
+		s.loader.Cancel()
+	}
+	return nil
+}
+
+func (s *ImageScanWidget) scan(ctx context.Context) (func(), error) {
+	report, err := s.scanner.Scan(ctx, s.name)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		s.report = report
+		s.buildLines()
+	}, nil
+}
+
+func (s *ImageScanWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	if s.report == nil {
+		return ""
+	}
+	if len(s.report.Vulnerabilities) == 0 {
+		return "<b><blue> | </><green>No vulnerabilities found</></> "
+	}
+	groups := s.report.BySeverity()
+	var counts []string
+	for _, severity := range docker.Severities {
+		if n := len(groups[severity]); n > 0 {
+			counts = append(counts, fmt.Sprintf(severityColors[severity], fmt.Sprintf("%s: %d", severity, n)))
+		}
+	}
+	details := "<b><blue> | </>" + strings.Join(counts, " ")
+	if s.fixableOnly {
+		details += "<blue> | Showing: </><yellow>fixable only</>"
+	}
+	return details + "</> "
+}
+
+//buildLines builds the lines of the widget from the report, the first line
+//has the column titles, each severity found is followed by its
+//vulnerabilities
+func (s *ImageScanWidget) buildLines() {
+	s.lines = nil
+	if s.report == nil {
+		return
+	}
+	groups := s.report.BySeverity()
+	widths := make([]int, len(scanTitles))
+	for i, title := range scanTitles {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	rows := make(map[string][][]string)
+	for _, severity := range docker.Severities {
+		for _, v := range groups[severity] {
+			if s.fixableOnly && v.Fixed == "" {
+				continue
+			}
+			row := scanRow(v)
+			for i, value := range row {
+				if n := utf8.RuneCountInString(value); n > widths[i] {
+					widths[i] = n
+				}
+			}
+			rows[severity] = append(rows[severity], row)
+		}
+	}
+	line := func(values []string) string {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			if i < len(values)-1 {
+				//the last column, the title, is not padded
+				value = padRight(value, widths[i])
+			}
+			cells[i] = value
+		}
+		return strings.Join(cells, "  ")
+	}
+	s.lines = append(s.lines, line(scanTitles))
+	for _, severity := range docker.Severities {
+		if len(rows[severity]) == 0 {
+			continue
+		}
+		s.lines = append(s.lines,
+			fmt.Sprintf(severityColors[severity], fmt.Sprintf("%s (%d)", severity, len(rows[severity]))))
+		for _, row := range rows[severity] {
+			s.lines = append(s.lines, line(row))
+		}
+	}
+}
+
+//scanRow returns the values of the columns of the given vulnerability
+func scanRow(v docker.Vulnerability) []string {
+	fixed := v.Fixed
+	if fixed == "" {
+		fixed = "-"
+	}
+	return []string{
+		v.ID,
+		v.Package,
+		v.Installed,
+		fixed,
+		strings.Join(strings.Fields(v.Title), " "),
+	}
+}
+
+func (s *ImageScanWidget) line(text string, y int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *ImageScanWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.lines)-1 {
+		index = len(s.lines) - 2
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//visibleLines returns the lines of the report that fit on the widget
+func (s *ImageScanWidget) visibleLines() []string {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.lines) < 2 {
+		return nil
+	}
+	entries := s.lines[1:]
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(entries)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[s.startIndex:end]
+}
@@ -0,0 +1,65 @@
+package appui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//vulnerableScanner finds the same vulnerabilities on every image
+type vulnerableScanner struct{}
+
+func (vulnerableScanner) Scan(ctx context.Context, image string) (*docker.ScanReport, error) {
+	return &docker.ScanReport{Image: image, Vulnerabilities: []docker.Vulnerability{
+		{ID: "CVE-2005-2541", Package: "tar", Installed: "1.34", Severity: docker.SeverityLow},
+		{ID: "CVE-2023-0286", Package: "openssl", Installed: "1.1.1n", Fixed: "1.1.1t", Severity: docker.SeverityHigh},
+		{ID: "CVE-2023-0464", Package: "openssl", Installed: "1.1.1n", Fixed: "1.1.1u", Severity: docker.SeverityCritical},
+		{ID: "CVE-2022-3715", Package: "bash", Installed: "5.1", Severity: docker.SeverityHigh},
+	}}, nil
+}
+
+func TestImageScanWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewImageScanWidget(0)
+	w.ForImage("3c3b4e1a9b2f", "nginx:latest", vulnerableScanner{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	//three severities and four vulnerabilities
+	if w.RowCount() != 7 {
+		t.Fatalf("Unexpected number of lines: %d", w.RowCount())
+	}
+	groups := func() string {
+		var result []string
+		for _, line := range w.lines[1:] {
+			if strings.HasPrefix(line, "<") {
+				result = append(result, line)
+			}
+		}
+		return strings.Join(result, ",")
+	}
+	if got := groups(); got != "<b><red>CRITICAL (1)</></>,<red>HIGH (2)</>,<white>LOW (1)</>" {
+		t.Errorf("Vulnerabilities are not grouped by severity, most severe first: %s", got)
+	}
+	if !strings.HasPrefix(w.lines[5], "CVE-2022-3715") || !strings.Contains(w.lines[5], " - ") {
+		t.Errorf("Unexpected line of a vulnerability without fix: %q", w.lines[5])
+	}
+
+	w.ToggleFixableOnly()
+	if got := groups(); got != "<b><red>CRITICAL (1)</></>,<red>HIGH (1)</>" {
+		t.Errorf("Vulnerabilities without fix are shown: %s", got)
+	}
+	w.ToggleFixableOnly()
+	if w.RowCount() != 7 {
+		t.Errorf("Every vulnerability is not shown again: %d", w.RowCount())
+	}
+}
@@ -0,0 +1,315 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/docker/docker/api/types/image"
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+var layerSizeTitles = []string{"SIZE", "%", "SHARE", "CREATED BY"}
+
+//Bar widths of the layer size chart, the bar takes a third of the screen
+//between them
+const (
+	minLayerBarWidth = 10
+	maxLayerBarWidth = 60
+)
+
+//ImageSizesWidget shows the size of each layer of an image as a bar, its
+//share of the image size, so the layers that make the image big stand out
+type ImageSizesWidget struct {
+	dockerDaemon  docker.ImageAPI
+	imageID       string
+	name          string
+	layers        []image.HistoryResponseItem
+	totalSize     int64
+	sortMode      historySort
+	lines         []string
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewImageSizesWidget creates an ImageSizesWidget, layers are shown largest
+//first
+func NewImageSizesWidget(dockerDaemon docker.ImageAPI, y int) *ImageSizesWidget {
+	w := &ImageSizesWidget{
+		dockerDaemon: dockerDaemon,
+		sortMode:     historySortSize,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *ImageSizesWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	widgetHeader := WidgetHeader("Layer sizes of "+s.name, len(s.layers), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if len(s.lines) > 0 {
+		buf.Merge(s.line("<blue>"+s.lines[0]+"</>", y, false).Buffer())
+		y++
+	}
+	for i, line := range s.visibleLines() {
+		buf.Merge(s.line(line, y, i+s.startIndex == s.selectedIndex).Buffer())
+		y++
+	}
+	return buf
+}
+
+//ForImage sets the image whose layers are shown
+func (s *ImageSizesWidget) ForImage(id, name string) {
+	s.Lock()
+	defer s.Unlock()
+	if id != s.imageID {
+		s.layers = nil
+		s.totalSize = 0
+		s.lines = nil
+		s.startIndex = 0
+	}
+	s.imageID = id
+	s.name = name
+	s.mounted = false
+}
+
+//ImageID returns the id of the image whose layers are shown
+func (s *ImageSizesWidget) ImageID() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.imageID
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *ImageSizesWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Reset()
+		s.loader.Load(s.fetchLayers)
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *ImageSizesWidget) Name() string {
+	return "ImageSizesWidget"
+}
+
+//RowCount returns the number of layers shown
+func (s *ImageSizesWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.layers)
+}
+
+//Sort shows the layers by the next sort mode, largest first or in the order
+//they were created, newest first
+func (s *ImageSizesWidget) Sort() {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = (s.sortMode + 1) % historySort(len(historySortNames))
+	s.buildLines()
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *ImageSizesWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *ImageSizesWidget) fetchLayers(ctx context.Context) (func(), error) {
+	history, err := s.dockerDaemon.History(s.imageID)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		//instructions that only change the metadata of the image add no
+		//layer, or an empty one
+		s.layers = nil
+		s.totalSize = 0
+		for _, h := range history {
+			if h.Size > 0 {
+				s.layers = append(s.layers, h)
+				s.totalSize += h.Size
+			}
+		}
+		s.buildLines()
+	}, nil
+}
+
+func (s *ImageSizesWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	return fmt.Sprintf("<b><blue> | Size: </><yellow>%s</><blue> | Sorted by: </><yellow>%s</></> ",
+		units.HumanSize(float64(s.totalSize)), historySortNames[s.sortMode])
+}
+
+//barWidth returns the width of the bars for the current width of the widget
+func (s *ImageSizesWidget) barWidth() int {
+	width := s.width / 3
+	if width < minLayerBarWidth {
+		return minLayerBarWidth
+	}
+	if width > maxLayerBarWidth {
+		return maxLayerBarWidth
+	}
+	return width
+}
+
+//buildLines sorts the layers and builds the lines of the widget from them,
+//the first line has the column titles
+func (s *ImageSizesWidget) buildLines() {
+	s.lines = nil
+	if s.layers == nil {
+		return
+	}
+	layers := make([]image.HistoryResponseItem, len(s.layers))
+	copy(layers, s.layers)
+	if s.sortMode == historySortSize {
+		sort.SliceStable(layers, func(i, j int) bool {
+			return layers[i].Size > layers[j].Size
+		})
+	}
+	barWidth := s.barWidth()
+	sizes := make([]string, len(layers))
+	shares := make([]string, len(layers))
+	sizeWidth := utf8.RuneCountInString(layerSizeTitles[0])
+	shareWidth := utf8.RuneCountInString(layerSizeTitles[1])
+	for i, layer := range layers {
+		sizes[i] = units.HumanSize(float64(layer.Size))
+		shares[i] = fmt.Sprintf("%.1f", s.share(layer))
+		if n := utf8.RuneCountInString(sizes[i]); n > sizeWidth {
+			sizeWidth = n
+		}
+		if n := utf8.RuneCountInString(shares[i]); n > shareWidth {
+			shareWidth = n
+		}
+	}
+	s.lines = append(s.lines, strings.Join([]string{
+		padLeft(layerSizeTitles[0], sizeWidth),
+		padLeft(layerSizeTitles[1], shareWidth),
+		padRight(layerSizeTitles[2], barWidth),
+		layerSizeTitles[3]}, "  "))
+	for i, layer := range layers {
+		share := s.share(layer)
+		s.lines = append(s.lines, strings.Join([]string{
+			padLeft(sizes[i], sizeWidth),
+			padLeft(shares[i], shareWidth),
+			fmt.Sprintf(shareColor(share), progressBar(share/100, barWidth)),
+			instruction(layer.CreatedBy)}, "  "))
+	}
+}
+
+//share returns the share of the image size the given layer takes, as a
+//percentage
+func (s *ImageSizesWidget) share(layer image.HistoryResponseItem) float64 {
+	if s.totalSize <= 0 {
+		return 0
+	}
+	return float64(layer.Size) * 100 / float64(s.totalSize)
+}
+
+//shareColor returns the markup the bar of a layer with the given share of
+//the image size is drawn with, the bigger the share the warmer the color
+func shareColor(share float64) string {
+	switch {
+	case share >= 50:
+		return "<red>%s</>"
+	case share >= 20:
+		return "<yellow>%s</>"
+	}
+	return "<green>%s</>"
+}
+
+func (s *ImageSizesWidget) line(text string, y int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *ImageSizesWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+		//bars take a share of the width
+		s.buildLines()
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.layers) {
+		index = len(s.layers) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//visibleLines returns the lines of the layers that fit on the widget
+func (s *ImageSizesWidget) visibleLines() []string {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.lines) < 2 {
+		return nil
+	}
+	layers := s.lines[1:]
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(layers)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(layers) {
+		end = len(layers)
+	}
+	return layers[s.startIndex:end]
+}
@@ -0,0 +1,57 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/moncho/dry/ui"
+)
+
+//sizedLayersImageAPI has an image whose biggest layer is in the middle of its
+//history
+type sizedLayersImageAPI struct {
+	noopImageAPI
+}
+
+func (i sizedLayersImageAPI) History(id string) ([]image.HistoryResponseItem, error) {
+	return []image.HistoryResponseItem{
+		{ID: "sha256:3c3b4e1a9b2f0d8e", CreatedBy: `/bin/sh -c #(nop)  CMD ["app"]`, Size: 0},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop) COPY dir:6f1a2b3c in /app ", Size: 100},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c apt-get update && apt-get install -y build-essential", Size: 600},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop) ADD file:4b03b5f5 in / ", Size: 300},
+	}, nil
+}
+
+func TestImageSizesWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewImageSizesWidget(sizedLayersImageAPI{}, 0)
+	w.ForImage("3c3b4e1a9b2f", "app:latest")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+
+	//the instruction that only sets the command adds no layer
+	if w.RowCount() != 3 {
+		t.Fatalf("Unexpected number of layers: %d", w.RowCount())
+	}
+	//bars take a third of the width of the screen
+	if !strings.Contains(w.lines[1], "60.0  <red>"+progressBar(0.6, 40)+"</>  RUN apt-get") {
+		t.Errorf("The biggest layer is not shown first with a bar of its share: %q", w.lines[1])
+	}
+	if !strings.Contains(w.lines[2], "30.0  <yellow>") || !strings.HasSuffix(w.lines[2], "ADD file:4b03b5f5 in /") {
+		t.Errorf("Unexpected line of the second biggest layer: %q", w.lines[2])
+	}
+	if !strings.Contains(w.lines[3], "10.0  <green>") {
+		t.Errorf("Unexpected line of the smallest layer: %q", w.lines[3])
+	}
+	w.Sort()
+	if !strings.HasSuffix(w.lines[1], "COPY dir:6f1a2b3c in /app") || !strings.HasSuffix(w.lines[3], "ADD file:4b03b5f5 in /") {
+		t.Errorf("Layers are not shown newest first: %q", strings.Join(w.lines[1:], "\n"))
+	}
+}
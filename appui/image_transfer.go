@@ -0,0 +1,166 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	units "github.com/docker/go-units"
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+const (
+	transferWidgetWidth = 90
+	transferBarWidth    = 30
+	transferOptions     = "<b>[Esc/Enter]:<darkgrey>Close, the transfer goes on as a background job</>"
+)
+
+//TransferWidget shows the progress of the pull or the push of an image,
+//with a bar for each of its layers, until the user closes it
+type TransferWidget struct {
+	title    string
+	progress docker.TransferProgress
+	result   string
+	err      error
+	sync.RWMutex
+}
+
+//NewTransferWidget creates a TransferWidget with the given title
+func NewTransferWidget(title string) *TransferWidget {
+	return &TransferWidget{title: title}
+}
+
+//Update shows the given progress of the transfer
+func (w *TransferWidget) Update(progress docker.TransferProgress) {
+	w.Lock()
+	w.progress = progress
+	w.Unlock()
+	RenderRequest()
+}
+
+//Finish tells the widget that the transfer finished, with the given result
+//or, if it failed, the given error
+func (w *TransferWidget) Finish(result string, err error) {
+	w.Lock()
+	w.result = result
+	w.err = err
+	w.Unlock()
+	RenderRequest()
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (w *TransferWidget) Buffer() gtermui.Buffer {
+	w.RLock()
+	lines := transferLines(w.progress, w.result, w.err)
+	w.RUnlock()
+
+	screenWidth := ui.ActiveScreen.Dimensions.Width
+	screenHeight := ui.ActiveScreen.Dimensions.Height
+	//the layers that do not fit are left out, the options are kept
+	if max := screenHeight - 2; len(lines) > max && max > 2 {
+		lines = append(lines[:max-2], "", transferOptions)
+	}
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	par := termui.NewParFromMarkupText(DryTheme, buf.String())
+	par.Width = transferWidgetWidth
+	if par.Width > screenWidth {
+		par.Width = screenWidth
+	}
+	par.Height = len(lines) + 2
+	par.X = (screenWidth - par.Width) / 2
+	par.Y = (screenHeight - par.Height) / 2
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = " " + w.title + " "
+	par.BorderFg = gtermui.Attribute(DryTheme.Footer)
+	par.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
+	return par.Buffer()
+}
+
+//Mount callback
+func (w *TransferWidget) Mount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (w *TransferWidget) Name() string {
+	return "TransferWidget"
+}
+
+//OnFocus shows the progress of the transfer until the user closes the widget,
+//it is a blocking call.
+func (w *TransferWidget) OnFocus(event ui.EventSource) error {
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		if ev.Key == termbox.KeyEsc || ev.Key == termbox.KeyEnter {
+			return nil
+		}
+	}
+	return nil
+}
+
+//Unmount callback
+func (w *TransferWidget) Unmount() error {
+	return nil
+}
+
+//transferLines returns the text of the given progress of a transfer, a line
+//for each layer, result is not empty once the transfer finished
+func transferLines(p docker.TransferProgress, result string, err error) []string {
+	status, tag := p.Status, "white"
+	switch {
+	case err != nil:
+		status, tag = err.Error(), "red"
+	case result != "":
+		status, tag = result, "green"
+	case status == "":
+		status = "Waiting for the registry" + ui.DotsGlyph.String()
+	}
+	lines := []string{fmt.Sprintf("<%s>%s</>", tag, status), ""}
+	for _, layer := range p.Layers {
+		lines = append(lines, fmt.Sprintf("%-12s %s %s",
+			docker.TruncateID(layer.ID), progressBar(layer.Fraction(), transferBarWidth), layerStatus(layer)))
+	}
+	if len(p.Layers) > 0 {
+		lines = append(lines, "")
+	}
+	return append(lines, transferOptions)
+}
+
+//layerStatus returns the status of the transfer of the given layer, with the
+//bytes of its current step
+func layerStatus(l docker.LayerProgress) string {
+	if l.Done() {
+		return "<green>" + l.Status + "</>"
+	}
+	if l.Total <= 0 {
+		return l.Status
+	}
+	return fmt.Sprintf("%s %s/%s", l.Status,
+		units.HumanSize(float64(l.Current)), units.HumanSize(float64(l.Total)))
+}
+
+//progressBar returns a bar of the given width that is filled as much as
+//the given fraction
+func progressBar(fraction float64, width int) string {
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+	filled := int(fraction * float64(width))
+	return strings.Repeat(ui.BarGlyph.String(), filled) +
+		strings.Repeat(ui.BarGapGlyph.String(), width-filled)
+}
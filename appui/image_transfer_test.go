@@ -0,0 +1,55 @@
+package appui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestTransferLines(t *testing.T) {
+	p := docker.TransferProgress{
+		Reference: "nginx:latest",
+		Status:    "Pulling from library/nginx",
+		Layers: []docker.LayerProgress{
+			{ID: "a1", Status: "Already exists"},
+			{ID: "b2", Status: "Downloading", Current: 1000, Total: 2000},
+		},
+	}
+	lines := transferLines(p, "", nil)
+	if len(lines) != 6 || lines[0] != "<white>Pulling from library/nginx</>" {
+		t.Fatalf("Unexpected lines: %q", lines)
+	}
+	if want := "a1           " + progressBar(1, transferBarWidth) + " <green>Already exists</>"; lines[2] != want {
+		t.Errorf("Unexpected line of a layer, got %q, want %q", lines[2], want)
+	}
+	if !strings.HasSuffix(lines[3], "Downloading 1kB/2kB") {
+		t.Errorf("Unexpected line of a layer: %q", lines[3])
+	}
+	if lines := transferLines(p, "Pulled nginx:latest", nil); lines[0] != "<green>Pulled nginx:latest</>" {
+		t.Errorf("Unexpected status of a finished pull: %q", lines[0])
+	}
+	if lines := transferLines(p, "", errors.New("manifest unknown")); lines[0] != "<red>manifest unknown</>" {
+		t.Errorf("Unexpected status of a failed pull: %q", lines[0])
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		fraction float64
+		want     string
+	}{
+		{0, "░░░░"},
+		{0.5, "██░░"},
+		{0.99, "███░"},
+		{1, "████"},
+		{2, "████"},
+		{-1, "░░░░"},
+	}
+	for _, tt := range tests {
+		if got := progressBar(tt.fraction, 4); got != tt.want {
+			t.Errorf("progressBar(%f) = %q, want %q", tt.fraction, got, tt.want)
+		}
+	}
+}
@@ -1,18 +1,21 @@
 package appui
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/docker/docker/api/types"
 	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 	"github.com/moncho/dry/ui/termui"
 )
 
-var defaultImageTableHeader = imageTableHeader()
+var defaultImageTableHeader = imageTableHeader(nil, imageColumnWidths)
 
 var imageTableHeaders = []SortableColumnHeader{
 	{`REPOSITORY`, docker.SortImagesByRepo},
@@ -22,25 +25,43 @@ var imageTableHeaders = []SortableColumnHeader{
 	{`Size`, docker.SortImagesBySize},
 }
 
+//imageColumnWidths are the widths of the columns of the image table, in the
+//order of imageTableHeaders, 0 for the columns that share the width left
+var imageColumnWidths = []int{0, 0, 12, 12, 0}
+
 //DockerImagesWidget knows how render a container list
 type DockerImagesWidget struct {
 	dockerDaemon         docker.ImageAPI
 	filteredRows         []*ImageRow
 	totalRows            []*ImageRow
 	filterPattern        string
+	search               RowSearch
 	header               *termui.TableHeader
+	hidden               hiddenColumns
+	resize               columnResize
 	selectedIndex        int
+	selectedID           string //the image on the cursor on the last rendering
+	pendingSelection     string
 	x, y                 int
 	height, width        int
 	startIndex, endIndex int
 	sortMode             docker.SortMode
+	order                sortOrder
+	danglingOnly         bool
 	mounted              bool
+	loader               *AsyncLoader
+	autoRefresh          *AutoRefresh
+	graph                *docker.ImageGraph //nil until the parent/child graph of the images is built
+	graphLoader          *AsyncLoader
+	//ids of the images marked for comparison, in the order they were marked
+	marked []string
 
 	sync.RWMutex
 }
 
-//NewDockerImagesWidget creates a renderer for a container list
-func NewDockerImagesWidget(dockerDaemon docker.ImageAPI, y int) *DockerImagesWidget {
+//NewDockerImagesWidget creates a renderer for a container list that starts
+//filtered and sorted as the given options tell
+func NewDockerImagesWidget(dockerDaemon docker.ImageAPI, y int, opts ListOptions) *DockerImagesWidget {
 	w := DockerImagesWidget{
 		y:            y,
 		dockerDaemon: dockerDaemon,
@@ -48,6 +69,12 @@ func NewDockerImagesWidget(dockerDaemon docker.ImageAPI, y int) *DockerImagesWid
 		height:       MainScreenAvailableHeight(),
 		sortMode:     docker.SortImagesByRepo,
 		width:        ui.ActiveScreen.Dimensions.Width}
+	w.filterPattern = opts.Filter
+	if opts.SortMode != docker.NoSort {
+		w.sortMode = opts.SortMode
+	}
+	w.loader = NewAsyncLoader(&w)
+	w.graphLoader = NewAsyncLoader(&w)
 
 	RegisterWidget(docker.ImageSource, &w)
 
@@ -65,10 +92,21 @@ func (s *DockerImagesWidget) Buffer() gizaktermui.Buffer {
 		var filter string
 		if s.filterPattern != "" {
 			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+				"<b><blue> | Active filter: </><yellow>%s</></> ", FilterText(s.filterPattern))
+		}
+
+		if s.danglingOnly {
+			filter += "<b><blue> | Showing: </><yellow>dangling only</></> "
+		}
+
+		var marked string
+		if len(s.marked) > 0 {
+			marked = fmt.Sprintf(
+				"<b><blue> | Marked: </><yellow>%d</></> ", len(s.marked))
 		}
 
-		widgetHeader := WidgetHeader("Images", s.RowCount(), filter)
+		widgetHeader := WidgetHeader("Images", s.RowCount(),
+			filter+marked+s.autoRefresh.headerDetails(time.Now())+s.loader.HeaderDetails())
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -83,12 +121,15 @@ func (s *DockerImagesWidget) Buffer() gizaktermui.Buffer {
 		for i, imageRow := range s.visibleRows() {
 			imageRow.SetY(y)
 			y += imageRow.GetHeight()
-			if i != selected {
-				imageRow.NotHighlighted()
-			} else {
+			if i == selected {
 				imageRow.Highlighted()
+			} else if s.isMarked(imageRow.image.ID) {
+				imageRow.Marked()
+			} else {
+				imageRow.NotHighlighted()
 			}
 			buf.Merge(imageRow.Buffer())
+			s.search.Highlight(buf, imageRow)
 		}
 	}
 	return buf
@@ -101,25 +142,104 @@ func (s *DockerImagesWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
-//Mount tells this widget to be ready for rendering
+//Search moves the cursor to the first image, starting from the selected
+//one, that matches the given pattern. Returns false if no image matches.
+func (s *DockerImagesWidget) Search(pattern string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Search(pattern, len(s.filteredRows), s.filterableRow)
+}
+
+//SearchNext moves the cursor to the next image that matches the last pattern searched
+func (s *DockerImagesWidget) SearchNext() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Next(len(s.filteredRows), s.filterableRow)
+}
+
+//SearchPrevious moves the cursor to the previous image that matches the last pattern searched
+func (s *DockerImagesWidget) SearchPrevious() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Previous(len(s.filteredRows), s.filterableRow)
+}
+
+//DanglingOnly returns true if only dangling images are listed
+func (s *DockerImagesWidget) DanglingOnly() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.danglingOnly
+}
+
+//ToggleDanglingOnly toggles between listing all images and listing only
+//the dangling ones, the filter, if any, applies on both
+func (s *DockerImagesWidget) ToggleDanglingOnly() {
+	s.Lock()
+	defer s.Unlock()
+	s.danglingOnly = !s.danglingOnly
+}
+
+//Mount tells this widget to be ready for rendering, images are loaded in the background
 func (s *DockerImagesWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
-		images, err := s.dockerDaemon.Images()
-		if err != nil {
-			return err
-		}
+		s.mounted = true
+		header, hidden := s.header, s.hidden
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			images, err := s.dockerDaemon.Images()
+			if err != nil {
+				return nil, err
+			}
+
+			imageRows := make([]*ImageRow, len(images))
+			for i, image := range images {
+				imageRows[i] = NewImageRow(image, header, hidden)
+			}
+			return func() {
+				s.totalRows = imageRows
+				if s.header != header {
+					//columns were hidden or shown while loading
+					s.rebuildRows()
+				} else {
+					s.showChildren()
+				}
+				s.forgetRemovedMarks()
+				s.loadGraph(images)
+			}, nil
+		})
+	}
+	return s.loader.Err()
+}
+
+//Marked returns the ids of the images marked for comparison, in the order
+//they were marked
+func (s *DockerImagesWidget) Marked() []string {
+	s.RLock()
+	defer s.RUnlock()
+	return append([]string(nil), s.marked...)
+}
 
-		imageRows := make([]*ImageRow, len(images))
-		for i, image := range images {
-			imageRows[i] = NewImageRow(image, s.header)
+//ToggleMark marks the selected image for comparison, or unmarks it if it is
+//already marked. At most two images are marked, marking a third one unmarks
+//the image that was marked first.
+func (s *DockerImagesWidget) ToggleMark() {
+	s.Lock()
+	defer s.Unlock()
+	if s.RowCount() == 0 {
+		return
+	}
+	id := s.filteredRows[s.selectedIndex].image.ID
+	for i, marked := range s.marked {
+		if marked == id {
+			s.marked = append(s.marked[:i], s.marked[i+1:]...)
+			return
 		}
-		s.totalRows = imageRows
-		s.mounted = true
-		s.align()
 	}
-	return nil
+	if len(s.marked) == 2 {
+		s.marked = s.marked[1:]
+	}
+	s.marked = append(s.marked, id)
 }
 
 //Name returns this widget name
@@ -140,6 +260,20 @@ func (s *DockerImagesWidget) RowCount() int {
 	return len(s.filteredRows)
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *DockerImagesWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *DockerImagesWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return SortModeTitle(imageTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
 //SortImagesByRepo -> SortImagesByID -> SortImagesByCreationDate -> SortImagesBySize -> SortImagesByRepo
 func (s *DockerImagesWidget) Sort() {
@@ -158,14 +292,222 @@ func (s *DockerImagesWidget) Sort() {
 	s.mounted = false
 }
 
-//Unmount tells this widget that it will not be rendering anymore
-func (s *DockerImagesWidget) Unmount() error {
+//ReverseSort switches the order of the column the list is sorted by
+//between ascending and descending, each column keeps its own order
+func (s *DockerImagesWidget) ReverseSort() {
+	s.Lock()
+	defer s.Unlock()
+	s.order.Reverse(s.sortMode)
+}
+
+//SortDescending returns true if the list is sorted in descending order
+func (s *DockerImagesWidget) SortDescending() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.order.Descending(s.sortMode)
+}
+
+//SortMode returns the sort mode of the list
+func (s *DockerImagesWidget) SortMode() docker.SortMode {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortMode
+}
+
+//SetSortMode sorts the list with the given sort mode
+func (s *DockerImagesWidget) SetSortMode(mode docker.SortMode) {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = mode
+	s.mounted = false
+}
+
+//Select moves the cursor to the row of the image with the given id. The
+//image might not be on the list yet, the cursor is moved once it shows up
+//or, if it does not, the next time the list is loaded.
+func (s *DockerImagesWidget) Select(id string) {
+	s.Lock()
+	defer s.Unlock()
+	s.pendingSelection = id
+}
+
+//SetAutoRefresh sets the auto refresh state shown on the header, nil if
+//the list does not refresh on its own
+func (s *DockerImagesWidget) SetAutoRefresh(r *AutoRefresh) {
+	s.Lock()
+	defer s.Unlock()
+	s.autoRefresh = r
+}
+
+//Refreshing returns true until the list is mounted and loaded
+func (s *DockerImagesWidget) Refreshing() bool {
 	s.RLock()
 	defer s.RUnlock()
+	return !s.mounted || s.loader.loading
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *DockerImagesWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
 	s.mounted = false
+	s.loader.Cancel()
+	s.graphLoader.Cancel()
 	return nil
 }
 
+//loadGraph builds the parent/child graph of the given images in the
+//background, the rows show how many images are built on theirs once it is
+func (s *DockerImagesWidget) loadGraph(images []types.ImageSummary) {
+	s.graphLoader.Load(func(ctx context.Context) (func(), error) {
+		graph, err := s.dockerDaemon.ImageGraph(images)
+		if err != nil {
+			return nil, err
+		}
+		return func() {
+			s.graph = graph
+			s.showChildren()
+		}, nil
+	})
+}
+
+//showChildren shows on each row how many images are built on its image,
+//as the last graph built tells
+func (s *DockerImagesWidget) showChildren() {
+	for _, row := range s.totalRows {
+		row.SetChildren(len(s.graph.Children(row.image.ID)))
+	}
+	s.align()
+}
+
+//matches returns true if the given row matches the given filter pattern,
+//the leaf: and parent: filters match no rows until the graph is built
+func (s *DockerImagesWidget) matches(row *ImageRow, pattern string) bool {
+	switch {
+	case strings.HasPrefix(pattern, docker.LeafFilterPrefix):
+		leaf := strings.TrimPrefix(pattern, docker.LeafFilterPrefix) != "false"
+		return s.graph != nil && s.graph.IsLeaf(row.image.ID) == leaf
+	case strings.HasPrefix(pattern, docker.ParentFilterPrefix):
+		parent := s.graph.Parent(row.image.ID)
+		id := docker.ImageID(strings.TrimPrefix(pattern, docker.ParentFilterPrefix))
+		return parent != "" && strings.HasPrefix(docker.ImageID(parent), id)
+	}
+	return RowFilters.ByPattern(pattern)(row)
+}
+
+//filterableRow returns the row of the filtered images at the given index
+func (s *DockerImagesWidget) filterableRow(i int) FilterableRow {
+	return s.filteredRows[i]
+}
+
+func (s *DockerImagesWidget) isMarked(id string) bool {
+	for _, marked := range s.marked {
+		if marked == id {
+			return true
+		}
+	}
+	return false
+}
+
+//forgetRemovedMarks unmarks the images that are gone
+func (s *DockerImagesWidget) forgetRemovedMarks() {
+	var marked []string
+	for _, id := range s.marked {
+		for _, row := range s.totalRows {
+			if row.image.ID == id {
+				marked = append(marked, id)
+				break
+			}
+		}
+	}
+	s.marked = marked
+}
+
+//Columns returns the titles of the columns of the list that can be hidden
+func (s *DockerImagesWidget) Columns() []string {
+	return columnTitles(imageTableHeaders, len(imageColumnWidths))
+}
+
+//HiddenColumns returns the titles of the hidden columns of the list
+func (s *DockerImagesWidget) HiddenColumns() []string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.hidden.titles(imageTableHeaders)
+}
+
+//SetHiddenColumns hides the columns of the list with the given titles and
+//shows the rest, the titles that are not columns of the list are ignored
+func (s *DockerImagesWidget) SetHiddenColumns(titles []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.hidden = newHiddenColumns(imageTableHeaders, titles)
+	s.resize.focus(s.columnTitles(), 0)
+	s.layoutColumns()
+}
+
+//ToggleColumnResize starts resizing the columns of the list, the first one
+//first, or stops resizing them. Returns true if they are being resized.
+func (s *DockerImagesWidget) ToggleColumnResize() bool {
+	s.Lock()
+	defer s.Unlock()
+	resizing := s.resize.toggle(s.columnTitles())
+	s.header.HighlightColumn(s.resize.column())
+	return resizing
+}
+
+//FocusColumn moves the column being resized to the next one if delta is
+//positive, to the previous one if negative
+func (s *DockerImagesWidget) FocusColumn(delta int) {
+	s.Lock()
+	defer s.Unlock()
+	s.resize.focus(s.columnTitles(), delta)
+	s.header.HighlightColumn(s.resize.column())
+}
+
+//ResizeColumn grows the column being resized by the given delta, shrinks it
+//if negative. Returns the title of the column and its new width, the width
+//is kept for as long as dry runs.
+func (s *DockerImagesWidget) ResizeColumn(delta int) (string, int) {
+	s.Lock()
+	defer s.Unlock()
+	title := s.resize.resize(s.columnTitles(), s.header.ColumnWidths(), delta, s.width)
+	if title == "" {
+		return "", 0
+	}
+	s.layoutColumns()
+	return title, s.resize.widths[title]
+}
+
+//ResizingColumn returns the title of the column being resized, empty if
+//the columns are not being resized
+func (s *DockerImagesWidget) ResizingColumn() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.resize.title(s.columnTitles())
+}
+
+//columnTitles returns the titles of the visible columns of the list
+func (s *DockerImagesWidget) columnTitles() []string {
+	return s.hidden.visibleTitles(imageTableHeaders)
+}
+
+//layoutColumns builds the header of the list again, with the visible columns
+//and their widths, rows are built again with the new header
+func (s *DockerImagesWidget) layoutColumns() {
+	s.header = imageTableHeader(s.hidden, s.resize.apply(imageTableHeaders, imageColumnWidths))
+	s.header.HighlightColumn(s.resize.column())
+	s.rebuildRows()
+}
+
+//rebuildRows builds the rows of the list again, with the columns that are
+//not hidden
+func (s *DockerImagesWidget) rebuildRows() {
+	for i, row := range s.totalRows {
+		s.totalRows[i] = NewImageRow(row.image, s.header, s.hidden)
+	}
+	s.showChildren()
+}
+
 //Align aligns rows
 func (s *DockerImagesWidget) align() {
 	x := s.x
@@ -183,11 +525,14 @@ func (s *DockerImagesWidget) align() {
 
 func (s *DockerImagesWidget) filterRows() {
 
-	if s.filterPattern != "" {
+	if s.filterPattern != "" || s.danglingOnly {
 		var rows []*ImageRow
 
 		for _, row := range s.totalRows {
-			if RowFilters.ByPattern(s.filterPattern)(row) {
+			if s.danglingOnly && !docker.IsDangling(row.image) {
+				continue
+			}
+			if s.filterPattern == "" || s.matches(row, s.filterPattern) {
 				rows = append(rows, row)
 			}
 		}
@@ -197,6 +542,40 @@ func (s *DockerImagesWidget) filterRows() {
 	}
 }
 
+//followSelection moves the cursor to the row of the image it was on, if
+//sorting or reloading the list moved the row, unless the user moved it
+func (s *DockerImagesWidget) followSelection() {
+	cursor := ui.ActiveScreen.Cursor
+	if s.selectedID == "" || cursor.Position() != s.selectedIndex {
+		return
+	}
+	for i, row := range s.filteredRows {
+		if row.image.ID == s.selectedID {
+			if i != cursor.Position() {
+				cursor.ScrollTo(i)
+			}
+			return
+		}
+	}
+}
+
+//selectPending moves the cursor to the row of the image to select, if any
+func (s *DockerImagesWidget) selectPending() {
+	if s.pendingSelection == "" {
+		return
+	}
+	for i, row := range s.filteredRows {
+		if row.image.ID == s.pendingSelection {
+			ui.ActiveScreen.Cursor.ScrollTo(i)
+			s.pendingSelection = ""
+			return
+		}
+	}
+	if !s.loader.loading {
+		s.pendingSelection = ""
+	}
+}
+
 func (s *DockerImagesWidget) calculateVisibleRows() {
 
 	count := s.RowCount()
@@ -238,6 +617,8 @@ func (s *DockerImagesWidget) calculateVisibleRows() {
 func (s *DockerImagesWidget) prepareForRendering() {
 	s.sortRows()
 	s.filterRows()
+	s.selectPending()
+	s.followSelection()
 	index := ui.ActiveScreen.Cursor.Position()
 	if index < 0 {
 		index = 0
@@ -245,6 +626,10 @@ func (s *DockerImagesWidget) prepareForRendering() {
 		index = s.RowCount() - 1
 	}
 	s.selectedIndex = index
+	s.selectedID = ""
+	if index >= 0 && index < len(s.filteredRows) {
+		s.selectedID = s.filteredRows[index].image.ID
+	}
 	s.calculateVisibleRows()
 }
 
@@ -252,11 +637,8 @@ func (s *DockerImagesWidget) updateHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := ColumnTitle(c.Text)
 		var header SortableColumnHeader
-		if strings.Contains(colTitle, DownArrow) {
-			colTitle = colTitle[DownArrowLength:]
-		}
 		for _, h := range imageTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -264,7 +646,7 @@ func (s *DockerImagesWidget) updateHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = DownArrow + colTitle
+			c.Text = SortColumnTitle(colTitle, s.order.Descending(sortMode))
 		} else {
 			c.Text = colTitle
 		}
@@ -284,8 +666,8 @@ func (s *DockerImagesWidget) sortRows() {
 	switch mode {
 	case docker.SortImagesByRepo:
 		sortAlg = func(i, j int) bool {
-			if rows[i].Repository.Text != rows[j].Repository.Text {
-				return rows[i].Repository.Text < rows[j].Repository.Text
+			if rows[i].repository != rows[j].repository {
+				return rows[i].repository < rows[j].repository
 			}
 			return rows[i].Tag.Text < rows[j].Tag.Text
 		}
@@ -303,20 +685,15 @@ func (s *DockerImagesWidget) sortRows() {
 		}
 
 	}
-	sort.SliceStable(rows, sortAlg)
+	sort.SliceStable(rows, s.order.less(mode, sortAlg))
 }
 
 func (s *DockerImagesWidget) visibleRows() []*ImageRow {
 	return s.filteredRows[s.startIndex:s.endIndex]
 }
 
-func imageTableHeader() *termui.TableHeader {
-	header := termui.NewHeader(DryTheme)
-	header.ColumnSpacing = DefaultColumnSpacing
-	header.AddColumn(imageTableHeaders[0].Title)
-	header.AddColumn(imageTableHeaders[1].Title)
-	header.AddFixedWidthColumn(imageTableHeaders[2].Title, 12)
-	header.AddFixedWidthColumn(imageTableHeaders[3].Title, 12)
-	header.AddColumn(imageTableHeaders[4].Title)
-	return header
+//imageTableHeader creates the header of the image table with the given
+//column widths, without the given hidden columns
+func imageTableHeader(hidden hiddenColumns, widths []int) *termui.TableHeader {
+	return hidden.tableHeader(imageTableHeaders, widths)
 }
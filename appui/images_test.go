@@ -1,11 +1,16 @@
 package appui
 
 import (
+	"context"
+	"io"
+	"reflect"
 	"testing"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
 
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/mocks"
 	"github.com/moncho/dry/ui"
 )
@@ -23,11 +28,12 @@ func TestImagesToShowSmallScreen(t *testing.T) {
 		Dimensions: &ui.Dimensions{Height: 15, Width: 100},
 		Cursor:     cursor}
 
-	renderer := NewDockerImagesWidget(daemon, 0)
+	renderer := NewDockerImagesWidget(daemon, 0, ListOptions{})
 
 	if err := renderer.Mount(); err != nil {
 		t.Errorf("There was an error mounting the widget %v", err)
 	}
+	renderer.loader.Wait()
 	ui.ActiveScreen.Cursor.ScrollTo(0)
 	renderer.prepareForRendering()
 
@@ -70,10 +76,11 @@ func TestImagesToShow(t *testing.T) {
 
 	ui.ActiveScreen = &ui.Screen{Dimensions: &ui.Dimensions{Height: 20, Width: 100},
 		Cursor: cursor}
-	renderer := NewDockerImagesWidget(daemon, 0)
+	renderer := NewDockerImagesWidget(daemon, 0, ListOptions{})
 	if err := renderer.Mount(); err != nil {
 		t.Errorf("There was an error mounting the widget %v", err)
 	}
+	renderer.loader.Wait()
 
 	renderer.prepareForRendering()
 	images := renderer.visibleRows()
@@ -98,9 +105,10 @@ func TestImagesToShow(t *testing.T) {
 }
 
 func TestImagesToShowNoImages(t *testing.T) {
-	renderer := NewDockerImagesWidget(noopImageAPI{}, 0)
+	renderer := NewDockerImagesWidget(noopImageAPI{}, 0, ListOptions{})
 
 	renderer.Mount()
+	renderer.loader.Wait()
 
 	images := renderer.visibleRows()
 	if len(images) != 0 {
@@ -120,6 +128,9 @@ func (i noopImageAPI) ImageByID(id string) (types.ImageSummary, error) {
 	return types.ImageSummary{}, nil
 
 }
+func (i noopImageAPI) ImageGraph(images []types.ImageSummary) (*docker.ImageGraph, error) {
+	return docker.NewImageGraph(images, nil), nil
+}
 func (i noopImageAPI) Images() ([]types.ImageSummary, error) {
 	return []types.ImageSummary{}, nil
 }
@@ -129,3 +140,170 @@ func (i noopImageAPI) ImagesCount() int {
 func (i noopImageAPI) RunImage(image types.ImageSummary, command string) error {
 	return nil
 }
+func (i noopImageAPI) ImageLayers(id string) ([]docker.ImageLayer, error) {
+	return nil, nil
+}
+func (i noopImageAPI) LayerContents(ctx context.Context, id, diffID string, progress func(float64)) (*docker.LayerContents, error) {
+	return nil, nil
+}
+func (i noopImageAPI) PullImage(ctx context.Context, ref string, progress func(docker.TransferProgress)) (string, error) {
+	return ref, nil
+}
+func (i noopImageAPI) PushImage(ctx context.Context, ref string, credentials *docker.RegistryCredentials, progress func(docker.TransferProgress)) (string, error) {
+	return ref, nil
+}
+func (i noopImageAPI) PruneImages(opts docker.ImagePruneOptions) (types.ImagesPruneReport, error) {
+	return types.ImagesPruneReport{}, nil
+}
+func (i noopImageAPI) SearchImages(ctx context.Context, term string) ([]registry.SearchResult, error) {
+	return nil, nil
+}
+func (i noopImageAPI) SaveImage(ctx context.Context, id, path string, progress func(float64)) (int64, error) {
+	return 0, nil
+}
+func (i noopImageAPI) LoadImage(ctx context.Context, path string, progress func(float64)) ([]string, error) {
+	return nil, nil
+}
+func (i noopImageAPI) TagImage(id, ref string) error {
+	return nil
+}
+func (i noopImageAPI) UntagImage(id, tag string) error {
+	return nil
+}
+func (i noopImageAPI) BuildImage(ctx context.Context, options docker.BuildOptions, output io.Writer) (string, error) {
+	return "", nil
+}
+
+func TestImagesFollowSelection(t *testing.T) {
+	cursor := ui.NewCursor()
+	ui.ActiveScreen = &ui.Screen{Dimensions: &ui.Dimensions{Height: 20, Width: 100},
+		Cursor: cursor}
+	w := NewDockerImagesWidget(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	cursor.ScrollTo(1)
+	w.prepareForRendering()
+	selected := w.filteredRows[1].image.ID
+
+	//the list is sorted again and reloaded, the cursor stays on the image
+	for _, mode := range []docker.SortMode{docker.SortImagesBySize, docker.SortImagesByID, docker.SortImagesByCreationDate} {
+		w.SetSortMode(mode)
+		w.Unmount()
+		w.Mount()
+		w.loader.Wait()
+		w.prepareForRendering()
+		if got := w.filteredRows[cursor.Position()].image.ID; got != selected {
+			t.Errorf("The selection did not follow the image after sorting by %v, got %s, want %s", mode, got, selected)
+		}
+	}
+
+	//the user moves the cursor
+	cursor.ScrollTo(0)
+	w.prepareForRendering()
+	if cursor.Position() != 0 {
+		t.Errorf("The cursor did not stay where the user moved it, got %d", cursor.Position())
+	}
+}
+
+//graphImageAPI has an image built on another one and an unrelated image
+type graphImageAPI struct {
+	noopImageAPI
+}
+
+func (i graphImageAPI) Images() ([]types.ImageSummary, error) {
+	return []types.ImageSummary{
+		{ID: "sha256:base", RepoTags: []string{"base:1"}},
+		{ID: "sha256:app", RepoTags: []string{"app:1"}, ParentID: "sha256:base"},
+		{ID: "sha256:tool", RepoTags: []string{"tool:1"}},
+	}, nil
+}
+
+func TestImagesGraph(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{Dimensions: &ui.Dimensions{Height: 20, Width: 100},
+		Cursor: ui.NewCursor()}
+	w := NewDockerImagesWidget(graphImageAPI{}, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.graphLoader.Wait()
+
+	for _, row := range w.totalRows {
+		want := row.repository
+		if row.image.ID == "sha256:base" {
+			want = "base (base of 1)"
+		}
+		if row.Repository.Text != want {
+			t.Errorf("Repository of %s = %q, want %q", row.image.ID, row.Repository.Text, want)
+		}
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"leaf:true", []string{"sha256:app", "sha256:tool"}},
+		{"leaf:false", []string{"sha256:base"}},
+		{"parent:base", []string{"sha256:app"}},
+		{"parent:tool", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			w.Filter(tt.filter)
+			w.prepareForRendering()
+			var got []string
+			for _, row := range w.filteredRows {
+				got = append(got, row.image.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Images listed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+//danglingImageAPI has a tagged image and two dangling ones
+type danglingImageAPI struct {
+	noopImageAPI
+}
+
+func (i danglingImageAPI) Images() ([]types.ImageSummary, error) {
+	return []types.ImageSummary{
+		{ID: "sha256:8dfafdbc3a40", RepoTags: []string{"dry/dry:1"}},
+		{ID: "sha256:541a0f4efc6f", RepoTags: []string{"<none>:<none>"}},
+		{ID: "sha256:a3d6e836e86a"},
+	}, nil
+}
+
+func TestImagesDanglingOnly(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{Dimensions: &ui.Dimensions{Height: 20, Width: 100},
+		Cursor: ui.NewCursor()}
+	w := NewDockerImagesWidget(danglingImageAPI{}, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.prepareForRendering()
+	if w.RowCount() != 3 {
+		t.Fatalf("Unexpected number of images: %d", w.RowCount())
+	}
+
+	w.ToggleDanglingOnly()
+	w.prepareForRendering()
+	if !w.DanglingOnly() || w.RowCount() != 2 {
+		t.Fatalf("Only the dangling images should be listed, got %d", w.RowCount())
+	}
+	for _, row := range w.filteredRows {
+		if !docker.IsDangling(row.image) {
+			t.Errorf("Image %s is not dangling", row.image.ID)
+		}
+	}
+
+	w.ToggleDanglingOnly()
+	w.prepareForRendering()
+	if w.DanglingOnly() || w.RowCount() != 3 {
+		t.Errorf("All the images should be listed again, got %d", w.RowCount())
+	}
+}
@@ -0,0 +1,213 @@
+package appui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//jsonIndent is the indentation of each level of an inspected document
+const jsonIndent = "    "
+
+//Tags of the parts of an inspected document
+const (
+	jsonKeyTag     = "cyan"
+	jsonStringTag  = "green"
+	jsonNumberTag  = "yellow"
+	jsonLiteralTag = "magenta"
+	jsonHintTag    = "darkgrey"
+)
+
+//jsonKind is the kind of a value of a JSON document
+type jsonKind int
+
+//Known JSON kinds
+const (
+	jsonScalar jsonKind = iota
+	jsonObject
+	jsonArray
+)
+
+//jsonNode is a value of a JSON document, objects keep the order of their
+//members
+type jsonNode struct {
+	key       string
+	kind      jsonKind
+	scalar    string
+	children  []*jsonNode
+	parent    *jsonNode
+	collapsed bool
+}
+
+//parseJSON parses the given JSON document
+func parseJSON(data []byte) (*jsonNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeJSONNode(dec, nil, "")
+}
+
+func decodeJSONNode(dec *json.Decoder, parent *jsonNode, key string) (*jsonNode, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &jsonNode{key: key, parent: parent}
+	switch t := token.(type) {
+	case json.Delim:
+		n.kind = jsonArray
+		if t == '{' {
+			n.kind = jsonObject
+		}
+		for dec.More() {
+			childKey := ""
+			if n.kind == jsonObject {
+				keyToken, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				childKey = fmt.Sprint(keyToken)
+			}
+			child, err := decodeJSONNode(dec, n, childKey)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+		//the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		quoted, _ := json.Marshal(t)
+		n.scalar = string(quoted)
+	case json.Number:
+		n.scalar = t.String()
+	case bool:
+		n.scalar = strconv.FormatBool(t)
+	case nil:
+		n.scalar = "null"
+	}
+	return n, nil
+}
+
+//expandable returns true if the node is an object or an array with values
+func (n *jsonNode) expandable() bool {
+	return n.kind != jsonScalar && len(n.children) > 0
+}
+
+//setCollapsed collapses, or expands, the node and every node under it
+func (n *jsonNode) setCollapsed(collapsed bool) {
+	n.collapsed = collapsed
+	for _, child := range n.children {
+		child.setCollapsed(collapsed)
+	}
+}
+
+//expandAncestors expands the nodes the node is under
+func (n *jsonNode) expandAncestors() {
+	for p := n.parent; p != nil; p = p.parent {
+		p.collapsed = false
+	}
+}
+
+//jsonSegment is a part of a line of a JSON document with the tag it is
+//shown with, if any
+type jsonSegment struct {
+	text string
+	tag  string
+}
+
+//jsonLine is a line of a JSON document, lines closing an object or an array
+//belong to the node of the object or the array too
+type jsonLine struct {
+	node     *jsonNode
+	closing  bool
+	segments []jsonSegment
+}
+
+//text returns the line as plain text
+func (l jsonLine) text() string {
+	var b strings.Builder
+	for _, s := range l.segments {
+		b.WriteString(s.text)
+	}
+	return b.String()
+}
+
+//jsonLines returns the lines of the document with the given root. Collapsed
+//nodes take a single line unless all lines are asked for.
+func jsonLines(root *jsonNode, all bool) []jsonLine {
+	return appendJSONLines(nil, root, 0, true, all)
+}
+
+func appendJSONLines(lines []jsonLine, n *jsonNode, depth int, last, all bool) []jsonLine {
+	indent := strings.Repeat(jsonIndent, depth)
+	line := jsonLine{node: n, segments: []jsonSegment{{text: indent}}}
+	if n.parent != nil && n.parent.kind == jsonObject {
+		quoted, _ := json.Marshal(n.key)
+		line.segments = append(line.segments,
+			jsonSegment{text: string(quoted), tag: jsonKeyTag},
+			jsonSegment{text: ": "})
+	}
+	comma := jsonSegment{text: ","}
+	if last {
+		comma.text = ""
+	}
+	if n.kind == jsonScalar {
+		line.segments = append(line.segments, jsonSegment{text: n.scalar, tag: scalarTag(n.scalar)}, comma)
+		return append(lines, line)
+	}
+	open, close := "[", "]"
+	if n.kind == jsonObject {
+		open, close = "{", "}"
+	}
+	if len(n.children) == 0 {
+		line.segments = append(line.segments, jsonSegment{text: open + close}, comma)
+		return append(lines, line)
+	}
+	if n.collapsed && !all {
+		line.segments = append(line.segments,
+			jsonSegment{text: open},
+			jsonSegment{text: "…", tag: jsonHintTag},
+			jsonSegment{text: close},
+			comma,
+			jsonSegment{text: " " + collapsedHint(n), tag: jsonHintTag})
+		return append(lines, line)
+	}
+	line.segments = append(line.segments, jsonSegment{text: open})
+	lines = append(lines, line)
+	for i, child := range n.children {
+		lines = appendJSONLines(lines, child, depth+1, i == len(n.children)-1, all)
+	}
+	return append(lines, jsonLine{
+		node:     n,
+		closing:  true,
+		segments: []jsonSegment{{text: indent + close}, comma},
+	})
+}
+
+//collapsedHint describes what a collapsed node has
+func collapsedHint(n *jsonNode) string {
+	what := "items"
+	if n.kind == jsonObject {
+		what = "keys"
+	}
+	if len(n.children) == 1 {
+		what = strings.TrimSuffix(what, "s")
+	}
+	return fmt.Sprintf("(%d %s)", len(n.children), what)
+}
+
+//scalarTag returns the tag a scalar value is shown with
+func scalarTag(scalar string) string {
+	switch {
+	case strings.HasPrefix(scalar, `"`):
+		return jsonStringTag
+	case scalar == "true", scalar == "false", scalar == "null":
+		return jsonLiteralTag
+	default:
+		return jsonNumberTag
+	}
+}
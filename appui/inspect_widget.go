@@ -0,0 +1,426 @@
+package appui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//inspectHints are the keys of the inspect widget shown on its status line
+const inspectHints = "<b>Enter</>:<darkgrey>Fold</> <b>-/+</>:<darkgrey>Collapse/Expand all</> " +
+	"<b>/</>:<darkgrey>Search</> <b>n/N</>:<darkgrey>Next/Previous match</> <b>Esc</>:<darkgrey>Back</>"
+
+//InspectWidget shows the low-level information Docker returns when something
+//is inspected, a JSON document, with its keys and values colored. Objects and
+//arrays can be collapsed and the document can be searched, matches are
+//highlighted.
+type InspectWidget struct {
+	title    string
+	root     *jsonNode
+	lines    []jsonLine
+	selected int
+	start    int
+	//pattern is the search pattern, input the one being typed
+	pattern string
+	input   []rune
+	typing  bool
+	sync.RWMutex
+}
+
+//NewInspectWidget creates an InspectWidget showing the given data, the title
+//tells what was inspected
+func NewInspectWidget(title string, data interface{}) (*InspectWidget, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	w := &InspectWidget{title: title, root: root}
+	w.lines = jsonLines(root, false)
+	return w, nil
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (w *InspectWidget) Buffer() gtermui.Buffer {
+	w.Lock()
+	defer w.Unlock()
+	buf := gtermui.NewBuffer()
+	width, height := ui.ActiveScreen.Dimensions.Width, ui.ActiveScreen.Dimensions.Height
+	w.scroll(w.pageSize())
+
+	title := fmt.Sprintf("<b><blue>docker inspect </><yellow>%s</></>", w.title)
+	buf.Merge(inspectLine(title, 0, width, false).Buffer())
+	y := 1
+	for i := w.start; i < len(w.lines) && y < height-1; i++ {
+		buf.Merge(inspectLine(highlight(w.lines[i], w.pattern), y, width, i == w.selected).Buffer())
+		y++
+	}
+	for ; y < height-1; y++ {
+		buf.Merge(inspectLine("", y, width, false).Buffer())
+	}
+	buf.Merge(inspectLine(w.statusLine(), height-1, width, false).Buffer())
+	return buf
+}
+
+//Mount callback
+func (w *InspectWidget) Mount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (w *InspectWidget) Name() string {
+	return "InspectWidget"
+}
+
+//OnFocus starts handling the given events until the user closes the widget,
+//with Esc. It is a blocking call.
+func (w *InspectWidget) OnFocus(event ui.EventSource) error {
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		w.Lock()
+		done := false
+		if w.typing {
+			w.handleSearchInput(ev)
+		} else {
+			done = w.handleKey(ev)
+		}
+		w.Unlock()
+		if event.EventHandledCallback != nil {
+			if err := event.EventHandledCallback(ev); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+//Unmount callback
+func (w *InspectWidget) Unmount() error {
+	return nil
+}
+
+//handleKey handles the given key, it returns true if the widget is closed
+func (w *InspectWidget) handleKey(ev termbox.Event) bool {
+	page := w.pageSize()
+	switch ev.Key {
+	case termbox.KeyEsc:
+		return true
+	case termbox.KeyArrowUp:
+		w.selected--
+	case termbox.KeyArrowDown:
+		w.selected++
+	case termbox.KeyPgup:
+		w.selected -= page
+	case termbox.KeyPgdn:
+		w.selected += page
+	case termbox.KeyHome:
+		w.selected = 0
+	case termbox.KeyEnd:
+		w.selected = len(w.lines) - 1
+	case termbox.KeyEnter, termbox.KeySpace:
+		w.toggle()
+	case termbox.KeyArrowLeft:
+		w.collapseOrUp()
+	case termbox.KeyArrowRight:
+		if n := w.selectedNode(); n != nil && n.expandable() && n.collapsed {
+			w.setCollapsed(n, false)
+		}
+	}
+	switch ev.Ch {
+	case 'g':
+		w.selected = 0
+	case 'G':
+		w.selected = len(w.lines) - 1
+	case '-':
+		for _, child := range w.root.children {
+			child.setCollapsed(true)
+		}
+		w.rebuild()
+	case '+':
+		w.root.setCollapsed(false)
+		w.rebuild()
+	case '/':
+		w.typing = true
+		w.input = nil
+	case 'n':
+		w.gotoMatch(1)
+	case 'N':
+		w.gotoMatch(-1)
+	}
+	w.clampSelection()
+	return false
+}
+
+//handleSearchInput handles the given key while the search pattern is typed
+func (w *InspectWidget) handleSearchInput(ev termbox.Event) {
+	switch ev.Key {
+	case termbox.KeyEsc:
+		w.typing = false
+	case termbox.KeyEnter:
+		w.typing = false
+		w.pattern = string(w.input)
+		w.gotoMatch(0)
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if len(w.input) > 0 {
+			w.input = w.input[:len(w.input)-1]
+		}
+	case termbox.KeySpace:
+		w.input = append(w.input, ' ')
+	default:
+		if ev.Ch != 0 {
+			w.input = append(w.input, ev.Ch)
+		}
+	}
+}
+
+//toggle collapses the object or array on the selected line or expands it
+func (w *InspectWidget) toggle() {
+	n := w.selectedNode()
+	if n == nil || !n.expandable() {
+		return
+	}
+	w.setCollapsed(n, !n.collapsed)
+}
+
+//collapseOrUp collapses the object or array on the selected line or, if it
+//is collapsed or a value, selects the object or array it belongs to
+func (w *InspectWidget) collapseOrUp() {
+	n := w.selectedNode()
+	if n == nil {
+		return
+	}
+	if n.expandable() && !n.collapsed {
+		w.setCollapsed(n, true)
+		return
+	}
+	if n.parent != nil {
+		w.selectLine(n.parent, false)
+	}
+}
+
+//setCollapsed collapses or expands the given node, the line that opens it is
+//selected
+func (w *InspectWidget) setCollapsed(n *jsonNode, collapsed bool) {
+	n.collapsed = collapsed
+	w.rebuild()
+	w.selectLine(n, false)
+}
+
+//rebuild builds the lines shown again, keeping the selected line or, if it
+//is not shown anymore, selecting the collapsed object or array it is in
+func (w *InspectWidget) rebuild() {
+	var selected jsonLine
+	if w.selected >= 0 && w.selected < len(w.lines) {
+		selected = w.lines[w.selected]
+	}
+	w.lines = jsonLines(w.root, false)
+	w.selected = 0
+	closing := selected.closing
+	for n := selected.node; n != nil; n = n.parent {
+		if w.selectLine(n, closing) {
+			return
+		}
+		closing = false
+	}
+}
+
+//selectLine selects the line of the given node, the opening one or the
+//closing one. It returns false if the line is not shown.
+func (w *InspectWidget) selectLine(n *jsonNode, closing bool) bool {
+	for i, line := range w.lines {
+		if line.node == n && line.closing == closing {
+			w.selected = i
+			return true
+		}
+	}
+	return false
+}
+
+func (w *InspectWidget) selectedNode() *jsonNode {
+	if w.selected < 0 || w.selected >= len(w.lines) {
+		return nil
+	}
+	return w.lines[w.selected].node
+}
+
+//gotoMatch selects the next line that matches the search pattern in the
+//given direction, 0 looks for a match starting on the selected line. The
+//objects and arrays the match is in are expanded.
+func (w *InspectWidget) gotoMatch(direction int) {
+	if w.pattern == "" {
+		return
+	}
+	all := jsonLines(w.root, true)
+	current := 0
+	if n := w.selectedNode(); n != nil {
+		for i, line := range all {
+			if line.node == n && line.closing == w.lines[w.selected].closing {
+				current = i
+				break
+			}
+		}
+	}
+	step := direction
+	if step == 0 {
+		step = 1
+		current--
+	}
+	for i := 1; i <= len(all); i++ {
+		line := all[((current+step*i)%len(all)+len(all))%len(all)]
+		if len(matches(line.text(), w.pattern)) == 0 {
+			continue
+		}
+		line.node.expandAncestors()
+		if line.closing {
+			line.node.collapsed = false
+		}
+		w.lines = jsonLines(w.root, false)
+		w.selectLine(line.node, line.closing)
+		return
+	}
+}
+
+//matchCount returns how many lines of the whole document match the pattern
+func (w *InspectWidget) matchCount() int {
+	count := 0
+	for _, line := range jsonLines(w.root, true) {
+		if len(matches(line.text(), w.pattern)) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func (w *InspectWidget) statusLine() string {
+	if w.typing {
+		return "<yellow>/</>" + string(w.input) + "_"
+	}
+	if w.pattern != "" {
+		return fmt.Sprintf("<yellow>%s</>: %d matching lines  %s", w.pattern, w.matchCount(), inspectHints)
+	}
+	return inspectHints
+}
+
+//pageSize is how many lines of the document are shown, the title and the
+//status line take a line each
+func (w *InspectWidget) pageSize() int {
+	if size := ui.ActiveScreen.Dimensions.Height - 2; size > 0 {
+		return size
+	}
+	return 1
+}
+
+func (w *InspectWidget) clampSelection() {
+	if w.selected >= len(w.lines) {
+		w.selected = len(w.lines) - 1
+	}
+	if w.selected < 0 {
+		w.selected = 0
+	}
+}
+
+//scroll moves the first line shown so the selected line is shown
+func (w *InspectWidget) scroll(page int) {
+	w.clampSelection()
+	if w.selected < w.start {
+		w.start = w.selected
+	} else if w.selected >= w.start+page {
+		w.start = w.selected - page + 1
+	}
+}
+
+func inspectLine(text string, y, width int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = width
+	par.Y = y
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gtermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gtermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gtermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+//highlight returns the given line with markup, the parts that match the
+//given pattern are shown reversed
+func highlight(line jsonLine, pattern string) string {
+	text := line.text()
+	hits := matches(text, pattern)
+	if hits == nil {
+		hits = make([]bool, len([]rune(text)))
+	}
+	var b strings.Builder
+	offset := 0
+	for _, s := range line.segments {
+		runes := []rune(s.text)
+		for start := 0; start < len(runes); {
+			hit := hits[offset+start]
+			end := start
+			for end < len(runes) && hits[offset+end] == hit {
+				end++
+			}
+			text := string(runes[start:end])
+			switch {
+			case hit:
+				b.WriteString("<r>" + text + "</r>")
+			case s.tag != "":
+				b.WriteString("<" + s.tag + ">" + text + "</>")
+			default:
+				b.WriteString(text)
+			}
+			start = end
+		}
+		offset += len(runes)
+	}
+	return b.String()
+}
+
+//matches tells, for each rune of the given text, if it is part of a match of
+//the given pattern, case is ignored. It returns nil if nothing matches.
+func matches(text, pattern string) []bool {
+	if pattern == "" {
+		return nil
+	}
+	t, p := lowerRunes(text), lowerRunes(pattern)
+	var hits []bool
+	for i := 0; i+len(p) <= len(t); i++ {
+		if string(t[i:i+len(p)]) != string(p) {
+			continue
+		}
+		if hits == nil {
+			hits = make([]bool, len(t))
+		}
+		for j := i; j < i+len(p); j++ {
+			hits[j] = true
+		}
+	}
+	return hits
+}
+
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
@@ -0,0 +1,181 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+var inspectedContainer = map[string]interface{}{
+	"Id":      "6dfafdbc3a40",
+	"Running": true,
+	"Config": map[string]interface{}{
+		"Env":      []string{"PATH=/usr/bin", "LANG=C"},
+		"Hostname": "web",
+	},
+	"RestartCount": 2,
+	"Mounts":       []string{},
+}
+
+func newTestInspectWidget(t *testing.T) *InspectWidget {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w, err := NewInspectWidget("6dfafdbc3a40", inspectedContainer)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the widget: %v", err)
+	}
+	return w
+}
+
+func texts(lines []jsonLine) []string {
+	var texts []string
+	for _, line := range lines {
+		texts = append(texts, line.text())
+	}
+	return texts
+}
+
+func focus(w *InspectWidget, events ...termbox.Event) {
+	ch := make(chan termbox.Event, len(events))
+	for _, ev := range events {
+		ch <- ev
+	}
+	close(ch)
+	w.OnFocus(ui.EventSource{Events: ch})
+}
+
+func chEvent(ch rune) termbox.Event {
+	return termbox.Event{Type: termbox.EventKey, Ch: ch}
+}
+
+func TestInspectWidget_Lines(t *testing.T) {
+	w := newTestInspectWidget(t)
+	want := []string{
+		`{`,
+		`    "Config": {`,
+		`        "Env": [`,
+		`            "PATH=/usr/bin",`,
+		`            "LANG=C"`,
+		`        ],`,
+		`        "Hostname": "web"`,
+		`    },`,
+		`    "Id": "6dfafdbc3a40",`,
+		`    "Mounts": [],`,
+		`    "RestartCount": 2,`,
+		`    "Running": true`,
+		`}`,
+	}
+	if got := texts(w.lines); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected lines:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	tags := map[string]string{}
+	for _, line := range w.lines {
+		for _, s := range line.segments {
+			tags[s.text] = s.tag
+		}
+	}
+	for text, tag := range map[string]string{
+		`"Hostname"`:      jsonKeyTag,
+		`"web"`:           jsonStringTag,
+		`2`:               jsonNumberTag,
+		`true`:            jsonLiteralTag,
+		`"PATH=/usr/bin"`: jsonStringTag,
+	} {
+		if tags[text] != tag {
+			t.Errorf("%s is shown with tag %q, want %q", text, tags[text], tag)
+		}
+	}
+}
+
+func TestInspectWidget_Collapse(t *testing.T) {
+	w := newTestInspectWidget(t)
+
+	//Config is collapsed, then expanded back
+	focus(w, keyEvent(termbox.KeyArrowDown), keyEvent(termbox.KeyEnter))
+	if got := w.lines[1].text(); got != `    "Config": {…}, (2 keys)` {
+		t.Errorf("Unexpected collapsed line: %q", got)
+	}
+	if len(w.lines) != 7 || w.selected != 1 {
+		t.Errorf("Unexpected lines after collapsing: %d lines, %d selected", len(w.lines), w.selected)
+	}
+	focus(w, keyEvent(termbox.KeyArrowRight))
+	if len(w.lines) != 13 {
+		t.Errorf("Unexpected number of lines after expanding: %d", len(w.lines))
+	}
+
+	//left on a value goes to the object it is in, then collapses it
+	focus(w, keyEvent(termbox.KeyArrowDown), keyEvent(termbox.KeyArrowDown),
+		keyEvent(termbox.KeyArrowLeft))
+	if w.selected != 2 || w.lines[2].node.key != "Env" {
+		t.Errorf("Unexpected selection: %d", w.selected)
+	}
+	focus(w, keyEvent(termbox.KeyArrowLeft))
+	if got := w.lines[2].text(); got != `        "Env": […], (2 items)` {
+		t.Errorf("Unexpected collapsed line: %q", got)
+	}
+
+	focus(w, chEvent('-'))
+	if len(w.lines) != 7 {
+		t.Errorf("Unexpected number of lines after collapsing all: %d", len(w.lines))
+	}
+	focus(w, chEvent('+'))
+	if len(w.lines) != 13 {
+		t.Errorf("Unexpected number of lines after expanding all: %d", len(w.lines))
+	}
+}
+
+func TestInspectWidget_Search(t *testing.T) {
+	w := newTestInspectWidget(t)
+	focus(w, chEvent('-'), chEvent('/'), chEvent('l'), chEvent('A'), chEvent('x'),
+		keyEvent(termbox.KeyBackspace2), chEvent('N'), chEvent('g'), keyEvent(termbox.KeyEnter))
+	if w.typing || w.pattern != "lANg" {
+		t.Fatalf("Unexpected search: %q, typing %v", w.pattern, w.typing)
+	}
+	if got := w.lines[w.selected].text(); got != `            "LANG=C"` {
+		t.Errorf("The match was not selected, got %q", got)
+	}
+	if got := highlight(w.lines[w.selected], w.pattern); got != `            <green>"</><r>LANG</r><green>=C"</>` {
+		t.Errorf("Unexpected highlighted line: %q", got)
+	}
+	if w.matchCount() != 1 {
+		t.Errorf("Unexpected number of matches: %d", w.matchCount())
+	}
+
+	focus(w, keyEvent(termbox.KeyHome), chEvent('/'), chEvent('e'), keyEvent(termbox.KeyEnter))
+	var found []string
+	for i := 0; i < 4; i++ {
+		found = append(found, w.lines[w.selected].node.key)
+		focus(w, chEvent('n'))
+	}
+	if got := strings.Join(found, ","); got != "Env,Hostname,RestartCount,Running" {
+		t.Errorf("Unexpected matches: %s", got)
+	}
+	if w.lines[w.selected].node.key != "Env" {
+		t.Errorf("Search did not wrap around, selected %q", w.lines[w.selected].node.key)
+	}
+	focus(w, chEvent('N'))
+	if w.lines[w.selected].node.key != "Running" {
+		t.Errorf("Unexpected previous match %q", w.lines[w.selected].node.key)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	if matches("web", "") != nil || matches("web", "db") != nil {
+		t.Error("Nothing must match")
+	}
+	got := matches("ñandú Ñu", "ñ")
+	want := []bool{true, false, false, false, false, false, true, false}
+	if len(got) != len(want) {
+		t.Fatalf("Unexpected matches: %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Unexpected matches: %v, want %v", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package appui
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//JobStatus is the status of a background job
+type JobStatus uint8
+
+//Known job statuses
+const (
+	JobPending JobStatus = iota
+	JobRunning
+	JobSucceeded
+	JobFailed
+	JobCanceled
+)
+
+func (s JobStatus) String() string {
+	switch s {
+	case JobPending:
+		return "pending"
+	case JobRunning:
+		return "running"
+	case JobSucceeded:
+		return "done"
+	case JobFailed:
+		return "failed"
+	case JobCanceled:
+		return "canceled"
+	}
+	return "unknown"
+}
+
+//Finished returns true if a job with this status is not going to run anymore
+func (s JobStatus) Finished() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCanceled
+}
+
+//Job describes an operation executed in the background
+type Job struct {
+	ID          int
+	Description string
+	Status      JobStatus
+	//Progress of the job, from 0 to 1, negative if the job does not report progress
+	Progress   float64
+	Cancelable bool
+	Created    time.Time
+	Started    time.Time
+	Finished   time.Time
+	Err        error
+}
+
+//Elapsed returns how long the job has been running, or how long it run if it
+//has finished
+func (j Job) Elapsed(now time.Time) time.Duration {
+	if j.Started.IsZero() {
+		return 0
+	}
+	if !j.Finished.IsZero() {
+		return j.Finished.Sub(j.Started)
+	}
+	return now.Sub(j.Started)
+}
+
+var jobsTableHeaders = []string{"ID", "DESCRIPTION", "STATUS", "PROGRESS", "ELAPSED"}
+
+//JobsWidget shows the list of background jobs
+type JobsWidget struct {
+	jobs          func() []Job
+	header        *termui.TableHeader
+	rows          []*JobRow
+	height, width int
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	mounted       bool
+	sync.RWMutex
+}
+
+//NewJobsWidget creates a widget that shows the jobs returned by the given func
+func NewJobsWidget(jobs func() []Job, y int) *JobsWidget {
+	header := termui.NewHeader(DryTheme)
+	header.ColumnSpacing = DefaultColumnSpacing
+	header.AddFixedWidthColumn(jobsTableHeaders[0], 6)
+	header.AddColumn(jobsTableHeaders[1])
+	header.AddFixedWidthColumn(jobsTableHeaders[2], 10)
+	header.AddFixedWidthColumn(jobsTableHeaders[3], 10)
+	header.AddFixedWidthColumn(jobsTableHeaders[4], 10)
+
+	return &JobsWidget{
+		jobs:   jobs,
+		header: header,
+		y:      y,
+		height: MainScreenAvailableHeight(),
+		width:  ui.ActiveScreen.Dimensions.Width,
+	}
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *JobsWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	var details string
+	if running := RunningJobs(s.jobs()); running > 0 {
+		details = fmt.Sprintf("<b><blue> | Running: </><yellow>%d</></> ", running)
+	}
+	widgetHeader := WidgetHeader("Jobs", len(s.rows), details)
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	s.header.SetX(s.x)
+	s.header.SetWidth(s.width)
+	s.header.SetY(y)
+	buf.Merge(s.header.Buffer())
+	y += s.header.GetHeight()
+
+	for i, row := range s.visibleRows() {
+		row.SetX(s.x)
+		row.SetWidth(s.width)
+		row.SetY(y)
+		y += row.GetHeight()
+		if i+s.startIndex == s.selectedIndex {
+			row.Highlighted()
+		} else {
+			row.NotHighlighted()
+		}
+		buf.Merge(row.Buffer())
+	}
+	return buf
+}
+
+//Mount tells this widget to be ready for rendering
+func (s *JobsWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = true
+	return nil
+}
+
+//Name returns this widget name
+func (s *JobsWidget) Name() string {
+	return "JobsWidget"
+}
+
+//OnEvent runs the given command on the ID of the selected job
+func (s *JobsWidget) OnEvent(event EventCommand) error {
+	s.RLock()
+	defer s.RUnlock()
+	if len(s.rows) == 0 {
+		return nil
+	}
+	return event(strconv.Itoa(s.rows[s.selectedIndex].job.ID))
+}
+
+//RowCount returns the number of rows of this widget
+func (s *JobsWidget) RowCount() int {
+	return len(s.jobs())
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *JobsWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	return nil
+}
+
+//prepareForRendering creates the rows, the most recent job first, and
+//calculates the selected row
+func (s *JobsWidget) prepareForRendering() {
+	jobs := s.jobs()
+	now := time.Now()
+	rows := make([]*JobRow, len(jobs))
+	for i, job := range jobs {
+		rows[len(jobs)-1-i] = NewJobRow(job, now, s.header)
+	}
+	s.rows = rows
+
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(rows) {
+		index = len(rows) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+func (s *JobsWidget) visibleRows() []*JobRow {
+	if s.height <= 0 || len(s.rows) == 0 {
+		return nil
+	}
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+s.height {
+		s.startIndex = s.selectedIndex - s.height + 1
+	}
+	end := s.startIndex + s.height
+	if end > len(s.rows) {
+		end = len(s.rows)
+	}
+	return s.rows[s.startIndex:end]
+}
+
+//JobRow is a row of the jobs widget
+type JobRow struct {
+	job         Job
+	ID          *termui.ParColumn
+	Description *termui.ParColumn
+	Status      *termui.ParColumn
+	Progress    *termui.ParColumn
+	Elapsed     *termui.ParColumn
+	Row
+}
+
+//NewJobRow creates a row for the given job
+func NewJobRow(job Job, now time.Time, table termui.Table) *JobRow {
+	description := job.Description
+	if job.Err != nil {
+		description = fmt.Sprintf("%s: %s", job.Description, job.Err.Error())
+	}
+	row := &JobRow{
+		job:         job,
+		ID:          termui.NewThemedParColumn(DryTheme, strconv.Itoa(job.ID)),
+		Description: termui.NewThemedParColumn(DryTheme, description),
+		Status:      termui.NewThemedParColumn(DryTheme, job.Status.String()),
+		Progress:    termui.NewThemedParColumn(DryTheme, jobProgress(job)),
+		Elapsed:     termui.NewThemedParColumn(DryTheme, jobElapsed(job, now)),
+	}
+	row.Height = 1
+	row.Table = table
+	row.Columns = []gizaktermui.GridBufferer{
+		row.ID,
+		row.Description,
+		row.Status,
+		row.Progress,
+		row.Elapsed,
+	}
+	row.ParColumns = []*termui.ParColumn{
+		row.ID,
+		row.Description,
+		row.Status,
+		row.Progress,
+		row.Elapsed,
+	}
+	return row
+}
+
+//RunningJobs returns the number of jobs pending or running
+func RunningJobs(jobs []Job) int {
+	running := 0
+	for _, job := range jobs {
+		if !job.Status.Finished() {
+			running++
+		}
+	}
+	return running
+}
+
+//JobsIndicator returns a compact widget telling how many jobs are running, to
+//be shown on the right side of the footer, nil if no job is running
+func JobsIndicator(jobs []Job) *termui.MarkupPar {
+	running := RunningJobs(jobs)
+	if running == 0 {
+		return nil
+	}
+	text := fmt.Sprintf(" %d jobs running ", running)
+	if running == 1 {
+		text = " 1 job running "
+	}
+	par := termui.NewParFromMarkupText(DryTheme, "<b><yellow>"+text+"</></>")
+	par.Border = false
+	par.Width = len(text)
+	par.Height = 1
+	par.X = ui.ActiveScreen.Dimensions.Width - par.Width
+	par.Y = ui.ActiveScreen.Dimensions.Height - MainScreenFooterSize
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Footer)
+	par.Bg = gizaktermui.Attribute(DryTheme.Footer)
+	return par
+}
+
+func jobProgress(job Job) string {
+	switch {
+	case job.Status == JobSucceeded:
+		return "100%"
+	case job.Status == JobPending, job.Progress < 0:
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", job.Progress*100)
+}
+
+func jobElapsed(job Job, now time.Time) string {
+	if job.Started.IsZero() {
+		return "-"
+	}
+	return job.Elapsed(now).Round(time.Second).String()
+}
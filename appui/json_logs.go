@@ -0,0 +1,218 @@
+package appui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/moncho/dry/terminal"
+)
+
+//DefaultJSONLogFields are the fields shown as columns of JSON logs if the
+//user has not chosen others
+var DefaultJSONLogFields = []string{"time", "level", "msg"}
+
+var jsonLogFields = DefaultJSONLogFields
+var jsonLogFieldsLock sync.RWMutex
+
+//jsonLogFieldAliases are the keys the most common logging libraries use
+//for the default fields, a field is looked up by its aliases in order
+var jsonLogFieldAliases = map[string][]string{
+	"time":  {"time", "timestamp", "ts", "@timestamp", "t"},
+	"level": {"level", "lvl", "severity", "loglevel", "@level"},
+	"msg":   {"msg", "message", "@message"},
+}
+
+//jsonLogLevelColors are the markup colors of the log lines by level
+var jsonLogLevelColors = map[string]string{
+	"trace":    "grey",
+	"debug":    "grey",
+	"warn":     "yellow",
+	"warning":  "yellow",
+	"error":    "red",
+	"err":      "red",
+	"fatal":    "red",
+	"panic":    "red",
+	"critical": "red",
+	"crit":     "red",
+	"alert":    "red",
+	"emerg":    "red",
+}
+
+//unparsedLogMarker flags the lines of a JSON log that are not JSON, parsed
+//lines are indented by as much
+const (
+	unparsedLogMarker = "<darkgrey>~</> "
+	parsedLogMarker   = "  "
+)
+
+//SetJSONLogFields sets the fields shown as columns of JSON logs, the default
+//ones are used if none is given
+func SetJSONLogFields(fields []string) {
+	jsonLogFieldsLock.Lock()
+	defer jsonLogFieldsLock.Unlock()
+	if len(fields) == 0 {
+		fields = DefaultJSONLogFields
+	}
+	jsonLogFields = fields
+}
+
+//JSONLogFields returns the fields shown as columns of JSON logs
+func JSONLogFields() []string {
+	jsonLogFieldsLock.RLock()
+	defer jsonLogFieldsLock.RUnlock()
+	return jsonLogFields
+}
+
+//jsonLogEntry is a log line parsed as a JSON object
+type jsonLogEntry struct {
+	//prefix is whatever comes before the object, like the timestamps Docker
+	//adds to each line
+	prefix string
+	fields map[string]json.RawMessage
+}
+
+//parseJSONLog parses the given log line, false is returned if it does not
+//have a JSON object
+func parseJSONLog(line string) (jsonLogEntry, bool) {
+	start := strings.IndexByte(line, '{')
+	if start < 0 {
+		return jsonLogEntry{}, false
+	}
+	prefix := line[:start]
+	if prefix != "" && !strings.HasSuffix(prefix, " ") {
+		return jsonLogEntry{}, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(strings.TrimSpace(line[start:])), &fields); err != nil {
+		return jsonLogEntry{}, false
+	}
+	return jsonLogEntry{prefix: prefix, fields: fields}, true
+}
+
+//field returns the value of the given field as text, and false if the
+//entry does not have it
+func (e jsonLogEntry) field(name string) (string, bool) {
+	if aliases, ok := jsonLogFieldAliases[name]; ok {
+		for _, alias := range aliases {
+			if value, ok := e.fields[alias]; ok {
+				return jsonLogValue(value), true
+			}
+		}
+		return "", false
+	}
+	value, ok := e.fields[name]
+	if !ok {
+		return "", false
+	}
+	return jsonLogValue(value), true
+}
+
+//jsonLogValue returns the given JSON value as text, strings are unquoted
+//and anything else is shown as it was logged
+func jsonLogValue(value json.RawMessage) string {
+	if len(value) > 0 && value[0] == '"' {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			return s
+		}
+	}
+	return string(value)
+}
+
+//jsonLogCondition is a field=value condition of a JSON log filter
+type jsonLogCondition struct {
+	field, value string
+}
+
+//parseJSONLogFilter parses a filter made of field=value conditions separated
+//by spaces, like "level=error request_id=abc123"
+func parseJSONLogFilter(filter string) ([]jsonLogCondition, error) {
+	var conditions []jsonLogCondition
+	for _, term := range strings.Fields(filter) {
+		i := strings.IndexByte(term, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid condition %q, conditions are field=value", term)
+		}
+		conditions = append(conditions, jsonLogCondition{field: term[:i], value: term[i+1:]})
+	}
+	return conditions, nil
+}
+
+//matches returns true if the entry meets all the given conditions, values
+//are compared ignoring case
+func (e jsonLogEntry) matches(conditions []jsonLogCondition) bool {
+	for _, c := range conditions {
+		value, ok := e.field(c.field)
+		if !ok || !strings.EqualFold(value, c.value) {
+			return false
+		}
+	}
+	return true
+}
+
+//JSONLogFormatter shows JSON log lines as columns with the fields in use,
+//colored by level. Lines that are not JSON are shown as they are, flagged.
+//It is the ui.LineFormatter of the log pager.
+type JSONLogFormatter struct {
+	fields     []string
+	conditions []jsonLogCondition
+}
+
+//NewJSONLogFormatter creates a formatter that shows the JSON log fields in use
+func NewJSONLogFormatter() *JSONLogFormatter {
+	return &JSONLogFormatter{fields: JSONLogFields()}
+}
+
+//SetFilter sets the field=value conditions the lines shown must meet, lines
+//that are not JSON are hidden while there are any
+func (f *JSONLogFormatter) SetFilter(filter string) error {
+	conditions, err := parseJSONLogFilter(filter)
+	if err != nil {
+		return err
+	}
+	f.conditions = conditions
+	return nil
+}
+
+//Format returns how the given log line is shown, cut to the given width,
+//and false if the filter hides it
+func (f *JSONLogFormatter) Format(line string, width int) (string, bool) {
+	entry, ok := parseJSONLog(line)
+	if !ok {
+		if len(f.conditions) > 0 {
+			return "", false
+		}
+		if strings.IndexByte(line, '\x1b') >= 0 {
+			//colored output is shown without its escape codes, as the pager
+			//shows it when not formatting
+			if clean := terminal.RemoveANSIEscapeCharacters(line); len(clean) > 0 {
+				line = string(clean[0])
+			}
+		}
+		return unparsedLogMarker + truncateCell(line, width-len(parsedLogMarker)), true
+	}
+	if !entry.matches(f.conditions) {
+		return "", false
+	}
+	var columns bytes.Buffer
+	columns.WriteString(entry.prefix)
+	for i, name := range f.fields {
+		value, _ := entry.field(name)
+		if i > 0 {
+			columns.WriteByte(' ')
+		}
+		if name == "level" {
+			value = fmt.Sprintf("%-5s", strings.ToUpper(value))
+		}
+		columns.WriteString(value)
+	}
+	text := truncateCell(columns.String(), width-len(parsedLogMarker))
+	level, _ := entry.field("level")
+	if color, ok := jsonLogLevelColors[strings.ToLower(level)]; ok {
+		return parsedLogMarker + "<" + color + ">" + text + "</>", true
+	}
+	return parsedLogMarker + text, true
+}
@@ -0,0 +1,153 @@
+package appui
+
+import (
+	"testing"
+)
+
+func TestJSONLogFormatter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		line   string
+		want   string
+		shown  bool
+	}{
+		{
+			"error lines are red",
+			"",
+			`{"time":"2026-10-16T10:00:00Z","level":"error","msg":"boom","request_id":"abc123"}`,
+			"  <red>2026-10-16T10:00:00Z ERROR boom</>",
+			true,
+		},
+		{
+			"fields are found by their aliases",
+			"",
+			`{"ts":1,"severity":"info","message":"up"}`,
+			"  1 INFO  up",
+			true,
+		},
+		{
+			"Docker timestamps are kept",
+			"",
+			`2026-10-16T10:00:00.000000000Z {"level":"warn","msg":"slow"}`,
+			"  <yellow>2026-10-16T10:00:00.000000000Z  WARN  slow</>",
+			true,
+		},
+		{
+			"lines that are not JSON are flagged",
+			"",
+			"starting server",
+			"<darkgrey>~</> starting server",
+			true,
+		},
+		{
+			"broken JSON is shown as it is",
+			"",
+			`{"level":"info","msg":`,
+			`<darkgrey>~</> {"level":"info","msg":`,
+			true,
+		},
+		{
+			"matching lines are shown",
+			"level=ERROR request_id=abc123",
+			`{"level":"error","msg":"boom","request_id":"abc123"}`,
+			"  <red> ERROR boom</>",
+			true,
+		},
+		{
+			"lines not matching are hidden",
+			"level=error",
+			`{"level":"info","msg":"up"}`,
+			"",
+			false,
+		},
+		{
+			"lines without the field are hidden",
+			"request_id=abc123",
+			`{"level":"info","msg":"up"}`,
+			"",
+			false,
+		},
+		{
+			"lines that are not JSON are hidden while filtering",
+			"level=error",
+			"starting server",
+			"",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewJSONLogFormatter()
+			if err := f.SetFilter(tt.filter); err != nil {
+				t.Fatalf("Unexpected error setting filter %q: %s", tt.filter, err)
+			}
+			got, shown := f.Format(tt.line, 200)
+			if got != tt.want || shown != tt.shown {
+				t.Errorf("Format() = %q, %v, want %q, %v", got, shown, tt.want, tt.shown)
+			}
+		})
+	}
+}
+
+func TestJSONLogFormatter_InvalidFilter(t *testing.T) {
+	f := NewJSONLogFormatter()
+	f.SetFilter("level=error")
+	if err := f.SetFilter("level"); err == nil {
+		t.Error("Expected an error for a condition without value")
+	}
+	if _, shown := f.Format(`{"level":"info"}`, 200); shown {
+		t.Error("The filter in use must be kept after an invalid one")
+	}
+}
+
+func TestJSONLogFormatter_Fields(t *testing.T) {
+	SetJSONLogFields([]string{"request_id", "msg"})
+	defer SetJSONLogFields(nil)
+
+	f := NewJSONLogFormatter()
+	got, _ := f.Format(`{"level":"debug","msg":"query","request_id":"abc123","took":12}`, 200)
+	if want := "  <grey>abc123 query</>"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+	got, _ = f.Format(`{"msg":"query","request_id":{"id":7}}`, 200)
+	if want := `  {"id":7} query`; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestJSONLogFormatter_Width(t *testing.T) {
+	f := NewJSONLogFormatter()
+	got, _ := f.Format(`{"time":"10:00","level":"info","msg":"a long message"}`, 14)
+	if want := "  10:00 INFO …"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func BenchmarkJSONLogFormatter(b *testing.B) {
+	f := NewJSONLogFormatter()
+	line := `2026-10-16T10:00:00.000000000Z {"time":"2026-10-16T10:00:00Z","level":"info","msg":"request served","method":"GET","path":"/api/v1/items","status":200,"took":0.0123,"request_id":"abc123"}`
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Format(line, 200)
+	}
+}
+
+func BenchmarkJSONLogFormatter_Filter(b *testing.B) {
+	f := NewJSONLogFormatter()
+	f.SetFilter("level=error")
+	line := `{"time":"2026-10-16T10:00:00Z","level":"info","msg":"request served","status":200,"request_id":"abc123"}`
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Format(line, 200)
+	}
+}
+
+func BenchmarkJSONLogFormatter_NotJSON(b *testing.B) {
+	f := NewJSONLogFormatter()
+	line := "2026-10-16 10:00:00 INFO request served GET /api/v1/items 200 12ms"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Format(line, 200)
+	}
+}
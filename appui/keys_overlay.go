@@ -0,0 +1,165 @@
+package appui
+
+import (
+	"fmt"
+	"strings"
+
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+//keysOverlayWidth is the width of the keys overlay, if the screen is wide enough
+const keysOverlayWidth = 130
+
+//keysOverlayColumnWidth is the narrowest a column of the keys overlay can be,
+//screens too narrow for two columns get a single one
+const keysOverlayColumnWidth = 40
+
+//KeyBinding is a key binding shown on the keys overlay
+type KeyBinding struct {
+	Keys        string
+	Description string
+	//Shadowed global bindings are shadowed by a binding of the view, they are
+	//shown greyed out
+	Shadowed bool
+}
+
+//KeysOverlay is a cheat sheet, drawn over the view, with the key bindings
+//of the view and the global ones. It closes on any key.
+type KeysOverlay struct {
+	title  string
+	view   []KeyBinding
+	global []KeyBinding
+}
+
+//NewKeysOverlay creates a KeysOverlay for the view with the given title
+func NewKeysOverlay(title string, view, global []KeyBinding) *KeysOverlay {
+	return &KeysOverlay{title: title, view: view, global: global}
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (o *KeysOverlay) Buffer() gtermui.Buffer {
+	width := keysOverlayWidth
+	if screenWidth := ui.ActiveScreen.Dimensions.Width - 2; width > screenWidth {
+		width = screenWidth
+	}
+	columns := 1
+	if (width-2)/2 >= keysOverlayColumnWidth {
+		columns = 2
+	}
+	columnWidth := (width - 2) / columns
+
+	var lines []string
+	if len(o.view) > 0 {
+		lines = append(lines, "<yellow>"+padCell(o.title, columnWidth)+"</>")
+		lines = append(lines, o.bindingLines(o.view, columnWidth)...)
+		lines = append(lines, padCell("", columnWidth))
+	}
+	lines = append(lines, "<yellow>"+padCell("Global", columnWidth)+"</>")
+	lines = append(lines, o.bindingLines(o.global, columnWidth)...)
+
+	rows := (len(lines) + columns - 1) / columns
+	//borders and the line telling how to close the overlay
+	maxRows := ui.ActiveScreen.Dimensions.Height - 2 - 3
+	if maxRows < 1 {
+		maxRows = 1
+	}
+	if rows > maxRows {
+		rows = maxRows
+	}
+	var buf strings.Builder
+	for r := 0; r < rows; r++ {
+		for c := 0; c < columns; c++ {
+			i := c*rows + r
+			if i >= len(lines) {
+				break
+			}
+			buf.WriteString(lines[i])
+		}
+		buf.WriteString("\n")
+	}
+	if len(lines) > rows*columns {
+		buf.WriteString(fmt.Sprintf("<grey>%d more, the help screen lists them all</>\n", len(lines)-rows*columns))
+		rows++
+	}
+	buf.WriteString("<grey>Any key closes this</>")
+
+	par := termui.NewParFromMarkupText(DryTheme, buf.String())
+	par.Width = width
+	par.Height = rows + 3
+	par.X = (ui.ActiveScreen.Dimensions.Width - par.Width) / 2
+	par.Y = (ui.ActiveScreen.Dimensions.Height - par.Height) / 2
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = " Keys "
+	par.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
+	return par.Buffer()
+}
+
+//DimsBackground returns true, the view behind the overlay is dimmed
+func (o *KeysOverlay) DimsBackground() bool {
+	return true
+}
+
+//Mount callback
+func (o *KeysOverlay) Mount() error {
+	return nil
+}
+
+//Name returns the widget name
+func (o *KeysOverlay) Name() string {
+	return "KeysOverlay"
+}
+
+//OnFocus waits for a key to be pressed, it is a blocking call
+func (o *KeysOverlay) OnFocus(event ui.EventSource) error {
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		if event.EventHandledCallback != nil {
+			return event.EventHandledCallback(ev)
+		}
+		return nil
+	}
+	return nil
+}
+
+//Unmount callback
+func (o *KeysOverlay) Unmount() error {
+	return nil
+}
+
+//bindingLines returns a line for each of the given bindings, cut or padded
+//with spaces to the given width
+func (o *KeysOverlay) bindingLines(bindings []KeyBinding, width int) []string {
+	keysWidth := 0
+	for _, b := range bindings {
+		if l := len([]rune(b.Keys)); l > keysWidth {
+			keysWidth = l
+		}
+	}
+	var lines []string
+	for _, b := range bindings {
+		keys := padCell(b.Keys, keysWidth) + strings.Repeat(" ", DefaultColumnSpacing)
+		description := padCell(b.Description, width-keysWidth-2*DefaultColumnSpacing)
+		if b.Shadowed {
+			lines = append(lines, "<grey>"+keys+description+"</>")
+		} else {
+			lines = append(lines, "<white>"+keys+"</>"+description)
+		}
+	}
+	return lines
+}
+
+//padCell cuts or pads with spaces the given text to the given width
+func padCell(text string, width int) string {
+	text = truncateCell(text, width)
+	if pad := width - len([]rune(text)); pad > 0 {
+		text += strings.Repeat(" ", pad)
+	}
+	return text
+}
@@ -0,0 +1,73 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moncho/dry/ui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestKeysOverlayLayout(t *testing.T) {
+	view := []KeyBinding{
+		{Keys: "F1", Description: "Cycles through sort modes"},
+		{Keys: "e/E", Description: "Removes the selected container"},
+	}
+	global := []KeyBinding{
+		{Keys: "1", Description: "To container list"},
+		{Keys: "m/M", Description: "Show container monitor mode", Shadowed: true},
+	}
+	tests := []struct {
+		name    string
+		width   int
+		columns int
+	}{
+		{"wide screen", 160, 2},
+		{"narrow screen", 60, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui.ActiveScreen = &ui.Screen{
+				Cursor:     &ui.Cursor{},
+				Dimensions: &ui.Dimensions{Height: 40, Width: tt.width},
+			}
+			o := NewKeysOverlay("Container list", view, global)
+			buf := o.Buffer()
+			//bindings, section titles, the line between sections and the closing line
+			lines := (len(view)+len(global)+3+tt.columns-1)/tt.columns + 1
+			if h := buf.Area.Dy(); h != lines+2 {
+				t.Errorf("Unexpected overlay height, expected: %d, got: %d", lines+2, h)
+			}
+			var text strings.Builder
+			for y := buf.Area.Min.Y; y < buf.Area.Max.Y; y++ {
+				for x := buf.Area.Min.X; x < buf.Area.Max.X; x++ {
+					text.WriteRune(buf.At(x, y).Ch)
+				}
+				text.WriteString("\n")
+			}
+			for _, b := range append(view, global...) {
+				if !strings.Contains(text.String(), b.Description) {
+					t.Errorf("Binding %s is not shown:\n%s", b.Keys, text.String())
+				}
+			}
+		})
+	}
+}
+
+func TestKeysOverlayClosesOnAnyKey(t *testing.T) {
+	o := NewKeysOverlay("Container list", nil, nil)
+	events := make(chan termbox.Event, 2)
+	events <- termbox.Event{Type: termbox.EventResize}
+	events <- termbox.Event{Type: termbox.EventKey, Ch: 'x'}
+	var handled []termbox.Event
+	o.OnFocus(ui.EventSource{Events: events, EventHandledCallback: func(e termbox.Event) error {
+		handled = append(handled, e)
+		return nil
+	}})
+	if len(handled) != 1 || handled[0].Ch != 'x' {
+		t.Errorf("Unexpected events handled: %v", handled)
+	}
+	if len(events) != 0 {
+		t.Error("The overlay did not wait for a key")
+	}
+}
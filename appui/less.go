@@ -2,14 +2,25 @@ package appui
 
 import (
 	"io"
+	"sync/atomic"
 
 	"github.com/moncho/dry/ui"
 	"github.com/nsf/termbox-go"
 )
 
+//activePagers is the number of pagers using the screen
+var activePagers int32
+
+//PagerActive returns true if a pager (i.e. Less or Stream) is using the screen
+func PagerActive() bool {
+	return atomic.LoadInt32(&activePagers) > 0
+}
+
 //Less renders the given renderer output in a "less" buffer
 func Less(renderer ui.Renderer, screen *ui.Screen, events <-chan termbox.Event, onDone func()) {
 	defer onDone()
+	atomic.AddInt32(&activePagers, 1)
+	defer atomic.AddInt32(&activePagers, -1)
 	screen.ClearAndFlush()
 
 	less := ui.NewLess(screen, DryTheme)
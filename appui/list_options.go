@@ -0,0 +1,64 @@
+package appui
+
+import (
+	"strings"
+
+	"github.com/moncho/dry/docker"
+)
+
+//ListOptions are the filter and the sort mode a list starts with, the zero
+//value keeps the defaults of the list
+type ListOptions struct {
+	Filter   string
+	SortMode docker.SortMode
+}
+
+//SortName returns the name of the sort mode of the given column header, as
+//it is given on the command line: its title in lower case, words joined by
+//dashes (i.e. network-id)
+func SortName(header SortableColumnHeader) string {
+	return strings.Join(strings.Fields(strings.ToLower(header.Title)), "-")
+}
+
+//SortNames returns the names of the sort modes of the given column headers,
+//the columns that do not sort the list are left out
+func SortNames(headers []SortableColumnHeader) []string {
+	var names []string
+	for _, header := range headers {
+		if header.Mode != docker.NoSort && header.Title != "" {
+			names = append(names, SortName(header))
+		}
+	}
+	return names
+}
+
+//SortModeByName returns the sort mode of the column of the given headers
+//with the given sort name, and false if no column sorts by that name
+func SortModeByName(headers []SortableColumnHeader, name string) (docker.SortMode, bool) {
+	for _, header := range headers {
+		if header.Mode != docker.NoSort && header.Title != "" && strings.EqualFold(SortName(header), name) {
+			return header.Mode, true
+		}
+	}
+	return docker.NoSort, false
+}
+
+//ContainerSortHeaders returns the column headers of the container list
+func ContainerSortHeaders() []SortableColumnHeader {
+	return containerTableHeaders
+}
+
+//ImageSortHeaders returns the column headers of the image list
+func ImageSortHeaders() []SortableColumnHeader {
+	return imageTableHeaders
+}
+
+//NetworkSortHeaders returns the column headers of the network list
+func NetworkSortHeaders() []SortableColumnHeader {
+	return networkTableHeaders
+}
+
+//MonitorSortHeaders returns the column headers of the monitor
+func MonitorSortHeaders() []SortableColumnHeader {
+	return monitorTableHeaders
+}
@@ -0,0 +1,128 @@
+package appui
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/moncho/dry/ui"
+)
+
+//RenderRequest is called when a widget has to be rendered again because the
+//data it was loading in the background arrived
+var RenderRequest = func() {}
+
+//loadDebounce is how long a refresh waits before fetching, so that rapid
+//mount and unmount cycles result in a single fetch
+var loadDebounce = 100 * time.Millisecond
+
+//loadingDetails are the header details of the widgets that are loading
+func loadingDetails() string {
+	return "<b><blue> | </><yellow>Loading" + ui.DotsGlyph.String() + "</></> "
+}
+
+//AsyncLoader loads the data of a widget in the background. All its methods
+//but Wait must be called with the lock of the widget held, the data loaded
+//is applied with the lock held too.
+type AsyncLoader struct {
+	lock       sync.Locker
+	cancel     context.CancelFunc
+	generation uint64
+	loading    bool
+	loaded     bool
+	err        error
+	inFlight   sync.WaitGroup
+}
+
+//NewAsyncLoader creates an AsyncLoader for a widget protected by the given lock
+func NewAsyncLoader(lock sync.Locker) *AsyncLoader {
+	return &AsyncLoader{lock: lock}
+}
+
+//Load cancels the load in flight, if any, and starts a new one. fetch runs in
+//the background and returns the func that applies what was fetched, it is
+//called unless the load is canceled or a newer one is started in the
+//meantime, so results from overlapping loads never interleave. Once there is
+//data loaded, fetching waits for a little while so that rapid remounts fetch
+//only once.
+func (l *AsyncLoader) Load(fetch func(ctx context.Context) (func(), error)) {
+	l.Cancel()
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.generation++
+	generation := l.generation
+	l.loading = true
+	var delay time.Duration
+	if l.loaded {
+		delay = loadDebounce
+	}
+	l.inFlight.Add(1)
+	go func() {
+		defer l.inFlight.Done()
+		defer cancel()
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		apply, err := fetch(ctx)
+
+		l.lock.Lock()
+		if ctx.Err() != nil || generation != l.generation {
+			l.lock.Unlock()
+			return
+		}
+		l.loading = false
+		l.err = err
+		if err == nil {
+			l.loaded = true
+			if apply != nil {
+				apply()
+			}
+		}
+		l.lock.Unlock()
+		RenderRequest()
+	}()
+}
+
+//Cancel cancels the load in flight, if any, its data is discarded
+func (l *AsyncLoader) Cancel() {
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+	l.loading = false
+}
+
+//Reset cancels the load in flight and forgets that data was loaded, the
+//next load is not delayed
+func (l *AsyncLoader) Reset() {
+	l.Cancel()
+	l.loaded = false
+	l.err = nil
+}
+
+//Err returns the error of the last load, nil if it succeeded
+func (l *AsyncLoader) Err() error {
+	return l.err
+}
+
+//Loading returns true if data is being loaded and there was no data loaded before
+func (l *AsyncLoader) Loading() bool {
+	return l.loading && !l.loaded
+}
+
+//HeaderDetails returns the details to show on the widget header about the
+//data being loaded
+func (l *AsyncLoader) HeaderDetails() string {
+	if l.Loading() {
+		return loadingDetails()
+	}
+	return ""
+}
+
+//Wait waits for the loads in flight to finish, it must be called without
+//the lock of the widget held
+func (l *AsyncLoader) Wait() {
+	l.inFlight.Wait()
+}
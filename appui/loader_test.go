@@ -0,0 +1,118 @@
+package appui
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncLoader_OverlappingLoads(t *testing.T) {
+	var lock sync.Mutex
+	var applied []string
+	l := NewAsyncLoader(&lock)
+
+	slow := make(chan struct{})
+	lock.Lock()
+	l.Load(func(ctx context.Context) (func(), error) {
+		<-slow
+		return func() { applied = append(applied, "first") }, nil
+	})
+	l.Load(func(ctx context.Context) (func(), error) {
+		return func() { applied = append(applied, "second") }, nil
+	})
+	lock.Unlock()
+	close(slow)
+	l.Wait()
+
+	if len(applied) != 1 || applied[0] != "second" {
+		t.Errorf("Only the last load should be applied, got %v", applied)
+	}
+}
+
+func TestAsyncLoader_Cancel(t *testing.T) {
+	var lock sync.Mutex
+	applied := false
+	l := NewAsyncLoader(&lock)
+
+	fetching := make(chan struct{})
+	lock.Lock()
+	l.Load(func(ctx context.Context) (func(), error) {
+		close(fetching)
+		<-ctx.Done()
+		return func() { applied = true }, nil
+	})
+	lock.Unlock()
+	<-fetching
+
+	lock.Lock()
+	l.Cancel()
+	loading := l.Loading()
+	lock.Unlock()
+	l.Wait()
+
+	if applied {
+		t.Error("A canceled load was applied")
+	}
+	if loading {
+		t.Error("Loader is still loading after canceling")
+	}
+}
+
+func TestAsyncLoader_Debounce(t *testing.T) {
+	defer func(debounce time.Duration) { loadDebounce = debounce }(loadDebounce)
+	loadDebounce = 50 * time.Millisecond
+
+	var lock sync.Mutex
+	var fetches int
+	fetch := func(ctx context.Context) (func(), error) {
+		fetches++
+		return nil, nil
+	}
+	l := NewAsyncLoader(&lock)
+
+	lock.Lock()
+	l.Load(fetch)
+	lock.Unlock()
+	l.Wait()
+
+	//remounting rapidly once loaded
+	for i := 0; i < 5; i++ {
+		lock.Lock()
+		l.Load(fetch)
+		lock.Unlock()
+	}
+	l.Wait()
+
+	if fetches != 2 {
+		t.Errorf("Unexpected number of fetches, got %d, want 2", fetches)
+	}
+}
+
+func TestAsyncLoader_Err(t *testing.T) {
+	var lock sync.Mutex
+	l := NewAsyncLoader(&lock)
+
+	lock.Lock()
+	if l.HeaderDetails() != "" {
+		t.Error("Loader has details to show before loading")
+	}
+	l.Load(func(ctx context.Context) (func(), error) {
+		return nil, errors.New("daemon not available")
+	})
+	if l.HeaderDetails() == "" {
+		t.Error("Loader does not show it is loading")
+	}
+	lock.Unlock()
+	l.Wait()
+
+	lock.Lock()
+	defer lock.Unlock()
+	if l.Err() == nil {
+		t.Error("Error of the last load was not kept")
+	}
+	if l.Loading() {
+		t.Error("Loader is still loading after the load failed")
+	}
+}
@@ -2,6 +2,9 @@ package appui
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +13,24 @@ import (
 	"github.com/moncho/dry/ui"
 )
 
+//monitorRefreshRates are the refresh rates that can be used by the monitor,
+//from the fastest to the slowest.
+var monitorRefreshRates = []time.Duration{
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+}
+
+//containerHistory is the stats history collected by the monitor for a container
+type containerHistory struct {
+	name    string
+	history *docker.StatsHistory
+}
+
 //Monitor is a self-refreshing ui component that shows monitoring information about docker
 //containers.
 type Monitor struct {
@@ -18,28 +39,50 @@ type Monitor struct {
 	rows                 []*ContainerStatsRow
 	openChannels         []*docker.StatsChannel
 	unmount              chan struct{}
+	refreshRateChanged   chan struct{}
+	refreshRateIndex     int
+	paused               bool
+	mounts               int
+	sortMode             docker.SortMode
+	thresholds           MonitorThresholds
+	containerAlerts      map[string]*containerAlerts
+	alerts               []MonitorAlert
+	collectHistory       bool
+	histories            map[string]*containerHistory
+	showTraffic          bool
+	trafficSort          trafficSortMode
 	selectedIndex        int
 	offset               int
 	x, y                 int
 	height, width        int
 	startIndex, endIndex int
+	OnAlert              func(MonitorAlert) //called when a new alert is raised
 	sync.RWMutex
 }
 
 //NewMonitor creates a new Monitor component that will render itself on the given screen
-//at the given position and with the given width.
-func NewMonitor(daemon docker.ContainerDaemon, y int) *Monitor {
+//at the given position and with the given width, sorted as the given options tell.
+//The monitor is not filtered.
+func NewMonitor(daemon docker.ContainerDaemon, y int, opts ListOptions) *Monitor {
 	height := MainScreenAvailableHeight()
 	m := Monitor{
-		header:        defaultMonitorTableHeader,
-		daemon:        daemon,
-		selectedIndex: 0,
-		offset:        0,
-		x:             0,
-		y:             y,
-		height:        height,
-		width:         ui.ActiveScreen.Dimensions.Width,
-		unmount:       make(chan struct{}),
+		header:          defaultMonitorTableHeader,
+		daemon:          daemon,
+		selectedIndex:   0,
+		offset:          0,
+		x:               0,
+		y:               y,
+		height:          height,
+		width:           ui.ActiveScreen.Dimensions.Width,
+		unmount:         make(chan struct{}),
+		sortMode:        docker.SortStatsByName,
+		thresholds:      DefaultMonitorThresholds,
+		containerAlerts: make(map[string]*containerAlerts),
+		//buffered so changing the refresh rate never blocks
+		refreshRateChanged: make(chan struct{}, 1),
+	}
+	if opts.SortMode != docker.NoSort {
+		m.sortMode = opts.SortMode
 	}
 	return &m
 }
@@ -51,12 +94,18 @@ func (m *Monitor) Buffer() gizaktermui.Buffer {
 	y := m.y
 	buf := gizaktermui.NewBuffer()
 
-	widgetHeader := WidgetHeader("Containers", m.RowCount(), "")
+	widgetHeader := WidgetHeader("Containers", m.RowCount(), m.headerDetails())
 	widgetHeader.Y = y
 	buf.Merge(widgetHeader.Buffer())
 	y += widgetHeader.Height
 
+	m.sortRows()
+	if m.showTraffic {
+		buf.Merge(m.trafficBuffer(y))
+		return buf
+	}
 	m.header.SetY(y)
+	m.header.sortedBy(m.sortMode)
 	buf.Merge(m.header.Buffer())
 
 	y += m.header.Height
@@ -71,19 +120,62 @@ func (m *Monitor) Buffer() gizaktermui.Buffer {
 	return buf
 }
 
+//Alerts returns the alerts raised by this monitor, from the oldest to the newest
+func (m *Monitor) Alerts() []MonitorAlert {
+	m.RLock()
+	defer m.RUnlock()
+	alerts := make([]MonitorAlert, len(m.alerts))
+	copy(alerts, m.alerts)
+	return alerts
+}
+
 //Filter filters the container list by the given filter
 func (m *Monitor) Filter(filter string) {
 
 }
 
+//IncreaseRefreshRate makes the monitor refresh faster, up to the fastest rate available
+func (m *Monitor) IncreaseRefreshRate() {
+	m.Lock()
+	defer m.Unlock()
+	if m.refreshRateIndex > 0 {
+		m.refreshRateIndex--
+		m.notifyRefreshRateChange()
+	}
+}
+
+//DecreaseRefreshRate makes the monitor refresh slower, down to the slowest rate available
+func (m *Monitor) DecreaseRefreshRate() {
+	m.Lock()
+	defer m.Unlock()
+	if m.refreshRateIndex < len(monitorRefreshRates)-1 {
+		m.refreshRateIndex++
+		m.notifyRefreshRateChange()
+	}
+}
+
 //Mount prepares this widget for rendering
 func (m *Monitor) Mount() error {
+	m.Lock()
+	defer m.Unlock()
+	//A paused monitor keeps showing the last stats received
+	if m.paused && m.rows != nil {
+		m.align()
+		return nil
+	}
+	//Stats channels from a previous mount are no longer needed
+	m.stopChannels()
+	m.mounts++
 	daemon := m.daemon
 	containers := daemon.Containers(
 		[]docker.ContainerFilter{docker.ContainerFilters.Running()}, docker.SortByName)
 	var rows []*ContainerStatsRow
 	var channels []*docker.StatsChannel
 	for _, c := range containers {
+		if m.paused {
+			rows = append(rows, NewContainerStatsRow(c, defaultMonitorTableHeader))
+			continue
+		}
 		statsChan := daemon.OpenChannel(c)
 		rows = append(rows, NewSelfUpdatedContainerStatsRow(statsChan, defaultMonitorTableHeader))
 		channels = append(channels, statsChan)
@@ -91,6 +183,11 @@ func (m *Monitor) Mount() error {
 
 	m.rows = rows
 	m.openChannels = channels
+	if m.collectHistory {
+		for _, r := range rows {
+			r.recordHistory(m.historyOf(r.container))
+		}
+	}
 
 	m.align()
 	return nil
@@ -113,21 +210,27 @@ func (m *Monitor) OnEvent(event EventCommand) error {
 //RenderLoop makes this monitor to render itself until stopped.
 func (m *Monitor) RenderLoop(ctx context.Context) {
 
+	m.RLock()
+	mount := m.mounts
+	m.RUnlock()
 	go func() {
-		refreshTimer := time.NewTicker(500 * time.Millisecond)
-		defer refreshTimer.Stop()
+		refreshTimer := time.NewTicker(m.RefreshRate())
 		defer func() {
-			for _, c := range m.openChannels {
-				c.Done <- struct{}{}
-			}
+			refreshTimer.Stop()
 		}()
+		defer m.closeChannels(mount)
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-m.unmount:
 				return
+			case <-m.refreshRateChanged:
+				refreshTimer.Stop()
+				refreshTimer = time.NewTicker(m.RefreshRate())
+				m.refresh()
 			case <-refreshTimer.C:
+				m.checkThresholds()
 				m.refresh()
 			}
 		}
@@ -135,14 +238,158 @@ func (m *Monitor) RenderLoop(ctx context.Context) {
 
 }
 
+//Paused returns true if this monitor is paused
+func (m *Monitor) Paused() bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.paused
+}
+
+//RefreshRate returns the current refresh rate of this monitor
+func (m *Monitor) RefreshRate() time.Duration {
+	m.RLock()
+	defer m.RUnlock()
+	return monitorRefreshRates[m.refreshRateIndex]
+}
+
+//TogglePause pauses the monitor if it is running or resumes it if it is paused.
+//While paused, no stats are requested to Docker and the last stats received
+//are shown.
+func (m *Monitor) TogglePause() {
+	m.Lock()
+	defer m.Unlock()
+	if m.paused {
+		m.paused = false
+		var channels []*docker.StatsChannel
+		for _, r := range m.rows {
+			statsChan := m.daemon.OpenChannel(r.container)
+			r.listen(statsChan)
+			channels = append(channels, statsChan)
+		}
+		m.openChannels = channels
+	} else {
+		m.paused = true
+		for _, r := range m.rows {
+			r.detach()
+		}
+		m.stopChannels()
+	}
+}
+
+//CollectingHistory returns true if the monitor keeps the stats history of the containers
+func (m *Monitor) CollectingHistory() bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.collectHistory
+}
+
+//ToggleHistory starts keeping the stats received from each container, up to
+//the default stats history window, or stops it and drops what was kept
+func (m *Monitor) ToggleHistory() {
+	m.Lock()
+	defer m.Unlock()
+	m.collectHistory = !m.collectHistory
+	m.histories = nil
+	for _, r := range m.rows {
+		if m.collectHistory {
+			r.recordHistory(m.historyOf(r.container))
+		} else {
+			r.recordHistory(nil)
+		}
+	}
+}
+
+//StatsSeries returns the stats history of every container seen since the
+//history is being collected, or the last stats received from each container
+//if it is not
+func (m *Monitor) StatsSeries() []docker.ContainerStatsSeries {
+	m.RLock()
+	defer m.RUnlock()
+	var series []docker.ContainerStatsSeries
+	if m.collectHistory {
+		for _, h := range m.histories {
+			series = append(series, docker.ContainerStatsSeries{Name: h.name, Samples: h.history.Samples()})
+		}
+	} else {
+		now := time.Now()
+		for _, r := range m.rows {
+			if s := r.lastStats(); s != nil {
+				series = append(series, docker.ContainerStatsSeries{
+					Name:    containerName(r.container),
+					Samples: []docker.StatsSample{{Time: now, Stats: s}},
+				})
+			}
+		}
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Name < series[j].Name
+	})
+	return series
+}
+
+//SetThresholds sets the thresholds used to raise alerts
+func (m *Monitor) SetThresholds(t MonitorThresholds) {
+	m.Lock()
+	defer m.Unlock()
+	m.thresholds = t
+}
+
+//Thresholds returns the thresholds used to raise alerts
+func (m *Monitor) Thresholds() MonitorThresholds {
+	m.RLock()
+	defer m.RUnlock()
+	return m.thresholds
+}
+
 //RowCount returns the number of rows of this Monitor.
 func (m *Monitor) RowCount() int {
 	return len(m.rows)
 }
 
-//Sort sorts the container list
-func (m *Monitor) Sort() {
+//ActiveFilter returns the filter applied to the list, the monitor
+//does not support filters so it is always empty
+func (m *Monitor) ActiveFilter() string {
+	return ""
+}
 
+//SortedBy returns the title of the column the list is sorted by
+func (m *Monitor) SortedBy() string {
+	m.RLock()
+	defer m.RUnlock()
+	if m.showTraffic {
+		return m.trafficSortTitle()
+	}
+	return SortModeTitle(monitorTableHeaders, m.sortMode)
+}
+
+//Sort rotates the sort mode of the container list, on the network traffic
+//view it switches between sorting by received and by sent traffic
+func (m *Monitor) Sort() {
+	m.Lock()
+	defer m.Unlock()
+	if m.showTraffic {
+		if m.trafficSort == sortTrafficByRx {
+			m.trafficSort = sortTrafficByTx
+		} else {
+			m.trafficSort = sortTrafficByRx
+		}
+		return
+	}
+	switch m.sortMode {
+	case docker.SortStatsByName:
+		m.sortMode = docker.SortStatsByCPU
+	case docker.SortStatsByCPU:
+		m.sortMode = docker.SortStatsByMemory
+	case docker.SortStatsByMemory:
+		m.sortMode = docker.SortStatsByMemoryPercentage
+	case docker.SortStatsByMemoryPercentage:
+		m.sortMode = docker.SortStatsByNetIO
+	case docker.SortStatsByNetIO:
+		m.sortMode = docker.SortStatsByBlockIO
+	case docker.SortStatsByBlockIO:
+		m.sortMode = docker.SortStatsByName
+	default:
+	}
 }
 
 //Unmount tells this widget that it will not be rendering anymore
@@ -153,6 +400,198 @@ func (m *Monitor) Unmount() error {
 	return nil
 }
 
+//checkThresholds checks the last stats of each container against the
+//thresholds, raising alerts if needed.
+func (m *Monitor) checkThresholds() {
+	m.Lock()
+	if m.paused {
+		m.Unlock()
+		return
+	}
+	var raised []MonitorAlert
+	t := m.thresholds
+	now := time.Now()
+	for _, r := range m.rows {
+		id := r.container.ID
+		state, ok := m.containerAlerts[id]
+		if !ok {
+			state = &containerAlerts{}
+			m.containerAlerts[id] = state
+		}
+		name := r.Name.Text
+		if r.hasExited() {
+			if t.ContainerExit && !state.exited {
+				//the container is checked once it is not running on the store
+				c := m.daemon.ContainerByID(id)
+				if c == nil || !docker.IsContainerRunning(c) {
+					state.exited = c == nil || unexpectedExit(c)
+					if state.exited {
+						raised = append(raised, MonitorAlert{now, id, name, "exited unexpectedly"})
+					}
+				}
+			}
+		} else if s := r.lastStats(); s != nil {
+			if state.cpu.update(s.CPUPercentage, t.CPU, t) {
+				raised = append(raised, MonitorAlert{now, id, name,
+					fmt.Sprintf("CPU usage over %.0f%%: %.2f%%", t.CPU, s.CPUPercentage)})
+			}
+			if state.memory.update(s.MemoryPercentage, t.Memory, t) {
+				raised = append(raised, MonitorAlert{now, id, name,
+					fmt.Sprintf("memory usage over %.0f%%: %.2f%%", t.Memory, s.MemoryPercentage)})
+			}
+		}
+		r.alerted = state.active()
+	}
+	m.alerts = append(m.alerts, raised...)
+	if len(m.alerts) > maxMonitorAlerts {
+		m.alerts = m.alerts[len(m.alerts)-maxMonitorAlerts:]
+	}
+	onAlert := m.OnAlert
+	m.Unlock()
+
+	if onAlert != nil {
+		for _, a := range raised {
+			onAlert(a)
+		}
+	}
+}
+
+//closeChannels closes the stats channels opened on the given mount, if the monitor has been
+//mounted again since then the channels were already closed.
+func (m *Monitor) closeChannels(mount int) {
+	m.Lock()
+	defer m.Unlock()
+	if mount == m.mounts {
+		m.stopChannels()
+	}
+}
+
+//sortRows sorts the rows using the current sort mode, stats change on each refresh
+//so rows are sorted before rendering. The cursor is moved so the selected
+//container stays selected.
+func (m *Monitor) sortRows() {
+	rows := m.rows
+	if len(rows) == 0 || (m.sortMode == docker.NoSortStats && !m.showTraffic) {
+		return
+	}
+	var selectedID string
+	cursor := ui.ActiveScreen.Cursor
+	if pos := cursor.Position(); pos >= 0 && pos < len(rows) {
+		selectedID = rows[pos].container.ID
+	}
+
+	var value func(s *docker.Stats) float64
+	switch m.sortMode {
+	case docker.SortStatsByCPU:
+		value = func(s *docker.Stats) float64 { return s.CPUPercentage }
+	case docker.SortStatsByMemory:
+		value = func(s *docker.Stats) float64 { return s.Memory }
+	case docker.SortStatsByMemoryPercentage:
+		value = func(s *docker.Stats) float64 { return s.MemoryPercentage }
+	case docker.SortStatsByNetIO:
+		value = func(s *docker.Stats) float64 { return s.NetworkRx + s.NetworkTx }
+	case docker.SortStatsByBlockIO:
+		value = func(s *docker.Stats) float64 { return s.BlockRead + s.BlockWrite }
+	}
+	var sortAlg func(i, j int) bool
+	if m.showTraffic {
+		values := make(map[*ContainerStatsRow]float64, len(rows))
+		for _, r := range rows {
+			values[r] = m.trafficValue(r)
+		}
+		sortAlg = func(i, j int) bool {
+			vi, vj := values[rows[i]], values[rows[j]]
+			if vi == vj {
+				return rows[i].Name.Text < rows[j].Name.Text
+			}
+			return vi > vj
+		}
+	} else if value == nil {
+		sortAlg = func(i, j int) bool {
+			return rows[i].Name.Text < rows[j].Name.Text
+		}
+	} else {
+		//Values are read once so they do not change while sorting
+		values := make(map[*ContainerStatsRow]float64, len(rows))
+		for _, r := range rows {
+			if s := r.lastStats(); s != nil {
+				values[r] = value(s)
+			} else {
+				values[r] = -1
+			}
+		}
+		//Higher values go first
+		sortAlg = func(i, j int) bool {
+			vi, vj := values[rows[i]], values[rows[j]]
+			if vi == vj {
+				return rows[i].Name.Text < rows[j].Name.Text
+			}
+			return vi > vj
+		}
+	}
+	sort.SliceStable(rows, sortAlg)
+
+	if selectedID != "" {
+		for i, r := range rows {
+			if r.container.ID == selectedID {
+				if i != cursor.Position() {
+					cursor.ScrollTo(i)
+				}
+				break
+			}
+		}
+	}
+}
+
+//historyOf returns the stats history of the given container, a new one if
+//there is none
+func (m *Monitor) historyOf(c *docker.Container) *docker.StatsHistory {
+	if m.histories == nil {
+		m.histories = make(map[string]*containerHistory)
+	}
+	h, ok := m.histories[c.ID]
+	if !ok {
+		h = &containerHistory{
+			name:    containerName(c),
+			history: docker.NewStatsHistory(docker.DefaultStatsHistoryWindow),
+		}
+		m.histories[c.ID] = h
+	}
+	return h.history
+}
+
+func (m *Monitor) stopChannels() {
+	for _, c := range m.openChannels {
+		closeStatsChannel(c)
+	}
+	m.openChannels = nil
+}
+
+func (m *Monitor) headerDetails() string {
+	details := fmt.Sprintf(
+		"<b><blue>| Refresh rate: </><yellow>%s</></>", monitorRefreshRates[m.refreshRateIndex])
+	if m.sortMode == docker.SortStatsByMemoryPercentage {
+		details += " <b><blue>| Sorted by: </><yellow>MEM %</></>"
+	}
+	if m.showTraffic {
+		details += " <b><blue>| </><yellow>Network traffic</></>"
+	}
+	if m.collectHistory {
+		details += " <b><blue>| </><yellow>Recording history</></>"
+	}
+	if m.paused {
+		details += " <b><red>PAUSED</></>"
+	}
+	return details
+}
+
+func (m *Monitor) notifyRefreshRateChange() {
+	select {
+	case m.refreshRateChanged <- struct{}{}:
+	default:
+	}
+}
+
 //Align aligns rows
 func (m *Monitor) align() {
 	x := m.x
@@ -220,6 +659,14 @@ func (m *Monitor) visibleRows() []*ContainerStatsRow {
 			m.endIndex = m.startIndex + m.height
 		}
 	}
+	//the selection might have jumped after sorting
+	if selected < m.startIndex {
+		m.startIndex = selected
+		m.endIndex = selected + m.height
+		if m.endIndex >= count {
+			m.endIndex = count - 1
+		}
+	}
 	start := m.startIndex
 	end := m.endIndex + 1
 	return rows[start:end]
@@ -228,3 +675,20 @@ func (m *Monitor) refresh() {
 	ui.ActiveScreen.RenderBufferer(m)
 	ui.ActiveScreen.Flush()
 }
+
+//closeStatsChannel stops the stats collector of the given channel, it is safe
+//to use on channels of non-running containers.
+func closeStatsChannel(c *docker.StatsChannel) {
+	if c != nil && c.Done != nil {
+		close(c.Done)
+	}
+}
+
+//containerName returns the name of the given container, its truncated ID if
+//it has no name
+func containerName(c *docker.Container) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return docker.TruncateID(c.ID)
+}
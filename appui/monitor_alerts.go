@@ -0,0 +1,201 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//maxMonitorAlerts is the max number of alerts kept by the monitor
+const maxMonitorAlerts = 50
+
+//MonitorThresholds defines when the monitor raises an alert for a container.
+//A threshold of zero disables the alert.
+type MonitorThresholds struct {
+	CPU           float64 `json:"cpu"`            //CPU percentage
+	Memory        float64 `json:"memory"`         //Memory percentage
+	Intervals     int     `json:"intervals"`      //Consecutive refresh intervals over a threshold to raise an alert
+	Hysteresis    float64 `json:"hysteresis"`     //How much a metric must drop below a threshold to clear an alert
+	ContainerExit bool    `json:"container_exit"` //Raise an alert when a container exits unexpectedly
+	Bell          bool    `json:"bell"`           //Ring the terminal bell on new alerts
+}
+
+//DefaultMonitorThresholds are the thresholds used if none are given
+var DefaultMonitorThresholds = MonitorThresholds{
+	CPU:           90,
+	Memory:        90,
+	Intervals:     3,
+	Hysteresis:    5,
+	ContainerExit: true,
+}
+
+//Valid returns the thresholds with the values the threshold editor rejects
+//clamped: at least one interval and no negative hysteresis
+func (t MonitorThresholds) Valid() MonitorThresholds {
+	if t.Intervals < 1 {
+		t.Intervals = 1
+	}
+	if t.Hysteresis < 0 {
+		t.Hysteresis = 0
+	}
+	return t
+}
+
+//String returns the thresholds using the format expected by ParseMonitorThresholds
+func (t MonitorThresholds) String() string {
+	return fmt.Sprintf("cpu=%s mem=%s intervals=%d exit=%s bell=%s",
+		strconv.FormatFloat(t.CPU, 'f', -1, 64),
+		strconv.FormatFloat(t.Memory, 'f', -1, 64),
+		t.Intervals,
+		onOff(t.ContainerExit),
+		onOff(t.Bell))
+}
+
+//ParseMonitorThresholds parses the given text, a list of key=value pairs separated by
+//spaces, into thresholds. Keys not found in the text keep the value found in the given thresholds.
+func ParseMonitorThresholds(s string, t MonitorThresholds) (MonitorThresholds, error) {
+	for _, field := range strings.Fields(s) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return t, fmt.Errorf("invalid threshold definition: %s", field)
+		}
+		key, value := strings.ToLower(kv[0]), strings.ToLower(kv[1])
+		var err error
+		switch key {
+		case "cpu":
+			t.CPU, err = parsePercentage(value)
+		case "mem", "memory":
+			t.Memory, err = parsePercentage(value)
+		case "intervals":
+			t.Intervals, err = strconv.Atoi(value)
+			if err == nil && t.Intervals < 1 {
+				err = fmt.Errorf("intervals must be a positive number")
+			}
+		case "exit":
+			t.ContainerExit, err = parseOnOff(value)
+		case "bell":
+			t.Bell, err = parseOnOff(value)
+		default:
+			err = fmt.Errorf("unknown threshold: %s", key)
+		}
+		if err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
+//MonitorAlert is an alert raised by the monitor
+type MonitorAlert struct {
+	Time        time.Time
+	ContainerID string
+	Container   string
+	Message     string
+}
+
+type monitorAlertsRenderer struct {
+	alerts []MonitorAlert
+}
+
+//NewMonitorAlertsRenderer creates a renderer for the given monitor alerts
+func NewMonitorAlertsRenderer(alerts []MonitorAlert) ui.Renderer {
+	return &monitorAlertsRenderer{alerts}
+}
+
+//Render the alerts, the most recent first
+func (r *monitorAlertsRenderer) Render() string {
+	buf := new(bytes.Buffer)
+	buf.WriteString("<white>Monitor alerts</>\n\n")
+	if len(r.alerts) == 0 {
+		buf.WriteString("No alerts have been raised")
+	}
+	for i := len(r.alerts) - 1; i >= 0; i-- {
+		a := r.alerts[i]
+		fmt.Fprintf(buf, "<blue>%s</> <white>%s</> <yellow>(%s)</> <red>%s</>\n",
+			a.Time.Format("15:04:05"), a.Container, docker.TruncateID(a.ContainerID), a.Message)
+	}
+	return buf.String()
+}
+
+//thresholdAlert tracks the state of an alert on a metric
+type thresholdAlert struct {
+	count  int
+	active bool
+}
+
+//update updates the alert state with the given value, it returns true if the alert
+//was raised.
+func (a *thresholdAlert) update(value float64, threshold float64, t MonitorThresholds) bool {
+	if threshold <= 0 {
+		a.count = 0
+		a.active = false
+		return false
+	}
+	if a.active {
+		//hysteresis, so the alert does not flap
+		if value < threshold-t.Hysteresis {
+			a.active = false
+			a.count = 0
+		}
+		return false
+	}
+	if value > threshold {
+		a.count++
+	} else {
+		a.count = 0
+	}
+	if a.count >= t.Intervals {
+		a.active = true
+		return true
+	}
+	return false
+}
+
+//containerAlerts tracks the alerts of a container
+type containerAlerts struct {
+	cpu    thresholdAlert
+	memory thresholdAlert
+	exited bool
+}
+
+func (c *containerAlerts) active() bool {
+	return c.cpu.active || c.memory.active || c.exited
+}
+
+//unexpectedExit returns true if the given container exited with an error or was OOM killed
+func unexpectedExit(c *docker.Container) bool {
+	if c.ContainerJSON.ContainerJSONBase == nil || c.ContainerJSON.State == nil {
+		return true
+	}
+	return c.ContainerJSON.State.OOMKilled || c.ContainerJSON.State.ExitCode != 0
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func parseOnOff(s string) (bool, error) {
+	switch s {
+	case "on", "true", "yes":
+		return true, nil
+	case "off", "false", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected on or off, got: %s", s)
+}
+
+func parsePercentage(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil || v < 0 || v > 100 {
+		return 0, fmt.Errorf("invalid percentage: %s", s)
+	}
+	return v, nil
+}
@@ -0,0 +1,90 @@
+package appui
+
+import (
+	"testing"
+)
+
+func TestParseMonitorThresholds(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    MonitorThresholds
+		wantErr bool
+	}{
+		{
+			"empty text keeps the given thresholds",
+			"",
+			DefaultMonitorThresholds,
+			false,
+		},
+		{
+			"all thresholds",
+			"cpu=50 mem=75% intervals=5 exit=off bell=on",
+			MonitorThresholds{CPU: 50, Memory: 75, Intervals: 5, Hysteresis: DefaultMonitorThresholds.Hysteresis, ContainerExit: false, Bell: true},
+			false,
+		},
+		{
+			"invalid percentage",
+			"cpu=150",
+			DefaultMonitorThresholds,
+			true,
+		},
+		{
+			"unknown threshold",
+			"disk=10",
+			DefaultMonitorThresholds,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMonitorThresholds(tt.text, DefaultMonitorThresholds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMonitorThresholds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseMonitorThresholds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonitorThresholdsRoundTrip(t *testing.T) {
+	thresholds := MonitorThresholds{CPU: 12.5, Memory: 80, Intervals: 2, Hysteresis: 5, ContainerExit: true}
+	got, err := ParseMonitorThresholds(thresholds.String(), MonitorThresholds{Hysteresis: 5})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if got != thresholds {
+		t.Errorf("Thresholds did not survive a round trip, expected %v, got %v", thresholds, got)
+	}
+}
+
+func TestThresholdAlert(t *testing.T) {
+	thresholds := MonitorThresholds{CPU: 80, Intervals: 2, Hysteresis: 10}
+	a := &thresholdAlert{}
+
+	steps := []struct {
+		value  float64
+		raised bool
+		active bool
+	}{
+		{90, false, false},
+		{70, false, false},
+		{90, false, false},
+		{90, true, true},
+		{95, false, true},
+		//below the threshold but not enough to clear the alert
+		{75, false, true},
+		{90, false, true},
+		{65, false, false},
+	}
+	for i, step := range steps {
+		raised := a.update(step.value, thresholds.CPU, thresholds)
+		if raised != step.raised || a.active != step.active {
+			t.Errorf("Step %d, value %f: expected raised %t and active %t, got %t and %t",
+				i, step.value, step.raised, step.active, raised, a.active)
+		}
+	}
+}
@@ -1,10 +1,22 @@
 package appui
 
-import "github.com/moncho/dry/ui/termui"
+import (
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui/termui"
+)
 
 //defaultMonitorTableHeader is the default header for the container monitor table
 var defaultMonitorTableHeader = NewMonitorTableHeader()
 
+//monitorTableHeaders are the sortable columns of the monitor table
+var monitorTableHeaders = []SortableColumnHeader{
+	{"NAME", docker.SortStatsByName},
+	{"CPU", docker.SortStatsByCPU},
+	{"MEM", docker.SortStatsByMemory},
+	{"NET RX/TX", docker.SortStatsByNetIO},
+	{"BLOCK I/O", docker.SortStatsByBlockIO},
+}
+
 //MonitorTableHeader is the header for container monitor tables
 type MonitorTableHeader struct {
 	*termui.TableHeader
@@ -12,17 +24,38 @@ type MonitorTableHeader struct {
 
 //NewMonitorTableHeader creates a table header for the monitor screen
 func NewMonitorTableHeader() *MonitorTableHeader {
-	fields := []string{"NAME", "CPU", "MEM", "NET RX/TX", "BLOCK I/O"}
-
 	header := termui.NewHeader(DryTheme)
 	header.ColumnSpacing = DefaultColumnSpacing
 	//Status indicator header
 	header.AddFixedWidthColumn("", 2)
 	header.AddFixedWidthColumn("CONTAINER", IDColumnWidth)
-	for _, f := range fields {
-		header.AddColumn(f)
+	for _, f := range monitorTableHeaders {
+		header.AddColumn(f.Title)
 	}
 	header.AddFixedWidthColumn("PIDS", 5)
 	header.AddFixedWidthColumn("UPTIME", IDColumnWidth)
 	return &MonitorTableHeader{header}
 }
+
+//sortedBy marks the column of the given sort mode as the one being used to sort
+func (h *MonitorTableHeader) sortedBy(mode docker.SortMode) {
+	//Both memory sort modes are shown on the same column
+	if mode == docker.SortStatsByMemoryPercentage {
+		mode = docker.SortStatsByMemory
+	}
+	for _, c := range h.Columns {
+		colTitle := ColumnTitle(c.Text)
+		var header SortableColumnHeader
+		for _, h := range monitorTableHeaders {
+			if colTitle == h.Title {
+				header = h
+				break
+			}
+		}
+		if mode != docker.NoSortStats && header.Mode == mode {
+			c.Text = SortedColumnTitle(colTitle)
+		} else {
+			c.Text = colTitle
+		}
+	}
+}
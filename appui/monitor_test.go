@@ -0,0 +1,193 @@
+package appui
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+func TestMonitorRefreshRate(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	m := NewMonitor(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+
+	if m.RefreshRate() != 500*time.Millisecond {
+		t.Errorf("Unexpected default refresh rate, got: %s", m.RefreshRate())
+	}
+	m.IncreaseRefreshRate()
+	if m.RefreshRate() != 500*time.Millisecond {
+		t.Errorf("Refresh rate went below the lower bound, got: %s", m.RefreshRate())
+	}
+	m.DecreaseRefreshRate()
+	if m.RefreshRate() != time.Second {
+		t.Errorf("Unexpected refresh rate, expected: 1s, got: %s", m.RefreshRate())
+	}
+	for i := 0; i < 2*len(monitorRefreshRates); i++ {
+		m.DecreaseRefreshRate()
+	}
+	if m.RefreshRate() != 30*time.Second {
+		t.Errorf("Refresh rate went above the upper bound, got: %s", m.RefreshRate())
+	}
+}
+
+func TestMonitorPause(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	m := NewMonitor(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	if m.Paused() {
+		t.Error("Monitor must not be paused when created")
+	}
+	m.TogglePause()
+	if !m.Paused() {
+		t.Error("Monitor was not paused")
+	}
+	if m.openChannels != nil {
+		t.Error("A paused monitor must not have open stats channels")
+	}
+	m.TogglePause()
+	if m.Paused() {
+		t.Error("Monitor was not resumed")
+	}
+}
+
+func TestMonitorSortFollowsSelection(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	m := NewMonitor(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	cpu := []float64{10, 50, 30}
+	for i, v := range cpu {
+		c := &docker.Container{
+			Container: types.Container{ID: strconv.Itoa(i), Names: []string{"c" + strconv.Itoa(i)}},
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{},
+				}},
+		}
+		row := NewContainerStatsRow(c, defaultMonitorTableHeader)
+		row.Update(c, &docker.Stats{CPUPercentage: v})
+		m.rows = append(m.rows, row)
+	}
+	//c0 is selected
+	ui.ActiveScreen.Cursor.ScrollTo(0)
+
+	m.Sort()
+	if m.sortMode != docker.SortStatsByCPU {
+		t.Fatalf("Unexpected sort mode: %d", m.sortMode)
+	}
+	m.sortRows()
+	expected := []string{"1", "2", "0"}
+	for i, id := range expected {
+		if m.rows[i].container.ID != id {
+			t.Errorf("Unexpected container at position %d, expected: %s, got: %s", i, id, m.rows[i].container.ID)
+		}
+	}
+	if pos := ui.ActiveScreen.Cursor.Position(); pos != 2 {
+		t.Errorf("Cursor did not follow the selected container, expected position 2, got: %d", pos)
+	}
+}
+
+func TestMonitorStatsSeries(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	m := NewMonitor(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	for i, name := range []string{"/web", "/db"} {
+		c := &docker.Container{
+			Container: types.Container{ID: strconv.Itoa(i), Names: []string{name}},
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{},
+				}},
+		}
+		m.rows = append(m.rows, NewContainerStatsRow(c, defaultMonitorTableHeader))
+	}
+	m.rows[0].Update(m.rows[0].container, &docker.Stats{CPUPercentage: 10})
+
+	series := m.StatsSeries()
+	if len(series) != 1 || series[0].Name != "web" || len(series[0].Samples) != 1 {
+		t.Fatalf("Unexpected snapshot of the last stats: %+v", series)
+	}
+
+	m.ToggleHistory()
+	if !m.CollectingHistory() {
+		t.Fatal("Monitor is not collecting the stats history")
+	}
+	for _, r := range m.rows {
+		r.Update(r.container, &docker.Stats{CPUPercentage: 20})
+	}
+	series = m.StatsSeries()
+	if len(series) != 2 {
+		t.Fatalf("Unexpected number of series, expected: 2, got: %d", len(series))
+	}
+	for i, name := range []string{"db", "web"} {
+		if series[i].Name != name {
+			t.Errorf("Unexpected series at position %d, expected: %s, got: %s", i, name, series[i].Name)
+		}
+		if len(series[i].Samples) != 1 || series[i].Samples[0].Stats.CPUPercentage != 20 {
+			t.Errorf("Unexpected samples of %s: %+v", name, series[i].Samples)
+		}
+	}
+
+	m.ToggleHistory()
+	if m.CollectingHistory() || m.histories != nil {
+		t.Error("Monitor must drop the stats history when it stops collecting it")
+	}
+}
+
+func TestMonitorTrafficSort(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	m := NewMonitor(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	traffic := [][2]float64{{100, 900}, {500, 100}, {300, 300}}
+	now := time.Now()
+	for i, v := range traffic {
+		c := &docker.Container{
+			Container: types.Container{ID: strconv.Itoa(i), Names: []string{"c" + strconv.Itoa(i)}},
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					State: &types.ContainerState{},
+				}},
+		}
+		row := NewContainerStatsRow(c, defaultMonitorTableHeader)
+		row.traffic.Add(now, &docker.Stats{})
+		row.traffic.Add(now.Add(time.Second), &docker.Stats{NetworkRx: v[0], NetworkTx: v[1]})
+		m.rows = append(m.rows, row)
+	}
+	m.ToggleTraffic()
+	if !m.ShowingTraffic() {
+		t.Fatal("Monitor is not showing the network traffic")
+	}
+	if m.SortedBy() != "RX/s" {
+		t.Errorf("Unexpected sort column: %s", m.SortedBy())
+	}
+	m.sortRows()
+	for i, id := range []string{"1", "2", "0"} {
+		if m.rows[i].container.ID != id {
+			t.Errorf("Unexpected container at position %d sorting by RX, expected: %s, got: %s", i, id, m.rows[i].container.ID)
+		}
+	}
+	m.Sort()
+	if m.SortedBy() != "TX/s" {
+		t.Errorf("Unexpected sort column: %s", m.SortedBy())
+	}
+	m.sortRows()
+	for i, id := range []string{"0", "2", "1"} {
+		if m.rows[i].container.ID != id {
+			t.Errorf("Unexpected container at position %d sorting by TX, expected: %s, got: %s", i, id, m.rows[i].container.ID)
+		}
+	}
+}
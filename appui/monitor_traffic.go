@@ -0,0 +1,142 @@
+package appui
+
+import (
+	"fmt"
+	"strings"
+
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//trafficSortMode is the direction of the traffic that sorts the network traffic view
+type trafficSortMode int
+
+const (
+	sortTrafficByRx trafficSortMode = iota
+	sortTrafficByTx
+)
+
+//trafficColumns are the titles of the columns of the network traffic view
+var trafficColumns = []string{"NAME", "INTERFACE", "RX/s", "TX/s", "PEAK RX/s", "PEAK TX/s"}
+
+//ShowingTraffic returns true if the monitor shows the network traffic rates
+//of the containers instead of their stats
+func (m *Monitor) ShowingTraffic() bool {
+	m.RLock()
+	defer m.RUnlock()
+	return m.showTraffic
+}
+
+//ToggleTraffic switches between showing the stats of the containers and
+//showing their network traffic rates. Rates are calculated from the stats the
+//monitor already receives, so no more stats are requested to Docker.
+func (m *Monitor) ToggleTraffic() {
+	m.Lock()
+	defer m.Unlock()
+	m.showTraffic = !m.showTraffic
+}
+
+//trafficSortTitle returns the title of the column that sorts the network traffic view
+func (m *Monitor) trafficSortTitle() string {
+	if m.trafficSort == sortTrafficByTx {
+		return "TX/s"
+	}
+	return "RX/s"
+}
+
+//trafficValue returns the rate used to sort the given row on the network traffic view
+func (m *Monitor) trafficValue(row *ContainerStatsRow) float64 {
+	total, _ := row.trafficRates()
+	if m.trafficSort == sortTrafficByTx {
+		return total.Tx
+	}
+	return total.Rx
+}
+
+//trafficBuffer renders the network traffic rates of the visible rows starting
+//at the given line. Containers with more than one interface get a line for
+//each interface below the line with their total traffic.
+func (m *Monitor) trafficBuffer(y int) gizaktermui.Buffer {
+	width := (m.width - 4) / len(trafficColumns)
+	cell := func(s string) string {
+		return padCell(s, width-1) + " "
+	}
+	var header strings.Builder
+	for _, title := range trafficColumns {
+		if title == m.trafficSortTitle() {
+			title = SortedColumnTitle(title)
+		}
+		header.WriteString(cell(title))
+	}
+	lines := []string{"<b><blue>  " + header.String() + "</></>"}
+
+	selected := ui.ActiveScreen.Cursor.Position()
+	for _, r := range m.visibleRows() {
+		if len(lines) > m.height {
+			break
+		}
+		marker := "  "
+		if r == m.rowAt(selected) {
+			marker = ui.PointerGlyph.String() + " "
+		}
+		total, rates := r.trafficRates()
+		iface := ""
+		if len(rates) == 1 {
+			iface = rates[0].Interface
+		} else if len(rates) > 1 {
+			iface = fmt.Sprintf("%d interfaces", len(rates))
+		}
+		line := marker + cell(r.Name.Text) + cell(iface) + rateCells(total, cell)
+		if r.hasExited() {
+			line = "<darkgrey>" + line + "</>"
+		} else if r == m.rowAt(selected) {
+			line = "<b>" + line + "</>"
+		}
+		lines = append(lines, line)
+		if len(rates) < 2 {
+			continue
+		}
+		for j, rate := range rates {
+			if len(lines) > m.height {
+				break
+			}
+			branch := ui.TreeBranchGlyph.String()
+			if j == len(rates)-1 {
+				branch = ui.TreeLastBranchGlyph.String()
+			}
+			lines = append(lines, "  "+cell("")+cell(branch+rate.Interface)+rateCells(rate, cell))
+		}
+	}
+
+	par := termui.NewParFromMarkupText(DryTheme, strings.Join(lines, "\n"))
+	par.Border = false
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	par.X = m.x
+	par.Y = y
+	par.Width = m.width
+	par.Height = len(lines)
+	return par.Buffer()
+}
+
+//rowAt returns the row at the given position, nil if there is none
+func (m *Monitor) rowAt(pos int) *ContainerStatsRow {
+	if pos < 0 || pos >= len(m.rows) {
+		return nil
+	}
+	return m.rows[pos]
+}
+
+//rateCells returns the cells with the current and peak rates of the given rate
+func rateCells(r docker.NetworkRate, cell func(string) string) string {
+	return cell(formatRate(r.Rx)) + cell(formatRate(r.Tx)) + cell(formatRate(r.PeakRx)) + cell(formatRate(r.PeakTx))
+}
+
+//formatRate formats the given bytes per second rate
+func formatRate(rate float64) string {
+	return units.BytesSize(rate) + "/s"
+}
@@ -0,0 +1,71 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+)
+
+//NetworkIPAMDetails describes the IPAM configs of the given network, every
+//one of them for dual-stack networks, and the given subnet conflicts
+func NetworkIPAMDetails(network types.NetworkResource, conflicts []docker.SubnetConflict) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<white>%s</> (%s), driver <white>%s</>, scope <white>%s</>\n\n",
+		network.Name, docker.TruncateID(network.ID), network.Driver, network.Scope)
+
+	ipamDriver := network.IPAM.Driver
+	if ipamDriver == "" {
+		ipamDriver = "default"
+	}
+	fmt.Fprintf(&buf, "<blue>IPAM driver:</> %s\n", ipamDriver)
+	fmt.Fprintf(&buf, "<blue>IPv6 enabled:</> %t\n", network.EnableIPv6)
+	fmt.Fprintf(&buf, "<blue>Internal:</> %t\n\n", network.Internal)
+
+	if len(network.IPAM.Config) == 0 {
+		buf.WriteString("The network has no IPAM config.\n")
+	} else {
+		w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "SUBNET\tGATEWAY\tIP RANGE\tAUXILIARY ADDRESSES")
+		for _, config := range network.IPAM.Config {
+			var aux []string
+			for host, address := range config.AuxAddress {
+				aux = append(aux, host+"="+address)
+			}
+			sort.Strings(aux)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				orDash(config.Subnet), orDash(config.Gateway), orDash(config.IPRange), orDash(strings.Join(aux, ", ")))
+		}
+		w.Flush()
+	}
+	if len(network.IPAM.Options) > 0 {
+		var options []string
+		for option, value := range network.IPAM.Options {
+			options = append(options, option+"="+value)
+		}
+		sort.Strings(options)
+		fmt.Fprintf(&buf, "\n<blue>IPAM options:</> %s\n", strings.Join(options, ", "))
+	}
+
+	buf.WriteString("\n")
+	if len(conflicts) == 0 {
+		buf.WriteString("<green>No subnet conflicts found</>\n")
+	} else {
+		buf.WriteString("<red>Subnet conflicts</>, containers may not reach the addresses their subnets share:\n")
+		for _, conflict := range conflicts {
+			fmt.Fprintf(&buf, "\t<red>%s</> %s\n", conflictSymbol, conflict)
+		}
+	}
+	return buf.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -1,12 +1,17 @@
 package appui
 
 import (
+	"fmt"
+
 	"github.com/docker/docker/api/types"
 	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/docker/formatter"
 	drytermui "github.com/moncho/dry/ui/termui"
 )
 
+const conflictSymbol = "\u26A0"
+
 //NetworkRow is a Grid row showing information about a Docker image
 type NetworkRow struct {
 	network    types.NetworkResource
@@ -18,22 +23,34 @@ type NetworkRow struct {
 	Scope      *drytermui.ParColumn
 	Subnet     *drytermui.ParColumn
 	Gateway    *drytermui.ParColumn
+	conflicts  []docker.SubnetConflict
 	Row
 }
 
-//NewNetworkRow creates a new NetworkRow widget
-func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *NetworkRow {
+//NewNetworkRow creates a new NetworkRow widget, networks whose subnets
+//conflict with others are flagged. The subnet and gateway columns are
+//only added if withIPAM is true.
+func NewNetworkRow(network types.NetworkResource, conflicts []docker.SubnetConflict, table drytermui.Table, withIPAM bool) *NetworkRow {
 	networkFormatter := formatter.NewNetworkFormatter(network, true)
 
+	name := networkFormatter.Name()
+	if len(conflicts) > 0 {
+		name = conflictSymbol + " " + name
+	}
+	subnet := networkFormatter.Subnet()
+	if more := len(docker.Subnets(network)) - 1; more > 0 {
+		subnet += fmt.Sprintf(" (+%d)", more)
+	}
 	row := &NetworkRow{
 		network:    network,
+		conflicts:  conflicts,
 		ID:         drytermui.NewThemedParColumn(DryTheme, networkFormatter.ID()),
-		Name:       drytermui.NewThemedParColumn(DryTheme, networkFormatter.Name()),
+		Name:       drytermui.NewThemedParColumn(DryTheme, name),
 		Driver:     drytermui.NewThemedParColumn(DryTheme, networkFormatter.Driver()),
 		Containers: drytermui.NewThemedParColumn(DryTheme, networkFormatter.Containers()),
 		Services:   drytermui.NewThemedParColumn(DryTheme, networkFormatter.Services()),
 		Scope:      drytermui.NewThemedParColumn(DryTheme, networkFormatter.Scope()),
-		Subnet:     drytermui.NewThemedParColumn(DryTheme, networkFormatter.Subnet()),
+		Subnet:     drytermui.NewThemedParColumn(DryTheme, subnet),
 		Gateway:    drytermui.NewThemedParColumn(DryTheme, networkFormatter.Gateway()),
 	}
 	row.Height = 1
@@ -46,8 +63,6 @@ func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *Networ
 		row.Containers,
 		row.Services,
 		row.Scope,
-		row.Subnet,
-		row.Gateway,
 	}
 	row.ParColumns = []*drytermui.ParColumn{
 		row.ID,
@@ -56,14 +71,27 @@ func NewNetworkRow(network types.NetworkResource, table drytermui.Table) *Networ
 		row.Containers,
 		row.Services,
 		row.Scope,
-		row.Subnet,
-		row.Gateway,
 	}
+	if withIPAM {
+		row.Columns = append(row.Columns, row.Subnet, row.Gateway)
+		row.ParColumns = append(row.ParColumns, row.Subnet, row.Gateway)
+	}
+	row.NotHighlighted()
 
 	return row
 
 }
 
+//NotHighlighted marks this rows as being not highlighted, the networks with
+//conflicting subnets stand out
+func (row *NetworkRow) NotHighlighted() {
+	row.Row.NotHighlighted()
+	if len(row.conflicts) > 0 {
+		row.Name.TextFgColor = termui.ColorRed
+		row.Subnet.TextFgColor = termui.ColorRed
+	}
+}
+
 //ColumnsForFilter returns the columns that are used to filter
 func (row *NetworkRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.ID, row.Name, row.Driver, row.Services, row.Scope, row.Subnet, row.Gateway}
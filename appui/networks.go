@@ -1,19 +1,19 @@
 package appui
 
 import (
+	"context"
 	"fmt"
 	"sort"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/docker/docker/api/types"
 	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 	"github.com/moncho/dry/ui/termui"
 )
 
-var defaultNetworkTableHeader = networkTableHeader()
-
 var networkTableHeaders = []SortableColumnHeader{
 	{`NETWORK ID`, docker.SortNetworksByID},
 	{`NAME`, docker.SortNetworksByName},
@@ -32,24 +32,40 @@ type DockerNetworksWidget struct {
 	filteredRows         []*NetworkRow
 	totalRows            []*NetworkRow
 	filterPattern        string
+	search               RowSearch
 	height, width        int
 	selectedIndex        int
+	selectedID           string //the network on the cursor on the last rendering
 	startIndex, endIndex int
 	x, y                 int
 	sortMode             docker.SortMode
-	mounted              bool
+	order                sortOrder
+	//showIPAM is true if the subnet and gateway of the networks are shown
+	showIPAM bool
+	//conflicts are the subnet conflicts of the networks, by network ID
+	conflicts   map[string][]docker.SubnetConflict
+	mounted     bool
+	loader      *AsyncLoader
+	autoRefresh *AutoRefresh
 	sync.RWMutex
 }
 
-//NewDockerNetworksWidget creates a renderer for a network list
-func NewDockerNetworksWidget(dockerDaemon docker.NetworkAPI, y int) *DockerNetworksWidget {
+//NewDockerNetworksWidget creates a renderer for a network list that starts
+//filtered and sorted as the given options tell
+func NewDockerNetworksWidget(dockerDaemon docker.NetworkAPI, y int, opts ListOptions) *DockerNetworksWidget {
 	w := DockerNetworksWidget{
 		dockerDaemon: dockerDaemon,
 		y:            y,
-		header:       defaultNetworkTableHeader,
+		header:       networkTableHeader(true),
 		height:       MainScreenAvailableHeight(),
 		sortMode:     docker.SortNetworksByID,
+		showIPAM:     true,
 		width:        ui.ActiveScreen.Dimensions.Width}
+	w.filterPattern = opts.Filter
+	if opts.SortMode != docker.NoSort {
+		w.sortMode = opts.SortMode
+	}
+	w.loader = NewAsyncLoader(&w)
 
 	RegisterWidget(docker.NetworkSource, &w)
 	return &w
@@ -66,10 +82,21 @@ func (s *DockerNetworksWidget) Buffer() gizaktermui.Buffer {
 		var filter string
 		if s.filterPattern != "" {
 			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+				"<b><blue> | Active filter: </><yellow>%s</></> ", FilterText(s.filterPattern))
+		}
+
+		var conflict string
+		if s.selectedIndex < len(s.filteredRows) {
+			if conflicts := s.filteredRows[s.selectedIndex].conflicts; len(conflicts) > 0 {
+				conflict = fmt.Sprintf("<b><blue> | </><red>%s %s</></>", conflictSymbol, conflicts[0])
+				if len(conflicts) > 1 {
+					conflict += fmt.Sprintf(" <red>and %d more</>", len(conflicts)-1)
+				}
+			}
 		}
 
-		widgetHeader := WidgetHeader("Networks", s.RowCount(), filter)
+		widgetHeader := WidgetHeader("Networks", s.RowCount(),
+			filter+s.autoRefresh.headerDetails(time.Now())+s.loader.HeaderDetails()+conflict)
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -90,6 +117,7 @@ func (s *DockerNetworksWidget) Buffer() gizaktermui.Buffer {
 				imageRow.Highlighted()
 			}
 			buf.Merge(imageRow.Buffer())
+			s.search.Highlight(buf, imageRow)
 		}
 	}
 	return buf
@@ -102,25 +130,51 @@ func (s *DockerNetworksWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
-//Mount tells this widget to be ready for rendering
+//Search moves the cursor to the first network, starting from the selected
+//one, that matches the given pattern. Returns false if no network matches.
+func (s *DockerNetworksWidget) Search(pattern string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Search(pattern, len(s.filteredRows), s.filterableRow)
+}
+
+//SearchNext moves the cursor to the next network that matches the last pattern searched
+func (s *DockerNetworksWidget) SearchNext() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Next(len(s.filteredRows), s.filterableRow)
+}
+
+//SearchPrevious moves the cursor to the previous network that matches the last pattern searched
+func (s *DockerNetworksWidget) SearchPrevious() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Previous(len(s.filteredRows), s.filterableRow)
+}
+
+//Mount tells this widget to be ready for rendering, networks are loaded in the background
 func (s *DockerNetworksWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
-		networks, err := s.dockerDaemon.Networks()
-		if err != nil {
-			return err
-		}
-
-		networkRows := make([]*NetworkRow, len(networks))
-		for i, network := range networks {
-			networkRows[i] = NewNetworkRow(network, s.header)
-		}
-		s.totalRows = networkRows
 		s.mounted = true
-		s.align()
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			networks, err := s.dockerDaemon.Networks()
+			if err != nil {
+				return nil, err
+			}
+			conflicts := docker.SubnetConflicts(networks, s.dockerDaemon.HostRanges())
+			return func() {
+				s.conflicts = conflicts
+				s.totalRows = make([]*NetworkRow, len(networks))
+				for i, network := range networks {
+					s.totalRows[i] = NewNetworkRow(network, conflicts[network.ID], s.header, s.showIPAM)
+				}
+				s.align()
+			}, nil
+		})
 	}
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
@@ -136,12 +190,47 @@ func (s *DockerNetworksWidget) OnEvent(event EventCommand) error {
 	return nil
 }
 
+//SubnetConflicts returns the subnet conflicts of the network with the given ID
+func (s *DockerNetworksWidget) SubnetConflicts(id string) []docker.SubnetConflict {
+	s.RLock()
+	defer s.RUnlock()
+	return s.conflicts[id]
+}
+
+//ToggleIPAM shows or hides the subnet and gateway columns, returns true if
+//they are shown
+func (s *DockerNetworksWidget) ToggleIPAM() bool {
+	s.Lock()
+	defer s.Unlock()
+	s.showIPAM = !s.showIPAM
+	s.header = networkTableHeader(s.showIPAM)
+	for i, row := range s.totalRows {
+		s.totalRows[i] = NewNetworkRow(row.network, row.conflicts, s.header, s.showIPAM)
+	}
+	s.align()
+	return s.showIPAM
+}
+
 //RowCount returns the number of rows of this widget.
 func (s *DockerNetworksWidget) RowCount() int {
 	return len(s.filteredRows)
 
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *DockerNetworksWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *DockerNetworksWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return SortModeTitle(networkTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
 //SortNetworksByID -> SortNetworksByName -> SortNetworksByDriver
 func (s *DockerNetworksWidget) Sort() {
@@ -163,11 +252,56 @@ func (s *DockerNetworksWidget) Sort() {
 	}
 }
 
-//Unmount tells this widget that it will not be rendering anymore
-func (s *DockerNetworksWidget) Unmount() error {
+//ReverseSort switches the order of the column the list is sorted by
+//between ascending and descending, each column keeps its own order
+func (s *DockerNetworksWidget) ReverseSort() {
+	s.Lock()
+	defer s.Unlock()
+	s.order.Reverse(s.sortMode)
+}
+
+//SortDescending returns true if the list is sorted in descending order
+func (s *DockerNetworksWidget) SortDescending() bool {
 	s.RLock()
 	defer s.RUnlock()
+	return s.order.Descending(s.sortMode)
+}
+
+//SortMode returns the sort mode of the list
+func (s *DockerNetworksWidget) SortMode() docker.SortMode {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sortMode
+}
+
+//SetSortMode sorts the list with the given sort mode
+func (s *DockerNetworksWidget) SetSortMode(mode docker.SortMode) {
+	s.Lock()
+	defer s.Unlock()
+	s.sortMode = mode
+}
+
+//SetAutoRefresh sets the auto refresh state shown on the header, nil if
+//the list does not refresh on its own
+func (s *DockerNetworksWidget) SetAutoRefresh(r *AutoRefresh) {
+	s.Lock()
+	defer s.Unlock()
+	s.autoRefresh = r
+}
+
+//Refreshing returns true until the list is mounted and loaded
+func (s *DockerNetworksWidget) Refreshing() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return !s.mounted || s.loader.loading
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *DockerNetworksWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
 	s.mounted = false
+	s.loader.Cancel()
 	return nil
 }
 
@@ -186,6 +320,11 @@ func (s *DockerNetworksWidget) align() {
 
 }
 
+//filterableRow returns the row of the filtered networks at the given index
+func (s *DockerNetworksWidget) filterableRow(i int) FilterableRow {
+	return s.filteredRows[i]
+}
+
 func (s *DockerNetworksWidget) filterRows() {
 
 	if s.filterPattern != "" {
@@ -202,6 +341,23 @@ func (s *DockerNetworksWidget) filterRows() {
 	}
 }
 
+//followSelection moves the cursor to the row of the network it was on, if
+//sorting or reloading the list moved the row, unless the user moved it
+func (s *DockerNetworksWidget) followSelection() {
+	cursor := ui.ActiveScreen.Cursor
+	if s.selectedID == "" || cursor.Position() != s.selectedIndex {
+		return
+	}
+	for i, row := range s.filteredRows {
+		if row.network.ID == s.selectedID {
+			if i != cursor.Position() {
+				cursor.ScrollTo(i)
+			}
+			return
+		}
+	}
+}
+
 func (s *DockerNetworksWidget) calculateVisibleRows() {
 
 	count := s.RowCount()
@@ -243,6 +399,7 @@ func (s *DockerNetworksWidget) calculateVisibleRows() {
 func (s *DockerNetworksWidget) prepareForRendering() {
 	s.sortRows()
 	s.filterRows()
+	s.followSelection()
 	index := ui.ActiveScreen.Cursor.Position()
 	if index < 0 {
 		index = 0
@@ -250,17 +407,18 @@ func (s *DockerNetworksWidget) prepareForRendering() {
 		index = s.RowCount() - 1
 	}
 	s.selectedIndex = index
+	s.selectedID = ""
+	if index >= 0 && index < len(s.filteredRows) {
+		s.selectedID = s.filteredRows[index].network.ID
+	}
 	s.calculateVisibleRows()
 }
 func (s *DockerNetworksWidget) updateHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := ColumnTitle(c.Text)
 		var header SortableColumnHeader
-		if strings.Contains(colTitle, DownArrow) {
-			colTitle = colTitle[DownArrowLength:]
-		}
 		for _, h := range networkTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -268,7 +426,7 @@ func (s *DockerNetworksWidget) updateHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = DownArrow + colTitle
+			c.Text = SortColumnTitle(colTitle, s.order.Descending(sortMode))
 		} else {
 			c.Text = colTitle
 		}
@@ -291,7 +449,7 @@ func (s *DockerNetworksWidget) sortRows() {
 		}
 	case docker.SortNetworksByName:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Name.Text < rows[j].Name.Text
+			return rows[i].network.Name < rows[j].network.Name
 		}
 	case docker.SortNetworksByDriver:
 		sortAlg = func(i, j int) bool {
@@ -307,18 +465,28 @@ func (s *DockerNetworksWidget) sortRows() {
 		}
 	case docker.SortNetworksBySubnet:
 		sortAlg = func(i, j int) bool {
-			return rows[i].Subnet.Text < rows[j].Subnet.Text
+			return docker.SubnetLess(firstSubnet(rows[i].network), firstSubnet(rows[j].network))
 		}
 
 	}
-	sort.SliceStable(rows, sortAlg)
+	sort.SliceStable(rows, s.order.less(mode, sortAlg))
+}
+
+//firstSubnet returns the subnet of the first IPAM config of the given network
+func firstSubnet(network types.NetworkResource) string {
+	if subnets := docker.Subnets(network); len(subnets) > 0 {
+		return subnets[0]
+	}
+	return ""
 }
 
 func (s *DockerNetworksWidget) visibleRows() []*NetworkRow {
 	return s.filteredRows[s.startIndex:s.endIndex]
 }
 
-func networkTableHeader() *termui.TableHeader {
+//networkTableHeader creates the header of the network table, with the subnet
+//and gateway columns if withIPAM is true
+func networkTableHeader(withIPAM bool) *termui.TableHeader {
 	header := termui.NewHeader(DryTheme)
 	header.ColumnSpacing = DefaultColumnSpacing
 	header.AddColumn(networkTableHeaders[0].Title)
@@ -327,8 +495,10 @@ func networkTableHeader() *termui.TableHeader {
 	header.AddFixedWidthColumn(networkTableHeaders[3].Title, 12)
 	header.AddFixedWidthColumn(networkTableHeaders[4].Title, 12)
 	header.AddColumn(networkTableHeaders[5].Title)
-	header.AddColumn(networkTableHeaders[6].Title)
-	header.AddColumn(networkTableHeaders[7].Title)
+	if withIPAM {
+		header.AddColumn(networkTableHeaders[6].Title)
+		header.AddColumn(networkTableHeaders[7].Title)
+	}
 
 	return header
 }
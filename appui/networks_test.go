@@ -0,0 +1,84 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//overlappingNetworks has two networks with overlapping subnets, one of
+//them dual-stack, and one without conflicts
+type overlappingNetworks struct{}
+
+func (n overlappingNetworks) HostRanges() []docker.HostRange {
+	return nil
+}
+
+func (n overlappingNetworks) Networks() ([]types.NetworkResource, error) {
+	front := types.NetworkResource{ID: "front", Name: "front"}
+	front.IPAM.Config = []network.IPAMConfig{{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"}, {Subnet: "fd00::/64"}}
+	back := types.NetworkResource{ID: "back", Name: "back"}
+	back.IPAM.Config = []network.IPAMConfig{{Subnet: "172.18.1.0/24"}}
+	bridge := types.NetworkResource{ID: "bridge", Name: "bridge"}
+	bridge.IPAM.Config = []network.IPAMConfig{{Subnet: "172.17.0.0/16"}}
+	return []types.NetworkResource{front, back, bridge}, nil
+}
+
+func (n overlappingNetworks) NetworkInspect(id string) (types.NetworkResource, error) {
+	return types.NetworkResource{}, nil
+}
+
+func TestNetworksWidget_SubnetConflicts(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 160},
+	}
+	w := NewDockerNetworksWidget(overlappingNetworks{}, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+
+	rows := make(map[string]*NetworkRow)
+	for _, row := range w.totalRows {
+		rows[row.network.ID] = row
+	}
+	if !strings.HasPrefix(rows["front"].Name.Text, conflictSymbol) || !strings.HasPrefix(rows["back"].Name.Text, conflictSymbol) {
+		t.Errorf("Networks with overlapping subnets are not flagged: %q, %q", rows["front"].Name.Text, rows["back"].Name.Text)
+	}
+	if strings.HasPrefix(rows["bridge"].Name.Text, conflictSymbol) {
+		t.Errorf("A network without conflicts is flagged: %q", rows["bridge"].Name.Text)
+	}
+	if got := rows["front"].Subnet.Text; got != "172.18.0.0/16 (+1)" {
+		t.Errorf("Unexpected subnet of a dual-stack network: %q", got)
+	}
+	if got := w.SubnetConflicts("back"); len(got) != 1 || got[0].With != "network front" {
+		t.Errorf("Unexpected conflicts of back: %v", got)
+	}
+
+	if w.ToggleIPAM() {
+		t.Error("The subnet columns are shown after hiding them")
+	}
+	if got := len(w.header.Columns); got != 6 {
+		t.Errorf("Header has %d columns after hiding the subnet columns, want 6", got)
+	}
+	for _, row := range w.totalRows {
+		if len(row.Columns) != 6 {
+			t.Errorf("Row of %s has %d columns after hiding the subnet columns, want 6", row.network.Name, len(row.Columns))
+		}
+	}
+	if !w.ToggleIPAM() || len(w.header.Columns) != 8 {
+		t.Error("The subnet columns are not shown again")
+	}
+
+	details := NetworkIPAMDetails(rows["front"].network, w.SubnetConflicts("front"))
+	for _, want := range []string{"172.18.0.0/16", "fd00::/64", "overlaps with network back"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("IPAM details do not mention %q:\n%s", want, details)
+		}
+	}
+}
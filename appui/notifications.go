@@ -0,0 +1,224 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//NotificationSeverity is the severity of a notification
+type NotificationSeverity uint8
+
+//Notification severities
+const (
+	InfoNotification NotificationSeverity = iota
+	SuccessNotification
+	ErrorNotification
+)
+
+//String returns the name of the severity
+func (s NotificationSeverity) String() string {
+	switch s {
+	case SuccessNotification:
+		return "success"
+	case ErrorNotification:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+//maxNotificationHistory is the max number of notifications kept on the history
+const maxNotificationHistory = 100
+
+//DefaultNotificationDuration is how long notifications are shown by default
+const DefaultNotificationDuration = 5 * time.Second
+
+//Notification is a message shown to the user
+type Notification struct {
+	Time     time.Time
+	Severity NotificationSeverity
+	Message  string
+}
+
+//Notifications is a widget that shows notifications, one at a time, on the
+//bottom right corner of the screen. Notifications are queued and shown for a
+//while or until dismissed, the widget keeps a history of all the notifications received.
+type Notifications struct {
+	queue    []Notification
+	history  []Notification
+	timer    *time.Timer
+	shown    int //increased every time a notification is shown
+	duration time.Duration
+	onChange func()
+	sync.Mutex
+}
+
+//NewNotifications creates a Notifications widget that shows each notification for
+//the given duration. The given func is called when the notification on screen changes.
+func NewNotifications(duration time.Duration, onChange func()) *Notifications {
+	return &Notifications{
+		duration: duration,
+		onChange: onChange,
+	}
+}
+
+//Buffer returns the notification on screen as a termui.Buffer
+func (n *Notifications) Buffer() gizaktermui.Buffer {
+	n.Lock()
+	defer n.Unlock()
+	if len(n.queue) == 0 {
+		return gizaktermui.NewBuffer()
+	}
+	current := n.queue[0]
+	screenWidth := ui.ActiveScreen.Dimensions.Width
+	width := len(ui.SupportedTags.ReplaceAllString(current.Message, "")) + 4
+	if max := screenWidth * 2 / 3; width > max {
+		width = max
+	}
+	label := " " + current.Severity.String() + " "
+	if pending := len(n.queue) - 1; pending > 0 {
+		label = fmt.Sprintf(" %s (+%d) ", current.Severity.String(), pending)
+	}
+	if width < len(label)+2 {
+		width = len(label) + 2
+	}
+
+	par := drytermui.NewParFromMarkupText(DryTheme, current.Message)
+	par.Width = width
+	par.Height = 3
+	par.X = screenWidth - width - 1
+	par.Y = ui.ActiveScreen.Dimensions.Height - MainScreenFooterSize - par.Height
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = label
+	par.BorderFg = severityColor(current.Severity)
+	par.BorderLabelFg = severityColor(current.Severity)
+	return par.Buffer()
+}
+
+//Dismiss removes the notification on screen
+func (n *Notifications) Dismiss() {
+	n.Lock()
+	shown := n.shown
+	n.Unlock()
+	n.next(shown)
+}
+
+//History returns the notifications received, from the oldest to the newest
+func (n *Notifications) History() []Notification {
+	n.Lock()
+	defer n.Unlock()
+	history := make([]Notification, len(n.history))
+	copy(history, n.history)
+	return history
+}
+
+//Notify queues a new notification
+func (n *Notifications) Notify(severity NotificationSeverity, message string) {
+	n.Lock()
+	notification := Notification{
+		Time:     time.Now(),
+		Severity: severity,
+		Message:  message,
+	}
+	n.history = append(n.history, notification)
+	if len(n.history) > maxNotificationHistory {
+		n.history = n.history[len(n.history)-maxNotificationHistory:]
+	}
+	n.queue = append(n.queue, notification)
+	first := len(n.queue) == 1
+	if first {
+		n.show()
+	}
+	n.Unlock()
+	if first && n.onChange != nil {
+		n.onChange()
+	}
+}
+
+//Pending returns the number of notifications waiting to be shown, including the one on screen
+func (n *Notifications) Pending() int {
+	n.Lock()
+	defer n.Unlock()
+	return len(n.queue)
+}
+
+//next removes the notification on screen, if it is the given one, and shows the next one
+func (n *Notifications) next(shown int) {
+	n.Lock()
+	if shown != n.shown || len(n.queue) == 0 {
+		n.Unlock()
+		return
+	}
+	if n.timer != nil {
+		n.timer.Stop()
+		n.timer = nil
+	}
+	n.queue = n.queue[1:]
+	if len(n.queue) > 0 {
+		n.show()
+	}
+	n.Unlock()
+	if n.onChange != nil {
+		n.onChange()
+	}
+}
+
+//show starts the timer that removes the first notification of the queue
+func (n *Notifications) show() {
+	n.shown++
+	shown := n.shown
+	n.timer = time.AfterFunc(n.duration, func() {
+		n.next(shown)
+	})
+}
+
+func severityColor(s NotificationSeverity) gizaktermui.Attribute {
+	switch s {
+	case SuccessNotification:
+		return Running
+	case ErrorNotification:
+		return NotRunning
+	default:
+		return gizaktermui.Attribute(DryTheme.Info)
+	}
+}
+
+type notificationsRenderer struct {
+	notifications []Notification
+}
+
+//NewNotificationsRenderer creates a renderer for the given notifications
+func NewNotificationsRenderer(notifications []Notification) ui.Renderer {
+	return &notificationsRenderer{notifications}
+}
+
+//Render the notifications, the most recent first
+func (r *notificationsRenderer) Render() string {
+	buf := new(bytes.Buffer)
+	buf.WriteString("<white>Notifications</>\n\n")
+	if len(r.notifications) == 0 {
+		buf.WriteString("There are no notifications")
+	}
+	for i := len(r.notifications) - 1; i >= 0; i-- {
+		n := r.notifications[i]
+		var severity string
+		switch n.Severity {
+		case SuccessNotification:
+			severity = "<green>success</>"
+		case ErrorNotification:
+			severity = "<red>error  </>"
+		default:
+			severity = "<blue>info   </>"
+		}
+		fmt.Fprintf(buf, "<blue>%s</> %s %s\n", n.Time.Format("15:04:05"), severity, n.Message)
+	}
+	return buf.String()
+}
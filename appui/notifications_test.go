@@ -0,0 +1,87 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotificationsQueue(t *testing.T) {
+	changes := 0
+	n := NewNotifications(time.Hour, func() { changes++ })
+
+	n.Notify(InfoNotification, "first")
+	n.Notify(ErrorNotification, "second")
+	n.Notify(SuccessNotification, "third")
+
+	if n.Pending() != 3 {
+		t.Errorf("Unexpected number of pending notifications, got %d, want 3", n.Pending())
+	}
+	if changes != 1 {
+		t.Errorf("Only the first notification should change the screen, got %d changes", changes)
+	}
+
+	n.Dismiss()
+	if n.Pending() != 2 {
+		t.Errorf("Unexpected number of pending notifications after dismissing, got %d, want 2", n.Pending())
+	}
+	n.Dismiss()
+	n.Dismiss()
+	n.Dismiss()
+	if n.Pending() != 0 {
+		t.Errorf("Unexpected number of pending notifications after dismissing all, got %d, want 0", n.Pending())
+	}
+	if changes != 4 {
+		t.Errorf("Unexpected number of screen changes, got %d, want 4", changes)
+	}
+
+	history := n.History()
+	if len(history) != 3 {
+		t.Fatalf("Unexpected history length, got %d, want 3", len(history))
+	}
+	for i, want := range []string{"first", "second", "third"} {
+		if history[i].Message != want {
+			t.Errorf("Unexpected notification at position %d, got %s, want %s", i, history[i].Message, want)
+		}
+	}
+}
+
+func TestNotificationsExpire(t *testing.T) {
+	changed := make(chan struct{}, 10)
+	n := NewNotifications(10*time.Millisecond, func() { changed <- struct{}{} })
+
+	n.Notify(InfoNotification, "first")
+	n.Notify(InfoNotification, "second")
+
+	timeout := time.After(time.Second)
+	for n.Pending() > 0 {
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("Notifications did not expire, %d pending", n.Pending())
+		}
+	}
+}
+
+func TestNotificationsHistoryIsBounded(t *testing.T) {
+	n := NewNotifications(time.Hour, nil)
+	for i := 0; i < maxNotificationHistory+10; i++ {
+		n.Notify(InfoNotification, "message")
+	}
+	if len(n.History()) != maxNotificationHistory {
+		t.Errorf("Unexpected history length, got %d, want %d", len(n.History()), maxNotificationHistory)
+	}
+}
+
+func TestNotificationsRenderer(t *testing.T) {
+	n := NewNotifications(time.Hour, nil)
+	n.Notify(ErrorNotification, "something failed")
+	n.Notify(SuccessNotification, "something worked")
+
+	rendered := NewNotificationsRenderer(n.History()).Render()
+	for _, want := range []string{"something failed", "something worked", ErrorNotification.String(), SuccessNotification.String()} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Rendered history does not contain %q: %s", want, rendered)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package appui
+
+import (
+	"github.com/docker/docker/api/types"
+	termui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//PluginRow is a Grid row showing information about a Docker engine plugin
+type PluginRow struct {
+	plugin      *types.Plugin
+	Name        *drytermui.ParColumn
+	Tag         *drytermui.ParColumn
+	Enabled     *drytermui.ParColumn
+	Description *drytermui.ParColumn
+	Row
+}
+
+//NewPluginRow creates a new PluginRow widget
+func NewPluginRow(plugin *types.Plugin, table drytermui.Table) *PluginRow {
+	name, tag := docker.PluginName(plugin)
+	enabled := "false"
+	if plugin.Enabled {
+		enabled = "true"
+	}
+	row := &PluginRow{
+		plugin:      plugin,
+		Name:        drytermui.NewThemedParColumn(DryTheme, name),
+		Tag:         drytermui.NewThemedParColumn(DryTheme, tag),
+		Enabled:     drytermui.NewThemedParColumn(DryTheme, enabled),
+		Description: drytermui.NewThemedParColumn(DryTheme, plugin.Config.Description),
+	}
+	row.Height = 1
+	row.Table = table
+	//Columns are rendered following the slice order
+	row.Columns = []termui.GridBufferer{
+		row.Name,
+		row.Tag,
+		row.Enabled,
+		row.Description,
+	}
+	row.ParColumns = []*drytermui.ParColumn{
+		row.Name,
+		row.Tag,
+		row.Enabled,
+		row.Description,
+	}
+
+	return row
+}
+
+//ColumnsForFilter returns the columns that are used to filter
+func (row *PluginRow) ColumnsForFilter() []*drytermui.ParColumn {
+	return []*drytermui.ParColumn{row.Name, row.Tag, row.Description}
+}
@@ -0,0 +1,270 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//DockerPluginsWidget shows the engine plugins installed on the Docker host
+type DockerPluginsWidget struct {
+	dockerDaemon  docker.PluginAPI
+	header        *termui.TableHeader
+	filteredRows  []*PluginRow
+	totalRows     []*PluginRow
+	filterPattern string
+	//why plugins cannot be listed, if they cannot
+	notSupported         string
+	height, width        int
+	selectedIndex        int
+	startIndex, endIndex int
+	x, y                 int
+	mounted              bool
+	loader               *AsyncLoader
+	sync.RWMutex
+}
+
+//NewDockerPluginsWidget creates a widget to show Docker engine plugins, the
+//list starts filtered as the given options tell, plugins are not sorted
+func NewDockerPluginsWidget(dockerDaemon docker.PluginAPI, y int, opts ListOptions) *DockerPluginsWidget {
+	w := DockerPluginsWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		header:       pluginTableHeader(),
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width}
+	w.filterPattern = opts.Filter
+	w.loader = NewAsyncLoader(&w)
+
+	RegisterWidget(docker.PluginSource, &w)
+	return &w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *DockerPluginsWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	y := s.y
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	var filter string
+	if s.filterPattern != "" {
+		filter = fmt.Sprintf(
+			"<b><blue> | Active filter: </><yellow>%s</></> ", FilterText(s.filterPattern))
+	}
+
+	widgetHeader := WidgetHeader("Plugins", s.RowCount(), filter+s.loader.HeaderDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if s.notSupported != "" {
+		par := termui.NewParFromMarkupText(DryTheme, "<yellow>"+s.notSupported+"</>")
+		par.Border = false
+		par.X = s.x
+		par.Y = y
+		par.Width = s.width
+		par.Height = 1
+		par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+		buf.Merge(par.Buffer())
+		return buf
+	}
+
+	s.header.SetY(y)
+	buf.Merge(s.header.Buffer())
+	y += s.header.GetHeight()
+
+	selected := s.selectedIndex - s.startIndex
+
+	for i, row := range s.visibleRows() {
+		row.SetY(y)
+		y += row.GetHeight()
+		if i != selected {
+			row.NotHighlighted()
+		} else {
+			row.Highlighted()
+		}
+		buf.Merge(row.Buffer())
+	}
+	return buf
+}
+
+//Filter filters the plugin list by the given filter
+func (s *DockerPluginsWidget) Filter(filter string) {
+	s.Lock()
+	defer s.Unlock()
+	s.filterPattern = filter
+}
+
+//Mount tells this widget to be ready for rendering, plugins are loaded in the background
+func (s *DockerPluginsWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			plugins, err := s.dockerDaemon.Plugins()
+			if featureErr, ok := err.(docker.FeatureError); ok || err == docker.ErrPluginsNotSupported {
+				reason := "Plugins are not supported by this daemon."
+				if ok {
+					reason = featureErr.Error() + "."
+				}
+				return func() {
+					s.notSupported = reason
+					s.totalRows = nil
+				}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]*PluginRow, len(plugins))
+			for i, plugin := range plugins {
+				rows[i] = NewPluginRow(plugin, s.header)
+			}
+			return func() {
+				s.notSupported = ""
+				s.totalRows = rows
+				s.align()
+			}, nil
+		})
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *DockerPluginsWidget) Name() string {
+	return "DockerPluginsWidget"
+}
+
+//NotSupported returns true if the Docker daemon does not support plugins
+func (s *DockerPluginsWidget) NotSupported() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.notSupported != ""
+}
+
+//OnEvent runs the given command on the name of the selected plugin
+func (s *DockerPluginsWidget) OnEvent(event EventCommand) error {
+	if s.RowCount() > 0 {
+		return event(s.filteredRows[s.selectedIndex].plugin.Name)
+	}
+	return nil
+}
+
+//RowCount returns the number of rows of this widget.
+func (s *DockerPluginsWidget) RowCount() int {
+	return len(s.filteredRows)
+}
+
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *DockerPluginsWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by, plugins
+//are always sorted by name
+func (s *DockerPluginsWidget) SortedBy() string {
+	return "NAME"
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *DockerPluginsWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *DockerPluginsWidget) align() {
+	x := s.x
+	width := s.width
+
+	s.header.SetWidth(width)
+	s.header.SetX(x)
+
+	for _, row := range s.totalRows {
+		row.SetX(x)
+		row.SetWidth(width)
+	}
+}
+
+func (s *DockerPluginsWidget) filterRows() {
+	if s.filterPattern != "" {
+		var rows []*PluginRow
+
+		for _, row := range s.totalRows {
+			if RowFilters.ByPattern(s.filterPattern)(row) {
+				rows = append(rows, row)
+			}
+		}
+		s.filteredRows = rows
+	} else {
+		s.filteredRows = s.totalRows
+	}
+}
+
+func (s *DockerPluginsWidget) calculateVisibleRows() {
+	count := s.RowCount()
+	height := s.height - s.header.GetHeight()
+	selected := s.selectedIndex
+
+	switch {
+	case height <= 0 || count == 0:
+		s.startIndex, s.endIndex = 0, 0
+	case count <= height:
+		s.startIndex, s.endIndex = 0, count
+	case selected < s.startIndex:
+		s.startIndex, s.endIndex = selected, selected+height
+	case selected >= s.startIndex+height:
+		s.startIndex, s.endIndex = selected-height+1, selected+1
+	default:
+		s.endIndex = s.startIndex + height
+		if s.endIndex > count {
+			s.startIndex, s.endIndex = count-height, count
+		}
+	}
+}
+
+//prepareForRendering sets the internal state of this widget so it is ready for
+//rendering (i.e. Buffer()).
+func (s *DockerPluginsWidget) prepareForRendering() {
+	s.filterRows()
+	index := ui.ActiveScreen.Cursor.Position()
+	if index < 0 {
+		index = 0
+	} else if index >= s.RowCount() {
+		index = s.RowCount() - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+	s.calculateVisibleRows()
+}
+
+func (s *DockerPluginsWidget) visibleRows() []*PluginRow {
+	return s.filteredRows[s.startIndex:s.endIndex]
+}
+
+func pluginTableHeader() *termui.TableHeader {
+	header := termui.NewHeader(DryTheme)
+	header.ColumnSpacing = DefaultColumnSpacing
+	header.AddColumn("NAME")
+	header.AddFixedWidthColumn("TAG", 12)
+	header.AddFixedWidthColumn("ENABLED", 8)
+	header.AddColumn("DESCRIPTION")
+	return header
+}
@@ -0,0 +1,63 @@
+package appui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+)
+
+type pluginsDaemonMock struct {
+	mocks.DockerDaemonMock
+	plugins []*types.Plugin
+	err     error
+}
+
+func (d *pluginsDaemonMock) Plugins() ([]*types.Plugin, error) {
+	return d.plugins, d.err
+}
+
+func TestDockerPluginsWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Dimensions: &ui.Dimensions{Height: 15, Width: 100},
+		Cursor:     ui.NewCursor()}
+	plugins := []*types.Plugin{
+		{Name: "rexray/ebs:0.11.1", Enabled: true, Config: types.PluginConfig{Description: "REX-Ray for Amazon EBS"}},
+		{Name: "vieux/sshfs:latest", Config: types.PluginConfig{Description: "sshFS plugin for Docker"}},
+	}
+	tests := []struct {
+		name             string
+		daemon           *pluginsDaemonMock
+		filter           string
+		wantRows         int
+		wantNotSupported bool
+		wantErr          bool
+	}{
+		{"plugins", &pluginsDaemonMock{plugins: plugins}, "", 2, false, false},
+		{"filtered plugins", &pluginsDaemonMock{plugins: plugins}, "sshfs", 1, false, false},
+		{"old daemon", &pluginsDaemonMock{err: docker.ErrPluginsNotSupported}, "", 0, true, false},
+		{"old API version", &pluginsDaemonMock{err: docker.FeatureError{Feature: docker.PluginsFeature, APIVersion: "1.24"}}, "", 0, true, false},
+		{"daemon error", &pluginsDaemonMock{err: errors.New("boom")}, "", 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewDockerPluginsWidget(tt.daemon, 0, ListOptions{})
+			w.Filter(tt.filter)
+			w.Mount()
+			w.loader.Wait()
+			if err := w.Mount(); (err != nil) != tt.wantErr {
+				t.Errorf("Mount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			w.prepareForRendering()
+			if w.RowCount() != tt.wantRows {
+				t.Errorf("RowCount() = %d, want %d", w.RowCount(), tt.wantRows)
+			}
+			if w.NotSupported() != tt.wantNotSupported {
+				t.Errorf("NotSupported() = %v, want %v", w.NotSupported(), tt.wantNotSupported)
+			}
+		})
+	}
+}
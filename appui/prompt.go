@@ -13,8 +13,13 @@ type Prompt struct {
 
 //NewPrompt creates a new Prompt with the given title
 func NewPrompt(title string) *Prompt {
+	return NewPromptWithText(title, "")
+}
+
+//NewPromptWithText creates a new Prompt with the given title and initial text
+func NewPromptWithText(title string, text string) *Prompt {
 	w := &Prompt{
-		TextInput: *termui.NewTextInput(""),
+		TextInput: *termui.NewTextInput(text),
 	}
 	w.Height = 3
 	w.Width = len(title) + 4
@@ -22,10 +27,18 @@ func NewPrompt(title string) *Prompt {
 	w.Y = ui.ActiveScreen.Dimensions.Height / 2
 	w.Bg = gtermui.Attribute(DryTheme.Bg)
 	w.TextBgColor = gtermui.Attribute(DryTheme.Bg)
-	w.TextFgColor = gtermui.ColorWhite
+	w.TextFgColor = gtermui.Attribute(DryTheme.Fg)
 	w.BorderLabel = title
-	w.BorderLabelFg = gtermui.ColorWhite
+	w.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
+
+	return w
+}
 
+//NewSecretPrompt creates a new Prompt with the given title that does not
+//show the text typed, i.e. to ask for a password
+func NewSecretPrompt(title string) *Prompt {
+	w := NewPrompt(title)
+	w.Mask = '*'
 	return w
 }
 
@@ -0,0 +1,282 @@
+package appui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/docker/docker/api/types/registry"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+var searchTitles = []string{"NAME", "STARS", "OFFICIAL", "AUTOMATED", "DESCRIPTION"}
+
+//RegistrySearchWidget shows the images of a registry that match a term, as
+//docker search does
+type RegistrySearchWidget struct {
+	dockerDaemon  docker.ImageAPI
+	term          string
+	results       []registry.SearchResult
+	lines         []string
+	selectedIndex int
+	startIndex    int
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *AsyncLoader
+	sync.RWMutex
+}
+
+//NewRegistrySearchWidget creates a RegistrySearchWidget
+func NewRegistrySearchWidget(dockerDaemon docker.ImageAPI, y int) *RegistrySearchWidget {
+	w := &RegistrySearchWidget{
+		dockerDaemon: dockerDaemon,
+		y:            y,
+		height:       MainScreenAvailableHeight(),
+		width:        ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *RegistrySearchWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	s.prepareForRendering()
+	y := s.y
+
+	widgetHeader := WidgetHeader("Registry search", len(s.results), s.headerDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	if len(s.lines) > 0 {
+		buf.Merge(s.line("<blue>"+s.lines[0]+"</>", y, false).Buffer())
+		y++
+	}
+	for i, line := range s.visibleLines() {
+		buf.Merge(s.line(line, y, i+s.startIndex == s.selectedIndex).Buffer())
+		y++
+	}
+	return buf
+}
+
+//Mount tells this widget to be ready for rendering, the registry is
+//searched if there is a term to search
+func (s *RegistrySearchWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Reset()
+		if s.term != "" {
+			s.loader.Load(s.search)
+		}
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *RegistrySearchWidget) Name() string {
+	return "RegistrySearchWidget"
+}
+
+//RowCount returns the number of results shown
+func (s *RegistrySearchWidget) RowCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.results)
+}
+
+//Search searches the registry for the given term, a term that starts with
+//the host of a registry is searched on it
+func (s *RegistrySearchWidget) Search(term string) {
+	s.Lock()
+	defer s.Unlock()
+	s.term = term
+	s.results = nil
+	s.lines = nil
+	s.startIndex = 0
+	s.mounted = false
+	s.loader.Cancel()
+}
+
+//Selected returns the name of the image on the cursor, false if there are
+//no results
+func (s *RegistrySearchWidget) Selected() (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.selectedIndex >= len(s.results) {
+		return "", false
+	}
+	name := s.results[s.selectedIndex].Name
+	//results of other registries than Docker Hub are pulled from them
+	if registry := docker.SearchedRegistry(s.term); registry != docker.DockerHub && docker.SearchedRegistry(name) == docker.DockerHub {
+		name = registry + "/" + name
+	}
+	return name, true
+}
+
+//Term returns the term searched, empty if none has been searched yet
+func (s *RegistrySearchWidget) Term() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.term
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *RegistrySearchWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+func (s *RegistrySearchWidget) search(ctx context.Context) (func(), error) {
+	results, err := s.dockerDaemon.SearchImages(ctx, s.term)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		s.results = results
+		s.buildLines()
+	}, nil
+}
+
+func (s *RegistrySearchWidget) headerDetails() string {
+	if details := s.loader.HeaderDetails(); details != "" {
+		return details
+	}
+	if err := s.loader.Err(); err != nil {
+		return fmt.Sprintf("<b><blue> | </><red>%s</></> ", err.Error())
+	}
+	if s.term == "" {
+		return "<b><blue> | </><yellow>Nothing searched yet</></> "
+	}
+	return fmt.Sprintf("<b><blue> | Registry: </><yellow>%s</><blue> | Term: </><yellow>%s</></> ",
+		docker.SearchedRegistry(s.term), s.term)
+}
+
+//buildLines builds the lines of the widget from the results, the first
+//line has the column titles
+func (s *RegistrySearchWidget) buildLines() {
+	s.lines = nil
+	rows := make([][]string, 0, len(s.results))
+	for _, result := range s.results {
+		rows = append(rows, searchRow(result))
+	}
+	widths := make([]int, len(searchTitles))
+	for i, title := range searchTitles {
+		widths[i] = utf8.RuneCountInString(title)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if n := utf8.RuneCountInString(value); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	line := func(values []string) string {
+		cells := make([]string, len(values))
+		for i, value := range values {
+			switch i {
+			case len(values) - 1:
+				//the last column, the description, is not padded
+			case 1:
+				value = padLeft(value, widths[i])
+			default:
+				value = padRight(value, widths[i])
+			}
+			cells[i] = value
+		}
+		return strings.Join(cells, "  ")
+	}
+	s.lines = append(s.lines, line(searchTitles))
+	for _, row := range rows {
+		s.lines = append(s.lines, line(row))
+	}
+}
+
+//searchRow returns the values of the columns of the given result
+func searchRow(result registry.SearchResult) []string {
+	flag := func(set bool) string {
+		if set {
+			return "[OK]"
+		}
+		return ""
+	}
+	return []string{
+		result.Name,
+		strconv.Itoa(result.StarCount),
+		flag(result.IsOfficial),
+		flag(result.IsAutomated),
+		strings.Join(strings.Fields(result.Description), " "),
+	}
+}
+
+func (s *RegistrySearchWidget) line(text string, y int, selected bool) *termui.MarkupPar {
+	par := termui.NewParFromMarkupText(DryTheme, text)
+	par.Border = false
+	par.Height = 1
+	par.Width = s.width
+	par.X = s.x
+	par.Y = y
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	if selected {
+		par.Bg = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextBgColor = gizaktermui.Attribute(DryTheme.CursorLineBg)
+		par.TextFgColor = gizaktermui.Attribute(DryTheme.CursorLineFg)
+	}
+	return par
+}
+
+func (s *RegistrySearchWidget) prepareForRendering() {
+	if width := ui.ActiveScreen.Dimensions.Width; width != s.width {
+		s.width = width
+	}
+	index := ui.ActiveScreen.Cursor.Position()
+	if index >= len(s.results) {
+		index = len(s.results) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	s.selectedIndex = index
+}
+
+//visibleLines returns the lines of the results that fit on the widget
+func (s *RegistrySearchWidget) visibleLines() []string {
+	//the widget header and the column titles take a line each
+	height := s.height - 2
+	if height <= 0 || len(s.lines) < 2 {
+		return nil
+	}
+	results := s.lines[1:]
+	if s.selectedIndex < s.startIndex {
+		s.startIndex = s.selectedIndex
+	} else if s.selectedIndex >= s.startIndex+height {
+		s.startIndex = s.selectedIndex - height + 1
+	}
+	if s.startIndex > len(results)-1 {
+		s.startIndex = 0
+	}
+	end := s.startIndex + height
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[s.startIndex:end]
+}
@@ -0,0 +1,70 @@
+package appui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/moncho/dry/ui"
+)
+
+//searchableImageAPI finds two images on any registry
+type searchableImageAPI struct {
+	noopImageAPI
+}
+
+func (i searchableImageAPI) SearchImages(ctx context.Context, term string) ([]registry.SearchResult, error) {
+	return []registry.SearchResult{
+		{Name: "nginx", StarCount: 15000, IsOfficial: true, Description: "Official build\nof Nginx."},
+		{Name: "bitnami/nginx", StarCount: 120, IsAutomated: true},
+	}, nil
+}
+
+func TestRegistrySearchWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 40, Width: 120},
+	}
+	w := NewRegistrySearchWidget(searchableImageAPI{}, 0)
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	if w.RowCount() != 0 {
+		t.Fatalf("Nothing should be searched without a term, got %d results", w.RowCount())
+	}
+	if _, ok := w.Selected(); ok {
+		t.Error("There is no result to select")
+	}
+
+	w.Search("nginx")
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	defer w.Unmount()
+	if w.RowCount() != 2 {
+		t.Fatalf("Unexpected number of results: %d", w.RowCount())
+	}
+	if got := strings.Join(strings.Fields(w.lines[0]), " "); got != "NAME STARS OFFICIAL AUTOMATED DESCRIPTION" {
+		t.Errorf("Unexpected titles line: %q", w.lines[0])
+	}
+	if got := strings.Join(strings.Fields(w.lines[1]), " "); got != "nginx 15000 [OK] Official build of Nginx." {
+		t.Errorf("Unexpected result line: %q", w.lines[1])
+	}
+
+	ui.ActiveScreen.Cursor.Max(1)
+	ui.ActiveScreen.Cursor.ScrollTo(1)
+	w.prepareForRendering()
+	if name, ok := w.Selected(); !ok || name != "bitnami/nginx" {
+		t.Errorf("Unexpected selected image: %s", name)
+	}
+
+	w.Search("registry.example.com/nginx")
+	w.Mount()
+	w.loader.Wait()
+	w.prepareForRendering()
+	if name, _ := w.Selected(); name != "registry.example.com/bitnami/nginx" {
+		t.Errorf("Images found on a registry should be pulled from it, got %s", name)
+	}
+}
@@ -16,10 +16,17 @@ type Row struct {
 //Highlighted marks this rows as being highlighted
 func (row *Row) Highlighted() {
 	row.changeTextColor(
-		termui.Attribute(DryTheme.Fg),
+		termui.Attribute(DryTheme.CursorLineFg),
 		termui.Attribute(DryTheme.CursorLineBg))
 }
 
+//Marked shows this row as being marked
+func (row *Row) Marked() {
+	row.changeTextColor(
+		termui.Attribute(DryTheme.Selected),
+		termui.Attribute(DryTheme.Bg))
+}
+
 //NotHighlighted marks this rows as being not highlighted
 func (row *Row) NotHighlighted() {
 
@@ -1,11 +1,16 @@
 package appui
 
 import (
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/moncho/dry/ui/termui"
 )
 
+//maxCachedFilterPatterns is how many compiled filter patterns are kept
+const maxCachedFilterPatterns = 32
+
 //FilterableRow is the interface for filterable columns
 type FilterableRow interface {
 	ColumnsForFilter() []*termui.ParColumn
@@ -17,15 +22,65 @@ type RowFilter func(FilterableRow) bool
 //RowFilters holds the existing RowFilter
 var RowFilters RowFilter
 
-//ByPattern filters row by the given pattern
+//compiledPattern is a filter pattern compiled as a regular expression, err
+//is why it could not be compiled
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+//filterPatterns caches the compiled filter patterns, every row of a list is
+//matched against the same pattern
+var filterPatterns = struct {
+	compiled map[string]compiledPattern
+	sync.Mutex
+}{compiled: make(map[string]compiledPattern)}
+
+//ByPattern filters row by the given pattern, see MatchesPattern
 func (rf RowFilter) ByPattern(pattern string) RowFilter {
 	return func(row FilterableRow) bool {
 		columns := row.ColumnsForFilter()
 		for _, column := range columns {
-			if strings.Contains(column.Text, pattern) {
+			if MatchesPattern(column.Text, pattern) {
 				return true
 			}
 		}
 		return false
 	}
 }
+
+//FilterRegexp returns the given filter pattern compiled as a regular
+//expression, and the error if it is not a valid one
+func FilterRegexp(pattern string) (*regexp.Regexp, error) {
+	filterPatterns.Lock()
+	defer filterPatterns.Unlock()
+	if c, ok := filterPatterns.compiled[pattern]; ok {
+		return c.re, c.err
+	}
+	if len(filterPatterns.compiled) >= maxCachedFilterPatterns {
+		filterPatterns.compiled = make(map[string]compiledPattern)
+	}
+	re, err := regexp.Compile(pattern)
+	filterPatterns.compiled[pattern] = compiledPattern{re, err}
+	return re, err
+}
+
+//MatchesPattern returns true if the given text matches the given filter
+//pattern, a regular expression or, if it is not a valid one, the text to
+//look for
+func MatchesPattern(text, pattern string) bool {
+	re, err := FilterRegexp(pattern)
+	if err != nil {
+		return strings.Contains(text, pattern)
+	}
+	return re.MatchString(text)
+}
+
+//FilterText returns the given filter pattern as shown on the header of a
+//list, flagged if it is not a valid regular expression
+func FilterText(pattern string) string {
+	if _, err := FilterRegexp(pattern); err != nil {
+		return pattern + " <red>(invalid regex, matched as text)</>"
+	}
+	return pattern
+}
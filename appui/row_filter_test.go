@@ -1,27 +1,68 @@
 package appui
 
 import (
-	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/moncho/dry/ui/termui"
 )
 
-func TestRowFilter_ByPattern(t *testing.T) {
-	type args struct {
-		pattern string
+//filterRow is a row with the given columns
+type filterRow []string
+
+func (r filterRow) ColumnsForFilter() []*termui.ParColumn {
+	var columns []*termui.ParColumn
+	for _, text := range r {
+		column := &termui.ParColumn{}
+		column.Text = text
+		columns = append(columns, column)
 	}
+	return columns
+}
+
+func TestRowFilter_ByPattern(t *testing.T) {
+	row := filterRow{"nginx:1.19", "web-frontend", "Up 2 hours"}
 	tests := []struct {
-		name string
-		rf   RowFilter
-		args args
-		want RowFilter
+		pattern string
+		want    bool
 	}{
-	// TODO: Add test cases.
+		{"nginx", true},
+		{"redis", false},
+		{"^web-", true},
+		{"^frontend", false},
+		{"nginx:1\\.(18|19)$", true},
+		{"(?i)UP", true},
+		//not valid regular expressions, matched as text
+		{"web-(", false},
+		{"1.19)", false},
+		{"Up 2 hours", true},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.rf.ByPattern(tt.args.pattern); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("RowFilter.ByPattern() = %v, want %v", got, tt.want)
-			}
-		})
+		if got := RowFilters.ByPattern(tt.pattern)(row); got != tt.want {
+			t.Errorf("ByPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+	if !RowFilters.ByPattern("c++")(filterRow{"g++ and c++"}) {
+		t.Error("An invalid regular expression must be matched as text")
+	}
+}
+
+func TestFilterText(t *testing.T) {
+	if got := FilterText("^web-"); got != "^web-" {
+		t.Errorf("FilterText of a valid regex = %q, want it as is", got)
+	}
+	if got := FilterText("web-("); !strings.HasPrefix(got, "web-(") || !strings.Contains(got, "invalid regex") {
+		t.Errorf("FilterText of an invalid regex = %q, want it flagged", got)
+	}
+}
+
+func TestFilterRegexpCache(t *testing.T) {
+	for i := 0; i < 2*maxCachedFilterPatterns; i++ {
+		FilterRegexp(strings.Repeat("a", i+1))
+	}
+	filterPatterns.Lock()
+	defer filterPatterns.Unlock()
+	if len(filterPatterns.compiled) > maxCachedFilterPatterns {
+		t.Errorf("The cache of filter patterns has %d patterns, want at most %d", len(filterPatterns.compiled), maxCachedFilterPatterns)
 	}
 }
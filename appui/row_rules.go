@@ -0,0 +1,288 @@
+package appui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+//rowRuleSeparator separates the condition of a rule from its style
+const rowRuleSeparator = "=>"
+
+//RowRule styles the rows of the containers that match its condition. A
+//condition is one or more comparisons joined by "and", each comparing
+//name, image, status, exit_code or label.<name> with a value using ==, !=,
+//~ (matches the regular expression), !~ or, for exit_code, <, <=, > and >=.
+//A style is a color, a name or a number of the 256-color palette, and the
+//bold and underline attributes.
+type RowRule struct {
+	If    string `json:"if"`
+	Style string `json:"style"`
+}
+
+//String returns the rule using the format expected by ParseRowRule
+func (r RowRule) String() string {
+	return r.If + " " + rowRuleSeparator + " " + r.Style
+}
+
+//ParseRowRule parses the given text, a condition and a style separated by
+//"=>", into a rule. The rule is compiled to tell if it is valid.
+func ParseRowRule(s string) (RowRule, error) {
+	parts := strings.SplitN(s, rowRuleSeparator, 2)
+	if len(parts) != 2 {
+		return RowRule{}, fmt.Errorf("a rule is a condition and a style separated by %s", rowRuleSeparator)
+	}
+	rule := RowRule{If: strings.TrimSpace(parts[0]), Style: strings.TrimSpace(parts[1])}
+	if _, err := compileRowRule(rule); err != nil {
+		return RowRule{}, err
+	}
+	return rule, nil
+}
+
+//rowRules are the rules in use, compiled
+var rowRules []compiledRowRule
+var rowRulesLock sync.RWMutex
+
+//SetRowRules sets the rules used to style container rows, later rules
+//take precedence over earlier ones. Invalid rules are left out, an error
+//is returned for each one of them.
+func SetRowRules(rules []RowRule) []error {
+	var compiled []compiledRowRule
+	var errs []error
+	for i, rule := range rules {
+		c, err := compileRowRule(rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %d (%s): %s", i+1, rule, err.Error()))
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+	rowRulesLock.Lock()
+	defer rowRulesLock.Unlock()
+	rowRules = compiled
+	return errs
+}
+
+//rowRuleColors are the names of the basic colors, other colors can be
+//given by their number or their ui name
+var rowRuleColors = map[string]ui.Color{
+	"black":   ui.ColorBlack,
+	"red":     ui.ColorRed,
+	"green":   ui.ColorLime,
+	"yellow":  ui.ColorYellow,
+	"blue":    ui.ColorBlue,
+	"magenta": ui.ColorFuchsia,
+	"cyan":    ui.ColorAqua,
+	"white":   ui.ColorWhite,
+	"grey":    ui.ColorGray,
+	"gray":    ui.ColorGray,
+}
+
+type compiledRowRule struct {
+	comparisons []rowComparison
+	hasColor    bool
+	color       termui.Attribute
+	attributes  termui.Attribute
+}
+
+//rowComparison compares a field of a container with a value
+type rowComparison struct {
+	field  string
+	label  string
+	op     string
+	value  string
+	re     *regexp.Regexp
+	number int
+}
+
+func compileRowRule(rule RowRule) (compiledRowRule, error) {
+	var c compiledRowRule
+	if strings.TrimSpace(rule.If) == "" {
+		return c, fmt.Errorf("the condition is empty")
+	}
+	for _, term := range strings.Split(rule.If, " and ") {
+		comparison, err := parseRowComparison(strings.TrimSpace(term))
+		if err != nil {
+			return c, err
+		}
+		c.comparisons = append(c.comparisons, comparison)
+	}
+	style := strings.Fields(strings.Replace(rule.Style, ",", " ", -1))
+	if len(style) == 0 {
+		return c, fmt.Errorf("the style is empty")
+	}
+	for _, s := range style {
+		s = strings.ToLower(s)
+		switch s {
+		case "bold":
+			c.attributes |= termui.Attribute(ui.AttrBold)
+			continue
+		case "underline":
+			c.attributes |= termui.Attribute(ui.AttrUnderline)
+			continue
+		}
+		color, ok := rowRuleColors[s]
+		if !ok {
+			color = ui.ColorFromName(s)
+			ok = color != 0
+		}
+		if !ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 0 || n > 255 {
+				return c, fmt.Errorf("unknown style %q, use a color or bold and underline", s)
+			}
+			color = ui.Color(n)
+		}
+		c.hasColor = true
+		c.color = termui.Attribute(color)
+	}
+	return c, nil
+}
+
+func parseRowComparison(term string) (rowComparison, error) {
+	fields := strings.SplitN(term, " ", 3)
+	if len(fields) != 3 {
+		return rowComparison{}, fmt.Errorf("%q is not a comparison, i.e. status == exited", term)
+	}
+	c := rowComparison{field: fields[0], op: fields[1], value: strings.TrimSpace(fields[2])}
+	if strings.HasPrefix(c.value, `"`) {
+		value, err := strconv.Unquote(c.value)
+		if err != nil {
+			return c, fmt.Errorf("invalid quoted value %s", c.value)
+		}
+		c.value = value
+	}
+	if strings.HasPrefix(c.field, "label.") {
+		c.label = strings.TrimPrefix(c.field, "label.")
+		c.field = "label"
+	}
+	switch c.field {
+	case "name", "image", "status", "label", "exit_code":
+	default:
+		return c, fmt.Errorf("unknown field %q, use name, image, status, exit_code or label.<name>", c.field)
+	}
+	switch c.op {
+	case "==", "!=":
+	case "~", "!~":
+		re, err := regexp.Compile(c.value)
+		if err != nil {
+			return c, fmt.Errorf("invalid regular expression %q: %s", c.value, err.Error())
+		}
+		c.re = re
+	case "<", "<=", ">", ">=":
+		if c.field != "exit_code" {
+			return c, fmt.Errorf("%s only compares exit_code", c.op)
+		}
+	default:
+		return c, fmt.Errorf("unknown operator %q", c.op)
+	}
+	if c.field == "exit_code" {
+		n, err := strconv.Atoi(c.value)
+		if err != nil {
+			return c, fmt.Errorf("exit_code is compared with a number, not %q", c.value)
+		}
+		c.number = n
+	}
+	return c, nil
+}
+
+func (r compiledRowRule) matches(c *docker.Container) bool {
+	for _, comparison := range r.comparisons {
+		if !comparison.matches(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cmp rowComparison) matches(c *docker.Container) bool {
+	if cmp.field == "exit_code" {
+		code := exitCode(c)
+		switch cmp.op {
+		case "==":
+			return code == cmp.number
+		case "!=":
+			return code != cmp.number
+		case "<":
+			return code < cmp.number
+		case "<=":
+			return code <= cmp.number
+		case ">":
+			return code > cmp.number
+		case ">=":
+			return code >= cmp.number
+		}
+		return false
+	}
+	var values []string
+	switch cmp.field {
+	case "name":
+		for _, name := range c.Names {
+			values = append(values, strings.TrimPrefix(name, "/"))
+		}
+	case "image":
+		values = []string{c.Image}
+	case "status":
+		values = []string{c.State}
+	case "label":
+		values = []string{c.Labels[cmp.label]}
+	}
+	//a name matches if any of the names of the container does
+	for _, value := range values {
+		var match bool
+		switch cmp.op {
+		case "==":
+			match = value == cmp.value
+		case "!=":
+			match = value != cmp.value
+		case "~":
+			match = cmp.re.MatchString(value)
+		case "!~":
+			match = !cmp.re.MatchString(value)
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+//exitedStatus finds the exit code on the status of a container, as given by the container list
+var exitedStatus = regexp.MustCompile(`^Exited \((-?\d+)\)`)
+
+//exitCode returns the exit code of the given container, 0 for containers
+//that have not exited
+func exitCode(c *docker.Container) int {
+	if c.ContainerJSONBase != nil && c.ContainerJSON.State != nil {
+		return c.ContainerJSON.State.ExitCode
+	}
+	if m := exitedStatus.FindStringSubmatch(c.Status); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return code
+	}
+	return 0
+}
+
+//styleByRowRules returns the given foreground styled by the rules the
+//given container matches
+func styleByRowRules(c *docker.Container, fg termui.Attribute) termui.Attribute {
+	rowRulesLock.RLock()
+	defer rowRulesLock.RUnlock()
+	var attributes termui.Attribute
+	for _, rule := range rowRules {
+		if !rule.matches(c) {
+			continue
+		}
+		if rule.hasColor {
+			fg = rule.color
+		}
+		attributes |= rule.attributes
+	}
+	return fg | attributes
+}
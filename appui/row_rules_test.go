@@ -0,0 +1,74 @@
+package appui
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+func TestParseRowRule(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    RowRule
+		wantErr bool
+	}{
+		{"status == exited => red", RowRule{If: "status == exited", Style: "red"}, false},
+		{`name == "my app" and exit_code >= 2 => 161, bold`, RowRule{If: `name == "my app" and exit_code >= 2`, Style: "161, bold"}, false},
+		{"status == exited", RowRule{}, true},
+		{"state == exited => red", RowRule{}, true},
+		{"image <> nginx => red", RowRule{}, true},
+		{"image > nginx => red", RowRule{}, true},
+		{"exit_code == one => red", RowRule{}, true},
+		{"image ~ ( => red", RowRule{}, true},
+		{"image == nginx => sparkly", RowRule{}, true},
+		{"image == nginx => ", RowRule{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseRowRule(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRowRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRowRule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyleByRowRules(t *testing.T) {
+	defer SetRowRules(nil)
+	errs := SetRowRules([]RowRule{
+		{If: "label.com.docker.compose.project == prod", Style: "magenta"},
+		{If: "exit_code != 0", Style: "red"},
+		{If: "image ~ :latest$", Style: "underline"},
+		{If: "colour == red", Style: "red"},
+	})
+	if len(errs) != 1 {
+		t.Errorf("Invalid rules were not reported once each: %v", errs)
+	}
+	prod := map[string]string{"com.docker.compose.project": "prod"}
+	fg := termui.Attribute(ui.ColorWhite)
+	tests := []struct {
+		name      string
+		container types.Container
+		want      termui.Attribute
+	}{
+		{"no rule matches", types.Container{Image: "nginx", Status: "Up 2 hours"}, fg},
+		{"prod project", types.Container{Image: "nginx", Labels: prod}, termui.Attribute(ui.ColorFuchsia)},
+		{"exited with an error", types.Container{Image: "nginx", Status: "Exited (137) 2 hours ago"}, termui.Attribute(ui.ColorRed)},
+		{"later colors win", types.Container{Image: "nginx", Labels: prod, Status: "Exited (1) 2 hours ago"}, termui.Attribute(ui.ColorRed)},
+		{"attributes add up", types.Container{Image: "nginx:latest", Labels: prod}, termui.Attribute(ui.ColorFuchsia) | termui.Attribute(ui.AttrUnderline)},
+		{"exited without errors", types.Container{Image: "nginx", Status: "Exited (0) 2 hours ago"}, fg},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := styleByRowRules(&docker.Container{Container: tt.container}, fg); got != tt.want {
+				t.Errorf("styleByRowRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package appui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+)
+
+//RowSearch keeps the pattern searched on a list and moves the cursor to the
+//rows that match it, a row matches if any of its columns does
+type RowSearch struct {
+	pattern string
+}
+
+//Search moves the cursor to the first of the given number of rows, starting
+//from the selected one, that matches the given pattern. Returns false if no
+//row matches.
+func (s *RowSearch) Search(pattern string, count int, row func(int) FilterableRow) bool {
+	s.pattern = pattern
+	return s.jump(count, row, ui.ActiveScreen.Cursor.Position(), true)
+}
+
+//Next moves the cursor to the next row that matches the last pattern searched
+func (s *RowSearch) Next(count int, row func(int) FilterableRow) bool {
+	return s.jump(count, row, ui.ActiveScreen.Cursor.Position()+1, true)
+}
+
+//Previous moves the cursor to the previous row that matches the last pattern searched
+func (s *RowSearch) Previous(count int, row func(int) FilterableRow) bool {
+	return s.jump(count, row, ui.ActiveScreen.Cursor.Position()-1, false)
+}
+
+//Highlight highlights the matches of the last pattern searched on the given
+//row, the row must be already rendered on the given buffer
+func (s *RowSearch) Highlight(buf gizaktermui.Buffer, row FilterableRow) {
+	highlightMatches(buf, row, s.pattern)
+}
+
+//jump moves the cursor to the row that matches the pattern searched,
+//searching from the given index in the given direction
+func (s *RowSearch) jump(count int, row func(int) FilterableRow, from int, forward bool) bool {
+	if s.pattern == "" {
+		return false
+	}
+	matches := RowFilters.ByPattern(s.pattern)
+	index := searchRows(count, func(i int) bool {
+		return matches(row(i))
+	}, from, forward)
+	if index < 0 {
+		return false
+	}
+	ui.ActiveScreen.Cursor.ScrollTo(index)
+	return true
+}
+
+//searchRows returns the index of the first of the given number of rows,
+//starting at the given index and wrapping around the list, for which the
+//given func returns true. Rows are searched towards the end of the list if
+//forward is true, towards the beginning otherwise. -1 is returned if no
+//row matches.
+func searchRows(count int, matches func(int) bool, from int, forward bool) int {
+	if count == 0 {
+		return -1
+	}
+	step := 1
+	if !forward {
+		step = -1
+	}
+	for i := 0; i < count; i++ {
+		index := ((from+i*step)%count + count) % count
+		if matches(index) {
+			return index
+		}
+	}
+	return -1
+}
+
+//highlightMatches highlights on the given buffer the matches of the given
+//pattern in the columns of the given row, the row must be already rendered.
+func highlightMatches(buf gizaktermui.Buffer, row FilterableRow, pattern string) {
+	if pattern == "" {
+		return
+	}
+	fg := gizaktermui.Attribute(DryTheme.CurrentMatch) | gizaktermui.AttrReverse
+	for _, column := range row.ColumnsForFilter() {
+		text := column.Text
+		for _, match := range patternMatches(text, pattern) {
+			start := utf8.RuneCountInString(text[:match[0]])
+			end := start + utf8.RuneCountInString(text[match[0]:match[1]])
+			for x := start; x < end && x < column.Width; x++ {
+				cell := buf.At(column.X+x, column.Y)
+				cell.Fg = fg
+				buf.Set(column.X+x, column.Y, cell)
+			}
+		}
+	}
+}
+
+//patternMatches returns the byte offsets of the matches of the given filter
+//pattern in the given text, as regexp FindAllStringIndex does. As with
+//MatchesPattern, a pattern that is not a valid regular expression is looked
+//for as text.
+func patternMatches(text, pattern string) [][]int {
+	if re, err := FilterRegexp(pattern); err == nil {
+		return re.FindAllStringIndex(text, -1)
+	}
+	var matches [][]int
+	offset := 0
+	for {
+		i := strings.Index(text[offset:], pattern)
+		if i < 0 {
+			return matches
+		}
+		matches = append(matches, []int{offset + i, offset + i + len(pattern)})
+		offset += i + len(pattern)
+	}
+}
@@ -0,0 +1,178 @@
+package appui
+
+import (
+	"testing"
+
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/mocks"
+	"github.com/moncho/dry/ui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+type searchableRow []string
+
+func (r searchableRow) ColumnsForFilter() []*drytermui.ParColumn {
+	var columns []*drytermui.ParColumn
+	for _, text := range r {
+		columns = append(columns, drytermui.NewParColumn(text))
+	}
+	return columns
+}
+
+//renderedRow is a row of a single column, already rendered
+type renderedRow struct {
+	column *drytermui.ParColumn
+}
+
+func (r renderedRow) ColumnsForFilter() []*drytermui.ParColumn {
+	return []*drytermui.ParColumn{r.column}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		pattern string
+		want    string
+	}{
+		{"text", "nginx-web-1", "web", "      ###  "},
+		{"every occurrence", "web-web", "web", "### ###"},
+		{"regex", "nginx-web-1", "web.*1", "      #####"},
+		{"anchored regex", "nginx-nginx", "^nginx", "#####      "},
+		{"regex wildcard", "abc", "a.c", "###"},
+		{"invalid regex is matched as text", "a(b", "a(", "## "},
+		{"no match", "redis", "web", "     "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := drytermui.NewParColumn(tt.text)
+			column.X, column.Y, column.Width = 0, 0, len(tt.text)
+			buf := gizaktermui.NewBuffer()
+			highlightMatches(buf, renderedRow{column}, tt.pattern)
+			got := ""
+			for x := 0; x < len(tt.text); x++ {
+				if buf.At(x, 0).Fg&gizaktermui.AttrReverse != 0 {
+					got += "#"
+				} else {
+					got += " "
+				}
+			}
+			if got != tt.want {
+				t.Errorf("highlightMatches() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchRows(t *testing.T) {
+	rows := []FilterableRow{
+		searchableRow{"abc", "nginx"},
+		searchableRow{"def", "redis"},
+		searchableRow{"ghi", "nginx:alpine"},
+		searchableRow{"jkl", "postgres"},
+	}
+	tests := []struct {
+		name    string
+		pattern string
+		from    int
+		forward bool
+		want    int
+	}{
+		{"the row the search starts from matches", "nginx", 0, true, 0},
+		{"next match", "nginx", 1, true, 2},
+		{"wraps around forward", "nginx", 3, true, 0},
+		{"previous match", "nginx", 1, false, 0},
+		{"wraps around backward", "nginx", -1, false, 2},
+		{"any column matches", "def", 0, true, 1},
+		{"no match", "mysql", 0, true, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := func(i int) bool {
+				return RowFilters.ByPattern(tt.pattern)(rows[i])
+			}
+			if got := searchRows(len(rows), matches, tt.from, tt.forward); got != tt.want {
+				t.Errorf("searchRows() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := searchRows(0, func(int) bool { return true }, 0, true); got != -1 {
+		t.Errorf("searchRows() on an empty list = %d, want -1", got)
+	}
+}
+
+func TestContainersWidget_Search(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 16, Width: 40},
+	}
+	//DockerDaemonMock returns 10 running containers
+	ui.ActiveScreen.Cursor.Max(10 - 1)
+
+	w := NewContainersWidget(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.prepareForRendering()
+
+	if !w.Search("7") {
+		t.Fatal("No container was found")
+	}
+	w.prepareForRendering()
+	rows := w.visibleRows()
+	if ui.ActiveScreen.Cursor.Position() != 7 || rows[len(rows)-1].container.ID != "7" {
+		t.Errorf("The cursor did not jump to the container, position: %d, last visible row: %s",
+			ui.ActiveScreen.Cursor.Position(), rows[len(rows)-1].container.ID)
+	}
+
+	if !w.Search("1") {
+		t.Fatal("No container was found")
+	}
+	w.prepareForRendering()
+	rows = w.visibleRows()
+	if ui.ActiveScreen.Cursor.Position() != 1 || rows[0].container.ID != "1" {
+		t.Errorf("The cursor did not jump to the container, position: %d, first visible row: %s",
+			ui.ActiveScreen.Cursor.Position(), rows[0].container.ID)
+	}
+
+	if !w.SearchNext() || ui.ActiveScreen.Cursor.Position() != 1 {
+		t.Errorf("The only match was not found again, position: %d", ui.ActiveScreen.Cursor.Position())
+	}
+
+	if w.Search("nope") || ui.ActiveScreen.Cursor.Position() != 1 {
+		t.Errorf("The cursor moved without a match, position: %d", ui.ActiveScreen.Cursor.Position())
+	}
+}
+
+func TestDockerImagesWidget_Search(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Cursor:     &ui.Cursor{},
+		Dimensions: &ui.Dimensions{Height: 15, Width: 100},
+	}
+	//DockerDaemonMock returns 5 images
+	ui.ActiveScreen.Cursor.Max(5 - 1)
+
+	w := NewDockerImagesWidget(&mocks.DockerDaemonMock{}, 0, ListOptions{})
+	if err := w.Mount(); err != nil {
+		t.Fatalf("There was an error mounting the widget %v", err)
+	}
+	w.loader.Wait()
+	w.prepareForRendering()
+
+	if !w.Search("examplevotingapp") {
+		t.Fatal("No image was found")
+	}
+	position := ui.ActiveScreen.Cursor.Position()
+	if id := w.filteredRows[position].image.ID; id != "a3d6e836e86a" {
+		t.Errorf("The cursor did not jump to the image, position: %d, image: %s", position, id)
+	}
+
+	if !w.SearchNext() || ui.ActiveScreen.Cursor.Position() != position {
+		t.Errorf("The only match was not found again, position: %d", ui.ActiveScreen.Cursor.Position())
+	}
+
+	if w.Search("nope") || ui.ActiveScreen.Cursor.Position() != position {
+		t.Errorf("The cursor moved without a match, position: %d", ui.ActiveScreen.Cursor.Position())
+	}
+}
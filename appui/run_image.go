@@ -25,9 +25,9 @@ func NewImageRunWidget(image types.ImageSummary) *ImageRunWidget {
 	w.Y = ui.ActiveScreen.Dimensions.Height / 2
 	w.Bg = gtermui.Attribute(DryTheme.Bg)
 	w.TextBgColor = gtermui.Attribute(DryTheme.Bg)
-	w.TextFgColor = gtermui.ColorWhite
+	w.TextFgColor = gtermui.Attribute(DryTheme.Fg)
 	w.BorderLabel = widgetTitle(&image)
-	w.BorderLabelFg = gtermui.ColorWhite
+	w.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
 
 	return w
 }
@@ -0,0 +1,33 @@
+package appui
+
+import "github.com/moncho/dry/docker"
+
+//sortOrder remembers, for each sort mode of a list, if it sorts the list in
+//descending order. Lists are sorted in ascending order until told otherwise.
+type sortOrder struct {
+	descending map[docker.SortMode]bool
+}
+
+//Descending returns true if the given sort mode sorts in descending order
+func (o *sortOrder) Descending(mode docker.SortMode) bool {
+	return o.descending[mode]
+}
+
+//Reverse switches the order of the given sort mode between ascending and descending
+func (o *sortOrder) Reverse(mode docker.SortMode) {
+	if o.descending == nil {
+		o.descending = make(map[docker.SortMode]bool)
+	}
+	o.descending[mode] = !o.descending[mode]
+}
+
+//less returns the given ordering of the given sort mode, reversed if the
+//mode sorts in descending order
+func (o *sortOrder) less(mode docker.SortMode, less func(i, j int) bool) func(i, j int) bool {
+	if less == nil || !o.Descending(mode) {
+		return less
+	}
+	return func(i, j int) bool {
+		return less(j, i)
+	}
+}
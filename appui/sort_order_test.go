@@ -0,0 +1,51 @@
+package appui
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestSortOrder(t *testing.T) {
+	var o sortOrder
+	values := []int{3, 1, 2}
+	less := func(i, j int) bool { return values[i] < values[j] }
+
+	if o.Descending(docker.SortByName) {
+		t.Error("Lists must be sorted in ascending order by default")
+	}
+	sort.SliceStable(values, o.less(docker.SortByName, less))
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Ascending sort, got %v, want %v", values, want)
+	}
+
+	o.Reverse(docker.SortByName)
+	if !o.Descending(docker.SortByName) {
+		t.Error("Sort by name must be descending once reversed")
+	}
+	if o.Descending(docker.SortByImage) {
+		t.Error("Reversing a sort mode must not reverse the others")
+	}
+	sort.SliceStable(values, o.less(docker.SortByName, less))
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Descending sort, got %v, want %v", values, want)
+	}
+
+	o.Reverse(docker.SortByName)
+	if o.Descending(docker.SortByName) {
+		t.Error("Sort by name must be ascending once reversed twice")
+	}
+}
+
+func TestColumnTitleWithSortMarks(t *testing.T) {
+	for _, title := range []string{"NAME", SortedColumnTitle("NAME"), ReverseSortedColumnTitle("NAME")} {
+		if got := ColumnTitle(title); got != "NAME" {
+			t.Errorf("ColumnTitle(%q) = %q, want NAME", title, got)
+		}
+	}
+	if got, want := SortColumnTitle("NAME", true), ReverseSortedColumnTitle("NAME"); got != want {
+		t.Errorf("Descending column title, got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,201 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	units "github.com/docker/go-units"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+//statsHistoryMetric is a metric shown on the stats history widget
+type statsHistoryMetric struct {
+	title  string
+	values func(samples []docker.StatsSample) []float64
+	format func(v float64) string
+}
+
+var statsHistoryMetrics = []statsHistoryMetric{
+	{
+		"CPU",
+		func(samples []docker.StatsSample) []float64 {
+			return sampleValues(samples, func(s *docker.Stats) float64 { return s.CPUPercentage })
+		},
+		func(v float64) string { return fmt.Sprintf("%.2f%%", v) },
+	},
+	{
+		"MEMORY",
+		func(samples []docker.StatsSample) []float64 {
+			return sampleValues(samples, func(s *docker.Stats) float64 { return s.Memory })
+		},
+		units.BytesSize,
+	},
+	{
+		"NET RX+TX",
+		func(samples []docker.StatsSample) []float64 {
+			return sampleRates(samples, func(s *docker.Stats) float64 { return s.NetworkRx + s.NetworkTx })
+		},
+		func(v float64) string { return units.BytesSize(v) + "/s" },
+	},
+	{
+		"BLOCK I/O",
+		func(samples []docker.StatsSample) []float64 {
+			return sampleRates(samples, func(s *docker.Stats) float64 { return s.BlockRead + s.BlockWrite })
+		},
+		func(v float64) string { return units.BytesSize(v) + "/s" },
+	},
+}
+
+//ContainerStatsHistory is a widget that shows the stats history of a
+//container as sparklines
+type ContainerStatsHistory struct {
+	container     *docker.Container
+	history       *docker.StatsHistory
+	x, y          int
+	height, width int
+}
+
+//NewContainerStatsHistory creates a widget to show the given stats history
+func NewContainerStatsHistory(container *docker.Container, history *docker.StatsHistory, y int) *ContainerStatsHistory {
+	return &ContainerStatsHistory{
+		container: container,
+		history:   history,
+		y:         y,
+		height:    MainScreenAvailableHeight(),
+		width:     ui.ActiveScreen.Dimensions.Width,
+	}
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (w *ContainerStatsHistory) Buffer() gizaktermui.Buffer {
+	par := drytermui.NewParFromMarkupText(DryTheme, w.content())
+	par.Border = false
+	par.X = w.x
+	par.Y = w.y
+	par.Width = w.width
+	par.Height = w.height
+	par.Bg = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(DryTheme.Fg)
+	return par.Buffer()
+}
+
+func (w *ContainerStatsHistory) content() string {
+	samples := w.history.Samples()
+	cf := formatter.NewContainerFormatter(w.container, true)
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "<b><blue>Stats history: </><yellow>%s</> <blue>(%s)</> <blue>| Window: </><yellow>%s</></>\n\n",
+		cf.Names(), cf.ID(), w.history.Window())
+	for _, m := range statsHistoryMetrics {
+		values := m.values(samples)
+		current, min, max, avg := summary(values)
+		fmt.Fprintf(buf, "<b><blue>%-10s</></> <blue>cur:</> <yellow>%s</> <blue>min:</> <yellow>%s</> <blue>max:</> <yellow>%s</> <blue>avg:</> <yellow>%s</>\n",
+			m.title, formatValue(current, m.format), formatValue(min, m.format), formatValue(max, m.format), formatValue(avg, m.format))
+		buf.WriteString(sparkline(values, w.width))
+		buf.WriteString("\n\n")
+	}
+	return buf.String()
+}
+
+//sampleValues returns the value of each sample, gaps are NaN
+func sampleValues(samples []docker.StatsSample, value func(*docker.Stats) float64) []float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		if s.Gap || s.Stats == nil {
+			values[i] = math.NaN()
+		} else {
+			values[i] = value(s.Stats)
+		}
+	}
+	return values
+}
+
+//sampleRates returns the per-second rate of change of the given cumulative value.
+//Gaps, and the first sample after a gap, are NaN.
+func sampleRates(samples []docker.StatsSample, value func(*docker.Stats) float64) []float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = math.NaN()
+		if i == 0 || s.Gap || s.Stats == nil {
+			continue
+		}
+		prev := samples[i-1]
+		if prev.Gap || prev.Stats == nil {
+			continue
+		}
+		elapsed := s.Time.Sub(prev.Time).Seconds()
+		if delta := value(s.Stats) - value(prev.Stats); elapsed > 0 && delta >= 0 {
+			values[i] = delta / elapsed
+		}
+	}
+	return values
+}
+
+//summary returns the current, min, max and average values, gaps are ignored.
+func summary(values []float64) (current, min, max, avg float64) {
+	current, min, max, avg = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+	var sum float64
+	var count int
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if count == 0 || v < min {
+			min = v
+		}
+		if count == 0 || v > max {
+			max = v
+		}
+		sum += v
+		count++
+	}
+	if count > 0 {
+		avg = sum / float64(count)
+	}
+	if len(values) > 0 {
+		current = values[len(values)-1]
+	}
+	return
+}
+
+func formatValue(v float64, format func(float64) string) string {
+	if math.IsNaN(v) {
+		return inactiveRowText
+	}
+	return format(v)
+}
+
+//sparkline returns a sparkline of the given values, using at most width characters.
+//If there are more values than characters the most recent values are used.
+//NaN values are shown as gaps, consecutive gaps are shown as one.
+func sparkline(values []float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	_, min, max, _ := summary(values)
+	sparkTicks := ui.SparkTicks()
+	sb := new(bytes.Buffer)
+	lastWasGap := false
+	for _, v := range values {
+		if math.IsNaN(v) {
+			if !lastWasGap {
+				sb.WriteString("<red>" + ui.SparkGapGlyph.String() + "</>")
+			}
+			lastWasGap = true
+			continue
+		}
+		lastWasGap = false
+		tick := 0
+		if max > min {
+			tick = int((v - min) / (max - min) * float64(len(sparkTicks)-1))
+		}
+		sb.WriteRune(sparkTicks[tick])
+	}
+	return sb.String()
+}
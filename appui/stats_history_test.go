@@ -0,0 +1,52 @@
+package appui
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/docker"
+)
+
+func TestSparkline(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"no values", nil, 10, ""},
+		{"flat line", []float64{3, 3, 3}, 10, "▁▁▁"},
+		{"ascending values", []float64{0, 7}, 10, "▁█"},
+		{"only the most recent values fit", []float64{7, 0, 7}, 2, "▁█"},
+		{"gaps are marked once", []float64{0, nan, nan, 7}, 10, "▁<red>╳</>█"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleRates(t *testing.T) {
+	now := time.Now()
+	samples := []docker.StatsSample{
+		{Time: now, Stats: &docker.Stats{NetworkRx: 0}},
+		{Time: now.Add(2 * time.Second), Stats: &docker.Stats{NetworkRx: 100}},
+		{Time: now.Add(2 * time.Second), Gap: true},
+		{Time: now.Add(10 * time.Second), Stats: &docker.Stats{NetworkRx: 10}},
+		{Time: now.Add(11 * time.Second), Stats: &docker.Stats{NetworkRx: 20}},
+	}
+	rates := sampleRates(samples, func(s *docker.Stats) float64 { return s.NetworkRx })
+
+	if !math.IsNaN(rates[0]) || rates[1] != 50 || !math.IsNaN(rates[2]) || !math.IsNaN(rates[3]) || rates[4] != 10 {
+		t.Errorf("Unexpected rates: %v", rates)
+	}
+	current, min, max, avg := summary(rates)
+	if current != 10 || min != 10 || max != 50 || avg != 30 {
+		t.Errorf("Unexpected summary, current %f, min %f, max %f, avg %f", current, min, max, avg)
+	}
+}
@@ -4,17 +4,21 @@ import (
 	"fmt"
 	"image"
 	"strconv"
+	"sync"
 	"time"
 
 	units "github.com/docker/go-units"
 	termui "github.com/gizak/termui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/docker/formatter"
-	"github.com/moncho/dry/ui"
 	drytermui "github.com/moncho/dry/ui/termui"
 )
 
-const inactiveRowColor = termui.Attribute(ui.Color244)
+//inactiveRowColor is the color of the rows of elements that are not running
+func inactiveRowColor() termui.Attribute {
+	return termui.Attribute(DryTheme.Inactive)
+}
+
 const inactiveRowText = "-"
 
 //ContainerStatsRow is a Grid row showing runtime information about a container
@@ -30,6 +34,15 @@ type ContainerStatsRow struct {
 	Pids      *drytermui.ParColumn
 	Uptime    *drytermui.ParColumn
 
+	//generation tells the stats channels the row listened to apart, only
+	//the last one updates the row
+	generation uint64
+	exited     bool
+	alerted    bool
+	stats      *docker.Stats
+	history    *docker.StatsHistory
+	traffic    *docker.NetworkMeter
+	statsLock  sync.Mutex
 	drytermui.Row
 }
 
@@ -47,6 +60,7 @@ func NewContainerStatsRow(container *docker.Container, table drytermui.Table) *C
 		Block:     drytermui.NewThemedParColumn(DryTheme, inactiveRowText),
 		Pids:      drytermui.NewThemedParColumn(DryTheme, inactiveRowText),
 		Uptime:    drytermui.NewThemedParColumn(DryTheme, container.Status),
+		traffic:   docker.NewNetworkMeter(),
 	}
 	row.Height = 1
 	row.Table = table
@@ -74,34 +88,112 @@ func NewContainerStatsRow(container *docker.Container, table drytermui.Table) *C
 //NewSelfUpdatedContainerStatsRow creates a ContainerStatsRow that updates
 //itself on stats message sent on the given channel
 func NewSelfUpdatedContainerStatsRow(s *docker.StatsChannel, table drytermui.Table) *ContainerStatsRow {
-	c := s.Container
-	row := NewContainerStatsRow(c, table)
+	row := NewContainerStatsRow(s.Container, table)
+	row.listen(s)
+	return row
+}
 
-	if docker.IsContainerRunning(c) {
-		go func() {
-			for stat := range s.Stats {
+//listen updates this row with the stats sent on the given channel. Once the channel
+//is closed the row is marked as not running, unless the row listens to another
+//channel or was detached from it in the meantime.
+func (row *ContainerStatsRow) listen(s *docker.StatsChannel) {
+	if s == nil {
+		return
+	}
+	c := s.Container
+	if !docker.IsContainerRunning(c) || s.Stats == nil {
+		return
+	}
+	row.statsLock.Lock()
+	row.generation++
+	generation := row.generation
+	row.exited = false
+	row.statsLock.Unlock()
+	go func() {
+		for stat := range s.Stats {
+			if row.listening(generation) {
 				row.Update(c, stat)
 			}
+		}
+		row.statsLock.Lock()
+		defer row.statsLock.Unlock()
+		if generation == row.generation {
+			row.exited = true
 			row.markAsNotRunning()
-		}()
+		}
+	}()
+}
+
+//listening returns true if the channel of the given generation is the one
+//this row listens to
+func (row *ContainerStatsRow) listening(generation uint64) bool {
+	row.statsLock.Lock()
+	defer row.statsLock.Unlock()
+	return generation == row.generation
+}
+
+//hasExited returns true if the stats stream of this row finished because the container stopped
+func (row *ContainerStatsRow) hasExited() bool {
+	row.statsLock.Lock()
+	defer row.statsLock.Unlock()
+	return row.exited
+}
+
+//lastStats returns the last stats received by this row, nil if none were received
+func (row *ContainerStatsRow) lastStats() *docker.Stats {
+	row.statsLock.Lock()
+	defer row.statsLock.Unlock()
+	return row.stats
+}
+
+//recordHistory makes this row add the stats it receives to the given history,
+//nil stops recording them
+func (row *ContainerStatsRow) recordHistory(history *docker.StatsHistory) {
+	row.statsLock.Lock()
+	defer row.statsLock.Unlock()
+	row.history = history
+}
+
+//trafficRates returns the network traffic rates of the container, in total
+//and for each interface
+func (row *ContainerStatsRow) trafficRates() (docker.NetworkRate, []docker.NetworkRate) {
+	if row.traffic == nil {
+		return docker.NetworkRate{}, nil
 	}
-	return row
+	return row.traffic.Total(), row.traffic.Rates()
+}
+
+//detach tells this row that its stats channel is about to be closed, the row
+//keeps showing the last stats received and ignores the ones still sent on it.
+func (row *ContainerStatsRow) detach() {
+	row.statsLock.Lock()
+	defer row.statsLock.Unlock()
+	row.generation++
 }
 
 //Highlighted marks this rows as being highlighted
 func (row *ContainerStatsRow) Highlighted() {
 	row.changeTextColor(
-		termui.Attribute(DryTheme.Fg),
+		row.textColor(termui.Attribute(DryTheme.CursorLineFg)),
 		termui.Attribute(DryTheme.CursorLineBg))
 }
 
 //NotHighlighted marks this rows as being not highlighted
 func (row *ContainerStatsRow) NotHighlighted() {
 	row.changeTextColor(
-		termui.Attribute(DryTheme.ListItem),
+		row.textColor(termui.Attribute(DryTheme.ListItem)),
 		termui.Attribute(DryTheme.Bg))
 }
 
+//textColor returns the color to use for the text of this row, rows with an
+//active alert use the Alerted color
+func (row *ContainerStatsRow) textColor(fg termui.Attribute) termui.Attribute {
+	if row.alerted {
+		return Alerted
+	}
+	return fg
+}
+
 //Buffer returns this Row data as a termui.Buffer
 func (row *ContainerStatsRow) Buffer() termui.Buffer {
 	buf := termui.NewBuffer()
@@ -145,6 +237,16 @@ func (row *ContainerStatsRow) Reset() {
 //Update updates the content of this row with the given stats
 func (row *ContainerStatsRow) Update(container *docker.Container, stat *docker.Stats) {
 	if stat != nil {
+		row.statsLock.Lock()
+		row.stats = stat
+		now := time.Now()
+		if row.history != nil {
+			row.history.Add(now, stat)
+		}
+		if row.traffic != nil {
+			row.traffic.Add(now, stat)
+		}
+		row.statsLock.Unlock()
 		row.setNet(stat.NetworkRx, stat.NetworkTx)
 		row.setCPU(stat.CPUPercentage)
 		row.setMem(stat.Memory, stat.MemoryLimit, stat.MemoryPercentage)
@@ -200,31 +302,31 @@ func (row *ContainerStatsRow) setUptime(startedAt string) {
 //markAsNotRunning
 func (row *ContainerStatsRow) markAsNotRunning() {
 	row.Status.TextFgColor = NotRunning
-	row.Name.TextFgColor = inactiveRowColor
-	row.ID.TextFgColor = inactiveRowColor
-	row.CPU.PercentColor = inactiveRowColor
+	row.Name.TextFgColor = inactiveRowColor()
+	row.ID.TextFgColor = inactiveRowColor()
+	row.CPU.PercentColor = inactiveRowColor()
 	row.CPU.Percent = 0
 	row.CPU.Label = inactiveRowText
-	row.Memory.PercentColor = inactiveRowColor
+	row.Memory.PercentColor = inactiveRowColor()
 	row.Memory.Percent = 0
 	row.Memory.Label = inactiveRowText
-	row.Net.TextFgColor = inactiveRowColor
+	row.Net.TextFgColor = inactiveRowColor()
 	row.Net.Text = inactiveRowText
-	row.Block.TextFgColor = inactiveRowColor
+	row.Block.TextFgColor = inactiveRowColor()
 	row.Block.Text = inactiveRowText
 	row.Pids.Text = "0"
-	row.Pids.TextFgColor = inactiveRowColor
+	row.Pids.TextFgColor = inactiveRowColor()
 	row.Uptime.Text = inactiveRowText
-	row.Pids.TextFgColor = inactiveRowColor
+	row.Pids.TextFgColor = inactiveRowColor()
 
 }
 
 func percentileToColor(n int) termui.Attribute {
-	c := ui.Color23
+	c := DryTheme.UsageLow
 	if n > 90 {
-		c = ui.Color161
+		c = DryTheme.UsageHigh
 	} else if n > 60 {
-		c = ui.Color131
+		c = DryTheme.UsageMedium
 	}
 	return termui.Attribute(c)
 }
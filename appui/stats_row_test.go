@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	units "github.com/docker/go-units"
@@ -165,3 +166,44 @@ func TestContainerStatsRow_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerStatsRow_Relisten(t *testing.T) {
+	container := &docker.Container{
+		Container: types.Container{ID: "CID", Names: []string{"Name"}, Status: "Up 2 minutes"},
+		ContainerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{Running: true},
+			}},
+	}
+	row := NewContainerStatsRow(container, NewMonitorTableHeader())
+
+	old := make(chan *docker.Stats)
+	row.listen(&docker.StatsChannel{Container: container, Stats: old})
+	row.detach()
+	current := make(chan *docker.Stats)
+	row.listen(&docker.StatsChannel{Container: container, Stats: current})
+
+	//the stats still sent on the old channel are ignored, and closing it
+	//does not mark the row as not running
+	old <- &docker.Stats{PidsCurrent: 1}
+	close(old)
+	time.Sleep(10 * time.Millisecond)
+	if row.lastStats() != nil || row.hasExited() {
+		t.Errorf("The old channel updated the row, stats: %+v, exited: %v", row.lastStats(), row.hasExited())
+	}
+
+	stat := &docker.Stats{PidsCurrent: 2}
+	current <- stat
+	close(current)
+
+	deadline := time.Now().Add(time.Second)
+	for !row.hasExited() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !row.hasExited() {
+		t.Error("Closing the channel the row listens to did not mark it as not running")
+	}
+	if row.lastStats() != stat {
+		t.Errorf("Unexpected stats on the row: %+v", row.lastStats())
+	}
+}
@@ -0,0 +1,225 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/swarm"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	drytermui "github.com/moncho/dry/ui/termui"
+)
+
+const statusSeparator = " <blue>|</> "
+
+//ListStatus is implemented by the widgets that show a list that can be
+//sorted and filtered
+type ListStatus interface {
+	SortedBy() string
+	ActiveFilter() string
+}
+
+//statusSegment is a part of the status bar, segments with a higher
+//priority value are dropped first when there is no room for all of them
+type statusSegment struct {
+	label    string
+	value    string
+	priority int
+}
+
+func (s statusSegment) width() int {
+	return len([]rune(s.label)) + len([]rune(s.value))
+}
+
+func (s statusSegment) String() string {
+	return fmt.Sprintf("<blue>%s</><yellow>%s</>", s.label, s.value)
+}
+
+//StatusBar is a widget that shows, in a single line, the daemon dry is connected
+//to, its version, container and image counts, swarm role, the sort mode and
+//filter of the list on screen and the current time.
+type StatusBar struct {
+	daemon     docker.ContainerDaemon
+	y          int
+	version    string
+	apiVersion string
+	apiPinned  bool
+	images     int
+	swarmRole  string
+	list       ListStatus
+	now        func() time.Time
+	sync.RWMutex
+}
+
+//NewStatusBar creates a StatusBar for the given daemon, shown on the given line
+func NewStatusBar(daemon docker.ContainerDaemon, y int) *StatusBar {
+	return &StatusBar{
+		daemon: daemon,
+		y:      y,
+		now:    time.Now,
+	}
+}
+
+//Refresh retrieves the daemon information shown by the status bar
+func (s *StatusBar) Refresh() error {
+	info, err := s.daemon.Info()
+	if err != nil {
+		return err
+	}
+	version, err := s.daemon.Version()
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.version = version.Version
+	//the API version used with the daemon, it is lower than the
+	//one of the daemon if dry does not know about the latter
+	s.apiVersion = s.daemon.APIVersion()
+	if s.apiVersion == "" {
+		s.apiVersion = version.APIVersion
+	}
+	s.apiPinned = false
+	if env := s.daemon.DockerEnv(); env != nil {
+		s.apiPinned = env.APIVersionPinned
+	}
+	s.images = info.Images
+	s.swarmRole = ""
+	if info.Swarm.LocalNodeState == swarm.LocalNodeStateActive {
+		s.swarmRole = string(swarm.NodeRoleWorker)
+		if info.Swarm.ControlAvailable {
+			s.swarmRole = string(swarm.NodeRoleManager)
+		}
+	}
+	return nil
+}
+
+//SetList sets the list whose sort mode and filter are shown, nil if the
+//view on screen does not show a list
+func (s *StatusBar) SetList(list ListStatus) {
+	s.Lock()
+	defer s.Unlock()
+	s.list = list
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *StatusBar) Buffer() gizaktermui.Buffer {
+	width := ui.ActiveScreen.Dimensions.Width
+	par := drytermui.NewParFromMarkupText(DryTheme, statusLine(fitStatusSegments(s.segments(), width-1)))
+	par.SetX(0)
+	par.SetY(s.y)
+	par.Border = false
+	par.Height = 1
+	par.Width = width
+	par.Bg = gizaktermui.Attribute(DryTheme.Header)
+	par.TextBgColor = gizaktermui.Attribute(DryTheme.Header)
+	return par.Buffer()
+}
+
+func (s *StatusBar) segments() []statusSegment {
+	s.RLock()
+	defer s.RUnlock()
+	running, stopped := containerCounts(s.daemon.Containers(nil, docker.NoSort))
+
+	segments := []statusSegment{
+		{"", s.daemon.DockerEnv().DockerHost, 0},
+		{"Docker ", fmt.Sprintf("%s (%s)", s.version, apiVersionLabel(s.apiVersion, s.apiPinned)), 3},
+		{"Containers: ", fmt.Sprintf("%d running/%d stopped", running, stopped), 2},
+		{"Images: ", fmt.Sprintf("%d", s.images), 4},
+	}
+	if s.swarmRole != "" {
+		segments = append(segments, statusSegment{"Swarm: ", s.swarmRole, 5})
+	}
+	if s.list != nil {
+		segments = append(segments, statusSegment{"Sort: ", s.list.SortedBy(), 1})
+		if filter := s.list.ActiveFilter(); filter != "" {
+			segments = append(segments, statusSegment{"Filter: ", filter, 1})
+		}
+	}
+	return append(segments, statusSegment{"", s.now().Format("15:04"), 6})
+}
+
+//containerCounts returns how many of the given containers are running and
+//how many are not
+func containerCounts(containers []*docker.Container) (running, stopped int) {
+	for _, c := range containers {
+		if docker.IsContainerRunning(c) {
+			running++
+		} else {
+			stopped++
+		}
+	}
+	return running, stopped
+}
+
+//fitStatusSegments returns the segments that fit in the given width, the
+//segments with a higher priority value are dropped first. The value of
+//the last segment standing is truncated if it does not fit either.
+func fitStatusSegments(segments []statusSegment, width int) []statusSegment {
+	separatorWidth := len([]rune(ui.SupportedTags.ReplaceAllString(statusSeparator, "")))
+	fitted := append([]statusSegment{}, segments...)
+	for len(fitted) > 1 && statusWidth(fitted, separatorWidth) > width {
+		drop := 0
+		for i, segment := range fitted {
+			if segment.priority > fitted[drop].priority {
+				drop = i
+			}
+		}
+		fitted = append(fitted[:drop], fitted[drop+1:]...)
+	}
+	if len(fitted) == 1 && fitted[0].width() > width {
+		value := []rune(fitted[0].value)
+		room := width - len([]rune(fitted[0].label)) - 1
+		if room < 0 {
+			return nil
+		}
+		fitted[0].value = string(value[:room]) + ui.EllipsisGlyph.String()
+	}
+	return fitted
+}
+
+func statusWidth(segments []statusSegment, separatorWidth int) int {
+	width := separatorWidth * (len(segments) - 1)
+	for _, segment := range segments {
+		width += segment.width()
+	}
+	return width
+}
+
+func statusLine(segments []statusSegment) string {
+	var buf bytes.Buffer
+	buf.WriteString(" ")
+	for i, segment := range segments {
+		if i > 0 {
+			buf.WriteString(statusSeparator)
+		}
+		buf.WriteString(segment.String())
+	}
+	return buf.String()
+}
+
+//SortModeTitle returns the title of the column of the given headers that
+//sorts by the given mode, "-" if no column sorts by it
+func SortModeTitle(headers []SortableColumnHeader, mode docker.SortMode) string {
+	if mode == docker.NoSort {
+		return "-"
+	}
+	for _, header := range headers {
+		if header.Mode == mode {
+			return header.Title
+		}
+	}
+	return "-"
+}
+
+//apiVersionLabel describes the API version used with the daemon, a pinned
+//version is the one given with DOCKER_API_VERSION
+func apiVersionLabel(apiVersion string, pinned bool) string {
+	if pinned {
+		return "API " + apiVersion + " pinned"
+	}
+	return "API " + apiVersion
+}
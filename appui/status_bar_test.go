@@ -0,0 +1,95 @@
+package appui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/mocks"
+)
+
+func TestFitStatusSegments(t *testing.T) {
+	segments := []statusSegment{
+		{"", "unix:///var/run/docker.sock", 0},
+		{"Docker ", "18.06.1 (API 1.38)", 3},
+		{"Containers: ", "2 running/1 stopped", 2},
+		{"Sort: ", "NAME", 1},
+		{"", "12:34", 6},
+	}
+	tests := []struct {
+		name  string
+		width int
+		want  []string
+	}{
+		{"everything fits", 200, []string{"unix:///var/run/docker.sock", "18.06.1 (API 1.38)", "2 running/1 stopped", "NAME", "12:34"}},
+		{"time is dropped first", 105, []string{"unix:///var/run/docker.sock", "18.06.1 (API 1.38)", "2 running/1 stopped", "NAME"}},
+		{"then the version", 80, []string{"unix:///var/run/docker.sock", "2 running/1 stopped", "NAME"}},
+		{"then the containers", 45, []string{"unix:///var/run/docker.sock", "NAME"}},
+		{"the endpoint is kept", 30, []string{"unix:///var/run/docker.sock"}},
+		{"the endpoint is truncated", 10, []string{"unix:///v…"}},
+		{"no room at all", 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitStatusSegments(segments, tt.width)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Unexpected segments, got %v, want %v", got, tt.want)
+			}
+			for i, segment := range got {
+				if segment.value != tt.want[i] {
+					t.Errorf("Unexpected segment %d, got %q, want %q", i, segment.value, tt.want[i])
+				}
+			}
+			if w := statusWidth(got, 3); len(got) > 0 && w > tt.width {
+				t.Errorf("Segments do not fit, width %d, want at most %d", w, tt.width)
+			}
+		})
+	}
+	if segments[4].value != "12:34" || segments[0].value != "unix:///var/run/docker.sock" {
+		t.Error("Fitting changed the given segments")
+	}
+}
+
+//countingDaemon counts how many times the containers are listed
+type countingDaemon struct {
+	mocks.DockerDaemonMock
+	calls int
+}
+
+func (d *countingDaemon) Containers(filters []docker.ContainerFilter, mode docker.SortMode) []*docker.Container {
+	d.calls++
+	return d.DockerDaemonMock.Containers(filters, mode)
+}
+
+func TestStatusBarContainerCounts(t *testing.T) {
+	daemon := &countingDaemon{}
+	s := NewStatusBar(daemon, 0)
+	s.now = func() time.Time { return time.Date(2018, 9, 1, 12, 34, 0, 0, time.UTC) }
+
+	segments := s.segments()
+	//DockerDaemonMock returns 10 running and 10 stopped containers
+	if segments[2].value != "10 running/10 stopped" {
+		t.Errorf("Unexpected containers segment: %q", segments[2].value)
+	}
+	if daemon.calls != 1 {
+		t.Errorf("The containers were listed %d times, want once", daemon.calls)
+	}
+}
+
+func TestSortModeTitle(t *testing.T) {
+	if got := SortModeTitle(containerTableHeaders, docker.SortByName); got != "NAMES" {
+		t.Errorf("Unexpected title, got %s", got)
+	}
+	if got := SortModeTitle(containerTableHeaders, docker.NoSort); got != "-" {
+		t.Errorf("Unexpected title for an unknown sort mode, got %s", got)
+	}
+}
+
+func TestAPIVersionLabel(t *testing.T) {
+	if got := apiVersionLabel("1.37", false); got != "API 1.37" {
+		t.Errorf("Unexpected label, got %s", got)
+	}
+	if got := apiVersionLabel("1.30", true); got != "API 1.30 pinned" {
+		t.Errorf("Unexpected label of a pinned version, got %s", got)
+	}
+}
@@ -1,7 +1,9 @@
 package appui
 
 import (
+	"fmt"
 	"io"
+	"sync/atomic"
 
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/moncho/dry/ui"
@@ -10,11 +12,29 @@ import (
 
 //Stream shows the content of the given stream on screen
 func Stream(stream io.ReadCloser, keyboardQueue chan termbox.Event, done func()) {
+	//TODO make sure that io errors can be safely ignored
+	show(stream, keyboardQueue, done, func(v io.Writer) {
+		stdcopy.StdCopy(v, v, stream)
+	})
+}
+
+//StreamText shows the content of the given plain text stream, one not
+//multiplexed as the Docker API sends them, on screen below the given banner
+func StreamText(stream io.ReadCloser, banner string, keyboardQueue chan termbox.Event, done func()) {
+	show(stream, keyboardQueue, done, func(v io.Writer) {
+		fmt.Fprintln(v, banner)
+		io.Copy(v, stream)
+	})
+}
+
+func show(stream io.ReadCloser, keyboardQueue chan termbox.Event, done func(), copyTo func(io.Writer)) {
 	defer done()
+	atomic.AddInt32(&activePagers, 1)
+	defer atomic.AddInt32(&activePagers, -1)
 	ui.ActiveScreen.ClearAndFlush()
 	v := ui.NewLess(ui.ActiveScreen, DryTheme)
-	//TODO make sure that io errors can be safely ignored
-	go stdcopy.StdCopy(v, v, stream)
+	v.SetFormatter(NewJSONLogFormatter())
+	go copyTo(v)
 	v.Focus(keyboardQueue)
 
 	stream.Close()
@@ -0,0 +1,18 @@
+package swarm
+
+import "github.com/moncho/dry/appui"
+
+//NodeSortHeaders returns the column headers of the node list
+func NodeSortHeaders() []appui.SortableColumnHeader {
+	return nodeTableHeaders
+}
+
+//ServiceSortHeaders returns the column headers of the service list
+func ServiceSortHeaders() []appui.SortableColumnHeader {
+	return serviceTableHeaders
+}
+
+//StackSortHeaders returns the column headers of the stack list
+func StackSortHeaders() []appui.SortableColumnHeader {
+	return stackTableHeaders
+}
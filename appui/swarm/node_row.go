@@ -87,7 +87,7 @@ func (row *NodeRow) ColumnsForFilter() []*drytermui.ParColumn {
 //Highlighted marks this rows as being highlighted
 func (row *NodeRow) Highlighted() {
 	row.changeTextColor(
-		termui.Attribute(appui.DryTheme.Fg),
+		termui.Attribute(appui.DryTheme.CursorLineFg),
 		termui.Attribute(appui.DryTheme.CursorLineBg))
 }
 
@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"context"
 	"fmt"
 
 	gizaktermui "github.com/gizak/termui"
@@ -35,6 +36,7 @@ func NewNodeTasksWidget(swarmClient docker.SwarmAPI, y int) *NodeTasksWidget {
 			height:        appui.MainScreenAvailableHeight(),
 			width:         ui.ActiveScreen.Dimensions.Width},
 	}
+	w.loader = appui.NewAsyncLoader(&w)
 
 	return &w
 
@@ -48,13 +50,9 @@ func (s *NodeTasksWidget) Buffer() gizaktermui.Buffer {
 	buf := gizaktermui.NewBuffer()
 	if s.mounted {
 		s.prepareForRendering()
-		var filter string
-		if s.filterPattern != "" {
-			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
-		}
+		filter := s.filterCaption()
 		s.tableTitle.Content(fmt.Sprintf(
-			"<b><blue>Node %s tasks: </><yellow>%d</></>", s.nodeName, s.RowCount()) + " " + filter)
+			"<b><blue>Node %s tasks: </><yellow>%d</></>", s.nodeName, s.RowCount()) + " " + filter + s.loader.HeaderDetails())
 
 		s.tableTitle.Y = y
 		buf.Merge(s.tableTitle.Buffer())
@@ -88,37 +86,41 @@ func (s *NodeTasksWidget) ForNode(nodeID string) {
 	s.nodeID = nodeID
 	s.mounted = false
 	s.sortMode = docker.SortByTaskService
+	s.totalRows = nil
+	s.loader.Reset()
 }
 
-//Mount prepares this widget for rendering
+//Mount prepares this widget for rendering, tasks are loaded in the background
 func (s *NodeTasksWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
-		node, err := s.swarmClient.Node(s.nodeID)
-		if err != nil {
-			return err
-		}
-		nodeName, _ := s.swarmClient.ResolveNode(node.ID)
-
-		s.nodeName = nodeName
-
-		tasks, err := s.swarmClient.NodeTasks(node.ID)
-		if err != nil {
-
-			return err
-		}
-
-		var tasksRows []*TaskRow
-		for _, task := range tasks {
-			tasksRows = append(tasksRows, NewTaskRow(s.swarmClient, task, s.header))
-		}
-		s.totalRows = tasksRows
-		s.align()
 		s.mounted = true
+		nodeID := s.nodeID
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			node, err := s.swarmClient.Node(nodeID)
+			if err != nil {
+				return nil, err
+			}
+			nodeName, _ := s.swarmClient.ResolveNode(node.ID)
 
+			tasks, err := s.swarmClient.NodeTasks(node.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			var tasksRows []*TaskRow
+			for _, task := range tasks {
+				tasksRows = append(tasksRows, NewTaskRow(s.swarmClient, task, s.header))
+			}
+			return func() {
+				s.nodeName = nodeName
+				s.totalRows = tasksRows
+				s.align()
+			}, nil
+		})
 	}
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -50,6 +51,7 @@ type NodesWidget struct {
 	filteredRows         []*NodeRow
 	totalRows            []*NodeRow
 	filterPattern        string
+	search               appui.RowSearch
 	header               *termui.TableHeader
 	selectedIndex        int
 	x, y                 int
@@ -60,11 +62,13 @@ type NodesWidget struct {
 	title                *termui.MarkupPar
 	totalMemory          int64
 	totalCPU             int
+	loader               *appui.AsyncLoader
 	sync.RWMutex
 }
 
-//NewNodesWidget creates a NodesWidget
-func NewNodesWidget(swarmClient docker.SwarmAPI, y int) *NodesWidget {
+//NewNodesWidget creates a NodesWidget that starts filtered and sorted as
+//the given options tell
+func NewNodesWidget(swarmClient docker.SwarmAPI, y int, opts appui.ListOptions) *NodesWidget {
 	w := NodesWidget{
 		swarmClient: swarmClient,
 		header:      defaultNodeTableHeader,
@@ -72,6 +76,11 @@ func NewNodesWidget(swarmClient docker.SwarmAPI, y int) *NodesWidget {
 		height:      appui.MainScreenAvailableHeight(),
 		width:       ui.ActiveScreen.Dimensions.Width,
 		sortMode:    docker.SortByNodeName}
+	w.filterPattern = opts.Filter
+	if opts.SortMode != docker.NoSort {
+		w.sortMode = opts.SortMode
+	}
+	w.loader = appui.NewAsyncLoader(&w)
 	appui.RegisterWidget(docker.NodeSource, &w)
 	return &w
 }
@@ -87,10 +96,10 @@ func (s *NodesWidget) Buffer() gizaktermui.Buffer {
 		var filter string
 		if s.filterPattern != "" {
 			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+				"<b><blue> | Active filter: </><yellow>%s</></> ", appui.FilterText(s.filterPattern))
 		}
 
-		widgetHeader := appui.WidgetHeader("Nodes", s.RowCount(), filter)
+		widgetHeader := appui.WidgetHeader("Nodes", s.RowCount(), filter+s.loader.HeaderDetails())
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -111,6 +120,7 @@ func (s *NodesWidget) Buffer() gizaktermui.Buffer {
 				nodeRow.Highlighted()
 			}
 			buf.Merge(nodeRow.Buffer())
+			s.search.Highlight(buf, nodeRow)
 		}
 	}
 	return buf
@@ -123,32 +133,63 @@ func (s *NodesWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
-//Mount prepares this widget for rendering
+//Search moves the cursor to the first node, starting from the selected
+//one, that matches the given pattern. Returns false if no node matches.
+func (s *NodesWidget) Search(pattern string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Search(pattern, len(s.filteredRows), s.filterableRow)
+}
+
+//SearchNext moves the cursor to the next node that matches the last pattern searched
+func (s *NodesWidget) SearchNext() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Next(len(s.filteredRows), s.filterableRow)
+}
+
+//SearchPrevious moves the cursor to the previous node that matches the last pattern searched
+func (s *NodesWidget) SearchPrevious() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Previous(len(s.filteredRows), s.filterableRow)
+}
+
+//Mount prepares this widget for rendering, nodes are loaded in the background
 func (s *NodesWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
+		s.mounted = true
 		swarmClient := s.swarmClient
-		if nodes, err := swarmClient.Nodes(); err == nil {
-			docker.SortNodes(nodes, s.sortMode)
+		sortMode := s.sortMode
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			nodes, err := swarmClient.Nodes()
+			if err != nil {
+				return nil, err
+			}
+			docker.SortNodes(nodes, sortMode)
 			var rows []*NodeRow
-			s.totalCPU = 0
-			s.totalMemory = 0
+			var totalCPU int
+			var totalMemory int64
 			for _, node := range nodes {
 				row := NewNodeRow(node, s.header)
 				rows = append(rows, row)
 				if cpu, err := strconv.Atoi(row.CPU.Text); err == nil {
-					s.totalCPU += cpu
+					totalCPU += cpu
 				}
-				s.totalMemory += node.Description.Resources.MemoryBytes
+				totalMemory += node.Description.Resources.MemoryBytes
 			}
-			s.totalRows = rows
-		}
-		addSwarmSpecs(s)
-		s.align()
-		s.mounted = true
+			return func() {
+				s.totalRows = rows
+				s.totalCPU = totalCPU
+				s.totalMemory = totalMemory
+				addSwarmSpecs(s)
+				s.align()
+			}, nil
+		})
 	}
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
@@ -170,6 +211,7 @@ func (s *NodesWidget) Unmount() error {
 	s.Lock()
 	defer s.Unlock()
 	s.mounted = false
+	s.loader.Cancel()
 	return nil
 
 }
@@ -179,6 +221,20 @@ func (s *NodesWidget) RowCount() int {
 	return len(s.filteredRows)
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *NodesWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *NodesWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return appui.SortModeTitle(nodeTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
 func (s *NodesWidget) Sort() {
 	s.RLock()
@@ -215,6 +271,11 @@ func (s *NodesWidget) align() {
 	}
 }
 
+//filterableRow returns the row of the filtered nodes at the given index
+func (s *NodesWidget) filterableRow(i int) appui.FilterableRow {
+	return s.filteredRows[i]
+}
+
 func (s *NodesWidget) filterRows() {
 
 	if s.filterPattern != "" {
@@ -319,11 +380,8 @@ func (s *NodesWidget) updateHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := appui.ColumnTitle(c.Text)
 		var header appui.SortableColumnHeader
-		if strings.Contains(colTitle, appui.DownArrow) {
-			colTitle = colTitle[appui.DownArrowLength:]
-		}
 		for _, h := range nodeTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -331,7 +389,7 @@ func (s *NodesWidget) updateHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = appui.DownArrow + colTitle
+			c.Text = appui.SortedColumnTitle(colTitle)
 		} else {
 			c.Text = colTitle
 		}
@@ -1,8 +1,12 @@
 package swarm
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/docker/docker/api/types/swarm"
+
+	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/mocks"
 	"github.com/moncho/dry/ui"
 )
@@ -11,7 +15,7 @@ func TestNodesWidgetCreation(t *testing.T) {
 	ui.ActiveScreen = &ui.Screen{
 		Dimensions: &ui.Dimensions{Height: 14, Width: 100},
 		Cursor:     ui.NewCursor()}
-	w := NewNodesWidget(&mocks.SwarmDockerDaemon{}, 1)
+	w := NewNodesWidget(&mocks.SwarmDockerDaemon{}, 1, appui.ListOptions{})
 	if w == nil {
 		t.Error("Swarm widget is nil")
 	}
@@ -29,16 +33,40 @@ func TestNodesWidgetMount(t *testing.T) {
 	ui.ActiveScreen = &ui.Screen{
 		Dimensions: &ui.Dimensions{Height: 14, Width: 100},
 		Cursor:     ui.NewCursor()}
-	w := NewNodesWidget(&mocks.SwarmDockerDaemon{}, 1)
+	w := NewNodesWidget(&mocks.SwarmDockerDaemon{}, 1, appui.ListOptions{})
 
 	if len(w.totalRows) != 0 {
 		t.Errorf("Swarm widget is not showing the expected number of totalRows. Got: %d", len(w.totalRows))
 	}
 
 	w.Mount()
+	w.loader.Wait()
 
 	if len(w.totalRows) != 1 {
 		t.Errorf("Swarm widget is not showing the expected number of totalRows. Got: %d", len(w.totalRows))
 	}
 
 }
+
+//unreachableSwarmDaemon fails to list the nodes of the swarm
+type unreachableSwarmDaemon struct {
+	*mocks.SwarmDockerDaemon
+}
+
+func (d unreachableSwarmDaemon) Nodes() ([]swarm.Node, error) {
+	return nil, errors.New("the swarm is unreachable")
+}
+
+func TestNodesWidgetMountError(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Dimensions: &ui.Dimensions{Height: 14, Width: 100},
+		Cursor:     ui.NewCursor()}
+	w := NewNodesWidget(unreachableSwarmDaemon{&mocks.SwarmDockerDaemon{}}, 1, appui.ListOptions{})
+
+	w.Mount()
+	w.loader.Wait()
+
+	if err := w.Mount(); err == nil {
+		t.Error("The error listing the nodes was not returned")
+	}
+}
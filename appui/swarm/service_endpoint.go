@@ -0,0 +1,62 @@
+package swarm
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/docker"
+)
+
+//ServiceEndpointDetails describes the endpoint of the given service: how it
+//is resolved, the ports it publishes and its virtual IP on each network. The
+//given names are the names of the networks, by ID, unknown networks are
+//shown by their ID.
+func ServiceEndpointDetails(service swarm.Service, networkNames map[string]string) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<white>%s</> (%s)\n\n", service.Spec.Name, docker.TruncateID(service.ID))
+
+	mode := swarm.ResolutionModeVIP
+	if service.Spec.EndpointSpec != nil && service.Spec.EndpointSpec.Mode != "" {
+		mode = service.Spec.EndpointSpec.Mode
+	}
+	fmt.Fprintf(&buf, "<blue>Endpoint mode:</> %s\n\n", mode)
+
+	ports := servicePorts(service)
+	if len(ports) == 0 {
+		buf.WriteString("The service publishes no ports.\n")
+	} else {
+		w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "PUBLISHED\tTARGET\tPROTOCOL\tMODE")
+		for _, p := range ports {
+			publishMode := p.PublishMode
+			if publishMode == "" {
+				publishMode = swarm.PortConfigPublishModeIngress
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", p.PublishedPort, p.TargetPort, p.Protocol, publishMode)
+		}
+		w.Flush()
+	}
+
+	buf.WriteString("\n")
+	if len(service.Endpoint.VirtualIPs) == 0 {
+		if mode == swarm.ResolutionModeDNSRR {
+			buf.WriteString("The service has no virtual IPs, its name resolves to the IPs of its tasks.\n")
+		} else {
+			buf.WriteString("The service has no virtual IPs.\n")
+		}
+		return buf.String()
+	}
+	w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NETWORK\tVIRTUAL IP")
+	for _, vip := range service.Endpoint.VirtualIPs {
+		name, ok := networkNames[vip.NetworkID]
+		if !ok {
+			name = docker.TruncateID(vip.NetworkID)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", name, vip.Addr)
+	}
+	w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,64 @@
+package swarm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestServiceEndpointDetails(t *testing.T) {
+	service := swarm.Service{
+		ID: "5sdnztdrfzc5i2cfpjdsblhs0",
+		Spec: swarm.ServiceSpec{
+			Annotations: swarm.Annotations{Name: "web"},
+			EndpointSpec: &swarm.EndpointSpec{
+				Ports: []swarm.PortConfig{
+					{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 80},
+				},
+			},
+		},
+		Endpoint: swarm.Endpoint{
+			Ports: []swarm.PortConfig{
+				{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 80, PublishedPort: 30000, PublishMode: swarm.PortConfigPublishModeIngress},
+				{Protocol: swarm.PortConfigProtocolUDP, TargetPort: 53, PublishedPort: 53, PublishMode: swarm.PortConfigPublishModeHost},
+			},
+			VirtualIPs: []swarm.EndpointVirtualIP{
+				{NetworkID: "ingressid", Addr: "10.255.0.5/16"},
+				{NetworkID: "unknownnetworkid", Addr: "10.0.1.2/24"},
+			},
+		},
+	}
+	got := ServiceEndpointDetails(service, map[string]string{"ingressid": "ingress"})
+	for _, want := range []string{
+		"<blue>Endpoint mode:</> vip",
+		"30000      80      tcp       ingress",
+		"53         53      udp       host",
+		"ingress       10.255.0.5/16",
+		"unknownnetw",
+		"10.0.1.2/24",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q on the endpoint details, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestServiceEndpointDetails_DNSRR(t *testing.T) {
+	service := swarm.Service{
+		Spec: swarm.ServiceSpec{
+			Annotations:  swarm.Annotations{Name: "db"},
+			EndpointSpec: &swarm.EndpointSpec{Mode: swarm.ResolutionModeDNSRR},
+		},
+	}
+	got := ServiceEndpointDetails(service, nil)
+	for _, want := range []string{
+		"<blue>Endpoint mode:</> dnsrr",
+		"The service publishes no ports.",
+		"its name resolves to the IPs of its tasks",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q on the endpoint details, got:\n%s", want, got)
+		}
+	}
+}
@@ -81,7 +81,7 @@ func serviceInfo(swarmClient docker.SwarmAPI, name string, service *swarm.Servic
 		},
 		{
 			ui.Blue("Networks:"), ui.Yellow(dryFormatter.FormatSwarmNetworks(service.Spec.TaskTemplate.Networks)),
-			ui.Blue("Ports:"), ui.Yellow(dryFormatter.FormatPorts(service.Spec.EndpointSpec.Ports)),
+			ui.Blue("Ports:"), ui.Yellow(dryFormatter.FormatServicePorts(servicePorts(*service))),
 		},
 		{
 			ui.Blue("Configs:"), ui.Yellow(
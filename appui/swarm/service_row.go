@@ -33,7 +33,7 @@ func NewServiceRow(service swarm.Service, serviceInfo ServiceListInfo, table dry
 		Replicas: drytermui.NewThemedParColumn(appui.DryTheme, serviceInfo.Replicas),
 		Image: drytermui.NewThemedParColumn(
 			appui.DryTheme, serviceImage(service)),
-		ServicePorts: drytermui.NewThemedParColumn(appui.DryTheme, dryformatter.FormatPorts(service.Spec.EndpointSpec.Ports)),
+		ServicePorts: drytermui.NewThemedParColumn(appui.DryTheme, dryformatter.FormatServicePorts(servicePorts(service))),
 	}
 	row.Height = 1
 	row.Table = table
@@ -61,6 +61,30 @@ func (row *ServiceRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.Name, row.Image, row.Mode}
 }
 
+//servicePorts returns the ports published by the given service, as the
+//service endpoint reports them, ports assigned by Docker included, or as
+//its spec declares them if the endpoint has none yet
+func servicePorts(service swarm.Service) []swarm.PortConfig {
+	if len(service.Endpoint.Ports) > 0 {
+		return service.Endpoint.Ports
+	}
+	if service.Spec.EndpointSpec != nil {
+		return service.Spec.EndpointSpec.Ports
+	}
+	return nil
+}
+
+//publishes returns true if the given service publishes the given port,
+//or sends to it the traffic of a published port
+func publishes(service swarm.Service, port uint32) bool {
+	for _, p := range servicePorts(service) {
+		if p.PublishedPort == port || p.TargetPort == port {
+			return true
+		}
+	}
+	return false
+}
+
 func serviceImage(service swarm.Service) string {
 	image := service.Spec.TaskTemplate.ContainerSpec.Image
 	digestMark := strings.LastIndex(image, "@")
@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"context"
 	"fmt"
 
 	gizaktermui "github.com/gizak/termui"
@@ -31,6 +32,7 @@ func NewServiceTasksWidget(swarmClient docker.SwarmAPI, y int) *ServiceTasksWidg
 			tableTitle:    createStackTableTitle(),
 			width:         ui.ActiveScreen.Dimensions.Width},
 	}
+	w.loader = appui.NewAsyncLoader(w)
 	return w
 }
 
@@ -42,15 +44,15 @@ func (s *ServiceTasksWidget) Buffer() gizaktermui.Buffer {
 	buf := gizaktermui.NewBuffer()
 	if s.mounted {
 		s.prepareForRendering()
-		buf.Merge(s.info.Buffer())
-		y += s.info.GetHeight()
-		var filter string
-		if s.filterPattern != "" {
-			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+		serviceName := s.serviceID
+		if s.info != nil {
+			buf.Merge(s.info.Buffer())
+			y += s.info.GetHeight()
+			serviceName = s.info.serviceName
 		}
+		filter := s.filterCaption()
 		s.tableTitle.Content(fmt.Sprintf(
-			"<b><blue>Service %s tasks: </><yellow>%d</></>", s.info.serviceName, s.RowCount()) + " " + filter)
+			"<b><blue>Service %s tasks: </><yellow>%d</></>", serviceName, s.RowCount()) + " " + filter + s.loader.HeaderDetails())
 
 		s.tableTitle.Y = y
 		buf.Merge(s.tableTitle.Buffer())
@@ -85,37 +87,44 @@ func (s *ServiceTasksWidget) ForService(serviceID string) {
 	s.serviceID = serviceID
 	s.mounted = false
 	s.sortMode = docker.SortByTaskService
+	s.info = nil
+	s.totalRows = nil
+	s.loader.Reset()
 
 }
 
-//Mount prepares this widget for rendering
+//Mount prepares this widget for rendering, tasks are loaded in the background
 func (s *ServiceTasksWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
-		service, err := s.swarmClient.Service(s.serviceID)
-		if err != nil {
-			return err
-		}
-		serviceInfo := NewServiceInfoWidget(s.swarmClient, service, s.y)
-		s.height = appui.MainScreenAvailableHeight() - serviceInfo.GetHeight()
-		s.info = serviceInfo
-
-		tasks, err := s.swarmClient.ServiceTasks(s.serviceID)
-		if err != nil {
-			return err
-		}
+		s.mounted = true
+		serviceID := s.serviceID
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			service, err := s.swarmClient.Service(serviceID)
+			if err != nil {
+				return nil, err
+			}
+			serviceInfo := NewServiceInfoWidget(s.swarmClient, service, s.y)
 
-		rows := make([]*TaskRow, len(tasks))
-		for i, task := range tasks {
-			rows[i] = NewTaskRow(s.swarmClient, task, s.header)
-		}
-		s.totalRows = rows
+			tasks, err := s.swarmClient.ServiceTasks(serviceID)
+			if err != nil {
+				return nil, err
+			}
 
-		s.align()
-		s.mounted = true
+			rows := make([]*TaskRow, len(tasks))
+			for i, task := range tasks {
+				rows[i] = NewTaskRow(s.swarmClient, task, s.header)
+			}
+			return func() {
+				s.height = appui.MainScreenAvailableHeight() - serviceInfo.GetHeight()
+				s.info = serviceInfo
+				s.totalRows = rows
+				s.align()
+			}, nil
+		})
 	}
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
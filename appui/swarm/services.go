@@ -1,8 +1,10 @@
 package swarm
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -16,13 +18,17 @@ import (
 	gizaktermui "github.com/gizak/termui"
 )
 
+//PortFilterPrefix is the prefix of the service filters on ports, i.e.
+//port:443 matches the services publishing port 443 or sending traffic to it
+const PortFilterPrefix = "port:"
+
 var defaultServiceTableHeader = serviceTableHeader()
 
 var serviceTableHeaders = []appui.SortableColumnHeader{
 	{Title: "NAME", Mode: docker.SortByServiceName},
 	{Title: "MODE", Mode: docker.NoSortService},
 	{Title: "REPLICAS", Mode: docker.NoSortService},
-	{Title: "SERVICE PORT(S)", Mode: docker.NoSortService},
+	{Title: "PORTS", Mode: docker.NoSortService},
 	{Title: "IMAGE", Mode: docker.SortByServiceImage},
 }
 
@@ -32,6 +38,8 @@ type ServicesWidget struct {
 	filteredRows         []*ServiceRow
 	totalRows            []*ServiceRow
 	filterPattern        string
+	search               appui.RowSearch
+	stack                string
 	header               *termui.TableHeader
 	selectedIndex        int
 	x, y                 int
@@ -39,11 +47,13 @@ type ServicesWidget struct {
 	startIndex, endIndex int
 	mounted              bool
 	sortMode             docker.SortMode
+	loader               *appui.AsyncLoader
 	sync.RWMutex
 }
 
-//NewServicesWidget creates a ServicesWidget
-func NewServicesWidget(swarmClient docker.SwarmAPI, y int) *ServicesWidget {
+//NewServicesWidget creates a ServicesWidget that starts filtered and sorted
+//as the given options tell
+func NewServicesWidget(swarmClient docker.SwarmAPI, y int, opts appui.ListOptions) *ServicesWidget {
 	w := ServicesWidget{
 		swarmClient:   swarmClient,
 		header:        defaultServiceTableHeader,
@@ -53,6 +63,11 @@ func NewServicesWidget(swarmClient docker.SwarmAPI, y int) *ServicesWidget {
 		height:        appui.MainScreenAvailableHeight(),
 		sortMode:      docker.SortByServiceName,
 		width:         ui.ActiveScreen.Dimensions.Width}
+	w.filterPattern = opts.Filter
+	if opts.SortMode != docker.NoSort {
+		w.sortMode = opts.SortMode
+	}
+	w.loader = appui.NewAsyncLoader(&w)
 
 	appui.RegisterWidget(docker.ServiceSource, &w)
 
@@ -71,10 +86,14 @@ func (s *ServicesWidget) Buffer() gizaktermui.Buffer {
 		var filter string
 		if s.filterPattern != "" {
 			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+				"<b><blue> | Active filter: </><yellow>%s</></> ", appui.FilterText(s.filterPattern))
 		}
 
-		widgetHeader := appui.WidgetHeader("Services", s.RowCount(), filter)
+		title := "Services"
+		if s.stack != "" {
+			title = fmt.Sprintf("Services of stack %s", s.stack)
+		}
+		widgetHeader := appui.WidgetHeader(title, s.RowCount(), filter+s.loader.HeaderDetails())
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -95,6 +114,7 @@ func (s *ServicesWidget) Buffer() gizaktermui.Buffer {
 				serviceRow.Highlighted()
 			}
 			buf.Merge(serviceRow.Buffer())
+			s.search.Highlight(buf, serviceRow)
 		}
 	}
 	return buf
@@ -107,22 +127,67 @@ func (s *ServicesWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
-//Mount prepares this widget for rendering
+//ForStack shows only the services of the given stack, or every service if
+//the given stack is empty
+func (s *ServicesWidget) ForStack(stack string) {
+	s.Lock()
+	defer s.Unlock()
+	s.stack = stack
+	s.filterPattern = ""
+}
+
+//Stack returns the stack whose services are shown, empty if services
+//are not being shown by stack
+func (s *ServicesWidget) Stack() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.stack
+}
+
+//Search moves the cursor to the first service, starting from the selected
+//one, that matches the given pattern. Returns false if no service matches.
+func (s *ServicesWidget) Search(pattern string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Search(pattern, len(s.filteredRows), s.filterableRow)
+}
+
+//SearchNext moves the cursor to the next service that matches the last pattern searched
+func (s *ServicesWidget) SearchNext() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Next(len(s.filteredRows), s.filterableRow)
+}
+
+//SearchPrevious moves the cursor to the previous service that matches the last pattern searched
+func (s *ServicesWidget) SearchPrevious() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Previous(len(s.filteredRows), s.filterableRow)
+}
+
+//Mount prepares this widget for rendering, services are loaded in the background
 func (s *ServicesWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
 		s.mounted = true
-		var rows []*ServiceRow
-		if services, servicesInfo, err := getServiceInfo(s.swarmClient); err == nil {
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			services, servicesInfo, err := getServiceInfo(s.swarmClient)
+			if err != nil {
+				return nil, err
+			}
+			var rows []*ServiceRow
 			for _, service := range services {
 				rows = append(rows, NewServiceRow(service, servicesInfo[service.ID], s.header))
 			}
-		}
-		s.totalRows = rows
+			return func() {
+				s.totalRows = rows
+				s.align()
+			}, nil
+		})
 	}
-	s.align()
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
@@ -143,6 +208,20 @@ func (s *ServicesWidget) RowCount() int {
 	return len(s.filteredRows)
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *ServicesWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *ServicesWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return appui.SortModeTitle(serviceTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
 //SortByServiceName -> SortByServiceImage -> SortByServiceName
 func (s *ServicesWidget) Sort() {
@@ -162,6 +241,7 @@ func (s *ServicesWidget) Unmount() error {
 	defer s.Unlock()
 
 	s.mounted = false
+	s.loader.Cancel()
 	return nil
 
 }
@@ -181,13 +261,21 @@ func (s *ServicesWidget) align() {
 
 }
 
+//filterableRow returns the row of the filtered services at the given index
+func (s *ServicesWidget) filterableRow(i int) appui.FilterableRow {
+	return s.filteredRows[i]
+}
+
 func (s *ServicesWidget) filterRows() {
 
-	if s.filterPattern != "" {
+	if s.filterPattern != "" || s.stack != "" {
 		var rows []*ServiceRow
 
 		for _, row := range s.totalRows {
-			if appui.RowFilters.ByPattern(s.filterPattern)(row) {
+			if s.stack != "" && row.service.Spec.Labels[docker.LabelNamespace] != s.stack {
+				continue
+			}
+			if s.matches(row) {
 				rows = append(rows, row)
 			}
 		}
@@ -197,6 +285,18 @@ func (s *ServicesWidget) filterRows() {
 	}
 }
 
+//matches returns true if the given row matches the filter pattern of the list
+func (s *ServicesWidget) matches(row *ServiceRow) bool {
+	switch {
+	case s.filterPattern == "":
+		return true
+	case strings.HasPrefix(s.filterPattern, PortFilterPrefix):
+		port, err := strconv.ParseUint(strings.TrimPrefix(s.filterPattern, PortFilterPrefix), 10, 32)
+		return err == nil && publishes(row.service, uint32(port))
+	}
+	return appui.RowFilters.ByPattern(s.filterPattern)(row)
+}
+
 func (s *ServicesWidget) calculateVisibleRows() {
 
 	count := s.RowCount()
@@ -252,11 +352,8 @@ func (s *ServicesWidget) updateHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := appui.ColumnTitle(c.Text)
 		var header appui.SortableColumnHeader
-		if strings.Contains(colTitle, appui.DownArrow) {
-			colTitle = colTitle[appui.DownArrowLength:]
-		}
 		for _, h := range serviceTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -264,7 +361,7 @@ func (s *ServicesWidget) updateHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = appui.DownArrow + colTitle
+			c.Text = appui.SortedColumnTitle(colTitle)
 		} else {
 			c.Text = colTitle
 		}
@@ -0,0 +1,106 @@
+package swarm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/docker"
+)
+
+func TestServicesWidget_ForStack(t *testing.T) {
+	service := func(name, stack string) *ServiceRow {
+		s := swarm.Service{
+			ID: name,
+			Spec: swarm.ServiceSpec{
+				Annotations: swarm.Annotations{Name: name},
+			},
+		}
+		if stack != "" {
+			s.Spec.Labels = map[string]string{docker.LabelNamespace: stack}
+		}
+		return NewServiceRow(s, ServiceListInfo{}, nil)
+	}
+	rows := []*ServiceRow{
+		service("app_web", "app"),
+		service("app_db", "app"),
+		service("monitoring_web", "monitoring"),
+		service("standalone", ""),
+	}
+	tests := []struct {
+		name   string
+		stack  string
+		filter string
+		want   []string
+	}{
+		{"all services", "", "", []string{"app_web", "app_db", "monitoring_web", "standalone"}},
+		{"services of a stack", "app", "", []string{"app_web", "app_db"}},
+		{"filtered services of a stack", "app", "web", []string{"app_web"}},
+		{"filtered services", "", "web", []string{"app_web", "monitoring_web"}},
+		{"unknown stack", "nope", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &ServicesWidget{totalRows: rows, filterPattern: "ignored"}
+			w.ForStack(tt.stack)
+			if w.Stack() != tt.stack {
+				t.Errorf("Stack() = %s, want %s", w.Stack(), tt.stack)
+			}
+			w.Filter(tt.filter)
+			w.filterRows()
+
+			var got []string
+			for _, row := range w.filteredRows {
+				got = append(got, row.Name.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filtered services = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServicesWidget_PortFilter(t *testing.T) {
+	service := func(name string, spec []swarm.PortConfig, endpoint []swarm.PortConfig) *ServiceRow {
+		s := swarm.Service{
+			ID: name,
+			Spec: swarm.ServiceSpec{
+				Annotations:  swarm.Annotations{Name: name},
+				EndpointSpec: &swarm.EndpointSpec{Ports: spec},
+			},
+			Endpoint: swarm.Endpoint{Ports: endpoint},
+		}
+		return NewServiceRow(s, ServiceListInfo{}, nil)
+	}
+	rows := []*ServiceRow{
+		service("web", nil, []swarm.PortConfig{{TargetPort: 8443, PublishedPort: 443}}),
+		service("proxy", []swarm.PortConfig{{TargetPort: 443, PublishedPort: 443, PublishMode: swarm.PortConfigPublishModeHost}}, nil),
+		service("api", nil, []swarm.PortConfig{{TargetPort: 443, PublishedPort: 30001}}),
+		service("db", nil, nil),
+	}
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"port:443", []string{"web", "proxy", "api"}},
+		{"port:30001", []string{"api"}},
+		{"port:8443", []string{"web"}},
+		{"port:5432", nil},
+		{"port:https", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			w := &ServicesWidget{totalRows: rows}
+			w.Filter(tt.filter)
+			w.filterRows()
+
+			var got []string
+			for _, row := range w.filteredRows {
+				got = append(got, row.Name.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filtered services = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"fmt"
 	"strconv"
 
 	termui "github.com/gizak/termui"
@@ -14,6 +15,7 @@ type StackRow struct {
 	stack        docker.Stack
 	Name         *drytermui.ParColumn
 	Services     *drytermui.ParColumn
+	Tasks        *drytermui.ParColumn
 	Orchestrator *drytermui.ParColumn
 	Networks     *drytermui.ParColumn
 	Configs      *drytermui.ParColumn
@@ -28,6 +30,7 @@ func NewStackRow(stack docker.Stack, table drytermui.Table) *StackRow {
 		stack:        stack,
 		Name:         drytermui.NewThemedParColumn(appui.DryTheme, stack.Name),
 		Services:     drytermui.NewThemedParColumn(appui.DryTheme, strconv.Itoa(stack.Services)),
+		Tasks:        drytermui.NewThemedParColumn(appui.DryTheme, stackTasks(stack)),
 		Orchestrator: drytermui.NewThemedParColumn(appui.DryTheme, stack.Orchestrator),
 		Networks:     drytermui.NewThemedParColumn(appui.DryTheme, strconv.Itoa(stack.Networks)),
 		Configs:      drytermui.NewThemedParColumn(appui.DryTheme, strconv.Itoa(stack.Configs)),
@@ -39,6 +42,7 @@ func NewStackRow(stack docker.Stack, table drytermui.Table) *StackRow {
 	row.Columns = []termui.GridBufferer{
 		row.Name,
 		row.Services,
+		row.Tasks,
 		row.Orchestrator,
 		row.Networks,
 		row.Configs,
@@ -47,6 +51,7 @@ func NewStackRow(stack docker.Stack, table drytermui.Table) *StackRow {
 	row.ParColumns = []*drytermui.ParColumn{
 		row.Name,
 		row.Services,
+		row.Tasks,
 		row.Orchestrator,
 		row.Networks,
 		row.Configs,
@@ -60,3 +65,8 @@ func NewStackRow(stack docker.Stack, table drytermui.Table) *StackRow {
 func (row *StackRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.Name, row.Orchestrator}
 }
+
+//stackTasks shows the running tasks of a stack against the desired ones
+func stackTasks(stack docker.Stack) string {
+	return fmt.Sprintf("%d/%d", stack.RunningTasks, stack.DesiredTasks)
+}
@@ -25,6 +25,8 @@ func TestStackRow(t *testing.T) {
 					Networks:     4,
 					Configs:      2,
 					Secrets:      300,
+					DesiredTasks: 6,
+					RunningTasks: 4,
 				},
 			},
 		},
@@ -40,6 +42,9 @@ func TestStackRow(t *testing.T) {
 			if row.Services.Text != strconv.Itoa(stack.Services) {
 				t.Errorf("Unexpected Services. Got %s, expected %d", row.Services.Text, stack.Services)
 			}
+			if row.Tasks.Text != "4/6" {
+				t.Errorf("Unexpected Tasks. Got %s, expected 4/6", row.Tasks.Text)
+			}
 			if row.Orchestrator.Text != stack.Orchestrator {
 				t.Errorf("Unexpected Orchestrator. Got %s, expected %s", row.Orchestrator.Text, stack.Orchestrator)
 			}
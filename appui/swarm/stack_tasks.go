@@ -1,6 +1,7 @@
 package swarm
 
 import (
+	"context"
 	"fmt"
 
 	gizaktermui "github.com/gizak/termui"
@@ -31,6 +32,7 @@ func NewStacksTasksWidget(swarmClient docker.SwarmAPI, y int) *StacksTasksWidget
 			tableTitle:    createStackTableTitle(),
 			width:         ui.ActiveScreen.Dimensions.Width},
 	}
+	w.loader = appui.NewAsyncLoader(&w)
 	return &w
 }
 
@@ -42,13 +44,9 @@ func (s *StacksTasksWidget) Buffer() gizaktermui.Buffer {
 	buf := gizaktermui.NewBuffer()
 	if s.mounted {
 		s.prepareForRendering()
-		var filter string
-		if s.filterPattern != "" {
-			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
-		}
+		filter := s.filterCaption()
 		s.tableTitle.Content(fmt.Sprintf(
-			"<b><blue>Stack %s tasks: </><yellow>%d</></>", s.stack, s.RowCount()) + " " + filter)
+			"<b><blue>Stack %s tasks: </><yellow>%d</></>", s.stack, s.RowCount()) + " " + filter + s.loader.HeaderDetails())
 
 		s.tableTitle.Y = y
 		buf.Merge(s.tableTitle.Buffer())
@@ -83,27 +81,34 @@ func (s *StacksTasksWidget) ForStack(stack string) {
 	s.stack = stack
 	s.mounted = false
 	s.sortMode = docker.SortByTaskService
+	s.totalRows = nil
+	s.loader.Reset()
 
 }
 
-//Mount prepares this widget for rendering
+//Mount prepares this widget for rendering, tasks are loaded in the background
 func (s *StacksTasksWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
 		s.mounted = true
-		var rows []*TaskRow
-		if tasks, err := s.swarmClient.StackTasks(s.stack); err == nil {
+		stack := s.stack
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			tasks, err := s.swarmClient.StackTasks(stack)
+			if err != nil {
+				return nil, err
+			}
+			var rows []*TaskRow
 			for _, task := range tasks {
 				rows = append(rows, NewTaskRow(s.swarmClient, task, s.header))
 			}
-			s.totalRows = rows
-		} else {
-			return err
-		}
+			return func() {
+				s.totalRows = rows
+				s.align()
+			}, nil
+		})
 	}
-	s.align()
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
@@ -1,9 +1,9 @@
 package swarm
 
 import (
+	"context"
 	"fmt"
 	"sort"
-	"strings"
 	"sync"
 
 	"github.com/moncho/dry/appui"
@@ -19,6 +19,7 @@ var defaultStackTableHeader = stackTableHeader()
 var stackTableHeaders = []appui.SortableColumnHeader{
 	{Title: "NAME", Mode: docker.SortByStackName},
 	{Title: "SERVICES", Mode: docker.NoSortStack},
+	{Title: "TASKS", Mode: docker.NoSortStack},
 	{Title: "ORCHESTRATOR", Mode: docker.NoSortStack},
 	{Title: "NETWORKS", Mode: docker.NoSortStack},
 	{Title: "CONFIGS", Mode: docker.NoSortStack},
@@ -31,6 +32,7 @@ type StacksWidget struct {
 	filteredRows         []*StackRow
 	totalRows            []*StackRow
 	filterPattern        string
+	search               appui.RowSearch
 	header               *termui.TableHeader
 	selectedIndex        int
 	offset               int
@@ -39,11 +41,13 @@ type StacksWidget struct {
 	startIndex, endIndex int
 	mounted              bool
 	sortMode             docker.SortMode
+	loader               *appui.AsyncLoader
 	sync.RWMutex
 }
 
-//NewStacksWidget creates a StacksWidget
-func NewStacksWidget(swarmClient docker.SwarmAPI, y int) *StacksWidget {
+//NewStacksWidget creates a StacksWidget that starts filtered and sorted as
+//the given options tell
+func NewStacksWidget(swarmClient docker.SwarmAPI, y int, opts appui.ListOptions) *StacksWidget {
 	w := StacksWidget{
 		swarmClient:   swarmClient,
 		header:        defaultStackTableHeader,
@@ -54,6 +58,11 @@ func NewStacksWidget(swarmClient docker.SwarmAPI, y int) *StacksWidget {
 		height:        appui.MainScreenAvailableHeight(),
 		sortMode:      docker.SortByServiceName,
 		width:         ui.ActiveScreen.Dimensions.Width}
+	w.filterPattern = opts.Filter
+	if opts.SortMode != docker.NoSort {
+		w.sortMode = opts.SortMode
+	}
+	w.loader = appui.NewAsyncLoader(&w)
 
 	appui.RegisterWidget(docker.ServiceSource, &w)
 
@@ -72,10 +81,10 @@ func (s *StacksWidget) Buffer() gizaktermui.Buffer {
 		var filter string
 		if s.filterPattern != "" {
 			filter = fmt.Sprintf(
-				"<b><blue> | Active filter: </><yellow>%s</></> ", s.filterPattern)
+				"<b><blue> | Active filter: </><yellow>%s</></> ", appui.FilterText(s.filterPattern))
 		}
 
-		widgetHeader := appui.WidgetHeader("Stacks", s.RowCount(), filter)
+		widgetHeader := appui.WidgetHeader("Stacks", s.RowCount(), filter+s.loader.HeaderDetails())
 		widgetHeader.Y = y
 		buf.Merge(widgetHeader.Buffer())
 		y += widgetHeader.GetHeight()
@@ -96,6 +105,7 @@ func (s *StacksWidget) Buffer() gizaktermui.Buffer {
 				stackRow.Highlighted()
 			}
 			buf.Merge(stackRow.Buffer())
+			s.search.Highlight(buf, stackRow)
 		}
 	}
 	return buf
@@ -108,24 +118,50 @@ func (s *StacksWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
-//Mount prepares this widget for rendering
+//Search moves the cursor to the first stack, starting from the selected
+//one, that matches the given pattern. Returns false if no stack matches.
+func (s *StacksWidget) Search(pattern string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Search(pattern, len(s.filteredRows), s.filterableRow)
+}
+
+//SearchNext moves the cursor to the next stack that matches the last pattern searched
+func (s *StacksWidget) SearchNext() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Next(len(s.filteredRows), s.filterableRow)
+}
+
+//SearchPrevious moves the cursor to the previous stack that matches the last pattern searched
+func (s *StacksWidget) SearchPrevious() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.search.Previous(len(s.filteredRows), s.filterableRow)
+}
+
+//Mount prepares this widget for rendering, stacks are loaded in the background
 func (s *StacksWidget) Mount() error {
 	s.Lock()
 	defer s.Unlock()
 	if !s.mounted {
 		s.mounted = true
-		var rows []*StackRow
-		if stacks, err := s.swarmClient.Stacks(); err == nil {
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			stacks, err := s.swarmClient.Stacks()
+			if err != nil {
+				return nil, err
+			}
+			var rows []*StackRow
 			for _, stack := range stacks {
 				rows = append(rows, NewStackRow(stack, s.header))
 			}
-			s.totalRows = rows
-		} else {
-			return err
-		}
+			return func() {
+				s.totalRows = rows
+				s.align()
+			}, nil
+		})
 	}
-	s.align()
-	return nil
+	return s.loader.Err()
 }
 
 //Name returns this widget name
@@ -146,6 +182,20 @@ func (s *StacksWidget) OnEvent(event appui.EventCommand) error {
 	return nil
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *StacksWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *StacksWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return appui.SortModeTitle(stackTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
 //SortByServiceName -> SortByServiceImage -> SortByServiceName
 func (s *StacksWidget) Sort() {
@@ -158,6 +208,7 @@ func (s *StacksWidget) Unmount() error {
 	defer s.Unlock()
 
 	s.mounted = false
+	s.loader.Cancel()
 	return nil
 
 }
@@ -177,6 +228,11 @@ func (s *StacksWidget) align() {
 
 }
 
+//filterableRow returns the row of the filtered stacks at the given index
+func (s *StacksWidget) filterableRow(i int) appui.FilterableRow {
+	return s.filteredRows[i]
+}
+
 func (s *StacksWidget) filterRows() {
 
 	if s.filterPattern != "" {
@@ -248,11 +304,8 @@ func (s *StacksWidget) updateHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := appui.ColumnTitle(c.Text)
 		var header appui.SortableColumnHeader
-		if strings.Contains(colTitle, appui.DownArrow) {
-			colTitle = colTitle[appui.DownArrowLength:]
-		}
 		for _, h := range stackTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -260,7 +313,7 @@ func (s *StacksWidget) updateHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = appui.DownArrow + colTitle
+			c.Text = appui.SortedColumnTitle(colTitle)
 		} else {
 			c.Text = colTitle
 		}
@@ -0,0 +1,177 @@
+package swarm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	gizaktermui "github.com/gizak/termui"
+	"github.com/moncho/dry/appui"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+)
+
+//swarmDaemon is what SwarmStatusWidget needs to know about the swarm
+type swarmDaemon interface {
+	Info() (types.Info, error)
+	SwarmJoinTokens() (docker.SwarmJoinTokens, error)
+}
+
+//SwarmStatusWidget shows whether the Docker host is part of a swarm and, if it
+//is a manager, the commands to join other hosts to the swarm
+type SwarmStatusWidget struct {
+	daemon        swarmDaemon
+	info          swarm.Info
+	tokens        docker.SwarmJoinTokens
+	x, y          int
+	height, width int
+	mounted       bool
+	loader        *appui.AsyncLoader
+	sync.RWMutex
+}
+
+//NewSwarmStatusWidget creates a SwarmStatusWidget
+func NewSwarmStatusWidget(daemon swarmDaemon, y int) *SwarmStatusWidget {
+	w := &SwarmStatusWidget{
+		daemon: daemon,
+		y:      y,
+		height: appui.MainScreenAvailableHeight(),
+		width:  ui.ActiveScreen.Dimensions.Width,
+	}
+	w.loader = appui.NewAsyncLoader(w)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (s *SwarmStatusWidget) Buffer() gizaktermui.Buffer {
+	s.Lock()
+	defer s.Unlock()
+	buf := gizaktermui.NewBuffer()
+	if !s.mounted {
+		return buf
+	}
+	y := s.y
+	widgetHeader := appui.WidgetHeader("Swarm nodes", s.info.Nodes, s.loader.HeaderDetails())
+	widgetHeader.Y = y
+	buf.Merge(widgetHeader.Buffer())
+	y += widgetHeader.GetHeight()
+
+	par := termui.NewParFromMarkupText(appui.DryTheme, s.status())
+	par.Border = false
+	par.X = s.x
+	par.Y = y
+	par.Width = ui.ActiveScreen.Dimensions.Width
+	par.Height = s.height - widgetHeader.GetHeight()
+	par.Bg = gizaktermui.Attribute(appui.DryTheme.Bg)
+	par.TextBgColor = gizaktermui.Attribute(appui.DryTheme.Bg)
+	par.TextFgColor = gizaktermui.Attribute(appui.DryTheme.Fg)
+	buf.Merge(par.Buffer())
+	return buf
+}
+
+//InSwarm returns true if the Docker host is an active node of a swarm
+func (s *SwarmStatusWidget) InSwarm() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.info.LocalNodeState == swarm.LocalNodeStateActive
+}
+
+//Manager returns true if the Docker host is a manager of the swarm
+func (s *SwarmStatusWidget) Manager() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.info.ControlAvailable
+}
+
+//Mount tells this widget to be ready for rendering, the state of the swarm is
+//loaded in the background
+func (s *SwarmStatusWidget) Mount() error {
+	s.Lock()
+	defer s.Unlock()
+	if !s.mounted {
+		s.mounted = true
+		s.loader.Load(func(ctx context.Context) (func(), error) {
+			info, err := s.daemon.Info()
+			if err != nil {
+				return nil, err
+			}
+			var tokens docker.SwarmJoinTokens
+			if info.Swarm.ControlAvailable {
+				tokens, err = s.daemon.SwarmJoinTokens()
+				if err != nil {
+					return nil, err
+				}
+			}
+			return func() {
+				s.info = info.Swarm
+				s.tokens = tokens
+			}, nil
+		})
+	}
+	return s.loader.Err()
+}
+
+//Name returns this widget name
+func (s *SwarmStatusWidget) Name() string {
+	return "SwarmStatusWidget"
+}
+
+//Tokens returns the tokens to join the swarm, empty unless the Docker host is a manager
+func (s *SwarmStatusWidget) Tokens() docker.SwarmJoinTokens {
+	s.RLock()
+	defer s.RUnlock()
+	return s.tokens
+}
+
+//Unmount tells this widget that it will not be rendering anymore
+func (s *SwarmStatusWidget) Unmount() error {
+	s.Lock()
+	defer s.Unlock()
+	s.mounted = false
+	s.loader.Cancel()
+	return nil
+}
+
+//status describes the swarm the Docker host is part of, if any
+func (s *SwarmStatusWidget) status() string {
+	buf := new(bytes.Buffer)
+	switch s.info.LocalNodeState {
+	case swarm.LocalNodeStateActive:
+	case "":
+		//not loaded yet
+		return ""
+	case swarm.LocalNodeStateInactive:
+		buf.WriteString("<yellow>This Docker host is not part of a swarm.</>\n\n")
+		buf.WriteString("Init a new swarm with this host as its first manager, or join an existing swarm\n")
+		buf.WriteString("using the address of one of its managers and a join token.\n")
+		return buf.String()
+	default:
+		fmt.Fprintf(buf, "<yellow>The swarm state of this Docker host is %s.</>\n", s.info.LocalNodeState)
+		if s.info.Error != "" {
+			fmt.Fprintf(buf, "<red>%s</>\n", s.info.Error)
+		}
+		return buf.String()
+	}
+
+	role := swarm.NodeRoleWorker
+	if s.info.ControlAvailable {
+		role = swarm.NodeRoleManager
+	}
+	fmt.Fprintf(buf, "<blue>Node ID:</> %s\n", s.info.NodeID)
+	fmt.Fprintf(buf, "<blue>Node address:</> %s\n", s.info.NodeAddr)
+	fmt.Fprintf(buf, "<blue>Role:</> %s\n", role)
+	fmt.Fprintf(buf, "<blue>Managers:</> %d\n\n", s.info.Managers)
+	if !s.info.ControlAvailable {
+		buf.WriteString("<darkgrey>Join tokens are only available on managers.</>\n")
+		return buf.String()
+	}
+	buf.WriteString("To add a worker to this swarm, run on the host:\n")
+	fmt.Fprintf(buf, "    <white>%s</>\n\n", s.tokens.JoinCommand(s.tokens.Worker))
+	buf.WriteString("To add a manager to this swarm, run on the host:\n")
+	fmt.Fprintf(buf, "    <white>%s</>\n", s.tokens.JoinCommand(s.tokens.Manager))
+	return buf.String()
+}
@@ -0,0 +1,85 @@
+package swarm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/docker"
+	"github.com/moncho/dry/ui"
+)
+
+type swarmDaemonMock struct {
+	info swarm.Info
+}
+
+func (d swarmDaemonMock) Info() (types.Info, error) {
+	return types.Info{Swarm: d.info}, nil
+}
+
+func (d swarmDaemonMock) SwarmJoinTokens() (docker.SwarmJoinTokens, error) {
+	return docker.SwarmJoinTokens{Worker: "SWMTKN-worker", Manager: "SWMTKN-manager", ManagerAddr: "10.0.0.1:2377"}, nil
+}
+
+func TestSwarmStatusWidget(t *testing.T) {
+	ui.ActiveScreen = &ui.Screen{
+		Dimensions: &ui.Dimensions{Height: 14, Width: 100},
+		Cursor:     ui.NewCursor()}
+	tests := []struct {
+		name        string
+		info        swarm.Info
+		inSwarm     bool
+		manager     bool
+		wantStatus  []string
+		wantNoToken bool
+	}{
+		{
+			"not in a swarm",
+			swarm.Info{LocalNodeState: swarm.LocalNodeStateInactive},
+			false, false,
+			[]string{"not part of a swarm"},
+			true,
+		},
+		{
+			"worker",
+			swarm.Info{LocalNodeState: swarm.LocalNodeStateActive, NodeID: "w1"},
+			true, false,
+			[]string{"w1", "worker", "only available on managers"},
+			true,
+		},
+		{
+			"manager",
+			swarm.Info{LocalNodeState: swarm.LocalNodeStateActive, NodeID: "m1", ControlAvailable: true},
+			true, true,
+			[]string{
+				"m1", "manager",
+				"docker swarm join --token SWMTKN-worker 10.0.0.1:2377",
+				"docker swarm join --token SWMTKN-manager 10.0.0.1:2377",
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewSwarmStatusWidget(swarmDaemonMock{tt.info}, 1)
+			if err := w.Mount(); err != nil {
+				t.Fatal(err)
+			}
+			w.loader.Wait()
+
+			if w.InSwarm() != tt.inSwarm || w.Manager() != tt.manager {
+				t.Errorf("InSwarm() = %v, Manager() = %v, want %v, %v", w.InSwarm(), w.Manager(), tt.inSwarm, tt.manager)
+			}
+			status := w.status()
+			for _, want := range tt.wantStatus {
+				if !strings.Contains(status, want) {
+					t.Errorf("status does not contain %q: %s", want, status)
+				}
+			}
+			if (w.Tokens().Worker == "") != tt.wantNoToken {
+				t.Errorf("unexpected tokens: %v", w.Tokens())
+			}
+		})
+	}
+}
@@ -8,12 +8,17 @@ import (
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/docker/formatter"
+	"github.com/moncho/dry/ui"
 	drytermui "github.com/moncho/dry/ui/termui"
 )
 
+//errorColumn is the position of the error column on the task table
+const errorColumn = 5
+
 //TaskRow is a Grid row showing runtime information about a task
 type TaskRow struct {
 	task         swarm.Task
+	err          string
 	ID           *drytermui.ParColumn
 	Name         *drytermui.ParColumn
 	Image        *drytermui.ParColumn
@@ -32,6 +37,7 @@ func NewTaskRow(swarmClient docker.SwarmAPI, task swarm.Task, table drytermui.Ta
 
 	row := &TaskRow{
 		task:         task,
+		err:          formatter.NewTaskStringer(swarmClient, task, false).Error(),
 		Name:         drytermui.NewThemedParColumn(appui.DryTheme, ts.Name()),
 		Image:        drytermui.NewThemedParColumn(appui.DryTheme, ts.Image()),
 		Node:         drytermui.NewThemedParColumn(appui.DryTheme, ts.NodeID()),
@@ -72,6 +78,23 @@ func (row *TaskRow) Buffer() termui.Buffer {
 	return buf
 }
 
+//SetWidth sets the width of this row, the error of the task is truncated to
+//the width of its column
+func (row *TaskRow) SetWidth(width int) {
+	row.Row.SetWidth(width)
+	if row.Table == nil {
+		return
+	}
+	if widths := row.Table.ColumnWidths(); len(widths) > errorColumn {
+		row.Error.Text = truncate(row.err, widths[errorColumn])
+	}
+}
+
+//Failed returns true if the task failed
+func (row *TaskRow) Failed() bool {
+	return row.task.Status.State == swarm.TaskStateFailed
+}
+
 //ColumnsForFilter returns the columns that are used to filter
 func (row *TaskRow) ColumnsForFilter() []*drytermui.ParColumn {
 	return []*drytermui.ParColumn{row.Name, row.Image, row.Node, row.CurrentState}
@@ -80,7 +103,7 @@ func (row *TaskRow) ColumnsForFilter() []*drytermui.ParColumn {
 //Highlighted marks this rows as being highlighted
 func (row *TaskRow) Highlighted() {
 	row.changeTextColor(
-		termui.Attribute(appui.DryTheme.Fg),
+		termui.Attribute(appui.DryTheme.CursorLineFg),
 		termui.Attribute(appui.DryTheme.CursorLineBg))
 }
 
@@ -100,7 +123,9 @@ func (row *TaskRow) changeTextColor(fg, bg termui.Attribute) {
 	row.Node.TextBgColor = bg
 	row.DesiredState.TextBgColor = bg
 	row.CurrentState.TextBgColor = bg
-	row.Error.TextFgColor = fg
+	if !row.Failed() {
+		row.Error.TextFgColor = fg
+	}
 	row.Error.TextBgColor = bg
 	row.Ports.TextFgColor = fg
 	row.Ports.TextBgColor = bg
@@ -118,5 +143,19 @@ func updateStateColumns(row *TaskRow) {
 	row.DesiredState.TextFgColor = color
 	row.CurrentState.TextFgColor = color
 	row.Error.TextFgColor = color
+	if row.Failed() {
+		row.Error.TextFgColor = termui.ColorRed
+	}
+}
 
+//truncate cuts the given text to the given width, an ellipsis marks that it was cut
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 || len(runes) <= width {
+		return text
+	}
+	if width == 1 {
+		return ui.EllipsisGlyph.String()
+	}
+	return string(runes[:width-1]) + ui.EllipsisGlyph.String()
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/gizak/termui"
 	"github.com/moncho/dry/docker/formatter"
 	"github.com/moncho/dry/mocks"
 )
@@ -50,3 +51,53 @@ func TestTaskRow(t *testing.T) {
 
 	}
 }
+
+type fixedWidthTable []int
+
+func (t fixedWidthTable) ColumnWidths() []int {
+	return t
+}
+
+func TestTaskRowError(t *testing.T) {
+	task := swarm.Task{
+		ID:        "task1",
+		ServiceID: "1",
+		NodeID:    "1",
+		Status: swarm.TaskStatus{
+			State: swarm.TaskStateFailed,
+			Err:   "task: non-zero exit (1)",
+		},
+	}
+	row := NewTaskRow(&mocks.SwarmDockerDaemon{}, task, fixedWidthTable{10, 10, 10, 10, 10, 12, 10})
+	row.SetWidth(100)
+
+	if row.Error.Text != "\"task: non-…" {
+		t.Errorf("Unexpected TaskRow error, got %s", row.Error.Text)
+	}
+	if row.Error.TextFgColor != termui.ColorRed {
+		t.Errorf("The error of a failed task is not red, got %v", row.Error.TextFgColor)
+	}
+	row.Highlighted()
+	if row.Error.TextFgColor != termui.ColorRed {
+		t.Errorf("The error of a highlighted failed task is not red, got %v", row.Error.TextFgColor)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"error", 10, "error"},
+		{"error", 5, "error"},
+		{"error", 4, "err…"},
+		{"error", 1, "…"},
+		{"error", 0, "error"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.text, tt.width); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
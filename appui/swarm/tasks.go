@@ -1,10 +1,12 @@
 package swarm
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/docker/docker/api/types/swarm"
 	gizaktermui "github.com/gizak/termui"
 	"github.com/moncho/dry/appui"
 	"github.com/moncho/dry/docker"
@@ -12,12 +14,22 @@ import (
 	"github.com/moncho/dry/ui/termui"
 )
 
+//NodeFilterPrefix is the prefix of the task filters on the name of the
+//node tasks run on, i.e. node:worker matches tasks on nodes named worker*
+const NodeFilterPrefix = "node:"
+
+//desiredStateFilters are the desired states tasks can be filtered by,
+//empty means that tasks are not filtered by their desired state
+var desiredStateFilters = []swarm.TaskState{"", swarm.TaskStateRunning, swarm.TaskStateShutdown}
+
 //TasksWidget shows a service's task information
 type TasksWidget struct {
 	header               *termui.TableHeader
 	filteredRows         []*TaskRow
 	totalRows            []*TaskRow
 	filterPattern        string
+	desiredState         swarm.TaskState
+	failedOnly           bool
 	height, width        int
 	mounted              bool
 	offset               int
@@ -27,6 +39,7 @@ type TasksWidget struct {
 	swarmClient          docker.SwarmAPI
 	tableTitle           *termui.MarkupPar
 	x, y                 int
+	loader               *appui.AsyncLoader
 	sync.RWMutex
 }
 
@@ -37,6 +50,28 @@ func (s *TasksWidget) Filter(filter string) {
 	s.filterPattern = filter
 }
 
+//ToggleDesiredStateFilter cycles through showing every task, only the tasks
+//meant to be running and only the tasks meant to be shut down
+func (s *TasksWidget) ToggleDesiredStateFilter() {
+	s.Lock()
+	defer s.Unlock()
+	for i, state := range desiredStateFilters {
+		if state == s.desiredState {
+			s.desiredState = desiredStateFilters[(i+1)%len(desiredStateFilters)]
+			return
+		}
+	}
+	s.desiredState = ""
+}
+
+//ToggleFailedFilter shows only the tasks that failed, or every task if they
+//were already filtered
+func (s *TasksWidget) ToggleFailedFilter() {
+	s.Lock()
+	defer s.Unlock()
+	s.failedOnly = !s.failedOnly
+}
+
 //OnEvent runs the given command
 func (s *TasksWidget) OnEvent(event appui.EventCommand) error {
 	if s.RowCount() > 0 {
@@ -50,8 +85,22 @@ func (s *TasksWidget) RowCount() int {
 	return len(s.filteredRows)
 }
 
+//ActiveFilter returns the filter applied to the list, empty if there is none
+func (s *TasksWidget) ActiveFilter() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.filterPattern
+}
+
+//SortedBy returns the title of the column the list is sorted by
+func (s *TasksWidget) SortedBy() string {
+	s.RLock()
+	defer s.RUnlock()
+	return appui.SortModeTitle(taskTableHeaders, s.sortMode)
+}
+
 //Sort rotates to the next sort mode.
-//SortByTaskService -> SortByTaskImage -> SortByTaskDesiredState -> SortByTaskState -> SortByTaskService
+//SortByTaskService -> SortByTaskImage -> SortByTaskNode -> SortByTaskDesiredState -> SortByTaskState -> SortByTaskService
 func (s *TasksWidget) Sort() {
 	s.Lock()
 	defer s.Unlock()
@@ -59,6 +108,8 @@ func (s *TasksWidget) Sort() {
 	case docker.SortByTaskService:
 		s.sortMode = docker.SortByTaskImage
 	case docker.SortByTaskImage:
+		s.sortMode = docker.SortByTaskNode
+	case docker.SortByTaskNode:
 		s.sortMode = docker.SortByTaskDesiredState
 	case docker.SortByTaskDesiredState:
 		s.sortMode = docker.SortByTaskState
@@ -73,6 +124,7 @@ func (s *TasksWidget) Unmount() error {
 	defer s.Unlock()
 
 	s.mounted = false
+	s.loader.Cancel()
 	return nil
 
 }
@@ -95,13 +147,31 @@ func (s *TasksWidget) align() {
 
 }
 
+//filterCaption describes the filters applied to the list, to be shown on its title
+func (s *TasksWidget) filterCaption() string {
+	var filters []string
+	if s.filterPattern != "" {
+		filters = append(filters, fmt.Sprintf("<blue>Active filter: </><yellow>%s</>", appui.FilterText(s.filterPattern)))
+	}
+	if s.desiredState != "" {
+		filters = append(filters, fmt.Sprintf("<blue>Desired state: </><yellow>%s</>", s.desiredState))
+	}
+	if s.failedOnly {
+		filters = append(filters, "<yellow>Failed only</>")
+	}
+	if len(filters) == 0 {
+		return ""
+	}
+	return "<b><blue> | </>" + strings.Join(filters, "<blue> | </>") + "</> "
+}
+
 func (s *TasksWidget) filterRows() {
 
-	if s.filterPattern != "" {
+	if s.filterPattern != "" || s.desiredState != "" || s.failedOnly {
 		var rows []*TaskRow
 
 		for _, row := range s.totalRows {
-			if appui.RowFilters.ByPattern(s.filterPattern)(row) {
+			if s.matches(row) {
 				rows = append(rows, row)
 			}
 		}
@@ -111,6 +181,24 @@ func (s *TasksWidget) filterRows() {
 	}
 }
 
+//matches returns true if the given row passes the filters of the list. Patterns
+//like node:worker match the tasks running on nodes whose name starts with worker.
+func (s *TasksWidget) matches(row *TaskRow) bool {
+	if s.desiredState != "" && row.task.DesiredState != s.desiredState {
+		return false
+	}
+	if s.failedOnly && row.task.Status.State != swarm.TaskStateFailed {
+		return false
+	}
+	switch {
+	case s.filterPattern == "":
+		return true
+	case strings.HasPrefix(s.filterPattern, NodeFilterPrefix):
+		return strings.HasPrefix(row.Node.Text, strings.TrimPrefix(s.filterPattern, NodeFilterPrefix))
+	}
+	return appui.RowFilters.ByPattern(s.filterPattern)(row)
+}
+
 func (s *TasksWidget) calculateVisibleRows() {
 
 	count := s.RowCount()
@@ -166,11 +254,8 @@ func (s *TasksWidget) updateHeader() {
 	sortMode := s.sortMode
 
 	for _, c := range s.header.Columns {
-		colTitle := c.Text
+		colTitle := appui.ColumnTitle(c.Text)
 		var header appui.SortableColumnHeader
-		if strings.Contains(colTitle, appui.DownArrow) {
-			colTitle = colTitle[appui.DownArrowLength:]
-		}
 		for _, h := range taskTableHeaders {
 			if colTitle == h.Title {
 				header = h
@@ -178,7 +263,7 @@ func (s *TasksWidget) updateHeader() {
 			}
 		}
 		if header.Mode == sortMode {
-			c.Text = appui.DownArrow + colTitle
+			c.Text = appui.SortedColumnTitle(colTitle)
 		} else {
 			c.Text = colTitle
 		}
@@ -215,6 +300,10 @@ func (s *TasksWidget) sortRows() {
 		sortAlg = func(i, j int) bool {
 			return rows[i].DesiredState.Text < rows[j].DesiredState.Text
 		}
+	case docker.SortByTaskNode:
+		sortAlg = func(i, j int) bool {
+			return rows[i].Node.Text < rows[j].Node.Text
+		}
 
 	}
 	sort.SliceStable(rows, sortAlg)
@@ -223,7 +312,7 @@ func (s *TasksWidget) sortRows() {
 var taskTableHeaders = []appui.SortableColumnHeader{
 	{Title: "NAME", Mode: docker.SortByTaskService},
 	{Title: "IMAGE", Mode: docker.SortByTaskImage},
-	{Title: "NODE", Mode: docker.NoSortTask},
+	{Title: "NODE", Mode: docker.SortByTaskNode},
 	{Title: "DESIRED STATE", Mode: docker.SortByTaskDesiredState},
 	{Title: "CURRENT STATE", Mode: docker.SortByTaskState},
 	{Title: "ERROR", Mode: docker.NoSortTask},
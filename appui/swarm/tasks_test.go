@@ -0,0 +1,76 @@
+package swarm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/moncho/dry/mocks"
+)
+
+func TestTasksWidgetFilters(t *testing.T) {
+	client := &mocks.SwarmDockerDaemon{}
+	header := taskTableHeader()
+	newRow := func(id, node string, desired, state swarm.TaskState) *TaskRow {
+		return NewTaskRow(client, swarm.Task{
+			ID:           id,
+			ServiceID:    "1",
+			NodeID:       node,
+			DesiredState: desired,
+			Status:       swarm.TaskStatus{State: state},
+		}, header)
+	}
+	rows := []*TaskRow{
+		newRow("running", "1", swarm.TaskStateRunning, swarm.TaskStateRunning),
+		newRow("failed", "10", swarm.TaskStateShutdown, swarm.TaskStateFailed),
+		newRow("shutdown", "2", swarm.TaskStateShutdown, swarm.TaskStateShutdown),
+	}
+
+	tests := []struct {
+		name          string
+		pattern       string
+		desiredToggle int
+		failedOnly    bool
+		want          []string
+		wantCaption   []string
+	}{
+		{"no filters", "", 0, false, []string{"running", "failed", "shutdown"}, nil},
+		{"desired state running", "", 1, false, []string{"running"}, []string{"Desired state", "running"}},
+		{"desired state shutdown", "", 2, false, []string{"failed", "shutdown"}, []string{"Desired state", "shutdown"}},
+		{"desired state toggled back to all", "", 3, false, []string{"running", "failed", "shutdown"}, nil},
+		{"failed only", "", 0, true, []string{"failed"}, []string{"Failed only"}},
+		{"node prefix", "node:Node1", 0, false, []string{"running", "failed"}, []string{"node:Node1"}},
+		{"node prefix and failed", "node:Node1", 0, true, []string{"failed"}, []string{"node:Node1", "Failed only"}},
+		{"node prefix without matches", "node:Node3", 0, false, nil, []string{"node:Node3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &TasksWidget{totalRows: rows}
+			w.Filter(tt.pattern)
+			for i := 0; i < tt.desiredToggle; i++ {
+				w.ToggleDesiredStateFilter()
+			}
+			if tt.failedOnly {
+				w.ToggleFailedFilter()
+			}
+			w.filterRows()
+
+			var got []string
+			for _, row := range w.filteredRows {
+				got = append(got, row.task.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("filtered tasks = %v, want %v", got, tt.want)
+			}
+			caption := w.filterCaption()
+			if len(tt.wantCaption) == 0 && caption != "" {
+				t.Errorf("unexpected filter caption: %s", caption)
+			}
+			for _, want := range tt.wantCaption {
+				if !strings.Contains(caption, want) {
+					t.Errorf("filter caption %q does not contain %q", caption, want)
+				}
+			}
+		})
+	}
+}
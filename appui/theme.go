@@ -1,19 +1,30 @@
 package appui
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
 	"github.com/gizak/termui"
 	"github.com/moncho/dry/ui"
 )
 
-const (
+var (
 	//Running is the color used to identify a running element (e.g container, task)
 	Running = termui.Attribute(ui.Color108)
 	//NotRunning is the color used to identify a non-running element
 	NotRunning = termui.Attribute(ui.Color161)
+	//Paused is the color used to identify a paused element
+	Paused = termui.Attribute(ui.Color179)
+	//Alerted is the color used to identify an element with an active alert
+	Alerted = termui.Attribute(ui.Color196)
 )
 
 //Default16 default theme for 16-color mode
 var Default16 = &ui.ColorTheme{
+	Name:         "default16",
 	Fg:           ui.ColorWhite,
 	Bg:           ui.ColorBlack,
 	DarkBg:       ui.ColorBlack,
@@ -25,11 +36,24 @@ var Default16 = &ui.ColorTheme{
 	Info:         ui.ColorWhite,
 	Cursor:       ui.ColorRed,
 	Selected:     ui.ColorPurple,
-	Header:       ui.ColorLime,
-	Footer:       ui.ColorLime}
+	Header:       ui.ColorBlack,
+	Footer:       ui.ColorLime,
+	ListItem:     ui.ColorWhite,
+	CursorLineFg: ui.ColorWhite,
+	CursorLineBg: ui.ColorBlue,
+	TableHeader:  ui.ColorWhite,
+	Running:      ui.ColorGreen,
+	NotRunning:   ui.ColorRed,
+	Paused:       ui.ColorYellow,
+	Alerted:      ui.ColorRed,
+	Inactive:     ui.ColorGray,
+	UsageLow:     ui.ColorGreen,
+	UsageMedium:  ui.ColorYellow,
+	UsageHigh:    ui.ColorRed}
 
 //Black256 black bg theme for 256-color mode
 var Black256 = &ui.ColorTheme{
+	Name:         "black",
 	Fg:           ui.Color255,
 	Bg:           ui.ColorBlack,
 	DarkBg:       ui.ColorBlack,
@@ -41,11 +65,24 @@ var Black256 = &ui.ColorTheme{
 	Info:         ui.Color144,
 	Cursor:       ui.Color161,
 	Selected:     ui.Color168,
-	Header:       ui.Color25,
-	Footer:       ui.Color25}
+	Header:       ui.ColorBlack,
+	Footer:       ui.Color25,
+	ListItem:     ui.Color181,
+	CursorLineFg: ui.Color255,
+	CursorLineBg: ui.Color25,
+	TableHeader:  ui.ColorGray,
+	Running:      ui.Color108,
+	NotRunning:   ui.Color161,
+	Paused:       ui.Color179,
+	Alerted:      ui.Color196,
+	Inactive:     ui.Color244,
+	UsageLow:     ui.Color23,
+	UsageMedium:  ui.Color131,
+	UsageHigh:    ui.Color161}
 
 //Dark256 dark theme for 256-color mode
 var Dark256 = &ui.ColorTheme{
+	Name:         "dark",
 	Fg:           ui.Color255,
 	Bg:           ui.Color234,
 	DarkBg:       ui.ColorBlack,
@@ -57,13 +94,24 @@ var Dark256 = &ui.ColorTheme{
 	Info:         ui.Color144,
 	Cursor:       ui.Color161,
 	Selected:     ui.Color168,
-	Header:       ui.Color25,
+	Header:       ui.Color234,
 	Footer:       ui.Color25,
 	ListItem:     ui.Color181,
-	CursorLineBg: ui.Color25}
+	CursorLineFg: ui.Color255,
+	CursorLineBg: ui.Color25,
+	TableHeader:  ui.ColorGray,
+	Running:      ui.Color108,
+	NotRunning:   ui.Color161,
+	Paused:       ui.Color179,
+	Alerted:      ui.Color196,
+	Inactive:     ui.Color244,
+	UsageLow:     ui.Color23,
+	UsageMedium:  ui.Color131,
+	UsageHigh:    ui.Color161}
 
 //Light256 light theme for 256-color mode
 var Light256 = &ui.ColorTheme{
+	Name:         "light",
 	Fg:           ui.Color241,
 	Bg:           ui.Color231,
 	DarkBg:       ui.Color251,
@@ -75,21 +123,228 @@ var Light256 = &ui.ColorTheme{
 	Info:         ui.Color101,
 	Cursor:       ui.Color161,
 	Selected:     ui.Color168,
-	Header:       ui.Color31,
-	Footer:       ui.Color31}
-
-//DryTheme is the active theme for dry
-var DryTheme = Dark256
-
-//ColorThemes holds the list of dry color themes
-var ColorThemes = []*ui.ColorTheme{Black256, Dark256}
-
-//RotateColorTheme changes the color theme to the next one in the
-//rotation order.
-func RotateColorTheme() {
-	if DryTheme == ColorThemes[0] {
-		DryTheme = ColorThemes[1]
-	} else {
-		DryTheme = ColorThemes[0]
+	Header:       ui.Color231,
+	Footer:       ui.Color31,
+	ListItem:     ui.Color238,
+	CursorLineFg: ui.Color235,
+	CursorLineBg: ui.Color189,
+	TableHeader:  ui.Color25,
+	Running:      ui.Color29,
+	NotRunning:   ui.Color161,
+	Paused:       ui.Color136,
+	Alerted:      ui.Color197,
+	Inactive:     ui.Color249,
+	UsageLow:     ui.Color29,
+	UsageMedium:  ui.Color131,
+	UsageHigh:    ui.Color161,
+	Tags: map[string]ui.Color{
+		"white":  ui.Color237,
+		"blue":   ui.Color25,
+		"yellow": ui.Color95,
+		"green":  ui.Color29,
+		"cyan0":  ui.Color96,
+		"grey":   ui.Color242,
+		"grey2":  ui.Color242,
+	}}
+
+//HighContrast256 high-contrast theme for 256-color mode
+var HighContrast256 = &ui.ColorTheme{
+	Name:         "high-contrast",
+	Fg:           ui.Color232,
+	Bg:           ui.Color17,
+	DarkBg:       ui.Color17,
+	Prompt:       ui.Color52,
+	Key:          ui.Color47,
+	Current:      ui.Color232,
+	CurrentMatch: ui.Color12,
+	Spinner:      ui.Color12,
+	Info:         ui.Color232,
+	Cursor:       ui.Color12,
+	Selected:     ui.Color12,
+	Header:       ui.Color17,
+	Footer:       ui.Color12,
+	ListItem:     ui.Color232,
+	CursorLineFg: ui.Color17,
+	CursorLineBg: ui.Color12,
+	TableHeader:  ui.Color12,
+	Running:      ui.Color47,
+	NotRunning:   ui.Color197,
+	Paused:       ui.Color226,
+	Alerted:      ui.Color202,
+	Inactive:     ui.Color250,
+	UsageLow:     ui.Color47,
+	UsageMedium:  ui.Color12,
+	UsageHigh:    ui.Color197,
+	Tags: map[string]ui.Color{
+		"white":    ui.Color232,
+		"blue":     ui.Color52,
+		"yellow":   ui.Color12,
+		"green":    ui.Color47,
+		"cyan0":    ui.Color52,
+		"red":      ui.Color197,
+		"red00":    ui.Color197,
+		"grey":     ui.Color232,
+		"grey2":    ui.Color232,
+		"darkgrey": ui.Color17,
+	}}
+
+//Monochrome theme for terminals with limited color support, it only
+//uses the default colors of the terminal and text attributes
+var Monochrome = &ui.ColorTheme{
+	Name:         "monochrome",
+	Cursor:       ui.AttrReverse,
+	Selected:     ui.AttrReverse,
+	CursorLineFg: ui.AttrReverse,
+	TableHeader:  ui.AttrBold,
+	NotRunning:   ui.AttrBold,
+	Paused:       ui.AttrUnderline,
+	Alerted:      ui.AttrBold | ui.AttrUnderline,
+	UsageHigh:    ui.AttrBold,
+	Tags: map[string]ui.Color{
+		"black":    ui.ColorBlack,
+		"red":      ui.AttrBold,
+		"red00":    ui.AttrBold,
+		"green":    ui.ColorBlack,
+		"yellow":   ui.AttrBold,
+		"blue":     ui.ColorBlack,
+		"magenta":  ui.ColorBlack,
+		"cyan":     ui.ColorBlack,
+		"cyan0":    ui.ColorBlack,
+		"white":    ui.AttrBold,
+		"grey":     ui.ColorBlack,
+		"grey2":    ui.ColorBlack,
+		"darkgrey": ui.ColorBlack,
+	}}
+
+//DryTheme is the active theme for dry, widgets keep a reference to
+//it so changing the active theme changes its values, not the reference
+var DryTheme = Dark256.Copy()
+
+//ColorThemes holds the list of dry color themes, in rotation order
+var ColorThemes = []*ui.ColorTheme{Dark256, Light256, HighContrast256, Monochrome}
+
+var themeLock sync.Mutex
+
+//pendingTheme is the theme set to be the active one on the next render
+var pendingTheme *ui.ColorTheme
+
+//themeName is the name of the active theme, or of the pending one if
+//there is one
+var themeName = DryTheme.Name
+
+//ColorThemeByName returns the color theme with the given name, nil if
+//there is no such theme
+func ColorThemeByName(name string) *ui.ColorTheme {
+	themeLock.Lock()
+	defer themeLock.Unlock()
+	return colorThemeByName(name)
+}
+
+//AddColorTheme adds the given theme to the color themes, replacing the
+//theme with the same name if there is one
+func AddColorTheme(theme *ui.ColorTheme) {
+	themeLock.Lock()
+	defer themeLock.Unlock()
+	for i, t := range ColorThemes {
+		if t.Name == theme.Name {
+			ColorThemes[i] = theme
+			return
+		}
+	}
+	ColorThemes = append(ColorThemes, theme)
+}
+
+//SetColorTheme sets the given theme to be the active one, it is made active
+//by ApplyColorTheme
+func SetColorTheme(theme *ui.ColorTheme) {
+	themeLock.Lock()
+	defer themeLock.Unlock()
+	pendingTheme = theme
+	themeName = theme.Name
+}
+
+//RotateColorTheme sets the next theme in the rotation order to be the
+//active one, the new theme is returned. It is made active by ApplyColorTheme.
+func RotateColorTheme() *ui.ColorTheme {
+	themeLock.Lock()
+	defer themeLock.Unlock()
+	next := ColorThemes[0]
+	for i, theme := range ColorThemes {
+		if theme.Name == themeName && i+1 < len(ColorThemes) {
+			next = ColorThemes[i+1]
+			break
+		}
 	}
+	pendingTheme = next
+	themeName = next.Name
+	return next
+}
+
+//ColorThemeName returns the name of the active theme, or of the theme set
+//to be the active one
+func ColorThemeName() string {
+	themeLock.Lock()
+	defer themeLock.Unlock()
+	return themeName
+}
+
+//ApplyColorTheme makes the theme set to be the active one, if there is one,
+//the active theme. DryTheme and the state colors are read while rendering,
+//so it must be called on the render path with nothing being rendered.
+//Returns true if the active theme changed.
+func ApplyColorTheme() bool {
+	themeLock.Lock()
+	defer themeLock.Unlock()
+	if pendingTheme == nil {
+		return false
+	}
+	setColorTheme(pendingTheme)
+	pendingTheme = nil
+	return true
+}
+
+//LoadColorTheme reads a color theme in JSON format from the given reader. The
+//elements not found are taken from the theme named as the "base" element, dark
+//if not given.
+func LoadColorTheme(r io.Reader) (*ui.ColorTheme, error) {
+	var spec struct {
+		Base string `json:"base"`
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+	if spec.Base == "" {
+		spec.Base = Dark256.Name
+	}
+	base := ColorThemeByName(spec.Base)
+	if base == nil {
+		return nil, fmt.Errorf("unknown base theme %q", spec.Base)
+	}
+	theme := base.Copy()
+	theme.Name = "custom"
+	if err := json.Unmarshal(b, theme); err != nil {
+		return nil, err
+	}
+	return theme, nil
+}
+
+func colorThemeByName(name string) *ui.ColorTheme {
+	for _, theme := range ColorThemes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+	return nil
+}
+
+func setColorTheme(theme *ui.ColorTheme) {
+	*DryTheme = *theme.Copy()
+	Running = termui.Attribute(theme.Running)
+	NotRunning = termui.Attribute(theme.NotRunning)
+	Paused = termui.Attribute(theme.Paused)
+	Alerted = termui.Attribute(theme.Alerted)
 }
@@ -0,0 +1,80 @@
+package appui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+)
+
+func TestLoadColorTheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		wantErr  bool
+		wantBase *ui.ColorTheme
+		wantBg   ui.Color
+		wantBlue ui.Color
+	}{
+		{"dark by default", `{"bg": 16}`, false, Dark256, ui.Color16, 0},
+		{"on top of another theme", `{"base": "light", "tags": {"blue": 18}}`, false, Light256, Light256.Bg, ui.Color18},
+		{"unknown base theme", `{"base": "pink"}`, true, nil, 0, 0},
+		{"invalid json", `{"bg": "red"}`, true, nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := LoadColorTheme(strings.NewReader(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadColorTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if theme.Name != "custom" {
+				t.Errorf("Unexpected theme name: %s", theme.Name)
+			}
+			if theme.Bg != tt.wantBg {
+				t.Errorf("Unexpected bg, got %d, want %d", theme.Bg, tt.wantBg)
+			}
+			if theme.Fg != tt.wantBase.Fg || theme.Running != tt.wantBase.Running {
+				t.Error("Elements not found were not taken from the base theme")
+			}
+			if theme.Tags["blue"] != tt.wantBlue {
+				t.Errorf("Unexpected blue tag color, got %d, want %d", theme.Tags["blue"], tt.wantBlue)
+			}
+		})
+	}
+	if Light256.Tags["blue"] != ui.Color25 {
+		t.Error("Loading a theme changed its base theme")
+	}
+}
+
+func TestRotateColorTheme(t *testing.T) {
+	defer func() {
+		SetColorTheme(Dark256)
+		ApplyColorTheme()
+	}()
+	SetColorTheme(Dark256)
+	ApplyColorTheme()
+	rotation := append([]*ui.ColorTheme{}, ColorThemes[1:]...)
+	rotation = append(rotation, ColorThemes[0])
+	for _, want := range rotation {
+		previous := DryTheme.Name
+		if got := RotateColorTheme(); got != want {
+			t.Fatalf("Unexpected theme, got %s, want %s", got.Name, want.Name)
+		}
+		if DryTheme.Name != previous || ColorThemeName() != want.Name {
+			t.Errorf("The theme was changed before being applied, active: %s, set: %s", DryTheme.Name, ColorThemeName())
+		}
+		if !ApplyColorTheme() || ApplyColorTheme() {
+			t.Errorf("The theme %s was not applied once", want.Name)
+		}
+		if DryTheme.Name != want.Name || DryTheme.Bg != want.Bg {
+			t.Errorf("Active theme is not %s", want.Name)
+		}
+		if Running != termui.Attribute(want.Running) {
+			t.Errorf("Running color was not changed for %s", want.Name)
+		}
+	}
+}
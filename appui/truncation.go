@@ -0,0 +1,71 @@
+package appui
+
+import "github.com/moncho/dry/ui"
+
+//TruncationMode tells how text that does not fit on a column is cut
+type TruncationMode int
+
+const (
+	//EllipsisTruncation cuts the text and marks that it was cut with an ellipsis
+	EllipsisTruncation TruncationMode = iota
+	//HardCutTruncation cuts the text at the column width
+	HardCutTruncation
+)
+
+//truncationMode is the truncation mode in use
+var truncationMode = EllipsisTruncation
+
+//String returns the name of this truncation mode
+func (m TruncationMode) String() string {
+	if m == HardCutTruncation {
+		return "hard cut"
+	}
+	return "ellipsis"
+}
+
+//ActiveTruncationMode returns the truncation mode in use
+func ActiveTruncationMode() TruncationMode {
+	return truncationMode
+}
+
+//SetTruncationMode sets the truncation mode to use
+func SetTruncationMode(mode TruncationMode) {
+	truncationMode = mode
+}
+
+//ToggleTruncationMode switches between cutting text with an ellipsis and
+//hard cutting it, the new mode is returned
+func ToggleTruncationMode() TruncationMode {
+	if truncationMode == EllipsisTruncation {
+		truncationMode = HardCutTruncation
+	} else {
+		truncationMode = EllipsisTruncation
+	}
+	return truncationMode
+}
+
+//truncateCell cuts the given text to the given width following the truncation mode in use
+func truncateCell(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 || len(runes) <= width {
+		return text
+	}
+	if truncationMode == HardCutTruncation {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + ui.EllipsisGlyph.String()
+}
+
+//wrapCell splits the given text in lines of the given width at most
+func wrapCell(text string, width int) []string {
+	runes := []rune(text)
+	if width <= 0 || len(runes) <= width {
+		return []string{text}
+	}
+	var lines []string
+	for len(runes) > width {
+		lines = append(lines, string(runes[:width]))
+		runes = runes[width:]
+	}
+	return append(lines, string(runes))
+}
@@ -0,0 +1,61 @@
+package appui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTruncateCell(t *testing.T) {
+	defer SetTruncationMode(EllipsisTruncation)
+	tests := []struct {
+		name  string
+		mode  TruncationMode
+		text  string
+		width int
+		want  string
+	}{
+		{"text that fits is not cut", EllipsisTruncation, "nginx", 5, "nginx"},
+		{"ellipsis", EllipsisTruncation, "nginx -g 'daemon off;'", 8, "nginx -…"},
+		{"hard cut", HardCutTruncation, "nginx -g 'daemon off;'", 8, "nginx -g"},
+		{"no width", EllipsisTruncation, "nginx", 0, "nginx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTruncationMode(tt.mode)
+			if got := truncateCell(tt.text, tt.width); got != tt.want {
+				t.Errorf("truncateCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleTruncationMode(t *testing.T) {
+	defer SetTruncationMode(EllipsisTruncation)
+	SetTruncationMode(EllipsisTruncation)
+	if mode := ToggleTruncationMode(); mode != HardCutTruncation {
+		t.Errorf("Unexpected truncation mode, expected: %s, got: %s", HardCutTruncation, mode)
+	}
+	if mode := ToggleTruncationMode(); mode != EllipsisTruncation {
+		t.Errorf("Unexpected truncation mode, expected: %s, got: %s", EllipsisTruncation, mode)
+	}
+}
+
+func TestWrapCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"text that fits", "nginx", 10, []string{"nginx"}},
+		{"text is split", "nginx -g daemon", 6, []string{"nginx ", "-g dae", "mon"}},
+		{"no width", "nginx", 0, []string{"nginx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapCell(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
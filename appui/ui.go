@@ -1,18 +1,13 @@
 package appui
 
 import (
+	"strings"
+
 	"github.com/moncho/dry/docker"
 	"github.com/moncho/dry/ui"
 )
 
 const (
-	//DownArrow character
-	DownArrow = string('\U00002193')
-	//DownArrowLength is the length of the DownArrow string
-	DownArrowLength = len(DownArrow)
-	//RightArrow character
-	RightArrow = string('\U00002192')
-
 	//MainScreenHeaderSize is the number of lines the header of the main screen uses
 	MainScreenHeaderSize = 5
 	//MainScreenFooterSize is the number of lines the footer of the main screen uses
@@ -33,3 +28,37 @@ func CalcItemWidth(width, items int) int {
 func MainScreenAvailableHeight() int {
 	return ui.ActiveScreen.Dimensions.Height - MainScreenHeaderSize - MainScreenFooterSize - 5
 }
+
+//SortedColumnTitle returns the given column title marked as the one that
+//sorts the list
+func SortedColumnTitle(title string) string {
+	return ui.DownArrowGlyph.String() + title
+}
+
+//ReverseSortedColumnTitle returns the given column title marked as the one
+//that sorts the list in descending order
+func ReverseSortedColumnTitle(title string) string {
+	return ui.UpArrowGlyph.String() + title
+}
+
+//SortColumnTitle returns the given column title marked as the one that
+//sorts the list, in descending order if told so
+func SortColumnTitle(title string, descending bool) string {
+	if descending {
+		return ReverseSortedColumnTitle(title)
+	}
+	return SortedColumnTitle(title)
+}
+
+//ColumnTitle returns the title of the column with the given header text,
+//without the mark of the column that sorts the list
+func ColumnTitle(text string) string {
+	for _, glyph := range []ui.Glyph{ui.DownArrowGlyph, ui.UpArrowGlyph} {
+		for _, mark := range glyph.Variants() {
+			if strings.HasPrefix(text, mark) {
+				return text[len(mark):]
+			}
+		}
+	}
+	return text
+}
@@ -20,6 +20,13 @@ type SortableWidget interface {
 	Sort()
 }
 
+//SearchableWidget interface defines how widgets search their rows
+type SearchableWidget interface {
+	Search(pattern string) bool
+	SearchNext() bool
+	SearchPrevious() bool
+}
+
 //AppWidget groups common behaviour for appui widgets
 type AppWidget interface {
 	termui.Widget
@@ -0,0 +1,349 @@
+package appui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	gtermui "github.com/gizak/termui"
+	"github.com/moncho/dry/ui"
+	"github.com/moncho/dry/ui/termui"
+	termbox "github.com/nsf/termbox-go"
+)
+
+const wizardOptions = "<b>[Enter]:<darkgrey>Next</> <b>[ArrowUp/ArrowDown]:<darkgrey>Move between steps</> <b>[Tab]:<darkgrey>Complete</> <b>[Esc]:<darkgrey>Cancel</>"
+
+//wizardStep is a step of a wizard, it asks for the value of a field
+type wizardStep struct {
+	field    string
+	label    string
+	help     string
+	value    []rune
+	validate func(string) error
+	//choices of a step whose value is picked from a fixed list
+	choices []string
+	//completions offered, on Tab, for the value being typed
+	completions []string
+}
+
+//wizard asks, step by step, for the values of a set of fields. The widgets
+//built on it turn the values into what they ask for.
+type wizard struct {
+	title     string
+	steps     []*wizardStep
+	current   int
+	cursorPos int
+	errField  string
+	err       error
+	canceled  bool
+	focused   bool
+	//check validates the values of every step once the last one is done,
+	//it returns the field whose value is not valid
+	check func(values map[string]string) (string, error)
+	//completion cycling state, reset on every edit
+	completionPrefix string
+	completionIndex  int
+	sync.RWMutex
+}
+
+func newWizard(title string, steps []*wizardStep, check func(map[string]string) (string, error)) *wizard {
+	w := &wizard{
+		title: title,
+		steps: steps,
+		check: check,
+	}
+	w.moveTo(0)
+	return w
+}
+
+//Buffer returns the content of this widget as a termui.Buffer
+func (w *wizard) Buffer() gtermui.Buffer {
+	w.RLock()
+	defer w.RUnlock()
+	lines, labelWidth := w.lines()
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	screenWidth := ui.ActiveScreen.Dimensions.Width
+	par := termui.NewParFromMarkupText(DryTheme, buf.String())
+	par.Width = screenWidth * 3 / 4
+	par.Height = len(lines) + 2
+	par.X = (screenWidth - par.Width) / 2
+	par.Y = (ui.ActiveScreen.Dimensions.Height - par.Height) / 2
+	par.Bg = gtermui.Attribute(DryTheme.Bg)
+	par.TextBgColor = gtermui.Attribute(DryTheme.Bg)
+	par.TextFgColor = gtermui.Attribute(DryTheme.Fg)
+	par.BorderLabel = fmt.Sprintf(" %s - step %d of %d ", w.title, w.current+1, len(w.steps))
+	par.BorderLabelFg = gtermui.Attribute(DryTheme.Fg)
+
+	if w.focused {
+		//+1 for the border, +2 for the step marker
+		termbox.SetCursor(par.X+1+2+labelWidth+w.cursorPos, par.Y+1+w.current)
+	}
+	return par.Buffer()
+}
+
+//Canceled returns true if the user canceled the wizard
+func (w *wizard) Canceled() bool {
+	w.RLock()
+	defer w.RUnlock()
+	return w.canceled
+}
+
+//Mount callback
+func (w *wizard) Mount() error {
+	return nil
+}
+
+//OnFocus starts handling the given events until the user either cancels
+//the wizard or goes past its last step with valid values. It is a blocking call.
+func (w *wizard) OnFocus(event ui.EventSource) error {
+	w.Lock()
+	w.focused = true
+	w.Unlock()
+	defer func() {
+		w.Lock()
+		w.focused = false
+		w.Unlock()
+		termbox.HideCursor()
+	}()
+	for ev := range event.Events {
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		w.Lock()
+		done := w.handleKey(ev)
+		w.Unlock()
+		if event.EventHandledCallback != nil {
+			if err := event.EventHandledCallback(ev); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+//SetError shows the given error as caused by the given field and moves
+//the wizard to the step of that field, so the user can fix it
+func (w *wizard) SetError(field string, err error) {
+	w.Lock()
+	defer w.Unlock()
+	w.errField = field
+	w.err = err
+	w.canceled = false
+	for i, step := range w.steps {
+		if step.field == field {
+			w.moveTo(i)
+			break
+		}
+	}
+}
+
+//Unmount callback
+func (w *wizard) Unmount() error {
+	return nil
+}
+
+//handleKey handles the given key event, it returns true once the wizard is done
+func (w *wizard) handleKey(ev termbox.Event) bool {
+	step := w.steps[w.current]
+	switch ev.Key {
+	case termbox.KeyEsc:
+		w.canceled = true
+		return true
+	case termbox.KeyEnter:
+		return w.next()
+	case termbox.KeyArrowUp:
+		w.moveTo(w.current - 1)
+	case termbox.KeyArrowDown:
+		w.moveTo(w.current + 1)
+	case termbox.KeyTab:
+		w.complete()
+	case termbox.KeyArrowLeft, termbox.KeyCtrlB:
+		if step.choices != nil {
+			w.cycleChoice(-1)
+		} else if w.cursorPos > 0 {
+			w.cursorPos--
+		}
+	case termbox.KeyArrowRight, termbox.KeyCtrlF:
+		if step.choices != nil {
+			w.cycleChoice(1)
+		} else if w.cursorPos < len(step.value) {
+			w.cursorPos++
+		}
+	case termbox.KeyHome, termbox.KeyCtrlA:
+		w.cursorPos = 0
+	case termbox.KeyEnd, termbox.KeyCtrlE:
+		w.cursorPos = len(step.value)
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if step.choices == nil && w.cursorPos > 0 {
+			step.value = append(step.value[:w.cursorPos-1], step.value[w.cursorPos:]...)
+			w.cursorPos--
+			w.edited()
+		}
+	case termbox.KeyDelete, termbox.KeyCtrlD:
+		if step.choices == nil && w.cursorPos < len(step.value) {
+			step.value = append(step.value[:w.cursorPos], step.value[w.cursorPos+1:]...)
+			w.edited()
+		}
+	case termbox.KeySpace:
+		w.insert(' ')
+	default:
+		if ev.Ch != 0 {
+			w.insert(ev.Ch)
+		}
+	}
+	return false
+}
+
+//next validates the value of the current step and moves to the next one,
+//past the last step every value is validated again. It returns true if
+//there are no steps left and every value is valid.
+func (w *wizard) next() bool {
+	step := w.steps[w.current]
+	if step.validate != nil {
+		if err := step.validate(string(step.value)); err != nil {
+			w.errField = step.field
+			w.err = err
+			return false
+		}
+	}
+	if w.errField == step.field {
+		w.errField = ""
+		w.err = nil
+	}
+	if w.current < len(w.steps)-1 {
+		w.moveTo(w.current + 1)
+		return false
+	}
+	if w.check != nil {
+		if field, err := w.check(w.values()); err != nil {
+			w.errField = field
+			w.err = err
+			for i, step := range w.steps {
+				if step.field == field {
+					w.moveTo(i)
+					break
+				}
+			}
+			return false
+		}
+	}
+	w.errField = ""
+	w.err = nil
+	return true
+}
+
+func (w *wizard) moveTo(step int) {
+	if step < 0 || step >= len(w.steps) {
+		return
+	}
+	w.current = step
+	w.cursorPos = len(w.steps[step].value)
+	w.edited()
+}
+
+func (w *wizard) insert(r rune) {
+	step := w.steps[w.current]
+	if step.choices != nil {
+		return
+	}
+	value := make([]rune, 0, len(step.value)+1)
+	value = append(value, step.value[:w.cursorPos]...)
+	value = append(value, r)
+	step.value = append(value, step.value[w.cursorPos:]...)
+	w.cursorPos++
+	w.edited()
+}
+
+//edited resets the completion cycle, the next Tab completes what is typed now
+func (w *wizard) edited() {
+	w.completionPrefix = string(w.steps[w.current].value)
+	w.completionIndex = -1
+}
+
+//complete replaces the value of the current step with the next completion
+//that starts with what was typed, choice steps move to the next choice
+func (w *wizard) complete() {
+	step := w.steps[w.current]
+	if step.choices != nil {
+		w.cycleChoice(1)
+		return
+	}
+	var candidates []string
+	for _, completion := range step.completions {
+		if strings.HasPrefix(completion, w.completionPrefix) {
+			candidates = append(candidates, completion)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	w.completionIndex = (w.completionIndex + 1) % len(candidates)
+	step.value = []rune(candidates[w.completionIndex])
+	w.cursorPos = len(step.value)
+}
+
+func (w *wizard) cycleChoice(delta int) {
+	step := w.steps[w.current]
+	current := 0
+	for i, choice := range step.choices {
+		if choice == string(step.value) {
+			current = i
+		}
+	}
+	next := (current + delta + len(step.choices)) % len(step.choices)
+	step.value = []rune(step.choices[next])
+	w.cursorPos = len(step.value)
+}
+
+//values returns the value of each step, by field, trimmed
+func (w *wizard) values() map[string]string {
+	values := make(map[string]string)
+	for _, step := range w.steps {
+		values[step.field] = strings.TrimSpace(string(step.value))
+	}
+	return values
+}
+
+//lines returns the lines of text of the wizard and the width of the
+//labels column
+func (w *wizard) lines() ([]string, int) {
+	labelWidth := 0
+	for _, step := range w.steps {
+		if l := len(step.label) + 2; l > labelWidth {
+			labelWidth = l
+		}
+	}
+	var lines []string
+	for i, step := range w.steps {
+		marker := "  "
+		if i == w.current {
+			marker = "<b>" + ui.PointerGlyph.String() + "</> "
+		}
+		label := fmt.Sprintf("%-*s", labelWidth, step.label+":")
+		switch {
+		case step.field == w.errField:
+			label = "<b><red>" + label + "</></>"
+		case i == w.current:
+			label = "<b><white>" + label + "</></>"
+		default:
+			label = "<darkgrey>" + label + "</>"
+		}
+		lines = append(lines, marker+label+string(step.value))
+	}
+	lines = append(lines, "", "<darkgrey>"+w.steps[w.current].help+"</>")
+	if w.err != nil {
+		lines = append(lines, "<red>"+w.err.Error()+"</>")
+	} else {
+		lines = append(lines, "")
+	}
+	return append(lines, "", wizardOptions), labelWidth
+}
@@ -3,11 +3,13 @@ package docker
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/image"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/swarm"
 )
 
@@ -19,10 +21,16 @@ type Container struct {
 
 //ContainerDaemon describes what is expected from the container daemon
 type ContainerDaemon interface {
+	CheckpointAPI
 	ContainerAPI
 	ImageAPI
 	NetworkAPI
+	PluginAPI
 	SwarmAPI
+	BuildCache() ([]*types.BuildCache, error)
+	BuildCachePrune(opts BuildCachePruneOptions) (uint64, error)
+	CallStats() []CallStats
+	APIVersion() string
 	DiskUsage() (types.DiskUsage, error)
 	DockerEnv() *Env
 	Events() (<-chan events.Message, chan<- struct{}, error)
@@ -32,42 +40,101 @@ type ContainerDaemon interface {
 	Ok() (bool, error)
 	Prune() (*PruneReport, error)
 	Rm(id string) error
+	RmWithVolumes(id string, volumes []string) (int, error)
 	Rmi(id string, force bool) ([]types.ImageDeleteResponseItem, error)
 	Refresh(notify func(error))
 	RemoveDanglingImages() (int, error)
 	RemoveNetwork(id string) error
+	Supports(f Feature) bool
 	Version() (*types.Version, error)
+	VolumeInspect(name string) (types.Volume, error)
 }
 
 //ContainerAPI defines the API for containers
 type ContainerAPI interface {
+	Attach(id string) (*ContainerAttachment, error)
+	CheckImageUpdates(ctx context.Context, containers []*Container, report func(ImageUpdate))
+	CommitContainer(id string, options CommitOptions) (string, error)
 	ContainerByID(id string) *Container
+	ContainerDiff(id string) ([]ContainerChange, error)
 	Containers(filter []ContainerFilter, mode SortMode) []*Container
+	ContainerSizes() (map[string]ContainerSize, error)
+	CopyFromContainer(ctx context.Context, id, src, dst string, progress CopyProgress) error
+	CopyToContainer(ctx context.Context, id, src, dst string, progress CopyProgress) error
+	Exec(id string, options ExecOptions) (io.ReadCloser, error)
 	Inspect(id string) (types.ContainerJSON, error)
 	IsContainerRunning(id string) bool
+	IsLocal() bool
+	JournalLogs(id string, since string) (io.ReadCloser, error)
 	Kill(id string) error
+	KillAndWait(id string, timeout time.Duration) (ContainerExit, error)
 	Logs(id string, since string, withTimeStamp bool) (io.ReadCloser, error)
+	LogTail(ctx context.Context, id string, lines int) ([]string, error)
+	OnContainerEvent(f func(ContainerEvent))
 	OpenChannel(container *Container) *StatsChannel
+	PauseContainer(id string) error
+	Processes(id string) (container.ContainerTopOKBody, error)
+	PruneContainers() (types.ContainersPruneReport, error)
+	RecreateContainer(ctx context.Context, id string, pull bool, step func(string)) (string, error)
 	RemoveAllStoppedContainers() (int, error)
+	RenameContainer(id, name string) (*Container, error)
 	RestartContainer(id string) error
+	RunContainer(options RunOptions) (string, error)
+	SignalContainer(id, signal string) error
+	StopAndWait(id string, timeout time.Duration) (ContainerExit, error)
 	StopContainer(id string) error
 	Top(id string) (container.ContainerTopOKBody, error)
+	UnpauseContainer(id string) error
+	UpdateLimits(id string, limits ContainerLimits) (*Container, error)
+	UpdateRestartPolicy(id string, policy string) (*Container, error)
 }
 
 //ImageAPI defines the API for Docker images
 type ImageAPI interface {
+	BuildImage(ctx context.Context, options BuildOptions, output io.Writer) (string, error)
 	History(id string) ([]image.HistoryResponseItem, error)
 	ImageByID(id string) (types.ImageSummary, error)
+	ImageGraph(images []types.ImageSummary) (*ImageGraph, error)
+	ImageLayers(id string) ([]ImageLayer, error)
 	Images() ([]types.ImageSummary, error)
+	LayerContents(ctx context.Context, id, diffID string, progress func(float64)) (*LayerContents, error)
+	LoadImage(ctx context.Context, path string, progress func(float64)) ([]string, error)
+	PruneImages(opts ImagePruneOptions) (types.ImagesPruneReport, error)
+	PullImage(ctx context.Context, ref string, progress func(TransferProgress)) (string, error)
+	PushImage(ctx context.Context, ref string, credentials *RegistryCredentials, progress func(TransferProgress)) (string, error)
 	RunImage(image types.ImageSummary, command string) error
+	SaveImage(ctx context.Context, id, path string, progress func(float64)) (int64, error)
+	SearchImages(ctx context.Context, term string) ([]dockerRegistry.SearchResult, error)
+	TagImage(id, ref string) error
+	UntagImage(id, tag string) error
 }
 
 //NetworkAPI defines the API for Docker networks
 type NetworkAPI interface {
+	HostRanges() []HostRange
 	Networks() ([]types.NetworkResource, error)
 	NetworkInspect(id string) (types.NetworkResource, error)
 }
 
+//CheckpointAPI defines the API for container checkpoints, only available on
+//daemons with experimental features enabled
+type CheckpointAPI interface {
+	Checkpoints(id string) ([]types.Checkpoint, error)
+	CreateCheckpoint(id, name string, leaveRunning bool) error
+	DeleteCheckpoint(id, name string) error
+	Experimental() bool
+	StartFromCheckpoint(id, name string) error
+}
+
+//PluginAPI defines the API for Docker engine plugins
+type PluginAPI interface {
+	Plugins() ([]*types.Plugin, error)
+	PluginInspect(name string) (*types.Plugin, error)
+	PluginEnable(name string) error
+	PluginDisable(name string) error
+	PluginRemove(name string, force bool) error
+}
+
 //SwarmAPI defines the API for Docker Swarm
 type SwarmAPI interface {
 	Node(id string) (*swarm.Node, error)
@@ -87,8 +154,16 @@ type SwarmAPI interface {
 	StackConfigs(stack string) ([]swarm.Config, error)
 	StackNetworks(stack string) ([]types.NetworkResource, error)
 	StackRemove(id string) error
+	StackRemoveResources(stack string, resources []StackResource, progress func(result StackRemoveResult, done, total int)) StackRemoveReport
+	StackResources(stack string, withSecretsAndConfigs bool) ([]StackResource, error)
 	StackSecrets(stack string) ([]swarm.Secret, error)
 	StackTasks(stack string) ([]swarm.Task, error)
+	SwarmInit(advertiseAddr string) error
+	SwarmJoin(remoteAddr, token string) error
+	SwarmJoinTokens() (SwarmJoinTokens, error)
+	SwarmLeave(force bool) error
+	SwarmMode() bool
+	SwarmRotateJoinTokens(worker, manager bool) error
 	Task(id string) (swarm.Task, error)
 }
 
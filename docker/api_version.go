@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/versions"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+//DefaultAPIVersion is the API version dry asks for when DOCKER_API_VERSION is not set,
+//it is lowered at connection time if the daemon does not support it
+const DefaultAPIVersion = "1.37"
+
+//oldestAPIVersion is the API version of daemons that do not report theirs
+const oldestAPIVersion = "1.24"
+
+//Feature is a feature of the Docker API that not every daemon supports
+type Feature string
+
+//Features that depend on the API version of the daemon
+const (
+	DiskUsageFeature   Feature = "Disk usage"
+	PluginsFeature     Feature = "Plugins"
+	ServiceLogsFeature Feature = "Service logs"
+	BuildCacheFeature  Feature = "Build cache"
+	CheckpointsFeature Feature = "Checkpoints"
+)
+
+//featureAPIVersions has the API version each feature was added on
+var featureAPIVersions = map[Feature]string{
+	DiskUsageFeature:   "1.25",
+	PluginsFeature:     "1.25",
+	ServiceLogsFeature: "1.29",
+	BuildCacheFeature:  "1.31",
+	CheckpointsFeature: "1.25",
+}
+
+//FeatureError is returned when the given feature is not supported by the
+//API version used with the daemon
+type FeatureError struct {
+	Feature    Feature
+	APIVersion string
+}
+
+func (e FeatureError) Error() string {
+	return fmt.Sprintf("%s requires API >= %s, the connection uses API %s",
+		e.Feature, MinAPIVersion(e.Feature), e.APIVersion)
+}
+
+//MinAPIVersion returns the API version the given feature requires
+func MinAPIVersion(f Feature) string {
+	return featureAPIVersions[f]
+}
+
+//FeatureSupported returns true if the given feature can be used with the given
+//API version. An unknown API version supports everything, the daemon will tell.
+func FeatureSupported(f Feature, apiVersion string) bool {
+	min, ok := featureAPIVersions[f]
+	if !ok || apiVersion == "" {
+		return true
+	}
+	return versions.GreaterThanOrEqualTo(apiVersion, min)
+}
+
+//negotiateAPIVersion returns the API version to use with a daemon whose
+//highest API version is the given server version. The client version is
+//lowered to the server one, never raised, unless the user pinned it.
+func negotiateAPIVersion(clientVersion, serverVersion string, pinned bool) string {
+	if pinned {
+		return clientVersion
+	}
+	if serverVersion == "" {
+		serverVersion = oldestAPIVersion
+	}
+	if versions.LessThan(serverVersion, clientVersion) {
+		return serverVersion
+	}
+	return clientVersion
+}
+
+//pingAPIVersion asks the daemon for its API version, empty if it does not answer
+func pingAPIVersion(client dockerAPI.APIClient) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	ping, err := client.Ping(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ping.APIVersion, nil
+}
+
+//APIVersion returns the API version used with the daemon
+func (daemon *DockerDaemon) APIVersion() string {
+	return daemon.apiVersion
+}
+
+//Supports returns true if the API version used with the daemon supports the given feature
+func (daemon *DockerDaemon) Supports(f Feature) bool {
+	return FeatureSupported(f, daemon.apiVersion)
+}
+
+func (daemon *DockerDaemon) requireFeature(f Feature) error {
+	if !daemon.Supports(f) {
+		return FeatureError{Feature: f, APIVersion: daemon.apiVersion}
+	}
+	return nil
+}
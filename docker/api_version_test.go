@@ -0,0 +1,54 @@
+package docker
+
+import "testing"
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientVersion string
+		serverVersion string
+		pinned        bool
+		want          string
+	}{
+		{"newer daemon", "1.37", "1.38", false, "1.37"},
+		{"same version", "1.37", "1.37", false, "1.37"},
+		{"older daemon", "1.37", "1.26", false, "1.26"},
+		{"daemon without version", "1.37", "", false, oldestAPIVersion},
+		{"pinned version", "1.30", "1.26", true, "1.30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negotiateAPIVersion(tt.clientVersion, tt.serverVersion, tt.pinned); got != tt.want {
+				t.Errorf("negotiateAPIVersion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureSupported(t *testing.T) {
+	tests := []struct {
+		feature    Feature
+		apiVersion string
+		want       bool
+	}{
+		{ServiceLogsFeature, "1.28", false},
+		{ServiceLogsFeature, "1.29", true},
+		{BuildCacheFeature, "1.30", false},
+		{BuildCacheFeature, "1.37", true},
+		{PluginsFeature, "1.24", false},
+		{DiskUsageFeature, "1.25", true},
+		{DiskUsageFeature, "", true},
+		{Feature("unknown"), "1.12", true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.feature)+" on "+tt.apiVersion, func(t *testing.T) {
+			if got := FeatureSupported(tt.feature, tt.apiVersion); got != tt.want {
+				t.Errorf("FeatureSupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	err := FeatureError{Feature: ServiceLogsFeature, APIVersion: "1.26"}
+	if want := "Service logs requires API >= 1.29, the connection uses API 1.26"; err.Error() != want {
+		t.Errorf("Unexpected error message, got %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/versions"
+)
+
+//buildCachePruneOptionsVersion is the first Engine API version whose build
+//cache prune endpoint accepts a storage limit, an age filter and all
+const buildCachePruneOptionsVersion = "1.39"
+
+//ErrBuildCachePruneOptionsNotSupported is returned when the build cache is pruned
+//with options that the Docker daemon does not support
+var ErrBuildCachePruneOptionsNotSupported = errors.New(
+	"pruning the build cache with a storage limit, an age or in full needs Docker Engine API " + buildCachePruneOptionsVersion)
+
+//BuildCachePruneOptions defines what is pruned from the build cache. Records
+//in use are never pruned.
+type BuildCachePruneOptions struct {
+	//KeepStorage is the amount of cache, in bytes, to keep
+	KeepStorage int64
+	//UnusedFor only prunes the records that have not been used for this long
+	UnusedFor time.Duration
+	//All prunes internal and frontend records too, not only the dangling ones
+	All bool
+}
+
+//query returns the query parameters of the prune request for these options
+func (o BuildCachePruneOptions) query() (url.Values, error) {
+	query := url.Values{}
+	if o.KeepStorage > 0 {
+		query.Set("keep-storage", strconv.FormatInt(o.KeepStorage, 10))
+	}
+	if o.All {
+		query.Set("all", "1")
+	}
+	if o.UnusedFor > 0 {
+		filters, err := json.Marshal(map[string]map[string]bool{
+			"unused-for": {o.UnusedFor.String(): true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		query.Set("filters", string(filters))
+	}
+	return query, nil
+}
+
+//BuildCache returns the records of the build cache of the Docker host
+func (daemon *DockerDaemon) BuildCache() ([]*types.BuildCache, error) {
+	if err := daemon.requireFeature(BuildCacheFeature); err != nil {
+		return nil, err
+	}
+	du, err := daemon.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+	return du.BuildCache, nil
+}
+
+//BuildCachePrune prunes the build cache, it returns the space reclaimed
+func (daemon *DockerDaemon) BuildCachePrune(opts BuildCachePruneOptions) (uint64, error) {
+	if daemon.engine == nil {
+		return 0, errors.New("the build cache cannot be pruned on this connection")
+	}
+	if err := daemon.requireFeature(BuildCacheFeature); err != nil {
+		return 0, err
+	}
+	query, err := opts.query()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	v, err := daemon.client.ServerVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	apiVersion := v.APIVersion
+	if !versions.LessThan(apiVersion, buildCachePruneOptionsVersion) {
+		apiVersion = buildCachePruneOptionsVersion
+	} else if len(query) > 0 {
+		return 0, ErrBuildCachePruneOptionsNotSupported
+	}
+	var report types.BuildCachePruneReport
+	if err := daemon.engine.post(ctx, apiVersion, "/build/prune", query, &report); err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
+//BuildCacheType returns the type of the given build cache record. The Engine
+//API version dry is built with only tells mutable records, such as cache mounts
+//and local sources, apart from regular ones.
+func BuildCacheType(record *types.BuildCache) string {
+	if record.Mutable {
+		return "mutable"
+	}
+	return "regular"
+}
+
+//BuildCacheLastUsed returns when the given record was last used, records never
+//used return when they were created
+func BuildCacheLastUsed(record *types.BuildCache) time.Time {
+	if record.LastUsedAt != nil {
+		return *record.LastUsedAt
+	}
+	return record.CreatedAt
+}
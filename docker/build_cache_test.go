@@ -0,0 +1,120 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+type serverVersionClientMock struct {
+	dockerAPI.APIClient
+	apiVersion string
+}
+
+func (c serverVersionClientMock) ServerVersion(ctx context.Context) (types.Version, error) {
+	return types.Version{APIVersion: c.apiVersion}, nil
+}
+
+func TestBuildCachePrune(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiVersion string
+		opts       BuildCachePruneOptions
+		wantPath   string
+		wantQuery  url.Values
+		wantErr    error
+	}{
+		{
+			"everything unused",
+			"1.38",
+			BuildCachePruneOptions{},
+			"/v1.38/build/prune",
+			url.Values{},
+			nil,
+		},
+		{
+			"storage limit and age",
+			"1.40",
+			BuildCachePruneOptions{KeepStorage: 1000, UnusedFor: 24 * time.Hour},
+			"/v1.39/build/prune",
+			url.Values{"keep-storage": {"1000"}, "filters": {`{"unused-for":{"24h0m0s":true}}`}},
+			nil,
+		},
+		{
+			"all",
+			"1.39",
+			BuildCachePruneOptions{All: true},
+			"/v1.39/build/prune",
+			url.Values{"all": {"1"}},
+			nil,
+		},
+		{
+			"options on an old daemon",
+			"1.38",
+			BuildCachePruneOptions{KeepStorage: 1000},
+			"",
+			nil,
+			ErrBuildCachePruneOptionsNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			var gotQuery url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotQuery = r.URL.Query()
+				fmt.Fprint(w, `{"SpaceReclaimed": 2048}`)
+			}))
+			defer server.Close()
+
+			daemon := DockerDaemon{
+				client: serverVersionClientMock{apiVersion: tt.apiVersion},
+				engine: &engineAPI{client: server.Client(), baseURL: server.URL},
+			}
+			reclaimed, err := daemon.BuildCachePrune(tt.opts)
+			if err != tt.wantErr {
+				t.Fatalf("BuildCachePrune() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if gotPath != "" {
+					t.Errorf("the build cache was pruned: %s", gotPath)
+				}
+				return
+			}
+			if reclaimed != 2048 {
+				t.Errorf("BuildCachePrune() = %d, want 2048", reclaimed)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("prune request path = %s, want %s", gotPath, tt.wantPath)
+			}
+			if gotQuery.Encode() != tt.wantQuery.Encode() {
+				t.Errorf("prune request query = %s, want %s", gotQuery.Encode(), tt.wantQuery.Encode())
+			}
+		})
+	}
+}
+
+func TestBuildCachePruneError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message": "build cache is locked"}`)
+	}))
+	defer server.Close()
+
+	daemon := DockerDaemon{
+		client: serverVersionClientMock{apiVersion: "1.39"},
+		engine: &engineAPI{client: server.Client(), baseURL: server.URL},
+	}
+	_, err := daemon.BuildCachePrune(BuildCachePruneOptions{})
+	if err == nil || err.Error() != "Error response from daemon: build cache is locked" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
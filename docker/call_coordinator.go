@@ -0,0 +1,165 @@
+package docker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//Endpoints of the list calls that go through the call coordinator
+const (
+	containersCall     = "containers"
+	containerSizesCall = "container sizes"
+	imagesCall         = "images"
+	networksCall       = "networks"
+	nodesCall          = "nodes"
+	servicesCall       = "services"
+)
+
+//callWindow is how long after a list call is issued the identical calls
+//issued after it share its result instead of calling the daemon again
+var callWindow = 250 * time.Millisecond
+
+//maxConcurrentCalls is how many calls to the same endpoint can be made
+//to the daemon at once, the rest wait for a slot
+var maxConcurrentCalls = 2
+
+//callTimeout is the timeout, as a time.Duration, of the calls made through
+//the call coordinator
+var callTimeout = int64(defaultOperationTimeout)
+
+//SetCallTimeout sets the timeout of the list calls made to the Docker
+//daemon, the default timeout is used if the given one is not positive
+func SetCallTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultOperationTimeout
+	}
+	atomic.StoreInt64(&callTimeout, int64(timeout))
+}
+
+//CallStats are the counters of the calls to a daemon endpoint
+type CallStats struct {
+	Endpoint string
+	//Calls is the number of calls made to the daemon
+	Calls int64
+	//Coalesced is the number of calls that shared the result of another one
+	Coalesced int64
+	//Latency is the average time the daemon took to answer
+	Latency time.Duration
+}
+
+//sharedCall is a call to the daemon whose result is shared by every
+//caller that issued an identical call while it was recent
+type sharedCall struct {
+	issued time.Time
+	done   chan struct{}
+	value  interface{}
+	err    error
+}
+
+//endpointCalls keeps the slots and the counters of the calls to an endpoint
+type endpointCalls struct {
+	slots     chan struct{}
+	calls     int64
+	coalesced int64
+	latency   time.Duration
+}
+
+//callCoordinator coalesces identical list calls issued within a short
+//window into one daemon call, gives each call a timeout and caps how
+//many calls to the same endpoint are made at once
+type callCoordinator struct {
+	window    time.Duration
+	calls     map[string]*sharedCall
+	endpoints map[string]*endpointCalls
+	lock      sync.Mutex
+}
+
+func newCallCoordinator() *callCoordinator {
+	return &callCoordinator{
+		window:    callWindow,
+		calls:     make(map[string]*sharedCall),
+		endpoints: make(map[string]*endpointCalls),
+	}
+}
+
+//do calls the given function, or waits for the result of the identical
+//call issued less than the window ago. A nil coordinator calls the given
+//function right away.
+func (c *callCoordinator) do(endpoint string, call func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(atomic.LoadInt64(&callTimeout)))
+	defer cancel()
+	if c == nil {
+		return call(ctx)
+	}
+	c.lock.Lock()
+	e := c.endpoint(endpoint)
+	if shared, ok := c.calls[endpoint]; ok && time.Since(shared.issued) < c.window {
+		e.coalesced++
+		c.lock.Unlock()
+		<-shared.done
+		return shared.value, shared.err
+	}
+	shared := &sharedCall{issued: time.Now(), done: make(chan struct{})}
+	c.calls[endpoint] = shared
+	c.lock.Unlock()
+
+	select {
+	case e.slots <- struct{}{}:
+		start := time.Now()
+		shared.value, shared.err = call(ctx)
+		<-e.slots
+		c.lock.Lock()
+		e.calls++
+		e.latency += time.Since(start)
+		c.lock.Unlock()
+	case <-ctx.Done():
+		shared.err = ctx.Err()
+	}
+	close(shared.done)
+	return shared.value, shared.err
+}
+
+//forget makes the next call to the given endpoint reach the daemon, it is
+//used once the result of previous calls is known to be outdated
+func (c *callCoordinator) forget(endpoint string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.calls, endpoint)
+}
+
+//stats returns the counters of the calls to each endpoint, sorted by endpoint
+func (c *callCoordinator) stats() []CallStats {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stats := make([]CallStats, 0, len(c.endpoints))
+	for name, e := range c.endpoints {
+		s := CallStats{Endpoint: name, Calls: e.calls, Coalesced: e.coalesced}
+		if e.calls > 0 {
+			s.Latency = e.latency / time.Duration(e.calls)
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Endpoint < stats[j].Endpoint
+	})
+	return stats
+}
+
+//endpoint returns the calls to the given endpoint, c must be locked
+func (c *callCoordinator) endpoint(name string) *endpointCalls {
+	e, ok := c.endpoints[name]
+	if !ok {
+		e = &endpointCalls{slots: make(chan struct{}, maxConcurrentCalls)}
+		c.endpoints[name] = e
+	}
+	return e
+}
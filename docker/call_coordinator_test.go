@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCallCoordinator_CoalescesIdenticalCalls(t *testing.T) {
+	c := newCallCoordinator()
+	var calls int32
+	release := make(chan struct{})
+	call := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "containers", nil
+	}
+	var wg sync.WaitGroup
+	results := make([]interface{}, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.do(containersCall, call)
+		}(i)
+	}
+	//let every caller join the call before it returns
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected one daemon call, got %d", calls)
+	}
+	for i, r := range results {
+		if r != "containers" {
+			t.Errorf("Caller %d got %v", i, r)
+		}
+	}
+	stats := c.stats()
+	if len(stats) != 1 || stats[0].Calls != 1 || stats[0].Coalesced != 4 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestCallCoordinator_Window(t *testing.T) {
+	c := newCallCoordinator()
+	c.window = 20 * time.Millisecond
+	var calls int32
+	call := func(ctx context.Context) (interface{}, error) {
+		return atomic.AddInt32(&calls, 1), nil
+	}
+	c.do(imagesCall, call)
+	if v, _ := c.do(imagesCall, call); v != int32(1) {
+		t.Errorf("A call within the window did not share the result, got %v", v)
+	}
+	c.do(networksCall, call)
+	if calls != 2 {
+		t.Errorf("Calls to other endpoints must not be shared, calls: %d", calls)
+	}
+	time.Sleep(c.window)
+	if v, _ := c.do(imagesCall, call); v != int32(3) {
+		t.Errorf("A call after the window shared the result, got %v", v)
+	}
+	c.forget(imagesCall)
+	if v, _ := c.do(imagesCall, call); v != int32(4) {
+		t.Errorf("A call after forgetting the endpoint shared the result, got %v", v)
+	}
+}
+
+func TestCallCoordinator_ErrorsAreShared(t *testing.T) {
+	c := newCallCoordinator()
+	errDaemon := errors.New("daemon is busy")
+	c.do(nodesCall, func(ctx context.Context) (interface{}, error) {
+		return nil, errDaemon
+	})
+	_, err := c.do(nodesCall, func(ctx context.Context) (interface{}, error) {
+		return "nodes", nil
+	})
+	if err != errDaemon {
+		t.Errorf("Expected the error of the shared call, got %v", err)
+	}
+}
+
+func TestCallCoordinator_ConcurrencyCap(t *testing.T) {
+	c := newCallCoordinator()
+	c.window = 0
+	var running, maxRunning int32
+	call := func(ctx context.Context) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil, nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.do(servicesCall, call)
+		}()
+	}
+	wg.Wait()
+	if maxRunning > int32(maxConcurrentCalls) {
+		t.Errorf("Expected at most %d calls at once, got %d", maxConcurrentCalls, maxRunning)
+	}
+	if stats := c.stats(); stats[0].Calls != 6 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestCallCoordinator_Timeout(t *testing.T) {
+	SetCallTimeout(20 * time.Millisecond)
+	defer SetCallTimeout(0)
+	c := newCallCoordinator()
+	_, err := c.do(containersCall, func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the call to time out, got %v", err)
+	}
+}
+
+func TestCallCoordinator_Nil(t *testing.T) {
+	var c *callCoordinator
+	v, err := c.do(imagesCall, func(ctx context.Context) (interface{}, error) {
+		return "images", nil
+	})
+	if v != "images" || err != nil {
+		t.Errorf("Unexpected result: %v, %v", v, err)
+	}
+	c.forget(imagesCall)
+	if stats := c.stats(); stats != nil {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
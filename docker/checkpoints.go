@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+//checkpointTimeout is how long checkpoint operations can take, CRIU dumps
+//and restores the memory of every process of the container
+const checkpointTimeout = 2 * time.Minute
+
+//Experimental returns true if the daemon has experimental features enabled
+func (daemon *DockerDaemon) Experimental() bool {
+	v, err := daemon.Version()
+	return err == nil && v.Experimental
+}
+
+//Checkpoints returns the checkpoints of the container with the given id,
+//sorted by name
+func (daemon *DockerDaemon) Checkpoints(id string) ([]types.Checkpoint, error) {
+	if err := daemon.requireFeature(CheckpointsFeature); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	checkpoints, err := daemon.client.CheckpointList(ctx, id, types.CheckpointListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(checkpoints, func(i, j int) bool {
+		return checkpoints[i].Name < checkpoints[j].Name
+	})
+	return checkpoints, nil
+}
+
+//CreateCheckpoint creates a checkpoint with the given name of the container
+//with the given id, the container is stopped unless asked to leave it running
+func (daemon *DockerDaemon) CreateCheckpoint(id, name string, leaveRunning bool) error {
+	if err := daemon.requireFeature(CheckpointsFeature); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointTimeout)
+	defer cancel()
+	options := types.CheckpointCreateOptions{CheckpointID: name, Exit: !leaveRunning}
+	if err := daemon.client.CheckpointCreate(ctx, id, options); err != nil {
+		return err
+	}
+	return daemon.refreshAndWait()
+}
+
+//DeleteCheckpoint deletes the checkpoint with the given name of the container
+//with the given id
+func (daemon *DockerDaemon) DeleteCheckpoint(id, name string) error {
+	if err := daemon.requireFeature(CheckpointsFeature); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.CheckpointDelete(ctx, id, types.CheckpointDeleteOptions{CheckpointID: name})
+}
+
+//StartFromCheckpoint starts the stopped container with the given id,
+//restoring it from the checkpoint with the given name
+func (daemon *DockerDaemon) StartFromCheckpoint(id, name string) error {
+	if err := daemon.requireFeature(CheckpointsFeature); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointTimeout)
+	defer cancel()
+	if err := daemon.client.ContainerStart(ctx, id, types.ContainerStartOptions{CheckpointID: name}); err != nil {
+		return err
+	}
+	return daemon.refreshAndWait()
+}
@@ -0,0 +1,74 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestCheckpoints(t *testing.T) {
+	client := &mock.CheckpointAPIClientMock{Checkpoints: []types.Checkpoint{
+		{Name: "nightly"}, {Name: "before-upgrade"}, {Name: "cp1"},
+	}}
+	daemon := &DockerDaemon{client: client, apiVersion: DefaultAPIVersion}
+	checkpoints, err := daemon.Checkpoints("web")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, c := range checkpoints {
+		names = append(names, c.Name)
+	}
+	if want := []string{"before-upgrade", "cp1", "nightly"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Checkpoints() = %v, want %v", names, want)
+	}
+}
+
+func TestCheckpointOperations(t *testing.T) {
+	client := &mock.CheckpointAPIClientMock{}
+	daemon := &DockerDaemon{client: client, apiVersion: DefaultAPIVersion}
+
+	if err := daemon.CreateCheckpoint("web", "cp1", true); err != nil {
+		t.Fatalf("Unexpected error creating a checkpoint: %v", err)
+	}
+	if err := daemon.CreateCheckpoint("web", "cp2", false); err != nil {
+		t.Fatalf("Unexpected error creating a checkpoint: %v", err)
+	}
+	wantCreated := []types.CheckpointCreateOptions{
+		{CheckpointID: "cp1", Exit: false},
+		{CheckpointID: "cp2", Exit: true},
+	}
+	if !reflect.DeepEqual(client.Created, wantCreated) {
+		t.Errorf("Checkpoints created = %+v, want %+v", client.Created, wantCreated)
+	}
+
+	if err := daemon.DeleteCheckpoint("web", "cp1"); err != nil {
+		t.Fatalf("Unexpected error deleting a checkpoint: %v", err)
+	}
+	if len(client.Deleted) != 1 || client.Deleted[0].CheckpointID != "cp1" {
+		t.Errorf("Checkpoints deleted = %+v, want cp1", client.Deleted)
+	}
+
+	if err := daemon.StartFromCheckpoint("web", "cp2"); err != nil {
+		t.Fatalf("Unexpected error starting from a checkpoint: %v", err)
+	}
+	if len(client.Started) != 1 || client.Started[0].CheckpointID != "cp2" {
+		t.Errorf("Containers started = %+v, want one from cp2", client.Started)
+	}
+}
+
+func TestCheckpointsOldAPI(t *testing.T) {
+	client := &mock.CheckpointAPIClientMock{}
+	daemon := &DockerDaemon{client: client, apiVersion: "1.24"}
+	if _, err := daemon.Checkpoints("web"); err == nil {
+		t.Error("Checkpoints were listed with an API version that does not support them")
+	}
+	if err := daemon.CreateCheckpoint("web", "cp1", true); err == nil {
+		t.Error("A checkpoint was created with an API version that does not support them")
+	}
+	if len(client.Created) > 0 {
+		t.Errorf("The daemon was asked to create a checkpoint: %+v", client.Created)
+	}
+}
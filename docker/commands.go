@@ -22,6 +22,30 @@ const (
 	STATS
 	//STOP stop command
 	STOP
+	//CHECKPOINTS checkpoints command
+	CHECKPOINTS
+	//PAUSE pause command
+	PAUSE
+	//UNPAUSE unpause command
+	UNPAUSE
+	//RENAME rename command
+	RENAME
+	//UPDATE update command
+	UPDATE
+	//RESTARTPOLICY restart policy command
+	RESTARTPOLICY
+	//COMMIT commit command
+	COMMIT
+	//COPY copy command
+	COPY
+	//DIFF diff command
+	DIFF
+	//EXEC exec command
+	EXEC
+	//ATTACH attach command
+	ATTACH
+	//BROWSE open published port command
+	BROWSE
 )
 
 //ContainerCommands is the list of container commands
@@ -34,6 +58,18 @@ var ContainerCommands = []CommandDescription{
 	{HISTORY, "Show image history"},
 	{STATS, "Stats + Top"},
 	{STOP, "Stop"},
+	{EXEC, "Exec command"},
+	{ATTACH, "Attach"},
+	{BROWSE, "Open published port"},
+	{PAUSE, "Pause"},
+	{UNPAUSE, "Unpause"},
+	{RENAME, "Rename"},
+	{UPDATE, "Update limits"},
+	{RESTARTPOLICY, "Restart policy"},
+	{COMMIT, "Commit to image"},
+	{COPY, "Copy files"},
+	{DIFF, "Filesystem changes"},
+	{CHECKPOINTS, "Checkpoints"},
 }
 
 //CommandDescriptions lists command descriptions in the same order
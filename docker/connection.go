@@ -40,6 +40,7 @@ func connect(client client.APIClient, env *Env) (*DockerDaemon, error) {
 		s:         store,
 		dockerEnv: env,
 		resolver:  newResolver(client, false),
+		calls:     newCallCoordinator(),
 	}
 	d.init()
 	return d, nil
@@ -116,9 +117,33 @@ func ConnectToDaemon(env *Env) (*DockerDaemon, error) {
 		return nil, errors.Wrap(err, "HttpClient creation error")
 	}
 
-	client, err := client.NewClient(host, env.DockerAPIVersion, httpClient, headers)
-	if err == nil {
-		return connect(client, env)
+	engine, err := newEngineAPI(host, tlsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Engine API client creation error")
+	}
+
+	apiVersion := env.DockerAPIVersion
+	dockerClient, err := client.NewClient(host, apiVersion, httpClient, headers)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating client")
+	}
+	if !env.APIVersionPinned {
+		//errors are left for connect to report
+		if serverVersion, err := pingAPIVersion(dockerClient); err == nil {
+			if negotiated := negotiateAPIVersion(apiVersion, serverVersion, false); negotiated != apiVersion {
+				apiVersion = negotiated
+				dockerClient, err = client.NewClient(host, apiVersion, httpClient, headers)
+				if err != nil {
+					return nil, errors.Wrap(err, "Error creating client")
+				}
+			}
+		}
+	}
+	daemon, err := connect(dockerClient, env)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.Wrap(err, "Error creating client")
+	daemon.engine = engine
+	daemon.apiVersion = apiVersion
+	return daemon, nil
 }
@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+//DetachKeys are the keys that detach from a container, as docker attach does
+const DetachKeys = "ctrl-p,ctrl-q"
+
+//detachSequence is what the terminal sends when the detach keys are pressed
+var detachSequence = []byte{0x10, 0x11}
+
+//ContainerAttachment is a connection to the main process of a container
+type ContainerAttachment struct {
+	//TTY is true if the container has a TTY, its output is then sent raw
+	TTY   bool
+	stdin bool
+	id    string
+	resp  types.HijackedResponse
+	//resize changes the size of the TTY of the container
+	resize func(ctx context.Context, options types.ResizeOptions) error
+}
+
+//Attach attaches to the main process of the container with the given id, as
+//docker attach does. Its input is attached only if the container keeps its
+//stdin open.
+func (daemon *DockerDaemon) Attach(id string) (*ContainerAttachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	c, err := daemon.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tty, stdin := false, false
+	if c.Config != nil {
+		tty, stdin = c.Config.Tty, c.Config.OpenStdin
+	}
+	//the connection is used after this function returns
+	resp, err := daemon.client.ContainerAttach(context.Background(), id, types.ContainerAttachOptions{
+		Stream:     true,
+		Stdin:      stdin,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: DetachKeys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ContainerAttachment{
+		TTY:   tty,
+		stdin: stdin,
+		id:    id,
+		resp:  resp,
+		resize: func(ctx context.Context, options types.ResizeOptions) error {
+			return daemon.client.ContainerResize(ctx, id, options)
+		},
+	}, nil
+}
+
+//Close closes the connection to the container
+func (a *ContainerAttachment) Close() error {
+	a.resp.Close()
+	return nil
+}
+
+//Resize changes the size of the TTY of the container, it does nothing if
+//the container has no TTY
+func (a *ContainerAttachment) Resize(width, height uint) error {
+	if !a.TTY || width == 0 || height == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return a.resize(ctx, types.ResizeOptions{Width: width, Height: height})
+}
+
+//Stream copies the given input to the container and the output of the
+//container to the given writers until the detach keys are pressed or the
+//container stops. Stream does not wait for the copy of the input to end,
+//closing the input once Stream returns stops it.
+func (a *ContainerAttachment) Stream(stdin io.Reader, stdout, stderr io.Writer) error {
+	detached := make(chan struct{})
+	go func() {
+		if a.stdin {
+			//the daemon detaches when it reads the detach keys
+			io.Copy(a.resp.Conn, stdin)
+			a.resp.CloseWrite()
+			return
+		}
+		//nothing is sent to a container that does not read its input, the
+		//detach keys are looked for here instead
+		if waitForDetachKeys(stdin) == nil {
+			close(detached)
+			a.resp.Close()
+		}
+	}()
+	var err error
+	if a.TTY {
+		_, err = io.Copy(stdout, a.resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, a.resp.Reader)
+	}
+	select {
+	case <-detached:
+		//reading from the closed connection fails
+		return nil
+	default:
+	}
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+//waitForDetachKeys reads the given input until the detach keys are read,
+//it returns the error reading the input if they are never read
+func waitForDetachKeys(r io.Reader) error {
+	br := bufio.NewReader(r)
+	matched := 0
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case b == detachSequence[matched]:
+			matched++
+		case b == detachSequence[0]:
+			matched = 1
+		default:
+			matched = 0
+		}
+		if matched == len(detachSequence) {
+			return nil
+		}
+	}
+}
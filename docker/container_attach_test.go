@@ -0,0 +1,127 @@
+package docker
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestAttach_TTY(t *testing.T) {
+	client := &mock.ContainerAttachAPIClientMock{
+		Config: &container.Config{Tty: true, OpenStdin: true},
+		Output: []byte("root@6dfafdbc3a40:/# "),
+	}
+	daemon := &DockerDaemon{client: client}
+
+	attachment, err := daemon.Attach("6dfafdbc3a40")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer attachment.Close()
+	if !attachment.TTY {
+		t.Error("The container has a TTY")
+	}
+	options := client.Options
+	if !options.Stream || !options.Stdin || !options.Stdout || !options.Stderr || options.DetachKeys != DetachKeys {
+		t.Errorf("Unexpected attach options: %+v", options)
+	}
+	if err := attachment.Resize(80, 24); err != nil {
+		t.Errorf("Unexpected error resizing: %v", err)
+	}
+	if len(client.Resized) != 1 || client.Resized[0].Width != 80 || client.Resized[0].Height != 24 {
+		t.Errorf("Unexpected resizes: %+v", client.Resized)
+	}
+
+	var stdout bytes.Buffer
+	input := "ls\n\x10\x11"
+	if err := attachment.Stream(strings.NewReader(input), &stdout, &stdout); err != nil {
+		t.Fatalf("Unexpected error streaming: %v", err)
+	}
+	if stdout.String() != "root@6dfafdbc3a40:/# " {
+		t.Errorf("Unexpected output: %q", stdout.String())
+	}
+	if got := string(client.Input()); got != input {
+		t.Errorf("The container read %q, want %q", got, input)
+	}
+}
+
+func TestAttach_NoStdin(t *testing.T) {
+	var output bytes.Buffer
+	stdcopy.NewStdWriter(&output, stdcopy.Stderr).Write([]byte("err"))
+	stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte("out"))
+	client := &mock.ContainerAttachAPIClientMock{
+		Config: &container.Config{},
+		Output: output.Bytes(),
+	}
+	daemon := &DockerDaemon{client: client}
+
+	attachment, err := daemon.Attach("6dfafdbc3a40")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer attachment.Close()
+	if attachment.TTY || client.Options.Stdin {
+		t.Errorf("Unexpected attachment to a container without TTY and stdin: %+v", client.Options)
+	}
+	if err := attachment.Resize(80, 24); err != nil || len(client.Resized) != 0 {
+		t.Errorf("Containers without a TTY must not be resized: %v, %+v", err, client.Resized)
+	}
+
+	//the detach keys are pressed once the output was received
+	stdin, keys := io.Pipe()
+	received := make(chan struct{})
+	stdout := &notifyingWriter{n: len("out"), done: received}
+	go func() {
+		<-received
+		keys.Write([]byte("\x10\x11"))
+	}()
+	var stderr bytes.Buffer
+	if err := attachment.Stream(stdin, stdout, &stderr); err != nil {
+		t.Fatalf("Unexpected error streaming: %v", err)
+	}
+	keys.Close()
+	if stdout.String() != "out" || stderr.String() != "err" {
+		t.Errorf("Unexpected output: %q, %q", stdout.String(), stderr.String())
+	}
+	if len(client.Input()) != 0 {
+		t.Errorf("Nothing must be sent to a container without stdin, got %q", client.Input())
+	}
+}
+
+func TestWaitForDetachKeys(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"\x10\x11", false},
+		{"ls\x10\x10\x11", false},
+		{"\x10x\x11", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if err := waitForDetachKeys(strings.NewReader(tt.input)); (err != nil) != tt.wantErr {
+			t.Errorf("waitForDetachKeys(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+	}
+}
+
+//notifyingWriter closes done once n bytes were written to it
+type notifyingWriter struct {
+	bytes.Buffer
+	n    int
+	done chan struct{}
+}
+
+func (w *notifyingWriter) Write(p []byte) (int, error) {
+	n, err := w.Buffer.Write(p)
+	if w.Len() >= w.n && w.done != nil {
+		close(w.done)
+		w.done = nil
+	}
+	return n, err
+}
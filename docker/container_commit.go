@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+)
+
+//commitTimeout is how long a commit can take, committing the changes of a
+//container takes longer than other operations
+const commitTimeout = 2 * time.Minute
+
+//CommitOptions are the options to create an image from the changes of a container
+type CommitOptions struct {
+	//Reference is the repository, and optionally the tag, of the new image
+	Reference string
+	//Message is the commit message
+	Message string
+	//Author is the author of the new image
+	Author string
+}
+
+//ValidateImageReference checks that the given reference is a valid
+//repository[:tag] to name an image with, digests are not allowed
+func ValidateImageReference(ref string) error {
+	if err := ValidateImageName(ref); err != nil {
+		return err
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return fmt.Errorf("invalid reference %q: %s", ref, err.Error())
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return fmt.Errorf("invalid reference %q: images are named by tag, not by digest", ref)
+	}
+	return nil
+}
+
+//CommitContainer creates a new image from the changes of the container with
+//the given id, the container is paused while it is committed. It returns
+//the id of the new image.
+func (daemon *DockerDaemon) CommitContainer(id string, options CommitOptions) (string, error) {
+	ref := strings.TrimSpace(options.Reference)
+	if err := ValidateImageReference(ref); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commitTimeout)
+	defer cancel()
+	resp, err := daemon.client.ContainerCommit(ctx, id, types.ContainerCommitOptions{
+		Reference: ref,
+		Comment:   options.Message,
+		Author:    options.Author,
+		Pause:     true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
@@ -0,0 +1,64 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestValidateImageReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		wantErr bool
+	}{
+		{"myapp", false},
+		{"myapp:1.0", false},
+		{"registry.example.com:5000/team/myapp:latest", false},
+		{"", true},
+		{"my app", true},
+		{"MyApp", true},
+		{"myapp@sha256:0000000000000000000000000000000000000000000000000000000000000000", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateImageReference(tt.ref); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateImageReference(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCommitContainer(t *testing.T) {
+	client := &mock.ContainerCommitAPIClientMock{}
+	daemon := &DockerDaemon{client: client}
+
+	id, err := daemon.CommitContainer("6dfafdbc3a40", CommitOptions{
+		Reference: " myapp:snapshot ",
+		Message:   "debug tools installed",
+		Author:    "dry",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error committing a container: %v", err)
+	}
+	if id != "sha256:1" {
+		t.Errorf("Id of the new image = %s, want sha256:1", id)
+	}
+	if len(client.Commits) != 1 {
+		t.Fatalf("Expected one commit, got %d", len(client.Commits))
+	}
+	commit := client.Commits[0]
+	if commit.Reference != "myapp:snapshot" || commit.Comment != "debug tools installed" ||
+		commit.Author != "dry" || !commit.Pause {
+		t.Errorf("Unexpected commit options: %+v", commit)
+	}
+
+	if _, err := daemon.CommitContainer("6dfafdbc3a40", CommitOptions{Reference: "my app"}); err == nil {
+		t.Error("Committing with an invalid reference must fail")
+	}
+	if len(client.Commits) != 1 {
+		t.Error("The daemon was asked to commit with an invalid reference")
+	}
+	client.Err = errors.New("No such container: 6dfafdbc3a40")
+	if _, err := daemon.CommitContainer("6dfafdbc3a40", CommitOptions{Reference: "myapp"}); err == nil {
+		t.Error("The error of the daemon was not returned")
+	}
+}
@@ -0,0 +1,257 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+//Directions of a copy between the host and a container
+const (
+	CopyToContainer   = "to container"
+	CopyFromContainer = "from container"
+)
+
+//CopyDirections are the directions a copy can go
+var CopyDirections = []string{CopyToContainer, CopyFromContainer}
+
+//CopyProgress is notified of the bytes of file content copied so far and
+//the total to copy, total is -1 if it is not known
+type CopyProgress func(copied, total int64)
+
+//CopyToContainer copies the given file or directory of the host to the given
+//path of the container with the given id, as docker cp does. If the
+//destination is an existing directory the source is copied into it,
+//otherwise the source is copied with the destination as its name.
+func (daemon *DockerDaemon) CopyToContainer(ctx context.Context, id, src, dst string, progress CopyProgress) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	dir, name := dst, filepath.Base(src)
+	stat, err := daemon.client.ContainerStatPath(ctx, id, dst)
+	switch {
+	case err == nil && stat.Mode.IsDir():
+	case err == nil && info.IsDir():
+		return fmt.Errorf("cannot copy a directory to %s, it is a file", dst)
+	case err == nil, dockerAPI.IsErrNotFound(err):
+		if strings.HasSuffix(dst, "/") {
+			return fmt.Errorf("destination directory %s does not exist", dst)
+		}
+		dir, name = path.Dir(dst), path.Base(dst)
+	default:
+		return err
+	}
+	total, err := contentSize(src)
+	if err != nil {
+		return err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeTar(w, src, name, func(copied int64) {
+			if progress != nil {
+				progress(copied, total)
+			}
+		}, nil))
+	}()
+	defer r.Close()
+	return daemon.client.CopyToContainer(ctx, id, dir, r, types.CopyToContainerOptions{})
+}
+
+//CopyFromContainer copies the given file or directory of the container with
+//the given id to the given path of the host, as docker cp does. If the
+//destination is an existing directory the source is copied into it,
+//otherwise the source is copied with the destination as its name.
+func (daemon *DockerDaemon) CopyFromContainer(ctx context.Context, id, src, dst string, progress CopyProgress) error {
+	content, stat, err := daemon.client.CopyFromContainer(ctx, id, src)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	dir, rename := dst, ""
+	info, err := os.Stat(dst)
+	switch {
+	case err == nil && info.IsDir():
+	case err == nil && stat.Mode.IsDir():
+		return fmt.Errorf("cannot copy a directory to %s, it is a file", dst)
+	case err == nil, os.IsNotExist(err):
+		if strings.HasSuffix(dst, string(filepath.Separator)) {
+			return fmt.Errorf("destination directory %s does not exist", dst)
+		}
+		dir, rename = filepath.Dir(dst), filepath.Base(dst)
+	default:
+		return err
+	}
+	total := int64(-1)
+	if stat.Mode.IsRegular() {
+		total = stat.Size
+	}
+	return extractTar(content, dir, stat.Name, rename, func(copied int64) {
+		if progress != nil {
+			progress(copied, total)
+		}
+	})
+}
+
+//contentSize returns the size of the regular files of the given path
+func contentSize(src string) (int64, error) {
+	var size int64
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+//writeTar writes a tar archive of the given path to the given writer, the
+//path is archived with the given name. copied is called as file content is
+//written with the bytes written so far. The paths, relative to the given
+//one, that exclude returns true for are left out of the archive, if given.
+func writeTar(w io.Writer, src, name string, copied func(int64), exclude func(rel string) bool) error {
+	tw := tar.NewWriter(w)
+	var written int64
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if exclude != nil && rel != "." && exclude(rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		n, err := io.Copy(tw, &countingReader{r: f, count: func(n int64) {
+			copied(written + n)
+		}})
+		written += n
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+//extractTar extracts the given tar archive into the given directory. The
+//entries under the given root are extracted under the given name instead,
+//if a name is given. copied is called as file content is extracted with
+//the bytes extracted so far.
+func extractTar(r io.Reader, dir, root, rename string, copied func(int64)) error {
+	tr := tar.NewReader(r)
+	var written int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if rename != "" && (name == root || strings.HasPrefix(name, root+"/")) {
+			name = rename + strings.TrimPrefix(name, root)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if rel, err := filepath.Rel(dir, target); err != nil || rel == ".." ||
+			strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path in the archive: %s", hdr.Name)
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+			if err != nil {
+				return err
+			}
+			n, err := io.Copy(f, &countingReader{r: tr, count: func(n int64) {
+				copied(written + n)
+			}})
+			written += n
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//countingReader is a reader that reports how many bytes were read from it
+type countingReader struct {
+	r     io.Reader
+	read  int64
+	count func(int64)
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.read += int64(n)
+		c.count(c.read)
+	}
+	return n, err
+}
+
+//ValidateCopyPath checks that a path to copy from or to was given
+func ValidateCopyPath(p string) error {
+	if strings.TrimSpace(p) == "" {
+		return errors.New("a path is required")
+	}
+	return nil
+}
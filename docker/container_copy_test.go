@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestCopyToAndFromContainer(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "dry-cp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "app")
+	files := map[string]string{
+		"config.yml":  "port: 8080\n",
+		"data/db.txt": "some data",
+	}
+	for name, content := range files {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := &mock.ContainerCopyAPIClientMock{Paths: map[string]bool{"/srv": true, "/srv/app.yml": false}}
+	daemon := &DockerDaemon{client: client}
+	var copied, total int64
+	progress := func(c, t int64) {
+		copied, total = c, t
+	}
+
+	if err := daemon.CopyToContainer(context.Background(), "6dfafdbc3a40", src, "/srv", progress); err != nil {
+		t.Fatalf("Unexpected error copying to a container: %v", err)
+	}
+	if client.Dir != "/srv" {
+		t.Errorf("Copied to %s, want /srv", client.Dir)
+	}
+	if copied != 20 || total != 20 {
+		t.Errorf("Progress = %d of %d, want 20 of 20", copied, total)
+	}
+	if err := daemon.CopyToContainer(context.Background(), "6dfafdbc3a40", src, "/srv/app.yml", nil); err == nil {
+		t.Error("Copying a directory over a file must fail")
+	}
+
+	dst := filepath.Join(tmp, "copy")
+	if err := daemon.CopyFromContainer(context.Background(), "6dfafdbc3a40", "/srv/app", dst, progress); err != nil {
+		t.Fatalf("Unexpected error copying from a container: %v", err)
+	}
+	for name, content := range files {
+		got, err := ioutil.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("File %s was not copied: %v", name, err)
+		} else if string(got) != content {
+			t.Errorf("Content of %s = %q, want %q", name, got, content)
+		}
+	}
+	if copied != 20 || total != -1 {
+		t.Errorf("Progress = %d of %d, want 20 of -1", copied, total)
+	}
+
+	if err := daemon.CopyToContainer(context.Background(), "6dfafdbc3a40", src, "/srv/copy", nil); err != nil {
+		t.Fatalf("Unexpected error copying to a container: %v", err)
+	}
+	if client.Dir != "/srv" {
+		t.Errorf("Copied to %s, want /srv", client.Dir)
+	}
+	tr := tar.NewReader(bytes.NewReader(client.Archive))
+	if hdr, err := tr.Next(); err != nil || hdr.Name != "copy/" {
+		t.Errorf("The source was not copied with the destination name: %v, %v", hdr, err)
+	}
+}
+
+func TestExtractTar_InvalidPath(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0644, Typeflag: tar.TypeReg})
+	tw.Close()
+
+	tmp, err := ioutil.TempDir("", "dry-cp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	if err := extractTar(&buf, tmp, "evil", "", func(int64) {}); err == nil {
+		t.Error("Entries out of the destination must not be extracted")
+	}
+}
@@ -0,0 +1,46 @@
+package docker
+
+import (
+	"context"
+	"sort"
+)
+
+//Kinds of change the daemon reports on the filesystem of a container
+const (
+	changeModify = 0
+	changeAdd    = 1
+	changeDelete = 2
+)
+
+//ContainerChange is a change of a path of the filesystem of a container,
+//compared to the filesystem of its image
+type ContainerChange struct {
+	Change Change
+	Path   string
+}
+
+//ContainerDiff returns what changed on the filesystem of the container with
+//the given id, compared to its image, sorted by path
+func (daemon *DockerDaemon) ContainerDiff(id string) ([]ContainerChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	items, err := daemon.client.ContainerDiff(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]ContainerChange, 0, len(items))
+	for _, item := range items {
+		change := Changed
+		switch item.Kind {
+		case changeAdd:
+			change = Added
+		case changeDelete:
+			change = Removed
+		}
+		changes = append(changes, ContainerChange{Change: change, Path: item.Path})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestContainerDiff(t *testing.T) {
+	client := &mock.ContainerDiffAPIClientMock{Changes: []container.ContainerChangeResponseItem{
+		{Kind: 0, Path: "/var/log"},
+		{Kind: 1, Path: "/var/log/app.log"},
+		{Kind: 2, Path: "/etc/motd"},
+		{Kind: 0, Path: "/etc"},
+	}}
+	daemon := &DockerDaemon{client: client}
+
+	changes, err := daemon.ContainerDiff("6dfafdbc3a40")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []ContainerChange{
+		{Changed, "/etc"},
+		{Removed, "/etc/motd"},
+		{Changed, "/var/log"},
+		{Added, "/var/log/app.log"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("ContainerDiff() = %v, want %v", changes, want)
+	}
+}
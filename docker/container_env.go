@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//EnvVar is an environment variable, or a label, of a container
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+//ContainerEnv returns the environment variables of the given container
+//sorted by key, variables with the same key keep the order they were defined
+func ContainerEnv(c types.ContainerJSON) []EnvVar {
+	if c.Config == nil {
+		return nil
+	}
+	env := make([]EnvVar, 0, len(c.Config.Env))
+	for _, v := range c.Config.Env {
+		kv := strings.SplitN(v, "=", 2)
+		envVar := EnvVar{Key: kv[0]}
+		if len(kv) == 2 {
+			envVar.Value = kv[1]
+		}
+		env = append(env, envVar)
+	}
+	sort.SliceStable(env, func(i, j int) bool {
+		return env[i].Key < env[j].Key
+	})
+	return env
+}
+
+//ContainerLabels returns the labels of the given container sorted by key
+func ContainerLabels(c types.ContainerJSON) []EnvVar {
+	if c.Config == nil {
+		return nil
+	}
+	labels := make([]EnvVar, 0, len(c.Config.Labels))
+	for k, v := range c.Config.Labels {
+		labels = append(labels, EnvVar{Key: k, Value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Key < labels[j].Key
+	})
+	return labels
+}
+
+//HasConfig returns true if the given container was inspected, so its
+//configuration is known
+func HasConfig(c *Container) bool {
+	return c != nil && c.ContainerJSON.ContainerJSONBase != nil && c.ContainerJSON.Config != nil
+}
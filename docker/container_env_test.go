@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestContainerEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		c    types.ContainerJSON
+		want []EnvVar
+	}{
+		{"not inspected", types.ContainerJSON{}, nil},
+		{
+			"variables are sorted by key",
+			types.ContainerJSON{Config: &container.Config{
+				Env: []string{"PATH=/usr/bin", "EMPTY=", "NO_VALUE", "URL=http://host/?a=b"},
+			}},
+			[]EnvVar{{"EMPTY", ""}, {"NO_VALUE", ""}, {"PATH", "/usr/bin"}, {"URL", "http://host/?a=b"}},
+		},
+		{
+			"repeated keys keep their order",
+			types.ContainerJSON{Config: &container.Config{
+				Env: []string{"PATH=/usr/bin", "LANG=C", "PATH=/usr/local/bin"},
+			}},
+			[]EnvVar{{"LANG", "C"}, {"PATH", "/usr/bin"}, {"PATH", "/usr/local/bin"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContainerEnv(tt.c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContainerEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerLabels(t *testing.T) {
+	c := types.ContainerJSON{Config: &container.Config{
+		Labels: map[string]string{
+			"com.docker.compose.service": "web",
+			"com.docker.compose.project": "shop",
+			"maintainer":                 "ops",
+		},
+	}}
+	want := []EnvVar{
+		{"com.docker.compose.project", "shop"},
+		{"com.docker.compose.service", "web"},
+		{"maintainer", "ops"},
+	}
+	if got := ContainerLabels(c); !reflect.DeepEqual(got, want) {
+		t.Errorf("ContainerLabels() = %v, want %v", got, want)
+	}
+}
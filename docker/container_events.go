@@ -0,0 +1,153 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	dockerEvents "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+//ContainerEvent tells that a container has changed, Container is the
+//container as the daemon lists it after the change, nil if it is gone
+type ContainerEvent struct {
+	ID        string
+	Action    string
+	Container *Container
+}
+
+//containerListActions are the actions of container events that change
+//what the container list shows
+var containerListActions = map[string]bool{
+	"create":  true,
+	"start":   true,
+	"restart": true,
+	"die":     true,
+	"kill":    true,
+	"stop":    true,
+	"pause":   true,
+	"unpause": true,
+	"rename":  true,
+	"update":  true,
+	"destroy": true,
+	"oom":     true,
+}
+
+//containerEvents applies container events to the container store, one at a
+//time, and tells the listeners about the containers changed
+type containerEvents struct {
+	listeners []func(ContainerEvent)
+	//when, in nanoseconds, the last event applied to each container happened,
+	//events older than it are out of date by the time they are handled
+	last map[string]int64
+	sync.Mutex
+}
+
+//changesList returns true if the given event changes what the container
+//list shows
+func changesList(message dockerEvents.Message) bool {
+	return containerListActions[message.Action] ||
+		strings.HasPrefix(message.Action, "health_status")
+}
+
+//OnContainerEvent registers the given func to be called with the containers
+//changed by the events of the daemon, once the change is on the container
+//list the daemon returns
+func (daemon *DockerDaemon) OnContainerEvent(f func(ContainerEvent)) {
+	daemon.containerEvents.Lock()
+	defer daemon.containerEvents.Unlock()
+	daemon.containerEvents.listeners = append(daemon.containerEvents.listeners, f)
+}
+
+//handleContainerEvent updates the container of the given event on the
+//container store, instead of retrieving the whole list again, and tells the
+//listeners about it
+func (daemon *DockerDaemon) handleContainerEvent(ctx context.Context, message dockerEvents.Message) error {
+	if !changesList(message) {
+		return nil
+	}
+	event, listeners, err := daemon.applyContainerEvent(ctx, message)
+	if err != nil || event == nil {
+		return err
+	}
+	//listeners are called without holding the lock, so they can register
+	//other listeners
+	for _, f := range listeners {
+		f(*event)
+	}
+	return nil
+}
+
+//applyContainerEvent applies the given event to the container store and
+//returns the listeners to tell about it, the event is nil if it is out of date
+func (daemon *DockerDaemon) applyContainerEvent(ctx context.Context, message dockerEvents.Message) (*ContainerEvent, []func(ContainerEvent), error) {
+	id := message.Actor.ID
+	if id == "" {
+		id = message.ID
+	}
+	events := &daemon.containerEvents
+	events.Lock()
+	defer events.Unlock()
+	if events.last == nil {
+		events.last = make(map[string]int64)
+	}
+	if message.TimeNano < events.last[id] {
+		return nil, nil, nil
+	}
+	if message.Action == "destroy" {
+		delete(events.last, id)
+	} else {
+		events.last[id] = message.TimeNano
+	}
+
+	var c *Container
+	if message.Action != "destroy" {
+		ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
+		defer cancel()
+		var err error
+		c, err = listedContainer(ctx, daemon.client, id)
+		if err != nil {
+			//the whole list is retrieved again instead
+			if err := daemon.refreshStore(); err != nil {
+				return nil, nil, err
+			}
+			c = daemon.store().Get(id)
+		}
+	}
+	if c != nil {
+		daemon.store().Add(c)
+	} else {
+		daemon.store().Remove(id)
+	}
+	listeners := make([]func(ContainerEvent), len(events.listeners))
+	copy(listeners, events.listeners)
+	return &ContainerEvent{ID: id, Action: message.Action, Container: c}, listeners, nil
+}
+
+//listedContainer returns the container with the given id as the given
+//client lists it, nil if there is none
+func listedContainer(ctx context.Context, client dockerAPI.ContainerAPIClient, id string) (*Container, error) {
+	args := filters.NewArgs()
+	args.Add("id", id)
+	containers, err := client.ContainerList(ctx, dockerTypes.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range containers {
+		if c.ID != id {
+			continue
+		}
+		details, err := client.ContainerInspect(ctx, id)
+		if err != nil {
+			if dockerAPI.IsErrNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return &Container{c, details}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dockerEvents "github.com/docker/docker/api/types/events"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func containerMessage(id, action string, when int64) dockerEvents.Message {
+	return dockerEvents.Message{
+		Type:     "container",
+		Action:   action,
+		Actor:    dockerEvents.Actor{ID: id},
+		TimeNano: when,
+	}
+}
+
+func TestHandleContainerEvent(t *testing.T) {
+	client := &mock.ContainerEventsAPIClientMock{Containers: []types.Container{
+		{ID: "6dfafdbc3a40", State: "running", Status: "Up 2 hours"},
+	}}
+	store, err := NewDockerContainerStore(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	daemon := &DockerDaemon{client: client, s: store}
+	var events []ContainerEvent
+	daemon.OnContainerEvent(func(e ContainerEvent) {
+		events = append(events, e)
+	})
+	ctx := context.Background()
+
+	client.Containers = append(client.Containers, types.Container{ID: "3e1ca6c5b1f2", State: "running", Status: "Up 1 second"})
+	if err := daemon.handleContainerEvent(ctx, containerMessage("3e1ca6c5b1f2", "start", 1)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if c := daemon.ContainerByID("3e1ca6c5b1f2"); c == nil || !IsContainerRunning(c) {
+		t.Errorf("The started container was not added to the list: %v", c)
+	}
+	if len(events) != 1 || events[0].Action != "start" || events[0].Container == nil {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+
+	//an event older than the last one handled is out of date
+	daemon.handleContainerEvent(ctx, containerMessage("3e1ca6c5b1f2", "die", 0))
+	if c := daemon.ContainerByID("3e1ca6c5b1f2"); len(events) != 1 || c == nil || !IsContainerRunning(c) {
+		t.Errorf("An out of date event was applied: %+v", events)
+	}
+
+	client.Containers[0].State = "exited"
+	client.Containers[0].Status = "Exited (0) 1 second ago"
+	daemon.handleContainerEvent(ctx, containerMessage("6dfafdbc3a40", "die", 2))
+	if c := daemon.ContainerByID("6dfafdbc3a40"); c == nil || IsContainerRunning(c) {
+		t.Errorf("The container that died was not updated: %v", c)
+	}
+
+	//events that do not change the list are ignored
+	daemon.handleContainerEvent(ctx, containerMessage("6dfafdbc3a40", "exec_start: sh", 3))
+	if len(events) != 2 {
+		t.Errorf("Unexpected number of events: %d", len(events))
+	}
+
+	//listeners can register other listeners
+	daemon.OnContainerEvent(func(e ContainerEvent) {
+		daemon.OnContainerEvent(func(ContainerEvent) {})
+	})
+	client.Containers = client.Containers[1:]
+	daemon.handleContainerEvent(ctx, containerMessage("6dfafdbc3a40", "destroy", 4))
+	if c := daemon.ContainerByID("6dfafdbc3a40"); c != nil {
+		t.Errorf("The removed container is still listed: %v", c)
+	}
+	if last := events[len(events)-1]; last.Action != "destroy" || last.Container != nil {
+		t.Errorf("Unexpected event: %+v", last)
+	}
+
+	if _, ok := daemon.containerEvents.last["6dfafdbc3a40"]; ok {
+		t.Error("The removed container is still tracked")
+	}
+	if client.FullLists != 1 {
+		t.Errorf("The container list was retrieved %d times, want once", client.FullLists)
+	}
+}
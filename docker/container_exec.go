@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//ExecOptions describes a command to run in a running container, as docker exec does
+type ExecOptions struct {
+	Command    string
+	User       string
+	WorkingDir string
+	Env        []string
+}
+
+//Validate checks the values of the given options
+func (o ExecOptions) Validate() error {
+	if _, err := SplitCommand(o.Command); err != nil {
+		return err
+	}
+	return ValidateEnv(o.Env)
+}
+
+//Exec runs the command of the given options in the container with the given
+//id. The output of the command, stdout and stderr multiplexed as the Docker
+//API sends them, is returned as a stream that ends once the command ends.
+func (daemon *DockerDaemon) Exec(id string, options ExecOptions) (io.ReadCloser, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	cmd, _ := SplitCommand(options.Command)
+	config := types.ExecConfig{
+		User:         options.User,
+		WorkingDir:   options.WorkingDir,
+		Env:          options.Env,
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	exec, err := daemon.client.ContainerExecCreate(ctx, id, config)
+	if err != nil {
+		return nil, err
+	}
+	//the output is read after this function returns
+	resp, err := daemon.client.ContainerExecAttach(context.Background(), exec.ID, config)
+	if err != nil {
+		return nil, err
+	}
+	return &execOutput{Reader: resp.Reader, close: resp.Close}, nil
+}
+
+//execOutput is the output of a command run in a container, closing it
+//closes the connection to the daemon
+type execOutput struct {
+	io.Reader
+	close func()
+}
+
+func (o *execOutput) Close() error {
+	o.close()
+	return nil
+}
+
+//SplitCommand splits the given command line into its arguments, arguments
+//are separated by spaces unless quoted with single or double quotes
+func SplitCommand(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	if len(args) == 0 {
+		return nil, errors.New("a command is required")
+	}
+	return args, nil
+}
@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+		wantErr bool
+	}{
+		{"ls -l /tmp", []string{"ls", "-l", "/tmp"}, false},
+		{"  ps   aux ", []string{"ps", "aux"}, false},
+		{`sh -c "ls -l | wc -l"`, []string{"sh", "-c", "ls -l | wc -l"}, false},
+		{`echo 'it"s' ""`, []string{"echo", `it"s`, ""}, false},
+		{`echo "unterminated`, nil, true},
+		{"   ", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := SplitCommand(tt.command)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitCommand(%q) error = %v, wantErr %v", tt.command, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	client := &mock.ContainerExecAPIClientMock{Output: "output"}
+	daemon := &DockerDaemon{client: client}
+
+	output, err := daemon.Exec("6dfafdbc3a40", ExecOptions{
+		Command:    "cat /etc/hostname",
+		User:       "www-data",
+		WorkingDir: "/srv",
+		Env:        []string{"DEBUG=1"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer output.Close()
+	if b, _ := ioutil.ReadAll(output); string(b) != "output" {
+		t.Errorf("Unexpected output: %q", b)
+	}
+	config := client.Configs[0]
+	if !reflect.DeepEqual([]string(config.Cmd), []string{"cat", "/etc/hostname"}) ||
+		config.User != "www-data" || config.WorkingDir != "/srv" ||
+		!reflect.DeepEqual(config.Env, []string{"DEBUG=1"}) {
+		t.Errorf("Unexpected exec configuration: %+v", config)
+	}
+
+	if _, err := daemon.Exec("6dfafdbc3a40", ExecOptions{Command: "env", Env: []string{"DEBUG"}}); err == nil {
+		t.Error("Exec with an invalid environment must fail")
+	}
+	if len(client.Configs) != 1 {
+		t.Error("The daemon was asked to run a command with invalid options")
+	}
+}
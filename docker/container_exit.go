@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+//defaultStopSignal is the signal docker stop sends to containers that do
+//not define their own
+const defaultStopSignal = "SIGTERM"
+
+//ContainerExit tells how a container exited
+type ContainerExit struct {
+	StatusCode int64
+	//After is how long the container took to exit once it was signalled
+	After time.Duration
+}
+
+//ExitTimeoutError is returned when a container does not exit in time
+type ExitTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *ExitTimeoutError) Error() string {
+	return fmt.Sprintf("did not exit within %s", e.Timeout)
+}
+
+//StopAndWait sends the stop signal of the container with the given id, as
+//docker stop does, and waits for the container to exit for up to the given
+//timeout. Unlike StopContainer, the container is not killed if it does not
+//exit in time, an *ExitTimeoutError is returned instead.
+func (daemon *DockerDaemon) StopAndWait(id string, timeout time.Duration) (ContainerExit, error) {
+	signal := defaultStopSignal
+	if c, err := daemon.Inspect(id); err == nil && c.Config != nil && c.Config.StopSignal != "" {
+		signal = c.Config.StopSignal
+	}
+	return daemon.signalAndWait(id, signal, timeout)
+}
+
+//KillAndWait kills the container with the given id and waits for it to
+//exit for up to the given timeout
+func (daemon *DockerDaemon) KillAndWait(id string, timeout time.Duration) (ContainerExit, error) {
+	//the daemon sends SIGKILL if no signal is given
+	return daemon.signalAndWait(id, "", timeout)
+}
+
+func (daemon *DockerDaemon) signalAndWait(id, signal string, timeout time.Duration) (ContainerExit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	start := time.Now()
+	//waiting for the container to not be running also covers a container
+	//that exits before the wait reaches the daemon
+	exits, errs := daemon.client.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+	if err := daemon.client.ContainerKill(ctx, id, signal); err != nil {
+		return ContainerExit{}, err
+	}
+	select {
+	case exit := <-exits:
+		if exit.Error != nil && exit.Error.Message != "" {
+			return ContainerExit{}, errors.New(exit.Error.Message)
+		}
+		return ContainerExit{StatusCode: exit.StatusCode, After: time.Since(start)}, daemon.refreshAndWait()
+	case err := <-errs:
+		if ctx.Err() == context.DeadlineExceeded {
+			return ContainerExit{}, &ExitTimeoutError{Timeout: timeout}
+		}
+		return ContainerExit{}, err
+	}
+}
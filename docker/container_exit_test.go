@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestSignalAndWait(t *testing.T) {
+	tests := []struct {
+		name        string
+		kill        bool
+		stopSignal  string
+		exit        *container.ContainerWaitOKBody
+		wantSignals []string
+		wantCode    int64
+		wantTimeout bool
+	}{
+		{"stop", false, "", &container.ContainerWaitOKBody{StatusCode: 0}, []string{"SIGTERM"}, 0, false},
+		{"stop with the signal of the container", false, "SIGQUIT", &container.ContainerWaitOKBody{StatusCode: 3}, []string{"SIGQUIT"}, 3, false},
+		{"stop times out", false, "", nil, []string{"SIGTERM"}, 0, true},
+		{"kill", true, "SIGQUIT", &container.ContainerWaitOKBody{StatusCode: 137}, []string{""}, 137, false},
+		{"kill times out", true, "", nil, []string{""}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mock.ContainerWaitAPIClientMock{StopSignal: tt.stopSignal, Exit: tt.exit}
+			daemon := &DockerDaemon{client: client}
+			timeout := 50 * time.Millisecond
+			var exit ContainerExit
+			var err error
+			if tt.kill {
+				exit, err = daemon.KillAndWait("web", timeout)
+			} else {
+				exit, err = daemon.StopAndWait("web", timeout)
+			}
+			if got := client.Signals(); !reflect.DeepEqual(got, tt.wantSignals) {
+				t.Errorf("Signals sent = %q, want %q", got, tt.wantSignals)
+			}
+			if tt.wantTimeout {
+				timeoutErr, ok := err.(*ExitTimeoutError)
+				if !ok {
+					t.Fatalf("Expected a timeout, got %v", err)
+				}
+				if timeoutErr.Timeout != timeout {
+					t.Errorf("Timeout = %s, want %s", timeoutErr.Timeout, timeout)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if exit.StatusCode != tt.wantCode {
+				t.Errorf("Exit code = %d, want %d", exit.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
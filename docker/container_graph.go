@@ -0,0 +1,246 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+//Labels set by docker-compose on the containers it creates
+const (
+	composeProjectLabel   = "com.docker.compose.project"
+	composeServiceLabel   = "com.docker.compose.service"
+	composeDependsOnLabel = "com.docker.compose.depends_on"
+)
+
+//ContainerLink is a resource, a network or a volume, shared by containers
+type ContainerLink struct {
+	Name       string
+	Containers []string
+}
+
+//ContainerLinks describes how a container relates to other containers
+type ContainerLinks struct {
+	//Networks the container is connected to and the other containers on each of them
+	Networks []ContainerLink
+	//Volumes and bind sources the container mounts and the other containers mounting them
+	Volumes []ContainerLink
+	//DependsOn lists the containers the container depends on, as declared with compose depends_on
+	DependsOn []string
+	//RequiredBy lists the containers that depend on the container
+	RequiredBy []string
+	//VolumesFrom lists the containers the container mounts the volumes of, as given with --volumes-from
+	VolumesFrom []string
+	//VolumesUsedBy lists the containers that mount the volumes of the container with --volumes-from
+	VolumesUsedBy []string
+}
+
+//ContainerGraph relates containers through the networks they are connected
+//to, the volumes they share, the containers they take volumes from and the
+//compose depends_on relationships between them
+type ContainerGraph struct {
+	containers  map[string]types.ContainerJSON
+	networks    map[string][]string
+	volumes     map[string][]string
+	dependsOn   map[string][]string
+	volumesFrom map[string][]string
+}
+
+//NewContainerGraph creates the graph of the given containers
+func NewContainerGraph(containers []types.ContainerJSON) *ContainerGraph {
+	g := &ContainerGraph{
+		containers:  make(map[string]types.ContainerJSON),
+		networks:    make(map[string][]string),
+		volumes:     make(map[string][]string),
+		dependsOn:   make(map[string][]string),
+		volumesFrom: make(map[string][]string),
+	}
+	services := make(map[string][]string)
+	for _, c := range containers {
+		if c.ContainerJSONBase == nil {
+			continue
+		}
+		g.containers[c.ID] = c
+		if c.NetworkSettings != nil {
+			for name := range c.NetworkSettings.Networks {
+				g.networks[name] = append(g.networks[name], c.ID)
+			}
+		}
+		for _, volume := range volumeSources(c) {
+			g.volumes[volume] = append(g.volumes[volume], c.ID)
+		}
+		if project, service := composeService(c); service != "" {
+			services[project+"/"+service] = append(services[project+"/"+service], c.ID)
+		}
+	}
+	for id, c := range g.containers {
+		project, _ := composeService(c)
+		for _, dependency := range composeDependencies(c) {
+			g.dependsOn[id] = append(g.dependsOn[id], services[project+"/"+dependency]...)
+		}
+		if c.HostConfig == nil {
+			continue
+		}
+		for _, from := range c.HostConfig.VolumesFrom {
+			if other, ok := g.lookup(volumesFromContainer(from)); ok {
+				g.volumesFrom[id] = append(g.volumesFrom[id], other)
+			}
+		}
+	}
+	return g
+}
+
+//Container returns the container with the given id, false if the graph does not have it
+func (g *ContainerGraph) Container(id string) (types.ContainerJSON, bool) {
+	c, ok := g.containers[id]
+	return c, ok
+}
+
+//Name returns the name of the container with the given id, its short id
+//if the graph does not have it
+func (g *ContainerGraph) Name(id string) string {
+	if c, ok := g.containers[id]; ok && c.Name != "" {
+		return strings.TrimPrefix(c.Name, "/")
+	}
+	return TruncateID(id)
+}
+
+//Links returns how the container with the given id relates to the other
+//containers of the graph. Networks, volumes and containers are sorted by name.
+func (g *ContainerGraph) Links(id string) ContainerLinks {
+	var links ContainerLinks
+	c, ok := g.containers[id]
+	if !ok {
+		return links
+	}
+	if c.NetworkSettings != nil {
+		for name := range c.NetworkSettings.Networks {
+			links.Networks = append(links.Networks, ContainerLink{name, g.others(id, g.networks[name])})
+		}
+	}
+	for _, volume := range volumeSources(c) {
+		links.Volumes = append(links.Volumes, ContainerLink{volume, g.others(id, g.volumes[volume])})
+	}
+	sort.Slice(links.Networks, func(i, j int) bool { return links.Networks[i].Name < links.Networks[j].Name })
+	sort.Slice(links.Volumes, func(i, j int) bool { return links.Volumes[i].Name < links.Volumes[j].Name })
+
+	links.DependsOn = g.others(id, g.dependsOn[id])
+	var requiredBy []string
+	for dependent, dependencies := range g.dependsOn {
+		for _, dependency := range dependencies {
+			if dependency == id {
+				requiredBy = append(requiredBy, dependent)
+				break
+			}
+		}
+	}
+	links.RequiredBy = g.others(id, requiredBy)
+
+	links.VolumesFrom = g.others(id, g.volumesFrom[id])
+	var usedBy []string
+	for other, sources := range g.volumesFrom {
+		for _, source := range sources {
+			if source == id {
+				usedBy = append(usedBy, other)
+				break
+			}
+		}
+	}
+	links.VolumesUsedBy = g.others(id, usedBy)
+	return links
+}
+
+//lookup returns the id of the container of the graph with the given name,
+//id or id prefix
+func (g *ContainerGraph) lookup(ref string) (string, bool) {
+	if ref == "" {
+		return "", false
+	}
+	if _, ok := g.containers[ref]; ok {
+		return ref, true
+	}
+	for id, c := range g.containers {
+		if strings.TrimPrefix(c.Name, "/") == ref {
+			return id, true
+		}
+	}
+	for id := range g.containers {
+		if strings.HasPrefix(id, ref) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+//others returns the given containers but the one with the given id, sorted by name
+func (g *ContainerGraph) others(id string, containers []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, other := range containers {
+		if other != id && !seen[other] {
+			seen[other] = true
+			result = append(result, other)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return g.Name(result[i]) < g.Name(result[j]) })
+	return result
+}
+
+//volumeSources returns the volume names and host paths mounted by the given container
+func volumeSources(c types.ContainerJSON) []string {
+	var sources []string
+	for _, m := range c.Mounts {
+		switch {
+		case m.Type == mount.TypeVolume && m.Name != "":
+			sources = append(sources, m.Name)
+		case m.Source != "":
+			sources = append(sources, m.Source)
+		}
+	}
+	return sources
+}
+
+//volumesFromContainer returns the container of the given --volumes-from
+//value, "container[:ro|rw]"
+func volumesFromContainer(from string) string {
+	return strings.SplitN(from, ":", 2)[0]
+}
+
+//ComposeProject returns the compose project of the given container, empty
+//if it was not created by compose
+func ComposeProject(c *Container) string {
+	if c == nil {
+		return ""
+	}
+	labels := c.Container.Labels
+	if labels == nil && c.ContainerJSON.Config != nil {
+		labels = c.ContainerJSON.Config.Labels
+	}
+	return labels[composeProjectLabel]
+}
+
+//composeService returns the compose project and service of the given container,
+//empty if it was not created by compose
+func composeService(c types.ContainerJSON) (string, string) {
+	if c.Config == nil {
+		return "", ""
+	}
+	return c.Config.Labels[composeProjectLabel], c.Config.Labels[composeServiceLabel]
+}
+
+//composeDependencies returns the services the given container depends on. The
+//label lists them as "service:condition[:restart]", separated by commas.
+func composeDependencies(c types.ContainerJSON) []string {
+	if c.Config == nil {
+		return nil
+	}
+	var services []string
+	for _, dependency := range strings.Split(c.Config.Labels[composeDependsOnLabel], ",") {
+		if service := strings.TrimSpace(strings.SplitN(dependency, ":", 2)[0]); service != "" {
+			services = append(services, service)
+		}
+	}
+	return services
+}
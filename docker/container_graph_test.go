@@ -0,0 +1,139 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+)
+
+func graphContainer(id, name string, networks []string, mounts []types.MountPoint, labels map[string]string) types.ContainerJSON {
+	endpoints := make(map[string]*network.EndpointSettings)
+	for _, n := range networks {
+		endpoints[n] = &network.EndpointSettings{}
+	}
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id, Name: "/" + name},
+		Config:            &container.Config{Labels: labels},
+		Mounts:            mounts,
+		NetworkSettings:   &types.NetworkSettings{Networks: endpoints},
+	}
+}
+
+func TestContainerGraph_Links(t *testing.T) {
+	data := []types.MountPoint{{Type: mount.TypeVolume, Name: "data", Destination: "/data"}}
+	compose := func(service, dependsOn string) map[string]string {
+		return map[string]string{
+			composeProjectLabel:   "shop",
+			composeServiceLabel:   service,
+			composeDependsOnLabel: dependsOn,
+		}
+	}
+	graph := NewContainerGraph([]types.ContainerJSON{
+		graphContainer("1", "web", []string{"front"}, nil, compose("web", "api:service_started:false")),
+		graphContainer("2", "api", []string{"front", "back"}, nil, compose("api", "db:service_healthy,cache:service_started")),
+		graphContainer("3", "db", []string{"back"}, data, compose("db", "")),
+		graphContainer("4", "backup", []string{"bridge"}, data, nil),
+		graphContainer("5", "cache", []string{"back"}, nil, compose("cache", "")),
+	})
+
+	tests := []struct {
+		id   string
+		want ContainerLinks
+	}{
+		{
+			"1",
+			ContainerLinks{
+				Networks:  []ContainerLink{{"front", []string{"2"}}},
+				DependsOn: []string{"2"},
+			},
+		},
+		{
+			"2",
+			ContainerLinks{
+				Networks: []ContainerLink{
+					{"back", []string{"5", "3"}},
+					{"front", []string{"1"}},
+				},
+				DependsOn:  []string{"5", "3"},
+				RequiredBy: []string{"1"},
+			},
+		},
+		{
+			"3",
+			ContainerLinks{
+				Networks:   []ContainerLink{{"back", []string{"2", "5"}}},
+				Volumes:    []ContainerLink{{"data", []string{"4"}}},
+				RequiredBy: []string{"2"},
+			},
+		},
+		{
+			"unknown",
+			ContainerLinks{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := graph.Links(tt.id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContainerGraph.Links() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerGraph_VolumesFrom(t *testing.T) {
+	volumesFrom := func(c types.ContainerJSON, from ...string) types.ContainerJSON {
+		c.HostConfig = &container.HostConfig{VolumesFrom: from}
+		return c
+	}
+	graph := NewContainerGraph([]types.ContainerJSON{
+		graphContainer("0123456789ab", "data", nil, nil, nil),
+		volumesFrom(graphContainer("2", "app", nil, nil, nil), "data:ro", "missing"),
+		volumesFrom(graphContainer("3", "backup", nil, nil, nil), "0123"),
+	})
+
+	tests := []struct {
+		id   string
+		want ContainerLinks
+	}{
+		{"0123456789ab", ContainerLinks{VolumesUsedBy: []string{"2", "3"}}},
+		{"2", ContainerLinks{VolumesFrom: []string{"0123456789ab"}}},
+		{"3", ContainerLinks{VolumesFrom: []string{"0123456789ab"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := graph.Links(tt.id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContainerGraph.Links() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeProject(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Container
+		want string
+	}{
+		{"nil", nil, ""},
+		{"not compose", &Container{Container: types.Container{Labels: map[string]string{"maintainer": "ops"}}}, ""},
+		{"listed", &Container{Container: types.Container{Labels: map[string]string{composeProjectLabel: "shop"}}}, "shop"},
+		{
+			"inspected",
+			&Container{ContainerJSON: types.ContainerJSON{Config: &container.Config{
+				Labels: map[string]string{composeProjectLabel: "blog"},
+			}}},
+			"blog",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComposeProject(tt.c); got != tt.want {
+				t.Errorf("ComposeProject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+//ContainerLimits are the settings of a container that docker update changes
+//without recreating the container
+type ContainerLimits struct {
+	//CPUShares is the relative CPU weight of the container, 0 if not set
+	CPUShares int64
+	//Memory is the memory limit of the container in bytes, 0 if unlimited
+	Memory int64
+	//RestartPolicy as docker run --restart expects it
+	RestartPolicy string
+}
+
+//Limits returns the limits of the given container
+func Limits(c *Container) ContainerLimits {
+	limits := ContainerLimits{RestartPolicy: FormatRestartPolicy(RestartPolicy(c))}
+	if c != nil && c.ContainerJSONBase != nil && c.ContainerJSON.HostConfig != nil {
+		limits.CPUShares = c.ContainerJSON.HostConfig.CPUShares
+		limits.Memory = c.ContainerJSON.HostConfig.Memory
+	}
+	return limits
+}
+
+//ParseCPUShares parses the given CPU shares, empty means not set
+func ParseCPUShares(shares string) (int64, error) {
+	shares = strings.TrimSpace(shares)
+	if shares == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseInt(shares, 10, 64)
+	if err != nil || value < 0 {
+		return 0, errors.New("CPU shares must be a positive number, i.e. 512")
+	}
+	return value, nil
+}
+
+//ParseMemoryLimit parses the given memory limit, as docker run --memory
+//expects it (i.e. 512m or 1g), empty means unlimited
+func ParseMemoryLimit(limit string) (int64, error) {
+	limit = strings.TrimSpace(limit)
+	if limit == "" {
+		return 0, nil
+	}
+	value, err := units.RAMInBytes(limit)
+	if err != nil || value < 0 {
+		return 0, errors.New("invalid memory limit, i.e. 512m or 1g")
+	}
+	return value, nil
+}
+
+//FormatMemoryLimit returns the given memory limit as ParseMemoryLimit
+//expects it, in the largest unit it is a whole number of, empty if unlimited
+func FormatMemoryLimit(limit int64) string {
+	if limit <= 0 {
+		return ""
+	}
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"g", units.GiB}, {"m", units.MiB}, {"k", units.KiB}} {
+		if limit%unit.size == 0 {
+			return strconv.FormatInt(limit/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+//UpdateLimits changes the limits of the container with the given id. Docker
+//cannot remove a limit this way, limits left to 0 keep their current value.
+//The container is updated in place, the returned container has the new limits.
+func (daemon *DockerDaemon) UpdateLimits(id string, limits ContainerLimits) (*Container, error) {
+	restartPolicy, err := parseRestartPolicy(limits.RestartPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if restartPolicy.Name == "" {
+		restartPolicy.Name = "no"
+	}
+	return daemon.update(id, container.UpdateConfig{
+		Resources: container.Resources{
+			CPUShares: limits.CPUShares,
+			Memory:    limits.Memory,
+		},
+		RestartPolicy: restartPolicy,
+	})
+}
+
+//update applies the given update to the container with the given id, the
+//container is inspected again and updated on the container list
+func (daemon *DockerDaemon) update(id string, update container.UpdateConfig) (*Container, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if _, err := daemon.client.ContainerUpdate(ctx, id, update); err != nil {
+		return nil, err
+	}
+	details, err := daemon.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated := &Container{ContainerJSON: details}
+	if c := daemon.store().Get(id); c != nil {
+		updated.Container = c.Container
+	}
+	daemon.store().Add(updated)
+	return updated, nil
+}
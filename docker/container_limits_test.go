@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestLimits(t *testing.T) {
+	c := &Container{
+		ContainerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				HostConfig: &container.HostConfig{
+					RestartPolicy: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+					Resources:     container.Resources{CPUShares: 512, Memory: 256 * 1024 * 1024},
+				},
+			},
+		},
+	}
+	want := ContainerLimits{CPUShares: 512, Memory: 256 * 1024 * 1024, RestartPolicy: "on-failure:3"}
+	if got := Limits(c); got != want {
+		t.Errorf("Limits() = %+v, want %+v", got, want)
+	}
+	if got := Limits(&Container{}); got != (ContainerLimits{RestartPolicy: "no"}) {
+		t.Errorf("Limits() of a container not inspected = %+v", got)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	memoryTests := []struct {
+		limit   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"512m", 512 * 1024 * 1024, false},
+		{" 1g ", 1024 * 1024 * 1024, false},
+		{"a lot", 0, true},
+	}
+	for _, tt := range memoryTests {
+		got, err := ParseMemoryLimit(tt.limit)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMemoryLimit(%q) = %d, %v, want %d", tt.limit, got, err, tt.want)
+		}
+	}
+	if got, err := ParseMemoryLimit(FormatMemoryLimit(1536 * 1024 * 1024)); err != nil || got != 1536*1024*1024 {
+		t.Errorf("A formatted memory limit does not parse to itself, got %d, %v", got, err)
+	}
+	formatTests := map[int64]string{0: "", 1536 * 1024 * 1024: "1536m", 2 * 1024 * 1024 * 1024: "2g", 1000: "1000"}
+	for limit, want := range formatTests {
+		if got := FormatMemoryLimit(limit); got != want {
+			t.Errorf("FormatMemoryLimit(%d) = %q, want %q", limit, got, want)
+		}
+	}
+
+	sharesTests := []struct {
+		shares  string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1024", 1024, false},
+		{"-2", 0, true},
+		{"half", 0, true},
+	}
+	for _, tt := range sharesTests {
+		got, err := ParseCPUShares(tt.shares)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCPUShares(%q) = %d, %v, want %d", tt.shares, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package docker
+
+import (
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+//VolumeFilterPrefix is the prefix of the container list filters that match
+//the containers using a named volume (i.e. volume:data)
+const VolumeFilterPrefix = "volume:"
+
+//ContainerMounts returns the mounts of the given container sorted by
+//destination, as the container was inspected
+func ContainerMounts(c types.ContainerJSON) []types.MountPoint {
+	mounts := append([]types.MountPoint(nil), c.Mounts...)
+	sort.SliceStable(mounts, func(i, j int) bool {
+		return mounts[i].Destination < mounts[j].Destination
+	})
+	return mounts
+}
+
+//UsesVolume returns true if the given container mounts the volume with
+//the given name
+func UsesVolume(c *Container, name string) bool {
+	if c == nil || name == "" {
+		return false
+	}
+	for _, mounts := range [][]types.MountPoint{c.Container.Mounts, c.ContainerJSON.Mounts} {
+		for _, m := range mounts {
+			if m.Type == mount.TypeVolume && m.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//MountMode returns RW if the given mount is writable, RO otherwise
+func MountMode(m types.MountPoint) string {
+	if m.RW {
+		return "RW"
+	}
+	return "RO"
+}
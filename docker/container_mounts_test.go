@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+func TestContainerMounts(t *testing.T) {
+	c := types.ContainerJSON{
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Destination: "/var/lib/data", Driver: "local", RW: true},
+			{Type: mount.TypeBind, Source: "/etc/app", Destination: "/etc/app"},
+			{Type: mount.TypeTmpfs, Destination: "/tmp", RW: true},
+		},
+	}
+	var got []string
+	for _, m := range ContainerMounts(c) {
+		got = append(got, m.Destination)
+	}
+	want := []string{"/etc/app", "/tmp", "/var/lib/data"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ContainerMounts() = %v, want %v", got, want)
+	}
+	if c.Mounts[0].Destination != "/var/lib/data" {
+		t.Error("The mounts of the container must not be sorted in place")
+	}
+}
+
+func TestUsesVolume(t *testing.T) {
+	listed := &Container{
+		Container: types.Container{
+			Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "data"}},
+		},
+	}
+	inspected := &Container{
+		ContainerJSON: types.ContainerJSON{
+			Mounts: []types.MountPoint{{Type: mount.TypeVolume, Name: "cache"}},
+		},
+	}
+	bound := &Container{
+		Container: types.Container{
+			Mounts: []types.MountPoint{{Type: mount.TypeBind, Name: "data", Source: "/srv/data"}},
+		},
+	}
+	tests := []struct {
+		name      string
+		container *Container
+		volume    string
+		want      bool
+	}{
+		{"listed container", listed, "data", true},
+		{"inspected container", inspected, "cache", true},
+		{"other volume", listed, "cache", false},
+		{"bind mounts are not volumes", bound, "data", false},
+		{"no volume name", listed, "", false},
+		{"no container", nil, "data", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UsesVolume(tt.container, tt.volume); got != tt.want {
+				t.Errorf("UsesVolume() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
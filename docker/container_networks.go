@@ -0,0 +1,40 @@
+package docker
+
+import (
+	"sort"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+//ContainerAddress returns the primary IP address of the given container and
+//the names of the networks it is attached to, sorted. The primary address is
+//the one on the network of its network mode or, if it has none there, the
+//one on the first network that gives it an address.
+func ContainerAddress(c *Container) (string, []string) {
+	if c == nil {
+		return "", nil
+	}
+	var endpoints map[string]*network.EndpointSettings
+	if c.Container.NetworkSettings != nil {
+		endpoints = c.Container.NetworkSettings.Networks
+	}
+	if len(endpoints) == 0 && c.ContainerJSON.NetworkSettings != nil {
+		endpoints = c.ContainerJSON.NetworkSettings.Networks
+	}
+	mode := c.Container.HostConfig.NetworkMode
+	networks := make([]string, 0, len(endpoints))
+	for name := range endpoints {
+		networks = append(networks, name)
+	}
+	sort.Strings(networks)
+
+	if e := endpoints[mode]; e != nil && e.IPAddress != "" {
+		return e.IPAddress, networks
+	}
+	for _, name := range networks {
+		if e := endpoints[name]; e != nil && e.IPAddress != "" {
+			return e.IPAddress, networks
+		}
+	}
+	return "", networks
+}
@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestContainerAddress(t *testing.T) {
+	attached := func(mode string, endpoints map[string]*network.EndpointSettings) *Container {
+		c := &Container{Container: types.Container{NetworkSettings: &types.SummaryNetworkSettings{Networks: endpoints}}}
+		c.Container.HostConfig.NetworkMode = mode
+		return c
+	}
+	tests := []struct {
+		name      string
+		container *Container
+		ip        string
+		networks  []string
+	}{
+		{"network mode first",
+			attached("proxy", map[string]*network.EndpointSettings{
+				"app_default": {IPAddress: "172.18.0.3"},
+				"proxy":       {IPAddress: "172.19.0.2"},
+			}),
+			"172.19.0.2", []string{"app_default", "proxy"}},
+		{"first network with an address",
+			attached("default", map[string]*network.EndpointSettings{
+				"bridge": {IPAddress: "172.17.0.2"},
+				"app":    {},
+			}),
+			"172.17.0.2", []string{"app", "bridge"}},
+		{"not running", attached("bridge", map[string]*network.EndpointSettings{"bridge": {}}), "", []string{"bridge"}},
+		{"host network", attached("host", map[string]*network.EndpointSettings{"host": {}}), "", []string{"host"}},
+	}
+	for _, test := range tests {
+		ip, networks := ContainerAddress(test.container)
+		if ip != test.ip || !reflect.DeepEqual(networks, test.networks) {
+			t.Errorf("%s: ContainerAddress() = %q, %v, want %q, %v", test.name, ip, networks, test.ip, test.networks)
+		}
+	}
+	if ip, networks := ContainerAddress(nil); ip != "" || networks != nil {
+		t.Errorf("ContainerAddress() of no container = %q, %v", ip, networks)
+	}
+}
@@ -0,0 +1,86 @@
+package docker
+
+import "sort"
+
+//OperationOrder is the order in which to operate on a set of containers that
+//depend on each other, as declared with compose depends_on
+type OperationOrder struct {
+	//Stages are run one after the other, the containers of a stage do not
+	//depend on each other so they can be operated on in parallel
+	Stages [][]string
+	//Cycle are the containers whose dependencies form a cycle, or depend on
+	//one, they are operated on after the stages in no particular order
+	Cycle []string
+}
+
+//Len returns the number of containers to operate on
+func (o OperationOrder) Len() int {
+	n := len(o.Cycle)
+	for _, stage := range o.Stages {
+		n += len(stage)
+	}
+	return n
+}
+
+//StopOrder returns the order in which to stop the containers with the given
+//ids, the containers that depend on others are stopped first. Only the
+//dependencies between the given containers are taken into account.
+func (g *ContainerGraph) StopOrder(ids []string) OperationOrder {
+	requiredBy := make(map[string][]string)
+	for _, id := range ids {
+		for _, dependency := range g.dependsOn[id] {
+			requiredBy[dependency] = append(requiredBy[dependency], id)
+		}
+	}
+	return g.order(ids, requiredBy)
+}
+
+//StartOrder returns the order in which to start, or restart, the containers
+//with the given ids, the containers that others depend on are started first.
+//Only the dependencies between the given containers are taken into account.
+func (g *ContainerGraph) StartOrder(ids []string) OperationOrder {
+	return g.order(ids, g.dependsOn)
+}
+
+//order sorts the given containers in stages so that every container comes
+//after the containers it waits for, as given by waitsFor
+func (g *ContainerGraph) order(ids []string, waitsFor map[string][]string) OperationOrder {
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+	var order OperationOrder
+	for len(pending) > 0 {
+		var stage []string
+		for id := range pending {
+			ready := true
+			for _, other := range waitsFor[id] {
+				if other != id && pending[other] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				stage = append(stage, id)
+			}
+		}
+		if len(stage) == 0 {
+			break
+		}
+		for _, id := range stage {
+			delete(pending, id)
+		}
+		order.Stages = append(order.Stages, g.sortedByName(stage))
+	}
+	for id := range pending {
+		order.Cycle = append(order.Cycle, id)
+	}
+	order.Cycle = g.sortedByName(order.Cycle)
+	return order
+}
+
+//sortedByName sorts the given containers by name
+func (g *ContainerGraph) sortedByName(ids []string) []string {
+	sort.Slice(ids, func(i, j int) bool { return g.Name(ids[i]) < g.Name(ids[j]) })
+	return ids
+}
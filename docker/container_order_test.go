@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestContainerGraph_Order(t *testing.T) {
+	compose := func(service, dependsOn string) map[string]string {
+		return map[string]string{
+			composeProjectLabel:   "shop",
+			composeServiceLabel:   service,
+			composeDependsOnLabel: dependsOn,
+		}
+	}
+	graph := NewContainerGraph([]types.ContainerJSON{
+		graphContainer("1", "web", nil, nil, compose("web", "api:service_started")),
+		graphContainer("2", "api", nil, nil, compose("api", "db:service_healthy,cache:service_started")),
+		graphContainer("3", "db", nil, nil, compose("db", "")),
+		graphContainer("4", "backup", nil, nil, nil),
+		graphContainer("5", "cache", nil, nil, compose("cache", "")),
+		graphContainer("6", "worker", nil, nil, compose("worker", "api:service_started")),
+	})
+
+	tests := []struct {
+		name  string
+		ids   []string
+		stop  OperationOrder
+		start OperationOrder
+	}{
+		{
+			"whole project",
+			[]string{"1", "2", "3", "4", "5", "6"},
+			OperationOrder{Stages: [][]string{{"4", "1", "6"}, {"2"}, {"5", "3"}}},
+			OperationOrder{Stages: [][]string{{"4", "5", "3"}, {"2"}, {"1", "6"}}},
+		},
+		{
+			"dependencies outside the selection are ignored",
+			[]string{"1", "3"},
+			OperationOrder{Stages: [][]string{{"3", "1"}}},
+			OperationOrder{Stages: [][]string{{"3", "1"}}},
+		},
+		{
+			"chain",
+			[]string{"1", "2", "3"},
+			OperationOrder{Stages: [][]string{{"1"}, {"2"}, {"3"}}},
+			OperationOrder{Stages: [][]string{{"3"}, {"2"}, {"1"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graph.StopOrder(tt.ids); !reflect.DeepEqual(got, tt.stop) {
+				t.Errorf("StopOrder() = %v, want %v", got, tt.stop)
+			}
+			if got := graph.StartOrder(tt.ids); !reflect.DeepEqual(got, tt.start) {
+				t.Errorf("StartOrder() = %v, want %v", got, tt.start)
+			}
+		})
+	}
+}
+
+func TestContainerGraph_OrderWithCycles(t *testing.T) {
+	compose := func(service, dependsOn string) map[string]string {
+		return map[string]string{
+			composeProjectLabel:   "loop",
+			composeServiceLabel:   service,
+			composeDependsOnLabel: dependsOn,
+		}
+	}
+	graph := NewContainerGraph([]types.ContainerJSON{
+		graphContainer("1", "a", nil, nil, compose("a", "b:service_started")),
+		graphContainer("2", "b", nil, nil, compose("b", "a:service_started")),
+		graphContainer("3", "c", nil, nil, compose("c", "a:service_started")),
+		graphContainer("4", "d", nil, nil, compose("d", "")),
+	})
+	ids := []string{"1", "2", "3", "4"}
+
+	start := graph.StartOrder(ids)
+	want := OperationOrder{Stages: [][]string{{"4"}}, Cycle: []string{"1", "2", "3"}}
+	if !reflect.DeepEqual(start, want) {
+		t.Errorf("StartOrder() = %v, want %v", start, want)
+	}
+	stop := graph.StopOrder(ids)
+	want = OperationOrder{Stages: [][]string{{"3", "4"}}, Cycle: []string{"1", "2"}}
+	if !reflect.DeepEqual(stop, want) {
+		t.Errorf("StopOrder() = %v, want %v", stop, want)
+	}
+	if stop.Len() != len(ids) {
+		t.Errorf("Unexpected number of containers: %d", stop.Len())
+	}
+}
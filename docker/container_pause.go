@@ -0,0 +1,37 @@
+package docker
+
+import (
+	"context"
+	"strings"
+)
+
+//IsContainerPaused returns true if the given container is paused, the
+//processes of a paused container are frozen until it is unpaused
+func IsContainerPaused(container *Container) bool {
+	if container == nil {
+		return false
+	}
+	return container.Container.State == "paused" ||
+		strings.Contains(container.Status, "(Paused)")
+}
+
+//PauseContainer pauses the processes of the container with the given id
+func (daemon *DockerDaemon) PauseContainer(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.ContainerPause(ctx, id); err != nil {
+		return err
+	}
+	return daemon.refreshAndWait()
+}
+
+//UnpauseContainer resumes the processes of the paused container with the
+//given id
+func (daemon *DockerDaemon) UnpauseContainer(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.ContainerUnpause(ctx, id); err != nil {
+		return err
+	}
+	return daemon.refreshAndWait()
+}
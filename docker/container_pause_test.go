@@ -0,0 +1,31 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestIsContainerPaused(t *testing.T) {
+	tests := []struct {
+		name      string
+		container *Container
+		want      bool
+	}{
+		{"no container", nil, false},
+		{"running", &Container{Container: types.Container{State: "running", Status: "Up 2 hours"}}, false},
+		{"paused", &Container{Container: types.Container{State: "paused", Status: "Up 2 hours (Paused)"}}, true},
+		{"paused, without state", &Container{Container: types.Container{Status: "Up 5 seconds (Paused)"}}, true},
+		{"exited", &Container{Container: types.Container{State: "exited", Status: "Exited (0) 3 days ago"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsContainerPaused(tt.container); got != tt.want {
+				t.Errorf("IsContainerPaused() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !IsContainerRunning(&Container{Container: types.Container{Status: "Up 2 hours (Paused)"}}) {
+		t.Error("A paused container must be running")
+	}
+}
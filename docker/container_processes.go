@@ -0,0 +1,24 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+//processListArgs are the ps options used to list the processes of a
+//container, aux adds the CPU and memory usage of each process
+var processListArgs = []string{"aux"}
+
+//Processes returns the processes running in the container with the given
+//id, with their CPU and memory usage if the ps of the daemon host can tell them
+func (daemon *DockerDaemon) Processes(id string) (container.ContainerTopOKBody, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	top, err := daemon.client.ContainerTop(ctx, id, processListArgs)
+	if err != nil {
+		//Windows daemons, and some ps, do not take options
+		return daemon.client.ContainerTop(ctx, id, nil)
+	}
+	return top, nil
+}
@@ -0,0 +1,29 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestProcesses(t *testing.T) {
+	client := &mock.ContainerTopAPIClientMock{}
+	daemon := &DockerDaemon{client: client}
+
+	top, err := daemon.Processes("6dfafdbc3a40")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(top.Titles) < 3 || top.Titles[2] != "%CPU" {
+		t.Errorf("Processes were not listed with their CPU usage: %v", top.Titles)
+	}
+
+	client.RefuseArgs = true
+	top, err = daemon.Processes("6dfafdbc3a40")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(top.Processes) != 1 || top.Titles[len(top.Titles)-1] != "CMD" {
+		t.Errorf("Processes were not listed with the default ps options: %v", top)
+	}
+}
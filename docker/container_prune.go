@@ -0,0 +1,29 @@
+package docker
+
+import (
+	"context"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+//PruneContainers removes every stopped container, as docker container
+//prune does, it returns the ids of the containers removed and the space
+//reclaimed
+func (daemon *DockerDaemon) PruneContainers() (dockerTypes.ContainersPruneReport, error) {
+	report, err := daemon.client.ContainersPrune(context.Background(), filters.NewArgs())
+	if err != nil {
+		return report, err
+	}
+	return report, daemon.refreshAndWait()
+}
+
+//ReclaimableSize returns the space that removing the given containers
+//reclaims, the size of their writable layers, as the given sizes tell
+func ReclaimableSize(containers []*Container, sizes map[string]ContainerSize) int64 {
+	var size int64
+	for _, c := range containers {
+		size += sizes[c.ID].RW
+	}
+	return size
+}
@@ -0,0 +1,48 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestPruneContainers(t *testing.T) {
+	client := &mock.ContainersPruneAPIClientMock{Containers: []types.Container{
+		{ID: "6dfafdbc3a40", State: "running"},
+		{ID: "3e1ca6c5b1f2", State: "exited"},
+		{ID: "8c2b4d0e7a11", State: "created"},
+	}}
+	store, err := NewDockerContainerStore(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	daemon := &DockerDaemon{client: client, s: store, calls: newCallCoordinator()}
+
+	report, err := daemon.PruneContainers()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.ContainersDeleted) != 2 || report.SpaceReclaimed != 2048 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if daemon.ContainerCount() != 1 || daemon.ContainerByID("6dfafdbc3a40") == nil {
+		t.Errorf("The container list was not refreshed, %d containers", daemon.ContainerCount())
+	}
+}
+
+func TestReclaimableSize(t *testing.T) {
+	containers := []*Container{
+		{Container: types.Container{ID: "a"}},
+		{Container: types.Container{ID: "b"}},
+		{Container: types.Container{ID: "c"}},
+	}
+	sizes := map[string]ContainerSize{
+		"a": {RW: 1024, RootFs: 5000},
+		"b": {RW: 2048, RootFs: 7000},
+		"d": {RW: 4096},
+	}
+	if got := ReclaimableSize(containers, sizes); got != 3072 {
+		t.Errorf("ReclaimableSize() = %d, want 3072", got)
+	}
+}
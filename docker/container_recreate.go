@@ -0,0 +1,206 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	pkgError "github.com/pkg/errors"
+)
+
+//recreateTimeout is how long recreating a container can take once the old one
+//is about to be removed, pulling the image is not limited by it
+var recreateTimeout = time.Minute
+
+//containerBlueprint is what is needed to create a container again, it is
+//captured from the inspect data of the container
+type containerBlueprint struct {
+	name             string
+	config           container.Config
+	hostConfig       container.HostConfig
+	networkingConfig network.NetworkingConfig
+	//networks the container is connected to once created, only one can be
+	//given on creation
+	extraNetworks map[string]*network.EndpointSettings
+}
+
+//RecreateContainer replaces the container with the given id with a new one with
+//the same configuration: name, ports, env, mounts and networks. Optionally the
+//image of the container is pulled first, so the new one runs its latest version.
+//Anonymous volumes of the old container are attached to the new one. If the new
+//container cannot be created the old one is restored. Each step is reported to
+//the given func, the id of the new container is returned.
+func (daemon *DockerDaemon) RecreateContainer(ctx context.Context, id string, pull bool, step func(string)) (string, error) {
+	step("Inspecting container " + TruncateID(id))
+	c, err := daemon.Inspect(id)
+	if err != nil {
+		return "", err
+	}
+	if c.ContainerJSONBase == nil || c.Config == nil || c.HostConfig == nil {
+		return "", fmt.Errorf("Container %s has no configuration to recreate it from", TruncateID(id))
+	}
+	blueprint := newContainerBlueprint(c)
+	restore := newContainerBlueprint(c)
+	//the old container goes back to the image it was running, even if a newer one was pulled
+	restore.config.Image = c.Image
+
+	if pull {
+		step("Pulling image " + blueprint.config.Image)
+		if err := daemon.pull(ctx, blueprint.config.Image); err != nil {
+			return "", pkgError.Wrap(err, "Cannot pull image "+blueprint.config.Image)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	//from here on the job cannot be canceled, the old container would be lost
+	ctx, cancel := context.WithTimeout(context.Background(), recreateTimeout)
+	defer cancel()
+
+	running := c.State != nil && c.State.Running
+	if running {
+		step("Stopping container " + blueprint.name)
+		if err := daemon.client.ContainerStop(ctx, id, &containerOpTimeout); err != nil {
+			return "", err
+		}
+	}
+	step("Removing container " + blueprint.name)
+	if err := daemon.client.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
+		return "", err
+	}
+
+	step("Creating container " + blueprint.name)
+	newID, err := daemon.create(ctx, blueprint)
+	if err != nil {
+		step("Restoring container " + blueprint.name)
+		if newID != "" {
+			//created but not connected to its networks, it is in the way of the restored one
+			daemon.client.ContainerRemove(ctx, newID, types.ContainerRemoveOptions{Force: true})
+		}
+		restoredID, restoreErr := daemon.create(ctx, restore)
+		if restoreErr == nil && running {
+			restoreErr = daemon.client.ContainerStart(ctx, restoredID, types.ContainerStartOptions{})
+		}
+		daemon.refreshAndWait()
+		if restoreErr != nil {
+			return "", fmt.Errorf(
+				"Cannot create the new container: %s, restoring the old one failed too: %s", err, restoreErr)
+		}
+		return restoredID, pkgError.Wrap(err, "Cannot create the new container, the old one was restored")
+	}
+
+	step("Starting container " + blueprint.name)
+	if err := daemon.client.ContainerStart(ctx, newID, types.ContainerStartOptions{}); err != nil {
+		daemon.refreshAndWait()
+		return newID, pkgError.Wrap(err, "The new container was created but cannot be started")
+	}
+	return newID, daemon.refreshAndWait()
+}
+
+//create creates a container from the given blueprint and connects it to its networks
+func (daemon *DockerDaemon) create(ctx context.Context, blueprint containerBlueprint) (string, error) {
+	created, err := daemon.client.ContainerCreate(
+		ctx, &blueprint.config, &blueprint.hostConfig, &blueprint.networkingConfig, blueprint.name)
+	if err != nil {
+		return "", err
+	}
+	for name, settings := range blueprint.extraNetworks {
+		if err := daemon.client.NetworkConnect(ctx, name, created.ID, settings); err != nil {
+			return created.ID, pkgError.Wrap(err, "Cannot connect the container to network "+name)
+		}
+	}
+	return created.ID, nil
+}
+
+func (daemon *DockerDaemon) pull(ctx context.Context, image string) error {
+	progress, err := daemon.client.ImagePull(ctx, image, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer progress.Close()
+	_, err = io.Copy(ioutil.Discard, progress)
+	return err
+}
+
+//newContainerBlueprint captures, from the given inspect data, the configuration
+//to create the container again
+func newContainerBlueprint(c types.ContainerJSON) containerBlueprint {
+	shortID := TruncateID(c.ID)
+	blueprint := containerBlueprint{
+		name:          strings.TrimPrefix(c.Name, "/"),
+		config:        *c.Config,
+		hostConfig:    *c.HostConfig,
+		extraNetworks: make(map[string]*network.EndpointSettings),
+	}
+	//Docker uses the container id as hostname if none is given
+	if blueprint.config.Hostname == shortID {
+		blueprint.config.Hostname = ""
+	}
+	blueprint.hostConfig.Binds = append(
+		append([]string{}, c.HostConfig.Binds...), anonymousVolumeBinds(c)...)
+
+	if c.NetworkSettings == nil {
+		return blueprint
+	}
+	primary := string(c.HostConfig.NetworkMode)
+	if primary == "default" {
+		primary = "bridge"
+	}
+	for name, endpoint := range c.NetworkSettings.Networks {
+		if endpoint == nil {
+			continue
+		}
+		settings := &network.EndpointSettings{
+			IPAMConfig: endpoint.IPAMConfig,
+			Links:      endpoint.Links,
+		}
+		for _, alias := range endpoint.Aliases {
+			if alias != shortID {
+				settings.Aliases = append(settings.Aliases, alias)
+			}
+		}
+		if name == primary {
+			blueprint.networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{
+				name: settings,
+			}
+		} else {
+			blueprint.extraNetworks[name] = settings
+		}
+	}
+	return blueprint
+}
+
+//anonymousVolumeBinds returns the binds that attach the anonymous volumes of
+//the given container to the same paths, the volumes are reused instead of
+//creating new ones
+func anonymousVolumeBinds(c types.ContainerJSON) []string {
+	declared := make(map[string]bool)
+	for _, bind := range c.HostConfig.Binds {
+		if parts := strings.Split(bind, ":"); len(parts) > 1 {
+			declared[parts[1]] = true
+		}
+	}
+	for _, m := range c.HostConfig.Mounts {
+		declared[m.Target] = true
+	}
+	var binds []string
+	for _, m := range c.Mounts {
+		if m.Type != mount.TypeVolume || m.Name == "" || declared[m.Destination] {
+			continue
+		}
+		bind := m.Name + ":" + m.Destination
+		if !m.RW {
+			bind += ":ro"
+		}
+		binds = append(binds, bind)
+	}
+	return binds
+}
@@ -0,0 +1,110 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestNewContainerBlueprint(t *testing.T) {
+	id := "0123456789abcdef0123456789abcdef"
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:    id,
+			Name:  "/web",
+			Image: "sha256:cafe",
+			HostConfig: &container.HostConfig{
+				Binds:       []string{"/srv:/srv:ro"},
+				NetworkMode: "frontend",
+				Mounts: []mount.Mount{
+					{Type: mount.TypeVolume, Source: "named", Target: "/named"},
+				},
+			},
+		},
+		Config: &container.Config{
+			Hostname: "0123456789ab",
+			Image:    "nginx:latest",
+			Env:      []string{"KEY=value"},
+		},
+		Mounts: []types.MountPoint{
+			{Type: mount.TypeBind, Source: "/srv", Destination: "/srv"},
+			{Type: mount.TypeVolume, Name: "named", Destination: "/named", RW: true},
+			{Type: mount.TypeVolume, Name: "4f3c2a", Destination: "/var/cache", RW: true},
+			{Type: mount.TypeVolume, Name: "9a8b7c", Destination: "/etc/conf"},
+		},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"frontend": {
+					NetworkID: "n1",
+					IPAddress: "172.18.0.2",
+					Aliases:   []string{"web", "0123456789ab"},
+				},
+				"backend": {
+					NetworkID: "n2",
+					Aliases:   []string{"0123456789ab"},
+				},
+			},
+		},
+	}
+
+	blueprint := newContainerBlueprint(c)
+
+	if blueprint.name != "web" {
+		t.Errorf("Unexpected name: %s", blueprint.name)
+	}
+	if blueprint.config.Hostname != "" {
+		t.Errorf("Default hostname was kept: %s", blueprint.config.Hostname)
+	}
+	if blueprint.config.Image != "nginx:latest" || !reflect.DeepEqual(blueprint.config.Env, c.Config.Env) {
+		t.Errorf("Unexpected config: %+v", blueprint.config)
+	}
+	wantBinds := []string{"/srv:/srv:ro", "4f3c2a:/var/cache", "9a8b7c:/etc/conf:ro"}
+	if !reflect.DeepEqual(blueprint.hostConfig.Binds, wantBinds) {
+		t.Errorf("Unexpected binds, got %v, want %v", blueprint.hostConfig.Binds, wantBinds)
+	}
+	if len(c.HostConfig.Binds) != 1 {
+		t.Errorf("The binds of the inspected container were modified: %v", c.HostConfig.Binds)
+	}
+	frontend, ok := blueprint.networkingConfig.EndpointsConfig["frontend"]
+	if !ok || len(blueprint.networkingConfig.EndpointsConfig) != 1 {
+		t.Fatalf("Unexpected networking config: %v", blueprint.networkingConfig.EndpointsConfig)
+	}
+	if !reflect.DeepEqual(frontend.Aliases, []string{"web"}) || frontend.IPAddress != "" {
+		t.Errorf("Unexpected endpoint settings: %+v", frontend)
+	}
+	backend, ok := blueprint.extraNetworks["backend"]
+	if !ok || len(blueprint.extraNetworks) != 1 {
+		t.Fatalf("Unexpected extra networks: %v", blueprint.extraNetworks)
+	}
+	if len(backend.Aliases) != 0 {
+		t.Errorf("Container id alias was kept: %v", backend.Aliases)
+	}
+}
+
+func TestNewContainerBlueprint_DefaultNetwork(t *testing.T) {
+	c := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name:       "/db",
+			HostConfig: &container.HostConfig{NetworkMode: "default"},
+		},
+		Config: &container.Config{Image: "postgres"},
+		NetworkSettings: &types.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"bridge": {},
+			},
+		},
+	}
+
+	blueprint := newContainerBlueprint(c)
+
+	if _, ok := blueprint.networkingConfig.EndpointsConfig["bridge"]; !ok {
+		t.Errorf("The default network was not used as the primary network: %v", blueprint.networkingConfig.EndpointsConfig)
+	}
+	if len(blueprint.extraNetworks) != 0 {
+		t.Errorf("Unexpected extra networks: %v", blueprint.extraNetworks)
+	}
+}
@@ -0,0 +1,50 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+//RenameContainer gives the container with the given id the given name. The
+//container is returned with its new name, which the container list shows
+//without being retrieved again.
+func (daemon *DockerDaemon) RenameContainer(id, name string) (*Container, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+	if name == "" {
+		return nil, errors.New("the new name is empty")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.ContainerRename(ctx, id, name); err != nil {
+		return nil, err
+	}
+	details, err := daemon.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	updated := &Container{ContainerJSON: details}
+	updated.Container.ID = id
+	if c := daemon.store().Get(id); c != nil {
+		updated.Container = c.Container
+	}
+	updated.Container.Names = renamed(updated.Container.Names, details.Name)
+	daemon.store().Add(updated)
+	return updated, nil
+}
+
+//renamed returns the given names of a container, as the container list has
+//them, with its name replaced by the given one. Names of links to the
+//container, i.e. /app/db, are kept.
+func renamed(names []string, name string) []string {
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	result := []string{name}
+	for _, n := range names {
+		if strings.Count(n, "/") > 1 {
+			result = append(result, n)
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package docker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestRenameContainer(t *testing.T) {
+	client := &mock.ContainerRenameAPIClientMock{Containers: []types.Container{
+		{ID: "6dfafdbc3a40", Names: []string{"/app/db", "/db"}},
+	}}
+	store, err := NewDockerContainerStore(client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	daemon := &DockerDaemon{client: client, s: store}
+
+	updated, err := daemon.RenameContainer("6dfafdbc3a40", " /postgres ")
+	if err != nil {
+		t.Fatalf("Unexpected error renaming a container: %v", err)
+	}
+	want := []string{"/postgres", "/app/db"}
+	if !reflect.DeepEqual(updated.Names, want) {
+		t.Errorf("Names of the renamed container = %v, want %v", updated.Names, want)
+	}
+	if c := daemon.ContainerByID("6dfafdbc3a40"); c == nil || !reflect.DeepEqual(c.Names, want) {
+		t.Errorf("The renamed container was not updated on the container list: %v", c)
+	}
+
+	if _, err := daemon.RenameContainer("6dfafdbc3a40", "  "); err == nil {
+		t.Error("Renaming a container to an empty name must fail")
+	}
+	client.Err = errors.New("Conflict. The container name \"/web\" is already in use")
+	if _, err := daemon.RenameContainer("6dfafdbc3a40", "web"); err == nil {
+		t.Error("The error of the daemon was not returned")
+	}
+}
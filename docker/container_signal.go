@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//KillSignal is the signal docker kill sends unless told otherwise
+const KillSignal = "SIGKILL"
+
+//KillSignals are the signals offered to kill a container with, the one
+//docker kill sends first
+var KillSignals = []string{KillSignal, "SIGTERM", "SIGINT", "SIGHUP", "SIGUSR1", "SIGUSR2", "SIGQUIT"}
+
+//maxSignal is the highest signal number, real-time signals included
+const maxSignal = 64
+
+var signalName = regexp.MustCompile(`^SIG[A-Z][A-Z0-9]*([+-][0-9]+)?$`)
+
+//ParseSignal returns the given signal as it is sent to the daemon. Signals
+//are given by number or by name, with or without the SIG prefix and in any
+//case, i.e. 1, hup or SIGHUP.
+func ParseSignal(s string) (string, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return "", errors.New("No signal given")
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 || n > maxSignal {
+			return "", fmt.Errorf("Invalid signal number: %d", n)
+		}
+		return s, nil
+	}
+	if !strings.HasPrefix(s, "SIG") {
+		s = "SIG" + s
+	}
+	if !signalName.MatchString(s) {
+		return "", fmt.Errorf("Invalid signal: %s", s)
+	}
+	return s, nil
+}
+
+//IsKillSignal returns true if the given signal, as ParseSignal returns it,
+//is SIGKILL
+func IsKillSignal(signal string) bool {
+	return signal == KillSignal || signal == "9"
+}
+
+//SignalContainer sends the given signal to the main process of the
+//container with the given id, as docker kill --signal does. Unlike
+//KillAndWait, the container is not waited for, most signals do not make it
+//exit.
+func (daemon *DockerDaemon) SignalContainer(id, signal string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.ContainerKill(ctx, id, signal)
+}
@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"SIGHUP", "SIGHUP", false},
+		{" hup ", "SIGHUP", false},
+		{"usr1", "SIGUSR1", false},
+		{"SIGRTMIN+3", "SIGRTMIN+3", false},
+		{"1", "1", false},
+		{"64", "64", false},
+		{"0", "", true},
+		{"65", "", true},
+		{"", "", true},
+		{"SIG HUP", "", true},
+		{"-1", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSignal(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSignal(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSignal(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSignalContainer(t *testing.T) {
+	client := &mock.ContainerWaitAPIClientMock{}
+	daemon := &DockerDaemon{client: client}
+	if err := daemon.SignalContainer("web", "SIGHUP"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := client.Signals(); !reflect.DeepEqual(got, []string{"SIGHUP"}) {
+		t.Errorf("Signals sent = %q, want SIGHUP", got)
+	}
+	if !IsKillSignal(KillSignal) || !IsKillSignal("9") || IsKillSignal("SIGHUP") {
+		t.Error("Unexpected kill signals")
+	}
+}
@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"context"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	pkgError "github.com/pkg/errors"
+)
+
+//ContainerSize is the disk space used by a container, as docker ps -s shows it
+type ContainerSize struct {
+	//RW is the size of the writable layer of the container
+	RW int64
+	//RootFs is the virtual size of the container, its image included
+	RootFs int64
+}
+
+//ContainerSizes returns the size of every container, by container id.
+//The daemon has to add up the files of each container to know them, which
+//is expensive, so the sizes are not retrieved with the container list.
+func (daemon *DockerDaemon) ContainerSizes() (map[string]ContainerSize, error) {
+	sizes, err := daemon.calls.do(containerSizesCall, func(ctx context.Context) (interface{}, error) {
+		containers, err := daemon.client.ContainerList(ctx, dockerTypes.ContainerListOptions{All: true, Size: true})
+		if err != nil {
+			return nil, pkgError.Wrap(err, "Error retrieving container sizes")
+		}
+		return containerSizes(containers), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sizes.(map[string]ContainerSize), nil
+}
+
+//containerSizes returns the sizes of the given containers, by container id
+func containerSizes(containers []dockerTypes.Container) map[string]ContainerSize {
+	sizes := make(map[string]ContainerSize, len(containers))
+	for _, c := range containers {
+		sizes[c.ID] = ContainerSize{RW: c.SizeRw, RootFs: c.SizeRootFs}
+	}
+	return sizes
+}
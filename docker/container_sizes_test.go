@@ -0,0 +1,22 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestContainerSizes(t *testing.T) {
+	containers := []types.Container{
+		{ID: "6dfafdbc3a40", SizeRw: 2, SizeRootFs: 72900000},
+		{ID: "7dfafdbc3a40"},
+	}
+	want := map[string]ContainerSize{
+		"6dfafdbc3a40": {RW: 2, RootFs: 72900000},
+		"7dfafdbc3a40": {},
+	}
+	if got := containerSizes(containers); !reflect.DeepEqual(got, want) {
+		t.Errorf("containerSizes() = %v, want %v", got, want)
+	}
+}
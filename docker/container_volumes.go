@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"context"
+	"sort"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+//anonymousVolumeLabel is the label Docker 23.0 and newer put on anonymous volumes
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+//AnonymousVolume is a volume Docker created for a container without a name,
+//removing the container leaves it behind unless it is removed with it
+type AnonymousVolume struct {
+	Name string
+	//Size is the size of the volume, -1 if unknown
+	Size int64
+}
+
+//AnonymousVolumes returns the anonymous volumes mounted by the given
+//container, sorted by name. Sizes come from the given disk usage, which
+//can be nil. Named volumes are never returned.
+func AnonymousVolumes(c *Container, du *types.DiskUsage) []AnonymousVolume {
+	if c == nil {
+		return nil
+	}
+	known := make(map[string]*types.Volume)
+	if du != nil {
+		for _, v := range du.Volumes {
+			if v != nil {
+				known[v.Name] = v
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	var volumes []AnonymousVolume
+	for _, mounts := range [][]types.MountPoint{c.Container.Mounts, c.ContainerJSON.Mounts} {
+		for _, m := range mounts {
+			if m.Type != mount.TypeVolume || m.Name == "" || seen[m.Name] {
+				continue
+			}
+			seen[m.Name] = true
+			v := known[m.Name]
+			if !isAnonymousVolume(m.Name, v) {
+				continue
+			}
+			size := int64(-1)
+			if v != nil && v.UsageData != nil {
+				size = v.UsageData.Size
+			}
+			volumes = append(volumes, AnonymousVolume{Name: m.Name, Size: size})
+		}
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	return volumes
+}
+
+//isAnonymousVolume returns true if the volume with the given name, and the
+//given details if known, was created without a name. Docker labels them
+//since 23.0, before that they are told by their name, 64 hex digits.
+func isAnonymousVolume(name string, v *types.Volume) bool {
+	if v != nil {
+		if _, ok := v.Labels[anonymousVolumeLabel]; ok {
+			return true
+		}
+	}
+	return len(name) == 64 && isHex(name)
+}
+
+//RmWithVolumes removes the container with the given id along with its
+//anonymous volumes, as docker rm -v does. Named volumes are kept, the daemon
+//never removes them this way. It returns how many of the given volumes,
+//the anonymous volumes of the container, are gone after the removal.
+func (daemon *DockerDaemon) RmWithVolumes(id string, volumes []string) (int, error) {
+	opts := types.ContainerRemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	if err := daemon.client.ContainerRemove(ctx, id, opts); err != nil {
+		return 0, err
+	}
+	daemon.store().Remove(id)
+	removed := 0
+	for _, name := range volumes {
+		//volumes still used by other containers are left
+		if _, err := daemon.client.VolumeInspect(ctx, name); dockerAPI.IsErrNotFound(err) {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+//VolumeInspect returns the volume with the given name
+func (daemon *DockerDaemon) VolumeInspect(name string) (types.Volume, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.VolumeInspect(ctx, name)
+}
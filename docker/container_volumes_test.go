@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/mount"
+)
+
+func TestAnonymousVolumes(t *testing.T) {
+	anonymous := strings.Repeat("ab", 32)
+	labelled := "f00"
+	c := &Container{
+		Container: types.Container{
+			Mounts: []types.MountPoint{
+				{Type: mount.TypeVolume, Name: anonymous},
+				{Type: mount.TypeVolume, Name: "data"},
+				{Type: mount.TypeBind, Source: "/etc/app"},
+			},
+		},
+		ContainerJSON: types.ContainerJSON{
+			Mounts: []types.MountPoint{
+				{Type: mount.TypeVolume, Name: anonymous},
+				{Type: mount.TypeVolume, Name: labelled},
+				//a named volume whose name looks like an anonymous one
+				{Type: mount.TypeVolume, Name: strings.Repeat("AB", 32)},
+			},
+		},
+	}
+
+	got := AnonymousVolumes(c, nil)
+	want := []AnonymousVolume{{Name: anonymous, Size: -1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AnonymousVolumes() without disk usage = %v, want %v", got, want)
+	}
+
+	du := &types.DiskUsage{
+		Volumes: []*types.Volume{
+			{Name: anonymous, UsageData: &types.VolumeUsageData{Size: 1024}},
+			{Name: labelled, Labels: map[string]string{anonymousVolumeLabel: ""}, UsageData: &types.VolumeUsageData{Size: -1}},
+			{Name: "data", UsageData: &types.VolumeUsageData{Size: 2048}},
+		},
+	}
+	got = AnonymousVolumes(c, du)
+	want = []AnonymousVolume{{Name: anonymous, Size: 1024}, {Name: labelled, Size: -1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AnonymousVolumes() = %v, want %v", got, want)
+	}
+
+	if got := AnonymousVolumes(nil, du); got != nil {
+		t.Errorf("AnonymousVolumes() of no container = %v, want none", got)
+	}
+}
@@ -1,9 +1,11 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -13,8 +15,8 @@ import (
 	"github.com/docker/docker/api/types/container"
 	dockerEvents "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/api/types/swarm"
 	dockerAPI "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	pkgError "github.com/pkg/errors"
 )
 
@@ -37,6 +39,7 @@ var defaultImageListOptions = dockerTypes.ImageListOptions{
 //DockerDaemon knows how to talk to the Docker daemon
 type DockerDaemon struct {
 	client    dockerAPI.APIClient //client used to to connect to the Docker daemon
+	engine    *engineAPI          //for the API features the client does not support
 	s         ContainerStore
 	err       error // Errors, if any.
 	dockerEnv *Env
@@ -45,6 +48,18 @@ type DockerDaemon struct {
 	storeLock sync.RWMutex
 	resolver  Resolver
 	eventLog  *EventLog
+	//the API version used with the daemon, empty if not known
+	apiVersion string
+	//the parent/child graph of the local images, built on first use and
+	//kept while the images with the ids in imageGraphKey are the local ones
+	imageGraph     *ImageGraph
+	imageGraphKey  string
+	imageGraphLock sync.Mutex
+	//calls coalesces the list calls made to the daemon
+	calls *callCoordinator
+	//containerEvents keeps the container list up to date with the events
+	//of the daemon
+	containerEvents containerEvents
 }
 
 //Containers returns the containers known by the daemon
@@ -69,6 +84,9 @@ func (daemon *DockerDaemon) ContainerByID(cid string) *Container {
 
 //DiskUsage returns reported Docker disk usage
 func (daemon *DockerDaemon) DiskUsage() (dockerTypes.DiskUsage, error) {
+	if err := daemon.requireFeature(DiskUsageFeature); err != nil {
+		return dockerTypes.DiskUsage{}, err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
 	defer cancel()
 	return daemon.client.DiskUsage(ctx)
@@ -214,9 +232,48 @@ func (daemon *DockerDaemon) Logs(id string, since string, withTimeStamps bool) (
 	return daemon.client.ContainerLogs(context.Background(), id, options)
 }
 
+//LogTail returns the last lines of the logs of the container with the given id
+func (daemon *DockerDaemon) LogTail(ctx context.Context, id string, lines int) ([]string, error) {
+	inspected, err := daemon.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	options := dockerTypes.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.Itoa(lines),
+	}
+	reader, err := daemon.client.ContainerLogs(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var buf bytes.Buffer
+	//the output of containers without a TTY comes multiplexed
+	if inspected.Config != nil && inspected.Config.Tty {
+		_, err = io.Copy(&buf, reader)
+	} else {
+		_, err = stdcopy.StdCopy(&buf, &buf, reader)
+	}
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(strings.Replace(buf.String(), "\r\n", "\n", -1), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
 //Networks returns the list of Docker networks
 func (daemon *DockerDaemon) Networks() ([]dockerTypes.NetworkResource, error) {
-	return networks(daemon.client)
+	networks, err := daemon.calls.do(networksCall, func(ctx context.Context) (interface{}, error) {
+		return networkList(ctx, daemon.client)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return networks.([]dockerTypes.NetworkResource), nil
 }
 
 //NetworkInspect returns network detailed information
@@ -245,6 +302,8 @@ func (daemon *DockerDaemon) OpenChannel(container *Container) *StatsChannel {
 //networks and volumes
 func (daemon *DockerDaemon) Prune() (*PruneReport, error) {
 	c := context.Background()
+	defer daemon.calls.forget(imagesCall)
+	defer daemon.calls.forget(networksCall)
 
 	args := filters.NewArgs()
 	cReport, err := daemon.client.ContainersPrune(c, args)
@@ -288,26 +347,36 @@ func (daemon *DockerDaemon) RestartContainer(id string) error {
 //operation completion.
 func (daemon *DockerDaemon) Refresh(notify func(error)) {
 	go func() {
-		store, err := NewDockerContainerStore(daemon.client)
-		if err == nil {
-			daemon.setStore(store)
-		}
+		err := daemon.refreshStore()
 		if notify != nil {
 			notify(err)
 		}
 	}()
 }
 
-func (daemon *DockerDaemon) refreshAndWait() error {
-	var wg sync.WaitGroup
-	var refreshError error
-	wg.Add(1)
-	daemon.Refresh(func(err error) {
-		refreshError = err
-		wg.Done()
+//refreshStore refreshes the container list, sharing the container list
+//call with the refreshes issued right before
+func (daemon *DockerDaemon) refreshStore() error {
+	store, err := daemon.calls.do(containersCall, func(ctx context.Context) (interface{}, error) {
+		return newContainerStore(ctx, daemon.client)
 	})
-	wg.Wait()
-	return refreshError
+	if err != nil {
+		return err
+	}
+	daemon.setStore(store.(ContainerStore))
+	return nil
+}
+
+//refreshAndWait refreshes the container list after the containers were
+//changed, the list is retrieved again even if it was just retrieved
+func (daemon *DockerDaemon) refreshAndWait() error {
+	daemon.calls.forget(containersCall)
+	return daemon.refreshStore()
+}
+
+//CallStats returns the counters of the list calls made to the daemon
+func (daemon *DockerDaemon) CallStats() []CallStats {
+	return daemon.calls.stats()
 }
 
 //RemoveAllStoppedContainers removes all stopped containers
@@ -387,6 +456,7 @@ func (daemon *DockerDaemon) RemoveDanglingImages() (int, error) {
 func (daemon *DockerDaemon) RemoveNetwork(id string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
 	defer cancel()
+	defer daemon.calls.forget(networksCall)
 	return daemon.client.NetworkRemove(ctx, id)
 }
 
@@ -414,6 +484,7 @@ func (daemon *DockerDaemon) Rmi(name string, force bool) ([]dockerTypes.ImageDel
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
 	defer cancel()
+	defer daemon.calls.forget(imagesCall)
 	return daemon.client.ImageRemove(ctx, name, options)
 }
 
@@ -474,22 +545,12 @@ func (daemon *DockerDaemon) init() {
 	daemon.eventLog = NewEventLog()
 	daemon.Version()
 
-	if info, err := daemon.Info(); err == nil {
-		daemon.swarmMode = info.Swarm.LocalNodeState == swarm.LocalNodeStateActive
-	}
-	GlobalRegistry.Register(
-		ContainerSource,
-		func(ctx context.Context, message dockerEvents.Message) error {
-			return daemon.refreshAndWait()
-		})
+	daemon.refreshSwarmMode()
+	GlobalRegistry.Register(ContainerSource, daemon.handleContainerEvent)
 }
 
-func containers(client dockerAPI.ContainerAPIClient) ([]*Container, error) {
-	//Since this is how dry fist connects to the Docker daemon
-	//a different (longer) timeout is used.
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultConnectionTimeout)
-	defer cancel()
-	containers, err := client.ContainerList(ctx, dockerTypes.ContainerListOptions{All: true, Size: true})
+func containers(ctx context.Context, client dockerAPI.ContainerAPIClient) ([]*Container, error) {
+	containers, err := client.ContainerList(ctx, dockerTypes.ContainerListOptions{All: true})
 	if err == nil {
 		var cPointers []*Container
 		for i, c := range containers {
@@ -507,9 +568,7 @@ func images(client dockerAPI.ImageAPIClient, opts dockerTypes.ImageListOptions)
 	return client.ImageList(ctx, opts)
 }
 
-func networks(client dockerAPI.NetworkAPIClient) ([]dockerTypes.NetworkResource, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
-	defer cancel()
+func networkList(ctx context.Context, client dockerAPI.NetworkAPIClient) ([]dockerTypes.NetworkResource, error) {
 	networks, err := client.NetworkList(ctx, dockerTypes.NetworkListOptions{})
 	if err != nil {
 		return nil, err
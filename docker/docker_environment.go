@@ -10,14 +10,16 @@ type Env struct {
 	DockerTLSVerify  bool //tls must be verified
 	DockerCertPath   string
 	DockerAPIVersion string
+	//APIVersionPinned is set if the API version was given with DOCKER_API_VERSION,
+	//it is used as is and not negotiated with the daemon
+	APIVersionPinned bool
 }
 
 //NewEnv creates a new docker environment struct
 func NewEnv() *Env {
 	version := os.Getenv("DOCKER_API_VERSION")
 	if version == "" {
-		version = "1.37"
-		//version = api.DefaultVersion
+		return &Env{DockerAPIVersion: DefaultAPIVersion}
 	}
-	return &Env{DockerAPIVersion: version}
+	return &Env{DockerAPIVersion: version, APIVersionPinned: true}
 }
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
@@ -10,7 +11,7 @@ import (
 )
 
 func TestContainerListRetrieval(t *testing.T) {
-	c, _ := containers(createClient())
+	c, _ := containers(context.Background(), createClient())
 
 	for i, container := range c {
 		if container.ID != strconv.Itoa(i) {
@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/sockets"
+)
+
+//engineAPI sends requests to Docker Engine API endpoints, or endpoint
+//parameters, that the Docker client dry is built with does not know about
+type engineAPI struct {
+	client  *http.Client
+	baseURL string
+}
+
+//newEngineAPI creates an engineAPI for the Docker daemon listening on the given host
+func newEngineAPI(host string, config *tls.Config) (*engineAPI, error) {
+	hostURL, err := client.ParseHostURL(host)
+	if err != nil {
+		return nil, err
+	}
+	transport := new(http.Transport)
+	transport.TLSClientConfig = config
+	if err := sockets.ConfigureTransport(transport, hostURL.Scheme, hostURL.Host); err != nil {
+		return nil, err
+	}
+	scheme := "http"
+	if config != nil {
+		scheme = "https"
+	}
+	addr := hostURL.Host
+	if hostURL.Scheme == "unix" || hostURL.Scheme == "npipe" {
+		//the transport dials the socket, any host name does
+		addr = "docker"
+	}
+	return &engineAPI{
+		client: &http.Client{
+			Transport:     transport,
+			CheckRedirect: client.CheckRedirect,
+		},
+		baseURL: scheme + "://" + addr + strings.TrimSuffix(hostURL.Path, "/"),
+	}, nil
+}
+
+//post sends a POST request to the given path of the given API version, the
+//JSON response is decoded into result
+func (e *engineAPI) post(ctx context.Context, apiVersion, path string, query url.Values, result interface{}) error {
+	u := fmt.Sprintf("%s/v%s%s", e.baseURL, apiVersion, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodPost, u, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("Error response from daemon: %s", apiErr.Message)
+		}
+		return fmt.Errorf("Error response from daemon: %s", strings.TrimSpace(string(body)))
+	}
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
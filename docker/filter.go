@@ -1,6 +1,105 @@
 package docker
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/docker/distribution/reference"
+)
+
+//StatusFilterPrefix is the prefix of the container list filters that match
+//containers by their state as Docker names it (i.e. status:exited)
+const StatusFilterPrefix = "status:"
+
+//LabelFilterPrefix is the prefix of the container list filters that match
+//containers by label, by key and value (i.e. label:com.example.team=payments)
+//or by key alone (i.e. label:com.example.team)
+const LabelFilterPrefix = "label:"
+
+//ImageFilterPrefix is the prefix of the container list filters that match
+//containers by the image they were created from (i.e. image:nginx)
+const ImageFilterPrefix = "image:"
+
+//ContainerStates are the states a container can be in, as Docker names them
+var ContainerStates = []string{"created", "restarting", "running", "removing", "paused", "exited", "dead"}
+
+//FilteredStatus returns the state the given container list filter matches,
+//false if it is not a status filter
+func FilteredStatus(filter string) (string, bool) {
+	if !strings.HasPrefix(filter, StatusFilterPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(filter, StatusFilterPrefix), true
+}
+
+//HasStatus returns true if the given container is in the given state:
+//created, restarting, running, removing, paused, exited or dead
+func HasStatus(c *Container, status string) bool {
+	return c != nil && strings.EqualFold(c.Container.State, status)
+}
+
+//HasLabel returns true if the given container has the given label, given
+//as key=value or as a key, that matches whatever the value is
+func HasLabel(c *Container, label string) bool {
+	if c == nil || label == "" {
+		return false
+	}
+	key, value := label, ""
+	withValue := false
+	if i := strings.Index(label, "="); i >= 0 {
+		key, value, withValue = label[:i], label[i+1:], true
+	}
+	labels := c.Container.Labels
+	if labels == nil && c.ContainerJSON.Config != nil {
+		labels = c.ContainerJSON.Config.Labels
+	}
+	v, ok := labels[key]
+	return ok && (!withValue || v == value)
+}
+
+//UsesImage returns true if the given container was created from the given
+//image, given as a reference (nginx, nginx:1.19, localhost:5000/app) or as
+//the beginning of its ID. A reference without tag matches every tag of the
+//repository.
+func UsesImage(c *Container, image string) bool {
+	if c == nil || image == "" {
+		return false
+	}
+	if id := ImageID(image); len(id) >= 4 && isHex(id) {
+		imageID := c.Container.ImageID
+		if imageID == "" && c.ContainerJSON.ContainerJSONBase != nil {
+			imageID = c.ContainerJSON.Image
+		}
+		if strings.HasPrefix(ImageID(imageID), id) {
+			return true
+		}
+	}
+	wanted, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return false
+	}
+	used := c.Container.Image
+	if used == "" && c.ContainerJSON.Config != nil {
+		used = c.ContainerJSON.Config.Image
+	}
+	usedRef, err := reference.ParseNormalizedNamed(used)
+	if err != nil || usedRef.Name() != wanted.Name() {
+		return false
+	}
+	if reference.IsNameOnly(wanted) {
+		return true
+	}
+	return reference.TagNameOnly(wanted).String() == reference.TagNameOnly(usedRef).String()
+}
+
+//isHex returns true if the given text only has hexadecimal digits
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
 
 //ContainerFilter defines a function to filter container
 type ContainerFilter func(*Container) bool
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 )
 
 func TestFilterByName(t *testing.T) {
@@ -61,3 +62,98 @@ func TestFilterByID(t *testing.T) {
 	}
 
 }
+
+func TestHasStatus(t *testing.T) {
+	c := &Container{
+		Container: dockerTypes.Container{State: "exited"},
+	}
+	if !HasStatus(c, "exited") || !HasStatus(c, "Exited") {
+		t.Error("An exited container must have the exited status")
+	}
+	if HasStatus(c, "running") {
+		t.Error("An exited container must not have the running status")
+	}
+	if HasStatus(nil, "exited") {
+		t.Error("No container has no status")
+	}
+}
+
+func TestFilteredStatus(t *testing.T) {
+	if status, ok := FilteredStatus("status:exited"); !ok || status != "exited" {
+		t.Errorf("FilteredStatus(status:exited) = %q, %v, want exited", status, ok)
+	}
+	if _, ok := FilteredStatus("exited"); ok {
+		t.Error("A filter without the status prefix is not a status filter")
+	}
+}
+
+func TestHasLabel(t *testing.T) {
+	listed := &Container{
+		Container: dockerTypes.Container{Labels: map[string]string{
+			"com.example.team": "payments",
+			"com.example.tier": "",
+		}},
+	}
+	inspected := &Container{
+		ContainerJSON: dockerTypes.ContainerJSON{
+			Config: &container.Config{Labels: map[string]string{
+				"com.example.team": "search",
+			}},
+		},
+	}
+	tests := []struct {
+		c     *Container
+		label string
+		want  bool
+	}{
+		{listed, "com.example.team=payments", true},
+		{listed, "com.example.team=search", false},
+		{listed, "com.example.team", true},
+		{listed, "com.example.tier=", true},
+		{listed, "com.example.owner", false},
+		{inspected, "com.example.team=search", true},
+		{inspected, "com.example.team=payments", false},
+		{listed, "", false},
+		{nil, "com.example.team", false},
+	}
+	for _, test := range tests {
+		if got := HasLabel(test.c, test.label); got != test.want {
+			t.Errorf("HasLabel(%q) = %v, want %v", test.label, got, test.want)
+		}
+	}
+}
+
+func TestUsesImage(t *testing.T) {
+	id := "sha256:4bb46517cac397bdb0bab6eba09b0e1f8e90ddd17cf99662997c3253531136f8"
+	nginx := &Container{
+		Container: dockerTypes.Container{Image: "nginx:1.19", ImageID: id},
+	}
+	registry := &Container{
+		Container: dockerTypes.Container{Image: "localhost:5000/team/app"},
+	}
+	tests := []struct {
+		c     *Container
+		image string
+		want  bool
+	}{
+		{nginx, "nginx", true},
+		{nginx, "nginx:1.19", true},
+		{nginx, "docker.io/library/nginx:1.19", true},
+		{nginx, "nginx:latest", false},
+		{nginx, "ngin", false},
+		{nginx, "redis", false},
+		{nginx, "4bb46517cac3", true},
+		{nginx, "sha256:4bb46517", true},
+		{nginx, "5bb46517cac3", false},
+		{registry, "localhost:5000/team/app", true},
+		{registry, "localhost:5000/team/app:latest", true},
+		{registry, "team/app", false},
+		{nil, "nginx", false},
+		{nginx, "", false},
+	}
+	for _, test := range tests {
+		if got := UsesImage(test.c, test.image); got != test.want {
+			t.Errorf("UsesImage(%q) = %v, want %v", test.image, got, test.want)
+		}
+	}
+}
@@ -3,8 +3,6 @@ package formatter
 import (
 	"bytes"
 	"fmt"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +23,8 @@ const (
 	portsHeader      = "PORTS"
 	sizeHeader       = "SIZE"
 	labelsHeader     = "LABELS"
+	restartHeader    = "RESTART POLICY"
+	addressHeader    = "IP"
 )
 
 //ContainerFormatter knows how to pretty-print the information of a container
@@ -117,14 +117,39 @@ func (c *ContainerFormatter) Status() string {
 	return c.c.Status
 }
 
+//Address prettifies the primary IP address of the container and the networks
+//it is attached to
+func (c *ContainerFormatter) Address() string {
+	c.addHeader(addressHeader)
+	ip, networks := docker.ContainerAddress(c.c)
+	switch {
+	case len(networks) == 0:
+		return ip
+	case ip == "":
+		return strings.Join(networks, ", ")
+	}
+	return fmt.Sprintf("%s (%s)", ip, strings.Join(networks, ", "))
+}
+
+//RestartPolicy prettifies the container restart policy
+func (c *ContainerFormatter) RestartPolicy() string {
+	c.addHeader(restartHeader)
+	return docker.FormatRestartPolicy(docker.RestartPolicy(c.c))
+}
+
 //Size prettifies the container size
 func (c *ContainerFormatter) Size() string {
 	c.addHeader(sizeHeader)
-	srw := units.HumanSize(float64(c.c.SizeRw))
+	return ContainerSize(docker.ContainerSize{RW: c.c.SizeRw, RootFs: c.c.SizeRootFs})
+}
+
+//ContainerSize prettifies the given container size as docker ps -s does
+func ContainerSize(size docker.ContainerSize) string {
+	srw := units.HumanSize(float64(size.RW))
 	sf := srw
 
-	if c.c.SizeRootFs > 0 {
-		sv := units.HumanSize(float64(c.c.SizeRootFs))
+	if size.RootFs > 0 {
+		sv := units.HumanSize(float64(size.RootFs))
 		sf = fmt.Sprintf("%s (virtual %s)", srw, sv)
 	}
 	return sf
@@ -164,91 +189,6 @@ func stripNamePrefix(ss []string) []string {
 	return ss
 }
 
-//DisplayablePorts formats the given ports information for displaying
-func DisplayablePorts(ports []types.Port) string {
-	type portGroup struct {
-		first int
-		last  int
-	}
-	groupMap := make(map[string]*portGroup)
-	var result []string
-	var hostMappings []string
-	var groupMapKeys []string
-	sort.Sort(byPortInfo(ports))
-	for _, port := range ports {
-		current := int(port.PrivatePort)
-		portKey := port.Type
-		if port.IP != "" {
-			if int(port.PublicPort) != current {
-				hostMappings = append(hostMappings,
-					fmt.Sprintf("%s:%d->%d/%s", port.IP, port.PublicPort, port.PrivatePort, port.Type))
-				continue
-			}
-			portKey = fmt.Sprintf("%s/%s", port.IP, port.Type)
-		}
-		group := groupMap[portKey]
-
-		if group == nil {
-			groupMap[portKey] = &portGroup{first: current, last: current}
-			// record order that groupMap keys are created
-			groupMapKeys = append(groupMapKeys, portKey)
-			continue
-		}
-		if current == (group.last + 1) {
-			group.last = current
-			continue
-		}
-
-		result = append(result, formGroup(portKey, group.first, group.last))
-		groupMap[portKey] = &portGroup{first: current, last: current}
-	}
-	for _, portKey := range groupMapKeys {
-		g := groupMap[portKey]
-		result = append(result, formGroup(portKey, g.first, g.last))
-	}
-	result = append(result, hostMappings...)
-	return strings.Join(result, ", ")
-}
-
-// byPortInfo is a temporary type used to sort types.Port by its fields
-type byPortInfo []types.Port
-
-func (r byPortInfo) Len() int      { return len(r) }
-func (r byPortInfo) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
-func (r byPortInfo) Less(i, j int) bool {
-	if r[i].PrivatePort != r[j].PrivatePort {
-		return r[i].PrivatePort < r[j].PrivatePort
-	}
-
-	if r[i].IP != r[j].IP {
-		return r[i].IP < r[j].IP
-	}
-
-	if r[i].PublicPort != r[j].PublicPort {
-		return r[i].PublicPort < r[j].PublicPort
-	}
-
-	return r[i].Type < r[j].Type
-}
-
-func formGroup(key string, start, last int) string {
-	parts := strings.Split(key, "/")
-	groupType := parts[0]
-	var ip string
-	if len(parts) > 1 {
-		ip = parts[0]
-		groupType = parts[1]
-	}
-	group := strconv.Itoa(start)
-	if start != last {
-		group = fmt.Sprintf("%s-%d", group, last)
-	}
-	if ip != "" {
-		group = fmt.Sprintf("%s:%s->%s", ip, group, group)
-	}
-	return fmt.Sprintf("%s/%s", group, groupType)
-}
-
 //FormatLabels returns the string representation of the given labels.
 func FormatLabels(labels map[string]string) string {
 	var joinLabels []string
@@ -0,0 +1,148 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//portBinding is a port of a container, as shown
+type portBinding struct {
+	ip      string
+	public  int
+	private int
+	proto   string
+}
+
+//portRange is a range of consecutive port bindings with the same protocol
+//and host IP
+type portRange struct {
+	first, last portBinding
+}
+
+//DisplayablePorts formats the given ports information for displaying.
+//Ports published on both the IPv4 and IPv6 wildcards are shown once,
+//consecutive ports are grouped in ranges (i.e. 8000-8010->8000-8010/tcp)
+//and the host IP is only shown when the port is bound to a given one.
+func DisplayablePorts(ports []types.Port) string {
+	seen := make(map[portBinding]bool)
+	var bindings []portBinding
+	for _, port := range ports {
+		b := portBinding{
+			ip:      port.IP,
+			public:  int(port.PublicPort),
+			private: int(port.PrivatePort),
+			proto:   port.Type,
+		}
+		if b.public == 0 || isWildcardIP(b.ip) {
+			b.ip = ""
+		}
+		if !seen[b] {
+			seen[b] = true
+			bindings = append(bindings, b)
+		}
+	}
+	sortPortBindings(bindings)
+
+	var ranges []*portRange
+	for _, b := range bindings {
+		if len(ranges) > 0 {
+			if last := ranges[len(ranges)-1]; last.extends(b) {
+				last.last = b
+				continue
+			}
+		}
+		ranges = append(ranges, &portRange{first: b, last: b})
+	}
+	result := make([]string, len(ranges))
+	for i, r := range ranges {
+		result[i] = r.String()
+	}
+	return strings.Join(result, ", ")
+}
+
+//AllPorts returns every port binding of the given ports, as the daemon
+//reports them, one per element
+func AllPorts(ports []types.Port) []string {
+	seen := make(map[portBinding]bool)
+	var bindings []portBinding
+	for _, port := range ports {
+		b := portBinding{
+			ip:      port.IP,
+			public:  int(port.PublicPort),
+			private: int(port.PrivatePort),
+			proto:   port.Type,
+		}
+		if !seen[b] {
+			seen[b] = true
+			bindings = append(bindings, b)
+		}
+	}
+	sortPortBindings(bindings)
+	result := make([]string, len(bindings))
+	for i, b := range bindings {
+		result[i] = portRange{first: b, last: b}.String()
+	}
+	return result
+}
+
+//extends returns true if the given binding is the one after the last
+//binding of the range
+func (r *portRange) extends(b portBinding) bool {
+	last := r.last
+	if b.proto != last.proto || b.ip != last.ip || b.private != last.private+1 {
+		return false
+	}
+	if last.public == 0 {
+		return b.public == 0
+	}
+	return b.public == last.public+1
+}
+
+func (r portRange) String() string {
+	private := formatPortRange(r.first.private, r.last.private)
+	if r.first.public == 0 {
+		return private + "/" + r.first.proto
+	}
+	public := formatPortRange(r.first.public, r.last.public)
+	if ip := r.first.ip; ip != "" {
+		if strings.Contains(ip, ":") {
+			ip = "[" + ip + "]"
+		}
+		public = ip + ":" + public
+	}
+	return fmt.Sprintf("%s->%s/%s", public, private, r.first.proto)
+}
+
+func formatPortRange(first, last int) string {
+	if first == last {
+		return strconv.Itoa(first)
+	}
+	return fmt.Sprintf("%d-%d", first, last)
+}
+
+//isWildcardIP returns true if the given host IP binds every interface
+func isWildcardIP(ip string) bool {
+	return ip == "" || ip == "0.0.0.0" || ip == "::"
+}
+
+//sortPortBindings sorts the given bindings by protocol, then host IP, then
+//port, so that the bindings of a range are next to each other
+func sortPortBindings(bindings []portBinding) {
+	sort.Slice(bindings, func(i, j int) bool {
+		a, b := bindings[i], bindings[j]
+		if a.proto != b.proto {
+			return a.proto < b.proto
+		}
+		if a.ip != b.ip {
+			return a.ip < b.ip
+		}
+		if a.private != b.private {
+			return a.private < b.private
+		}
+		return a.public < b.public
+	})
+}
@@ -0,0 +1,107 @@
+package formatter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func publishedPort(ip string, public, private uint16, proto string) types.Port {
+	return types.Port{IP: ip, PublicPort: public, PrivatePort: private, Type: proto}
+}
+
+func exposedPort(private uint16, proto string) types.Port {
+	return types.Port{PrivatePort: private, Type: proto}
+}
+
+func TestDisplayablePorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		ports []types.Port
+		want  string
+	}{
+		{"no ports", nil, ""},
+		{"exposed port", []types.Port{exposedPort(80, "tcp")}, "80/tcp"},
+		{"published port",
+			[]types.Port{publishedPort("0.0.0.0", 8080, 80, "tcp")},
+			"8080->80/tcp"},
+		{"IPv4 and IPv6 wildcards",
+			[]types.Port{
+				publishedPort("0.0.0.0", 8080, 80, "tcp"),
+				publishedPort("::", 8080, 80, "tcp")},
+			"8080->80/tcp"},
+		{"published range",
+			[]types.Port{
+				publishedPort("0.0.0.0", 8002, 8002, "tcp"),
+				publishedPort("::", 8000, 8000, "tcp"),
+				publishedPort("0.0.0.0", 8000, 8000, "tcp"),
+				publishedPort("0.0.0.0", 8001, 8001, "tcp"),
+				publishedPort("::", 8001, 8001, "tcp"),
+				publishedPort("::", 8002, 8002, "tcp")},
+			"8000-8002->8000-8002/tcp"},
+		{"published range to other ports",
+			[]types.Port{
+				publishedPort("0.0.0.0", 9000, 8000, "tcp"),
+				publishedPort("0.0.0.0", 9001, 8001, "tcp")},
+			"9000-9001->8000-8001/tcp"},
+		{"exposed range",
+			[]types.Port{exposedPort(7001, "tcp"), exposedPort(7000, "tcp"), exposedPort(7002, "tcp")},
+			"7000-7002/tcp"},
+		{"gaps split ranges",
+			[]types.Port{exposedPort(7000, "tcp"), exposedPort(7001, "tcp"), exposedPort(7003, "tcp")},
+			"7000-7001/tcp, 7003/tcp"},
+		{"ports not published consecutively split ranges",
+			[]types.Port{
+				publishedPort("0.0.0.0", 9000, 8000, "tcp"),
+				publishedPort("0.0.0.0", 9005, 8001, "tcp")},
+			"9000->8000/tcp, 9005->8001/tcp"},
+		{"udp and tcp",
+			[]types.Port{
+				publishedPort("0.0.0.0", 53, 53, "udp"),
+				publishedPort("0.0.0.0", 53, 53, "tcp"),
+				exposedPort(54, "udp"),
+				exposedPort(55, "udp"),
+				publishedPort("0.0.0.0", 54, 54, "tcp")},
+			"53-54->53-54/tcp, 53->53/udp, 54-55/udp"},
+		{"host IP",
+			[]types.Port{
+				publishedPort("127.0.0.1", 8080, 80, "tcp"),
+				publishedPort("127.0.0.1", 8081, 81, "tcp")},
+			"127.0.0.1:8080-8081->80-81/tcp"},
+		{"host IP and wildcard",
+			[]types.Port{
+				publishedPort("0.0.0.0", 8080, 80, "tcp"),
+				publishedPort("127.0.0.1", 9090, 90, "tcp")},
+			"8080->80/tcp, 127.0.0.1:9090->90/tcp"},
+		{"IPv6 host IP",
+			[]types.Port{publishedPort("::1", 8080, 80, "tcp")},
+			"[::1]:8080->80/tcp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayablePorts(tt.ports); got != tt.want {
+				t.Errorf("DisplayablePorts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllPorts(t *testing.T) {
+	ports := []types.Port{
+		publishedPort("::", 8001, 8001, "tcp"),
+		publishedPort("0.0.0.0", 8000, 8000, "tcp"),
+		publishedPort("0.0.0.0", 8001, 8001, "tcp"),
+		publishedPort("0.0.0.0", 8001, 8001, "tcp"),
+		exposedPort(53, "udp"),
+	}
+	want := []string{
+		"0.0.0.0:8000->8000/tcp",
+		"0.0.0.0:8001->8001/tcp",
+		"[::]:8001->8001/tcp",
+		"53/udp",
+	}
+	if got := AllPorts(ports); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllPorts() = %v, want %v", got, want)
+	}
+}
@@ -20,6 +20,21 @@ func FormatPorts(ports []swarm.PortConfig) string {
 	return strings.Join(result, ",")
 }
 
+//FormatServicePorts returns the published ports of a service as
+//published->target/protocol, those published on the host of each task,
+//instead of on the routing mesh, are labelled as such
+func FormatServicePorts(ports []swarm.PortConfig) string {
+	result := make([]string, 0, len(ports))
+	for _, p := range ports {
+		port := fmt.Sprintf("%d->%d/%s", p.PublishedPort, p.TargetPort, p.Protocol)
+		if p.PublishMode == swarm.PortConfigPublishModeHost {
+			port += " (host)"
+		}
+		result = append(result, port)
+	}
+	return strings.Join(result, ", ")
+}
+
 //FormatSwarmNetworks returns the string representation of the given slice of NetworkAttachmentConfig
 func FormatSwarmNetworks(networks []swarm.NetworkAttachmentConfig) string {
 	result := []string{}
@@ -0,0 +1,40 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestFormatServicePorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		ports []swarm.PortConfig
+		want  string
+	}{
+		{"no ports", nil, ""},
+		{"ingress port",
+			[]swarm.PortConfig{
+				{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 8443, PublishedPort: 443, PublishMode: swarm.PortConfigPublishModeIngress},
+			},
+			"443->8443/tcp"},
+		{"host port",
+			[]swarm.PortConfig{
+				{Protocol: swarm.PortConfigProtocolUDP, TargetPort: 53, PublishedPort: 5353, PublishMode: swarm.PortConfigPublishModeHost},
+			},
+			"5353->53/udp (host)"},
+		{"ingress and host ports",
+			[]swarm.PortConfig{
+				{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 80, PublishedPort: 8080},
+				{Protocol: swarm.PortConfigProtocolTCP, TargetPort: 9090, PublishedPort: 9090, PublishMode: swarm.PortConfigPublishModeHost},
+			},
+			"8080->80/tcp, 9090->9090/tcp (host)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatServicePorts(tt.ports); got != tt.want {
+				t.Errorf("FormatServicePorts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//HealthLog describes the healthcheck of a container and its latest results
+type HealthLog struct {
+	//Defined is false if the container has no healthcheck
+	Defined bool
+	//Test is the command the healthcheck runs
+	Test          string
+	Status        string
+	FailingStreak int
+	//Probes are the latest probe results, newest first
+	Probes []types.HealthcheckResult
+}
+
+//ContainerHealthLog returns the healthcheck of the given container and the
+//latest results of its probes, as kept by the daemon
+func ContainerHealthLog(c types.ContainerJSON) HealthLog {
+	var log HealthLog
+	if c.Config != nil && c.Config.Healthcheck != nil {
+		test := c.Config.Healthcheck.Test
+		if len(test) > 0 && test[0] != "NONE" {
+			log.Defined = true
+			log.Test = healthcheckCommand(test)
+		}
+	}
+	if c.ContainerJSONBase == nil || c.State == nil || c.State.Health == nil {
+		return log
+	}
+	//the healthcheck might be defined by the image only
+	log.Defined = true
+	health := c.State.Health
+	log.Status = health.Status
+	log.FailingStreak = health.FailingStreak
+	for _, probe := range health.Log {
+		if probe != nil {
+			log.Probes = append(log.Probes, *probe)
+		}
+	}
+	sort.SliceStable(log.Probes, func(i, j int) bool {
+		return log.Probes[i].Start.After(log.Probes[j].Start)
+	})
+	return log
+}
+
+//HealthStatus returns the health of the given container as its healthcheck
+//tells: healthy, unhealthy or starting. Empty if the container is not
+//running or has no healthcheck.
+func HealthStatus(c *Container) string {
+	if c == nil || !IsContainerRunning(c) {
+		return ""
+	}
+	if details := c.ContainerJSON; details.ContainerJSONBase != nil && details.State != nil && details.State.Health != nil {
+		return details.State.Health.Status
+	}
+	//the list tells it on the status, i.e. Up 5 minutes (healthy)
+	for _, status := range []string{types.Unhealthy, types.Healthy, types.Starting} {
+		if strings.HasSuffix(c.Status, "("+status+")") ||
+			strings.HasSuffix(c.Status, "(health: "+status+")") {
+			return status
+		}
+	}
+	return ""
+}
+
+//HealthRank ranks the given health status, the unhealthy first, then the
+//containers starting, the healthy and last the ones without healthcheck
+func HealthRank(status string) int {
+	switch status {
+	case types.Unhealthy:
+		return 0
+	case types.Starting:
+		return 1
+	case types.Healthy:
+		return 2
+	}
+	return 3
+}
+
+//healthcheckCommand returns the command of a healthcheck test, which is
+//either ["CMD", args...] or ["CMD-SHELL", command]
+func healthcheckCommand(test []string) string {
+	switch test[0] {
+	case "CMD", "CMD-SHELL":
+		return strings.Join(test[1:], " ")
+	}
+	return strings.Join(test, " ")
+}
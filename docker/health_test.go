@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestContainerHealthLog(t *testing.T) {
+	now := time.Now()
+	probe := func(secondsAgo, exitCode int) *types.HealthcheckResult {
+		start := now.Add(-time.Duration(secondsAgo) * time.Second)
+		return &types.HealthcheckResult{Start: start, End: start.Add(time.Second), ExitCode: exitCode}
+	}
+	inspected := func(test []string, health *types.Health) types.ContainerJSON {
+		c := types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Health: health}},
+			Config:            &container.Config{},
+		}
+		if test != nil {
+			c.Config.Healthcheck = &container.HealthConfig{Test: test}
+		}
+		return c
+	}
+	tests := []struct {
+		name       string
+		container  types.ContainerJSON
+		defined    bool
+		test       string
+		exitCodes  []int
+		streak     int
+		wantStatus string
+	}{
+		{
+			"no healthcheck",
+			inspected(nil, nil),
+			false, "", nil, 0, "",
+		},
+		{
+			"healthcheck disabled",
+			inspected([]string{"NONE"}, nil),
+			false, "", nil, 0, "",
+		},
+		{
+			"not probed yet",
+			inspected([]string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"}, nil),
+			true, "curl -f http://localhost/ || exit 1", nil, 0, "",
+		},
+		{
+			"probes are sorted newest first",
+			inspected([]string{"CMD", "pg_isready", "-U", "postgres"}, &types.Health{
+				Status:        "unhealthy",
+				FailingStreak: 2,
+				Log:           []*types.HealthcheckResult{probe(30, 0), probe(20, 1), probe(10, 1)},
+			}),
+			true, "pg_isready -U postgres", []int{1, 1, 0}, 2, "unhealthy",
+		},
+		{
+			"healthcheck defined by the image",
+			inspected(nil, &types.Health{Status: "healthy", Log: []*types.HealthcheckResult{probe(1, 0)}}),
+			true, "", []int{0}, 0, "healthy",
+		},
+		{
+			"not inspected",
+			types.ContainerJSON{},
+			false, "", nil, 0, "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := ContainerHealthLog(tt.container)
+			if log.Defined != tt.defined || log.Test != tt.test {
+				t.Errorf("ContainerHealthLog() defined = %v, test = %q, want %v, %q", log.Defined, log.Test, tt.defined, tt.test)
+			}
+			if log.Status != tt.wantStatus || log.FailingStreak != tt.streak {
+				t.Errorf("ContainerHealthLog() status = %s (%d), want %s (%d)", log.Status, log.FailingStreak, tt.wantStatus, tt.streak)
+			}
+			var exitCodes []int
+			for _, p := range log.Probes {
+				exitCodes = append(exitCodes, p.ExitCode)
+			}
+			if !reflect.DeepEqual(exitCodes, tt.exitCodes) {
+				t.Errorf("ContainerHealthLog() exit codes = %v, want %v", exitCodes, tt.exitCodes)
+			}
+		})
+	}
+}
+
+func TestHealthStatus(t *testing.T) {
+	running := func(status string, health *types.Health) *Container {
+		return &Container{
+			Container: types.Container{Status: status},
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{State: &types.ContainerState{Health: health}},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		container *Container
+		want      string
+	}{
+		{"inspected", running("Up 5 minutes", &types.Health{Status: types.Unhealthy}), types.Unhealthy},
+		{"listed", &Container{Container: types.Container{Status: "Up 5 minutes (healthy)"}}, types.Healthy},
+		{"listed while starting", &Container{Container: types.Container{Status: "Up 2 seconds (health: starting)"}}, types.Starting},
+		{"no healthcheck", running("Up 5 minutes", nil), ""},
+		{"not running", running("Exited (0) 5 minutes ago", &types.Health{Status: types.Unhealthy}), ""},
+		{"no container", nil, ""},
+	}
+	for _, test := range tests {
+		if got := HealthStatus(test.container); got != test.want {
+			t.Errorf("%s: HealthStatus() = %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	ranked := []string{types.Unhealthy, types.Starting, types.Healthy, ""}
+	for i := 1; i < len(ranked); i++ {
+		if HealthRank(ranked[i-1]) >= HealthRank(ranked[i]) {
+			t.Errorf("%q is not ranked before %q", ranked[i-1], ranked[i])
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+//ValidateArchivePath checks that the given path is a file an image can be
+//saved to, the directory it goes in must exist
+func ValidateArchivePath(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("the path of the archive is required")
+	}
+	path, err := homedir.Expand(strings.TrimSpace(path))
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	info, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", filepath.Dir(path))
+	}
+	return nil
+}
+
+//SaveImage writes the image with the given id to a tar archive on the given
+//path, as docker save does. The image is saved by its tags, so that loading
+//the archive brings them back, or by its id if it has none. The archive is
+//written to a temporary file that replaces the given one once complete.
+//progress is told, as a fraction, how much of the image has been saved. It
+//returns the size of the archive.
+func (daemon *DockerDaemon) SaveImage(ctx context.Context, id, path string, progress func(float64)) (int64, error) {
+	if err := ValidateArchivePath(path); err != nil {
+		return 0, err
+	}
+	path, _ = homedir.Expand(strings.TrimSpace(path))
+	inspect, err := daemon.InspectImage(id)
+	if err != nil {
+		return 0, err
+	}
+	refs := ImageTags(inspect.RepoTags)
+	if len(refs) == 0 {
+		refs = []string{inspect.ID}
+	}
+	saved, err := daemon.client.ImageSave(ctx, refs)
+	if err != nil {
+		return 0, err
+	}
+	defer saved.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return 0, err
+	}
+	r := &progressReader{r: saved, total: inspect.VirtualSize, progress: progress}
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return 0, ctxErr
+		}
+		return 0, err
+	}
+	return written, nil
+}
+
+//LoadImage loads the images of the tar archive on the given path, as docker
+//load does. progress is told, as a fraction, how much of the archive has
+//been sent to the daemon. It returns the images loaded, by the names the
+//daemon gives them.
+func (daemon *DockerDaemon) LoadImage(ctx context.Context, path string, progress func(float64)) ([]string, error) {
+	path, err := homedir.Expand(strings.TrimSpace(path))
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	r := &progressReader{r: f, total: info.Size(), progress: progress}
+	response, err := daemon.client.ImageLoad(ctx, r, true)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	defer daemon.calls.forget(imagesCall)
+	if !response.JSON {
+		return loadedImages(response.Body)
+	}
+	return followLoad(ctx, response.Body)
+}
+
+//followLoad reads the messages the daemon streams as it loads an archive,
+//it returns the images loaded
+func followLoad(ctx context.Context, stream io.Reader) ([]string, error) {
+	var loaded []string
+	decoder := json.NewDecoder(stream)
+	for {
+		var m jsonmessage.JSONMessage
+		if err := decoder.Decode(&m); err != nil {
+			if err == io.EOF {
+				return loaded, nil
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+		if m.Error != nil {
+			return nil, errors.New(m.Error.Message)
+		}
+		if m.ErrorMessage != "" {
+			return nil, errors.New(m.ErrorMessage)
+		}
+		if image := loadedImage(m.Stream); image != "" {
+			loaded = append(loaded, image)
+		}
+	}
+}
+
+//loadedImages reads the plain text answer of daemons that do not stream
+//JSON messages, it returns the images loaded
+func loadedImages(r io.Reader) ([]string, error) {
+	var loaded []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if image := loadedImage(scanner.Text()); image != "" {
+			loaded = append(loaded, image)
+		}
+	}
+	return loaded, scanner.Err()
+}
+
+//loadedImage returns the image a line of the output of a load tells about,
+//i.e. "Loaded image: nginx:alpine" or "Loaded image ID: sha256:...", the
+//ids are shortened
+func loadedImage(line string) string {
+	line = strings.TrimSpace(line)
+	if id := strings.TrimPrefix(line, "Loaded image ID: "); id != line {
+		return ShortImageID(id)
+	}
+	if image := strings.TrimPrefix(line, "Loaded image: "); image != line {
+		return image
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestSaveAndLoadImage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	client := &mock.ImageArchiveAPIClientMock{
+		Tags:    []string{"nginx:alpine", "<none>:<none>"},
+		Content: "image archive",
+		Messages: []jsonmessage.JSONMessage{
+			{Stream: "Loaded image: nginx:alpine\n"},
+			{Stream: "Loaded image ID: sha256:4f1a8dfafdbc3a40a3d6e836e86a\n"},
+		},
+	}
+	daemon := &DockerDaemon{client: client, calls: newCallCoordinator()}
+	archive := filepath.Join(dir, "nginx.tar")
+
+	var done float64
+	size, err := daemon.SaveImage(context.Background(), "sha256:4f1a", archive, func(p float64) { done = p })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if size != int64(len(client.Content)) || done != 1 {
+		t.Errorf("Unexpected size %d or progress %f", size, done)
+	}
+	if !reflect.DeepEqual(client.Saved, []string{"nginx:alpine"}) {
+		t.Errorf("The image was not saved by its tags: %v", client.Saved)
+	}
+	if b, _ := ioutil.ReadFile(archive); string(b) != client.Content {
+		t.Errorf("Unexpected archive content: %q", b)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("The temporary archive was left behind: %d files", len(files))
+	}
+
+	client.Tags = nil
+	if _, err := daemon.SaveImage(context.Background(), "sha256:4f1a", archive, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(client.Saved, []string{"sha256:4f1a"}) {
+		t.Errorf("An image without tags was not saved by its id: %v", client.Saved)
+	}
+	if _, err := daemon.SaveImage(context.Background(), "sha256:4f1a", dir, nil); err == nil {
+		t.Error("An image was saved over a directory")
+	}
+
+	loaded, err := daemon.LoadImage(context.Background(), archive, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.Loaded != client.Content {
+		t.Errorf("Unexpected archive loaded: %q", client.Loaded)
+	}
+	if want := []string{"nginx:alpine", "4f1a8dfafdbc"}; !reflect.DeepEqual(loaded, want) {
+		t.Errorf("Unexpected images loaded: %v, want %v", loaded, want)
+	}
+
+	client.Messages = []jsonmessage.JSONMessage{{Error: &jsonmessage.JSONError{Message: "invalid tar header"}}}
+	if _, err := daemon.LoadImage(context.Background(), archive, nil); err == nil {
+		t.Error("A failed load returned no error")
+	}
+	if _, err := daemon.LoadImage(context.Background(), filepath.Join(dir, "nope.tar"), nil); err == nil {
+		t.Error("A missing archive was loaded")
+	}
+}
@@ -0,0 +1,208 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/builder/dockerignore"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/jsonmessage"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+//DefaultDockerfile is the Dockerfile built if none is given
+const DefaultDockerfile = "Dockerfile"
+
+//BuildOptions are the options to build an image with
+type BuildOptions struct {
+	//ContextDir is the directory sent to the daemon to build the image from
+	ContextDir string
+	//Dockerfile is the path of the Dockerfile relative to the context
+	Dockerfile string
+	//Reference is the repository:tag of the image, optional
+	Reference string
+}
+
+//ValidateBuildContext checks that the given path is a directory to build
+//an image from
+func ValidateBuildContext(dir string) error {
+	if strings.TrimSpace(dir) == "" {
+		return errors.New("the build context is required")
+	}
+	dir, err := homedir.Expand(strings.TrimSpace(dir))
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
+
+//ValidateDockerfile checks that the given Dockerfile is a file of the given
+//build context
+func ValidateDockerfile(contextDir, dockerfile string) error {
+	if dockerfile == "" {
+		dockerfile = DefaultDockerfile
+	}
+	if filepath.IsAbs(dockerfile) || strings.HasPrefix(filepath.Clean(dockerfile), "..") {
+		return fmt.Errorf("the Dockerfile must be in the build context, %s is not", dockerfile)
+	}
+	dir, err := homedir.Expand(contextDir)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(filepath.Join(dir, dockerfile))
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", dockerfile)
+	}
+	return nil
+}
+
+//BuildImage builds an image as docker build does, the files of the context
+//ignored by its .dockerignore are not sent to the daemon. The build output
+//is written to the given writer as the daemon streams it. It returns the id
+//of the image built.
+func (daemon *DockerDaemon) BuildImage(ctx context.Context, options BuildOptions, output io.Writer) (string, error) {
+	dir, err := homedir.Expand(strings.TrimSpace(options.ContextDir))
+	if err != nil {
+		return "", err
+	}
+	dockerfile := filepath.ToSlash(options.Dockerfile)
+	if dockerfile == "" {
+		dockerfile = DefaultDockerfile
+	}
+	if err := ValidateDockerfile(dir, dockerfile); err != nil {
+		return "", err
+	}
+	buildOptions := types.ImageBuildOptions{
+		Dockerfile:  dockerfile,
+		Remove:      true,
+		ForceRemove: true,
+	}
+	if ref := strings.TrimSpace(options.Reference); ref != "" {
+		if err := ValidateImageReference(ref); err != nil {
+			return "", err
+		}
+		buildOptions.Tags = []string{ref}
+	}
+	exclude, err := buildContextExclude(dir, dockerfile)
+	if err != nil {
+		return "", err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeTar(w, dir, "", func(int64) {}, exclude))
+	}()
+	defer r.Close()
+	response, err := daemon.client.ImageBuild(ctx, r, buildOptions)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	defer daemon.calls.forget(imagesCall)
+	return followBuild(ctx, response.Body, output)
+}
+
+//buildContextExclude returns the func that tells which files of the given
+//build context its .dockerignore ignores. The Dockerfile and the
+//.dockerignore itself are always sent, as docker build does. Exceptions to
+//a pattern that ignores a directory do not bring back the files in it.
+func buildContextExclude(dir, dockerfile string) (func(string) bool, error) {
+	f, err := os.Open(filepath.Join(dir, ".dockerignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	patterns, err := dockerignore.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := fileutils.NewPatternMatcher(patterns)
+	if err != nil {
+		return nil, err
+	}
+	dockerfile = path.Clean(dockerfile)
+	keep := map[string]bool{
+		dockerfile:      true,
+		".dockerignore": true,
+	}
+	return func(rel string) bool {
+		rel = filepath.ToSlash(rel)
+		//the directories the Dockerfile is in are kept, not all they have
+		if keep[rel] || strings.HasPrefix(dockerfile, rel+"/") {
+			return false
+		}
+		excluded, err := matcher.Matches(rel)
+		return err == nil && excluded
+	}, nil
+}
+
+//followBuild writes the output of a build, as the daemon streams it, to the
+//given writer until the build is done. It returns the id of the image built.
+func followBuild(ctx context.Context, stream io.Reader, output io.Writer) (string, error) {
+	var id string
+	decoder := json.NewDecoder(stream)
+	for {
+		var m jsonmessage.JSONMessage
+		if err := decoder.Decode(&m); err != nil {
+			if err != io.EOF {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return "", ctxErr
+				}
+				return "", err
+			}
+			if id == "" {
+				return "", errors.New("the daemon did not tell the id of the image built")
+			}
+			return id, nil
+		}
+		if m.Error != nil {
+			fmt.Fprintln(output, m.Error.Message)
+			return "", errors.New(m.Error.Message)
+		}
+		if m.ErrorMessage != "" {
+			fmt.Fprintln(output, m.ErrorMessage)
+			return "", errors.New(m.ErrorMessage)
+		}
+		switch {
+		case m.Aux != nil:
+			var aux types.BuildResult
+			if err := json.Unmarshal(*m.Aux, &aux); err == nil && aux.ID != "" {
+				id = aux.ID
+			}
+		case m.Stream != "":
+			fmt.Fprint(output, m.Stream)
+			//daemons that do not send the id apart only tell it here
+			if built := strings.TrimPrefix(m.Stream, "Successfully built "); built != m.Stream && id == "" {
+				id = strings.TrimSpace(built)
+			}
+		case m.Status != "" && m.Progress == nil:
+			//pulls of the base image, their progress is left out
+			if m.ID != "" {
+				fmt.Fprintf(output, "%s: %s\n", m.ID, m.Status)
+			} else {
+				fmt.Fprintln(output, m.Status)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func buildContext(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "dry-build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBuildImage(t *testing.T) {
+	dir := buildContext(t, map[string]string{
+		"build/Dockerfile": "FROM alpine\nCOPY . /app",
+		"main.go":          "package main",
+		"logs/debug.log":   "debug",
+		"notes.tmp":        "notes",
+		".dockerignore":    "logs\n*.tmp\nbuild",
+		".git/HEAD":        "ref: refs/heads/master",
+		"docs/readme.md":   "docs",
+		//patterns without a directory only match on the root of the context
+		"docs/internal.tmp": "internal",
+	})
+	defer os.RemoveAll(dir)
+	aux := json.RawMessage(`{"ID":"sha256:4f1a"}`)
+	client := &mock.ImageBuildAPIClientMock{Messages: []jsonmessage.JSONMessage{
+		{Stream: "Step 1/2 : FROM alpine\n"},
+		{ID: "alpine", Status: "Pulling fs layer"},
+		{ID: "alpine", Status: "Downloading", Progress: &jsonmessage.JSONProgress{Current: 1, Total: 2}},
+		{Stream: "Step 2/2 : COPY . /app\n"},
+		{Aux: &aux},
+		{Stream: "Successfully built 4f1a\n"},
+	}}
+	daemon := &DockerDaemon{client: client, calls: newCallCoordinator()}
+	var output bytes.Buffer
+	id, err := daemon.BuildImage(context.Background(), BuildOptions{
+		ContextDir: dir,
+		Dockerfile: "build/Dockerfile",
+		Reference:  "myapp:dev",
+	}, &output)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id != "sha256:4f1a" {
+		t.Errorf("Unexpected image id: %s", id)
+	}
+	want := "Step 1/2 : FROM alpine\nalpine: Pulling fs layer\nStep 2/2 : COPY . /app\nSuccessfully built 4f1a\n"
+	if output.String() != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", output.String(), want)
+	}
+	if client.Options.Dockerfile != "build/Dockerfile" || !reflect.DeepEqual(client.Options.Tags, []string{"myapp:dev"}) {
+		t.Errorf("Unexpected build options: %+v", client.Options)
+	}
+	sort.Strings(client.Files)
+	wantFiles := []string{".dockerignore", ".git/HEAD", "build/Dockerfile", "docs/internal.tmp", "docs/readme.md", "main.go"}
+	if !reflect.DeepEqual(client.Files, wantFiles) {
+		t.Errorf("Unexpected files sent: %v, want %v", client.Files, wantFiles)
+	}
+
+	client.Messages = []jsonmessage.JSONMessage{
+		{Stream: "Step 1/1 : FROM nosuchimage\n"},
+		{Error: &jsonmessage.JSONError{Message: "pull access denied for nosuchimage"}},
+	}
+	if _, err := daemon.BuildImage(context.Background(), BuildOptions{ContextDir: dir, Dockerfile: "build/Dockerfile"}, ioutil.Discard); err == nil {
+		t.Error("A failed build returned no error")
+	}
+	if _, err := daemon.BuildImage(context.Background(), BuildOptions{ContextDir: dir}, ioutil.Discard); err == nil {
+		t.Error("A context without a Dockerfile was built")
+	}
+}
+
+func TestValidateBuildContext(t *testing.T) {
+	dir := buildContext(t, map[string]string{"Dockerfile": "FROM alpine"})
+	defer os.RemoveAll(dir)
+	if err := ValidateBuildContext(dir); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, invalid := range []string{"", filepath.Join(dir, "Dockerfile"), filepath.Join(dir, "nope")} {
+		if err := ValidateBuildContext(invalid); err == nil {
+			t.Errorf("%q is not a valid build context", invalid)
+		}
+	}
+	if err := ValidateDockerfile(dir, ""); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := ValidateDockerfile(dir, "../Dockerfile"); err == nil {
+		t.Error("A Dockerfile out of the context is not valid")
+	}
+}
@@ -0,0 +1,205 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+//Change describes how something differs between two images
+type Change int
+
+//Known changes
+const (
+	Unchanged Change = iota
+	Added
+	Removed
+	Changed
+)
+
+//LayerDiff compares a layer of an image with the layer of another image in
+//the same place. Old is nil for added layers, New is nil for removed layers.
+type LayerDiff struct {
+	Change Change
+	Old    *image.HistoryResponseItem
+	New    *image.HistoryResponseItem
+}
+
+//SizeDelta returns how much the layer grew, negative if it shrank
+func (l LayerDiff) SizeDelta() int64 {
+	var delta int64
+	if l.New != nil {
+		delta += l.New.Size
+	}
+	if l.Old != nil {
+		delta -= l.Old.Size
+	}
+	return delta
+}
+
+//ValueDiff compares a config value, an exposed port, an env variable or a
+//label, of two images
+type ValueDiff struct {
+	Change Change
+	Key    string
+	Old    string
+	New    string
+}
+
+//ImageDiff describes what changed from an image to another
+type ImageDiff struct {
+	OldSize int64
+	NewSize int64
+	//Layers of both images, from the base layer to the top one
+	Layers []LayerDiff
+	Ports  []ValueDiff
+	Env    []ValueDiff
+	Labels []ValueDiff
+}
+
+//SizeDelta returns how much the new image grew, negative if it shrank
+func (d ImageDiff) SizeDelta() int64 {
+	return d.NewSize - d.OldSize
+}
+
+//CompareImages compares the old image with the new one using their histories,
+//as returned by the daemon, and their configs. Layers are matched by the
+//instruction that created them and their size, layers between matches are
+//paired as changed, leftovers are either added or removed. Images with no
+//layers in common show every layer as changed.
+func CompareImages(
+	oldHistory, newHistory []image.HistoryResponseItem,
+	oldImage, newImage types.ImageInspect) ImageDiff {
+	diff := ImageDiff{
+		OldSize: oldImage.Size,
+		NewSize: newImage.Size,
+		Layers:  compareLayers(oldestFirst(oldHistory), oldestFirst(newHistory)),
+	}
+	oldPorts, oldEnv, oldLabels := imageConfig(oldImage)
+	newPorts, newEnv, newLabels := imageConfig(newImage)
+	diff.Ports = compareValues(oldPorts, newPorts)
+	diff.Env = compareValues(oldEnv, newEnv)
+	diff.Labels = compareValues(oldLabels, newLabels)
+	return diff
+}
+
+//compareLayers aligns both layer lists using their longest common subsequence
+func compareLayers(old, new []image.HistoryResponseItem) []LayerDiff {
+	lcs := make([][]int, len(old)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if sameLayer(old[i], new[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diffs []LayerDiff
+	var removed, added []image.HistoryResponseItem
+	flush := func() {
+		for len(removed) > 0 && len(added) > 0 {
+			diffs = append(diffs, LayerDiff{Change: Changed, Old: &removed[0], New: &added[0]})
+			removed, added = removed[1:], added[1:]
+		}
+		for i := range removed {
+			diffs = append(diffs, LayerDiff{Change: Removed, Old: &removed[i]})
+		}
+		for i := range added {
+			diffs = append(diffs, LayerDiff{Change: Added, New: &added[i]})
+		}
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(old) && j < len(new) {
+		switch {
+		case sameLayer(old[i], new[j]):
+			flush()
+			diffs = append(diffs, LayerDiff{Change: Unchanged, Old: &old[i], New: &new[j]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			removed = append(removed, old[i])
+			i++
+		default:
+			added = append(added, new[j])
+			j++
+		}
+	}
+	removed = append(removed, old[i:]...)
+	added = append(added, new[j:]...)
+	flush()
+	return diffs
+}
+
+func sameLayer(a, b image.HistoryResponseItem) bool {
+	return a.CreatedBy == b.CreatedBy && a.Size == b.Size
+}
+
+//oldestFirst returns the given history, that the daemon returns from the
+//top layer down, starting with the base layer
+func oldestFirst(history []image.HistoryResponseItem) []image.HistoryResponseItem {
+	result := make([]image.HistoryResponseItem, len(history))
+	for i, layer := range history {
+		result[len(history)-1-i] = layer
+	}
+	return result
+}
+
+//imageConfig returns the exposed ports, env variables and labels of the given image
+func imageConfig(img types.ImageInspect) (map[string]string, map[string]string, map[string]string) {
+	ports := make(map[string]string)
+	env := make(map[string]string)
+	labels := make(map[string]string)
+	config := img.Config
+	if config == nil {
+		config = img.ContainerConfig
+	}
+	if config == nil {
+		return ports, env, labels
+	}
+	for port := range config.ExposedPorts {
+		ports[string(port)] = ""
+	}
+	for _, e := range config.Env {
+		kv := strings.SplitN(e, "=", 2)
+		if len(kv) == 2 {
+			env[kv[0]] = kv[1]
+		} else {
+			env[kv[0]] = ""
+		}
+	}
+	for k, v := range config.Labels {
+		labels[k] = v
+	}
+	return ports, env, labels
+}
+
+//compareValues returns the values that differ between old and new, sorted by key
+func compareValues(old, new map[string]string) []ValueDiff {
+	var diffs []ValueDiff
+	for k, v := range old {
+		newValue, ok := new[k]
+		switch {
+		case !ok:
+			diffs = append(diffs, ValueDiff{Change: Removed, Key: k, Old: v})
+		case newValue != v:
+			diffs = append(diffs, ValueDiff{Change: Changed, Key: k, Old: v, New: newValue})
+		}
+	}
+	for k, v := range new {
+		if _, ok := old[k]; !ok {
+			diffs = append(diffs, ValueDiff{Change: Added, Key: k, New: v})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+	return diffs
+}
@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/go-connections/nat"
+)
+
+//layers builds an image history, top layer first as the daemon returns it,
+//from the given instructions and sizes, listed from the base layer up
+func layers(instructions ...interface{}) []image.HistoryResponseItem {
+	var history []image.HistoryResponseItem
+	for i := 0; i < len(instructions); i += 2 {
+		history = append([]image.HistoryResponseItem{{
+			CreatedBy: instructions[i].(string),
+			Size:      int64(instructions[i+1].(int)),
+		}}, history...)
+	}
+	return history
+}
+
+func changes(diffs []LayerDiff) []Change {
+	var result []Change
+	for _, d := range diffs {
+		result = append(result, d.Change)
+	}
+	return result
+}
+
+func TestCompareImages_Layers(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    []image.HistoryResponseItem
+		new    []image.HistoryResponseItem
+		want   []Change
+		deltas []int64
+	}{
+		{
+			"same layers",
+			layers("ADD base", 10, "RUN apt-get", 20),
+			layers("ADD base", 10, "RUN apt-get", 20),
+			[]Change{Unchanged, Unchanged},
+			[]int64{0, 0},
+		},
+		{
+			"layer added on top",
+			layers("ADD base", 10),
+			layers("ADD base", 10, "COPY app", 5),
+			[]Change{Unchanged, Added},
+			[]int64{0, 5},
+		},
+		{
+			"layer removed",
+			layers("ADD base", 10, "RUN rm", 1, "COPY app", 5),
+			layers("ADD base", 10, "COPY app", 5),
+			[]Change{Unchanged, Removed, Unchanged},
+			[]int64{0, -1, 0},
+		},
+		{
+			"layer changed",
+			layers("ADD base", 10, "RUN apt-get", 20, "COPY app", 5),
+			layers("ADD base", 10, "RUN apt-get", 25, "COPY app", 5),
+			[]Change{Unchanged, Changed, Unchanged},
+			[]int64{0, 5, 0},
+		},
+		{
+			"no shared layers",
+			layers("ADD alpine", 5, "COPY app", 5),
+			layers("ADD debian", 50, "RUN apt-get", 20, "COPY app", 6),
+			[]Change{Changed, Changed, Added},
+			[]int64{45, 15, 6},
+		},
+		{
+			"empty history",
+			nil,
+			layers("ADD base", 10),
+			[]Change{Added},
+			[]int64{10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := CompareImages(tt.old, tt.new, types.ImageInspect{}, types.ImageInspect{})
+			if got := changes(diff.Layers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareImages() layer changes = %v, want %v", got, tt.want)
+			}
+			var deltas []int64
+			for _, l := range diff.Layers {
+				deltas = append(deltas, l.SizeDelta())
+			}
+			if !reflect.DeepEqual(deltas, tt.deltas) {
+				t.Errorf("CompareImages() layer size deltas = %v, want %v", deltas, tt.deltas)
+			}
+		})
+	}
+}
+
+func TestCompareImages_Config(t *testing.T) {
+	old := types.ImageInspect{
+		Size: 100,
+		Config: &container.Config{
+			ExposedPorts: nat.PortSet{"80/tcp": {}, "443/tcp": {}},
+			Env:          []string{"PATH=/usr/bin", "VERSION=1.4", "DEBUG"},
+			Labels:       map[string]string{"maintainer": "me"},
+		},
+	}
+	new := types.ImageInspect{
+		Size: 150,
+		Config: &container.Config{
+			ExposedPorts: nat.PortSet{"443/tcp": {}, "8080/tcp": {}},
+			Env:          []string{"PATH=/usr/bin", "VERSION=1.5"},
+			Labels:       map[string]string{"maintainer": "me", "version": "1.5"},
+		},
+	}
+	diff := CompareImages(nil, nil, old, new)
+
+	if diff.SizeDelta() != 50 {
+		t.Errorf("Unexpected size delta: %d", diff.SizeDelta())
+	}
+	wantPorts := []ValueDiff{
+		{Change: Removed, Key: "80/tcp"},
+		{Change: Added, Key: "8080/tcp"},
+	}
+	if !reflect.DeepEqual(diff.Ports, wantPorts) {
+		t.Errorf("Unexpected ports diff: %+v", diff.Ports)
+	}
+	wantEnv := []ValueDiff{
+		{Change: Removed, Key: "DEBUG"},
+		{Change: Changed, Key: "VERSION", Old: "1.4", New: "1.5"},
+	}
+	if !reflect.DeepEqual(diff.Env, wantEnv) {
+		t.Errorf("Unexpected env diff: %+v", diff.Env)
+	}
+	wantLabels := []ValueDiff{
+		{Change: Added, Key: "version", New: "1.5"},
+	}
+	if !reflect.DeepEqual(diff.Labels, wantLabels) {
+		t.Errorf("Unexpected labels diff: %+v", diff.Labels)
+	}
+}
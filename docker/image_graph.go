@@ -0,0 +1,129 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+//Prefixes of the image list filters that use the parent/child relationships
+//of images. With leaf:true the list has the images no other local image is
+//built on, with leaf:false those that are and with parent:<id> the children
+//of the image with the given id.
+const (
+	LeafFilterPrefix   = "leaf:"
+	ParentFilterPrefix = "parent:"
+)
+
+//ImageGraph has the parent/child relationships of the local images
+type ImageGraph struct {
+	parents  map[string]string
+	children map[string][]string
+}
+
+//NewImageGraph builds the graph of the given images, histories has the
+//history of each image by image id. The parent of an image is the nearest
+//image on its history that is one of the given images, images built locally
+//also tell their parent on their summary.
+func NewImageGraph(images []types.ImageSummary, histories map[string][]image.HistoryResponseItem) *ImageGraph {
+	g := &ImageGraph{
+		parents:  make(map[string]string),
+		children: make(map[string][]string),
+	}
+	local := make(map[string]bool, len(images))
+	for _, i := range images {
+		local[i.ID] = true
+	}
+	for _, i := range images {
+		parent := ""
+		for _, entry := range histories[i.ID] {
+			if entry.ID != i.ID && local[entry.ID] {
+				parent = entry.ID
+				break
+			}
+		}
+		if parent == "" && local[i.ParentID] && i.ParentID != i.ID {
+			parent = i.ParentID
+		}
+		if parent != "" {
+			g.parents[i.ID] = parent
+			g.children[parent] = append(g.children[parent], i.ID)
+		}
+	}
+	for _, children := range g.children {
+		sort.Strings(children)
+	}
+	return g
+}
+
+//Parent returns the id of the parent of the image with the given id, empty
+//if it has none among the local images
+func (g *ImageGraph) Parent(id string) string {
+	if g == nil {
+		return ""
+	}
+	return g.parents[id]
+}
+
+//Children returns the ids of the images built on the image with the given id
+func (g *ImageGraph) Children(id string) []string {
+	if g == nil {
+		return nil
+	}
+	return g.children[id]
+}
+
+//Descendants returns the ids of the images built, directly or not, on the
+//image with the given id
+func (g *ImageGraph) Descendants(id string) []string {
+	var descendants []string
+	pending := g.Children(id)
+	for len(pending) > 0 {
+		child := pending[0]
+		pending = pending[1:]
+		descendants = append(descendants, child)
+		pending = append(pending, g.Children(child)...)
+	}
+	return descendants
+}
+
+//IsLeaf returns true if no local image is built on the image with the given id
+func (g *ImageGraph) IsLeaf(id string) bool {
+	return len(g.Children(id)) == 0
+}
+
+//imageGraphKey identifies the given set of images, the graph of the images is
+//kept until the key of the local images changes
+func imageGraphKey(images []types.ImageSummary) string {
+	ids := make([]string, len(images))
+	for i, image := range images {
+		ids[i] = image.ID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+//ImageGraph returns the parent/child graph of the given local images. It is
+//built from the history of every image the first time it is asked for, and
+//kept until the local images change.
+func (daemon *DockerDaemon) ImageGraph(images []types.ImageSummary) (*ImageGraph, error) {
+	key := imageGraphKey(images)
+	daemon.imageGraphLock.Lock()
+	defer daemon.imageGraphLock.Unlock()
+	if daemon.imageGraph != nil && daemon.imageGraphKey == key {
+		return daemon.imageGraph, nil
+	}
+	histories := make(map[string][]image.HistoryResponseItem, len(images))
+	for _, i := range images {
+		history, err := daemon.History(i.ID)
+		if err != nil {
+			return nil, err
+		}
+		histories[i.ID] = history
+	}
+	daemon.imageGraph = NewImageGraph(images, histories)
+	daemon.imageGraphKey = key
+	return daemon.imageGraph, nil
+}
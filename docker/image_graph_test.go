@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/moncho/dry/docker/mock"
+)
+
+//imageGraphFixture has a pulled base image, an image built on it, two
+//images built on that one and an image unrelated to the others
+func imageGraphFixture() ([]types.ImageSummary, map[string][]image.HistoryResponseItem) {
+	images := []types.ImageSummary{
+		{ID: "sha256:base"},
+		{ID: "sha256:runtime"},
+		{ID: "sha256:app"},
+		{ID: "sha256:worker", ParentID: "sha256:runtime"},
+		{ID: "sha256:other"},
+	}
+	histories := map[string][]image.HistoryResponseItem{
+		"sha256:base":    {{ID: "sha256:base"}, {ID: "<missing>"}},
+		"sha256:runtime": {{ID: "sha256:runtime"}, {ID: "<missing>"}, {ID: "sha256:base"}, {ID: "<missing>"}},
+		//intermediate images of a local build are skipped if they were removed
+		"sha256:app":    {{ID: "sha256:app"}, {ID: "sha256:intermediate"}, {ID: "sha256:runtime"}, {ID: "sha256:base"}},
+		"sha256:worker": {{ID: "sha256:worker"}, {ID: "<missing>"}},
+		"sha256:other":  {{ID: "sha256:other"}, {ID: "sha256:not-local"}},
+	}
+	return images, histories
+}
+
+func TestImageGraph(t *testing.T) {
+	images, histories := imageGraphFixture()
+	g := NewImageGraph(images, histories)
+
+	tests := []struct {
+		id              string
+		wantParent      string
+		wantChildren    []string
+		wantDescendants []string
+	}{
+		{"sha256:base", "", []string{"sha256:runtime"}, []string{"sha256:runtime", "sha256:app", "sha256:worker"}},
+		{"sha256:runtime", "sha256:base", []string{"sha256:app", "sha256:worker"}, []string{"sha256:app", "sha256:worker"}},
+		{"sha256:app", "sha256:runtime", nil, nil},
+		{"sha256:worker", "sha256:runtime", nil, nil},
+		{"sha256:other", "", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := g.Parent(tt.id); got != tt.wantParent {
+				t.Errorf("Parent() = %q, want %q", got, tt.wantParent)
+			}
+			if got := g.Children(tt.id); !reflect.DeepEqual(got, tt.wantChildren) {
+				t.Errorf("Children() = %v, want %v", got, tt.wantChildren)
+			}
+			if got := g.Descendants(tt.id); !reflect.DeepEqual(got, tt.wantDescendants) {
+				t.Errorf("Descendants() = %v, want %v", got, tt.wantDescendants)
+			}
+			if got := g.IsLeaf(tt.id); got != (len(tt.wantChildren) == 0) {
+				t.Errorf("IsLeaf() = %v", got)
+			}
+		})
+	}
+
+	var unknown *ImageGraph
+	if unknown.Parent("sha256:app") != "" || !unknown.IsLeaf("sha256:base") {
+		t.Error("A nil graph has relationships")
+	}
+}
+
+func TestImageGraphIsCached(t *testing.T) {
+	images, histories := imageGraphFixture()
+	client := &mock.ImageHistoryAPIClientMock{Histories: histories}
+	daemon := &DockerDaemon{client: client}
+
+	g, err := daemon.ImageGraph(images)
+	if err != nil {
+		t.Fatalf("Unexpected error building the graph: %v", err)
+	}
+	if client.Calls() != len(images) {
+		t.Errorf("Image histories read = %d, want %d", client.Calls(), len(images))
+	}
+	//the same images in a different order
+	reversed := make([]types.ImageSummary, len(images))
+	for i, image := range images {
+		reversed[len(images)-1-i] = image
+	}
+	cached, err := daemon.ImageGraph(reversed)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached != g || client.Calls() != len(images) {
+		t.Errorf("The graph was built again for the same images, histories read = %d", client.Calls())
+	}
+
+	//an image is removed
+	g, err = daemon.ImageGraph(images[:4])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g == cached || client.Calls() != 2*len(images)-1 {
+		t.Errorf("The graph was not built again once the images changed, histories read = %d", client.Calls())
+	}
+
+	if _, err := daemon.ImageGraph(append(images, types.ImageSummary{ID: "sha256:gone"})); err == nil {
+		t.Error("Expected an error building the graph of an image without history")
+	}
+}
@@ -0,0 +1,294 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+//whiteoutPrefix marks, on a layer, the files deleted from the layers below
+const whiteoutPrefix = ".wh."
+
+//opaqueWhiteout marks, on a layer, a directory whose content on the layers
+//below is hidden
+const opaqueWhiteout = whiteoutPrefix + whiteoutPrefix + ".opq"
+
+//maxLayerEntries is how many entries of a layer are indexed, the size of
+//the entries past it is still added to their closest indexed directory
+var maxLayerEntries = 200000
+
+//ErrLayerNotFound is returned when the saved image does not have the layer asked for
+var ErrLayerNotFound = errors.New("the layer was not found on the saved image")
+
+//ImageLayer is a layer of the filesystem of an image
+type ImageLayer struct {
+	//DiffID is the digest of the uncompressed content of the layer
+	DiffID string
+	//CreatedBy is the instruction that created the layer, if known
+	CreatedBy string
+	//Size of the layer, if known
+	Size int64
+}
+
+//LayerEntry is a file or a directory of a layer
+type LayerEntry struct {
+	Name string
+	//Size of the file or, for directories, of everything in it
+	Size int64
+	Dir  bool
+	//Deleted is true if the entry is a whiteout, it removes the file with
+	//the same name from the layers below
+	Deleted bool
+	//Opaque is true for directories whose content on the layers below is hidden
+	Opaque   bool
+	Children []*LayerEntry
+	//children by name, only while indexing
+	byName map[string]*LayerEntry
+}
+
+//LayerContents is the index of the content of a layer
+type LayerContents struct {
+	Root *LayerEntry
+	//Entries is the number of entries indexed
+	Entries int
+	//Truncated is true if the layer has more entries than those indexed
+	Truncated bool
+}
+
+//ImageLayers returns the layers of the image with the given id, the
+//first one is the base layer
+func (daemon *DockerDaemon) ImageLayers(id string) ([]ImageLayer, error) {
+	history, err := daemon.History(id)
+	if err != nil {
+		return nil, err
+	}
+	inspect, err := daemon.InspectImage(id)
+	if err != nil {
+		return nil, err
+	}
+	return imageLayers(history, inspect), nil
+}
+
+//LayerContents indexes the content of the layer of the image with the given
+//id whose DiffID is the given one. The Engine API does not give access to
+//the content store, so the image is saved and the stream read until the
+//layer is found, no file is written to disk. progress is told, as a
+//fraction, how much of the image has been read.
+func (daemon *DockerDaemon) LayerContents(ctx context.Context, id, diffID string, progress func(float64)) (*LayerContents, error) {
+	inspect, err := daemon.InspectImage(id)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := daemon.client.ImageSave(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	defer saved.Close()
+	r := &progressReader{r: saved, total: inspect.VirtualSize, progress: progress}
+	return findLayer(ctx, r, diffID)
+}
+
+//imageLayers pairs the layers of an image with the history entries that
+//created them. History entries of instructions that only change the config
+//of the image have no layer, they are told apart by their size; when the
+//sizes do not add up the layers are returned without their history.
+func imageLayers(history []image.HistoryResponseItem, inspect types.ImageInspect) []ImageLayer {
+	layers := make([]ImageLayer, len(inspect.RootFS.Layers))
+	for i, diffID := range inspect.RootFS.Layers {
+		layers[i].DiffID = diffID
+	}
+	var withContent []image.HistoryResponseItem
+	//the history comes newest first
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Size > 0 {
+			withContent = append(withContent, history[i])
+		}
+	}
+	if len(withContent) != len(layers) {
+		return layers
+	}
+	for i, h := range withContent {
+		layers[i].CreatedBy = h.CreatedBy
+		layers[i].Size = h.Size
+	}
+	return layers
+}
+
+//findLayer reads the given image, as saved by the Docker daemon, until the
+//layer with the given DiffID is found and returns the index of its content.
+//The layers are only known by their DiffID once read, so every layer read is
+//indexed and hashed at once and thrown away if it is not the one asked for.
+func findLayer(ctx context.Context, saved io.Reader, diffID string) (*LayerContents, error) {
+	archive := tar.NewReader(saved)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil, ErrLayerNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || path.Base(header.Name) != "layer.tar" {
+			continue
+		}
+		hash := sha256.New()
+		contents, err := IndexLayer(io.TeeReader(archive, hash))
+		if err != nil {
+			return nil, fmt.Errorf("reading layer %s: %s", header.Name, err.Error())
+		}
+		if "sha256:"+hex.EncodeToString(hash.Sum(nil)) == diffID {
+			return contents, nil
+		}
+	}
+}
+
+//IndexLayer reads the given layer, a tar stream, and returns the tree of
+//its content. Directories are sized after everything in them and their
+//entries sorted largest first.
+func IndexLayer(layer io.Reader) (*LayerContents, error) {
+	contents := &LayerContents{Root: &LayerEntry{Name: "/", Dir: true}}
+	archive := tar.NewReader(layer)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		contents.add(header)
+	}
+	//so that the reader is left at the end of the stream
+	if _, err := io.Copy(ioutil.Discard, layer); err != nil {
+		return nil, err
+	}
+	sizeAndSort(contents.Root)
+	return contents, nil
+}
+
+//add adds the entry with the given header to the tree
+func (c *LayerContents) add(header *tar.Header) {
+	name := strings.Trim(path.Clean("/"+header.Name), "/")
+	if name == "" {
+		return
+	}
+	parts := strings.Split(name, "/")
+	dir := c.Root
+	for _, part := range parts[:len(parts)-1] {
+		child := dir.child(part)
+		if child == nil {
+			if c.full() {
+				//the entry is accounted for on the closest indexed directory
+				dir.Size += header.Size
+				return
+			}
+			child = &LayerEntry{Name: part, Dir: true}
+			dir.addChild(child)
+			c.Entries++
+		}
+		dir = child
+	}
+	base := parts[len(parts)-1]
+	if base == opaqueWhiteout {
+		dir.Opaque = true
+		return
+	}
+	entry := &LayerEntry{
+		Name: base,
+		Dir:  header.Typeflag == tar.TypeDir,
+		Size: header.Size,
+	}
+	if strings.HasPrefix(base, whiteoutPrefix) {
+		entry.Name = strings.TrimPrefix(base, whiteoutPrefix)
+		entry.Deleted = true
+		entry.Size = 0
+	}
+	if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+		entry.Size = 0
+	}
+	if dir.child(entry.Name) != nil {
+		//directories can be listed after their content
+		return
+	}
+	if c.full() {
+		dir.Size += entry.Size
+		return
+	}
+	dir.addChild(entry)
+	c.Entries++
+}
+
+func (c *LayerContents) full() bool {
+	if c.Entries >= maxLayerEntries {
+		c.Truncated = true
+	}
+	return c.Truncated
+}
+
+//child returns the entry of this directory with the given name, nil if there is none
+func (e *LayerEntry) child(name string) *LayerEntry {
+	return e.byName[name]
+}
+
+func (e *LayerEntry) addChild(child *LayerEntry) {
+	if e.byName == nil {
+		e.byName = make(map[string]*LayerEntry)
+	}
+	e.byName[child.Name] = child
+	e.Children = append(e.Children, child)
+}
+
+//sizeAndSort adds the size of their content to the given directory and
+//those below, sorting their entries largest first. The lookup by name of
+//the entries is no longer needed and dropped.
+func sizeAndSort(dir *LayerEntry) int64 {
+	dir.byName = nil
+	for _, child := range dir.Children {
+		if child.Dir {
+			child.Size = sizeAndSort(child)
+		}
+		dir.Size += child.Size
+	}
+	sort.SliceStable(dir.Children, func(i, j int) bool {
+		if dir.Children[i].Size != dir.Children[j].Size {
+			return dir.Children[i].Size > dir.Children[j].Size
+		}
+		return dir.Children[i].Name < dir.Children[j].Name
+	})
+	return dir.Size
+}
+
+//progressReader tells, as it is read, how much of its total has been read
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	total    int64
+	progress func(float64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.progress != nil && p.total > 0 {
+		done := float64(p.read) / float64(p.total)
+		if done > 1 {
+			done = 1
+		}
+		p.progress(done)
+	}
+	return n, err
+}
@@ -0,0 +1,189 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+)
+
+//tarEntry is an entry of a tar built for testing, directories end with /
+type tarEntry struct {
+	name string
+	size int
+}
+
+func buildTar(t *testing.T, entries ...tarEntry) []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: 0644, Size: int64(e.size), Typeflag: tar.TypeReg}
+		if e.name[len(e.name)-1] == '/' {
+			header.Typeflag = tar.TypeDir
+			header.Mode = 0755
+		}
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(make([]byte, e.size)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestIndexLayer(t *testing.T) {
+	layer := buildTar(t,
+		tarEntry{"app/", 0},
+		tarEntry{"app/index.js", 10},
+		tarEntry{"app/node_modules/", 0},
+		tarEntry{"app/node_modules/a/big.js", 300},
+		tarEntry{"app/node_modules/b.js", 50},
+		tarEntry{"etc/.wh.passwd", 0},
+		tarEntry{"var/cache/.wh..wh..opq", 0},
+		tarEntry{"var/cache/new", 5},
+		tarEntry{"README", 20},
+	)
+	contents, err := IndexLayer(bytes.NewReader(layer))
+	if err != nil {
+		t.Fatalf("IndexLayer() error = %v", err)
+	}
+	root := contents.Root
+	if root.Size != 385 {
+		t.Errorf("Root size = %d, want 385", root.Size)
+	}
+	var names []string
+	for _, c := range root.Children {
+		names = append(names, c.Name)
+	}
+	if got := names; len(got) != 4 || got[0] != "app" || got[1] != "README" || got[2] != "var" || got[3] != "etc" {
+		t.Errorf("Root entries = %v, want them largest first: [app README var etc]", got)
+	}
+	app := root.Children[0]
+	if app.Size != 360 || !app.Dir {
+		t.Errorf("app = %d bytes, dir %v, want 360 bytes, dir", app.Size, app.Dir)
+	}
+	modules := app.Children[0]
+	if modules.Name != "node_modules" || modules.Size != 350 {
+		t.Errorf("Largest entry of app = %s (%d bytes), want node_modules (350 bytes)", modules.Name, modules.Size)
+	}
+	etc := root.Children[3]
+	if len(etc.Children) != 1 || etc.Children[0].Name != "passwd" || !etc.Children[0].Deleted {
+		t.Errorf("etc/.wh.passwd should be a deleted passwd entry, got %+v", etc.Children)
+	}
+	cache := root.Children[2].Children[0]
+	if !cache.Opaque || len(cache.Children) != 1 {
+		t.Errorf("var/cache should be opaque and only have its new entry, got %+v", cache)
+	}
+	if contents.Entries != 12 || contents.Truncated {
+		t.Errorf("Entries = %d, truncated %v, want 12, not truncated", contents.Entries, contents.Truncated)
+	}
+}
+
+func TestIndexLayer_Truncated(t *testing.T) {
+	defer func(max int) { maxLayerEntries = max }(maxLayerEntries)
+	maxLayerEntries = 2
+
+	layer := buildTar(t,
+		tarEntry{"lib/", 0},
+		tarEntry{"lib/a", 10},
+		tarEntry{"lib/b", 20},
+		tarEntry{"lib/sub/c", 30},
+	)
+	contents, err := IndexLayer(bytes.NewReader(layer))
+	if err != nil {
+		t.Fatalf("IndexLayer() error = %v", err)
+	}
+	if !contents.Truncated || contents.Entries != 2 {
+		t.Errorf("Entries = %d, truncated %v, want 2, truncated", contents.Entries, contents.Truncated)
+	}
+	lib := contents.Root.Children[0]
+	if lib.Size != 60 || len(lib.Children) != 1 {
+		t.Errorf("lib = %d bytes with %d entries, want 60 bytes, those not indexed included, with 1 entry", lib.Size, len(lib.Children))
+	}
+}
+
+func TestFindLayer(t *testing.T) {
+	base := buildTar(t, tarEntry{"bin/sh", 100})
+	top := buildTar(t, tarEntry{"app/main", 42})
+	digest := func(layer []byte) string {
+		sum := sha256.Sum256(layer)
+		return "sha256:" + hex.EncodeToString(sum[:])
+	}
+	var saved bytes.Buffer
+	w := tar.NewWriter(&saved)
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{
+		{"1111/VERSION", []byte("1.0")},
+		{"1111/layer.tar", base},
+		{"2222/layer.tar", top},
+		{"manifest.json", []byte("[]")},
+	} {
+		w.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg})
+		w.Write(f.content)
+	}
+	w.Close()
+
+	tests := []struct {
+		name    string
+		diffID  string
+		want    string
+		wantErr error
+	}{
+		{"base layer", digest(base), "bin", nil},
+		{"top layer", digest(top), "app", nil},
+		{"unknown layer", "sha256:0000", "", ErrLayerNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents, err := findLayer(context.Background(), bytes.NewReader(saved.Bytes()), tt.diffID)
+			if err != tt.wantErr {
+				t.Fatalf("findLayer() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := contents.Root.Children[0].Name; got != tt.want {
+				t.Errorf("findLayer() found a layer with %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := findLayer(ctx, bytes.NewReader(saved.Bytes()), digest(top)); err != context.Canceled {
+		t.Errorf("findLayer() on a canceled context, error = %v", err)
+	}
+}
+
+func TestImageLayers(t *testing.T) {
+	inspect := types.ImageInspect{}
+	inspect.RootFS.Layers = []string{"sha256:base", "sha256:app"}
+	history := []image.HistoryResponseItem{
+		{CreatedBy: "CMD [\"node\"]"},
+		{CreatedBy: "COPY . /app", Size: 200},
+		{CreatedBy: "ENV NODE_ENV=production"},
+		{CreatedBy: "ADD rootfs.tar /", Size: 1000},
+	}
+	layers := imageLayers(history, inspect)
+	if len(layers) != 2 || layers[0].CreatedBy != "ADD rootfs.tar /" || layers[1].CreatedBy != "COPY . /app" || layers[1].Size != 200 {
+		t.Errorf("imageLayers() = %+v, want the layers paired with the history entries that have content", layers)
+	}
+
+	//sizes that do not add up leave the history out
+	history[0].Size = 10
+	layers = imageLayers(history, inspect)
+	if len(layers) != 2 || layers[0].CreatedBy != "" || layers[1].DiffID != "sha256:app" {
+		t.Errorf("imageLayers() = %+v, want the layers without history", layers)
+	}
+}
@@ -0,0 +1,401 @@
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+)
+
+//registryTimeout is the most a request to a registry takes
+const registryTimeout = 30 * time.Second
+
+//maxManifestSize is the biggest manifest read from a registry
+const maxManifestSize = 4 << 20
+
+//dockerHubRegistry is the host the Registry API of Docker Hub is served on
+const dockerHubRegistry = "registry-1.docker.io"
+
+//Media types of manifests
+const (
+	mediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIIndex     = "application/vnd.oci.image.index.v1+json"
+	mediaTypeManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeOCIManifest  = "application/vnd.oci.image.manifest.v1+json"
+)
+
+var manifestMediaTypes = []string{mediaTypeManifestList, mediaTypeOCIIndex, mediaTypeManifest, mediaTypeOCIManifest}
+
+//ManifestList is what a registry has for an image reference: a manifest
+//list, or OCI index, with an image for each platform, or the manifest of a
+//single image
+type ManifestList struct {
+	//Reference is the image reference inspected
+	Reference string
+	Digest    string
+	MediaType string
+	//List tells whether the reference names a manifest list instead of a
+	//single image
+	List      bool
+	Platforms []PlatformManifest
+}
+
+//PlatformManifest is the image of a manifest list for a platform
+type PlatformManifest struct {
+	//Platform is the platform as os/architecture[/variant]
+	Platform  string
+	Digest    string
+	MediaType string
+	//Size is the size of the config and the layers of the image, compressed
+	//as the registry serves them, -1 if the manifest of the image could not
+	//be read
+	Size   int64
+	Layers int
+}
+
+//ManifestInspector fetches from registries the manifests of image references
+type ManifestInspector interface {
+	InspectManifest(ctx context.Context, ref string) (*ManifestList, error)
+}
+
+//RegistryClient fetches manifests using the HTTP API of registries, with the
+//credentials the Docker CLI has for them
+type RegistryClient struct {
+	client *http.Client
+}
+
+//NewRegistryClient creates a RegistryClient
+func NewRegistryClient() *RegistryClient {
+	return &RegistryClient{client: &http.Client{Timeout: registryTimeout}}
+}
+
+//manifestDescriptor describes the content a manifest references
+type manifestDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	Platform  *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+		Variant      string `json:"variant"`
+	} `json:"platform"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+//manifest is either a manifest list or the manifest of an image
+type manifest struct {
+	MediaType string               `json:"mediaType"`
+	Manifests []manifestDescriptor `json:"manifests"`
+	Config    manifestDescriptor   `json:"config"`
+	Layers    []manifestDescriptor `json:"layers"`
+}
+
+//InspectManifest fetches the manifest the registry has for the given image
+//reference and, for manifest lists, the manifest of the image of each
+//platform to tell its size
+func (c *RegistryClient) InspectManifest(ctx context.Context, ref string) (*ManifestList, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, err
+	}
+	var object string
+	if canonical, ok := named.(reference.Canonical); ok {
+		object = canonical.Digest().String()
+	} else {
+		named = reference.TagNameOnly(named)
+		object = named.(reference.Tagged).Tag()
+	}
+	session := c.session(named)
+	m, mediaType, digest, err := session.manifest(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	list := &ManifestList{
+		Reference: reference.FamiliarString(named),
+		Digest:    digest,
+		MediaType: mediaType,
+		List:      mediaType == mediaTypeManifestList || mediaType == mediaTypeOCIIndex || len(m.Manifests) > 0,
+	}
+	if !list.List {
+		platform := PlatformManifest{
+			Platform:  session.platform(ctx, m.Config.Digest),
+			Digest:    digest,
+			MediaType: mediaType,
+			Size:      imageSize(m),
+			Layers:    len(m.Layers),
+		}
+		list.Platforms = append(list.Platforms, platform)
+		return list, nil
+	}
+	for _, d := range m.Manifests {
+		//attestations of buildx are on the list as images of no platform
+		if d.Annotations["vnd.docker.reference.type"] == "attestation-manifest" {
+			continue
+		}
+		platform := PlatformManifest{
+			Platform:  "unknown",
+			Digest:    d.Digest,
+			MediaType: d.MediaType,
+			Size:      -1,
+		}
+		if d.Platform != nil {
+			platform.Platform = platformName(d.Platform.OS, d.Platform.Architecture, d.Platform.Variant)
+		}
+		if image, _, _, err := session.manifest(ctx, d.Digest); err == nil {
+			platform.Size = imageSize(image)
+			platform.Layers = len(image.Layers)
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		list.Platforms = append(list.Platforms, platform)
+	}
+	return list, nil
+}
+
+//session creates a session on the registry of the given image, with the
+//credentials of the Docker CLI for it
+func (c *RegistryClient) session(named reference.Named) *registrySession {
+	host := reference.Domain(named)
+	if host == DockerHub {
+		host = dockerHubRegistry
+	}
+	scheme := "https"
+	if isLoopback(host) {
+		//as the daemon does, registries on this host are insecure
+		scheme = "http"
+	}
+	credentials, _ := loadCLIConfig(cliConfigFile()).credentials(registryAuthKey(named))
+	return &registrySession{
+		client:      c.client,
+		base:        scheme + "://" + host,
+		repository:  reference.Path(named),
+		credentials: credentials,
+	}
+}
+
+//registrySession are the requests made to a registry about a repository,
+//once authorized every request is
+type registrySession struct {
+	client        *http.Client
+	base          string
+	repository    string
+	credentials   types.AuthConfig
+	authorization string
+}
+
+//manifest fetches the manifest with the given tag or digest, with its media
+//type and digest
+func (s *registrySession) manifest(ctx context.Context, object string) (manifest, string, string, error) {
+	var m manifest
+	resp, err := s.get(ctx, "/v2/"+s.repository+"/manifests/"+object, manifestMediaTypes...)
+	if err != nil {
+		return m, "", "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return m, "", "", err
+	}
+	if err := json.Unmarshal(body, &m); err != nil {
+		return m, "", "", fmt.Errorf("the manifest of %s cannot be read: %s", object, err.Error())
+	}
+	mediaType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	if m.MediaType != "" {
+		mediaType = m.MediaType
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+	return m, mediaType, digest, nil
+}
+
+//platform returns the platform of the image with the given config, unknown
+//if the config cannot be read
+func (s *registrySession) platform(ctx context.Context, config string) string {
+	resp, err := s.get(ctx, "/v2/"+s.repository+"/blobs/"+config)
+	if err != nil {
+		return "unknown"
+	}
+	defer resp.Body.Close()
+	var image struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+		Variant      string `json:"variant"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&image); err != nil {
+		return "unknown"
+	}
+	return platformName(image.OS, image.Architecture, image.Variant)
+}
+
+//get requests the given path to the registry, asking for authorization
+//when the registry challenges the request
+func (s *registrySession) get(ctx context.Context, path string, accept ...string) (*http.Response, error) {
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, s.base+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, mediaType := range accept {
+			req.Header.Add("Accept", mediaType)
+		}
+		if s.authorization != "" {
+			req.Header.Set("Authorization", s.authorization)
+		}
+		return s.client.Do(req.WithContext(ctx))
+	}
+	resp, err := do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && s.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := s.authorize(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = do(); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp, nil
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+//authorize gets the authorization the given challenge asks for, a token of
+//the realm of the challenge or the credentials themselves
+func (s *registrySession) authorize(ctx context.Context, challenge string) error {
+	params := make(map[string]string)
+	for _, match := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+	switch scheme := strings.ToLower(strings.SplitN(strings.TrimSpace(challenge), " ", 2)[0]); scheme {
+	case "basic":
+		if s.credentials.Username == "" {
+			return errors.New("unauthorized: the registry asks for credentials, log in with docker login")
+		}
+		s.authorization = "Basic " + basicAuth(s.credentials)
+		return nil
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return fmt.Errorf("unauthorized: invalid challenge %q", challenge)
+		}
+		query := realm.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = "repository:" + s.repository + ":pull"
+		}
+		query.Set("scope", scope)
+		realm.RawQuery = query.Encode()
+		req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return err
+		}
+		if s.credentials.Username != "" {
+			req.Header.Set("Authorization", "Basic "+basicAuth(s.credentials))
+		}
+		resp, err := s.client.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return responseError(resp)
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+			return fmt.Errorf("the token of the registry cannot be read: %s", err.Error())
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		s.authorization = "Bearer " + token.Token
+		return nil
+	default:
+		return fmt.Errorf("unauthorized: unsupported authorization scheme %q", scheme)
+	}
+}
+
+//responseError returns the error a registry responded with, as its errors
+//explain it or as the status of the response
+func responseError(resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&body); err == nil && len(body.Errors) > 0 {
+		var msgs []string
+		for _, e := range body.Errors {
+			msgs = append(msgs, strings.ToLower(e.Code)+": "+e.Message)
+		}
+		return errors.New(strings.Join(msgs, ", "))
+	}
+	return fmt.Errorf("the registry responded %s", resp.Status)
+}
+
+func basicAuth(credentials types.AuthConfig) string {
+	return base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+}
+
+//imageSize returns the size of the config and the layers of the image with
+//the given manifest
+func imageSize(m manifest) int64 {
+	size := m.Config.Size
+	for _, layer := range m.Layers {
+		size += layer.Size
+	}
+	return size
+}
+
+//platformName returns the given platform as os/architecture[/variant]
+func platformName(os, architecture, variant string) string {
+	if os == "" && architecture == "" {
+		return "unknown"
+	}
+	name := os + "/" + architecture
+	if variant != "" {
+		name += "/" + variant
+	}
+	return name
+}
+
+//isLoopback tells whether the given registry host, with or without port, is
+//this host
+func isLoopback(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
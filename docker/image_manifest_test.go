@@ -0,0 +1,122 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+//testRegistry serves a manifest list with images for amd64 and arm64, only
+//the manifest of the amd64 image is there, and a single platform image
+func testRegistry(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	manifests := map[string]string{
+		"/v2/library/app/manifests/1.0": `{
+			"schemaVersion": 2,
+			"mediaType": "` + mediaTypeManifestList + `",
+			"manifests": [
+				{"mediaType": "` + mediaTypeManifest + `", "digest": "sha256:amd64", "size": 528, "platform": {"architecture": "amd64", "os": "linux"}},
+				{"mediaType": "` + mediaTypeManifest + `", "digest": "sha256:arm64", "size": 528, "platform": {"architecture": "arm64", "os": "linux", "variant": "v8"}},
+				{"mediaType": "` + mediaTypeOCIManifest + `", "digest": "sha256:attestation", "size": 566, "platform": {"architecture": "unknown", "os": "unknown"},
+				 "annotations": {"vnd.docker.reference.type": "attestation-manifest"}}
+			]}`,
+		"/v2/library/app/manifests/sha256:amd64": `{
+			"schemaVersion": 2,
+			"mediaType": "` + mediaTypeManifest + `",
+			"config": {"digest": "sha256:config", "size": 100},
+			"layers": [{"digest": "sha256:l1", "size": 1000}, {"digest": "sha256:l2", "size": 2000}]}`,
+		"/v2/library/single/manifests/latest": `{
+			"schemaVersion": 2,
+			"mediaType": "` + mediaTypeManifest + `",
+			"config": {"digest": "sha256:config", "size": 100},
+			"layers": [{"digest": "sha256:l1", "size": 1000}]}`,
+		"/v2/library/single/blobs/sha256:config": `{"architecture": "arm64", "os": "linux"}`,
+	}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") == "" {
+				t.Errorf("No scope was asked for")
+			}
+			fmt.Fprint(w, `{"token": "t0k"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t0k" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := manifests[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors": [{"code": "MANIFEST_UNKNOWN", "message": "manifest unknown"}]}`)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "manifests/1.0") {
+			w.Header().Set("Docker-Content-Digest", "sha256:list")
+		}
+		fmt.Fprint(w, body)
+	}))
+	return server
+}
+
+func TestInspectManifest(t *testing.T) {
+	defer os.Setenv("DOCKER_CONFIG", os.Getenv("DOCKER_CONFIG"))
+	os.Setenv("DOCKER_CONFIG", t.Name())
+	server := testRegistry(t)
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+	client := NewRegistryClient()
+
+	list, err := client.InspectManifest(context.Background(), registry+"/library/app:1.0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !list.List || list.Digest != "sha256:list" || list.MediaType != mediaTypeManifestList {
+		t.Errorf("Unexpected manifest list: %+v", list)
+	}
+	if len(list.Platforms) != 2 {
+		t.Fatalf("Unexpected platforms: %+v", list.Platforms)
+	}
+	if p := list.Platforms[0]; p.Platform != "linux/amd64" || p.Digest != "sha256:amd64" || p.Size != 3100 || p.Layers != 2 {
+		t.Errorf("Unexpected amd64 image: %+v", p)
+	}
+	if p := list.Platforms[1]; p.Platform != "linux/arm64/v8" || p.Size != -1 {
+		t.Errorf("Unexpected arm64 image: %+v", p)
+	}
+
+	list, err = client.InspectManifest(context.Background(), registry+"/library/single")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if list.List || len(list.Platforms) != 1 || list.Reference != registry+"/library/single:latest" {
+		t.Fatalf("Unexpected single image: %+v", list)
+	}
+	if p := list.Platforms[0]; p.Platform != "linux/arm64" || p.Size != 1100 || !strings.HasPrefix(p.Digest, "sha256:") {
+		t.Errorf("Unexpected image: %+v", p)
+	}
+
+	if _, err := client.InspectManifest(context.Background(), registry+"/library/missing:1.0"); err == nil ||
+		!strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("Unexpected error inspecting a missing image: %v", err)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		"localhost:5000":       true,
+		"127.0.0.1:35000":      true,
+		"[::1]:5000":           true,
+		"registry-1.docker.io": false,
+		"10.0.0.1:5000":        false,
+	}
+	for host, expected := range tests {
+		if isLoopback(host) != expected {
+			t.Errorf("isLoopback(%q) = %t", host, !expected)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
+)
+
+//ImagePruneOptions defines which images are pruned, images used by a
+//container are never pruned
+type ImagePruneOptions struct {
+	//All prunes every unused image, not only the dangling ones
+	All bool
+	//Until only prunes the images created before this timestamp, a
+	//duration, i.e. 24h, is relative to now
+	Until string
+	//Labels only prunes the images with these labels, as key or key=value,
+	//a leading ! prunes the images without the label instead
+	Labels []string
+}
+
+//ParseImagePruneLabels returns the labels of the given text, separated by
+//spaces or commas
+func ParseImagePruneLabels(text string) ([]string, error) {
+	labels := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	for _, label := range labels {
+		if key := strings.TrimPrefix(label, "!"); key == "" || strings.HasPrefix(key, "=") {
+			return nil, fmt.Errorf("invalid label %q, labels are key or key=value", label)
+		}
+	}
+	return labels, nil
+}
+
+//ValidatePruneUntil checks that the given until filter is a timestamp or a
+//duration the daemon understands
+func ValidatePruneUntil(until string) error {
+	if strings.TrimSpace(until) == "" {
+		return nil
+	}
+	if _, err := timetypes.GetTimestamp(strings.TrimSpace(until), time.Now()); err != nil {
+		return fmt.Errorf("invalid until %q, use a duration (i.e. 24h) or a timestamp", until)
+	}
+	return nil
+}
+
+//args returns the filters of the prune request for these options
+func (o ImagePruneOptions) args() (filters.Args, error) {
+	args := filters.NewArgs()
+	if o.All {
+		args.Add("dangling", "false")
+	} else {
+		args.Add("dangling", "true")
+	}
+	if until := strings.TrimSpace(o.Until); until != "" {
+		if err := ValidatePruneUntil(until); err != nil {
+			return args, err
+		}
+		args.Add("until", until)
+	}
+	for _, label := range o.Labels {
+		if key := strings.TrimPrefix(label, "!"); key != label {
+			args.Add("label!", key)
+		} else {
+			args.Add("label", label)
+		}
+	}
+	return args, nil
+}
+
+//Describe returns what a prune with these options removes
+func (o ImagePruneOptions) Describe() []string {
+	target := "dangling images"
+	if o.All {
+		target = "images without at least one container associated to them"
+	}
+	if until := strings.TrimSpace(o.Until); until != "" {
+		target += " created before " + until
+	}
+	targets := []string{target}
+	for _, label := range o.Labels {
+		if key := strings.TrimPrefix(label, "!"); key != label {
+			targets = append(targets, "without label "+key)
+		} else {
+			targets = append(targets, "with label "+label)
+		}
+	}
+	return targets
+}
+
+//PruneImages removes the images the given options tell, as docker image
+//prune does, it returns the images untagged and deleted and the space
+//reclaimed
+func (daemon *DockerDaemon) PruneImages(opts ImagePruneOptions) (types.ImagesPruneReport, error) {
+	args, err := opts.args()
+	if err != nil {
+		return types.ImagesPruneReport{}, err
+	}
+	defer daemon.calls.forget(imagesCall)
+	return daemon.client.ImagesPrune(context.Background(), args)
+}
@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestPruneImages(t *testing.T) {
+	client := &mock.ImagesPruneAPIClientMock{Report: types.ImagesPruneReport{
+		ImagesDeleted: []types.ImageDeleteResponseItem{
+			{Untagged: "nginx:alpine"},
+			{Deleted: "sha256:8dfafdbc3a40"},
+		},
+		SpaceReclaimed: 2048,
+	}}
+	daemon := &DockerDaemon{client: client, calls: newCallCoordinator()}
+
+	report, err := daemon.PruneImages(ImagePruneOptions{
+		All:    true,
+		Until:  "24h",
+		Labels: []string{"env=dev", "!keep"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.SpaceReclaimed != 2048 || len(report.ImagesDeleted) != 2 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	for key, want := range map[string][]string{
+		"dangling": {"false"},
+		"until":    {"24h"},
+		"label":    {"env=dev"},
+		"label!":   {"keep"},
+	} {
+		got := client.Filters.Get(key)
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unexpected %s filter: %v, want %v", key, got, want)
+		}
+	}
+
+	if _, err := daemon.PruneImages(ImagePruneOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := client.Filters.Get("dangling"); !reflect.DeepEqual(got, []string{"true"}) {
+		t.Errorf("Only dangling images should be pruned by default, got %v", got)
+	}
+	if _, err := daemon.PruneImages(ImagePruneOptions{Until: "yesterday"}); err == nil {
+		t.Error("An invalid until filter was accepted")
+	}
+}
+
+func TestParseImagePruneLabels(t *testing.T) {
+	labels, err := ParseImagePruneLabels(" env=dev, !keep  team=web ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"env=dev", "!keep", "team=web"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Unexpected labels: %v, want %v", labels, want)
+	}
+	for _, invalid := range []string{"!", "=dev", "!=dev"} {
+		if _, err := ParseImagePruneLabels(invalid); err == nil {
+			t.Errorf("%q is not a valid label", invalid)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+)
+
+//PullImage pulls the image with the given reference, with the credentials
+//of the Docker CLI, as docker pull does. The reference gets the latest tag
+//if it has neither a tag nor a digest. progress is told about every change
+//reported by the daemon. It returns the reference pulled.
+func (daemon *DockerDaemon) PullImage(ctx context.Context, ref string, progress func(TransferProgress)) (string, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(ref))
+	if err != nil {
+		return "", fmt.Errorf("invalid reference %q: %s", ref, err.Error())
+	}
+	named = reference.TagNameOnly(named)
+	pulled := reference.FamiliarString(named)
+
+	auth := loadCLIConfig(cliConfigFile()).registryAuth(named)
+	stream, err := daemon.client.ImagePull(ctx, named.String(), types.ImagePullOptions{RegistryAuth: auth})
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+	defer daemon.calls.forget(imagesCall)
+
+	if err := followTransfer(ctx, stream, pulled, progress); err != nil {
+		return "", err
+	}
+	return pulled, nil
+}
@@ -0,0 +1,56 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestPullImage(t *testing.T) {
+	client := &mock.ImageTransferAPIClientMock{Messages: []jsonmessage.JSONMessage{
+		{ID: "latest", Status: "Pulling from library/nginx"},
+		{ID: "a1", Status: "Already exists"},
+		{ID: "b2", Status: "Pulling fs layer"},
+		{ID: "b2", Status: "Downloading", Progress: &jsonmessage.JSONProgress{Current: 50, Total: 100}},
+		{ID: "b2", Status: "Download complete"},
+		{ID: "b2", Status: "Extracting", Progress: &jsonmessage.JSONProgress{Current: 25, Total: 100}},
+	}}
+	daemon := &DockerDaemon{client: client, calls: newCallCoordinator()}
+	var updates []TransferProgress
+	pulled, err := daemon.PullImage(context.Background(), " nginx ", func(p TransferProgress) {
+		updates = append(updates, p)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pulled != "nginx:latest" || client.Pulled != "docker.io/library/nginx:latest" {
+		t.Errorf("Unexpected reference pulled: %s, %s", pulled, client.Pulled)
+	}
+	if len(updates) != len(client.Messages) {
+		t.Fatalf("Unexpected number of updates: %d", len(updates))
+	}
+	last := updates[len(updates)-1]
+	if last.Status != "Pulling from library/nginx" || len(last.Layers) != 2 {
+		t.Errorf("Unexpected progress: %+v", last)
+	}
+	if !last.Layers[0].Done() || last.Layers[1].Done() {
+		t.Errorf("Unexpected layers: %+v", last.Layers)
+	}
+	if f := last.Fraction(); f != (1+0.625)/2 {
+		t.Errorf("Unexpected fraction pulled: %f", f)
+	}
+	//updates are not changed by the ones after them
+	if f := updates[3].Layers[1].Fraction(); f != 0.25 {
+		t.Errorf("Unexpected fraction of the layer being downloaded: %f", f)
+	}
+
+	client.Messages = append(client.Messages, jsonmessage.JSONMessage{Error: &jsonmessage.JSONError{Message: "no space left on device"}})
+	if _, err := daemon.PullImage(context.Background(), "nginx", nil); err == nil || err.Error() != "no space left on device" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := daemon.PullImage(context.Background(), "Nginx", nil); err == nil {
+		t.Error("An invalid reference was pulled")
+	}
+}
@@ -0,0 +1,87 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+)
+
+//RegistryCredentials are the credentials to log in to a registry with
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+//pushReference returns the given reference as it is pushed, with the latest
+//tag if it has none. Images are pushed by tag, references with a digest are
+//not valid.
+func pushReference(ref string) (reference.Named, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %q: %s", ref, err.Error())
+	}
+	if _, ok := named.(reference.Digested); ok {
+		return nil, fmt.Errorf("invalid reference %q: images are pushed by tag, not by digest", ref)
+	}
+	return reference.TagNameOnly(named), nil
+}
+
+//RegistryOf returns the registry the image with the given reference is
+//pushed to
+func RegistryOf(ref string) (string, error) {
+	named, err := pushReference(ref)
+	if err != nil {
+		return "", err
+	}
+	return reference.Domain(named), nil
+}
+
+//HasRegistryCredentials returns true if the Docker CLI has credentials for
+//the registry the image with the given reference is pushed to
+func HasRegistryCredentials(ref string) bool {
+	named, err := pushReference(ref)
+	if err != nil {
+		return false
+	}
+	return loadCLIConfig(cliConfigFile()).registryAuth(named) != ""
+}
+
+//PushImage pushes the image with the given reference to its registry, as
+//docker push does. The registry is logged in to with the given credentials
+//or, if none are given, with the ones of the Docker CLI. progress is told
+//about every change reported by the daemon. It returns the reference pushed.
+func (daemon *DockerDaemon) PushImage(ctx context.Context, ref string, credentials *RegistryCredentials, progress func(TransferProgress)) (string, error) {
+	named, err := pushReference(ref)
+	if err != nil {
+		return "", err
+	}
+	pushed := reference.FamiliarString(named)
+
+	var auth string
+	if credentials != nil {
+		auth = encodeAuth(types.AuthConfig{
+			Username:      credentials.Username,
+			Password:      credentials.Password,
+			ServerAddress: registryAuthKey(named),
+		})
+	} else {
+		auth = loadCLIConfig(cliConfigFile()).registryAuth(named)
+	}
+	if auth == "" {
+		//the daemon expects credentials on pushes, even if empty
+		auth = encodeAuth(types.AuthConfig{})
+	}
+	stream, err := daemon.client.ImagePush(ctx, named.String(), types.ImagePushOptions{RegistryAuth: auth})
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	if err := followTransfer(ctx, stream, pushed, progress); err != nil {
+		return "", err
+	}
+	return pushed, nil
+}
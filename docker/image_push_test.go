@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestPushImage(t *testing.T) {
+	client := &mock.ImageTransferAPIClientMock{Messages: []jsonmessage.JSONMessage{
+		{Status: "The push refers to repository [registry.example.com/app]"},
+		{ID: "a1", Status: "Preparing"},
+		{ID: "b2", Status: "Preparing"},
+		{ID: "c3", Status: "Preparing"},
+		{ID: "a1", Status: "Layer already exists"},
+		{ID: "b2", Status: "Mounted from library/alpine"},
+		{ID: "c3", Status: "Pushing", Progress: &jsonmessage.JSONProgress{Current: 30, Total: 40}},
+	}}
+	daemon := &DockerDaemon{client: client}
+	var last TransferProgress
+	pushed, err := daemon.PushImage(context.Background(), "registry.example.com/app",
+		&RegistryCredentials{Username: "user", Password: "secret"},
+		func(p TransferProgress) {
+			last = p
+		})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pushed != "registry.example.com/app:latest" || client.Pushed != "registry.example.com/app:latest" {
+		t.Errorf("Unexpected reference pushed: %s, %s", pushed, client.Pushed)
+	}
+	b, err := base64.URLEncoding.DecodeString(client.PushAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var auth types.AuthConfig
+	if err := json.Unmarshal(b, &auth); err != nil {
+		t.Fatal(err)
+	}
+	if want := (types.AuthConfig{Username: "user", Password: "secret", ServerAddress: "registry.example.com"}); auth != want {
+		t.Errorf("Unexpected credentials: %+v, want %+v", auth, want)
+	}
+	if len(last.Layers) != 3 || !last.Layers[0].Done() || !last.Layers[1].Done() || last.Layers[2].Done() {
+		t.Errorf("Unexpected layers: %+v", last.Layers)
+	}
+	if f := last.Fraction(); f != (1+1+0.75)/3 {
+		t.Errorf("Unexpected fraction pushed: %f", f)
+	}
+
+	if _, err := daemon.PushImage(context.Background(), "nginx@sha256:"+
+		"0123456789012345678901234567890123456789012345678901234567890123", nil, nil); err == nil {
+		t.Error("An image was pushed by digest")
+	}
+	if registry, _ := RegistryOf("nginx"); registry != "docker.io" {
+		t.Errorf("Unexpected registry: %s", registry)
+	}
+}
@@ -0,0 +1,232 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+//DefaultScannerCommand is the command images are scanned with unless
+//another one is configured
+const DefaultScannerCommand = "trivy image --quiet --format json {image}"
+
+//scannerImagePlaceholder is replaced by the image to scan on the scanner
+//command, the image is added at the end of commands without it
+const scannerImagePlaceholder = "{image}"
+
+//Severities of vulnerabilities, from the most to the least severe
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+//Severities are the severities of vulnerabilities, most severe first
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityUnknown}
+
+//Vulnerability is a vulnerability found on a package of an image
+type Vulnerability struct {
+	ID        string
+	Package   string
+	Installed string
+	//Fixed is the version that fixes the vulnerability, empty if none does
+	Fixed    string
+	Severity string
+	Title    string
+}
+
+//ScanReport are the vulnerabilities found on an image
+type ScanReport struct {
+	Image           string
+	Vulnerabilities []Vulnerability
+}
+
+//BySeverity returns the vulnerabilities of the report grouped by severity
+func (r *ScanReport) BySeverity() map[string][]Vulnerability {
+	groups := make(map[string][]Vulnerability)
+	for _, v := range r.Vulnerabilities {
+		groups[v.Severity] = append(groups[v.Severity], v)
+	}
+	return groups
+}
+
+//ImageScanner scans images for vulnerabilities
+type ImageScanner interface {
+	Scan(ctx context.Context, image string) (*ScanReport, error)
+}
+
+//CommandScanner scans images by running a scanner, like trivy or grype,
+//that writes its report as JSON on its standard output
+type CommandScanner struct {
+	//Command is the command line of the scanner, {image} is replaced by the
+	//image to scan
+	Command string
+}
+
+//NewCommandScanner creates a CommandScanner that runs the given command,
+//the default one if empty
+func NewCommandScanner(command string) CommandScanner {
+	if strings.TrimSpace(command) == "" {
+		command = DefaultScannerCommand
+	}
+	return CommandScanner{Command: command}
+}
+
+//Scan runs the scanner against the image with the given reference and
+//reads the vulnerabilities it reports, the scanner is killed if the given
+//context is canceled
+func (s CommandScanner) Scan(ctx context.Context, image string) (*ScanReport, error) {
+	args := strings.Fields(s.Command)
+	if len(args) == 0 {
+		return nil, errors.New("no scanner command is configured")
+	}
+	replaced := false
+	for i, arg := range args {
+		if strings.Contains(arg, scannerImagePlaceholder) {
+			args[i] = strings.Replace(arg, scannerImagePlaceholder, image, -1)
+			replaced = true
+		}
+	}
+	if !replaced {
+		args = append(args, image)
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if execErr, ok := err.(*exec.Error); ok && execErr.Err == exec.ErrNotFound {
+			return nil, fmt.Errorf("the scanner %s was not found, install it or configure another one", args[0])
+		}
+		if lines := strings.Split(strings.TrimSpace(stderr.String()), "\n"); lines[len(lines)-1] != "" {
+			return nil, fmt.Errorf("%s failed: %s", args[0], lines[len(lines)-1])
+		}
+		return nil, fmt.Errorf("%s failed: %s", args[0], err.Error())
+	}
+	vulnerabilities, err := ParseScanReport(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("the report of %s cannot be read: %s", args[0], err.Error())
+	}
+	return &ScanReport{Image: image, Vulnerabilities: vulnerabilities}, nil
+}
+
+//trivyResult is a result of a trivy report, the vulnerabilities of a target
+//of the image
+type trivyResult struct {
+	Target          string
+	Vulnerabilities []struct {
+		VulnerabilityID  string
+		PkgName          string
+		InstalledVersion string
+		FixedVersion     string
+		Severity         string
+		Title            string
+	}
+}
+
+//grypeReport is the part of a grype report about the vulnerabilities found
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+//ParseScanReport reads the vulnerabilities of the given JSON report, as
+//trivy, old versions too, or grype write them, anything written after the
+//report is ignored
+func ParseScanReport(output []byte) ([]Vulnerability, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, errors.New("the report is empty")
+	}
+	var report json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(output)).Decode(&report); err != nil {
+		return nil, err
+	}
+	report = bytes.TrimSpace(report)
+	var results []trivyResult
+	if report[0] == '[' {
+		//trivy before 0.20 writes the results alone
+		if err := json.Unmarshal(report, &results); err != nil {
+			return nil, err
+		}
+		return trivyVulnerabilities(results), nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(report, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["matches"]; ok {
+		var grype grypeReport
+		if err := json.Unmarshal(report, &grype); err != nil {
+			return nil, err
+		}
+		var vulnerabilities []Vulnerability
+		for _, m := range grype.Matches {
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				ID:        m.Vulnerability.ID,
+				Package:   m.Artifact.Name,
+				Installed: m.Artifact.Version,
+				Fixed:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+				Severity:  severity(m.Vulnerability.Severity),
+				Title:     m.Vulnerability.Description,
+			})
+		}
+		return vulnerabilities, nil
+	}
+	if raw, ok := fields["Results"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &results); err != nil {
+			return nil, err
+		}
+	}
+	return trivyVulnerabilities(results), nil
+}
+
+func trivyVulnerabilities(results []trivyResult) []Vulnerability {
+	var vulnerabilities []Vulnerability
+	for _, result := range results {
+		for _, v := range result.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				ID:        v.VulnerabilityID,
+				Package:   v.PkgName,
+				Installed: v.InstalledVersion,
+				Fixed:     v.FixedVersion,
+				Severity:  severity(v.Severity),
+				Title:     v.Title,
+			})
+		}
+	}
+	return vulnerabilities
+}
+
+//severity returns the given severity as one of the known ones, the
+//negligible vulnerabilities of grype are low ones
+func severity(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	switch s {
+	case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow:
+		return s
+	case "NEGLIGIBLE":
+		return SeverityLow
+	}
+	return SeverityUnknown
+}
@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const trivyReportJSON = `{
+  "SchemaVersion": 2,
+  "ArtifactName": "nginx:latest",
+  "Results": [
+    {
+      "Target": "nginx:latest (debian 11.6)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2023-0286", "PkgName": "openssl", "InstalledVersion": "1.1.1n-0+deb11u3", "FixedVersion": "1.1.1n-0+deb11u4", "Severity": "HIGH", "Title": "X.400 address type confusion"},
+        {"VulnerabilityID": "CVE-2022-3715", "PkgName": "bash", "InstalledVersion": "5.1-2+deb11u1", "Severity": "HIGH"},
+        {"VulnerabilityID": "CVE-2005-2541", "PkgName": "tar", "InstalledVersion": "1.34+dfsg-1", "Severity": "LOW"}
+      ]
+    },
+    {"Target": "usr/share/nginx", "Vulnerabilities": null}
+  ]
+}`
+
+const grypeReportJSON = `{
+  "matches": [
+    {
+      "vulnerability": {"id": "CVE-2023-0286", "severity": "High", "fix": {"versions": ["1.1.1n-0+deb11u4"]}},
+      "artifact": {"name": "openssl", "version": "1.1.1n-0+deb11u3"}
+    },
+    {
+      "vulnerability": {"id": "CVE-2005-2541", "severity": "Negligible", "fix": {"versions": []}},
+      "artifact": {"name": "tar", "version": "1.34+dfsg-1"}
+    }
+  ]
+}`
+
+func TestParseScanReport(t *testing.T) {
+	vulnerabilities, err := ParseScanReport([]byte(trivyReportJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(vulnerabilities) != 3 {
+		t.Fatalf("Unexpected vulnerabilities: %+v", vulnerabilities)
+	}
+	if v := vulnerabilities[0]; v.ID != "CVE-2023-0286" || v.Package != "openssl" || v.Fixed != "1.1.1n-0+deb11u4" || v.Severity != SeverityHigh {
+		t.Errorf("Unexpected vulnerability: %+v", v)
+	}
+
+	//trivy before 0.20 writes the results alone
+	results := trivyReportJSON[strings.Index(trivyReportJSON, "[") : strings.LastIndex(trivyReportJSON, "]")+1]
+	if vulnerabilities, err := ParseScanReport([]byte(results)); err != nil || len(vulnerabilities) != 3 {
+		t.Errorf("Unexpected vulnerabilities of an old trivy report: %+v, %v", vulnerabilities, err)
+	}
+
+	vulnerabilities, err = ParseScanReport([]byte(grypeReportJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(vulnerabilities) != 2 {
+		t.Fatalf("Unexpected vulnerabilities: %+v", vulnerabilities)
+	}
+	if v := vulnerabilities[0]; v.Severity != SeverityHigh || v.Installed != "1.1.1n-0+deb11u3" || v.Fixed != "1.1.1n-0+deb11u4" {
+		t.Errorf("Unexpected vulnerability: %+v", v)
+	}
+	if v := vulnerabilities[1]; v.Severity != SeverityLow || v.Fixed != "" {
+		t.Errorf("Unexpected negligible vulnerability: %+v", v)
+	}
+
+	if vulnerabilities, err := ParseScanReport([]byte(`{"SchemaVersion": 2, "Results": null}`)); err != nil || len(vulnerabilities) != 0 {
+		t.Errorf("Unexpected vulnerabilities of a clean image: %+v, %v", vulnerabilities, err)
+	}
+	if _, err := ParseScanReport([]byte(" ")); err == nil {
+		t.Error("An empty report was read")
+	}
+}
+
+func TestScanReportBySeverity(t *testing.T) {
+	vulnerabilities, _ := ParseScanReport([]byte(trivyReportJSON))
+	report := &ScanReport{Image: "nginx:latest", Vulnerabilities: vulnerabilities}
+	groups := report.BySeverity()
+	if len(groups[SeverityHigh]) != 2 || len(groups[SeverityLow]) != 1 || len(groups[SeverityCritical]) != 0 {
+		t.Errorf("Unexpected groups: %+v", groups)
+	}
+}
+
+func TestCommandScanner(t *testing.T) {
+	if s := NewCommandScanner(" "); s.Command != DefaultScannerCommand {
+		t.Errorf("Unexpected default command: %q", s.Command)
+	}
+	//echo writes the report, the image is added after it as the command has
+	//no placeholder
+	report, err := NewCommandScanner(`echo {"Results":[{"Vulnerabilities":[{"VulnerabilityID":"CVE-1","Severity":"critical"}]}]}`).
+		Scan(context.Background(), "nginx:latest")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Image != "nginx:latest" || len(report.Vulnerabilities) != 1 || report.Vulnerabilities[0].Severity != SeverityCritical {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if _, err := NewCommandScanner("dry-missing-scanner {image}").Scan(context.Background(), "nginx"); err == nil ||
+		!strings.Contains(err.Error(), "not found") {
+		t.Errorf("Unexpected error running a missing scanner: %v", err)
+	}
+}
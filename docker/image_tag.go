@@ -0,0 +1,84 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+)
+
+//ErrLastTag is returned when the tag to remove is the only one of the
+//image, Docker would remove the image along with it
+var ErrLastTag = errors.New("it is the only tag of the image, removing it would remove the image")
+
+//TagImage tags the image with the given id with the given repository[:tag]
+//reference, as docker tag does. The reference gets the latest tag if it
+//has none.
+func (daemon *DockerDaemon) TagImage(id, ref string) error {
+	ref = strings.TrimSpace(ref)
+	if err := ValidateImageReference(ref); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	defer daemon.calls.forget(imagesCall)
+	return daemon.client.ImageTag(ctx, id, ref)
+}
+
+//UntagImage removes the given tag from the image with the given id, as
+//docker rmi does with one of the tags of an image that has more. The only
+//tag of an image is not removed, see ErrLastTag.
+func (daemon *DockerDaemon) UntagImage(id, tag string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	image, _, err := daemon.client.ImageInspectWithRaw(ctx, id)
+	if err != nil {
+		return err
+	}
+	tags := ImageTags(image.RepoTags)
+	found := false
+	for _, t := range tags {
+		found = found || sameTag(t, tag)
+	}
+	if !found {
+		return fmt.Errorf("image %s is not tagged %s", ShortImageID(id), tag)
+	}
+	if len(tags) == 1 {
+		return ErrLastTag
+	}
+	defer daemon.calls.forget(imagesCall)
+	_, err = daemon.client.ImageRemove(ctx, tag, types.ImageRemoveOptions{})
+	return err
+}
+
+//ImageTags returns the given tags of an image, without the one Docker gives
+//to untagged images
+func ImageTags(repoTags []string) []string {
+	var tags []string
+	for _, tag := range repoTags {
+		if tag != "<none>:<none>" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+//sameTag returns true if the given references name the same tag, i.e.
+//nginx and docker.io/library/nginx:latest
+func sameTag(a, b string) bool {
+	if a == b {
+		return true
+	}
+	named := make([]string, 2)
+	for i, ref := range []string{a, b} {
+		n, err := reference.ParseNormalizedNamed(ref)
+		if err != nil {
+			return false
+		}
+		named[i] = reference.TagNameOnly(n).String()
+	}
+	return named[0] == named[1]
+}
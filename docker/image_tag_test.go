@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestTagImage(t *testing.T) {
+	client := &mock.ImageTagAPIClientMock{Tags: map[string][]string{
+		"sha256:1": {"myapp:1.0"},
+	}}
+	daemon := &DockerDaemon{client: client, calls: newCallCoordinator()}
+
+	if err := daemon.TagImage("sha256:1", " registry.example.com/myapp:1.0 "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := daemon.TagImage("sha256:1", "My App"); err == nil {
+		t.Error("An invalid reference was tagged")
+	}
+	if want := []string{"myapp:1.0", "registry.example.com/myapp:1.0"}; !reflect.DeepEqual(client.Tags["sha256:1"], want) {
+		t.Errorf("Unexpected tags: %v, want %v", client.Tags["sha256:1"], want)
+	}
+
+	if err := daemon.UntagImage("sha256:1", "myapp:2.0"); err == nil {
+		t.Error("A tag the image does not have was removed")
+	}
+	if err := daemon.UntagImage("sha256:1", "myapp:1.0"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := daemon.UntagImage("sha256:1", "registry.example.com/myapp:1.0"); err != ErrLastTag {
+		t.Errorf("The last tag of the image was removed, error: %v", err)
+	}
+	if want := []string{"registry.example.com/myapp:1.0"}; !reflect.DeepEqual(client.Tags["sha256:1"], want) {
+		t.Errorf("Unexpected tags: %v, want %v", client.Tags["sha256:1"], want)
+	}
+}
+
+func TestImageTags(t *testing.T) {
+	if got := ImageTags([]string{"<none>:<none>"}); len(got) != 0 {
+		t.Errorf("Unexpected tags: %v", got)
+	}
+	if !sameTag("nginx", "docker.io/library/nginx:latest") || sameTag("nginx", "nginx:alpine") {
+		t.Error("Unexpected tag comparison")
+	}
+}
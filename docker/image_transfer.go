@@ -0,0 +1,151 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+//Statuses the daemon reports about the layers of an image being pulled or
+//pushed
+const (
+	layerDownloading = "Downloading"
+	layerDownloaded  = "Download complete"
+	layerVerifying   = "Verifying Checksum"
+	layerExtracting  = "Extracting"
+	layerPulled      = "Pull complete"
+	layerExists      = "Already exists"
+	layerPushing     = "Pushing"
+	layerPushed      = "Pushed"
+	layerOnRegistry  = "Layer already exists"
+	layerMounted     = "Mounted from"
+)
+
+//LayerProgress is the progress of the pull or the push of a layer of an
+//image, Current and Total are the bytes of the step, downloading, extracting
+//or uploading, the layer is on
+type LayerProgress struct {
+	ID      string
+	Status  string
+	Current int64
+	Total   int64
+}
+
+//Done returns true if the layer is where it is being transferred to
+func (l LayerProgress) Done() bool {
+	switch l.Status {
+	case layerPulled, layerExists, layerPushed, layerOnRegistry:
+		return true
+	}
+	return strings.HasPrefix(l.Status, layerMounted)
+}
+
+//Fraction returns how much of the layer has been transferred. Downloading
+//a layer is the first half of its pull and extracting it the second one.
+func (l LayerProgress) Fraction() float64 {
+	step := 0.0
+	if l.Total > 0 {
+		step = float64(l.Current) / float64(l.Total)
+		if step > 1 {
+			step = 1
+		}
+	}
+	switch {
+	case l.Done():
+		return 1
+	case l.Status == layerPushing:
+		return step
+	case l.Status == layerDownloading:
+		return step / 2
+	case l.Status == layerDownloaded, l.Status == layerVerifying:
+		return 0.5
+	case l.Status == layerExtracting:
+		return 0.5 + step/2
+	}
+	return 0
+}
+
+//TransferProgress is the progress of the pull or the push of an image, as
+//the daemon reports it
+type TransferProgress struct {
+	Reference string
+	//Status is the last status reported about the image, not about a layer
+	Status string
+	Layers []LayerProgress
+}
+
+//Fraction returns how much of the image has been transferred, 0 until its
+//layers are known
+func (p TransferProgress) Fraction() float64 {
+	if len(p.Layers) == 0 {
+		return 0
+	}
+	var transferred float64
+	for _, l := range p.Layers {
+		transferred += l.Fraction()
+	}
+	return transferred / float64(len(p.Layers))
+}
+
+//update applies the given message of the daemon to the progress
+func (p *TransferProgress) update(m jsonmessage.JSONMessage) {
+	if m.ID == "" || strings.HasPrefix(m.Status, "Pulling from") {
+		p.Status = m.Status
+		return
+	}
+	i := 0
+	for ; i < len(p.Layers); i++ {
+		if p.Layers[i].ID == m.ID {
+			break
+		}
+	}
+	if i == len(p.Layers) {
+		p.Layers = append(p.Layers, LayerProgress{ID: m.ID})
+	}
+	layer := &p.Layers[i]
+	layer.Status = m.Status
+	layer.Current, layer.Total = 0, 0
+	if m.Progress != nil {
+		layer.Current, layer.Total = m.Progress.Current, m.Progress.Total
+	}
+}
+
+//snapshot returns a copy of the progress that later updates do not change
+func (p TransferProgress) snapshot() TransferProgress {
+	p.Layers = append([]LayerProgress(nil), p.Layers...)
+	return p
+}
+
+//followTransfer reads the messages the daemon streams about the transfer of
+//the image with the given reference until it is done, telling progress about
+//every change. The first error reported by the daemon is returned.
+func followTransfer(ctx context.Context, stream io.Reader, ref string, progress func(TransferProgress)) error {
+	p := TransferProgress{Reference: ref}
+	decoder := json.NewDecoder(stream)
+	for {
+		var m jsonmessage.JSONMessage
+		if err := decoder.Decode(&m); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+		if m.Error != nil {
+			return errors.New(m.Error.Message)
+		}
+		if m.ErrorMessage != "" {
+			return errors.New(m.ErrorMessage)
+		}
+		p.update(m)
+		if progress != nil {
+			progress(p.snapshot())
+		}
+	}
+}
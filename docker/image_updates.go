@@ -0,0 +1,191 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution/reference"
+)
+
+//OutdatedFilterPrefix is the prefix of the container list filters that match
+//containers by whether their image is behind its registry version (i.e. outdated:true)
+const OutdatedFilterPrefix = "outdated:"
+
+//imageUpdateWorkers is how many registry lookups run at the same time
+const imageUpdateWorkers = 4
+
+//imageUpdateTimeout is how long a registry lookup can take
+var imageUpdateTimeout = 30 * time.Second
+
+var (
+	//ErrRegistryRateLimited is returned when the registry refuses a lookup
+	//because too many requests were made, Docker Hub limits anonymous users
+	ErrRegistryRateLimited = errors.New("registry rate limit reached, try again later")
+	//ErrImageNotInRegistry is returned when the registry does not know about
+	//the image, or does not let the user see it
+	ErrImageNotInRegistry = errors.New("image not found on the registry, or access denied")
+)
+
+//ImageUpdateStatus tells if the image of a container is behind its registry version
+type ImageUpdateStatus int
+
+//Image update statuses
+const (
+	//ImageUpdateUnknown is the status of images not checked, or whose check failed
+	ImageUpdateUnknown ImageUpdateStatus = iota
+	//ImageUpToDate is the status of images that the registry has no newer version of
+	ImageUpToDate
+	//ImageOutdated is the status of images that the registry has a newer version of
+	ImageOutdated
+	//ImageNotPulled is the status of images that do not come from a registry,
+	//such as images built locally or containers created from an image ID
+	ImageNotPulled
+)
+
+//String returns the name of this status as shown on the container list
+func (s ImageUpdateStatus) String() string {
+	switch s {
+	case ImageUpToDate:
+		return "current"
+	case ImageOutdated:
+		return "outdated"
+	case ImageNotPulled:
+		return "local"
+	}
+	return ""
+}
+
+//ImageUpdate is the result of checking if the image of a container is behind
+//its registry version
+type ImageUpdate struct {
+	ContainerID string
+	Image       string
+	Status      ImageUpdateStatus
+	Err         error
+}
+
+//imageUpdateKey identifies an image to check, containers created from the
+//same reference can run different versions of it
+type imageUpdateKey struct {
+	reference string
+	imageID   string
+}
+
+//CheckImageUpdates checks, for each of the given containers, if the image
+//reference it was created from points to a newer image on the registry than
+//the one the container runs. The registry is asked once per image, a few
+//images at a time, with the credentials of the Docker CLI. The result of each
+//container is reported as soon as it is known, report must be safe for
+//concurrent use. It returns once every container was checked or ctx is done.
+func (daemon *DockerDaemon) CheckImageUpdates(ctx context.Context, containers []*Container, report func(ImageUpdate)) {
+	config := loadCLIConfig(cliConfigFile())
+	byImage := make(map[imageUpdateKey][]*Container)
+	var keys []imageUpdateKey
+	for _, c := range containers {
+		key := imageUpdateKey{c.Image, c.ImageID}
+		if _, ok := byImage[key]; !ok {
+			keys = append(keys, key)
+		}
+		byImage[key] = append(byImage[key], c)
+	}
+
+	pending := make(chan imageUpdateKey)
+	var wg sync.WaitGroup
+	for i := 0; i < imageUpdateWorkers && i < len(keys); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range pending {
+				status, err := daemon.checkImageUpdate(ctx, config, key)
+				for _, c := range byImage[key] {
+					report(ImageUpdate{ContainerID: c.ID, Image: key.reference, Status: status, Err: err})
+				}
+			}
+		}()
+	}
+sending:
+	for _, key := range keys {
+		select {
+		case pending <- key:
+		case <-ctx.Done():
+			break sending
+		}
+	}
+	close(pending)
+	wg.Wait()
+}
+
+//checkImageUpdate compares the digest of the image with the given id with the
+//digest the registry has for the given reference
+func (daemon *DockerDaemon) checkImageUpdate(ctx context.Context, config cliConfig, key imageUpdateKey) (ImageUpdateStatus, error) {
+	if strings.HasPrefix(key.reference, "sha256:") {
+		//created from an image ID
+		return ImageNotPulled, nil
+	}
+	named, err := reference.ParseNormalizedNamed(key.reference)
+	if err != nil {
+		return ImageNotPulled, nil
+	}
+	if _, ok := named.(reference.Canonical); ok {
+		//pinned to a digest, it does not change
+		return ImageUpToDate, nil
+	}
+	named = reference.TagNameOnly(named)
+
+	lookupCtx, cancel := context.WithTimeout(ctx, imageUpdateTimeout)
+	defer cancel()
+	local, _, err := daemon.client.ImageInspectWithRaw(lookupCtx, key.imageID)
+	if err != nil {
+		return ImageUpdateUnknown, err
+	}
+	var localDigests []string
+	for _, repoDigest := range local.RepoDigests {
+		canonical, err := reference.ParseNormalizedNamed(repoDigest)
+		if err != nil {
+			continue
+		}
+		if c, ok := canonical.(reference.Canonical); ok && c.Name() == named.Name() {
+			localDigests = append(localDigests, c.Digest().String())
+		}
+	}
+	if len(localDigests) == 0 {
+		return ImageNotPulled, nil
+	}
+	remote, err := daemon.client.DistributionInspect(lookupCtx, named.String(), config.registryAuth(named))
+	if err != nil {
+		if lookupCtx.Err() == context.DeadlineExceeded {
+			return ImageUpdateUnknown, fmt.Errorf("registry lookup timed out after %s", imageUpdateTimeout)
+		}
+		return ImageUpdateUnknown, registryError(err)
+	}
+	for _, digest := range localDigests {
+		if digest == remote.Descriptor.Digest.String() {
+			return ImageUpToDate, nil
+		}
+	}
+	return ImageOutdated, nil
+}
+
+//registryError tells rate limit errors apart from errors about images that
+//the registry does not have, other errors are returned as they are
+func registryError(err error) error {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "toomanyrequests"),
+		strings.Contains(msg, "too many requests"),
+		strings.Contains(msg, "rate limit"):
+		return ErrRegistryRateLimited
+	case strings.Contains(msg, "manifest unknown"),
+		strings.Contains(msg, "not found"),
+		strings.Contains(msg, "does not exist"),
+		strings.Contains(msg, "access denied"),
+		strings.Contains(msg, "access to the resource is denied"),
+		strings.Contains(msg, "unauthorized"):
+		return ErrImageNotInRegistry
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker/mock"
+	digest "github.com/opencontainers/go-digest"
+)
+
+func TestCheckImageUpdates(t *testing.T) {
+	os.Setenv("DOCKER_CONFIG", t.Name())
+	defer os.Unsetenv("DOCKER_CONFIG")
+
+	const (
+		oldDigest = digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+		newDigest = digest.Digest("sha256:2222222222222222222222222222222222222222222222222222222222222222")
+	)
+	client := &mock.RegistryAPIClientMock{
+		Images: map[string]types.ImageInspect{
+			"nginx-old":   {RepoDigests: []string{"nginx@" + oldDigest.String()}},
+			"redis-new":   {RepoDigests: []string{"redis@" + newDigest.String()}},
+			"local-build": {},
+			"private":     {RepoDigests: []string{"registry.example.com/app@" + oldDigest.String()}},
+			"limited":     {RepoDigests: []string{"busybox@" + oldDigest.String()}},
+		},
+		Digests: map[string]digest.Digest{
+			"docker.io/library/nginx:latest": newDigest,
+			"docker.io/library/redis:5":      newDigest,
+		},
+		Errors: map[string]error{
+			"registry.example.com/app:latest":  errors.New("Error response from daemon: manifest unknown: manifest unknown"),
+			"docker.io/library/busybox:latest": errors.New("toomanyrequests: You have reached your pull rate limit"),
+		},
+	}
+	containers := []*Container{
+		{Container: types.Container{ID: "web1", Image: "nginx", ImageID: "nginx-old"}},
+		{Container: types.Container{ID: "web2", Image: "nginx", ImageID: "nginx-old"}},
+		{Container: types.Container{ID: "cache", Image: "redis:5", ImageID: "redis-new"}},
+		{Container: types.Container{ID: "app", Image: "app", ImageID: "local-build"}},
+		{Container: types.Container{ID: "byid", Image: "sha256:" + newDigest.Hex(), ImageID: "redis-new"}},
+		{Container: types.Container{ID: "private", Image: "registry.example.com/app", ImageID: "private"}},
+		{Container: types.Container{ID: "limited", Image: "busybox", ImageID: "limited"}},
+	}
+	want := map[string]ImageUpdate{
+		"web1":    {Status: ImageOutdated},
+		"web2":    {Status: ImageOutdated},
+		"cache":   {Status: ImageUpToDate},
+		"app":     {Status: ImageNotPulled},
+		"byid":    {Status: ImageNotPulled},
+		"private": {Status: ImageUpdateUnknown, Err: ErrImageNotInRegistry},
+		"limited": {Status: ImageUpdateUnknown, Err: ErrRegistryRateLimited},
+	}
+
+	daemon := DockerDaemon{client: client}
+	var lock sync.Mutex
+	got := make(map[string]ImageUpdate)
+	daemon.CheckImageUpdates(context.Background(), containers, func(u ImageUpdate) {
+		lock.Lock()
+		defer lock.Unlock()
+		got[u.ContainerID] = u
+	})
+
+	for id, w := range want {
+		u, ok := got[id]
+		if !ok {
+			t.Errorf("No update reported for container %s", id)
+			continue
+		}
+		if u.Status != w.Status || u.Err != w.Err {
+			t.Errorf("Container %s: got status %s, error %v, want status %s, error %v", id, u.Status, u.Err, w.Status, w.Err)
+		}
+	}
+	//nginx is looked up once for both containers, local images are not looked up
+	if lookups := client.Lookups(); lookups != 4 {
+		t.Errorf("Unexpected number of registry lookups, got %d, want 4", lookups)
+	}
+}
+
+func TestRegistryError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{errors.New("toomanyrequests: Too Many Requests"), ErrRegistryRateLimited},
+		{errors.New("Error response from daemon: manifest unknown"), ErrImageNotInRegistry},
+		{errors.New("errors: denied: requested access to the resource is denied"), ErrImageNotInRegistry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			if got := registryError(tt.err); got != tt.want {
+				t.Errorf("registryError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	other := errors.New("connection refused")
+	if got := registryError(other); got != other {
+		t.Errorf("registryError() = %v, want %v", got, other)
+	}
+}
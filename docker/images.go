@@ -38,9 +38,13 @@ func (daemon *DockerDaemon) ImageByID(id string) (dockerTypes.ImageSummary, erro
 
 //Images returns the list of Docker images
 func (daemon *DockerDaemon) Images() ([]dockerTypes.ImageSummary, error) {
-
-	return images(daemon.client, defaultImageListOptions)
-
+	images, err := daemon.calls.do(imagesCall, func(ctx context.Context) (interface{}, error) {
+		return daemon.client.ImageList(ctx, defaultImageListOptions)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images.([]dockerTypes.ImageSummary), nil
 }
 
 //RunImage creates a container based on the given image and runs the given command
@@ -81,3 +85,13 @@ func (daemon *DockerDaemon) RunImage(image dockerTypes.ImageSummary, command str
 	}
 	return nil
 }
+
+//IsDangling returns true if the given image is dangling, i.e. it is not tagged
+func IsDangling(image dockerTypes.ImageSummary) bool {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
@@ -17,3 +17,23 @@ func TestImageRun(t *testing.T) {
 		t.Errorf("Running an image resulted in error %s", err.Error())
 	}
 }
+
+func TestIsDangling(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want bool
+	}{
+		{"no tags", nil, true},
+		{"none tag", []string{"<none>:<none>"}, true},
+		{"tagged", []string{"nope:latest"}, false},
+		{"tagged and none", []string{"<none>:<none>", "nope:latest"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDangling(types.ImageSummary{RepoTags: tt.tags}); got != tt.want {
+				t.Errorf("IsDangling() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+//JournaldLogDriver is the name of the log driver that sends the logs of
+//containers to the systemd journal
+const JournaldLogDriver = "journald"
+
+//ErrJournalNotLocal is returned when the journal of the Docker host cannot be
+//read because the host is not this one
+var ErrJournalNotLocal = errors.New("the journal can only be read when Docker runs on this host")
+
+//readableLogDrivers are the log drivers whose logs dry reads through the
+//Docker API, the empty one stands for containers whose driver is not known
+var readableLogDrivers = map[string]bool{
+	"":          true,
+	"json-file": true,
+	"local":     true,
+}
+
+//logDestinations are, per log driver, the options of the driver that tell
+//where the logs go
+var logDestinations = map[string][]string{
+	"awslogs":    {"awslogs-region", "awslogs-group", "awslogs-stream"},
+	"fluentd":    {"fluentd-address", "tag"},
+	"gcplogs":    {"gcp-project", "gcp-log-cmd"},
+	"gelf":       {"gelf-address", "tag"},
+	"journald":   {"tag"},
+	"logentries": {"logentries-token"},
+	"splunk":     {"splunk-url", "splunk-index", "splunk-source"},
+	"syslog":     {"syslog-address", "syslog-facility", "tag"},
+}
+
+//LogDriver returns the log driver of the given container, empty if the
+//container was not inspected
+func LogDriver(c *Container) string {
+	if c == nil || c.ContainerJSONBase == nil || c.ContainerJSON.HostConfig == nil {
+		return ""
+	}
+	return c.ContainerJSON.HostConfig.LogConfig.Type
+}
+
+//LogsReadable returns true if the logs written by the given log driver can
+//be read through the Docker API
+func LogsReadable(driver string) bool {
+	return readableLogDrivers[driver]
+}
+
+//LogDestination describes, from the options of its log driver, where the
+//logs of the given container go. Empty if the options do not tell.
+func LogDestination(c *Container) string {
+	driver := LogDriver(c)
+	if driver == "" {
+		return ""
+	}
+	options := c.ContainerJSON.HostConfig.LogConfig.Config
+	var destination []string
+	for _, option := range logDestinations[driver] {
+		if value := options[option]; value != "" {
+			destination = append(destination, option+"="+value)
+		}
+	}
+	return strings.Join(destination, ", ")
+}
+
+//IsLocal returns true if the Docker host is this one
+func (daemon *DockerDaemon) IsLocal() bool {
+	if daemon.dockerEnv == nil {
+		return true
+	}
+	host := daemon.dockerEnv.DockerHost
+	return host == "" || strings.HasPrefix(host, "unix://")
+}
+
+//JournalLogs reads the logs of the container with the given id from the
+//systemd journal of this host, with journalctl, following them as they are
+//written. The logs are plain text, not multiplexed as the Docker API sends
+//them. since is either a timestamp or a duration relative to now, as for
+//Logs. Closing the returned stream stops journalctl.
+func (daemon *DockerDaemon) JournalLogs(id string, since string) (io.ReadCloser, error) {
+	if !daemon.IsLocal() {
+		return nil, ErrJournalNotLocal
+	}
+	args := []string{"CONTAINER_ID_FULL=" + id, "--follow", "--no-tail", "--no-pager", "--output", "cat"}
+	if since != "" {
+		journalSince, err := journalctlSince(since, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--since", journalSince)
+	}
+	cmd := exec.Command("journalctl", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("journalctl could not be run: %s", err.Error())
+	}
+	return &commandOutput{stdout, cmd}, nil
+}
+
+//journalctlSince translates the given "since" value, a timestamp or a
+//duration relative to now as the Docker API takes them, to the format of
+//journalctl
+func journalctlSince(since string, now time.Time) (string, error) {
+	const journalctlTime = "2006-01-02 15:04:05"
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d).Format(journalctlTime), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, since, now.Location()); err == nil {
+			return t.In(now.Location()).Format(journalctlTime), nil
+		}
+	}
+	return "", fmt.Errorf("invalid time %q, expected a timestamp (e.g. 2013-01-02T13:23:37) or a duration (e.g. 42m)", since)
+}
+
+//commandOutput is the output of a command, closing it kills the command
+type commandOutput struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (o *commandOutput) Close() error {
+	o.cmd.Process.Kill()
+	err := o.ReadCloser.Close()
+	o.cmd.Wait()
+	return err
+}
@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func withLogConfig(driver string, options map[string]string) *Container {
+	return &Container{ContainerJSON: types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				LogConfig: container.LogConfig{Type: driver, Config: options},
+			},
+		},
+	}}
+}
+
+func TestLogDriver(t *testing.T) {
+	tests := []struct {
+		name            string
+		c               *Container
+		wantDriver      string
+		wantReadable    bool
+		wantDestination string
+	}{
+		{"not inspected", &Container{}, "", true, ""},
+		{"json-file", withLogConfig("json-file", map[string]string{"max-size": "10m"}), "json-file", true, ""},
+		{"local", withLogConfig("local", nil), "local", true, ""},
+		{"journald", withLogConfig("journald", nil), "journald", false, ""},
+		{
+			"awslogs",
+			withLogConfig("awslogs", map[string]string{"awslogs-region": "eu-west-1", "awslogs-group": "web", "awslogs-create-group": "true"}),
+			"awslogs", false, "awslogs-region=eu-west-1, awslogs-group=web",
+		},
+		{"none", withLogConfig("none", nil), "none", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := LogDriver(tt.c)
+			if driver != tt.wantDriver {
+				t.Errorf("LogDriver() = %q, want %q", driver, tt.wantDriver)
+			}
+			if got := LogsReadable(driver); got != tt.wantReadable {
+				t.Errorf("LogsReadable(%q) = %v, want %v", driver, got, tt.wantReadable)
+			}
+			if got := LogDestination(tt.c); got != tt.wantDestination {
+				t.Errorf("LogDestination() = %q, want %q", got, tt.wantDestination)
+			}
+		})
+	}
+}
+
+func TestJournalctlSince(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		since   string
+		want    string
+		wantErr bool
+	}{
+		{"42m", "2018-06-01 11:18:00", false},
+		{"2h", "2018-06-01 10:00:00", false},
+		{"2018-05-31T23:10:05", "2018-05-31 23:10:05", false},
+		{"2018-05-31T23:10:05+02:00", "2018-05-31 21:10:05", false},
+		{"2018-05-31", "2018-05-31 00:00:00", false},
+		{"yesterday-ish", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.since, func(t *testing.T) {
+			got, err := journalctlSince(tt.since, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("journalctlSince() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("journalctlSince() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"sync"
 
 	dockerAPI "github.com/docker/docker/client"
@@ -8,6 +9,7 @@ import (
 
 //ContainerStore defines a container storage.
 type ContainerStore interface {
+	Add(c *Container)
 	Get(id string) *Container
 	List() []*Container
 	Remove(id string)
@@ -25,7 +27,17 @@ type InMemoryContainerStore struct {
 //NewDockerContainerStore creates a new Docker container store that will use the given Docker
 //daemon client to retrieve container information.
 func NewDockerContainerStore(client dockerAPI.ContainerAPIClient) (ContainerStore, error) {
-	containers, err := containers(client)
+	//Since this is how dry fist connects to the Docker daemon
+	//a different (longer) timeout is used.
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultConnectionTimeout)
+	defer cancel()
+	return newContainerStore(ctx, client)
+}
+
+//newContainerStore creates a container store with the containers the
+//given client lists before the given context is done
+func newContainerStore(ctx context.Context, client dockerAPI.ContainerAPIClient) (ContainerStore, error) {
+	containers, err := containers(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -34,18 +46,18 @@ func NewDockerContainerStore(client dockerAPI.ContainerAPIClient) (ContainerStor
 		client: client,
 	}
 	for _, container := range containers {
-		store.add(container)
+		store.Add(container)
 	}
 	return store, nil
 }
 
-func (c *InMemoryContainerStore) add(cont *Container) {
+// Add adds a container to the store, a container with the same id is replaced.
+func (c *InMemoryContainerStore) Add(cont *Container) {
 	c.Lock()
-	//If a container with the given ID exists already it is replaced
 	if _, ok := c.s[cont.ID]; ok {
 		for pos, container := range c.c {
 			if container.ID == cont.ID {
-				c.c = append(c.c[0:pos], c.c[pos:]...)
+				c.c[pos] = cont
 				break
 			}
 		}
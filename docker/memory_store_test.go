@@ -49,6 +49,28 @@ func TestMemoryStoreCreation(t *testing.T) {
 	checkMemoryStore(memStore.(*InMemoryContainerStore), containerCount, t)
 }
 
+func TestMemoryStoreAdd(t *testing.T) {
+	c := mock.ContainerAPIClientMock{}
+	memStore, _ := NewDockerContainerStore(c)
+
+	updated := &Container{Container: dockerTypes.Container{ID: "1", Status: "updated"}}
+	memStore.Add(updated)
+	if memStore.Get("1") != updated {
+		t.Error("Memstore did not replace the container")
+	}
+	var found bool
+	for _, c := range memStore.List() {
+		if c == updated {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Memstore does not list the replaced container")
+	}
+	memStore.Add(&Container{Container: dockerTypes.Container{ID: "new"}})
+	checkMemoryStore(memStore.(*InMemoryContainerStore), containerCount+1, t)
+}
+
 func createTestContainers(numberOfContainers int) []dockerTypes.Container {
 	var containers []dockerTypes.Container
 
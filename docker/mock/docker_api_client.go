@@ -1,14 +1,31 @@
 package mock
 
 import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
 	"strconv"
+	"strings"
+	"sync"
 
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
 	dockerAPI "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	digest "github.com/opencontainers/go-digest"
 )
 
 //Docker repo is vendoring x/net/context and it seems that it conflicts
@@ -77,3 +94,665 @@ func (mock ImageAPIClientMock) ImageInspectWithRaw(ctx context.Context, image st
 		ContainerConfig: &container.Config{},
 	}, nil, nil
 }
+
+//PluginAPIClientMock mocks docker PluginAPIClient
+type PluginAPIClientMock struct {
+	dockerAPI.APIClient
+	Plugins types.PluginsListResponse
+	Err     error
+}
+
+//PluginList returns the plugins of the mock, or its error
+func (m PluginAPIClientMock) PluginList(ctx context.Context, filter filters.Args) (types.PluginsListResponse, error) {
+	return m.Plugins, m.Err
+}
+
+//RegistryAPIClientMock mocks the image inspection and the registry lookups of
+//a Docker client
+type RegistryAPIClientMock struct {
+	dockerAPI.APIClient
+	//Images are the local images, by ID
+	Images map[string]types.ImageInspect
+	//Digests are the digests on the registry, by image reference
+	Digests map[string]digest.Digest
+	//Errors are the errors of the registry lookups, by image reference
+	Errors  map[string]error
+	lookups int
+	sync.Mutex
+}
+
+//ImageInspectWithRaw returns the local image with the given ID
+func (m *RegistryAPIClientMock) ImageInspectWithRaw(ctx context.Context, image string) (types.ImageInspect, []byte, error) {
+	if i, ok := m.Images[image]; ok {
+		return i, nil, nil
+	}
+	return types.ImageInspect{}, nil, errors.New("No such image: " + image)
+}
+
+//DistributionInspect returns the digest on the registry of the given image reference
+func (m *RegistryAPIClientMock) DistributionInspect(ctx context.Context, image, encodedRegistryAuth string) (registry.DistributionInspect, error) {
+	m.Lock()
+	m.lookups++
+	m.Unlock()
+	if err, ok := m.Errors[image]; ok {
+		return registry.DistributionInspect{}, err
+	}
+	var inspect registry.DistributionInspect
+	inspect.Descriptor.Digest = m.Digests[image]
+	return inspect, nil
+}
+
+//Lookups returns the number of registry lookups made
+func (m *RegistryAPIClientMock) Lookups() int {
+	m.Lock()
+	defer m.Unlock()
+	return m.lookups
+}
+
+//ContainerWaitAPIClientMock mocks the signalling of a container and the
+//waits for it to exit
+type ContainerWaitAPIClientMock struct {
+	dockerAPI.APIClient
+	//StopSignal is the stop signal the container defines
+	StopSignal string
+	//Exit is how the container exits once signalled, it does not exit if nil
+	Exit    *container.ContainerWaitOKBody
+	signals []string
+	sync.Mutex
+}
+
+//ContainerInspect returns a container with the stop signal of the mock
+func (m *ContainerWaitAPIClientMock) ContainerInspect(ctx context.Context, id string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{Config: &container.Config{StopSignal: m.StopSignal}}, nil
+}
+
+//ContainerList returns no containers
+func (m *ContainerWaitAPIClientMock) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	return nil, nil
+}
+
+//ContainerKill records the signal sent
+func (m *ContainerWaitAPIClientMock) ContainerKill(ctx context.Context, id, signal string) error {
+	m.Lock()
+	defer m.Unlock()
+	m.signals = append(m.signals, signal)
+	return nil
+}
+
+//ContainerWait returns the exit of the mock, or the error of the given
+//context once it is done if the container does not exit
+func (m *ContainerWaitAPIClientMock) ContainerWait(ctx context.Context, id string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+	exits := make(chan container.ContainerWaitOKBody, 1)
+	errs := make(chan error, 1)
+	if m.Exit != nil {
+		exits <- *m.Exit
+	} else {
+		go func() {
+			<-ctx.Done()
+			errs <- ctx.Err()
+		}()
+	}
+	return exits, errs
+}
+
+//Signals returns the signals sent to the container
+func (m *ContainerWaitAPIClientMock) Signals() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string(nil), m.signals...)
+}
+
+//CheckpointAPIClientMock mocks the checkpoint operations of a Docker client
+type CheckpointAPIClientMock struct {
+	dockerAPI.APIClient
+	Checkpoints []types.Checkpoint
+	Err         error
+	//Created, Deleted and Started record the options of the operations run
+	Created []types.CheckpointCreateOptions
+	Deleted []types.CheckpointDeleteOptions
+	Started []types.ContainerStartOptions
+}
+
+//CheckpointList returns the checkpoints of the mock, or its error
+func (m *CheckpointAPIClientMock) CheckpointList(ctx context.Context, container string, options types.CheckpointListOptions) ([]types.Checkpoint, error) {
+	return m.Checkpoints, m.Err
+}
+
+//CheckpointCreate records the checkpoint creation
+func (m *CheckpointAPIClientMock) CheckpointCreate(ctx context.Context, container string, options types.CheckpointCreateOptions) error {
+	m.Created = append(m.Created, options)
+	return m.Err
+}
+
+//CheckpointDelete records the checkpoint deletion
+func (m *CheckpointAPIClientMock) CheckpointDelete(ctx context.Context, container string, options types.CheckpointDeleteOptions) error {
+	m.Deleted = append(m.Deleted, options)
+	return m.Err
+}
+
+//ContainerStart records the container start
+func (m *CheckpointAPIClientMock) ContainerStart(ctx context.Context, container string, options types.ContainerStartOptions) error {
+	m.Started = append(m.Started, options)
+	return m.Err
+}
+
+//ContainerList returns no containers
+func (m *CheckpointAPIClientMock) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	return nil, nil
+}
+
+//ImageHistoryAPIClientMock mocks the image history of a Docker client
+type ImageHistoryAPIClientMock struct {
+	dockerAPI.APIClient
+	//Histories are the histories of the images, by image id
+	Histories map[string][]image.HistoryResponseItem
+	calls     int
+	sync.Mutex
+}
+
+//ImageHistory returns the history of the image with the given id
+func (m *ImageHistoryAPIClientMock) ImageHistory(ctx context.Context, id string) ([]image.HistoryResponseItem, error) {
+	m.Lock()
+	m.calls++
+	m.Unlock()
+	if history, ok := m.Histories[id]; ok {
+		return history, nil
+	}
+	return nil, errors.New("No such image: " + id)
+}
+
+//Calls returns the number of image histories asked for
+func (m *ImageHistoryAPIClientMock) Calls() int {
+	m.Lock()
+	defer m.Unlock()
+	return m.calls
+}
+
+//ContainerRenameAPIClientMock mocks the renaming of the containers of a
+//Docker client
+type ContainerRenameAPIClientMock struct {
+	dockerAPI.APIClient
+	Containers []types.Container
+	Err        error
+	names      map[string]string
+}
+
+//ContainerList returns the containers of the mock
+func (m *ContainerRenameAPIClientMock) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	return m.Containers, nil
+}
+
+//ContainerInspect returns the container with the given id, with the name
+//it was given last
+func (m *ContainerRenameAPIClientMock) ContainerInspect(ctx context.Context, id string) (types.ContainerJSON, error) {
+	name := m.names[id]
+	if name == "" {
+		for _, c := range m.Containers {
+			if c.ID == id && len(c.Names) > 0 {
+				name = c.Names[0]
+			}
+		}
+	}
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: id, Name: name}}, nil
+}
+
+//ContainerRename records the new name of the container with the given id,
+//or fails with the error of the mock
+func (m *ContainerRenameAPIClientMock) ContainerRename(ctx context.Context, id, name string) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	if m.names == nil {
+		m.names = make(map[string]string)
+	}
+	m.names[id] = "/" + name
+	return nil
+}
+
+//ContainerCommitAPIClientMock mocks the commits of the containers of a
+//Docker client
+type ContainerCommitAPIClientMock struct {
+	dockerAPI.APIClient
+	Err     error
+	Commits []types.ContainerCommitOptions
+}
+
+//ContainerCommit records the options of the commit and returns the id of
+//the new image, or fails with the error of the mock
+func (m *ContainerCommitAPIClientMock) ContainerCommit(ctx context.Context, id string, options types.ContainerCommitOptions) (types.IDResponse, error) {
+	if m.Err != nil {
+		return types.IDResponse{}, m.Err
+	}
+	m.Commits = append(m.Commits, options)
+	return types.IDResponse{ID: "sha256:" + strconv.Itoa(len(m.Commits))}, nil
+}
+
+//ContainerCopyAPIClientMock mocks the copies between the host and the
+//containers of a Docker client. What is copied to a container is kept, as
+//an archive, and is what is copied from it.
+type ContainerCopyAPIClientMock struct {
+	dockerAPI.APIClient
+	//Paths of the container, the value is true for directories
+	Paths   map[string]bool
+	Archive []byte
+	Dir     string
+}
+
+//ContainerStatPath returns the stat of the given path, if it is one of the
+//paths of the mock
+func (m *ContainerCopyAPIClientMock) ContainerStatPath(ctx context.Context, id, containerPath string) (types.ContainerPathStat, error) {
+	dir, ok := m.Paths[containerPath]
+	if !ok {
+		return types.ContainerPathStat{}, notFoundError{containerPath}
+	}
+	stat := types.ContainerPathStat{Name: path.Base(containerPath), Mode: 0644}
+	if dir {
+		stat.Mode = os.ModeDir | 0755
+	}
+	return stat, nil
+}
+
+//CopyToContainer keeps the given content and the directory it was copied to
+func (m *ContainerCopyAPIClientMock) CopyToContainer(ctx context.Context, id, containerPath string, content io.Reader, options types.CopyToContainerOptions) error {
+	archive, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.Archive = archive
+	m.Dir = containerPath
+	return nil
+}
+
+//CopyFromContainer returns the content kept, with the stat of a directory
+//named after the given path
+func (m *ContainerCopyAPIClientMock) CopyFromContainer(ctx context.Context, id, containerPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	stat := types.ContainerPathStat{Name: path.Base(containerPath), Mode: os.ModeDir | 0755}
+	return ioutil.NopCloser(bytes.NewReader(m.Archive)), stat, nil
+}
+
+//notFoundError is the error of the daemon when something is not found
+type notFoundError struct {
+	path string
+}
+
+func (e notFoundError) Error() string {
+	return "Could not find the file " + e.path + " in container"
+}
+
+//NotFound tells the Docker client the error is a not found error
+func (e notFoundError) NotFound() bool {
+	return true
+}
+
+//ContainerDiffAPIClientMock mocks the filesystem changes of the containers
+//of a Docker client
+type ContainerDiffAPIClientMock struct {
+	dockerAPI.APIClient
+	Changes []container.ContainerChangeResponseItem
+}
+
+//ContainerDiff returns the changes of the mock
+func (m *ContainerDiffAPIClientMock) ContainerDiff(ctx context.Context, id string) ([]container.ContainerChangeResponseItem, error) {
+	return m.Changes, nil
+}
+
+//ContainerTopAPIClientMock mocks the process lists of the containers of a
+//Docker client, ps options are refused if the mock is told so
+type ContainerTopAPIClientMock struct {
+	dockerAPI.APIClient
+	RefuseArgs bool
+}
+
+//ContainerTop returns a process list with the titles of the ps options given
+func (m *ContainerTopAPIClientMock) ContainerTop(ctx context.Context, id string, arguments []string) (container.ContainerTopOKBody, error) {
+	if len(arguments) == 0 {
+		return container.ContainerTopOKBody{
+			Titles:    []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"},
+			Processes: [][]string{{"root", "1", "0", "0", "10:00", "?", "00:00:01", "nginx"}},
+		}, nil
+	}
+	if m.RefuseArgs {
+		return container.ContainerTopOKBody{}, errors.New("ps: unknown option")
+	}
+	return container.ContainerTopOKBody{
+		Titles:    []string{"USER", "PID", "%CPU", "%MEM", "VSZ", "RSS", "TTY", "STAT", "START", "TIME", "COMMAND"},
+		Processes: [][]string{{"root", "1", "0.5", "0.1", "10000", "5000", "?", "Ss", "10:00", "0:01", "nginx"}},
+	}, nil
+}
+
+//ContainerExecAPIClientMock mocks the commands run in the containers of a
+//Docker client, the output of every command is the output of the mock
+type ContainerExecAPIClientMock struct {
+	dockerAPI.APIClient
+	Output  string
+	Configs []types.ExecConfig
+}
+
+//ContainerExecCreate records the given configuration
+func (m *ContainerExecAPIClientMock) ContainerExecCreate(ctx context.Context, id string, config types.ExecConfig) (types.IDResponse, error) {
+	m.Configs = append(m.Configs, config)
+	return types.IDResponse{ID: "exec" + strconv.Itoa(len(m.Configs))}, nil
+}
+
+//ContainerExecAttach returns the output of the mock
+func (m *ContainerExecAPIClientMock) ContainerExecAttach(ctx context.Context, execID string, config types.ExecConfig) (types.HijackedResponse, error) {
+	conn, _ := net.Pipe()
+	return types.HijackedResponse{
+		Conn:   conn,
+		Reader: bufio.NewReader(bytes.NewBufferString(m.Output)),
+	}, nil
+}
+
+//ContainerAttachAPIClientMock mocks attaching to the containers of a Docker
+//client, containers send the output of the mock and then, if attached to
+//their input, read it until the detach keys are read
+type ContainerAttachAPIClientMock struct {
+	dockerAPI.APIClient
+	Config  *container.Config
+	Output  []byte
+	Options types.ContainerAttachOptions
+	Resized []types.ResizeOptions
+	input   bytes.Buffer
+	sync.Mutex
+}
+
+//ContainerInspect returns a container with the configuration of the mock
+func (m *ContainerAttachAPIClientMock) ContainerInspect(ctx context.Context, id string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id},
+		Config:            m.Config,
+	}, nil
+}
+
+//ContainerAttach records the given options and returns a connection to a
+//container that sends the output of the mock
+func (m *ContainerAttachAPIClientMock) ContainerAttach(ctx context.Context, id string, options types.ContainerAttachOptions) (types.HijackedResponse, error) {
+	m.Options = options
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		server.Write(m.Output)
+		b := make([]byte, 1)
+		for {
+			if _, err := server.Read(b); err != nil {
+				return
+			}
+			m.Lock()
+			m.input.Write(b)
+			detached := bytes.HasSuffix(m.input.Bytes(), []byte{0x10, 0x11})
+			m.Unlock()
+			if options.Stdin && detached {
+				return
+			}
+		}
+	}()
+	return types.HijackedResponse{
+		Conn:   client,
+		Reader: bufio.NewReader(client),
+	}, nil
+}
+
+//ContainerResize records the given options
+func (m *ContainerAttachAPIClientMock) ContainerResize(ctx context.Context, id string, options types.ResizeOptions) error {
+	m.Resized = append(m.Resized, options)
+	return nil
+}
+
+//Input returns what the containers of the mock read from their input
+func (m *ContainerAttachAPIClientMock) Input() []byte {
+	m.Lock()
+	defer m.Unlock()
+	return append([]byte(nil), m.input.Bytes()...)
+}
+
+//ContainerEventsAPIClientMock mocks the containers of a Docker client that
+//change while dry runs
+type ContainerEventsAPIClientMock struct {
+	dockerAPI.APIClient
+	Containers []types.Container
+	//FullLists counts the lists of all the containers retrieved
+	FullLists int
+}
+
+//ContainerList returns the containers of the mock, only the one with the
+//given id if the list is filtered by id
+func (m *ContainerEventsAPIClientMock) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	ids := options.Filters.Get("id")
+	if len(ids) == 0 {
+		m.FullLists++
+		return m.Containers, nil
+	}
+	var containers []types.Container
+	for _, c := range m.Containers {
+		if c.ID == ids[0] {
+			containers = append(containers, c)
+		}
+	}
+	return containers, nil
+}
+
+//ContainerInspect returns the state of the container with the given id
+func (m *ContainerEventsAPIClientMock) ContainerInspect(ctx context.Context, id string) (types.ContainerJSON, error) {
+	for _, c := range m.Containers {
+		if c.ID == id {
+			return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				ID:    id,
+				State: &types.ContainerState{Status: c.State, Running: c.State == "running"}}}, nil
+		}
+	}
+	return types.ContainerJSON{}, notFoundError{id}
+}
+
+//ContainersPruneAPIClientMock mocks the pruning of the stopped containers
+//of a Docker client, each one reclaims 1KB
+type ContainersPruneAPIClientMock struct {
+	dockerAPI.APIClient
+	Containers []types.Container
+}
+
+//ContainerList returns the containers of the mock
+func (m *ContainersPruneAPIClientMock) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	return m.Containers, nil
+}
+
+//ContainerInspect returns an empty inspection result
+func (m *ContainersPruneAPIClientMock) ContainerInspect(ctx context.Context, id string) (types.ContainerJSON, error) {
+	return types.ContainerJSON{}, nil
+}
+
+//ContainersPrune removes the containers of the mock that are not running
+func (m *ContainersPruneAPIClientMock) ContainersPrune(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error) {
+	var report types.ContainersPruneReport
+	var running []types.Container
+	for _, c := range m.Containers {
+		if c.State == "running" {
+			running = append(running, c)
+			continue
+		}
+		report.ContainersDeleted = append(report.ContainersDeleted, c.ID)
+		report.SpaceReclaimed += 1024
+	}
+	m.Containers = running
+	return report, nil
+}
+
+//ImageTransferAPIClientMock mocks the pull and the push of images of a
+//Docker client, the daemon reports the given messages
+type ImageTransferAPIClientMock struct {
+	dockerAPI.APIClient
+	Messages []jsonmessage.JSONMessage
+	//Pulled and Pushed are the references of the last image pulled and pushed
+	Pulled string
+	Pushed string
+	//PushAuth are the encoded credentials of the last push
+	PushAuth string
+}
+
+//ImagePull returns the messages of the mock as the daemon streams them
+func (m *ImageTransferAPIClientMock) ImagePull(ctx context.Context, ref string, options types.ImagePullOptions) (io.ReadCloser, error) {
+	m.Pulled = ref
+	return m.stream()
+}
+
+//ImagePush returns the messages of the mock as the daemon streams them
+func (m *ImageTransferAPIClientMock) ImagePush(ctx context.Context, ref string, options types.ImagePushOptions) (io.ReadCloser, error) {
+	m.Pushed = ref
+	m.PushAuth = options.RegistryAuth
+	return m.stream()
+}
+
+func (m *ImageTransferAPIClientMock) stream() (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, message := range m.Messages {
+		if err := encoder.Encode(message); err != nil {
+			return nil, err
+		}
+	}
+	return ioutil.NopCloser(&buf), nil
+}
+
+//ImageTagAPIClientMock mocks the tagging of the images of a Docker client,
+//Tags are the tags of each image, by id
+type ImageTagAPIClientMock struct {
+	dockerAPI.APIClient
+	Tags map[string][]string
+}
+
+//ImageInspectWithRaw returns the image with the given id and its tags
+func (m *ImageTagAPIClientMock) ImageInspectWithRaw(ctx context.Context, id string) (types.ImageInspect, []byte, error) {
+	tags, ok := m.Tags[id]
+	if !ok {
+		return types.ImageInspect{}, nil, errors.New("No such image: " + id)
+	}
+	return types.ImageInspect{ID: id, RepoTags: tags}, nil, nil
+}
+
+//ImageTag adds the given tag to the image with the given id
+func (m *ImageTagAPIClientMock) ImageTag(ctx context.Context, id, ref string) error {
+	if _, ok := m.Tags[id]; !ok {
+		return errors.New("No such image: " + id)
+	}
+	m.Tags[id] = append(m.Tags[id], ref)
+	return nil
+}
+
+//ImageRemove removes the given tag from the image that has it
+func (m *ImageTagAPIClientMock) ImageRemove(ctx context.Context, ref string, options types.ImageRemoveOptions) ([]types.ImageDeleteResponseItem, error) {
+	for id, tags := range m.Tags {
+		for i, tag := range tags {
+			if tag == ref {
+				m.Tags[id] = append(tags[:i:i], tags[i+1:]...)
+				return []types.ImageDeleteResponseItem{{Untagged: ref}}, nil
+			}
+		}
+	}
+	return nil, errors.New("No such image: " + ref)
+}
+
+//ImageBuildAPIClientMock mocks the builds of images of a Docker client, the
+//daemon reports the given messages
+type ImageBuildAPIClientMock struct {
+	dockerAPI.APIClient
+	Messages []jsonmessage.JSONMessage
+	//Files are the files of the context of the last build
+	Files   []string
+	Options types.ImageBuildOptions
+}
+
+//ImageBuild reads the files of the given context and returns the messages
+//of the mock as the daemon streams them
+func (m *ImageBuildAPIClientMock) ImageBuild(ctx context.Context, buildContext io.Reader, options types.ImageBuildOptions) (types.ImageBuildResponse, error) {
+	m.Options = options
+	m.Files = nil
+	tr := tar.NewReader(buildContext)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return types.ImageBuildResponse{}, err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			m.Files = append(m.Files, hdr.Name)
+		}
+	}
+	transfer := ImageTransferAPIClientMock{Messages: m.Messages}
+	body, err := transfer.stream()
+	return types.ImageBuildResponse{Body: body}, err
+}
+
+//ImageArchiveAPIClientMock mocks the save and the load of images of a
+//Docker client, the images saved have the given tags and content, the
+//daemon reports the given messages on loads
+type ImageArchiveAPIClientMock struct {
+	dockerAPI.APIClient
+	Tags     []string
+	Content  string
+	Messages []jsonmessage.JSONMessage
+	//Saved are the references of the last images saved
+	Saved []string
+	//Loaded is the archive of the last load
+	Loaded string
+}
+
+//ImageInspectWithRaw returns an image with the tags of the mock
+func (m *ImageArchiveAPIClientMock) ImageInspectWithRaw(ctx context.Context, id string) (types.ImageInspect, []byte, error) {
+	return types.ImageInspect{ID: id, RepoTags: m.Tags, VirtualSize: int64(len(m.Content))}, nil, nil
+}
+
+//ImageSave returns the content of the mock as the archive of the given images
+func (m *ImageArchiveAPIClientMock) ImageSave(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	m.Saved = refs
+	return ioutil.NopCloser(bytes.NewBufferString(m.Content)), nil
+}
+
+//ImageLoad reads the given archive and returns the messages of the mock as
+//the daemon streams them
+func (m *ImageArchiveAPIClientMock) ImageLoad(ctx context.Context, input io.Reader, quiet bool) (types.ImageLoadResponse, error) {
+	b, err := ioutil.ReadAll(input)
+	if err != nil {
+		return types.ImageLoadResponse{}, err
+	}
+	m.Loaded = string(b)
+	transfer := ImageTransferAPIClientMock{Messages: m.Messages}
+	body, err := transfer.stream()
+	return types.ImageLoadResponse{Body: body, JSON: true}, err
+}
+
+//ImagesPruneAPIClientMock mocks the pruning of the images of a Docker
+//client, Report is the report of every prune
+type ImagesPruneAPIClientMock struct {
+	dockerAPI.APIClient
+	Report types.ImagesPruneReport
+	//Filters are the filters of the last prune
+	Filters filters.Args
+}
+
+//ImagesPrune returns the report of the mock
+func (m *ImagesPruneAPIClientMock) ImagesPrune(ctx context.Context, pruneFilters filters.Args) (types.ImagesPruneReport, error) {
+	m.Filters = pruneFilters
+	return m.Report, nil
+}
+
+//ImageSearchAPIClientMock mocks the registry searches of a Docker client,
+//the results of every search are those whose name has the term searched
+type ImageSearchAPIClientMock struct {
+	dockerAPI.APIClient
+	Results []registry.SearchResult
+	//Options are the options of the last search
+	Options types.ImageSearchOptions
+}
+
+//ImageSearch returns the results of the mock whose name has the given term
+func (m *ImageSearchAPIClientMock) ImageSearch(ctx context.Context, term string, options types.ImageSearchOptions) ([]registry.SearchResult, error) {
+	m.Options = options
+	var results []registry.SearchResult
+	for _, result := range m.Results {
+		if strings.Contains(result.Name, term) {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
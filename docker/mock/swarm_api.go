@@ -26,23 +26,51 @@ func (mock SwarmAPIClientMock) NodeList(context context.Context, options types.N
 	}}, nil
 }
 
-//TaskList returns a list of tasks, node with id 1 will return a non empty list
+//TaskList returns a list of tasks, node with id 1 will return a non empty list.
+//If no node is given, the tasks of the services of the stacks are returned.
 func (mock SwarmAPIClientMock) TaskList(context context.Context, options types.TaskListOptions) ([]swarm.Task, error) {
-	nodeID := options.Filters.Get("node")[0]
-	if nodeID == "1" {
-		return []swarm.Task{
-			{
-				ID:     "1",
-				NodeID: "1",
-			},
-		}, nil
+	if nodes := options.Filters.Get("node"); len(nodes) > 0 {
+		if nodes[0] == "1" {
+			return []swarm.Task{
+				{
+					ID:     "1",
+					NodeID: "1",
+				},
+			}, nil
+		}
+		return nil, nil
 	}
-
-	return nil, nil
+	return []swarm.Task{
+		{
+			ID:           "2",
+			ServiceID:    "1",
+			DesiredState: swarm.TaskStateRunning,
+			Status:       swarm.TaskStatus{State: swarm.TaskStateRunning},
+		},
+		{
+			ID:           "3",
+			ServiceID:    "2",
+			DesiredState: swarm.TaskStateRunning,
+			Status:       swarm.TaskStatus{State: swarm.TaskStateRunning},
+		},
+		{
+			ID:           "4",
+			ServiceID:    "3",
+			DesiredState: swarm.TaskStateRunning,
+			Status:       swarm.TaskStatus{State: swarm.TaskStateFailed},
+		},
+		{
+			ID:           "5",
+			ServiceID:    "3",
+			DesiredState: swarm.TaskStateShutdown,
+			Status:       swarm.TaskStatus{State: swarm.TaskStateShutdown},
+		},
+	}, nil
 }
 
 //ServiceList returns a list of services
 func (mock SwarmAPIClientMock) ServiceList(context context.Context, options types.ServiceListOptions) ([]swarm.Service, error) {
+	one, two := uint64(1), uint64(2)
 
 	return []swarm.Service{
 		{
@@ -51,6 +79,7 @@ func (mock SwarmAPIClientMock) ServiceList(context context.Context, options type
 				Annotations: swarm.Annotations{
 					Labels: map[string]string{LabelNamespace: "stack1"},
 				},
+				Mode: swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: &one}},
 			},
 		},
 		{
@@ -59,6 +88,7 @@ func (mock SwarmAPIClientMock) ServiceList(context context.Context, options type
 				Annotations: swarm.Annotations{
 					Labels: map[string]string{LabelNamespace: "stack1"},
 				},
+				Mode: swarm.ServiceMode{Global: &swarm.GlobalService{}},
 			},
 		},
 		{
@@ -67,6 +97,7 @@ func (mock SwarmAPIClientMock) ServiceList(context context.Context, options type
 				Annotations: swarm.Annotations{
 					Labels: map[string]string{LabelNamespace: "stack2"},
 				},
+				Mode: swarm.ServiceMode{Replicated: &swarm.ReplicatedService{Replicas: &two}},
 			},
 		},
 	}, nil
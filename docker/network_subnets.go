@@ -0,0 +1,164 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+//HostRange is the range of addresses of a network interface of the Docker host
+type HostRange struct {
+	Interface string
+	Network   *net.IPNet
+}
+
+//SubnetConflict is a subnet of a network that overlaps with the subnet of
+//another network or with the range of an interface of the Docker host
+type SubnetConflict struct {
+	Subnet string
+	//With describes what the subnet overlaps with
+	With       string
+	WithSubnet string
+}
+
+func (c SubnetConflict) String() string {
+	return fmt.Sprintf("%s overlaps with %s (%s)", c.Subnet, c.With, c.WithSubnet)
+}
+
+//dockerInterfacePrefixes are the prefixes of the names of the interfaces
+//that Docker creates for its own networks
+var dockerInterfacePrefixes = []string{"docker", "br-", "veth"}
+
+//HostRanges returns the ranges of the network interfaces of the Docker host,
+//those created by Docker for its networks left out. Nil if Docker does not
+//run on this host, as the interfaces of the host are not known then.
+func (daemon *DockerDaemon) HostRanges() []HostRange {
+	if !daemon.IsLocal() {
+		return nil
+	}
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var ranges []HostRange
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 || isDockerInterface(iface.Name) {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			ranges = append(ranges, HostRange{
+				Interface: iface.Name,
+				Network:   &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask},
+			})
+		}
+	}
+	return ranges
+}
+
+func isDockerInterface(name string) bool {
+	for _, prefix := range dockerInterfacePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+//Subnets returns the subnets of the IPAM configs of the given network
+func Subnets(network types.NetworkResource) []string {
+	var subnets []string
+	for _, config := range network.IPAM.Config {
+		if config.Subnet != "" {
+			subnets = append(subnets, config.Subnet)
+		}
+	}
+	return subnets
+}
+
+//SubnetConflicts finds the subnets of the given networks that overlap with
+//each other or with the given ranges of the host. The conflicts are
+//returned by network ID. A host range that is the very subnet of a network
+//is the interface of that network and not a conflict.
+func SubnetConflicts(networks []types.NetworkResource, hostRanges []HostRange) map[string][]SubnetConflict {
+	type subnet struct {
+		network types.NetworkResource
+		cidr    string
+		ipNet   *net.IPNet
+	}
+	var subnets []subnet
+	own := make(map[string]bool)
+	for _, network := range networks {
+		for _, cidr := range Subnets(network) {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				continue
+			}
+			subnets = append(subnets, subnet{network, cidr, ipNet})
+			own[ipNet.String()] = true
+		}
+	}
+
+	conflicts := make(map[string][]SubnetConflict)
+	for i, a := range subnets {
+		for _, b := range subnets[i+1:] {
+			if a.network.ID == b.network.ID || !overlap(a.ipNet, b.ipNet) {
+				continue
+			}
+			conflicts[a.network.ID] = append(conflicts[a.network.ID],
+				SubnetConflict{Subnet: a.cidr, With: "network " + b.network.Name, WithSubnet: b.cidr})
+			conflicts[b.network.ID] = append(conflicts[b.network.ID],
+				SubnetConflict{Subnet: b.cidr, With: "network " + a.network.Name, WithSubnet: a.cidr})
+		}
+		for _, r := range hostRanges {
+			if own[r.Network.String()] || !overlap(a.ipNet, r.Network) {
+				continue
+			}
+			conflicts[a.network.ID] = append(conflicts[a.network.ID],
+				SubnetConflict{Subnet: a.cidr, With: "host interface " + r.Interface, WithSubnet: r.Network.String()})
+		}
+	}
+	return conflicts
+}
+
+//overlap returns true if the given networks have addresses in common,
+//either one has the other in it as CIDR blocks cannot partially overlap
+func overlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+//SubnetLess compares the given subnets by address, IPv4 before IPv6 and
+//invalid or empty subnets last
+func SubnetLess(a, b string) bool {
+	_, aNet, aErr := net.ParseCIDR(a)
+	_, bNet, bErr := net.ParseCIDR(b)
+	switch {
+	case aErr != nil:
+		return false
+	case bErr != nil:
+		return true
+	}
+	aIP, bIP := aNet.IP.To4(), bNet.IP.To4()
+	if (aIP == nil) != (bIP == nil) {
+		return aIP != nil
+	}
+	if aIP == nil {
+		aIP, bIP = aNet.IP.To16(), bNet.IP.To16()
+	}
+	if c := bytes.Compare(aIP, bIP); c != 0 {
+		return c < 0
+	}
+	aOnes, _ := aNet.Mask.Size()
+	bOnes, _ := bNet.Mask.Size()
+	return aOnes < bOnes
+}
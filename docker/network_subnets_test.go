@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+)
+
+func networkWithSubnets(id string, subnets ...string) types.NetworkResource {
+	n := types.NetworkResource{ID: id, Name: id}
+	for _, subnet := range subnets {
+		n.IPAM.Config = append(n.IPAM.Config, network.IPAMConfig{Subnet: subnet})
+	}
+	return n
+}
+
+func TestSubnetConflicts(t *testing.T) {
+	_, lan, _ := net.ParseCIDR("192.168.1.0/24")
+	_, ownBridge, _ := net.ParseCIDR("10.10.0.0/16")
+	networks := []types.NetworkResource{
+		networkWithSubnets("bridge", "172.17.0.0/16"),
+		networkWithSubnets("front", "172.18.0.0/16", "fd00:1::/64"),
+		networkWithSubnets("back", "172.18.5.0/24"),
+		networkWithSubnets("dual", "10.1.0.0/16", "fd00:1::/80"),
+		networkWithSubnets("home", "192.168.0.0/16"),
+		networkWithSubnets("custom", "10.10.0.0/16"),
+		networkWithSubnets("host"),
+	}
+	hostRanges := []HostRange{
+		{Interface: "eth0", Network: lan},
+		{Interface: "mybridge", Network: ownBridge},
+	}
+	conflicts := SubnetConflicts(networks, hostRanges)
+
+	tests := []struct {
+		network string
+		want    []string
+	}{
+		{"bridge", nil},
+		{"front", []string{"172.18.0.0/16 overlaps with network back (172.18.5.0/24)", "fd00:1::/64 overlaps with network dual (fd00:1::/80)"}},
+		{"back", []string{"172.18.5.0/24 overlaps with network front (172.18.0.0/16)"}},
+		{"dual", []string{"fd00:1::/80 overlaps with network front (fd00:1::/64)"}},
+		{"home", []string{"192.168.0.0/16 overlaps with host interface eth0 (192.168.1.0/24)"}},
+		{"custom", nil},
+		{"host", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			var got []string
+			for _, c := range conflicts[tt.network] {
+				got = append(got, c.String())
+			}
+			sort.Strings(got)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Conflicts of %s = %q, want %q", tt.network, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubnetLess(t *testing.T) {
+	subnets := []string{"", "fd00::/64", "172.18.0.0/16", "10.0.0.0/8", "172.9.0.0/16", "172.18.0.0/24", "invalid"}
+	sort.SliceStable(subnets, func(i, j int) bool { return SubnetLess(subnets[i], subnets[j]) })
+	want := []string{"10.0.0.0/8", "172.9.0.0/16", "172.18.0.0/16", "172.18.0.0/24", "fd00::/64", "", "invalid"}
+	if strings.Join(subnets, ",") != strings.Join(want, ",") {
+		t.Errorf("Sorted subnets = %v, want %v", subnets, want)
+	}
+}
@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+//networkPeakSamples is how many rates are kept to calculate the peak rate
+const networkPeakSamples = 10
+
+//totalInterface is the interface name used for the container traffic when
+//stats do not tell the traffic of each interface
+const totalInterface = "total"
+
+//NetworkRate is the traffic, in bytes per second, received and sent through
+//a network interface, along with the highest rates of the last samples
+type NetworkRate struct {
+	Interface string
+	Rx        float64
+	Tx        float64
+	PeakRx    float64
+	PeakTx    float64
+}
+
+//rateWindow keeps the last rates calculated for an interface
+type rateWindow struct {
+	rx, tx []float64
+}
+
+func (w *rateWindow) add(rx, tx float64) {
+	w.rx = append(w.rx, rx)
+	w.tx = append(w.tx, tx)
+	if len(w.rx) > networkPeakSamples {
+		w.rx = w.rx[1:]
+		w.tx = w.tx[1:]
+	}
+}
+
+func (w *rateWindow) rate(name string) NetworkRate {
+	r := NetworkRate{Interface: name}
+	if n := len(w.rx); n > 0 {
+		r.Rx, r.Tx = w.rx[n-1], w.tx[n-1]
+	}
+	r.PeakRx, r.PeakTx = peak(w.rx), peak(w.tx)
+	return r
+}
+
+//networkCounters are the counters of an interface on a stats sample
+type networkCounters struct {
+	rx, tx uint64
+}
+
+//NetworkMeter calculates the network traffic rates of a container from
+//successive stats samples. Counters going backwards, i.e. because the container
+//was restarted, are taken as a new start and give no rate for that sample.
+type NetworkMeter struct {
+	last     time.Time
+	counters map[string]networkCounters
+	windows  map[string]*rateWindow
+	total    rateWindow
+	sync.RWMutex
+}
+
+//NewNetworkMeter creates a NetworkMeter
+func NewNetworkMeter() *NetworkMeter {
+	return &NetworkMeter{
+		counters: make(map[string]networkCounters),
+		windows:  make(map[string]*rateWindow),
+	}
+}
+
+//Add calculates the rates since the previous sample using the stats
+//received at the given time
+func (m *NetworkMeter) Add(t time.Time, stats *Stats) {
+	if stats == nil {
+		return
+	}
+	counters := interfaceCounters(stats)
+	m.Lock()
+	defer m.Unlock()
+	elapsed := t.Sub(m.last).Seconds()
+	first := m.last.IsZero()
+	m.last = t
+	if first || elapsed <= 0 {
+		m.counters = counters
+		return
+	}
+	var totalRx, totalTx float64
+	windows := make(map[string]*rateWindow, len(counters))
+	for name, c := range counters {
+		w, ok := m.windows[name]
+		if !ok {
+			w = &rateWindow{}
+		}
+		windows[name] = w
+		prev, ok := m.counters[name]
+		if !ok || c.rx < prev.rx || c.tx < prev.tx {
+			continue
+		}
+		rx := float64(c.rx-prev.rx) / elapsed
+		tx := float64(c.tx-prev.tx) / elapsed
+		w.add(rx, tx)
+		totalRx += rx
+		totalTx += tx
+	}
+	m.total.add(totalRx, totalTx)
+	m.counters = counters
+	//interfaces no longer reported are dropped
+	m.windows = windows
+}
+
+//Rates returns the rates of each interface, sorted by interface name
+func (m *NetworkMeter) Rates() []NetworkRate {
+	m.RLock()
+	defer m.RUnlock()
+	rates := make([]NetworkRate, 0, len(m.windows))
+	for name, w := range m.windows {
+		rates = append(rates, w.rate(name))
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Interface < rates[j].Interface
+	})
+	return rates
+}
+
+//Total returns the rates of the container, adding up every interface
+func (m *NetworkMeter) Total() NetworkRate {
+	m.RLock()
+	defer m.RUnlock()
+	return m.total.rate(totalInterface)
+}
+
+//interfaceCounters returns the counters of each interface on the given stats
+func interfaceCounters(stats *Stats) map[string]networkCounters {
+	counters := make(map[string]networkCounters)
+	if stats.Stats != nil && len(stats.Stats.Networks) > 0 {
+		for name, n := range stats.Stats.Networks {
+			counters[name] = networkCounters{n.RxBytes, n.TxBytes}
+		}
+		return counters
+	}
+	counters[totalInterface] = networkCounters{uint64(stats.NetworkRx), uint64(stats.NetworkTx)}
+	return counters
+}
+
+func peak(values []float64) float64 {
+	var max float64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func networkStats(counters map[string][2]uint64) *Stats {
+	networks := make(map[string]types.NetworkStats)
+	for name, c := range counters {
+		networks[name] = types.NetworkStats{RxBytes: c[0], TxBytes: c[1]}
+	}
+	return &Stats{Stats: &types.StatsJSON{Networks: networks}}
+}
+
+func TestNetworkMeterRates(t *testing.T) {
+	m := NewNetworkMeter()
+	now := time.Now()
+	m.Add(now, networkStats(map[string][2]uint64{"eth0": {1000, 100}, "eth1": {0, 0}}))
+	if rates := m.Rates(); len(rates) != 0 {
+		t.Errorf("No rates expected after the first sample, got %v", rates)
+	}
+	m.Add(now.Add(2*time.Second), networkStats(map[string][2]uint64{"eth0": {3000, 300}, "eth1": {400, 0}}))
+	rates := m.Rates()
+	if len(rates) != 2 {
+		t.Fatalf("Unexpected number of rates, expected 2, got %d", len(rates))
+	}
+	expected := []NetworkRate{
+		{Interface: "eth0", Rx: 1000, Tx: 100, PeakRx: 1000, PeakTx: 100},
+		{Interface: "eth1", Rx: 200, Tx: 0, PeakRx: 200, PeakTx: 0},
+	}
+	for i, r := range rates {
+		if r != expected[i] {
+			t.Errorf("Unexpected rate at position %d, expected %v, got %v", i, expected[i], r)
+		}
+	}
+	total := m.Total()
+	if total.Rx != 1200 || total.Tx != 100 {
+		t.Errorf("Unexpected total rate: %v", total)
+	}
+}
+
+func TestNetworkMeterPeak(t *testing.T) {
+	m := NewNetworkMeter()
+	now := time.Now()
+	var rx uint64
+	m.Add(now, networkStats(map[string][2]uint64{"eth0": {rx, 0}}))
+	//a burst followed by quiet samples
+	rx += 5000
+	m.Add(now.Add(time.Second), networkStats(map[string][2]uint64{"eth0": {rx, 0}}))
+	for i := 2; i <= networkPeakSamples; i++ {
+		rx += 10
+		m.Add(now.Add(time.Duration(i)*time.Second), networkStats(map[string][2]uint64{"eth0": {rx, 0}}))
+	}
+	total := m.Total()
+	if total.Rx != 10 || total.PeakRx != 5000 {
+		t.Errorf("The burst was expected as the peak rate, got %v", total)
+	}
+	//the burst falls out of the window
+	rx += 10
+	m.Add(now.Add(time.Duration(networkPeakSamples+1)*time.Second), networkStats(map[string][2]uint64{"eth0": {rx, 0}}))
+	if total := m.Total(); total.PeakRx != 10 {
+		t.Errorf("The burst was not expected as the peak rate anymore, got %v", total)
+	}
+}
+
+func TestNetworkMeterCounterReset(t *testing.T) {
+	m := NewNetworkMeter()
+	now := time.Now()
+	m.Add(now, networkStats(map[string][2]uint64{"eth0": {100000, 100000}}))
+	m.Add(now.Add(time.Second), networkStats(map[string][2]uint64{"eth0": {100, 50}}))
+	for _, r := range append(m.Rates(), m.Total()) {
+		if r.Rx < 0 || r.Tx < 0 || r.PeakRx != 0 || r.PeakTx != 0 {
+			t.Errorf("No rate was expected after the counters were reset, got %v", r)
+		}
+	}
+	m.Add(now.Add(2*time.Second), networkStats(map[string][2]uint64{"eth0": {300, 150}}))
+	if total := m.Total(); total.Rx != 200 || total.Tx != 100 {
+		t.Errorf("Rates were expected from the new counters, got %v", total)
+	}
+}
+
+func TestNetworkMeterWithoutInterfaces(t *testing.T) {
+	m := NewNetworkMeter()
+	now := time.Now()
+	m.Add(now, &Stats{NetworkRx: 100, NetworkTx: 10})
+	m.Add(now.Add(time.Second), &Stats{NetworkRx: 600, NetworkTx: 20})
+	rates := m.Rates()
+	if len(rates) != 1 || rates[0].Interface != totalInterface {
+		t.Fatalf("Unexpected rates: %v", rates)
+	}
+	if rates[0].Rx != 500 || rates[0].Tx != 10 {
+		t.Errorf("Unexpected rate: %v", rates[0])
+	}
+}
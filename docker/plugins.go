@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	dockerAPI "github.com/docker/docker/client"
+)
+
+//ErrPluginsNotSupported is returned when the Docker daemon does not support engine plugins
+var ErrPluginsNotSupported = errors.New("plugins are not supported by this daemon")
+
+//Plugins returns the engine plugins installed on the Docker host, sorted by name
+func (daemon *DockerDaemon) Plugins() ([]*types.Plugin, error) {
+	if err := daemon.requireFeature(PluginsFeature); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	plugins, err := daemon.client.PluginList(ctx, filters.NewArgs())
+	if err != nil {
+		if pluginsNotSupported(err) {
+			return nil, ErrPluginsNotSupported
+		}
+		return nil, err
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins, nil
+}
+
+//PluginInspect returns the details of the plugin with the given name or id
+func (daemon *DockerDaemon) PluginInspect(name string) (*types.Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	plugin, _, err := daemon.client.PluginInspectWithRaw(ctx, name)
+	return plugin, err
+}
+
+//PluginEnable enables the plugin with the given name or id
+func (daemon *DockerDaemon) PluginEnable(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.PluginEnable(ctx, name, types.PluginEnableOptions{})
+}
+
+//PluginDisable disables the plugin with the given name or id
+func (daemon *DockerDaemon) PluginDisable(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.PluginDisable(ctx, name, types.PluginDisableOptions{})
+}
+
+//PluginRemove removes the plugin with the given name or id. Enabled plugins
+//are only removed if forced to.
+func (daemon *DockerDaemon) PluginRemove(name string, force bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	return daemon.client.PluginRemove(ctx, name, types.PluginRemoveOptions{Force: force})
+}
+
+//PluginName splits the name of a plugin, i.e. vieux/sshfs:latest, into its
+//name and its tag
+func PluginName(plugin *types.Plugin) (string, string) {
+	name := plugin.Name
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		return name[:i], name[i+1:]
+	}
+	return name, "latest"
+}
+
+//pluginsNotSupported returns true if the given error means that the daemon
+//knows nothing about plugins, as daemons older than API 1.25 do
+func pluginsNotSupported(err error) bool {
+	if dockerAPI.IsErrNotFound(err) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "requires API version") || strings.Contains(msg, "page not found")
+}
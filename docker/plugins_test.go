@@ -0,0 +1,71 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestPlugins(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    mock.PluginAPIClientMock
+		wantNames []string
+		wantErr   error
+	}{
+		{
+			"plugins are sorted by name",
+			mock.PluginAPIClientMock{Plugins: types.PluginsListResponse{
+				{Name: "vieux/sshfs:latest"}, {Name: "rexray/ebs:0.11.1"},
+			}},
+			[]string{"rexray/ebs:0.11.1", "vieux/sshfs:latest"},
+			nil,
+		},
+		{
+			"old daemon",
+			mock.PluginAPIClientMock{Err: errors.New(`"plugin list" requires API version 1.25, but the Docker daemon API version is 1.24`)},
+			nil,
+			ErrPluginsNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemon := DockerDaemon{client: tt.client}
+			plugins, err := daemon.Plugins()
+			if err != tt.wantErr {
+				t.Fatalf("Plugins() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(plugins) != len(tt.wantNames) {
+				t.Fatalf("Plugins() = %d plugins, want %d", len(plugins), len(tt.wantNames))
+			}
+			for i, name := range tt.wantNames {
+				if plugins[i].Name != name {
+					t.Errorf("plugin %d = %s, want %s", i, plugins[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestPluginName(t *testing.T) {
+	tests := []struct {
+		plugin   string
+		wantName string
+		wantTag  string
+	}{
+		{"vieux/sshfs:latest", "vieux/sshfs", "latest"},
+		{"rexray/ebs:0.11.1", "rexray/ebs", "0.11.1"},
+		{"localhost:5000/sshfs", "localhost:5000/sshfs", "latest"},
+		{"localhost:5000/sshfs:next", "localhost:5000/sshfs", "next"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.plugin, func(t *testing.T) {
+			name, tag := PluginName(&types.Plugin{Name: tt.plugin})
+			if name != tt.wantName || tag != tt.wantTag {
+				t.Errorf("PluginName() = %s, %s, want %s, %s", name, tag, tt.wantName, tt.wantTag)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"strconv"
+	"strings"
+)
+
+//PortFilterPrefix is the prefix of the container list filters that match
+//containers by a port, published or not (i.e. port:8080 or port:53/udp)
+const PortFilterPrefix = "port:"
+
+//HasPort returns true if the given container has the given port, on the
+//host side or on the container side. The port can be followed by its
+//protocol, i.e. 53/udp.
+func HasPort(c *Container, port string) bool {
+	proto := ""
+	if i := strings.Index(port, "/"); i >= 0 {
+		port, proto = port[:i], port[i+1:]
+	}
+	number, err := strconv.Atoi(port)
+	if err != nil {
+		return false
+	}
+	for _, p := range c.Ports {
+		if proto != "" && p.Type != proto {
+			continue
+		}
+		if int(p.PrivatePort) == number || (p.PublicPort != 0 && int(p.PublicPort) == number) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestHasPort(t *testing.T) {
+	c := &Container{Container: types.Container{Ports: []types.Port{
+		{IP: "0.0.0.0", PublicPort: 8080, PrivatePort: 80, Type: "tcp"},
+		{PrivatePort: 53, Type: "udp"},
+	}}}
+	tests := []struct {
+		port string
+		want bool
+	}{
+		{"8080", true},
+		{"80", true},
+		{"80/tcp", true},
+		{"80/udp", false},
+		{"53", true},
+		{"53/udp", true},
+		{"0", false},
+		{"443", false},
+		{"http", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			if got := HasPort(c, tt.port); got != tt.want {
+				t.Errorf("HasPort(%s) = %v, want %v", tt.port, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+)
+
+//httpsPorts are the container ports published URLs use https for
+var httpsPorts = map[uint16]bool{443: true, 8443: true}
+
+//PublishedURLs returns the URLs of the TCP ports of the given container
+//published on the host, sorted by port. Ports published on every address
+//of the host are reached through the given Docker host, localhost if the
+//daemon is local.
+func PublishedURLs(c *Container, dockerHost string) []string {
+	if c == nil {
+		return nil
+	}
+	host := publishedPortsHost(dockerHost)
+	ports := append([]types.Port(nil), c.Ports...)
+	sort.SliceStable(ports, func(i, j int) bool {
+		return ports[i].PublicPort < ports[j].PublicPort
+	})
+	seen := make(map[string]bool)
+	var urls []string
+	for _, port := range ports {
+		if port.PublicPort == 0 || (port.Type != "" && port.Type != "tcp") {
+			continue
+		}
+		ip := port.IP
+		if ip == "" || net.ParseIP(ip).IsUnspecified() {
+			ip = host
+		}
+		scheme := "http"
+		if httpsPorts[port.PrivatePort] {
+			scheme = "https"
+		}
+		u := (&url.URL{
+			Scheme: scheme,
+			Host:   net.JoinHostPort(ip, strconv.Itoa(int(port.PublicPort))),
+		}).String()
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+//publishedPortsHost returns the host that ports published on every address
+//of the given Docker host are reached through
+func publishedPortsHost(dockerHost string) string {
+	u, err := url.Parse(dockerHost)
+	if err != nil || u.Scheme != "tcp" || u.Hostname() == "" {
+		return "localhost"
+	}
+	return u.Hostname()
+}
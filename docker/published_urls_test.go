@@ -0,0 +1,35 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestPublishedURLs(t *testing.T) {
+	c := &Container{Container: types.Container{Ports: []types.Port{
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+		{IP: "::", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+		{IP: "127.0.0.1", PrivatePort: 9000, PublicPort: 9000, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 53, PublicPort: 53, Type: "udp"},
+		{PrivatePort: 6379, Type: "tcp"},
+	}}}
+	tests := []struct {
+		host string
+		want []string
+	}{
+		{"unix:///var/run/docker.sock", []string{"http://localhost:8080", "https://localhost:8443", "http://127.0.0.1:9000"}},
+		{"tcp://192.168.99.100:2376", []string{"http://192.168.99.100:8080", "https://192.168.99.100:8443", "http://127.0.0.1:9000"}},
+		{"", []string{"http://localhost:8080", "https://localhost:8443", "http://127.0.0.1:9000"}},
+	}
+	for _, tt := range tests {
+		if got := PublishedURLs(c, tt.host); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PublishedURLs(%s) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+	if got := PublishedURLs(&Container{}, ""); len(got) != 0 {
+		t.Errorf("A container without ports has no URLs, got %v", got)
+	}
+}
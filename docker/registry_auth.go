@@ -0,0 +1,137 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+//dockerHubAuthKey is the key of the Docker Hub credentials on the Docker CLI configuration
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+//cliConfig is the part of the Docker CLI configuration file about registry credentials
+type cliConfig struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+//cliConfigFile returns the path of the Docker CLI configuration file
+func cliConfigFile() string {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir, _ = homedir.Expand("~/.docker")
+	}
+	return filepath.Join(dir, "config.json")
+}
+
+//loadCLIConfig loads the Docker CLI configuration from the given file, an
+//empty configuration is returned if the file cannot be read
+func loadCLIConfig(path string) cliConfig {
+	var config cliConfig
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return config
+	}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return cliConfig{}
+	}
+	return config
+}
+
+//registryAuth returns the credentials that the Docker CLI would use to pull
+//the given image, base64 encoded as the Engine API expects them. Empty if
+//there are none.
+func (config cliConfig) registryAuth(image reference.Named) string {
+	key := registryAuthKey(image)
+	auth, ok := config.credentials(key)
+	if !ok {
+		return ""
+	}
+	auth.ServerAddress = key
+	return encodeAuth(auth)
+}
+
+//registryAuthKey returns the key of the credentials of the registry of the
+//given image on the Docker CLI configuration
+func registryAuthKey(image reference.Named) string {
+	key := reference.Domain(image)
+	if key == "docker.io" {
+		key = dockerHubAuthKey
+	}
+	return key
+}
+
+//encodeAuth returns the given credentials base64 encoded as the Engine API
+//expects them, empty if they cannot be encoded
+func encodeAuth(auth types.AuthConfig) string {
+	b, err := json.Marshal(auth)
+	if err != nil {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+//credentials returns the credentials kept for the given registry, either by a
+//credential helper or on the configuration file itself
+func (config cliConfig) credentials(registry string) (types.AuthConfig, bool) {
+	helper := config.CredHelpers[registry]
+	if helper == "" {
+		helper = config.CredsStore
+	}
+	if helper != "" {
+		return credentialHelper(helper, registry)
+	}
+	for _, key := range []string{registry, "https://" + registry, "http://" + registry} {
+		entry, ok := config.Auths[key]
+		if !ok {
+			continue
+		}
+		auth := types.AuthConfig{IdentityToken: entry.IdentityToken}
+		if decoded, err := base64.StdEncoding.DecodeString(entry.Auth); err == nil {
+			userAndPassword := strings.SplitN(string(decoded), ":", 2)
+			if len(userAndPassword) == 2 {
+				auth.Username = userAndPassword[0]
+				auth.Password = userAndPassword[1]
+			}
+		}
+		return auth, auth.Username != "" || auth.IdentityToken != ""
+	}
+	return types.AuthConfig{}, false
+}
+
+//credentialHelper asks the given Docker credential helper for the
+//credentials of the given registry
+func credentialHelper(helper, registry string) (types.AuthConfig, bool) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(registry)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return types.AuthConfig{}, false
+	}
+	var credentials struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(out.Bytes(), &credentials); err != nil {
+		return types.AuthConfig{}, false
+	}
+	//helpers return identity tokens with this user name
+	if credentials.Username == "<token>" {
+		return types.AuthConfig{IdentityToken: credentials.Secret}, true
+	}
+	return types.AuthConfig{Username: credentials.Username, Password: credentials.Secret}, true
+}
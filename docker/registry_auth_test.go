@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+)
+
+func TestRegistryAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dry-docker-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	config := `{"auths": {
+		"https://index.docker.io/v1/": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("hubuser:hubpass")) + `"},
+		"registry.example.com": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("user:pa:ss")) + `"}
+	}}`
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cliConfig := loadCLIConfig(path)
+
+	tests := []struct {
+		image string
+		want  types.AuthConfig
+	}{
+		{"nginx", types.AuthConfig{Username: "hubuser", Password: "hubpass", ServerAddress: dockerHubAuthKey}},
+		{"registry.example.com/app:1.0", types.AuthConfig{Username: "user", Password: "pa:ss", ServerAddress: "registry.example.com"}},
+		{"quay.io/coreos/etcd", types.AuthConfig{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			named, err := reference.ParseNormalizedNamed(tt.image)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded := cliConfig.registryAuth(named)
+			var got types.AuthConfig
+			if encoded != "" {
+				b, err := base64.URLEncoding.DecodeString(encoded)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := json.Unmarshal(b, &got); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("registryAuth() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
+)
+
+//DockerHub is the registry searched unless another one is given
+const DockerHub = "docker.io"
+
+//SearchLimit is the most results a registry search returns
+const SearchLimit = 50
+
+//SearchTerm returns the term to search the given registry for the given
+//text, as docker search expects it. Docker Hub is searched if no registry
+//is given, texts that already name a registry are searched on it.
+func SearchTerm(registry, text string) string {
+	text = strings.TrimSpace(text)
+	registry = strings.TrimSuffix(strings.TrimSpace(registry), "/")
+	if registry == "" || registry == DockerHub || SearchedRegistry(text) != DockerHub {
+		return text
+	}
+	return registry + "/" + text
+}
+
+//SearchedRegistry returns the registry the given term is searched on, the
+//part before the first slash if it is a host, as docker search does
+func SearchedRegistry(term string) string {
+	i := strings.Index(term, "/")
+	if i <= 0 {
+		return DockerHub
+	}
+	host := term[:i]
+	if strings.ContainsAny(host, ".:") || host == "localhost" {
+		return host
+	}
+	return DockerHub
+}
+
+//SearchImages searches the registry the given term names, Docker Hub unless
+//it starts with the host of a registry, as docker search does. The
+//credentials of the Docker CLI for the registry are used if it has them.
+func (daemon *DockerDaemon) SearchImages(ctx context.Context, term string) ([]dockerRegistry.SearchResult, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, errors.New("the term to search is required")
+	}
+	key := SearchedRegistry(term)
+	if key == DockerHub {
+		key = dockerHubAuthKey
+	}
+	var auth string
+	if credentials, ok := loadCLIConfig(cliConfigFile()).credentials(key); ok {
+		credentials.ServerAddress = key
+		auth = encodeAuth(credentials)
+	}
+	return daemon.client.ImageSearch(ctx, term, types.ImageSearchOptions{
+		RegistryAuth: auth,
+		Limit:        SearchLimit,
+	})
+}
@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	dockerRegistry "github.com/docker/docker/api/types/registry"
+	"github.com/moncho/dry/docker/mock"
+)
+
+func TestSearchImages(t *testing.T) {
+	client := &mock.ImageSearchAPIClientMock{Results: []dockerRegistry.SearchResult{
+		{Name: "nginx", StarCount: 15000, IsOfficial: true},
+		{Name: "bitnami/nginx", StarCount: 120},
+		{Name: "redis", StarCount: 11000, IsOfficial: true},
+	}}
+	daemon := &DockerDaemon{client: client, calls: newCallCoordinator()}
+
+	results, err := daemon.SearchImages(context.Background(), " nginx ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Name != "nginx" {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+	if client.Options.Limit != SearchLimit {
+		t.Errorf("Unexpected search limit: %d", client.Options.Limit)
+	}
+	if _, err := daemon.SearchImages(context.Background(), " "); err == nil {
+		t.Error("An empty term was searched")
+	}
+}
+
+func TestSearchTerm(t *testing.T) {
+	tests := []struct {
+		registry, text, term, searched string
+	}{
+		{"", "nginx", "nginx", DockerHub},
+		{DockerHub, "nginx", "nginx", DockerHub},
+		{"registry.example.com", " nginx", "registry.example.com/nginx", "registry.example.com"},
+		{"localhost:5000/", "nginx", "localhost:5000/nginx", "localhost:5000"},
+		{"registry.example.com", "bitnami/nginx", "registry.example.com/bitnami/nginx", "registry.example.com"},
+		{"registry.example.com", "quay.io/nginx", "quay.io/nginx", "quay.io"},
+	}
+	for _, test := range tests {
+		term := SearchTerm(test.registry, test.text)
+		if term != test.term {
+			t.Errorf("SearchTerm(%q, %q) = %q, want %q", test.registry, test.text, term, test.term)
+		}
+		if searched := SearchedRegistry(term); searched != test.searched {
+			t.Errorf("SearchedRegistry(%q) = %q, want %q", term, searched, test.searched)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+//RestartPolicyFilterPrefix is the prefix of the container list filters that
+//match containers by their restart policy name (i.e. restart:always)
+const RestartPolicyFilterPrefix = "restart:"
+
+//RestartPolicy returns the restart policy of the given container, containers
+//with no policy get the "no" policy
+func RestartPolicy(c *Container) container.RestartPolicy {
+	var policy container.RestartPolicy
+	if c != nil && c.ContainerJSONBase != nil && c.ContainerJSON.HostConfig != nil {
+		policy = c.ContainerJSON.HostConfig.RestartPolicy
+	}
+	if policy.Name == "" {
+		policy.Name = "no"
+	}
+	return policy
+}
+
+//RestartCount returns how many times the daemon has restarted the given
+//container, 0 if it was not inspected
+func RestartCount(c *Container) int {
+	if c == nil || c.ContainerJSONBase == nil {
+		return 0
+	}
+	return c.ContainerJSONBase.RestartCount
+}
+
+//FormatRestartPolicy returns the given policy as docker run --restart expects
+//it, on-failure policies with a maximum retry count are shown as on-failure:count
+func FormatRestartPolicy(policy container.RestartPolicy) string {
+	if policy.Name == "" {
+		return "no"
+	}
+	if policy.IsOnFailure() && policy.MaximumRetryCount > 0 {
+		return policy.Name + ":" + strconv.Itoa(policy.MaximumRetryCount)
+	}
+	return policy.Name
+}
+
+//UpdateRestartPolicy changes the restart policy of the container with the given
+//id, the policy is given as docker run --restart expects it. The container is
+//updated in place, the returned container has the new policy.
+func (daemon *DockerDaemon) UpdateRestartPolicy(id string, policy string) (*Container, error) {
+	restartPolicy, err := parseRestartPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	if restartPolicy.Name == "" {
+		restartPolicy.Name = "no"
+	}
+	return daemon.update(id, container.UpdateConfig{RestartPolicy: restartPolicy})
+}
@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestRestartPolicy(t *testing.T) {
+	withPolicy := func(policy container.RestartPolicy) *Container {
+		return &Container{
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					HostConfig: &container.HostConfig{RestartPolicy: policy},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		container *Container
+		want      string
+	}{
+		{"not inspected", &Container{}, "no"},
+		{"no policy", withPolicy(container.RestartPolicy{}), "no"},
+		{"always", withPolicy(container.RestartPolicy{Name: "always"}), "always"},
+		{"unless stopped", withPolicy(container.RestartPolicy{Name: "unless-stopped"}), "unless-stopped"},
+		{"on failure", withPolicy(container.RestartPolicy{Name: "on-failure"}), "on-failure"},
+		{"on failure with retries", withPolicy(container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 5}), "on-failure:5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatRestartPolicy(RestartPolicy(tt.container)); got != tt.want {
+				t.Errorf("FormatRestartPolicy(RestartPolicy()) = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestartCount(t *testing.T) {
+	if got := RestartCount(&Container{}); got != 0 {
+		t.Errorf("RestartCount() of a container not inspected = %d, want 0", got)
+	}
+	c := &Container{
+		ContainerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{RestartCount: 7},
+		},
+	}
+	if got := RestartCount(c); got != 7 {
+		t.Errorf("RestartCount() = %d, want 7", got)
+	}
+}
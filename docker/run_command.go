@@ -0,0 +1,232 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+//safeShellWord matches the words that the shell reads as they are
+var safeShellWord = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+
+//ShellQuote quotes the given word so that a POSIX shell reads it as a
+//single word with the same value
+func ShellQuote(word string) string {
+	if safeShellWord.MatchString(word) {
+		return word
+	}
+	return "'" + strings.Replace(word, "'", `'\''`, -1) + "'"
+}
+
+//RunCommand returns a docker run command line that creates a container like
+//the given one. The values the container got from its image, if given, are
+//left out. What docker run cannot reproduce, i.e. devices or being attached
+//to more than one network, is written as comments before the command.
+func RunCommand(c types.ContainerJSON, image *types.ImageInspect) string {
+	var comments []string
+	var imageConfig container.Config
+	if image != nil && image.Config != nil {
+		imageConfig = *image.Config
+	}
+	config := c.Config
+	if config == nil {
+		config = &container.Config{}
+	}
+
+	args := [][]string{{"docker", "run", "-d"}}
+	option := func(values ...string) {
+		args = append(args, values)
+	}
+	name := ""
+	if c.ContainerJSONBase != nil {
+		name = strings.TrimPrefix(c.Name, "/")
+	}
+	if name != "" {
+		option("--name", name)
+	}
+	if config.OpenStdin {
+		option("-i")
+	}
+	if config.Tty {
+		option("-t")
+	}
+	if config.User != "" && config.User != imageConfig.User {
+		option("--user", config.User)
+	}
+	if config.WorkingDir != "" && config.WorkingDir != imageConfig.WorkingDir {
+		option("--workdir", config.WorkingDir)
+	}
+	imageEnv := make(map[string]bool)
+	for _, variable := range imageConfig.Env {
+		imageEnv[variable] = true
+	}
+	for _, variable := range config.Env {
+		if !imageEnv[variable] {
+			option("-e", variable)
+		}
+	}
+	for _, label := range sortedKeys(config.Labels) {
+		if value, ok := imageConfig.Labels[label]; !ok || value != config.Labels[label] {
+			option("--label", label+"="+config.Labels[label])
+		}
+	}
+
+	var hc *container.HostConfig
+	if c.ContainerJSONBase != nil {
+		hc = c.HostConfig
+	}
+	if hc != nil {
+		var ports []string
+		for port, bindings := range hc.PortBindings {
+			for _, binding := range bindings {
+				ports = append(ports, portMapping(port, binding))
+			}
+		}
+		sort.Strings(ports)
+		for _, port := range ports {
+			option("-p", port)
+		}
+		for _, bind := range hc.Binds {
+			option("-v", bind)
+		}
+		for _, m := range hc.Mounts {
+			mount := fmt.Sprintf("type=%s,source=%s,target=%s", m.Type, m.Source, m.Target)
+			if m.Source == "" {
+				mount = fmt.Sprintf("type=%s,target=%s", m.Type, m.Target)
+			}
+			if m.ReadOnly {
+				mount += ",readonly"
+			}
+			option("--mount", mount)
+		}
+		if mode := string(hc.NetworkMode); mode != "" && mode != "default" && mode != "bridge" {
+			option("--network", mode)
+		}
+		if policy := FormatRestartPolicy(hc.RestartPolicy); policy != "no" {
+			option("--restart", policy)
+		}
+		if hc.AutoRemove {
+			option("--rm")
+		}
+		if hc.Privileged {
+			option("--privileged")
+		}
+		for _, capability := range hc.CapAdd {
+			option("--cap-add", capability)
+		}
+		for _, capability := range hc.CapDrop {
+			option("--cap-drop", capability)
+		}
+		for _, host := range hc.ExtraHosts {
+			option("--add-host", host)
+		}
+		for _, dns := range hc.DNS {
+			option("--dns", dns)
+		}
+		if hc.Memory > 0 {
+			option("--memory", formatBytes(hc.Memory))
+		}
+		if hc.MemoryReservation > 0 {
+			option("--memory-reservation", formatBytes(hc.MemoryReservation))
+		}
+		if hc.MemorySwap > 0 {
+			option("--memory-swap", formatBytes(hc.MemorySwap))
+		} else if hc.MemorySwap == -1 {
+			option("--memory-swap", "-1")
+		}
+		if hc.NanoCPUs > 0 {
+			option("--cpus", strconv.FormatFloat(float64(hc.NanoCPUs)/1e9, 'f', -1, 64))
+		}
+		if hc.CPUShares > 0 {
+			option("--cpu-shares", strconv.FormatInt(hc.CPUShares, 10))
+		}
+		if hc.CpusetCpus != "" {
+			option("--cpuset-cpus", hc.CpusetCpus)
+		}
+		for _, device := range hc.Devices {
+			comments = append(comments, fmt.Sprintf("device %s:%s:%s is not mapped, check it exists on this host and add --device",
+				device.PathOnHost, device.PathInContainer, device.CgroupPermissions))
+		}
+	}
+	if c.NetworkSettings != nil && len(c.NetworkSettings.Networks) > 1 {
+		networks := make([]string, 0, len(c.NetworkSettings.Networks))
+		for network := range c.NetworkSettings.Networks {
+			networks = append(networks, network)
+		}
+		sort.Strings(networks)
+		comments = append(comments, fmt.Sprintf("the container is attached to networks %s, docker run attaches it to one, connect the others with docker network connect",
+			strings.Join(networks, ", ")))
+	}
+
+	entrypoint := []string(config.Entrypoint)
+	cmd := []string(config.Cmd)
+	if len(entrypoint) > 0 && !equalStrings(entrypoint, imageConfig.Entrypoint) {
+		//--entrypoint takes a single executable, its arguments go before the command
+		option("--entrypoint", entrypoint[0])
+		cmd = append(append([]string{}, entrypoint[1:]...), cmd...)
+	} else if equalStrings(cmd, imageConfig.Cmd) {
+		cmd = nil
+	}
+	args = append(args, append([]string{config.Image}, cmd...))
+
+	var b bytes.Buffer
+	for _, comment := range comments {
+		b.WriteString("# " + comment + "\n")
+	}
+	for i, words := range args {
+		quoted := make([]string, len(words))
+		for j, word := range words {
+			quoted[j] = ShellQuote(word)
+		}
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		b.WriteString(strings.Join(quoted, " "))
+		if i < len(args)-1 {
+			b.WriteString(" \\")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+//formatBytes formats the given amount of bytes as docker run reads them,
+//using the largest unit it can be exactly given with
+func formatBytes(n int64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"g", 1 << 30}, {"m", 1 << 20}, {"k", 1 << 10}} {
+		if n%unit.size == 0 {
+			return strconv.FormatInt(n/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func readInspectFixture(t *testing.T, name string, v interface{}) {
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "run_command", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//shellWords runs the given command line with a shell, docker being a
+//function that prints its arguments, and returns the arguments docker gets
+func shellWords(t *testing.T, command string) []string {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no shell to run the command with")
+	}
+	script := `docker() { for arg in "$@"; do printf '%s\0' "$arg"; done; }` + "\n" + command
+	out, err := exec.Command(sh, "-c", script).Output()
+	if err != nil {
+		t.Fatalf("The shell could not run the command: %v\n%s", err, command)
+	}
+	return strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+}
+
+func TestRunCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		container    string
+		image        string
+		wantArgs     []string
+		wantComments []string
+	}{
+		{
+			"image values left out", "web", "web_image",
+			[]string{"run", "-d", "--name", "web",
+				"-e", "SERVER_NAME=example.com www.example.com",
+				"--label", "tier=frontend",
+				"-p", "127.0.0.1:8443:443", "-p", "8080:80",
+				"-v", "/srv/www:/usr/share/nginx/html:ro",
+				"--restart", "unless-stopped",
+				"--memory", "256m", "--cpus", "1.5",
+				"nginx:1.15-alpine"},
+			nil,
+		},
+		{
+			"image not known", "web", "",
+			[]string{"run", "-d", "--name", "web",
+				"-e", "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+				"-e", "NGINX_VERSION=1.15.0",
+				"-e", "SERVER_NAME=example.com www.example.com",
+				"--label", "maintainer=NGINX Docker Maintainers",
+				"--label", "tier=frontend",
+				"-p", "127.0.0.1:8443:443", "-p", "8080:80",
+				"-v", "/srv/www:/usr/share/nginx/html:ro",
+				"--restart", "unless-stopped",
+				"--memory", "256m", "--cpus", "1.5",
+				"nginx:1.15-alpine", "nginx", "-g", "daemon off;"},
+			nil,
+		},
+		{
+			"quoting, entrypoint and what docker run cannot do", "worker", "",
+			[]string{"run", "-d", "--name", "worker", "-i", "-t",
+				"--user", "1000:1000", "--workdir", "/app",
+				"-e", `GREETING=it's a "test"`,
+				"-e", "PRICE=$5 `date`",
+				"-e", "MULTILINE=first\nsecond",
+				"-e", "EMPTY=",
+				"--mount", "type=volume,source=jobs-data,target=/data",
+				"--mount", "type=tmpfs,target=/scratch",
+				"--network", "backend",
+				"--restart", "on-failure:5",
+				"--cap-add", "NET_ADMIN",
+				"--add-host", "db.local:10.0.0.5",
+				"--memory", "512m", "--memory-swap", "-1",
+				"--cpuset-cpus", "0,1",
+				"--entrypoint", "/bin/sh",
+				"registry.example.com/jobs/worker:2.1",
+				"-c", "echo $GREETING && exec worker --queue 'high priority'"},
+			[]string{"/dev/fuse:/dev/fuse:rwm", "backend, monitoring"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c types.ContainerJSON
+			readInspectFixture(t, tt.container, &c)
+			var image *types.ImageInspect
+			if tt.image != "" {
+				image = &types.ImageInspect{}
+				readInspectFixture(t, tt.image, image)
+			}
+			command := RunCommand(c, image)
+
+			var comments []string
+			for _, line := range strings.Split(command, "\n") {
+				if strings.HasPrefix(line, "#") {
+					comments = append(comments, line)
+				}
+			}
+			if len(comments) != len(tt.wantComments) {
+				t.Errorf("Unexpected comments, got %q, want comments about %q", comments, tt.wantComments)
+			}
+			for i := 0; i < len(comments) && i < len(tt.wantComments); i++ {
+				if !strings.Contains(comments[i], tt.wantComments[i]) {
+					t.Errorf("Comment %q does not tell about %q", comments[i], tt.wantComments[i])
+				}
+			}
+
+			words := shellWords(t, command)
+			if words[0] != "run" {
+				t.Fatalf("The command does not run docker:\n%s", command)
+			}
+			if !reflect.DeepEqual(words, tt.wantArgs) {
+				t.Errorf("The shell read different arguments than expected\ngot:  %q\nwant: %q\ncommand:\n%s", words, tt.wantArgs, command)
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	words := []string{
+		"plain", "", "two words", "it's", `"double"`, "$HOME", "`id`", "a\\b",
+		"line\nbreak", "tab\there", "*", "~user", "a;b", "!bang", "'''",
+	}
+	command := "docker"
+	for _, word := range words {
+		command += " " + ShellQuote(word)
+	}
+	if got := shellWords(t, command); !reflect.DeepEqual(got, words) {
+		t.Errorf("The shell read different words than quoted\ngot:  %q\nwant: %q", got, words)
+	}
+}
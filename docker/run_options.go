@@ -0,0 +1,334 @@
+package docker
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	pkgError "github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+//Fields of RunOptions, as shown to the user
+const (
+	RunFieldImage         = "image"
+	RunFieldName          = "name"
+	RunFieldCommand       = "command"
+	RunFieldPorts         = "ports"
+	RunFieldEnv           = "env"
+	RunFieldBinds         = "binds"
+	RunFieldNetwork       = "network"
+	RunFieldRestartPolicy = "restart"
+	RunFieldMode          = "mode"
+)
+
+//Run modes
+const (
+	RunDetached    = "detached"
+	RunInteractive = "interactive"
+)
+
+//RestartPolicies are the restart policies a container can be run with,
+//on-failure also accepts a maximum retry count (i.e. on-failure:3)
+var RestartPolicies = []string{"no", "always", "unless-stopped", "on-failure"}
+
+var (
+	containerNameRegexp = regexp.MustCompile(`^/?[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+	volumeNameRegexp    = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	envKeyRegexp        = regexp.MustCompile(`^[^=\s]+$`)
+	bindModes           = map[string]bool{
+		"ro": true, "rw": true, "z": true, "Z": true, "nocopy": true,
+		"shared": true, "rshared": true, "slave": true, "rslave": true,
+		"private": true, "rprivate": true,
+		"cached": true, "delegated": true, "consistent": true,
+	}
+)
+
+//RunOptions describes a container to be created and started, as docker run does
+type RunOptions struct {
+	Image         string
+	Name          string
+	Command       string
+	Ports         []string
+	Env           []string
+	Binds         []string
+	Network       string
+	RestartPolicy string
+	Interactive   bool
+}
+
+//RunOptionError is an error caused by the value of a RunOptions field
+type RunOptionError struct {
+	Field string
+	Err   error
+}
+
+func (e *RunOptionError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Err.Error())
+}
+
+//Validate checks the values of the given options, the error returned is a
+//*RunOptionError for the first field with an invalid value
+func (o RunOptions) Validate() error {
+	checks := []struct {
+		field string
+		err   error
+	}{
+		{RunFieldImage, ValidateImageName(o.Image)},
+		{RunFieldName, ValidateContainerName(o.Name)},
+		{RunFieldPorts, ValidatePorts(o.Ports)},
+		{RunFieldEnv, ValidateEnv(o.Env)},
+		{RunFieldBinds, ValidateBinds(o.Binds)},
+		{RunFieldRestartPolicy, ValidateRestartPolicy(o.RestartPolicy)},
+	}
+	for _, check := range checks {
+		if check.err != nil {
+			return &RunOptionError{check.field, check.err}
+		}
+	}
+	return nil
+}
+
+//ValidateImageName checks that an image name was given
+func ValidateImageName(image string) error {
+	if strings.TrimSpace(image) == "" {
+		return pkgError.New("an image is required")
+	}
+	if strings.ContainsAny(image, " \t") {
+		return pkgError.New("image names cannot contain spaces")
+	}
+	return nil
+}
+
+//ValidateContainerName checks that the given name, if any, is a valid container name
+func ValidateContainerName(name string) error {
+	if name != "" && !containerNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid name %q, only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", name)
+	}
+	return nil
+}
+
+//ValidatePorts checks that the given port mappings follow the docker run
+//syntax, i.e. 8080:80, 127.0.0.1:53:53/udp or 80
+func ValidatePorts(ports []string) error {
+	for _, port := range ports {
+		if _, err := nat.ParsePortSpec(port); err != nil {
+			return fmt.Errorf("invalid port mapping %q: %s", port, err.Error())
+		}
+	}
+	return nil
+}
+
+//ValidateEnv checks that the given environment variables are KEY=value pairs
+func ValidateEnv(env []string) error {
+	for _, variable := range env {
+		kv := strings.SplitN(variable, "=", 2)
+		if len(kv) != 2 || !envKeyRegexp.MatchString(kv[0]) {
+			return fmt.Errorf("invalid variable %q, use KEY=value", variable)
+		}
+	}
+	return nil
+}
+
+//ValidateBinds checks that the given volume binds follow the docker run syntax,
+//that is "source:destination[:mode]", where source is an absolute host path or
+//a volume name and destination an absolute path in the container
+func ValidateBinds(binds []string) error {
+	for _, bind := range binds {
+		parts := strings.Split(bind, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return fmt.Errorf("invalid bind %q, use source:destination[:mode]", bind)
+		}
+		source, destination := parts[0], parts[1]
+		if !path.IsAbs(source) && !volumeNameRegexp.MatchString(source) {
+			return fmt.Errorf("invalid bind %q, the source must be an absolute path or a volume name", bind)
+		}
+		if !path.IsAbs(destination) {
+			return fmt.Errorf("invalid bind %q, the destination must be an absolute path", bind)
+		}
+		if len(parts) == 3 && !bindModes[parts[2]] {
+			return fmt.Errorf("invalid bind %q, unknown mode %q", bind, parts[2])
+		}
+	}
+	return nil
+}
+
+//ValidateRestartPolicy checks that the given restart policy, if any, is one
+//of RestartPolicies
+func ValidateRestartPolicy(policy string) error {
+	_, err := parseRestartPolicy(policy)
+	return err
+}
+
+func parseRestartPolicy(policy string) (container.RestartPolicy, error) {
+	var restartPolicy container.RestartPolicy
+	if policy == "" {
+		return restartPolicy, nil
+	}
+	parts := strings.SplitN(policy, ":", 2)
+	restartPolicy.Name = parts[0]
+	switch parts[0] {
+	case "no", "always", "unless-stopped":
+		if len(parts) == 2 {
+			return restartPolicy, fmt.Errorf("restart policy %q does not accept a retry count", parts[0])
+		}
+	case "on-failure":
+		if len(parts) == 2 {
+			count, err := strconv.Atoi(parts[1])
+			if err != nil || count < 0 {
+				return restartPolicy, fmt.Errorf("invalid retry count %q", parts[1])
+			}
+			restartPolicy.MaximumRetryCount = count
+		}
+	default:
+		return restartPolicy, fmt.Errorf("unknown restart policy %q, use one of %s",
+			policy, strings.Join(RestartPolicies, ", "))
+	}
+	return restartPolicy, nil
+}
+
+//configs returns the container and host configuration for these options
+func (o RunOptions) configs() (container.Config, container.HostConfig, error) {
+	if err := o.Validate(); err != nil {
+		return container.Config{}, container.HostConfig{}, err
+	}
+	cc, hc, err := newCCB().image(o.Image).command(o.Command).build()
+	if err != nil {
+		return cc, hc, err
+	}
+	exposed, bindings, err := nat.ParsePortSpecs(o.Ports)
+	if err != nil {
+		return cc, hc, &RunOptionError{RunFieldPorts, err}
+	}
+	if len(exposed) > 0 {
+		cc.ExposedPorts = exposed
+		hc.PortBindings = bindings
+	}
+	cc.Env = o.Env
+	hc.Binds = o.Binds
+	if o.Network != "" {
+		hc.NetworkMode = container.NetworkMode(o.Network)
+	}
+	hc.RestartPolicy, _ = parseRestartPolicy(o.RestartPolicy)
+	if o.Interactive {
+		cc.Tty = true
+		cc.OpenStdin = true
+		cc.AttachStdin = true
+		cc.AttachStdout = true
+		cc.AttachStderr = true
+	}
+	return cc, hc, nil
+}
+
+//RunContainer creates and starts a container with the given options, the id of
+//the new container is returned. Errors caused by a field of the options are
+//returned as a *RunOptionError.
+func (daemon *DockerDaemon) RunContainer(options RunOptions) (string, error) {
+	cc, hc, err := options.configs()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+
+	created, err := daemon.client.ContainerCreate(ctx, &cc, &hc, nil, options.Name)
+	if err != nil {
+		return "", &RunOptionError{runErrorField(err), err}
+	}
+	if err := daemon.client.ContainerStart(ctx, created.ID, dockerTypes.ContainerStartOptions{}); err != nil {
+		return created.ID, &RunOptionError{runErrorField(err), err}
+	}
+	return created.ID, daemon.refreshAndWait()
+}
+
+//runErrorFields are fragments of the errors returned by the daemon when
+//creating or starting a container and the field that causes them
+var runErrorFields = []struct {
+	fragment string
+	field    string
+}{
+	{"No such image", RunFieldImage},
+	{"pull access denied", RunFieldImage},
+	{"invalid reference format", RunFieldImage},
+	{"container name", RunFieldName},
+	{"Invalid container name", RunFieldName},
+	{"port is already allocated", RunFieldPorts},
+	{"address already in use", RunFieldPorts},
+	{"Bind for", RunFieldPorts},
+	{"network", RunFieldNetwork},
+	{"mount", RunFieldBinds},
+	{"bind source path", RunFieldBinds},
+	{"volume", RunFieldBinds},
+	{"restart policy", RunFieldRestartPolicy},
+	{"RestartPolicy", RunFieldRestartPolicy},
+	{"executable file not found", RunFieldCommand},
+	{"exec:", RunFieldCommand},
+}
+
+//runErrorField returns the field that causes the given daemon error, empty
+//if it is not known
+func runErrorField(err error) string {
+	message := err.Error()
+	for _, f := range runErrorFields {
+		if strings.Contains(message, f.fragment) {
+			return f.field
+		}
+	}
+	return ""
+}
+
+//RunOptionsFromContainer returns the options to run a container like the
+//given one. The name is left empty as it must be unique.
+func RunOptionsFromContainer(c dockerTypes.ContainerJSON) RunOptions {
+	var options RunOptions
+	if c.Config != nil {
+		options.Image = c.Config.Image
+		options.Command = strings.Join(c.Config.Cmd, " ")
+		options.Env = c.Config.Env
+		options.Interactive = c.Config.Tty && c.Config.OpenStdin
+	}
+	if c.ContainerJSONBase == nil || c.HostConfig == nil {
+		return options
+	}
+	hc := c.HostConfig
+	for port, bindings := range hc.PortBindings {
+		for _, binding := range bindings {
+			options.Ports = append(options.Ports, portMapping(port, binding))
+		}
+	}
+	sort.Strings(options.Ports)
+	options.Binds = hc.Binds
+	if mode := string(hc.NetworkMode); mode != "default" {
+		options.Network = mode
+	}
+	if name := hc.RestartPolicy.Name; name != "" && name != "no" {
+		options.RestartPolicy = name
+		if name == "on-failure" && hc.RestartPolicy.MaximumRetryCount > 0 {
+			options.RestartPolicy += ":" + strconv.Itoa(hc.RestartPolicy.MaximumRetryCount)
+		}
+	}
+	return options
+}
+
+func portMapping(port nat.Port, binding nat.PortBinding) string {
+	mapping := port.Port()
+	if port.Proto() != "tcp" {
+		mapping += "/" + port.Proto()
+	}
+	if binding.HostPort != "" || binding.HostIP != "" {
+		mapping = binding.HostPort + ":" + mapping
+	}
+	if binding.HostIP != "" {
+		mapping = binding.HostIP + ":" + mapping
+	}
+	return mapping
+}
@@ -0,0 +1,196 @@
+package docker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+)
+
+func TestRunOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   RunOptions
+		wantField string
+	}{
+		{
+			"only the image is given -> valid",
+			RunOptions{Image: "nginx"},
+			"",
+		},
+		{
+			"every field is given -> valid",
+			RunOptions{
+				Image:         "nginx:latest",
+				Name:          "web_1",
+				Ports:         []string{"8080:80", "127.0.0.1:53:53/udp", "443"},
+				Env:           []string{"KEY=value", "EMPTY="},
+				Binds:         []string{"/srv/www:/usr/share/nginx/html:ro", "data:/data"},
+				Network:       "bridge",
+				RestartPolicy: "on-failure:3",
+			},
+			"",
+		},
+		{
+			"no image -> image error",
+			RunOptions{},
+			RunFieldImage,
+		},
+		{
+			"invalid name -> name error",
+			RunOptions{Image: "nginx", Name: "-web"},
+			RunFieldName,
+		},
+		{
+			"invalid port -> ports error",
+			RunOptions{Image: "nginx", Ports: []string{"8080:http"}},
+			RunFieldPorts,
+		},
+		{
+			"variable without value -> env error",
+			RunOptions{Image: "nginx", Env: []string{"KEY"}},
+			RunFieldEnv,
+		},
+		{
+			"relative bind destination -> binds error",
+			RunOptions{Image: "nginx", Binds: []string{"/srv:srv"}},
+			RunFieldBinds,
+		},
+		{
+			"relative bind source -> binds error",
+			RunOptions{Image: "nginx", Binds: []string{"./srv:/srv"}},
+			RunFieldBinds,
+		},
+		{
+			"unknown bind mode -> binds error",
+			RunOptions{Image: "nginx", Binds: []string{"/srv:/srv:rx"}},
+			RunFieldBinds,
+		},
+		{
+			"unknown restart policy -> restart error",
+			RunOptions{Image: "nginx", RestartPolicy: "sometimes"},
+			RunFieldRestartPolicy,
+		},
+		{
+			"retry count on a policy that does not accept it -> restart error",
+			RunOptions{Image: "nginx", RestartPolicy: "always:3"},
+			RunFieldRestartPolicy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("RunOptions.Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			runErr, ok := err.(*RunOptionError)
+			if !ok {
+				t.Fatalf("RunOptions.Validate() error = %v, want a RunOptionError", err)
+			}
+			if runErr.Field != tt.wantField {
+				t.Errorf("RunOptions.Validate() error field = %s, want %s", runErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestRunOptions_configs(t *testing.T) {
+	options := RunOptions{
+		Image:         "nginx",
+		Command:       "nginx -g daemon",
+		Ports:         []string{"8080:80"},
+		Env:           []string{"KEY=value"},
+		Binds:         []string{"/srv:/srv:ro"},
+		Network:       "host",
+		RestartPolicy: "on-failure:2",
+		Interactive:   true,
+	}
+	cc, hc, err := options.configs()
+	if err != nil {
+		t.Fatalf("RunOptions.configs() unexpected error = %v", err)
+	}
+	if cc.Image != "nginx" || len(cc.Cmd) != 3 {
+		t.Errorf("Unexpected image or command: %s %v", cc.Image, cc.Cmd)
+	}
+	if _, ok := cc.ExposedPorts["80/tcp"]; !ok {
+		t.Errorf("Port 80 is not exposed: %v", cc.ExposedPorts)
+	}
+	if bindings := hc.PortBindings["80/tcp"]; len(bindings) != 1 || bindings[0].HostPort != "8080" {
+		t.Errorf("Unexpected port bindings: %v", hc.PortBindings)
+	}
+	if !reflect.DeepEqual(cc.Env, options.Env) || !reflect.DeepEqual(hc.Binds, options.Binds) {
+		t.Errorf("Unexpected env or binds: %v %v", cc.Env, hc.Binds)
+	}
+	if hc.NetworkMode != "host" {
+		t.Errorf("Unexpected network mode: %s", hc.NetworkMode)
+	}
+	if hc.RestartPolicy != (container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 2}) {
+		t.Errorf("Unexpected restart policy: %v", hc.RestartPolicy)
+	}
+	if !cc.Tty || !cc.OpenStdin {
+		t.Error("Interactive containers must have a tty and an open stdin")
+	}
+}
+
+func TestRunErrorField(t *testing.T) {
+	tests := []struct {
+		err  string
+		want string
+	}{
+		{`Error: No such image: nginx:nope`, RunFieldImage},
+		{`Conflict. The container name "/web" is already in use by container "abc"`, RunFieldName},
+		{`driver failed programming external connectivity on endpoint web: Bind for 0.0.0.0:8080 failed: port is already allocated`, RunFieldPorts},
+		{`network nope not found`, RunFieldNetwork},
+		{`invalid mount config for type "bind": bind source path does not exist: /nope`, RunFieldBinds},
+		{`something else went wrong`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			if got := runErrorField(errors.New(tt.err)); got != tt.want {
+				t.Errorf("runErrorField() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunOptionsFromContainer(t *testing.T) {
+	c := dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				PortBindings: nat.PortMap{
+					"80/tcp": []nat.PortBinding{{HostPort: "8080"}},
+					"53/udp": []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: "53"}},
+				},
+				Binds:         []string{"/srv:/srv:ro"},
+				NetworkMode:   "default",
+				RestartPolicy: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 5},
+			},
+		},
+		Config: &container.Config{
+			Image: "nginx",
+			Cmd:   []string{"nginx", "-g", "daemon"},
+			Env:   []string{"KEY=value"},
+		},
+	}
+	want := RunOptions{
+		Image:         "nginx",
+		Command:       "nginx -g daemon",
+		Ports:         []string{"127.0.0.1:53:53/udp", "8080:80"},
+		Env:           []string{"KEY=value"},
+		Binds:         []string{"/srv:/srv:ro"},
+		RestartPolicy: "on-failure:5",
+	}
+	got := RunOptionsFromContainer(c)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RunOptionsFromContainer() = %+v, want %+v", got, want)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("Options from a container are not valid: %v", err)
+	}
+}
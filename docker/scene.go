@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+//scrubbed replaces the values that are not exported with a scene
+const scrubbed = "<scrubbed>"
+
+//Scene is what dry was showing at some point: the containers, images and
+//networks of the daemon, how their lists were sorted and filtered and the
+//size of the terminal. Scenes are saved to reproduce rendering problems
+//without access to the daemon they were taken from.
+type Scene struct {
+	Taken      time.Time
+	DryVersion string
+	Width      int
+	Height     int
+	//View is the list on screen when the scene was taken, if it was one
+	View       string
+	Containers []SceneContainer
+	Images     []types.ImageSummary
+	Networks   []types.NetworkResource
+	//Lists has how each list was sorted and filtered, by view
+	Lists map[string]SceneList
+}
+
+//SceneContainer is a container of a scene. The summary and the details of
+//a container are kept apart, as their fields collide once encoded.
+type SceneContainer struct {
+	Summary types.Container
+	Details *types.ContainerJSON `json:",omitempty"`
+}
+
+//SceneList is how a list was sorted and filtered
+type SceneList struct {
+	Sort         SortMode
+	Filter       string
+	ShowAll      bool `json:",omitempty"`
+	DanglingOnly bool `json:",omitempty"`
+}
+
+//NewSceneContainer creates the SceneContainer of the given container
+func NewSceneContainer(c *Container) SceneContainer {
+	sc := SceneContainer{Summary: c.Container}
+	if c.ContainerJSONBase != nil {
+		details := c.ContainerJSON
+		sc.Details = &details
+	}
+	return sc
+}
+
+//Container returns the container of this SceneContainer
+func (sc SceneContainer) Container() *Container {
+	c := &Container{Container: sc.Summary}
+	if sc.Details != nil {
+		c.ContainerJSON = *sc.Details
+	}
+	return c
+}
+
+//ReadScene reads the scene saved on the given file
+func ReadScene(path string) (*Scene, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scene Scene
+	if err := json.Unmarshal(b, &scene); err != nil {
+		return nil, err
+	}
+	return &scene, nil
+}
+
+//WriteScene writes the given scene as JSON. The values of the environment
+//variables of its containers are scrubbed, they often hold secrets.
+func WriteScene(w io.Writer, scene Scene) error {
+	containers := make([]SceneContainer, len(scene.Containers))
+	for i, c := range scene.Containers {
+		containers[i] = scrubContainer(c)
+	}
+	scene.Containers = containers
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(scene)
+}
+
+//scrubContainer returns a copy of the given container without the values
+//of its environment variables, the given one is left as it is
+func scrubContainer(c SceneContainer) SceneContainer {
+	if c.Details == nil || c.Details.Config == nil {
+		return c
+	}
+	details := *c.Details
+	config := *details.Config
+	config.Env = scrubEnv(config.Env)
+	details.Config = &config
+	c.Details = &details
+	return c
+}
+
+func scrubEnv(env []string) []string {
+	if env == nil {
+		return nil
+	}
+	result := make([]string, len(env))
+	for i, variable := range env {
+		//variables without a value are passed from the environment of the client
+		if parts := strings.SplitN(variable, "=", 2); len(parts) == 2 {
+			variable = parts[0] + "=" + scrubbed
+		}
+		result[i] = variable
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestWriteScene(t *testing.T) {
+	c := &Container{
+		Container: types.Container{ID: "c1", Names: []string{"/web"}, State: "running", Mounts: []types.MountPoint{{Destination: "/data"}}},
+		ContainerJSON: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{ID: "c1", State: &types.ContainerState{Running: true}},
+			Config:            &container.Config{Env: []string{"PASSWORD=hunter2", "DEBUG"}},
+		},
+	}
+	scene := Scene{
+		Width:      120,
+		Height:     40,
+		View:       "containers",
+		Containers: []SceneContainer{NewSceneContainer(c)},
+		Lists:      map[string]SceneList{"containers": {Sort: SortByName, Filter: "web", ShowAll: true}},
+	}
+	var buf bytes.Buffer
+	if err := WriteScene(&buf, scene); err != nil {
+		t.Fatalf("Error writing the scene: %s", err)
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("The value of an environment variable was exported:\n%s", buf.String())
+	}
+	if c.Config.Env[0] != "PASSWORD=hunter2" {
+		t.Errorf("Writing the scene changed the container, env is %v", c.Config.Env)
+	}
+
+	path := filepath.Join(os.TempDir(), "dry-scene-test.json")
+	defer os.Remove(path)
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadScene(path)
+	if err != nil {
+		t.Fatalf("Error reading the scene: %s", err)
+	}
+	if read.Width != 120 || read.Height != 40 || read.View != "containers" || read.Lists["containers"] != scene.Lists["containers"] {
+		t.Errorf("Unexpected scene read: %+v", read)
+	}
+	if len(read.Containers) != 1 {
+		t.Fatalf("Unexpected containers read: %v", read.Containers)
+	}
+	got := read.Containers[0].Container()
+	if got.ID != "c1" || len(got.Container.Mounts) != 1 || got.ContainerJSON.State == nil || !got.ContainerJSON.State.Running {
+		t.Errorf("The container was not read as it was written: %+v", got)
+	}
+	if env := got.Config.Env; len(env) != 2 || env[0] != "PASSWORD=<scrubbed>" || env[1] != "DEBUG" {
+		t.Errorf("Unexpected env read: %v", env)
+	}
+}
@@ -9,6 +9,16 @@ const (
 	SortByImage
 	SortByStatus
 	SortByName
+	//SortByCPU and SortByMemory sort by current resource usage, which comes
+	//from the stats of each container, so lists sort by them on their own
+	SortByCPU
+	SortByMemory
+	SortByCreated
+	SortByHealth
+	//SortBySize sorts by the size of the writable layer of each container,
+	//which is not listed with the containers, so lists sort by it on their own
+	SortBySize
+	SortByRestarts
 )
 
 //SortMode represents allowed modes to sort a container slice
@@ -43,6 +53,41 @@ func (a byStatus) Less(i, j int) bool {
 	return a.apiContainers[i].Status < a.apiContainers[j].Status
 }
 
+type byCreated struct{ apiContainers }
+
+//the newest first
+func (a byCreated) Less(i, j int) bool {
+	//If the creation time is the same, sorting is done by name
+	if a.apiContainers[i].Created == a.apiContainers[j].Created {
+		return byName(a).Less(i, j)
+	}
+	return a.apiContainers[i].Created > a.apiContainers[j].Created
+}
+
+type byHealth struct{ apiContainers }
+
+//the unhealthy first
+func (a byHealth) Less(i, j int) bool {
+	ri, rj := HealthRank(HealthStatus(a.apiContainers[i])), HealthRank(HealthStatus(a.apiContainers[j]))
+	//If the health is the same, sorting is done by name
+	if ri == rj {
+		return byName(a).Less(i, j)
+	}
+	return ri < rj
+}
+
+type byRestarts struct{ apiContainers }
+
+//the most restarted first
+func (a byRestarts) Less(i, j int) bool {
+	ri, rj := RestartCount(a.apiContainers[i]), RestartCount(a.apiContainers[j])
+	//If the restart count is the same, sorting is done by name
+	if ri == rj {
+		return byName(a).Less(i, j)
+	}
+	return ri > rj
+}
+
 type byName struct{ apiContainers }
 
 func (a byName) Less(i, j int) bool {
@@ -67,5 +112,33 @@ func SortContainers(containers []*Container, mode SortMode) {
 		sort.Sort(byStatus{containers})
 	case SortByName:
 		sort.Sort(byName{containers})
+	case SortByCreated:
+		sort.Sort(byCreated{containers})
+	case SortByHealth:
+		sort.Sort(byHealth{containers})
+	case SortByRestarts:
+		sort.Sort(byRestarts{containers})
+	case SortByCPU, SortByMemory, SortBySize:
+	}
+}
+
+//IsUsageSort returns true if the given mode sorts containers by their
+//current resource usage
+func IsUsageSort(mode SortMode) bool {
+	return mode == SortByCPU || mode == SortByMemory
+}
+
+//UsageValue returns the resource usage of the given stats that the given
+//mode sorts by, -1 if there are no stats
+func UsageValue(stats *Stats, mode SortMode) float64 {
+	if stats == nil {
+		return -1
+	}
+	switch mode {
+	case SortByCPU:
+		return stats.CPUPercentage
+	case SortByMemory:
+		return stats.Memory
 	}
+	return -1
 }
@@ -0,0 +1,8 @@
+package docker
+
+//Allowed sort methods for the build cache
+const (
+	NoSortBuildCache SortMode = iota
+	SortBuildCacheBySize
+	SortBuildCacheByLastUsed
+)
@@ -61,7 +61,7 @@ type networksBySubnet struct{ dockerNetworks }
 func (s networksBySubnet) Less(i, j int) bool {
 	if len(s.dockerNetworks[i].IPAM.Config) > 0 {
 		if len(s.dockerNetworks[j].IPAM.Config) > 0 {
-			return s.dockerNetworks[i].IPAM.Config[0].Subnet < s.dockerNetworks[j].IPAM.Config[0].Subnet
+			return SubnetLess(s.dockerNetworks[i].IPAM.Config[0].Subnet, s.dockerNetworks[j].IPAM.Config[0].Subnet)
 		}
 		return true
 	}
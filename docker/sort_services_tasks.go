@@ -13,6 +13,7 @@ const (
 	SortByTaskService
 	SortByTaskState
 	SortByTaskDesiredState
+	SortByTaskNode
 )
 
 type swarmTasks []swarm.Task
@@ -44,6 +45,12 @@ func (s tasksByDesiredState) Less(i, j int) bool {
 	return s.swarmTasks[i].DesiredState < s.swarmTasks[j].DesiredState
 }
 
+type tasksByNode struct{ swarmTasks }
+
+func (s tasksByNode) Less(i, j int) bool {
+	return s.swarmTasks[i].NodeID < s.swarmTasks[j].NodeID
+}
+
 //SortTasks sorts the given Task slice using the given mode
 func SortTasks(tasks []swarm.Task, mode SortMode) {
 
@@ -60,6 +67,9 @@ func SortTasks(tasks []swarm.Task, mode SortMode) {
 	case SortByTaskDesiredState:
 		sortingAlg := tasksByDesiredState{tasks}
 		sort.SliceStable(sortingAlg.swarmTasks, sortingAlg.Less)
+	case SortByTaskNode:
+		sortingAlg := tasksByNode{tasks}
+		sort.SliceStable(sortingAlg.swarmTasks, sortingAlg.Less)
 	}
 
 }
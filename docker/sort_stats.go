@@ -0,0 +1,13 @@
+package docker
+
+//Allowed sort methods for container stats, the sorting is done by the
+//widgets showing the stats since values change on every refresh.
+const (
+	NoSortStats SortMode = iota
+	SortStatsByName
+	SortStatsByCPU
+	SortStatsByMemory
+	SortStatsByMemoryPercentage
+	SortStatsByNetIO
+	SortStatsByBlockIO
+)
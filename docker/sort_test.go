@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/docker/docker/api/types"
 )
 
 func TestSortById(t *testing.T) {
@@ -36,6 +38,22 @@ func TestSortByName(t *testing.T) {
 	}
 }
 
+func TestSortByCreated(t *testing.T) {
+	c, error := containersToSort()
+	if error != nil {
+		t.Error("Could not create container list")
+	}
+	SortContainers(c, SortByCreated)
+	//the newest first, by name if created at the same time
+	want := []string{"7dfafdbc3a40", "8dfafdbc3a40", "6dfafdbc3a40"}
+	for i, id := range want {
+		if c[i].ID != id {
+			t.Errorf("Sorting by creation time did not work. Sorted to: %s", strings.Join(containersAsString(c), ","))
+			break
+		}
+	}
+}
+
 func TestSortByImage(t *testing.T) {
 	c, error := containersToSort()
 	if error != nil {
@@ -64,6 +82,24 @@ func TestSortByStatus(t *testing.T) {
 	}
 }
 
+func TestSortByRestarts(t *testing.T) {
+	restarted := func(id string, count int) *Container {
+		return &Container{
+			Container: types.Container{ID: id, Names: []string{"/" + id}},
+			ContainerJSON: types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{RestartCount: count},
+			},
+		}
+	}
+	c := []*Container{restarted("a", 0), {Container: types.Container{ID: "b", Names: []string{"/b"}}},
+		restarted("c", 12), restarted("d", 3), restarted("e", 12)}
+	SortContainers(c, SortByRestarts)
+	//the most restarted first, by name if restarted as many times
+	if got := strings.Join(containersAsString(c), ","); got != "c,e,d,a,b" {
+		t.Errorf("Sorting by restart count did not work. Sorted to: %s", got)
+	}
+}
+
 func containersToSort() ([]*Container, error) {
 	jsonContainers := `[
      {
@@ -8,4 +8,8 @@ type Stack struct {
 	Networks     int
 	Configs      int
 	Secrets      int
+	//DesiredTasks is the number of tasks the services of the stack should be running
+	DesiredTasks int
+	//RunningTasks is the number of tasks of the stack that are running
+	RunningTasks int
 }
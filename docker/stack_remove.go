@@ -6,119 +6,178 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/api/types/versions"
 
 	"github.com/pkg/errors"
 )
 
+//StackResourceKind is the kind of a resource created by a stack
+type StackResourceKind string
+
+//Kinds of stack resources, in the order they are removed
+const (
+	StackService StackResourceKind = "service"
+	StackSecret  StackResourceKind = "secret"
+	StackConfig  StackResourceKind = "config"
+	StackNetwork StackResourceKind = "network"
+)
+
+//StackResource is a resource created by the deployment of a stack
+type StackResource struct {
+	Kind StackResourceKind
+	ID   string
+	Name string
+}
+
+func (r StackResource) String() string {
+	return fmt.Sprintf("%s %s", r.Kind, r.Name)
+}
+
+//StackRemoveResult is the outcome of removing a stack resource
+type StackRemoveResult struct {
+	StackResource
+	Err error
+}
+
+//StackRemoveReport describes the outcome of removing the resources of a stack
+type StackRemoveReport struct {
+	Stack   string
+	Results []StackRemoveResult
+}
+
+//Failed returns the results of the resources that could not be removed
+func (r StackRemoveReport) Failed() []StackRemoveResult {
+	var failed []StackRemoveResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+//Summary describes in a line how many resources were removed and which ones failed
+func (r StackRemoveReport) Summary() string {
+	failed := r.Failed()
+	summary := fmt.Sprintf("Stack %s: %d of %d resources removed",
+		r.Stack, len(r.Results)-len(failed), len(r.Results))
+	if len(failed) == 0 {
+		return summary
+	}
+	var reasons []string
+	for _, result := range failed {
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", result.StackResource, result.Err.Error()))
+	}
+	return fmt.Sprintf("%s, could not remove %s", summary, strings.Join(reasons, ", "))
+}
+
 //StackRemove removes the stack with the given in
 func (daemon *DockerDaemon) StackRemove(stack string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
-	defer cancel()
+	resources, err := daemon.StackResources(stack, true)
+	if err != nil {
+		return err
+	}
+	report := daemon.StackRemoveResources(stack, resources, nil)
+	if len(report.Failed()) > 0 {
+		return errors.Errorf("Failed to remove some resources from stack: %s", stack)
+	}
+	return nil
+}
 
-	var errs []string
+//StackResources returns the resources of the given stack in the order they have
+//to be removed: services, secrets, configs and networks. Secrets and configs are
+//only included if asked for.
+func (daemon *DockerDaemon) StackResources(stack string, withSecretsAndConfigs bool) ([]StackResource, error) {
 	services, err := daemon.StackServices(stack)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	networks, err := daemon.StackNetworks(stack)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var secrets []swarm.Secret
-	if versions.GreaterThanOrEqualTo(daemon.client.ClientVersion(), "1.25") {
-		secrets, err = daemon.StackSecrets(stack)
-		if err != nil {
-			return err
-		}
-	}
-
 	var configs []swarm.Config
-	if versions.GreaterThanOrEqualTo(daemon.client.ClientVersion(), "1.30") {
-		configs, err = daemon.StackConfigs(stack)
-		if err != nil {
-			return err
+	if withSecretsAndConfigs {
+		if versions.GreaterThanOrEqualTo(daemon.client.ClientVersion(), "1.25") {
+			secrets, err = daemon.StackSecrets(stack)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if versions.GreaterThanOrEqualTo(daemon.client.ClientVersion(), "1.30") {
+			configs, err = daemon.StackConfigs(stack)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	if len(services)+len(networks)+len(secrets)+len(configs) == 0 {
-		return fmt.Errorf("Nothing found in stack: %s", stack)
+		return nil, fmt.Errorf("Nothing found in stack: %s", stack)
 	}
 
-	hasError := removeServices(ctx, daemon, services)
-	hasError = removeSecrets(ctx, daemon, secrets) || hasError
-	hasError = removeConfigs(ctx, daemon, configs) || hasError
-	hasError = removeNetworks(ctx, daemon, networks) || hasError
-
-	if hasError {
-		errs = append(errs, fmt.Sprintf("Failed to remove some resources from stack: %s", stack))
+	var resources []StackResource
+	sort.Slice(services, sortServiceByName(services))
+	for _, service := range services {
+		resources = append(resources, StackResource{StackService, service.ID, service.Spec.Name})
 	}
-
-	if len(errs) > 0 {
-		return errors.Errorf(strings.Join(errs, "\n"))
+	for _, secret := range secrets {
+		resources = append(resources, StackResource{StackSecret, secret.ID, secret.Spec.Name})
 	}
-	return nil
-}
-
-func sortServiceByName(services []swarm.Service) func(i, j int) bool {
-	return func(i, j int) bool {
-		return services[i].Spec.Name < services[j].Spec.Name
+	for _, config := range configs {
+		resources = append(resources, StackResource{StackConfig, config.ID, config.Spec.Name})
 	}
-}
-
-func removeServices(
-	ctx context.Context,
-	daemon *DockerDaemon,
-	services []swarm.Service) bool {
-	var hasError bool
-	sort.Slice(services, sortServiceByName(services))
-	for _, service := range services {
-		if err := daemon.client.ServiceRemove(ctx, service.ID); err != nil {
-			hasError = true
-		}
+	for _, network := range networks {
+		resources = append(resources, StackResource{StackNetwork, network.ID, network.Name})
 	}
-	return hasError
+	return resources, nil
 }
 
-func removeNetworks(
-	ctx context.Context,
-	daemon *DockerDaemon,
-	networks []types.NetworkResource) bool {
-	var hasError bool
-	for _, network := range networks {
-		if err := daemon.client.NetworkRemove(ctx, network.ID); err != nil {
-			hasError = true
+//StackRemoveResources removes the given resources of a stack, one by one and in the
+//given order. The removal goes on if a resource cannot be removed, the result of
+//each removal is reported to the given func, if any, as it happens.
+func (daemon *DockerDaemon) StackRemoveResources(
+	stack string, resources []StackResource, progress func(result StackRemoveResult, done, total int)) StackRemoveReport {
+	remove := func(r StackResource) error {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+		defer cancel()
+		switch r.Kind {
+		case StackService:
+			return daemon.client.ServiceRemove(ctx, r.ID)
+		case StackSecret:
+			return daemon.client.SecretRemove(ctx, r.ID)
+		case StackConfig:
+			return daemon.client.ConfigRemove(ctx, r.ID)
+		case StackNetwork:
+			return daemon.client.NetworkRemove(ctx, r.ID)
 		}
+		return errors.Errorf("unknown stack resource kind: %s", r.Kind)
 	}
-	return hasError
+	return removeStackResources(stack, resources, remove, progress)
 }
 
-func removeSecrets(
-	ctx context.Context,
-	daemon *DockerDaemon,
-	secrets []swarm.Secret) bool {
-	var hasError bool
-	for _, secret := range secrets {
-		if err := daemon.client.SecretRemove(ctx, secret.ID); err != nil {
-			hasError = true
+func removeStackResources(
+	stack string,
+	resources []StackResource,
+	remove func(StackResource) error,
+	progress func(result StackRemoveResult, done, total int)) StackRemoveReport {
+	report := StackRemoveReport{Stack: stack}
+	for i, resource := range resources {
+		result := StackRemoveResult{resource, remove(resource)}
+		report.Results = append(report.Results, result)
+		if progress != nil {
+			progress(result, i+1, len(resources))
 		}
 	}
-	return hasError
+	return report
 }
 
-func removeConfigs(
-	ctx context.Context,
-	daemon *DockerDaemon,
-	configs []swarm.Config) bool {
-	var hasError bool
-	for _, config := range configs {
-		if err := daemon.client.ConfigRemove(ctx, config.ID); err != nil {
-			hasError = true
-		}
+func sortServiceByName(services []swarm.Service) func(i, j int) bool {
+	return func(i, j int) bool {
+		return services[i].Spec.Name < services[j].Spec.Name
 	}
-	return hasError
 }
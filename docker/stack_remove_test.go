@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRemoveStackResources(t *testing.T) {
+	resources := []StackResource{
+		{StackService, "s1", "web"},
+		{StackService, "s2", "db"},
+		{StackSecret, "x1", "db_password"},
+		{StackNetwork, "n1", "app_default"},
+	}
+	tests := []struct {
+		name        string
+		failing     map[string]bool
+		wantRemoved []string
+		wantFailed  []string
+		wantSummary string
+	}{
+		{
+			"everything is removed",
+			nil,
+			[]string{"s1", "s2", "x1", "n1"},
+			nil,
+			"Stack app: 4 of 4 resources removed",
+		},
+		{
+			"removal goes on after a failure",
+			map[string]bool{"s2": true, "n1": true},
+			[]string{"s1", "x1"},
+			[]string{"s2", "n1"},
+			"Stack app: 2 of 4 resources removed, could not remove service db (in use), network app_default (in use)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempted, removed []string
+			remove := func(r StackResource) error {
+				attempted = append(attempted, r.ID)
+				if tt.failing[r.ID] {
+					return errors.New("in use")
+				}
+				removed = append(removed, r.ID)
+				return nil
+			}
+			var steps []int
+			progress := func(result StackRemoveResult, done, total int) {
+				if total != len(resources) {
+					t.Errorf("progress total = %d, want %d", total, len(resources))
+				}
+				steps = append(steps, done)
+			}
+			report := removeStackResources("app", resources, remove, progress)
+
+			if !reflect.DeepEqual(attempted, []string{"s1", "s2", "x1", "n1"}) {
+				t.Errorf("removeStackResources() attempted %v", attempted)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removeStackResources() removed %v, want %v", removed, tt.wantRemoved)
+			}
+			var failed []string
+			for _, result := range report.Failed() {
+				failed = append(failed, result.ID)
+			}
+			if !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("StackRemoveReport.Failed() = %v, want %v", failed, tt.wantFailed)
+			}
+			if !reflect.DeepEqual(steps, []int{1, 2, 3, 4}) {
+				t.Errorf("progress reported steps %v", steps)
+			}
+			if got := report.Summary(); got != tt.wantSummary {
+				t.Errorf("StackRemoveReport.Summary() = %q, want %q", got, tt.wantSummary)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 //StatsChannel is a container and its stats channel.
 //If the container is not running stats and done channel are nil.
+//Closing the done channel stops the stats collection.
 type StatsChannel struct {
 	Container *Container
 	Stats     <-chan *Stats
@@ -28,18 +29,20 @@ func NewStatsChannel(daemon *DockerDaemon, container *Container) *StatsChannel {
 		go func() {
 			cli := daemon.client
 			ctx, cancel := context.WithCancel(context.Background())
-			containerStats, err := cli.ContainerStats(ctx, container.Names[0], true)
-			responseBody := containerStats.Body
-			defer responseBody.Close()
+			defer cancel()
 			defer close(stats)
+			containerStats, err := cli.ContainerStats(ctx, container.Names[0], true)
 			if err != nil {
 				return
 			}
+			responseBody := containerStats.Body
+			defer responseBody.Close()
 
 			var statsJSON *types.StatsJSON
 			dec := json.NewDecoder(responseBody)
 
 			timer := time.NewTicker(1000 * time.Millisecond)
+			defer timer.Stop()
 			for {
 				select {
 				case <-timer.C:
@@ -54,7 +57,6 @@ func NewStatsChannel(daemon *DockerDaemon, container *Container) *StatsChannel {
 				case <-ctx.Done():
 					return
 				case <-done:
-					cancel()
 					return
 				}
 			}
@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//statsCSVColumns are the columns written for each container on a stats CSV export
+var statsCSVColumns = []string{
+	"cpu %", "memory usage", "memory limit", "network rx", "network tx", "block read", "block write",
+}
+
+//ContainerStatsSeries holds the stats samples of a container
+type ContainerStatsSeries struct {
+	Name    string
+	Samples []StatsSample
+}
+
+//WriteStatsCSV writes the given series as CSV, one row per second with samples
+//and a group of columns per container, memory, network and block I/O in bytes.
+//Containers with no sample on a given second, because they were not running
+//yet or anymore, get empty cells on its row. It returns the number of rows
+//written, not counting the header.
+func WriteStatsCSV(w io.Writer, series []ContainerStatsSeries) (int, error) {
+	header := []string{"timestamp"}
+	for _, s := range series {
+		for _, column := range statsCSVColumns {
+			header = append(header, s.Name+" "+column)
+		}
+	}
+
+	//samples by second, then by container
+	rows := make(map[int64]map[int]*Stats)
+	for i, s := range series {
+		for _, sample := range s.Samples {
+			if sample.Gap || sample.Stats == nil {
+				continue
+			}
+			second := sample.Time.Unix()
+			if rows[second] == nil {
+				rows[second] = make(map[int]*Stats)
+			}
+			rows[second][i] = sample.Stats
+		}
+	}
+	seconds := make([]int64, 0, len(rows))
+	for second := range rows {
+		seconds = append(seconds, second)
+	}
+	sort.Slice(seconds, func(i, j int) bool { return seconds[i] < seconds[j] })
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return 0, err
+	}
+	for _, second := range seconds {
+		record := []string{time.Unix(second, 0).UTC().Format(time.RFC3339)}
+		for i := range series {
+			record = append(record, statsCSVCells(rows[second][i])...)
+		}
+		if err := writer.Write(record); err != nil {
+			return 0, err
+		}
+	}
+	writer.Flush()
+	return len(seconds), writer.Error()
+}
+
+//statsCSVCells returns the cells of the given stats, empty cells if there are none
+func statsCSVCells(stats *Stats) []string {
+	if stats == nil {
+		return make([]string, len(statsCSVColumns))
+	}
+	format := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return []string{
+		strconv.FormatFloat(stats.CPUPercentage, 'f', 2, 64),
+		format(stats.Memory),
+		format(stats.MemoryLimit),
+		format(stats.NetworkRx),
+		format(stats.NetworkTx),
+		format(stats.BlockRead),
+		format(stats.BlockWrite),
+	}
+}
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteStatsCSV(t *testing.T) {
+	start := time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	stats := &Stats{CPUPercentage: 12.5, Memory: 1024, MemoryLimit: 2048, NetworkRx: 1, NetworkTx: 2, BlockRead: 3, BlockWrite: 4}
+
+	tests := []struct {
+		name     string
+		series   []ContainerStatsSeries
+		wantRows int
+		want     string
+	}{
+		{
+			"no containers",
+			nil,
+			0,
+			"timestamp\n",
+		},
+		{
+			"containers appearing and disappearing",
+			[]ContainerStatsSeries{
+				{"web", []StatsSample{{Time: at(0), Stats: stats}, {Time: at(1), Stats: stats}}},
+				{"db", []StatsSample{{Time: at(1), Stats: stats}, {Time: at(1), Gap: true}, {Time: at(2), Stats: stats}}},
+			},
+			3,
+			"timestamp,web cpu %,web memory usage,web memory limit,web network rx,web network tx,web block read,web block write," +
+				"db cpu %,db memory usage,db memory limit,db network rx,db network tx,db block read,db block write\n" +
+				"2018-07-01T10:00:00Z,12.50,1024,2048,1,2,3,4,,,,,,,\n" +
+				"2018-07-01T10:00:01Z,12.50,1024,2048,1,2,3,4,12.50,1024,2048,1,2,3,4\n" +
+				"2018-07-01T10:00:02Z,,,,,,,,12.50,1024,2048,1,2,3,4\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			rows, err := WriteStatsCSV(&buf, tt.series)
+			if err != nil {
+				t.Fatalf("WriteStatsCSV() error = %v", err)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("WriteStatsCSV() = %d rows, want %d", rows, tt.wantRows)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteStatsCSV() wrote:\n%s\nwant:\n%s", got, tt.want)
+			}
+			for i, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				if got, want := strings.Count(line, ","), strings.Count(strings.SplitN(tt.want, "\n", 2)[0], ","); got != want {
+					t.Errorf("line %d has %d columns, want %d", i, got+1, want+1)
+				}
+			}
+		})
+	}
+}
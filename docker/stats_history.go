@@ -0,0 +1,132 @@
+package docker
+
+import (
+	"sync"
+	"time"
+)
+
+//DefaultStatsHistoryWindow is the time window kept by a StatsHistory by default
+const DefaultStatsHistoryWindow = 5 * time.Minute
+
+//statsInterval is the interval at which stats are received from a StatsChannel
+const statsInterval = time.Second
+
+//StatsSample is a sample of the stats of a container at a given time.
+//A sample marked as a gap has no stats, it tells that the stats
+//stream was interrupted (i.e. the container was restarted).
+type StatsSample struct {
+	Time  time.Time
+	Stats *Stats
+	Gap   bool
+}
+
+//StatsHistory keeps the stats of a container received during a time window.
+//It has a limited capacity and behaves as a ring buffer, adding a new sample
+//removes the oldest one if the buffer is at its max capacity.
+type StatsHistory struct {
+	window  time.Duration
+	samples []StatsSample
+	next    int
+	count   int
+	sync.RWMutex
+}
+
+//NewStatsHistory creates a StatsHistory that keeps the samples received
+//during the given time window.
+func NewStatsHistory(window time.Duration) *StatsHistory {
+	h := &StatsHistory{}
+	h.SetWindow(window)
+	return h
+}
+
+//Add adds the given stats to the history. If the time elapsed since the last sample
+//is longer than expected, a gap is added before the stats.
+func (h *StatsHistory) Add(t time.Time, stats *Stats) {
+	h.Lock()
+	defer h.Unlock()
+	if last, ok := h.last(); ok && !last.Gap && t.Sub(last.Time) > 3*statsInterval {
+		h.push(StatsSample{Time: last.Time, Gap: true})
+	}
+	h.push(StatsSample{Time: t, Stats: stats})
+}
+
+//AddGap marks the stats stream as interrupted
+func (h *StatsHistory) AddGap(t time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	if last, ok := h.last(); ok && !last.Gap {
+		h.push(StatsSample{Time: t, Gap: true})
+	}
+}
+
+//Capacity returns the max number of samples kept
+func (h *StatsHistory) Capacity() int {
+	h.RLock()
+	defer h.RUnlock()
+	return len(h.samples)
+}
+
+//Samples returns a copy of the samples kept, from the oldest to the newest
+func (h *StatsHistory) Samples() []StatsSample {
+	h.RLock()
+	defer h.RUnlock()
+	samples := make([]StatsSample, h.count)
+	start := h.next - h.count
+	if start < 0 {
+		start += len(h.samples)
+	}
+	for i := 0; i < h.count; i++ {
+		samples[i] = h.samples[(start+i)%len(h.samples)]
+	}
+	return samples
+}
+
+//SetWindow changes the time window of this history, the most recent
+//samples that fit on the new window are kept.
+func (h *StatsHistory) SetWindow(window time.Duration) {
+	capacity := int(window / statsInterval)
+	if capacity < 1 {
+		capacity = 1
+	}
+	var samples []StatsSample
+	if h.samples != nil {
+		samples = h.Samples()
+	}
+	h.Lock()
+	defer h.Unlock()
+	if len(samples) > capacity {
+		samples = samples[len(samples)-capacity:]
+	}
+	h.window = window
+	h.samples = make([]StatsSample, capacity)
+	h.next, h.count = 0, 0
+	for _, s := range samples {
+		h.push(s)
+	}
+}
+
+//Window returns the time window of this history
+func (h *StatsHistory) Window() time.Duration {
+	h.RLock()
+	defer h.RUnlock()
+	return h.window
+}
+
+func (h *StatsHistory) last() (StatsSample, bool) {
+	if h.count == 0 {
+		return StatsSample{}, false
+	}
+	i := h.next - 1
+	if i < 0 {
+		i += len(h.samples)
+	}
+	return h.samples[i], true
+}
+
+func (h *StatsHistory) push(s StatsSample) {
+	h.samples[h.next] = s
+	h.next = (h.next + 1) % len(h.samples)
+	if h.count < len(h.samples) {
+		h.count++
+	}
+}
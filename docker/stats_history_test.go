@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsHistory(t *testing.T) {
+	h := NewStatsHistory(5 * time.Second)
+	if h.Capacity() != 5 {
+		t.Errorf("Unexpected capacity, expected 5, got %d", h.Capacity())
+	}
+	now := time.Now()
+	for i := 0; i < 7; i++ {
+		h.Add(now.Add(time.Duration(i)*time.Second), &Stats{CPUPercentage: float64(i)})
+	}
+	samples := h.Samples()
+	if len(samples) != 5 {
+		t.Fatalf("Unexpected number of samples, expected 5, got %d", len(samples))
+	}
+	for i, s := range samples {
+		if s.Stats.CPUPercentage != float64(i+2) {
+			t.Errorf("Unexpected sample at position %d: %f", i, s.Stats.CPUPercentage)
+		}
+	}
+}
+
+func TestStatsHistoryGaps(t *testing.T) {
+	h := NewStatsHistory(time.Minute)
+	now := time.Now()
+	h.Add(now, &Stats{})
+	h.AddGap(now)
+	//consecutive gaps are not added
+	h.AddGap(now)
+	h.Add(now.Add(time.Second), &Stats{})
+	//too much time without samples is a gap
+	h.Add(now.Add(10*time.Second), &Stats{})
+
+	samples := h.Samples()
+	expected := []bool{false, true, false, true, false}
+	if len(samples) != len(expected) {
+		t.Fatalf("Unexpected number of samples, expected %d, got %d", len(expected), len(samples))
+	}
+	for i, s := range samples {
+		if s.Gap != expected[i] {
+			t.Errorf("Unexpected sample at position %d, gap: %t", i, s.Gap)
+		}
+	}
+}
+
+func TestStatsHistorySetWindow(t *testing.T) {
+	h := NewStatsHistory(10 * time.Second)
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		h.Add(now.Add(time.Duration(i)*time.Second), &Stats{CPUPercentage: float64(i)})
+	}
+	h.SetWindow(3 * time.Second)
+	samples := h.Samples()
+	if len(samples) != 3 || samples[0].Stats.CPUPercentage != 7 {
+		t.Errorf("The most recent samples were not kept after changing the window: %v", samples)
+	}
+}
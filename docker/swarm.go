@@ -48,12 +48,11 @@ func (daemon *DockerDaemon) NodeChangeAvailability(nodeID string, availability s
 
 //Nodes returns the nodes that are part of the Swarm
 func (daemon *DockerDaemon) Nodes() ([]swarm.Node, error) {
-
-	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
-	defer cancel()
-	nodes, err := daemon.client.NodeList(ctx, types.NodeListOptions{})
+	nodes, err := daemon.calls.do(nodesCall, func(ctx context.Context) (interface{}, error) {
+		return daemon.client.NodeList(ctx, types.NodeListOptions{})
+	})
 	if err == nil {
-		return nodes, nil
+		return nodes.([]swarm.Node), nil
 	}
 	return nil, pkgError.Wrap(err, "Error retrieving node list")
 }
@@ -103,7 +102,9 @@ func (daemon *DockerDaemon) Service(id string) (*swarm.Service, error) {
 
 //ServiceLogs returns logs of the service with the given id
 func (daemon *DockerDaemon) ServiceLogs(id string, since string, withTimestamps bool) (io.ReadCloser, error) {
-
+	if err := daemon.requireFeature(ServiceLogsFeature); err != nil {
+		return nil, err
+	}
 	options := types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -117,9 +118,13 @@ func (daemon *DockerDaemon) ServiceLogs(id string, since string, withTimestamps
 
 //Services returns the services known by the Swarm
 func (daemon *DockerDaemon) Services() ([]swarm.Service, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
-	defer cancel()
-	return daemon.client.ServiceList(ctx, types.ServiceListOptions{})
+	services, err := daemon.calls.do(servicesCall, func(ctx context.Context) (interface{}, error) {
+		return daemon.client.ServiceList(ctx, types.ServiceListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return services.([]swarm.Service), nil
 }
 
 //ServiceRemove removes the service with the given in
@@ -247,6 +252,13 @@ func (daemon *DockerDaemon) Stacks() ([]Stack, error) {
 			ztack.Services++
 		}
 	}
+	if len(m) > 0 {
+		tasks, err := daemon.client.TaskList(ctx, types.TaskListOptions{Filters: getAllStacksFilter()})
+		if err != nil {
+			return nil, pkgError.Wrap(err, "cannot get the tasks of the stacks")
+		}
+		countStackTasks(m, services, tasks)
+	}
 	var stacks []Stack
 	for _, stack := range m {
 		stacks = append(stacks, *stack)
@@ -254,6 +266,38 @@ func (daemon *DockerDaemon) Stacks() ([]Stack, error) {
 	return stacks, nil
 }
 
+//countStackTasks adds up, for each stack, the tasks its services should be running
+//and the ones actually running. A replicated service should run as many tasks as
+//replicas, a global service one per node, which are the tasks not meant to be shut down.
+func countStackTasks(stacks map[string]*Stack, services []swarm.Service, tasks []swarm.Task) {
+	stackOf := make(map[string]*Stack)
+	global := make(map[string]bool)
+	for _, service := range services {
+		stack, ok := stacks[service.Spec.Labels[LabelNamespace]]
+		if !ok {
+			continue
+		}
+		stackOf[service.ID] = stack
+		if replicated := service.Spec.Mode.Replicated; replicated != nil && replicated.Replicas != nil {
+			stack.DesiredTasks += int(*replicated.Replicas)
+		} else if service.Spec.Mode.Global != nil {
+			global[service.ID] = true
+		}
+	}
+	for _, task := range tasks {
+		stack, ok := stackOf[task.ServiceID]
+		if !ok {
+			continue
+		}
+		if global[task.ServiceID] && task.DesiredState != swarm.TaskStateShutdown {
+			stack.DesiredTasks++
+		}
+		if task.Status.State == swarm.TaskStateRunning {
+			stack.RunningTasks++
+		}
+	}
+}
+
 //StackConfigs returns the configs created for the given stack
 func (daemon *DockerDaemon) StackConfigs(stack string) ([]swarm.Config, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+)
+
+//swarmListenAddr is the address swarm nodes created or joined from dry listen on
+const swarmListenAddr = "0.0.0.0:2377"
+
+//SwarmJoinTokens are the tokens to join a swarm as a worker or as a manager
+type SwarmJoinTokens struct {
+	Worker  string
+	Manager string
+	//ManagerAddr is the address of the manager to join to
+	ManagerAddr string
+}
+
+//JoinCommand returns the command to run on a host to join the swarm with the given token
+func (t SwarmJoinTokens) JoinCommand(token string) string {
+	return fmt.Sprintf("docker swarm join --token %s %s", token, t.ManagerAddr)
+}
+
+//SwarmMode returns true if the Docker host is an active node of a swarm
+func (daemon *DockerDaemon) SwarmMode() bool {
+	return daemon.swarmMode
+}
+
+//SwarmInit creates a new swarm with the Docker host as its first manager, the
+//given advertise address can be empty to let the daemon choose it
+func (daemon *DockerDaemon) SwarmInit(advertiseAddr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultConnectionTimeout)
+	defer cancel()
+	_, err := daemon.client.SwarmInit(ctx, swarm.InitRequest{
+		ListenAddr:    swarmListenAddr,
+		AdvertiseAddr: advertiseAddr,
+	})
+	daemon.refreshSwarmMode()
+	return err
+}
+
+//SwarmJoin joins the Docker host to the swarm managed from the given address,
+//as a worker or as a manager depending on the given token
+func (daemon *DockerDaemon) SwarmJoin(remoteAddr, token string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultConnectionTimeout)
+	defer cancel()
+	err := daemon.client.SwarmJoin(ctx, swarm.JoinRequest{
+		ListenAddr:  swarmListenAddr,
+		RemoteAddrs: []string{remoteAddr},
+		JoinToken:   token,
+	})
+	daemon.refreshSwarmMode()
+	return err
+}
+
+//SwarmLeave makes the Docker host leave the swarm, managers have to be forced to
+func (daemon *DockerDaemon) SwarmLeave(force bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultConnectionTimeout)
+	defer cancel()
+	err := daemon.client.SwarmLeave(ctx, force)
+	daemon.refreshSwarmMode()
+	return err
+}
+
+//SwarmJoinTokens returns the tokens to join the swarm, only managers know them
+func (daemon *DockerDaemon) SwarmJoinTokens() (SwarmJoinTokens, error) {
+	info, err := daemon.Info()
+	if err != nil {
+		return SwarmJoinTokens{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	sw, err := daemon.client.SwarmInspect(ctx)
+	if err != nil {
+		return SwarmJoinTokens{}, err
+	}
+	return SwarmJoinTokens{
+		Worker:      sw.JoinTokens.Worker,
+		Manager:     sw.JoinTokens.Manager,
+		ManagerAddr: managerAddr(info),
+	}, nil
+}
+
+//SwarmRotateJoinTokens replaces the worker token, the manager token or both by new
+//ones, nodes already in the swarm are not affected
+func (daemon *DockerDaemon) SwarmRotateJoinTokens(worker, manager bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	sw, err := daemon.client.SwarmInspect(ctx)
+	if err != nil {
+		return err
+	}
+	return daemon.client.SwarmUpdate(ctx, sw.Version, sw.Spec, swarm.UpdateFlags{
+		RotateWorkerToken:  worker,
+		RotateManagerToken: manager,
+	})
+}
+
+//refreshSwarmMode checks again whether the Docker host is part of a swarm
+func (daemon *DockerDaemon) refreshSwarmMode() {
+	if info, err := daemon.Info(); err == nil {
+		daemon.swarmMode = info.Swarm.LocalNodeState == swarm.LocalNodeStateActive
+	}
+}
+
+//managerAddr returns the address other nodes use to reach the given manager,
+//which is the address of the node itself on the list of managers of the swarm
+func managerAddr(info types.Info) string {
+	var addr string
+	for _, manager := range info.Swarm.RemoteManagers {
+		if manager.NodeID == info.Swarm.NodeID {
+			return manager.Addr
+		}
+		if addr == "" {
+			addr = manager.Addr
+		}
+	}
+	if addr == "" && info.Swarm.NodeAddr != "" {
+		addr = info.Swarm.NodeAddr + ":2377"
+	}
+	return addr
+}
@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+)
+
+func TestManagerAddr(t *testing.T) {
+	tests := []struct {
+		name string
+		info swarm.Info
+		want string
+	}{
+		{
+			"the node is on the list of managers",
+			swarm.Info{
+				NodeID: "2",
+				RemoteManagers: []swarm.Peer{
+					{NodeID: "1", Addr: "10.0.0.1:2377"},
+					{NodeID: "2", Addr: "10.0.0.2:2377"},
+				},
+			},
+			"10.0.0.2:2377",
+		},
+		{
+			"the node is not on the list of managers",
+			swarm.Info{
+				NodeID:         "3",
+				RemoteManagers: []swarm.Peer{{NodeID: "1", Addr: "10.0.0.1:2377"}},
+			},
+			"10.0.0.1:2377",
+		},
+		{
+			"no list of managers",
+			swarm.Info{NodeID: "1", NodeAddr: "192.168.1.10"},
+			"192.168.1.10:2377",
+		},
+		{
+			"not in a swarm",
+			swarm.Info{},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := managerAddr(types.Info{Swarm: tt.info}); got != tt.want {
+				t.Errorf("managerAddr() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwarmJoinTokens_JoinCommand(t *testing.T) {
+	tokens := SwarmJoinTokens{Worker: "SWMTKN-1-worker", Manager: "SWMTKN-1-manager", ManagerAddr: "10.0.0.1:2377"}
+	want := "docker swarm join --token SWMTKN-1-worker 10.0.0.1:2377"
+	if got := tokens.JoinCommand(tokens.Worker); got != want {
+		t.Errorf("JoinCommand() = %s, want %s", got, want)
+	}
+}
@@ -100,6 +100,8 @@ func TestDockerDaemon_Stacks(t *testing.T) {
 					Configs:      0,
 					Secrets:      0,
 					Networks:     0,
+					DesiredTasks: 2,
+					RunningTasks: 2,
 				},
 				{
 					Name:         "stack2",
@@ -108,6 +110,8 @@ func TestDockerDaemon_Stacks(t *testing.T) {
 					Configs:      0,
 					Secrets:      0,
 					Networks:     0,
+					DesiredTasks: 2,
+					RunningTasks: 0,
 				},
 			},
 			false,
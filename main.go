@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"net/http"
@@ -56,12 +57,30 @@ type dryOptions struct {
 	DockerTLSVerifiy string `short:"t" long:"docker_tls" description:"Docker TLS verify"`
 	//Whale
 	Whale uint `short:"w" long:"whale" description:"Show whale for w seconds"`
+	//View to start on, and how its list is filtered and sorted
+	View   string `long:"view" description:"Starts dry on the given view: containers, disk-usage, images, jobs, monitor, networks, nodes, plugins, services or stacks"`
+	Filter string `long:"filter" description:"Filters the list of the view dry starts on, i.e. status:exited"`
+	Sort   string `long:"sort" description:"Sorts the list of the view dry starts on by the given column, i.e. name"`
+	//ASCII tells dry to draw only ASCII characters and text attributes
+	ASCII bool `long:"ascii" description:"Draws only ASCII characters and text attributes, for consoles and screen readers that cannot show anything else, implied if TERM is vt100-like"`
+	//Scene saved with F12 to show instead of connecting to Docker
+	Replay string `short:"r" long:"replay" description:"Replays the scene saved on the given file, no Docker host is needed"`
 }
 
 //-----------------------------------------------------------------------------
 
-func newApp(screen *ui.Screen, dockerEnv *docker.Env) (*app.Dry, error) {
-	return app.NewDry(screen, dockerEnv)
+//asciiTerminal returns true if the terminal with the given TERM value can
+//only show ASCII characters, i.e. vt100
+func asciiTerminal(term string) bool {
+	return term == "dumb" || strings.HasPrefix(term, "vt")
+}
+
+func newApp(screen *ui.Screen, dockerEnv *docker.Env, opts dryOptions) (*app.Dry, error) {
+	return app.NewDry(screen, dockerEnv, app.StartupOptions{
+		View:   opts.View,
+		Filter: opts.Filter,
+		Sort:   opts.Sort,
+	})
 }
 
 func newDockerEnv(opts dryOptions) *docker.Env {
@@ -193,6 +212,9 @@ func main() {
 			"There was an error launching dry")
 		return
 	}
+	if opts.ASCII || asciiTerminal(os.Getenv("TERM")) {
+		screen.CompatibilityMode(true)
+	}
 
 	running = true
 
@@ -202,8 +224,13 @@ func main() {
 	start := time.Now()
 	showLoadingScreen(screen, dockerEnv, stopLoadScreen)
 
-	//newApp will load dry and try to establish a connection with the docker daemon
-	dry, err := newApp(screen, dockerEnv)
+	var dry *app.Dry
+	if opts.Replay != "" {
+		dry, err = app.ReplayScene(screen, opts.Replay)
+	} else {
+		//newApp will load dry and try to establish a connection with the docker daemon
+		dry, err = newApp(screen, dockerEnv, opts)
+	}
 
 	//show whale to bablat
 	showWale, errP := time.ParseDuration(fmt.Sprintf("%ds", opts.Whale))
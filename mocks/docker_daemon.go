@@ -1,14 +1,17 @@
 package mocks
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/swarm"
 	drydocker "github.com/moncho/dry/docker"
 )
@@ -17,11 +20,41 @@ import (
 type DockerDaemonMock struct {
 }
 
+//CommitContainer mock
+func (_m *DockerDaemonMock) CommitContainer(id string, options drydocker.CommitOptions) (string, error) {
+	return "", nil
+}
+
+//CopyFromContainer mock
+func (_m *DockerDaemonMock) CopyFromContainer(ctx context.Context, id, src, dst string, progress drydocker.CopyProgress) error {
+	return nil
+}
+
+//Attach mock
+func (_m *DockerDaemonMock) Attach(id string) (*drydocker.ContainerAttachment, error) {
+	return nil, nil
+}
+
+//CopyToContainer mock
+func (_m *DockerDaemonMock) CopyToContainer(ctx context.Context, id, src, dst string, progress drydocker.CopyProgress) error {
+	return nil
+}
+
+//Exec mock
+func (_m *DockerDaemonMock) Exec(id string, options drydocker.ExecOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+
 //ContainerByID mock
 func (_m *DockerDaemonMock) ContainerByID(id string) *drydocker.Container {
 	return nil
 }
 
+//ContainerDiff mock
+func (_m *DockerDaemonMock) ContainerDiff(id string) ([]drydocker.ContainerChange, error) {
+	return nil, nil
+}
+
 //Containers mock
 func (_m *DockerDaemonMock) Containers(filters []drydocker.ContainerFilter, mode drydocker.SortMode) []*drydocker.Container {
 
@@ -44,6 +77,31 @@ func (_m *DockerDaemonMock) Containers(filters []drydocker.ContainerFilter, mode
 	return containers
 }
 
+//ContainerSizes mock
+func (_m *DockerDaemonMock) ContainerSizes() (map[string]drydocker.ContainerSize, error) {
+	return nil, nil
+}
+
+//APIVersion mock
+func (_m *DockerDaemonMock) APIVersion() string {
+	return drydocker.DefaultAPIVersion
+}
+
+//BuildCache mock
+func (_m *DockerDaemonMock) BuildCache() ([]*types.BuildCache, error) {
+	return nil, nil
+}
+
+//BuildCachePrune mock
+func (_m *DockerDaemonMock) BuildCachePrune(opts drydocker.BuildCachePruneOptions) (uint64, error) {
+	return 0, nil
+}
+
+//CallStats mock
+func (_m *DockerDaemonMock) CallStats() []drydocker.CallStats {
+	return nil
+}
+
 //DiskUsage mock
 func (_m *DockerDaemonMock) DiskUsage() (types.DiskUsage, error) {
 	return types.DiskUsage{}, nil
@@ -70,11 +128,21 @@ func (_m *DockerDaemonMock) History(id string) ([]image.HistoryResponseItem, err
 	return nil, nil
 }
 
+//HostRanges mock
+func (_m *DockerDaemonMock) HostRanges() []drydocker.HostRange {
+	return nil
+}
+
 //ImageByID mock
 func (_m *DockerDaemonMock) ImageByID(id string) (types.ImageSummary, error) {
 	return types.ImageSummary{}, nil
 }
 
+//ImageLayers mock
+func (_m *DockerDaemonMock) ImageLayers(id string) ([]drydocker.ImageLayer, error) {
+	return nil, nil
+}
+
 //Images mock
 func (_m *DockerDaemonMock) Images() ([]types.ImageSummary, error) {
 
@@ -151,6 +219,11 @@ func (_m *DockerDaemonMock) Inspect(id string) (types.ContainerJSON, error) {
 	return types.ContainerJSON{}, nil
 }
 
+//ImageGraph mock
+func (_m *DockerDaemonMock) ImageGraph(images []types.ImageSummary) (*drydocker.ImageGraph, error) {
+	return drydocker.NewImageGraph(images, nil), nil
+}
+
 // InspectImage mock
 func (_m *DockerDaemonMock) InspectImage(name string) (types.ImageInspect, error) {
 	return types.ImageInspect{}, nil
@@ -166,11 +239,86 @@ func (_m *DockerDaemonMock) Kill(id string) error {
 	return nil
 }
 
+// KillAndWait mock
+func (_m *DockerDaemonMock) KillAndWait(id string, timeout time.Duration) (drydocker.ContainerExit, error) {
+	return drydocker.ContainerExit{}, nil
+}
+
+//SignalContainer mock
+func (_m *DockerDaemonMock) SignalContainer(id, signal string) error {
+	return nil
+}
+
 // Logs provides a mock function with given fields: id
 func (_m *DockerDaemonMock) Logs(id, since string, ts bool) (io.ReadCloser, error) {
 	return nil, nil
 }
 
+//IsLocal mock
+func (_m *DockerDaemonMock) IsLocal() bool {
+	return true
+}
+
+//JournalLogs mock
+func (_m *DockerDaemonMock) JournalLogs(id, since string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+//LogTail mock
+func (_m *DockerDaemonMock) LogTail(ctx context.Context, id string, lines int) ([]string, error) {
+	return nil, nil
+}
+
+//LayerContents mock
+func (_m *DockerDaemonMock) LayerContents(ctx context.Context, id, diffID string, progress func(float64)) (*drydocker.LayerContents, error) {
+	return nil, nil
+}
+
+//PullImage mock
+func (_m *DockerDaemonMock) PullImage(ctx context.Context, ref string, progress func(drydocker.TransferProgress)) (string, error) {
+	return ref, nil
+}
+
+//PushImage mock
+func (_m *DockerDaemonMock) PushImage(ctx context.Context, ref string, credentials *drydocker.RegistryCredentials, progress func(drydocker.TransferProgress)) (string, error) {
+	return ref, nil
+}
+
+//PruneImages mock
+func (_m *DockerDaemonMock) PruneImages(opts drydocker.ImagePruneOptions) (types.ImagesPruneReport, error) {
+	return types.ImagesPruneReport{}, nil
+}
+
+//SearchImages mock
+func (_m *DockerDaemonMock) SearchImages(ctx context.Context, term string) ([]registry.SearchResult, error) {
+	return nil, nil
+}
+
+//SaveImage mock
+func (_m *DockerDaemonMock) SaveImage(ctx context.Context, id, path string, progress func(float64)) (int64, error) {
+	return 0, nil
+}
+
+//LoadImage mock
+func (_m *DockerDaemonMock) LoadImage(ctx context.Context, path string, progress func(float64)) ([]string, error) {
+	return nil, nil
+}
+
+//BuildImage mock
+func (_m *DockerDaemonMock) BuildImage(ctx context.Context, options drydocker.BuildOptions, output io.Writer) (string, error) {
+	return "", nil
+}
+
+//TagImage mock
+func (_m *DockerDaemonMock) TagImage(id, ref string) error {
+	return nil
+}
+
+//UntagImage mock
+func (_m *DockerDaemonMock) UntagImage(id, tag string) error {
+	return nil
+}
+
 //Networks mock
 func (_m *DockerDaemonMock) Networks() ([]types.NetworkResource, error) {
 	return nil, nil
@@ -191,6 +339,61 @@ func (_m *DockerDaemonMock) NetworkInspect(id string) (types.NetworkResource, er
 	return types.NetworkResource{}, nil
 }
 
+//VolumeInspect mock
+func (_m *DockerDaemonMock) VolumeInspect(name string) (types.Volume, error) {
+	return types.Volume{}, nil
+}
+
+//Checkpoints mock
+func (_m *DockerDaemonMock) Checkpoints(id string) ([]types.Checkpoint, error) {
+	return nil, nil
+}
+
+//CreateCheckpoint mock
+func (_m *DockerDaemonMock) CreateCheckpoint(id, name string, leaveRunning bool) error {
+	return nil
+}
+
+//DeleteCheckpoint mock
+func (_m *DockerDaemonMock) DeleteCheckpoint(id, name string) error {
+	return nil
+}
+
+//Experimental mock
+func (_m *DockerDaemonMock) Experimental() bool {
+	return false
+}
+
+//StartFromCheckpoint mock
+func (_m *DockerDaemonMock) StartFromCheckpoint(id, name string) error {
+	return nil
+}
+
+//Plugins mock
+func (_m *DockerDaemonMock) Plugins() ([]*types.Plugin, error) {
+	return nil, nil
+}
+
+//PluginInspect mock
+func (_m *DockerDaemonMock) PluginInspect(name string) (*types.Plugin, error) {
+	return &types.Plugin{Name: name}, nil
+}
+
+//PluginEnable mock
+func (_m *DockerDaemonMock) PluginEnable(name string) error {
+	return nil
+}
+
+//PluginDisable mock
+func (_m *DockerDaemonMock) PluginDisable(name string) error {
+	return nil
+}
+
+//PluginRemove mock
+func (_m *DockerDaemonMock) PluginRemove(name string, force bool) error {
+	return nil
+}
+
 //Node mock
 func (_m *DockerDaemonMock) Node(id string) (*swarm.Node, error) {
 	return nil, nil
@@ -217,27 +420,62 @@ func (_m *DockerDaemonMock) Ok() (bool, error) {
 	return false, nil
 }
 
+//OnContainerEvent mocks OnContainerEvent
+func (_m *DockerDaemonMock) OnContainerEvent(f func(drydocker.ContainerEvent)) {
+}
+
 //OpenChannel mocks OpenChannel
 func (_m *DockerDaemonMock) OpenChannel(container *drydocker.Container) *drydocker.StatsChannel {
 	return nil
 }
 
+//PruneContainers mock
+func (_m *DockerDaemonMock) PruneContainers() (types.ContainersPruneReport, error) {
+	return types.ContainersPruneReport{}, nil
+}
+
 // Prune mocks prune command
 func (_m *DockerDaemonMock) Prune() (*drydocker.PruneReport, error) {
 	return nil, nil
 }
 
+// CheckImageUpdates provides a mock function with given fields: ctx, containers, report
+func (_m *DockerDaemonMock) CheckImageUpdates(ctx context.Context, containers []*drydocker.Container, report func(drydocker.ImageUpdate)) {
+}
+
+// RecreateContainer provides a mock function with given fields: ctx, id, pull, step
+func (_m *DockerDaemonMock) RecreateContainer(ctx context.Context, id string, pull bool, step func(string)) (string, error) {
+
+	return "", nil
+}
+
+//RenameContainer mock
+func (_m *DockerDaemonMock) RenameContainer(id, name string) (*drydocker.Container, error) {
+	return nil, nil
+}
+
 // RestartContainer provides a mock function with given fields: id
 func (_m *DockerDaemonMock) RestartContainer(id string) error {
 
 	return nil
 }
 
+// RunContainer provides a mock function with given fields: options
+func (_m *DockerDaemonMock) RunContainer(options drydocker.RunOptions) (string, error) {
+
+	return "", nil
+}
+
 // Rm provides a mock function with given fields: id
 func (_m *DockerDaemonMock) Rm(id string) error {
 	return nil
 }
 
+// RmWithVolumes mock
+func (_m *DockerDaemonMock) RmWithVolumes(id string, volumes []string) (int, error) {
+	return len(volumes), nil
+}
+
 // Rmi mock
 func (_m *DockerDaemonMock) Rmi(id string, force bool) ([]types.ImageDeleteResponseItem, error) {
 	return nil, nil
@@ -324,11 +562,31 @@ func (_m *DockerDaemonMock) ServiceUpdate(id string) error {
 	return nil
 }
 
+// StopAndWait mock
+func (_m *DockerDaemonMock) StopAndWait(id string, timeout time.Duration) (drydocker.ContainerExit, error) {
+	return drydocker.ContainerExit{}, nil
+}
+
 // StopContainer provides a mock function with given fields: id
 func (_m *DockerDaemonMock) StopContainer(id string) error {
 	return nil
 }
 
+//PauseContainer mock
+func (_m *DockerDaemonMock) PauseContainer(id string) error {
+	return nil
+}
+
+//Processes mock
+func (_m *DockerDaemonMock) Processes(id string) (container.ContainerTopOKBody, error) {
+	return container.ContainerTopOKBody{}, nil
+}
+
+//UnpauseContainer mock
+func (_m *DockerDaemonMock) UnpauseContainer(id string) error {
+	return nil
+}
+
 // Sort provides a mock function with given fields: sortMode
 func (_m *DockerDaemonMock) Sort(sortMode drydocker.SortMode) {
 
@@ -367,6 +625,16 @@ func (_m *DockerDaemonMock) StackRemove(stack string) error {
 	return nil
 }
 
+//StackRemoveResources mock
+func (_m *DockerDaemonMock) StackRemoveResources(stack string, resources []drydocker.StackResource, progress func(result drydocker.StackRemoveResult, done, total int)) drydocker.StackRemoveReport {
+	return drydocker.StackRemoveReport{Stack: stack}
+}
+
+//StackResources mock
+func (_m *DockerDaemonMock) StackResources(stack string, withSecretsAndConfigs bool) ([]drydocker.StackResource, error) {
+	return nil, nil
+}
+
 //StackServices mock
 func (_m *DockerDaemonMock) StackServices(stack string) ([]swarm.Service, error) {
 	return nil, nil
@@ -377,6 +645,41 @@ func (_m *DockerDaemonMock) StackTasks(stack string) ([]swarm.Task, error) {
 	return nil, nil
 }
 
+//Supports mock
+func (_m *DockerDaemonMock) Supports(f drydocker.Feature) bool {
+	return true
+}
+
+//SwarmInit mock
+func (_m *DockerDaemonMock) SwarmInit(advertiseAddr string) error {
+	return nil
+}
+
+//SwarmJoin mock
+func (_m *DockerDaemonMock) SwarmJoin(remoteAddr, token string) error {
+	return nil
+}
+
+//SwarmJoinTokens mock
+func (_m *DockerDaemonMock) SwarmJoinTokens() (drydocker.SwarmJoinTokens, error) {
+	return drydocker.SwarmJoinTokens{}, nil
+}
+
+//SwarmLeave mock
+func (_m *DockerDaemonMock) SwarmLeave(force bool) error {
+	return nil
+}
+
+//SwarmMode mock
+func (_m *DockerDaemonMock) SwarmMode() bool {
+	return false
+}
+
+//SwarmRotateJoinTokens mock
+func (_m *DockerDaemonMock) SwarmRotateJoinTokens(worker, manager bool) error {
+	return nil
+}
+
 //Task empty mock
 func (_m *DockerDaemonMock) Task(id string) (swarm.Task, error) {
 	return swarm.Task{}, nil
@@ -388,6 +691,17 @@ func (_m *DockerDaemonMock) Top(id string) (container.ContainerTopOKBody, error)
 	return container.ContainerTopOKBody{}, nil
 }
 
+//UpdateLimits mock
+func (_m *DockerDaemonMock) UpdateLimits(id string, limits drydocker.ContainerLimits) (*drydocker.Container, error) {
+	return nil, nil
+}
+
+// UpdateRestartPolicy provides a mock function with given fields: id, policy
+func (_m *DockerDaemonMock) UpdateRestartPolicy(id string, policy string) (*drydocker.Container, error) {
+
+	return nil, nil
+}
+
 // Version provides a mock function with given fields:
 func (_m *DockerDaemonMock) Version() (*types.Version, error) {
 
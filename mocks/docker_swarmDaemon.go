@@ -32,6 +32,11 @@ func (_m *SwarmDockerDaemon) Info() (types.Info, error) {
 		Swarm:    swarmInfo}, nil
 }
 
+//SwarmMode returns true, the mocked daemon is part of a swarm
+func (_m *SwarmDockerDaemon) SwarmMode() bool {
+	return true
+}
+
 //Node returns a node with the given id
 func (_m *SwarmDockerDaemon) Node(id string) (*swarm.Node, error) {
 	return &swarm.Node{ID: id}, nil
@@ -0,0 +1,248 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/api/types/swarm"
+	drydocker "github.com/moncho/dry/docker"
+)
+
+//errReplay is returned by the operations that need a real daemon
+var errReplay = errors.New("not available when replaying a scene")
+
+//SceneDaemon is a Docker daemon backed by a saved scene, the containers,
+//images and networks of the scene are listed as the real daemon listed them.
+//Operations that change the daemon do nothing, those that need more than
+//the scene has fail.
+type SceneDaemon struct {
+	DockerDaemonMock
+	scene      *drydocker.Scene
+	path       string
+	containers []*drydocker.Container
+	eventLog   *drydocker.EventLog
+}
+
+//NewSceneDaemon creates a SceneDaemon for the given scene, read from the given path
+func NewSceneDaemon(scene *drydocker.Scene, path string) *SceneDaemon {
+	containers := make([]*drydocker.Container, len(scene.Containers))
+	for i, c := range scene.Containers {
+		containers[i] = c.Container()
+	}
+	return &SceneDaemon{
+		scene:      scene,
+		path:       path,
+		containers: containers,
+		eventLog:   drydocker.NewEventLog(),
+	}
+}
+
+//ContainerByID returns the container of the scene with the given id
+func (d *SceneDaemon) ContainerByID(id string) *drydocker.Container {
+	for _, c := range d.containers {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+//Containers returns the containers of the scene
+func (d *SceneDaemon) Containers(filters []drydocker.ContainerFilter, mode drydocker.SortMode) []*drydocker.Container {
+	containers := make([]*drydocker.Container, len(d.containers))
+	copy(containers, d.containers)
+	for _, filter := range filters {
+		containers = filter.Apply(containers)
+	}
+	drydocker.SortContainers(containers, mode)
+	return containers
+}
+
+//ContainerDiff fails, filesystem changes are not part of a scene
+func (d *SceneDaemon) ContainerDiff(id string) ([]drydocker.ContainerChange, error) {
+	return nil, errReplay
+}
+
+//ContainerSizes returns the sizes of the containers of the scene, as the
+//scene has them
+func (d *SceneDaemon) ContainerSizes() (map[string]drydocker.ContainerSize, error) {
+	sizes := make(map[string]drydocker.ContainerSize, len(d.containers))
+	for _, c := range d.containers {
+		sizes[c.ID] = drydocker.ContainerSize{RW: c.SizeRw, RootFs: c.SizeRootFs}
+	}
+	return sizes, nil
+}
+
+//PullImage fails, images cannot be pulled into a scene
+func (d *SceneDaemon) PullImage(ctx context.Context, ref string, progress func(drydocker.TransferProgress)) (string, error) {
+	return "", errReplay
+}
+
+//PushImage fails, images cannot be pushed from a scene
+func (d *SceneDaemon) PushImage(ctx context.Context, ref string, credentials *drydocker.RegistryCredentials, progress func(drydocker.TransferProgress)) (string, error) {
+	return "", errReplay
+}
+
+//BuildImage fails, images cannot be built into a scene
+func (d *SceneDaemon) BuildImage(ctx context.Context, options drydocker.BuildOptions, output io.Writer) (string, error) {
+	return "", errReplay
+}
+
+//PruneImages fails, the images of a scene cannot be changed
+func (d *SceneDaemon) PruneImages(opts drydocker.ImagePruneOptions) (types.ImagesPruneReport, error) {
+	return types.ImagesPruneReport{}, errReplay
+}
+
+//SearchImages fails, a scene has no registry to search
+func (d *SceneDaemon) SearchImages(ctx context.Context, term string) ([]registry.SearchResult, error) {
+	return nil, errReplay
+}
+
+//SaveImage fails, the images of a scene have no content to save
+func (d *SceneDaemon) SaveImage(ctx context.Context, id, path string, progress func(float64)) (int64, error) {
+	return 0, errReplay
+}
+
+//LoadImage fails, images cannot be loaded into a scene
+func (d *SceneDaemon) LoadImage(ctx context.Context, path string, progress func(float64)) ([]string, error) {
+	return nil, errReplay
+}
+
+//TagImage fails, the images of a scene cannot be changed
+func (d *SceneDaemon) TagImage(id, ref string) error {
+	return errReplay
+}
+
+//UntagImage fails, the images of a scene cannot be changed
+func (d *SceneDaemon) UntagImage(id, tag string) error {
+	return errReplay
+}
+
+//DockerEnv returns an environment whose host is the file of the scene
+func (d *SceneDaemon) DockerEnv() *drydocker.Env {
+	return &drydocker.Env{DockerHost: "scene://" + d.path}
+}
+
+//Events returns a channel of events on which nothing is ever sent
+func (d *SceneDaemon) Events() (<-chan events.Message, chan<- struct{}, error) {
+	return make(chan events.Message), make(chan struct{}), nil
+}
+
+//Attach fails, there is nothing to attach to in the containers of a scene
+func (d *SceneDaemon) Attach(id string) (*drydocker.ContainerAttachment, error) {
+	return nil, errReplay
+}
+
+//VolumeInspect fails, the scene has no volumes
+func (d *SceneDaemon) VolumeInspect(name string) (types.Volume, error) {
+	return types.Volume{}, errReplay
+}
+
+//EventLog returns an empty event log
+func (d *SceneDaemon) EventLog() *drydocker.EventLog {
+	return d.eventLog
+}
+
+//Exec fails, commands cannot run in the containers of a scene
+func (d *SceneDaemon) Exec(id string, options drydocker.ExecOptions) (io.ReadCloser, error) {
+	return nil, errReplay
+}
+
+//ImageByID returns the image of the scene with the given id
+func (d *SceneDaemon) ImageByID(id string) (types.ImageSummary, error) {
+	for _, image := range d.scene.Images {
+		if image.ID == id {
+			return image, nil
+		}
+	}
+	return types.ImageSummary{}, errors.New("image not found in the scene")
+}
+
+//Images returns the images of the scene
+func (d *SceneDaemon) Images() ([]types.ImageSummary, error) {
+	return d.scene.Images, nil
+}
+
+//Info returns the counts of the scene, the rest is not known
+func (d *SceneDaemon) Info() (types.Info, error) {
+	info := types.Info{
+		Name:       "scene",
+		Containers: len(d.containers),
+		Images:     len(d.scene.Images),
+		Swarm:      swarm.Info{LocalNodeState: swarm.LocalNodeStateInactive},
+	}
+	for _, c := range d.containers {
+		if drydocker.IsContainerRunning(c) {
+			info.ContainersRunning++
+		} else {
+			info.ContainersStopped++
+		}
+	}
+	return info, nil
+}
+
+//Inspect returns the details of the container of the scene with the given id
+func (d *SceneDaemon) Inspect(id string) (types.ContainerJSON, error) {
+	if c := d.ContainerByID(id); c != nil && c.ContainerJSONBase != nil {
+		return c.ContainerJSON, nil
+	}
+	return types.ContainerJSON{}, errReplay
+}
+
+//IsContainerRunning returns true if the container of the scene with the given id was running
+func (d *SceneDaemon) IsContainerRunning(id string) bool {
+	c := d.ContainerByID(id)
+	return c != nil && drydocker.IsContainerRunning(c)
+}
+
+//JournalLogs fails, logs are not part of a scene
+func (d *SceneDaemon) JournalLogs(id, since string) (io.ReadCloser, error) {
+	return nil, errReplay
+}
+
+//Logs fails, logs are not part of a scene
+func (d *SceneDaemon) Logs(id, since string, ts bool) (io.ReadCloser, error) {
+	return nil, errReplay
+}
+
+//NetworkInspect returns the network of the scene with the given id
+func (d *SceneDaemon) NetworkInspect(id string) (types.NetworkResource, error) {
+	for _, network := range d.scene.Networks {
+		if network.ID == id {
+			return network, nil
+		}
+	}
+	return types.NetworkResource{}, errors.New("network not found in the scene")
+}
+
+//Networks returns the networks of the scene
+func (d *SceneDaemon) Networks() ([]types.NetworkResource, error) {
+	return d.scene.Networks, nil
+}
+
+//Ok returns true, a scene is always there
+func (d *SceneDaemon) Ok() (bool, error) {
+	return true, nil
+}
+
+//OpenChannel returns a channel on which no stats are sent
+func (d *SceneDaemon) OpenChannel(container *drydocker.Container) *drydocker.StatsChannel {
+	return &drydocker.StatsChannel{Container: container}
+}
+
+//ServiceLogs fails, logs are not part of a scene
+func (d *SceneDaemon) ServiceLogs(id, since string, ts bool) (io.ReadCloser, error) {
+	return nil, errReplay
+}
+
+//Version returns the version of dry that took the scene
+func (d *SceneDaemon) Version() (*types.Version, error) {
+	return &types.Version{
+		Version:    "scene taken with dry " + d.scene.DryVersion,
+		APIVersion: d.APIVersion(),
+	}, nil
+}
@@ -1,5 +1,7 @@
 package ui
 
+import "github.com/nsf/termbox-go"
+
 //Color representation
 type Color uint32
 
@@ -10,6 +12,14 @@ const (
 	Darkgrey Color = Color232
 )
 
+//Attributes that can be combined with colors, i.e. on themes for terminals
+//with a limited number of colors
+const (
+	AttrBold      = Color(termbox.AttrBold)
+	AttrUnderline = Color(termbox.AttrUnderline)
+	AttrReverse   = Color(termbox.AttrReverse)
+)
+
 // First 256 entries correspond to Colors from the color palette as defined by the standard
 //https://en.wikipedia.org/wiki/ANSI_escape_code#Colors. Order is important.
 //Below that, a few colors from the TrueColor palette are defined.
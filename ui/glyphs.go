@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"sync/atomic"
+
+	"github.com/nsf/termbox-go"
+)
+
+//Glyph is a character, or a few, drawn by dry that not every terminal can
+//show. Each glyph has an ASCII replacement used on compatibility mode.
+type Glyph int
+
+//Glyphs drawn by dry
+const (
+	//DownArrowGlyph marks the column that sorts a list
+	DownArrowGlyph Glyph = iota
+	UpArrowGlyph
+	LeftArrowGlyph
+	RightArrowGlyph
+	//EllipsisGlyph ends the text that was cut to fit
+	EllipsisGlyph
+	//DotsGlyph ends the messages about something that goes on, its ASCII
+	//replacement is wider
+	DotsGlyph
+	//PointerGlyph points to the selected choice of a prompt
+	PointerGlyph
+	//TreeBranchGlyph, TreeLastBranchGlyph and TreeTrunkGlyph draw trees
+	TreeBranchGlyph
+	TreeLastBranchGlyph
+	TreeTrunkGlyph
+	//SparkGapGlyph marks the gaps on sparklines
+	SparkGapGlyph
+	//BarGlyph and BarGapGlyph draw the done and the pending part of progress bars
+	BarGlyph
+	BarGapGlyph
+)
+
+//glyphs are the glyphs drawn by dry, as they are drawn by default and
+//on compatibility mode
+var glyphs = map[Glyph][2]string{
+	DownArrowGlyph:      {"↓", "v"},
+	UpArrowGlyph:        {"↑", "^"},
+	LeftArrowGlyph:      {"←", "<"},
+	RightArrowGlyph:     {"→", ">"},
+	EllipsisGlyph:       {"…", "~"},
+	DotsGlyph:           {"…", "..."},
+	PointerGlyph:        {"»", ">"},
+	TreeBranchGlyph:     {"├─ ", "|- "},
+	TreeLastBranchGlyph: {"└─ ", "`- "},
+	TreeTrunkGlyph:      {"│  ", "|  "},
+	SparkGapGlyph:       {"╳", "x"},
+	BarGlyph:            {"█", "#"},
+	BarGapGlyph:         {"░", "-"},
+}
+
+//sparkTicks are the characters of sparklines, from the lowest value to
+//the highest, by default and on compatibility mode
+var sparkTicks = [2][]rune{[]rune("▁▂▃▄▅▆▇█"), []rune("_.-:=+*#")}
+
+//compatibilityMode is 1 if only ASCII characters and text attributes are drawn
+var compatibilityMode int32
+
+//SetCompatibilityMode sets whether dry only draws ASCII characters and text
+//attributes, for terminals, consoles and screen readers that cannot show
+//anything else. Glyphs are drawn with their ASCII replacement, other
+//characters are replaced when drawn and colors are left out.
+func SetCompatibilityMode(on bool) {
+	if on {
+		atomic.StoreInt32(&compatibilityMode, 1)
+	} else {
+		atomic.StoreInt32(&compatibilityMode, 0)
+	}
+}
+
+//CompatibilityMode returns true if only ASCII characters and text attributes
+//are drawn
+func CompatibilityMode() bool {
+	return atomic.LoadInt32(&compatibilityMode) == 1
+}
+
+//String returns the glyph as it is drawn on the current mode
+func (g Glyph) String() string {
+	if CompatibilityMode() {
+		return glyphs[g][1]
+	}
+	return glyphs[g][0]
+}
+
+//Variants returns the glyph as it is drawn on every mode
+func (g Glyph) Variants() []string {
+	v := glyphs[g]
+	return []string{v[0], v[1]}
+}
+
+//SparkTicks returns the characters of sparklines on the current mode, from
+//the lowest value to the highest
+func SparkTicks() []rune {
+	if CompatibilityMode() {
+		return sparkTicks[1]
+	}
+	return sparkTicks[0]
+}
+
+//textAttributes are the attributes kept on compatibility mode, colors are
+//left out
+const textAttributes = termbox.AttrBold | termbox.AttrUnderline | termbox.AttrReverse
+
+//asciiReplacements are the replacements of the characters, other than
+//glyphs, that are drawn on compatibility mode
+var asciiReplacements = map[rune]rune{
+	'─': '-', '━': '-', '═': '-',
+	'│': '|', '┃': '|', '║': '|',
+	'┌': '+', '┐': '+', '└': '+', '┘': '+',
+	'├': '+', '┤': '+', '┬': '+', '┴': '+', '┼': '+',
+	'╭': '+', '╮': '+', '╰': '+', '╯': '+',
+	'«': '<', '♥': '*', 'ŏ': 'o',
+}
+
+//compatibleCell returns the given cell as it is drawn on the current mode
+func compatibleCell(ch rune, fg, bg termbox.Attribute) (rune, termbox.Attribute, termbox.Attribute) {
+	if !CompatibilityMode() {
+		return ch, fg, bg
+	}
+	return asciiRune(ch), fg & textAttributes, bg & textAttributes
+}
+
+//asciiRune returns the ASCII replacement of the given character
+func asciiRune(ch rune) rune {
+	if ch < 0x80 {
+		return ch
+	}
+	for _, g := range glyphs {
+		if r := []rune(g[0]); len(r) == 1 && r[0] == ch {
+			return []rune(g[1])[0]
+		}
+	}
+	for i, tick := range sparkTicks[0] {
+		if tick == ch {
+			return sparkTicks[1][i]
+		}
+	}
+	switch {
+	case ch == 0x2800: //blank braille pattern
+		return ' '
+	case ch > 0x2800 && ch <= 0x28FF: //braille patterns
+		return '*'
+	}
+	if r, ok := asciiReplacements[ch]; ok {
+		return r
+	}
+	return '?'
+}
+
+//setCell sets the cell at the given position of the termbox buffer, as it
+//is drawn on the current mode
+func setCell(x, y int, ch rune, fg, bg termbox.Attribute) {
+	ch, fg, bg = compatibleCell(ch, fg, bg)
+	termbox.SetCell(x, y, ch, fg, bg)
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/nsf/termbox-go"
+)
+
+func TestGlyphs(t *testing.T) {
+	defer SetCompatibilityMode(false)
+	for g, variants := range glyphs {
+		SetCompatibilityMode(false)
+		if g.String() != variants[0] {
+			t.Errorf("Glyph %d: got %q, want %q", g, g.String(), variants[0])
+		}
+		SetCompatibilityMode(true)
+		ascii := g.String()
+		for _, r := range ascii {
+			if r >= 0x80 {
+				t.Errorf("Glyph %d is not ASCII on compatibility mode: %q", g, ascii)
+			}
+		}
+		if g != DotsGlyph && len([]rune(ascii)) != len([]rune(variants[0])) {
+			t.Errorf("Glyph %d changes its width on compatibility mode: %q, %q", g, variants[0], ascii)
+		}
+	}
+	SetCompatibilityMode(true)
+	if ticks := SparkTicks(); len(ticks) != len(sparkTicks[0]) {
+		t.Errorf("Unexpected spark ticks on compatibility mode: %q", string(ticks))
+	}
+}
+
+func TestCompatibleCell(t *testing.T) {
+	defer SetCompatibilityMode(false)
+	tests := []struct {
+		in, want rune
+	}{
+		{'a', 'a'},
+		{'↓', 'v'},
+		{'…', '~'},
+		{'─', '-'},
+		{'│', '|'},
+		{'┌', '+'},
+		{'▃', '-'},
+		{'⣿', '*'},
+		{'⠀', ' '},
+		{'♥', '*'},
+		{'日', '?'},
+	}
+	SetCompatibilityMode(true)
+	for _, tt := range tests {
+		got, fg, bg := compatibleCell(tt.in, termbox.Attribute(Color190)|termbox.AttrBold, termbox.ColorRed|termbox.AttrReverse)
+		if got != tt.want {
+			t.Errorf("compatibleCell(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if fg != termbox.AttrBold || bg != termbox.AttrReverse {
+			t.Errorf("Colors were not left out: %v, %v", fg, bg)
+		}
+	}
+	SetCompatibilityMode(false)
+	if got, fg, _ := compatibleCell('↓', termbox.ColorRed, termbox.ColorDefault); got != '↓' || fg != termbox.ColorRed {
+		t.Errorf("Cell changed out of compatibility mode: %q, %v", got, fg)
+	}
+}
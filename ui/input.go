@@ -46,7 +46,7 @@ func (eb *InputBox) Draw(x, y, w, h int) {
 		}
 
 		if rx >= w {
-			termbox.SetCell(x+w-1, y, '→',
+			setCell(x+w-1, y, []rune(RightArrowGlyph.String())[0],
 				coldef, coldef)
 			break
 		}
@@ -60,12 +60,12 @@ func (eb *InputBox) Draw(x, y, w, h int) {
 				}
 
 				if rx >= 0 {
-					termbox.SetCell(x+rx, y, ' ', coldef, coldef)
+					setCell(x+rx, y, ' ', coldef, coldef)
 				}
 			}
 		} else {
 			if rx >= 0 {
-				termbox.SetCell(x+rx, y, r, coldef, coldef)
+				setCell(x+rx, y, r, coldef, coldef)
 			}
 			lx++
 		}
@@ -74,7 +74,7 @@ func (eb *InputBox) Draw(x, y, w, h int) {
 	}
 
 	if eb.lineVOffset != 0 {
-		termbox.SetCell(x, y, '←', coldef, coldef)
+		setCell(x, y, []rune(LeftArrowGlyph.String())[0], coldef, coldef)
 	}
 }
 
@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"bytes"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/moncho/dry/search"
 	"github.com/nsf/termbox-go"
@@ -17,6 +19,8 @@ const (
 // * The cursor is always shown at the bottom of the screen.
 // * Navigation is done using less keybindings.
 // * Basic search is supported.
+// * Lines can be shown formatted, if it is given a LineFormatter.
+// * Log timestamps can be shown in UTC, local time or as ages.
 type Less struct {
 	*View
 	searchResult *search.Result
@@ -25,9 +29,31 @@ type Less struct {
 	refresh      chan struct{}
 	screen       *Screen
 
+	formatter    LineFormatter
+	formatting   bool
+	formatMarkup *Markup
+	lineFilter   string
+	filterError  string
+	//raw has the whole lines written so far, partial the line being written
+	//and formatted how the lines not hidden by the formatter are shown
+	raw       []string
+	partial   []rune
+	formatted [][]rune
+	//timestamped is true once a line with a timestamp has been written
+	timestamped bool
+
 	sync.Mutex
 }
 
+//LineFormatter formats the lines shown by a Less view
+type LineFormatter interface {
+	//Format returns how the given line is shown, with markup and cut to the
+	//given width, and false if it is hidden
+	Format(line string, width int) (string, bool)
+	//SetFilter sets the filter of the lines shown
+	SetFilter(filter string) error
+}
+
 //NewLess creates a view that partially simulates less.
 func NewLess(screen *Screen, theme *ColorTheme) *Less {
 	width, height := termbox.Size()
@@ -41,6 +67,126 @@ func NewLess(screen *Screen, theme *ColorTheme) *Less {
 	return less
 }
 
+//SetFormatter sets the formatter of the lines of the view, lines are shown
+//formatted once the user asks for it. It must be set before anything is
+//written on the view.
+func (less *Less) SetFormatter(formatter LineFormatter) {
+	less.formatter = formatter
+	less.formatMarkup = NewMarkup(less.theme)
+}
+
+//Write appends the given bytes to the view buffer, keeping the whole lines
+//for the formatter if the view has one
+func (less *Less) Write(p []byte) (int, error) {
+	if less.formatter != nil {
+		less.Lock()
+		for _, ch := range bytes.Runes(p) {
+			switch ch {
+			case '\n':
+				less.addLine(string(less.partial))
+				less.partial = nil
+			case '\r':
+				less.partial = nil
+			default:
+				less.partial = append(less.partial, ch)
+			}
+		}
+		less.Unlock()
+	}
+	return less.View.Write(p)
+}
+
+//addLine keeps the given whole line and, if lines are being formatted,
+//formats it
+func (less *Less) addLine(line string) {
+	less.raw = append(less.raw, line)
+	if !less.timestamped {
+		_, less.timestamped = LogTimestamp(line)
+	}
+	if !less.formatting {
+		return
+	}
+	if text, ok := less.formatter.Format(line, less.width); ok {
+		less.formatted = append(less.formatted, []rune(text))
+	}
+}
+
+//hasTimestamps returns true if lines with a timestamp have been written
+func (less *Less) hasTimestamps() bool {
+	less.Lock()
+	defer less.Unlock()
+	return less.timestamped
+}
+
+//reformat formats again every line written so far
+func (less *Less) reformat() {
+	less.formatted = nil
+	for _, line := range less.raw {
+		if text, ok := less.formatter.Format(line, less.width); ok {
+			less.formatted = append(less.formatted, []rune(text))
+		}
+	}
+	//lines have moved, so have search hits
+	less.searchResult = nil
+}
+
+//content returns the lines shown, formatted or as they were written
+func (less *Less) content() [][]rune {
+	if less.formatting {
+		return less.formatted
+	}
+	return less.lines
+}
+
+//flipFormatting switches between showing the lines formatted and as they
+//were written
+func (less *Less) flipFormatting() {
+	if less.formatter == nil {
+		return
+	}
+	less.Lock()
+	less.formatting = !less.formatting
+	if less.formatting {
+		less.reformat()
+	} else {
+		less.formatted = nil
+		less.searchResult = nil
+	}
+	less.Unlock()
+	less.scrollAfterReformat()
+}
+
+//setLineFilter sets the filter of the formatted lines, lines are shown
+//formatted from then on
+func (less *Less) setLineFilter(filter string) {
+	if less.formatter == nil {
+		return
+	}
+	less.Lock()
+	if err := less.formatter.SetFilter(filter); err != nil {
+		less.filterError = err.Error()
+		less.Unlock()
+		less.refreshBuffer()
+		return
+	}
+	less.lineFilter = strings.TrimSpace(filter)
+	less.filterError = ""
+	less.formatting = true
+	less.reformat()
+	less.Unlock()
+	less.scrollAfterReformat()
+}
+
+//scrollAfterReformat moves to the bottom of the buffer if following it,
+//to its top if not
+func (less *Less) scrollAfterReformat() {
+	if less.following {
+		less.ScrollToBottom()
+	} else {
+		less.ScrollToTop()
+	}
+}
+
 //Focus sets the view as active, so it starts handling terminal events
 //and user actions
 func (less *Less) Focus(events <-chan termbox.Event) error {
@@ -56,11 +202,17 @@ func (less *Less) Focus(events <-chan termbox.Event) error {
 		}
 	}
 	inputMode := false
+	//lineFilterInput tells if the input being read is a line filter or
+	//a search pattern
+	lineFilterInput := false
 
 	//This ensures at least one refresh
 	less.refreshBuffer()
 
 	go func(inputMode *bool) {
+		//ages of log lines are kept up to date
+		ages := time.NewTicker(time.Second)
+		defer ages.Stop()
 
 		inputBoxEventChan := make(chan termbox.Event)
 		inputBoxOutput := make(chan string, 1)
@@ -72,8 +224,16 @@ func (less *Less) Focus(events <-chan termbox.Event) error {
 
 			case input := <-inputBoxOutput:
 				*inputMode = false
-				less.search(input)
-				less.refreshBuffer()
+				if lineFilterInput {
+					less.setLineFilter(input)
+				} else {
+					less.search(input)
+					less.refreshBuffer()
+				}
+			case <-ages.C:
+				if LogTimestampMode() == TimestampsAge && less.hasTimestamps() {
+					less.refreshBuffer()
+				}
 			case event := <-events:
 				switch event.Type {
 				case termbox.EventKey:
@@ -96,6 +256,7 @@ func (less *Less) Focus(events <-chan termbox.Event) error {
 							less.flipFollow()
 						} else if event.Ch == 'F' {
 							*inputMode = true
+							lineFilterInput = false
 							less.filtering = true
 							go less.readInput(inputBoxEventChan, inputBoxOutput)
 						} else if event.Ch == 'g' { //to the top of the view
@@ -108,8 +269,18 @@ func (less *Less) Focus(events <-chan termbox.Event) error {
 							less.gotoNextSearchHit()
 						} else if event.Ch == '/' {
 							*inputMode = true
+							lineFilterInput = false
 							less.filtering = false
 							go less.readInput(inputBoxEventChan, inputBoxOutput)
+						} else if event.Ch == 'j' { //toggle formatting
+							less.flipFormatting()
+						} else if event.Ch == '=' && less.formatter != nil { //filter formatted lines
+							*inputMode = true
+							lineFilterInput = true
+							go less.readInput(inputBoxEventChan, inputBoxOutput)
+						} else if event.Ch == 't' && less.hasTimestamps() { //how timestamps are shown
+							nextLogTimestampMode()
+							less.refreshBuffer()
 						}
 					} else {
 						inputBoxEventChan <- event
@@ -135,7 +306,7 @@ func (less *Less) Focus(events <-chan termbox.Event) error {
 //Search searches in the view buffer for the given pattern
 func (less *Less) search(pattern string) error {
 	if pattern != "" {
-		searchResult, err := search.NewSearch(less.lines, pattern)
+		searchResult, err := search.NewSearch(less.content(), pattern)
 		if err == nil {
 			less.searchResult = searchResult
 			if searchResult.Hits > 0 {
@@ -170,12 +341,18 @@ func (less *Less) render() {
 	if less.bufferY < less.bufferSize() && less.bufferY > 0 {
 		bufferStart = less.bufferY
 	}
-	for _, line := range less.lines[bufferStart:] {
+	mode := LogTimestampMode()
+	now := time.Now()
+	for _, line := range less.content()[bufferStart:] {
 
 		if y > maxY {
 			break
 		}
-		less.renderLine(0, y, string(line))
+		text := string(line)
+		if less.timestamped {
+			text = showLogTimestamp(text, mode, now)
+		}
+		less.renderLine(0, y, text)
 		y++
 	}
 
@@ -246,7 +423,7 @@ func (less *Less) atTheEndOfBuffer() bool {
 }
 
 func (less *Less) bufferSize() int {
-	return len(less.lines)
+	return len(less.content())
 }
 
 func (less *Less) gotoPreviousSearchHit() {
@@ -289,15 +466,23 @@ func (less *Less) renderableArea() (int, int) {
 func (less *Less) renderLine(x int, y int, line string) (int, error) {
 	var lines = 1
 	maxWidth, _ := less.renderableArea()
+	markup := less.markup
+	if less.formatting {
+		markup = less.formatMarkup
+	}
+	if less.formatting && (less.searchResult == nil ||
+		!less.filtering && !strings.Contains(line, less.searchResult.Pattern)) {
+		return renderLineWithMarkup(x, y, maxWidth, line, markup), nil
+	}
 	if less.searchResult != nil {
 		//If markup support is active then it might happen that tags are present in the line
 		//but since we are searching, markups are ignored and coloring output is
 		//decided here.
 		if strings.Contains(line, less.searchResult.Pattern) {
-			if less.markup != nil {
+			if markup != nil {
 				start, column := 0, 0
 				for _, token := range Tokenize(line, SupportedTags) {
-					if less.markup.IsTag(token) {
+					if markup.IsTag(token) {
 
 						continue
 					}
@@ -305,7 +490,7 @@ func (less *Less) renderLine(x int, y int, line string) (int, error) {
 					for _, char := range token {
 						start = x + column
 						column++
-						termbox.SetCell(start, y, char, termbox.ColorYellow, termbox.Attribute(less.View.theme.Bg))
+						setCell(start, y, char, termbox.ColorYellow, termbox.Attribute(less.View.theme.Bg))
 					}
 				}
 			} else {
@@ -393,9 +578,30 @@ func (less *Less) statusLine() string {
 		end = end + " Follow: Off"
 	}
 
+	if less.formatter != nil {
+		switch {
+		case less.filterError != "":
+			end = end + " Format: " + less.filterError
+		case less.formatting && less.lineFilter != "":
+			end = end + " Format: " + less.lineFilter
+		case less.formatting:
+			end = end + " Format: On"
+		default:
+			end = end + " Format: Off"
+		}
+	}
+
+	if less.timestamped {
+		end = end + " Time: " + LogTimestampMode().String()
+	}
+
+	padding := maxWidth - len(start) - len(end)
+	if padding < 0 {
+		padding = 0
+	}
 	return strings.Join(
 		[]string{start, end},
-		strings.Repeat(" ", maxWidth-len(start)-len(end)))
+		strings.Repeat(" ", padding))
 }
 
 func (less *Less) drawCursor() {
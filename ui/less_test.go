@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -100,6 +103,71 @@ func TestLessSearch(t *testing.T) {
 	}
 }
 
+//upperFormatter shows lines in upper case, hiding those without the filter
+type upperFormatter struct {
+	filter string
+}
+
+func (f *upperFormatter) Format(line string, width int) (string, bool) {
+	if !strings.Contains(line, f.filter) {
+		return "", false
+	}
+	return strings.ToUpper(line), true
+}
+
+func (f *upperFormatter) SetFilter(filter string) error {
+	if filter == "invalid" {
+		return errors.New("invalid filter")
+	}
+	f.filter = filter
+	return nil
+}
+
+func TestLessFormatting(t *testing.T) {
+	less := newLess(20, 10)
+	less.formatter = &upperFormatter{}
+
+	fmt.Fprint(less, "line 0\nline 1\n")
+	if got := len(less.content()); got != 3 {
+		t.Errorf("Expected 3 lines before formatting, got: %d", got)
+	}
+
+	less.flipFormatting()
+	fmt.Fprint(less, "line 2\nline 3")
+	want := []string{"LINE 0", "LINE 1", "LINE 2"}
+	if got := contentLines(less); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected formatted lines %v, got: %v", want, got)
+	}
+
+	fmt.Fprint(less, "\n")
+	less.setLineFilter("3")
+	want = []string{"LINE 3"}
+	if got := contentLines(less); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected filtered lines %v, got: %v", want, got)
+	}
+	if less.lineFilter != "3" {
+		t.Errorf("Expected line filter 3, got: %s", less.lineFilter)
+	}
+
+	less.setLineFilter("invalid")
+	if less.filterError == "" || less.lineFilter != "3" {
+		t.Errorf("An invalid filter must be reported and the filter in use kept, got: %q %q", less.filterError, less.lineFilter)
+	}
+
+	less.flipFormatting()
+	if got := len(less.content()); got != 5 {
+		t.Errorf("Expected 5 lines once formatting is off, got: %d", got)
+	}
+}
+
+func contentLines(less *Less) []string {
+	var lines []string
+	for _, line := range less.content() {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
 func testLessCursor(t *testing.T, less *Less, expectedX int, expectedY int) {
 	x, y := less.Cursor()
 	if x != expectedX || y != expectedY {
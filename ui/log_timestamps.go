@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"regexp"
+	"sync"
+	"time"
+)
+
+//TimestampMode is how the timestamps Docker adds to log lines are shown
+type TimestampMode int
+
+const (
+	//TimestampsUTC shows timestamps as Docker sends them, in UTC
+	TimestampsUTC TimestampMode = iota
+	//TimestampsLocal shows timestamps in the local time zone
+	TimestampsLocal
+	//TimestampsAge shows how long ago each line was logged
+	TimestampsAge
+)
+
+//localTimestampLayout is the layout of timestamps shown in local time, as
+//precise as the ones Docker sends
+const localTimestampLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+//logTimestampPattern finds the timestamp Docker puts at the beginning of a log
+//line. Whatever markup, spaces or line markers the formatter puts before it are
+//skipped. RFC3339Nano drops the trailing zeros of the fraction, so it can have
+//any number of digits or be missing.
+var logTimestampPattern = regexp.MustCompile(
+	`^((?:<[^>]*>|~|\s)*)(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2}))(\s|<|$)`)
+
+var timestampMode = TimestampsUTC
+var timestampModeLock sync.RWMutex
+
+//String returns the name of the mode as shown on the status line
+func (m TimestampMode) String() string {
+	switch m {
+	case TimestampsLocal:
+		return "Local"
+	case TimestampsAge:
+		return "Age"
+	default:
+		return "UTC"
+	}
+}
+
+//LogTimestampMode returns how log timestamps are shown, the mode chosen last
+//is kept for the whole session
+func LogTimestampMode() TimestampMode {
+	timestampModeLock.RLock()
+	defer timestampModeLock.RUnlock()
+	return timestampMode
+}
+
+//nextLogTimestampMode moves on to the next way of showing log timestamps and
+//returns it
+func nextLogTimestampMode() TimestampMode {
+	timestampModeLock.Lock()
+	defer timestampModeLock.Unlock()
+	timestampMode = (timestampMode + 1) % (TimestampsAge + 1)
+	return timestampMode
+}
+
+//LogTimestamp returns the timestamp Docker put at the beginning of the given
+//log line, false if it has none. Lines that continue one longer than 16KB,
+//which the daemon splits, might have no timestamp of their own.
+//Timestamps are only shown differently, lines keep the UTC ones, so
+//they are still what log lines are ordered by.
+func LogTimestamp(line string) (time.Time, bool) {
+	m := logTimestampPattern.FindStringSubmatch(line)
+	if m == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, m[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+//showLogTimestamp returns the given log line with its timestamp shown as the
+//given mode says, relative to now for ages. Lines without a timestamp are
+//returned as they are.
+func showLogTimestamp(line string, mode TimestampMode, now time.Time) string {
+	if mode == TimestampsUTC {
+		return line
+	}
+	loc := logTimestampPattern.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return line
+	}
+	start, end := loc[4], loc[5]
+	t, err := time.Parse(time.RFC3339Nano, line[start:end])
+	if err != nil {
+		return line
+	}
+	var shown string
+	if mode == TimestampsLocal {
+		shown = t.Local().Format(localTimestampLayout)
+	} else {
+		shown = timestampAge(t, now)
+	}
+	return line[:start] + shown + line[end:]
+}
+
+//timestampAge returns how long before now the given time was, to the second
+func timestampAge(t, now time.Time) string {
+	age := now.Sub(t).Round(time.Second)
+	if age < 0 {
+		//clocks of the daemon and this host might not agree
+		age = 0
+	}
+	return age.String() + " ago"
+}
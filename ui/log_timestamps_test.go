@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogTimestamp(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{"2018-05-02T10:11:12.123456789Z hello", "2018-05-02T10:11:12.123456789Z", true},
+		//RFC3339Nano drops the trailing zeros of the fraction
+		{"2018-05-02T10:11:12.1Z hello", "2018-05-02T10:11:12.1Z", true},
+		{"2018-05-02T10:11:12Z hello", "2018-05-02T10:11:12Z", true},
+		{"2018-05-02T10:11:12Z", "2018-05-02T10:11:12Z", true},
+		//as the JSON log formatter shows lines
+		{"  2018-05-02T10:11:12.5Z INFO started", "2018-05-02T10:11:12.5Z", true},
+		{"<darkgrey>~</> 2018-05-02T10:11:12.5Z plain", "2018-05-02T10:11:12.5Z", true},
+		//the rest of a line split by the daemon
+		{"continued from a long line", "", false},
+		{"text 2018-05-02T10:11:12Z", "", false},
+		{"2018-05-02T10:11:12Ztext", "", false},
+		{"2018-13-02T10:11:12Z month 13", "", false},
+	}
+	for _, test := range tests {
+		got, ok := LogTimestamp(test.line)
+		if ok != test.ok {
+			t.Errorf("LogTimestamp(%q) found a timestamp: %v, want %v", test.line, ok, test.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		want, _ := time.Parse(time.RFC3339Nano, test.want)
+		if !got.Equal(want) {
+			t.Errorf("LogTimestamp(%q) = %v, want %v", test.line, got, want)
+		}
+	}
+}
+
+func TestShowLogTimestamp(t *testing.T) {
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = time.FixedZone("CEST", 2*60*60)
+
+	line := "2018-05-02T10:11:12.5Z hello"
+	now := time.Date(2018, 5, 2, 10, 13, 25, 0, time.UTC)
+
+	if got := showLogTimestamp(line, TimestampsUTC, now); got != line {
+		t.Errorf("UTC timestamps are shown as %q, want %q", got, line)
+	}
+	if got, want := showLogTimestamp(line, TimestampsLocal, now), "2018-05-02T12:11:12.500000000+02:00 hello"; got != want {
+		t.Errorf("Local timestamps are shown as %q, want %q", got, want)
+	}
+	if got, want := showLogTimestamp(line, TimestampsAge, now), "2m13s ago hello"; got != want {
+		t.Errorf("Ages are shown as %q, want %q", got, want)
+	}
+	if got, want := showLogTimestamp("  "+line, TimestampsAge, now), "  2m13s ago hello"; got != want {
+		t.Errorf("Ages of formatted lines are shown as %q, want %q", got, want)
+	}
+	//daemon ahead of this host
+	if got := showLogTimestamp(line, TimestampsAge, now.Add(-time.Hour)); !strings.HasPrefix(got, "0s ago") {
+		t.Errorf("Lines from the future are shown as %q, want 0s ago", got)
+	}
+	if got, want := showLogTimestamp("no timestamp", TimestampsLocal, now), "no timestamp"; got != want {
+		t.Errorf("Lines without timestamp are shown as %q, want %q", got, want)
+	}
+}
+
+func TestNextLogTimestampMode(t *testing.T) {
+	defer func(mode TimestampMode) { timestampMode = mode }(timestampMode)
+	timestampMode = TimestampsUTC
+
+	for _, want := range []TimestampMode{TimestampsLocal, TimestampsAge, TimestampsUTC} {
+		if got := nextLogTimestampMode(); got != want {
+			t.Errorf("Next timestamp mode is %s, want %s", got, want)
+		}
+		if got := LogTimestampMode(); got != want {
+			t.Errorf("Timestamp mode is %s, want %s", got, want)
+		}
+	}
+}
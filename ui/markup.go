@@ -28,9 +28,9 @@ func tags() map[string]termbox.Attribute {
 	//the same for all color tags (the magic number is 5, because white) to avoid,
 	//text alignment problems, hence the strange tag names.
 
-	//TODO Figure out a way to use markups and the ColorTheme. Right now a tag
-	//value corresponds to an specific color, and there are cases might now even
-	//fit the description (so green is not really green but something that fits the DarkTheme).
+	//These are the default tag colors, a ColorTheme can override them. The tag name
+	//does not always fit the description (so green is not really green but something
+	//that fits the DarkTheme).
 	tags := make(map[string]termbox.Attribute)
 	tags[`/`] = termbox.Attribute(ColorWhite)
 	tags[`black`] = termbox.ColorBlack
@@ -93,7 +93,7 @@ func (markup *Markup) IsTag(str string) bool {
 }
 
 func (markup *Markup) process(tag string, open bool) bool {
-	if attribute, ok := tagsToAttributeMap[tag]; ok {
+	if attribute, ok := markup.tagAttribute(tag); ok {
 		if open {
 			markup.Foreground = attribute // Set the Termbox color.
 		} else {
@@ -104,6 +104,16 @@ func (markup *Markup) process(tag string, open bool) bool {
 	return true
 }
 
+//tagAttribute returns the attribute of the given tag, the color theme
+//of the markup takes precedence over the default tag colors
+func (markup *Markup) tagAttribute(tag string) (termbox.Attribute, bool) {
+	if color, ok := markup.theme.Tags[tag]; ok {
+		return termbox.Attribute(color), true
+	}
+	attribute, ok := tagsToAttributeMap[tag]
+	return attribute, ok
+}
+
 func probeForTag(str string) (string, bool) {
 	if len(str) > 2 && str[0:1] == `<` && str[len(str)-1:] == `>` {
 		return extractTagName(str), str[1:2] != "/"
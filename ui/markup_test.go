@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/nsf/termbox-go"
 )
 
 func TestTokenize(t *testing.T) {
@@ -51,3 +53,26 @@ func TestTokenizeNil(t *testing.T) {
 			len(result))
 	}
 }
+
+func TestMarkupThemeTags(t *testing.T) {
+	theme := &ColorTheme{Fg: Color255, Tags: map[string]Color{"blue": Color25}}
+	tests := []struct {
+		tag  string
+		want termbox.Attribute
+	}{
+		{"<blue>", termbox.Attribute(Color25)},
+		{"<yellow>", termbox.ColorYellow},
+		{"</>", termbox.Attribute(Color255)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			markup := NewMarkup(theme)
+			if !markup.IsTag(tt.tag) {
+				t.Fatalf("%s is not a tag", tt.tag)
+			}
+			if markup.Foreground != tt.want {
+				t.Errorf("Unexpected foreground for %s, got %d, want %d", tt.tag, markup.Foreground, tt.want)
+			}
+		})
+	}
+}
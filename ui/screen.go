@@ -20,6 +20,7 @@ type Screen struct {
 	theme      *ColorTheme
 	Dimensions *Dimensions
 	closing    bool
+	suspended  bool
 }
 
 //NewScreen initializes Termbox, creates screen along with layout and markup, and
@@ -53,8 +54,50 @@ func (screen *Screen) Closing() bool {
 	return screen.closing
 }
 
+//Suspend closes Termbox, giving the terminal back as it was before the screen
+//was created, until Resume is called. Nothing is drawn while suspended.
+func (screen *Screen) Suspend() *Screen {
+	screen.Lock()
+	defer screen.Unlock()
+	if !screen.suspended {
+		screen.suspended = true
+		termbox.Close()
+	}
+	return screen
+}
+
+//Resume initializes Termbox again after the screen was suspended, what was
+//shown before suspending has to be rendered again.
+func (screen *Screen) Resume() error {
+	screen.Lock()
+	defer screen.Unlock()
+	if !screen.suspended {
+		return nil
+	}
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+	screen.suspended = false
+	if CompatibilityMode() {
+		termbox.SetOutputMode(termbox.OutputNormal)
+	} else {
+		termbox.SetOutputMode(termbox.Output256)
+	}
+	return nil
+}
+
+//Suspended returns true if the screen is suspended
+func (screen *Screen) Suspended() bool {
+	screen.RLock()
+	defer screen.RUnlock()
+	return screen.suspended
+}
+
 // Resize recalculates active screen dimensions.
 func Resize() {
+	if ActiveScreen.Suspended() {
+		return
+	}
 	termbox.Sync()
 	w, h := termbox.Size()
 	if w > 0 && h > 0 {
@@ -81,7 +124,9 @@ func (screen *Screen) ClearAndFlush() *Screen {
 func (screen *Screen) Sync() *Screen {
 	screen.Lock()
 	defer screen.Unlock()
-	termbox.Sync()
+	if !screen.suspended {
+		termbox.Sync()
+	}
 	return screen
 }
 
@@ -90,6 +135,37 @@ func (screen *Screen) ColorTheme(theme *ColorTheme) *Screen {
 	screen.Lock()
 	defer screen.Unlock()
 	screen.markup = NewMarkup(theme)
+	screen.theme = theme
+	return screen
+}
+
+//CompatibilityMode sets whether the screen only draws ASCII characters and
+//text attributes, see SetCompatibilityMode.
+func (screen *Screen) CompatibilityMode(on bool) *Screen {
+	screen.Lock()
+	defer screen.Unlock()
+	SetCompatibilityMode(on)
+	if on {
+		termbox.SetOutputMode(termbox.OutputNormal)
+	} else {
+		termbox.SetOutputMode(termbox.Output256)
+	}
+	return screen
+}
+
+//Dim dims what has been rendered so far, so whatever is rendered next
+//stands out, i.e. an overlay.
+func (screen *Screen) Dim() *Screen {
+	screen.Lock()
+	defer screen.Unlock()
+	fg := termbox.Attribute(Grey2)
+	if CompatibilityMode() {
+		fg = termbox.ColorDefault
+	}
+	cells := termbox.CellBuffer()
+	for i := range cells {
+		cells[i].Fg = fg
+	}
 	return screen
 }
 
@@ -97,7 +173,9 @@ func (screen *Screen) ColorTheme(theme *ColorTheme) *Screen {
 func (screen *Screen) Flush() *Screen {
 	screen.Lock()
 	defer screen.Unlock()
-	termbox.Flush()
+	if !screen.suspended {
+		termbox.Flush()
+	}
 	return screen
 }
 
@@ -112,7 +190,7 @@ func (screen *Screen) RenderBufferer(bs ...termui.Bufferer) {
 		// set cels in buf
 		for p, c := range buf.CellMap {
 			if p.In(buf.Area) {
-				termbox.SetCell(p.X, p.Y, c.Ch, toTmAttr(c.Fg), toTmAttr(c.Bg))
+				setCell(p.X, p.Y, c.Ch, toTmAttr(c.Fg), toTmAttr(c.Bg))
 			}
 		}
 	}
@@ -132,7 +210,7 @@ func (screen *Screen) RenderLine(x int, y int, str string) {
 
 		// Here comes the actual text: displays it one character at a time.
 		for _, char := range token {
-			termbox.SetCell(column, y, char, screen.markup.Foreground, screen.markup.Background)
+			setCell(column, y, char, screen.markup.Foreground, screen.markup.Background)
 			column++
 		}
 	}
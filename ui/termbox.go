@@ -13,7 +13,7 @@ import (
 //fill fills the screen with the given cell starting at x,y until w,h.
 func fill(x, y, w, h int, cell termbox.Cell) {
 	for lx := 0; lx < w; lx++ {
-		termbox.SetCell(x+lx, y, cell.Ch, cell.Fg, cell.Bg)
+		setCell(x+lx, y, cell.Ch, cell.Fg, cell.Bg)
 	}
 }
 
@@ -39,7 +39,7 @@ func renderString(x, y, maxWidth int, s string, foreground, background termbox.A
 			//new line, start column goes back to the beginning
 			startCol = x
 		}
-		termbox.SetCell(startCol, y, char, foreground, background)
+		setCell(startCol, y, char, foreground, background)
 		startCol += runewidth
 
 	}
@@ -75,7 +75,7 @@ func renderLineWithMarkup(x, y, maxWidth int, str string, markup *Markup) int {
 				y += additionalLines
 			}
 
-			termbox.SetCell(column, y, char, markup.Foreground, markup.Background)
+			setCell(column, y, char, markup.Foreground, markup.Background)
 			column++
 		}
 	}
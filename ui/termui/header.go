@@ -15,6 +15,7 @@ type TableHeader struct {
 	varWidthColumns   []*termui.Par
 	Theme             *ui.ColorTheme
 	columnWidths      []int
+	highlighted       *termui.Par
 }
 
 //NewHeader creates a header of height 1 that uses the given Theme
@@ -68,11 +69,26 @@ func (th *TableHeader) SetY(y int) {
 func (th *TableHeader) Buffer() termui.Buffer {
 	buf := termui.NewBuffer()
 	for _, p := range th.Columns {
+		th.setColors(p)
+		if p == th.highlighted {
+			p.Bg = termui.Attribute(th.Theme.CursorLineBg)
+			p.TextBgColor = termui.Attribute(th.Theme.CursorLineBg)
+			p.TextFgColor = termui.Attribute(th.Theme.CursorLineFg)
+		}
 		buf.Merge(p.Buffer())
 	}
 	return buf
 }
 
+//HighlightColumn highlights the column on the given position, none if the
+//position is out of range
+func (th *TableHeader) HighlightColumn(i int) {
+	th.highlighted = nil
+	if i >= 0 && i < len(th.Columns) {
+		th.highlighted = th.Columns[i]
+	}
+}
+
 //AddColumn adds a column to this header
 func (th *TableHeader) AddColumn(s string) {
 	p := newHeaderColumn(s, th)
@@ -97,7 +113,13 @@ func (th *TableHeader) calcColumnWidth() int {
 		fixedWidthColumnsSpacing += column.Width
 	}
 	colCount := len(th.varWidthColumns)
+	if colCount == 0 {
+		return 0
+	}
 	spacing := th.ColumnSpacing*colCount + fixedWidthColumnsSpacing
+	if spacing > th.Width {
+		return 0
+	}
 	return (th.Width - spacing) / colCount
 }
 
@@ -115,9 +137,14 @@ func newHeaderColumn(columnTitle string, th *TableHeader) *termui.Par {
 	p := termui.NewPar(columnTitle)
 	p.Height = th.Height
 	p.Border = false
-	p.Bg = termui.Attribute(th.Theme.Bg)
-	p.TextBgColor = termui.Attribute(th.Theme.Bg)
-	p.TextFgColor = termui.ColorWhite
+	th.setColors(p)
 	p.Width = -1
 	return p
 }
+
+//setColors sets the colors of the given column from the header theme
+func (th *TableHeader) setColors(p *termui.Par) {
+	p.Bg = termui.Attribute(th.Theme.Bg)
+	p.TextBgColor = termui.Attribute(th.Theme.Bg)
+	p.TextFgColor = termui.Attribute(th.Theme.TableHeader)
+}
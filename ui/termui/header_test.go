@@ -121,3 +121,37 @@ func TestHeaderMultipleWidthSetting(t *testing.T) {
 	}
 
 }
+
+func TestHeaderWiderThanScreen(t *testing.T) {
+	header := NewHeader(&ui.ColorTheme{})
+	header.ColumnSpacing = 1
+	header.AddColumn("column1")
+	header.AddFixedWidthColumn("column2", 50)
+	header.SetWidth(40)
+
+	if widths := header.ColumnWidths(); widths[0] != 0 || widths[1] != 50 {
+		t.Errorf("Columns that do not fit are not left without width: %v", widths)
+	}
+}
+
+func TestHeaderHighlightColumn(t *testing.T) {
+	header := NewHeader(&ui.ColorTheme{CursorLineFg: 1, CursorLineBg: 2})
+	header.AddColumn("column1")
+	header.AddColumn("column2")
+	header.SetWidth(40)
+
+	header.HighlightColumn(1)
+	header.Buffer()
+	if c := header.Columns[1]; c.TextBgColor != 2 || c.TextFgColor != 1 {
+		t.Errorf("Highlighted column is not shown as highlighted, colors: %v, %v", c.TextFgColor, c.TextBgColor)
+	}
+	if c := header.Columns[0]; c.TextBgColor == 2 {
+		t.Error("Column not highlighted is shown as highlighted")
+	}
+
+	header.HighlightColumn(-1)
+	header.Buffer()
+	if c := header.Columns[1]; c.TextBgColor == 2 {
+		t.Error("Column is still highlighted")
+	}
+}
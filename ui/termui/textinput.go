@@ -2,6 +2,7 @@ package termui
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/gizak/termui"
 	"github.com/moncho/dry/ui"
@@ -20,6 +21,7 @@ type TextInput struct {
 	TextFgColor   termui.Attribute
 	TextBgColor   termui.Attribute
 	TextBuilder   termui.TextBuilder
+	Mask          rune //if set, drawn instead of each character of the input
 }
 
 //NewTextInput creates a new TextInput with the given initial text
@@ -192,6 +194,9 @@ func (i *TextInput) Buffer() termui.Buffer {
 	buffer := i.Block.Buffer()
 	innerArea := i.InnerBounds()
 	text := string(i.input)
+	if i.Mask != 0 {
+		text = strings.Repeat(string(i.Mask), len(i.input))
+	}
 
 	fg, bg := i.TextFgColor, i.TextBgColor
 	cells := i.TextBuilder.Build(text, fg, bg)
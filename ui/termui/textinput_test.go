@@ -297,6 +297,22 @@ func Test_TextInput_Buffer(t *testing.T) {
 	}
 }
 
+func Test_TextInput_MaskedBuffer(t *testing.T) {
+	input := NewTextInput("pass")
+	input.Mask = '*'
+	input.Width = 6
+	input.Height = 3
+	cells := input.Buffer().CellMap
+	for x := 1; x <= 4; x++ {
+		if c := cells[image.Point{X: x, Y: 1}]; c.Ch != '*' {
+			t.Errorf("Unexpected character drawn at %d: %q", x, c.Ch)
+		}
+	}
+	if text, _ := input.Text(); text != "pass" {
+		t.Errorf("Unexpected text: %q", text)
+	}
+}
+
 func Test_TextInput_RemoveCharsFromInput(t *testing.T) {
 
 	type arg struct {
@@ -2,19 +2,45 @@ package ui
 
 //ColorTheme represents a color theme
 type ColorTheme struct {
-	Fg           Color
-	Bg           Color
-	DarkBg       Color
-	Prompt       Color
-	Key          Color
-	Current      Color
-	CurrentMatch Color
-	Spinner      Color
-	Info         Color
-	Cursor       Color
-	Selected     Color
-	Header       Color
-	Footer       Color
-	ListItem     Color
-	CursorLineBg Color
+	Name         string `json:"name"`
+	Fg           Color  `json:"fg"`
+	Bg           Color  `json:"bg"`
+	DarkBg       Color  `json:"dark_bg"`
+	Prompt       Color  `json:"prompt"`
+	Key          Color  `json:"key"`
+	Current      Color  `json:"current"`
+	CurrentMatch Color  `json:"current_match"`
+	Spinner      Color  `json:"spinner"`
+	Info         Color  `json:"info"`
+	Cursor       Color  `json:"cursor"`
+	Selected     Color  `json:"selected"`
+	Header       Color  `json:"header"`
+	Footer       Color  `json:"footer"`
+	ListItem     Color  `json:"list_item"`
+	CursorLineFg Color  `json:"cursor_line_fg"`
+	CursorLineBg Color  `json:"cursor_line_bg"`
+	TableHeader  Color  `json:"table_header"`
+	Running      Color  `json:"running"`
+	NotRunning   Color  `json:"not_running"`
+	Paused       Color  `json:"paused"`
+	Alerted      Color  `json:"alerted"`
+	Inactive     Color  `json:"inactive"`
+	UsageLow     Color  `json:"usage_low"`
+	UsageMedium  Color  `json:"usage_medium"`
+	UsageHigh    Color  `json:"usage_high"`
+	//Tags overrides the colors of markup tags, i.e. "blue", tags
+	//not found use their default color
+	Tags map[string]Color `json:"tags"`
+}
+
+//Copy returns a copy of this theme
+func (theme *ColorTheme) Copy() *ColorTheme {
+	c := *theme
+	if theme.Tags != nil {
+		c.Tags = make(map[string]Color, len(theme.Tags))
+		for tag, color := range theme.Tags {
+			c.Tags[tag] = color
+		}
+	}
+	return &c
 }
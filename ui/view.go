@@ -162,7 +162,7 @@ func (v *View) clearRunes() {
 	maxX, maxY := v.ViewSize()
 	for x := 0; x < maxX; x++ {
 		for y := 0; y < maxY; y++ {
-			termbox.SetCell(v.x0+x+1, v.y0+y+1, ' ',
+			setCell(v.x0+x+1, v.y0+y+1, ' ',
 				termbox.Attribute(v.theme.Fg), termbox.Attribute(v.theme.Bg))
 		}
 	}